@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type apiKeyInfo struct {
+	ApiKeyID  string   `json:"apiKeyId"`
+	Name      string   `json:"name"`
+	KeyPrefix string   `json:"keyPrefix"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expiresAt"`
+	RevokedAt string   `json:"revokedAt"`
+	CreatedAt string   `json:"createdAt"`
+}
+
+var apiKeyCmd = &cobra.Command{
+	Use:   "apikey",
+	Short: "Manage API keys for CI and scripts",
+	Long: "jennah apikey create|list|revoke\n\n" +
+		"API keys let CI pipelines and other scripts call Jennah without logging in.\n" +
+		"Set JENNAH_API_KEY to a key and every command uses it instead of your login.",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var apiKeyCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an API key",
+	Long:  "jennah apikey create --name <name> [--scopes submit,read] [--ttl 720h]\n\nScopes: submit, read, cancel, admin (admin allows everything).",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		scopes, _ := cmd.Flags().GetStringSlice("scopes")
+		ttl, _ := cmd.Flags().GetDuration("ttl")
+
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		var result struct {
+			ApiKey apiKeyInfo `json:"apiKey"`
+			Key    string     `json:"key"`
+		}
+		body := map[string]interface{}{
+			"name":       name,
+			"scopes":     scopes,
+			"ttlSeconds": int64(ttl.Seconds()),
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/CreateApiKey", body, &result); err != nil {
+			return fmt.Errorf("failed to create API key: %w", err)
+		}
+
+		fmt.Println("✅ API key created")
+		fmt.Println(strings.Repeat("─", 40))
+		fmt.Printf("ID:      %s\n", result.ApiKey.ApiKeyID)
+		fmt.Printf("Scopes:  %s\n", strings.Join(result.ApiKey.Scopes, ", "))
+		fmt.Printf("Expires: %s\n", formatApiKeyTime(result.ApiKey.ExpiresAt, "never"))
+		fmt.Println()
+		fmt.Println("Key (shown only once, store it as JENNAH_API_KEY):")
+		fmt.Println(result.Key)
+		return nil
+	},
+}
+
+var apiKeyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your API keys",
+	Long:  "jennah apikey list",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		var result struct {
+			ApiKeys []apiKeyInfo `json:"apiKeys"`
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/ListApiKeys", map[string]interface{}{}, &result); err != nil {
+			return fmt.Errorf("failed to list API keys: %w", err)
+		}
+
+		if len(result.ApiKeys) == 0 {
+			fmt.Println("No API keys found.")
+			return nil
+		}
+
+		fmt.Printf("%-38s  %-20s  %-16s  %-24s  %-19s  %s\n", "ID", "NAME", "KEY", "SCOPES", "EXPIRES", "STATUS")
+		fmt.Println(strings.Repeat("─", 130))
+		for _, k := range result.ApiKeys {
+			name := k.Name
+			if name == "" {
+				name = "—"
+			}
+			status := "active"
+			if k.RevokedAt != "" {
+				status = "revoked " + formatApiKeyTime(k.RevokedAt, "")
+			}
+			fmt.Printf("%-38s  %-20s  %-16s  %-24s  %-19s  %s\n",
+				k.ApiKeyID, name, k.KeyPrefix+"…", strings.Join(k.Scopes, ","),
+				formatApiKeyTime(k.ExpiresAt, "never"), status)
+		}
+		return nil
+	},
+}
+
+var apiKeyRevokeCmd = &cobra.Command{
+	Use:   "revoke <api-key-id>",
+	Short: "Revoke an API key",
+	Long:  "jennah apikey revoke <api-key-id>\n\nRequests using the key are rejected from then on.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		var result struct {
+			ApiKey apiKeyInfo `json:"apiKey"`
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/RevokeApiKey", map[string]string{"apiKeyId": args[0]}, &result); err != nil {
+			if strings.Contains(err.Error(), "not_found") {
+				return fmt.Errorf("API key %s not found", args[0])
+			}
+			return fmt.Errorf("failed to revoke API key: %w", err)
+		}
+
+		fmt.Printf("✅ API key %s revoked\n", result.ApiKey.ApiKeyID)
+		return nil
+	},
+}
+
+// formatApiKeyTime renders an RFC3339 timestamp in local time, or empty if
+// the timestamp is unset.
+func formatApiKeyTime(ts, empty string) string {
+	if ts == "" {
+		return empty
+	}
+	if t, err := time.Parse(time.RFC3339, ts); err == nil {
+		return t.Local().Format("2006-01-02 15:04:05")
+	}
+	return ts
+}
+
+func init() {
+	apiKeyCreateCmd.Flags().String("name", "", "Name to recognize the key by")
+	apiKeyCreateCmd.Flags().StringSlice("scopes", []string{"submit", "read"}, "Scopes: submit, read, cancel, admin")
+	apiKeyCreateCmd.Flags().Duration("ttl", 0, "Lifetime of the key, e.g. 720h (default: never expires)")
+
+	apiKeyCmd.AddCommand(apiKeyCreateCmd)
+	apiKeyCmd.AddCommand(apiKeyListCmd)
+	apiKeyCmd.AddCommand(apiKeyRevokeCmd)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var cancelCmd = &cobra.Command{
+	Use:   "cancel <job-id>",
+	Short: "Cancel a job",
+	Long:  "jennah cancel <job-id> [--selector <labels>]\n\nStops a queued or running job. Use --selector to cancel every active job\nwhose labels match, e.g. --selector team=billing,env=prod.",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if selector, _ := cmd.Flags().GetString("selector"); selector != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		selector, _ := cmd.Flags().GetString("selector")
+
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		if selector != "" {
+			return bulkJobAction(gw, "BulkCancelJobs", selector, "cancelled")
+		}
+
+		fmt.Printf("Cancelling job %s...\n", args[0])
+		var result struct {
+			JobID  string `json:"jobId"`
+			Status string `json:"status"`
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/CancelJob", map[string]string{"jobId": args[0]}, &result); err != nil {
+			if strings.Contains(err.Error(), "not_found") {
+				return fmt.Errorf("job %s not found", args[0])
+			}
+			return fmt.Errorf("cancel failed: %w", err)
+		}
+
+		fmt.Println()
+		fmt.Printf("✅ Job %s is %s\n", args[0], result.Status)
+		return nil
+	},
+}
+
+func init() {
+	cancelCmd.Flags().StringP("selector", "l", "", "Cancel every active job whose labels match, e.g. team=billing,env=prod")
+}
+
+// bulkJobAction calls BulkCancelJobs or BulkDeleteJobs with a label selector
+// and prints which jobs were done and which failed.
+func bulkJobAction(gw *GatewayClient, rpc, selector, done string) error {
+	fmt.Printf("Applying to jobs matching %q...\n", selector)
+
+	var result struct {
+		JobIDs   []string          `json:"jobIds"`
+		Failures map[string]string `json:"failures"`
+	}
+	if err := gw.post("/jennah.v1.DeploymentService/"+rpc, map[string]string{"labelSelector": selector}, &result); err != nil {
+		return fmt.Errorf("%s failed: %w", rpc, err)
+	}
+
+	fmt.Println()
+	for _, id := range result.JobIDs {
+		fmt.Printf("  %s ✅\n", id)
+	}
+	failed := make([]string, 0, len(result.Failures))
+	for id := range result.Failures {
+		failed = append(failed, id)
+	}
+	sort.Strings(failed)
+	for _, id := range failed {
+		fmt.Printf("  %s ❌ %s\n", id, result.Failures[id])
+	}
+
+	fmt.Println()
+	switch {
+	case len(result.JobIDs) == 0 && len(failed) == 0:
+		fmt.Println("No matching jobs.")
+	case len(failed) == 0:
+		fmt.Printf("✅ %d job(s) %s.\n", len(result.JobIDs), done)
+	default:
+		fmt.Printf("%d job(s) %s, %d failed.\n", len(result.JobIDs), done, len(failed))
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -20,6 +21,8 @@ type GatewayClient struct {
 	userID   string
 	tenantID string
 	provider string
+	token    string
+	org      string // Active organization, sent as X-Jennah-Organization
 	http     *http.Client
 }
 
@@ -29,10 +32,25 @@ func newGatewayClient(cmd *cobra.Command) (*GatewayClient, error) {
 	email, _ := cmd.Flags().GetString("email")
 	userID, _ := cmd.Flags().GetString("user-id")
 	provider, _ := cmd.Flags().GetString("provider")
+	org, _ := cmd.Flags().GetString("org")
+	token := os.Getenv("JENNAH_TOKEN")
 
 	if gateway == "" {
 		gateway = os.Getenv("JENNAH_GATEWAY")
 	}
+	if gateway == "" {
+		gateway = defaultGateway
+	}
+	if org == "" {
+		org = os.Getenv("JENNAH_ORG")
+	}
+
+	// An API key stands in for a login, e.g. in CI pipelines. It always acts
+	// in the tenant or organization it was created in.
+	if apiKey := os.Getenv("JENNAH_API_KEY"); apiKey != "" {
+		return &GatewayClient{baseURL: gateway, token: apiKey, org: org, http: &http.Client{}}, nil
+	}
+
 	if email == "" {
 		email = os.Getenv("JENNAH_EMAIL")
 	}
@@ -45,7 +63,7 @@ func newGatewayClient(cmd *cobra.Command) (*GatewayClient, error) {
 
 	// Fall back to saved config from `jennah login`
 	tenantID := ""
-	if email == "" || userID == "" {
+	if email == "" || userID == "" || token == "" || org == "" {
 		if cfg, err := loadConfig(); err == nil && cfg != nil {
 			if email == "" {
 				email = cfg.Email
@@ -56,21 +74,24 @@ func newGatewayClient(cmd *cobra.Command) (*GatewayClient, error) {
 			if provider == "" && cfg.Provider != "" {
 				provider = cfg.Provider
 			}
+			if token == "" {
+				token = cfg.Token
+			}
+			if org == "" {
+				org = cfg.Organization
+			}
 			tenantID = cfg.TenantID
 		}
 	}
 
-	if gateway == "" {
-		gateway = defaultGateway
-	}
 	if provider == "" {
 		provider = "google"
 	}
-	if email == "" {
-		return nil, fmt.Errorf("not logged in: run 'jennah login --email <email> --user-id <id>'")
+	if email == "" || userID == "" {
+		return nil, fmt.Errorf("not logged in: run 'jennah login'")
 	}
-	if userID == "" {
-		return nil, fmt.Errorf("not logged in: run 'jennah login --email <email> --user-id <id>'")
+	if token == "" {
+		return nil, fmt.Errorf("saved login has no access token: run 'jennah logout' and 'jennah login' again")
 	}
 
 	return &GatewayClient{
@@ -79,10 +100,30 @@ func newGatewayClient(cmd *cobra.Command) (*GatewayClient, error) {
 		userID:   userID,
 		tenantID: tenantID,
 		provider: provider,
+		token:    token,
+		org:      org,
 		http:     &http.Client{},
 	}, nil
 }
 
+// setAuthHeaders authenticates req with the API key or the access token from
+// login. The gateway derives the caller's identity from the token; the
+// X-OAuth-Email and X-OAuth-UserId headers are only used by gateways running
+// in local development mode. Requests act in the active organization, if
+// any.
+func (c *GatewayClient) setAuthHeaders(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if c.org != "" {
+		req.Header.Set("X-Jennah-Organization", c.org)
+	}
+	if c.email == "" {
+		return // API key
+	}
+	req.Header.Set("X-OAuth-Email", c.email)
+	req.Header.Set("X-OAuth-UserId", c.userID)
+	req.Header.Set("X-OAuth-Provider", c.provider)
+}
+
 // postRaw sends a JSON POST and returns the HTTP status code and raw body.
 func (c *GatewayClient) postRaw(path string, body interface{}) (int, []byte, error) {
 	var buf bytes.Buffer
@@ -94,9 +135,7 @@ func (c *GatewayClient) postRaw(path string, body interface{}) (int, []byte, err
 		return 0, nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-OAuth-Email", c.email)
-	req.Header.Set("X-OAuth-UserId", c.userID)
-	req.Header.Set("X-OAuth-Provider", c.provider)
+	c.setAuthHeaders(req)
 
 	resp, err := c.http.Do(req)
 	if err != nil {
@@ -119,9 +158,7 @@ func (c *GatewayClient) post(path string, body interface{}, out interface{}) err
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-OAuth-Email", c.email)
-	req.Header.Set("X-OAuth-UserId", c.userID)
-	req.Header.Set("X-OAuth-Provider", c.provider)
+	c.setAuthHeaders(req)
 
 	resp, err := c.http.Do(req)
 	if err != nil {
@@ -146,3 +183,62 @@ func (c *GatewayClient) post(path string, body interface{}, out interface{}) err
 	}
 	return nil
 }
+
+// stream calls a server-streaming RPC over the Connect protocol and passes
+// each JSON message the gateway sends to onMessage, until the stream ends.
+func (c *GatewayClient) stream(path string, body interface{}, onMessage func([]byte) error) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	// Connect frames every message with a flags byte and a 4-byte length.
+	var buf bytes.Buffer
+	buf.WriteByte(0)
+	binary.Write(&buf, binary.BigEndian, uint32(len(payload)))
+	buf.Write(payload)
+
+	req, err := http.NewRequest("POST", c.baseURL+path, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/connect+json")
+	c.setAuthHeaders(req)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("gateway error %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	for {
+		var header [5]byte
+		if _, err := io.ReadFull(resp.Body, header[:]); err != nil {
+			return fmt.Errorf("stream interrupted: %w", err)
+		}
+		frame := make([]byte, binary.BigEndian.Uint32(header[1:]))
+		if _, err := io.ReadFull(resp.Body, frame); err != nil {
+			return fmt.Errorf("stream interrupted: %w", err)
+		}
+
+		// The end-of-stream frame carries the call's error, if any.
+		if header[0]&0x02 != 0 {
+			var end struct {
+				Error *struct {
+					Code    string `json:"code"`
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			if json.Unmarshal(frame, &end) == nil && end.Error != nil {
+				return fmt.Errorf("%s: %s", end.Error.Code, end.Error.Message)
+			}
+			return nil
+		}
+		if err := onMessage(frame); err != nil {
+			return err
+		}
+	}
+}
@@ -11,16 +11,18 @@ import (
 var deleteCmd = &cobra.Command{
 	Use:   "delete <job-id>",
 	Short: "Delete a job",
-	Long:  "jennah delete <job-id> [--all]\n\nPermanently removes a job record from the system.\nUse --all to delete all jobs at once.",
+	Long:  "jennah delete <job-id> [--all] [--selector <labels>]\n\nPermanently removes a job record from the system.\nUse --all to delete all jobs at once, or --selector to delete every job\nwhose labels match, e.g. --selector team=billing,env=prod.",
 	Args: func(cmd *cobra.Command, args []string) error {
 		all, _ := cmd.Flags().GetBool("all")
-		if all {
-			return nil
+		selector, _ := cmd.Flags().GetString("selector")
+		if all || selector != "" {
+			return cobra.NoArgs(cmd, args)
 		}
 		return cobra.ExactArgs(1)(cmd, args)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		all, _ := cmd.Flags().GetBool("all")
+		selector, _ := cmd.Flags().GetString("selector")
 
 		gw, err := newGatewayClient(cmd)
 		if err != nil {
@@ -30,6 +32,9 @@ var deleteCmd = &cobra.Command{
 		if all {
 			return deleteAllJobs(gw)
 		}
+		if selector != "" {
+			return bulkJobAction(gw, "BulkDeleteJobs", selector, "deleted")
+		}
 
 		return deleteSingleJob(gw, args[0])
 	},
@@ -37,6 +42,8 @@ var deleteCmd = &cobra.Command{
 
 func init() {
 	deleteCmd.Flags().Bool("all", false, "Delete all jobs")
+	deleteCmd.Flags().StringP("selector", "l", "", "Delete every job whose labels match, e.g. team=billing,env=prod")
+	deleteCmd.MarkFlagsMutuallyExclusive("all", "selector")
 }
 
 func deleteSingleJob(gw *GatewayClient, jobID string) error {
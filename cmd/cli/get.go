@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -12,17 +13,22 @@ import (
 var getCmd = &cobra.Command{
 	Use:   "get <job-id>",
 	Short: "Get job details",
-	Long:  "jennah get <job-id> [--output json]\n\nFetches and displays full details of a specific job by ID.",
+	Long:  "jennah get <job-id> [--output json] [--timeline]\n\nFetches and displays full details of a specific job by ID.\nUse --timeline to show the states the job went through and how long it spent in each.",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		jobID := args[0]
 		outputFmt, _ := cmd.Flags().GetString("output")
+		timeline, _ := cmd.Flags().GetBool("timeline")
 
 		gw, err := newGatewayClient(cmd)
 		if err != nil {
 			return err
 		}
 
+		if timeline {
+			return printJobTimeline(gw, jobID, outputFmt)
+		}
+
 		jobs, err := fetchJobs(gw)
 		if err != nil {
 			return fmt.Errorf("failed to fetch jobs: %w", err)
@@ -91,6 +97,7 @@ var getCmd = &cobra.Command{
 		fmt.Printf("Status:          %s\n", j.Status)
 		fmt.Printf("Error:           %s\n", dash(j.ErrorMessage))
 		fmt.Printf("Retries:         %s\n", retries)
+		fmt.Printf("Retry Policy:    %s\n", dash(j.RetryPolicy))
 		fmt.Printf("Created:         %s\n", fmtTime(j.CreatedAt))
 		fmt.Printf("Updated:         %s\n", fmtTime(j.UpdatedAt))
 		fmt.Printf("Scheduled:       %s\n", fmtTime(j.ScheduledAt))
@@ -107,6 +114,7 @@ var getCmd = &cobra.Command{
 		fmt.Printf("Service Account: %s\n", dash(j.ServiceAccount))
 		fmt.Printf("GCP Job Path:    %s\n", dash(j.GcpBatchJobPath))
 		fmt.Printf("Image:           %s\n", dash(j.ImageURI))
+		fmt.Printf("Labels:          %s\n", dash(formatLabels(j.Labels)))
 		if j.EnvVarsJson != "" && j.EnvVarsJson != "{}" && j.EnvVarsJson != "null" {
 			var envMap map[string]string
 			if json.Unmarshal([]byte(j.EnvVarsJson), &envMap) == nil && len(envMap) > 0 {
@@ -125,4 +133,15 @@ var getCmd = &cobra.Command{
 
 func init() {
 	getCmd.Flags().String("output", "", "Output format: json")
+	getCmd.Flags().Bool("timeline", false, "Show the job's state changes and time spent in each state")
+}
+
+// formatLabels renders labels as a sorted "key=value,..." selector.
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
 }
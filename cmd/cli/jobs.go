@@ -14,43 +14,76 @@ type ResourceOverride struct {
 
 // Job is the common job structure returned by the gateway.
 type Job struct {
-	JobID            string           `json:"jobId"`
-	TenantID         string           `json:"tenantId"`
-	Name             string           `json:"name"`
-	ImageURI         string           `json:"imageUri"`
-	Status           string           `json:"status"`
-	CreatedAt        string           `json:"createdAt"`
-	UpdatedAt        string           `json:"updatedAt"`
-	ScheduledAt      string           `json:"scheduledAt"`
-	StartedAt        string           `json:"startedAt"`
-	CompletedAt      string           `json:"completedAt"`
-	RetryCount       json.Number      `json:"retryCount"`
-	MaxRetries       json.Number      `json:"maxRetries"`
-	ErrorMessage     string           `json:"errorMessage"`
-	GcpBatchJobPath  string           `json:"gcpBatchJobPath"`
-	Commands         []string         `json:"commands"`
-	EnvVarsJson      string           `json:"envVarsJson"`
-	ResourceProfile  string           `json:"resourceProfile"`
-	ResourceOverride ResourceOverride `json:"resourceOverride"`
-	MachineType      string           `json:"machineType"`
-	BootDiskSizeGb   json.Number      `json:"bootDiskSizeGb"`
-	UseSpotVms       bool             `json:"useSpotVms"`
-	ServiceAccount   string           `json:"serviceAccount"`
-	ComplexityLevel  string           `json:"complexityLevel"`
-	AssignedService  string           `json:"assignedService"`
+	JobID            string            `json:"jobId"`
+	TenantID         string            `json:"tenantId"`
+	Name             string            `json:"name"`
+	ImageURI         string            `json:"imageUri"`
+	Status           string            `json:"status"`
+	CreatedAt        string            `json:"createdAt"`
+	UpdatedAt        string            `json:"updatedAt"`
+	ScheduledAt      string            `json:"scheduledAt"`
+	StartedAt        string            `json:"startedAt"`
+	CompletedAt      string            `json:"completedAt"`
+	RetryCount       json.Number       `json:"retryCount"`
+	MaxRetries       json.Number       `json:"maxRetries"`
+	RetryPolicy      string            `json:"retryPolicy"`
+	ErrorMessage     string            `json:"errorMessage"`
+	GcpBatchJobPath  string            `json:"gcpBatchJobPath"`
+	Commands         []string          `json:"commands"`
+	EnvVarsJson      string            `json:"envVarsJson"`
+	ResourceProfile  string            `json:"resourceProfile"`
+	ResourceOverride ResourceOverride  `json:"resourceOverride"`
+	MachineType      string            `json:"machineType"`
+	BootDiskSizeGb   json.Number       `json:"bootDiskSizeGb"`
+	UseSpotVms       bool              `json:"useSpotVms"`
+	ServiceAccount   string            `json:"serviceAccount"`
+	ComplexityLevel  string            `json:"complexityLevel"`
+	AssignedService  string            `json:"assignedService"`
+	Labels           map[string]string `json:"labels"`
 }
 
 // fetchJobs calls ListJobs on the gateway and returns all jobs for the user.
 func fetchJobs(gw *GatewayClient) ([]Job, error) {
-	var result struct {
-		Jobs []Job `json:"jobs"`
+	return listJobs(gw, map[string]interface{}{}, 0)
+}
+
+// listJobs calls ListJobs with the given filters and follows page tokens
+// until limit jobs (0 for every match) have been fetched.
+func listJobs(gw *GatewayClient, filters map[string]interface{}, limit int) ([]Job, error) {
+	body := map[string]interface{}{}
+	for k, v := range filters {
+		body[k] = v
 	}
-	if err := gw.post("/jennah.v1.DeploymentService/ListJobs", map[string]interface{}{}, &result); err != nil {
-		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	if _, ok := body["pageSize"]; !ok {
+		body["pageSize"] = maxListPageSize
+		if limit > 0 && limit < maxListPageSize {
+			body["pageSize"] = limit
+		}
+	}
+
+	var jobs []Job
+	for {
+		var result struct {
+			Jobs          []Job  `json:"jobs"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/ListJobs", body, &result); err != nil {
+			return nil, fmt.Errorf("failed to list jobs: %w", err)
+		}
+		jobs = append(jobs, result.Jobs...)
+		if limit > 0 && len(jobs) >= limit {
+			return jobs[:limit], nil
+		}
+		if result.NextPageToken == "" {
+			return jobs, nil
+		}
+		body["pageToken"] = result.NextPageToken
 	}
-	return result.Jobs, nil
 }
 
+// maxListPageSize is the largest page the gateway returns.
+const maxListPageSize = 1000
+
 // findJob returns the job with the given ID from a list, or nil.
 func findJob(jobs []Job, jobID string) *Job {
 	for i := range jobs {
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,15 +11,29 @@ import (
 
 var listCmd = &cobra.Command{
 	Use:   "list",
-	Short: "List all your jobs",
-	Long:  "jennah list\n\nDisplays all jobs submitted under your account.",
+	Short: "List your jobs",
+	Long: "jennah list [--status RUNNING,QUEUED] [--since 24h] [--until <time>] [--name-prefix <prefix>]\n" +
+		"            [--selector team=billing,env=prod] [--service run|batch] [--oldest-first]\n" +
+		"            [--limit N] [--page-size N]\n\n" +
+		"Displays the jobs submitted under your account, newest first, fetching\n" +
+		"further pages from the gateway as needed. --since and --until take an\n" +
+		"RFC3339 time or a duration before now (e.g. 2h, 7d).",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		filters, err := listFilters(cmd)
+		if err != nil {
+			return err
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+		if limit < 0 {
+			return fmt.Errorf("--limit must not be negative")
+		}
+
 		gw, err := newGatewayClient(cmd)
 		if err != nil {
 			return err
 		}
 
-		jobs, err := fetchJobs(gw)
+		jobs, err := listJobs(gw, filters, limit)
 		if err != nil {
 			return err
 		}
@@ -63,3 +78,79 @@ var listCmd = &cobra.Command{
 		return nil
 	},
 }
+
+// listFilters builds the ListJobs filters from the list command's flags.
+func listFilters(cmd *cobra.Command) (map[string]interface{}, error) {
+	filters := map[string]interface{}{}
+
+	if statuses, _ := cmd.Flags().GetStringSlice("status"); len(statuses) > 0 {
+		for i, s := range statuses {
+			statuses[i] = strings.ToUpper(strings.TrimSpace(s))
+		}
+		filters["statuses"] = statuses
+	}
+	for flag, field := range map[string]string{"since": "createdAfter", "until": "createdBefore"} {
+		v, _ := cmd.Flags().GetString(flag)
+		if v == "" {
+			continue
+		}
+		t, err := parseListTime(v)
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %w", flag, err)
+		}
+		filters[field] = t.UTC().Format(time.RFC3339)
+	}
+	if v, _ := cmd.Flags().GetString("name-prefix"); v != "" {
+		filters["namePrefix"] = v
+	}
+	if v, _ := cmd.Flags().GetString("selector"); v != "" {
+		filters["labelSelector"] = v
+	}
+	if v, _ := cmd.Flags().GetString("service"); v != "" {
+		switch strings.ToLower(v) {
+		case "run", "cloud_run_job":
+			filters["assignedService"] = "CLOUD_RUN_JOB"
+		case "batch", "cloud_batch":
+			filters["assignedService"] = "CLOUD_BATCH"
+		default:
+			return nil, fmt.Errorf("--service must be run or batch, got %q", v)
+		}
+	}
+	if oldest, _ := cmd.Flags().GetBool("oldest-first"); oldest {
+		filters["orderBy"] = "created_at asc"
+	}
+	if v, _ := cmd.Flags().GetInt("page-size"); v > 0 {
+		filters["pageSize"] = v
+	}
+	return filters, nil
+}
+
+// parseListTime parses an RFC3339 time, or a duration before now such as
+// 90m, 24h or 7d.
+func parseListTime(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	if days, ok := strings.CutSuffix(v, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return time.Now().AddDate(0, 0, -n), nil
+		}
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("want an RFC3339 time or a duration such as 24h or 7d, got %q", v)
+	}
+	return time.Now().Add(-d), nil
+}
+
+func init() {
+	listCmd.Flags().StringSlice("status", nil, "Only jobs in these statuses, e.g. RUNNING,QUEUED")
+	listCmd.Flags().String("since", "", "Only jobs created at or after this time (RFC3339, or a duration ago such as 24h or 7d)")
+	listCmd.Flags().String("until", "", "Only jobs created before this time (RFC3339, or a duration ago)")
+	listCmd.Flags().String("name-prefix", "", "Only jobs whose name starts with this prefix")
+	listCmd.Flags().StringP("selector", "l", "", "Only jobs whose labels match, e.g. team=billing,env=prod (also key!=value, key, !key)")
+	listCmd.Flags().String("service", "", "Only jobs run on this service: run (Cloud Run Jobs) or batch (Cloud Batch)")
+	listCmd.Flags().Bool("oldest-first", false, "List the oldest jobs first")
+	listCmd.Flags().Int("limit", 0, "Stop after this many jobs (default: all)")
+	listCmd.Flags().Int("page-size", 0, "Jobs fetched per request (default: as many as the gateway allows)")
+}
@@ -189,6 +189,10 @@ type Config struct {
 	UserID   string `json:"user_id"`
 	TenantID string `json:"tenant_id,omitempty"`
 	Provider string `json:"provider,omitempty"`
+	Token    string `json:"token,omitempty"` // OAuth access token sent to the gateway
+	// Organization is the active organization set by `jennah org use`; empty
+	// means the personal tenant.
+	Organization string `json:"organization,omitempty"`
 }
 
 func configPath() (string, error) {
@@ -325,8 +329,8 @@ var loginCmd = &cobra.Command{
 			}
 		}
 
-		// Temporarily save config so newGatewayClient can read headers.
-		cfg := &Config{Email: email, UserID: userID, Provider: "github"}
+		// Temporarily save config so newGatewayClient can read the credentials.
+		cfg := &Config{Email: email, UserID: userID, Provider: "github", Token: accessToken}
 		if err := saveConfig(cfg); err != nil {
 			return fmt.Errorf("failed to save credentials: %w", err)
 		}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// LogEntry is one line of a job's container output.
+type LogEntry struct {
+	Timestamp string      `json:"timestamp"`
+	TaskIndex json.Number `json:"taskIndex"`
+	Message   string      `json:"message"`
+}
+
+var logsCmd = &cobra.Command{
+	Use:   "logs <job-id>",
+	Short: "Show job logs",
+	Long:  "jennah logs <job-id> [-f]\n\nPrints the container output of a job.\nUse -f to keep following new output until the job finishes.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		jobID := args[0]
+		follow, _ := cmd.Flags().GetBool("follow")
+
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		if follow {
+			return gw.stream("/jennah.v1.DeploymentService/StreamJobLogs",
+				map[string]interface{}{"jobId": jobID, "follow": true},
+				func(msg []byte) error {
+					var result struct {
+						Entries []LogEntry `json:"entries"`
+					}
+					if err := json.Unmarshal(msg, &result); err != nil {
+						return fmt.Errorf("invalid log message: %w", err)
+					}
+					printLogEntries(result.Entries)
+					return nil
+				})
+		}
+
+		pageToken := ""
+		for {
+			var result struct {
+				Entries       []LogEntry `json:"entries"`
+				NextPageToken string     `json:"nextPageToken"`
+			}
+			body := map[string]interface{}{"jobId": jobID, "pageToken": pageToken}
+			if err := gw.post("/jennah.v1.DeploymentService/GetJobLogs", body, &result); err != nil {
+				return fmt.Errorf("failed to fetch logs: %w", err)
+			}
+			printLogEntries(result.Entries)
+			if result.NextPageToken == "" {
+				return nil
+			}
+			pageToken = result.NextPageToken
+		}
+	},
+}
+
+func init() {
+	logsCmd.Flags().BoolP("follow", "f", false, "Follow new output until the job finishes")
+}
+
+// printLogEntries prints entries as "<time> [task <n>] <message>".
+func printLogEntries(entries []LogEntry) {
+	for _, e := range entries {
+		ts := e.Timestamp
+		if t, err := time.Parse(time.RFC3339Nano, e.Timestamp); err == nil {
+			ts = t.Local().Format("2006-01-02 15:04:05")
+		}
+		task := e.TaskIndex.String()
+		if task == "" {
+			task = "0"
+		}
+		fmt.Printf("%s [task %s] %s\n", ts, task, e.Message)
+	}
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+type orgInfo struct {
+	OrganizationID string `json:"organizationId"`
+	Name           string `json:"name"`
+	Role           string `json:"role"`
+	CreatedBy      string `json:"createdBy"`
+	CreatedAt      string `json:"createdAt"`
+}
+
+type orgMemberInfo struct {
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	AddedBy   string `json:"addedBy"`
+	CreatedAt string `json:"createdAt"`
+}
+
+var orgCmd = &cobra.Command{
+	Use:   "org",
+	Short: "Manage organizations shared with your team",
+	Long: "jennah org create|list|use|members|add-member|remove-member\n\n" +
+		"An organization is a tenant shared by its members. Each member has a role:\n" +
+		"  viewer     read jobs, logs and notifications\n" +
+		"  submitter  viewer, plus submit and cancel jobs\n" +
+		"  admin      everything, including members and API keys\n\n" +
+		"Commands act in your personal tenant unless an organization is selected\n" +
+		"with 'jennah org use', --org or JENNAH_ORG.",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var orgCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an organization",
+	Long:  "jennah org create <name>\n\nYou become its first admin.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		var result struct {
+			Organization orgInfo `json:"organization"`
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/CreateOrganization", map[string]string{"name": args[0]}, &result); err != nil {
+			return fmt.Errorf("failed to create organization: %w", err)
+		}
+
+		fmt.Printf("✅ Organization %q created: %s\n", result.Organization.Name, result.Organization.OrganizationID)
+		fmt.Printf("Run 'jennah org use %s' to work in it.\n", result.Organization.OrganizationID)
+		return nil
+	},
+}
+
+var orgListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your organizations",
+	Long:  "jennah org list",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		var result struct {
+			Organizations []orgInfo `json:"organizations"`
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/ListOrganizations", map[string]interface{}{}, &result); err != nil {
+			return fmt.Errorf("failed to list organizations: %w", err)
+		}
+
+		if len(result.Organizations) == 0 {
+			fmt.Println("You are not a member of any organization.")
+			return nil
+		}
+
+		fmt.Printf("  %-38s  %-24s  %s\n", "ID", "NAME", "ROLE")
+		fmt.Println(strings.Repeat("─", 80))
+		for _, o := range result.Organizations {
+			active := " "
+			if o.OrganizationID == gw.org {
+				active = "*"
+			}
+			fmt.Printf("%s %-38s  %-24s  %s\n", active, o.OrganizationID, o.Name, o.Role)
+		}
+		return nil
+	},
+}
+
+var orgUseCmd = &cobra.Command{
+	Use:   "use [organization-id]",
+	Short: "Select the organization commands act in",
+	Long:  "jennah org use <organization-id>\njennah org use            (back to your personal tenant)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if cfg == nil {
+			return fmt.Errorf("not logged in: run 'jennah login'")
+		}
+
+		org := ""
+		if len(args) == 1 {
+			org = args[0]
+		}
+		cfg.Organization = org
+
+		// Check membership before saving, so a typo does not lock every
+		// command out.
+		role := ""
+		if org != "" {
+			gw, err := newGatewayClient(cmd)
+			if err != nil {
+				return err
+			}
+			gw.org = org
+
+			var result struct {
+				Role string `json:"role"`
+			}
+			if err := gw.post("/jennah.v1.DeploymentService/GetCurrentTenant", map[string]interface{}{}, &result); err != nil {
+				return fmt.Errorf("cannot use organization %s: %w", org, err)
+			}
+			role = result.Role
+		}
+
+		if err := saveConfig(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		if org == "" {
+			fmt.Println("✅ Using your personal tenant")
+		} else {
+			fmt.Printf("✅ Using organization %s as %s\n", org, role)
+		}
+		return nil
+	},
+}
+
+var orgMembersCmd = &cobra.Command{
+	Use:   "members",
+	Short: "List the members of the active organization",
+	Long:  "jennah org members",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		var result struct {
+			Members []orgMemberInfo `json:"members"`
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/ListOrganizationMembers", map[string]interface{}{}, &result); err != nil {
+			return fmt.Errorf("failed to list members: %w", err)
+		}
+
+		fmt.Printf("%-32s  %-10s  %-32s  %s\n", "EMAIL", "ROLE", "ADDED BY", "SINCE")
+		fmt.Println(strings.Repeat("─", 100))
+		for _, m := range result.Members {
+			addedBy := m.AddedBy
+			if addedBy == "" {
+				addedBy = "—"
+			}
+			fmt.Printf("%-32s  %-10s  %-32s  %s\n", m.Email, m.Role, addedBy, formatApiKeyTime(m.CreatedAt, ""))
+		}
+		return nil
+	},
+}
+
+var orgAddMemberCmd = &cobra.Command{
+	Use:   "add-member <email>",
+	Short: "Add a member to the active organization, or change their role",
+	Long:  "jennah org add-member <email> [--role viewer|submitter|admin]",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		role, _ := cmd.Flags().GetString("role")
+
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		var result struct {
+			Member orgMemberInfo `json:"member"`
+		}
+		body := map[string]string{"email": args[0], "role": role}
+		if err := gw.post("/jennah.v1.DeploymentService/AddOrganizationMember", body, &result); err != nil {
+			return fmt.Errorf("failed to add member: %w", err)
+		}
+
+		fmt.Printf("✅ %s is now %s\n", result.Member.Email, result.Member.Role)
+		return nil
+	},
+}
+
+var orgRemoveMemberCmd = &cobra.Command{
+	Use:   "remove-member <email>",
+	Short: "Remove a member from the active organization",
+	Long:  "jennah org remove-member <email>",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		var result struct {
+			Email string `json:"email"`
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/RemoveOrganizationMember", map[string]string{"email": args[0]}, &result); err != nil {
+			if strings.Contains(err.Error(), "not_found") {
+				return fmt.Errorf("%s is not a member", args[0])
+			}
+			return fmt.Errorf("failed to remove member: %w", err)
+		}
+
+		fmt.Printf("✅ %s removed\n", result.Email)
+		return nil
+	},
+}
+
+func init() {
+	orgAddMemberCmd.Flags().String("role", "submitter", "Role: viewer, submitter or admin")
+
+	orgCmd.AddCommand(orgCreateCmd)
+	orgCmd.AddCommand(orgListCmd)
+	orgCmd.AddCommand(orgUseCmd)
+	orgCmd.AddCommand(orgMembersCmd)
+	orgCmd.AddCommand(orgAddMemberCmd)
+	orgCmd.AddCommand(orgRemoveMemberCmd)
+}
@@ -21,6 +21,7 @@ func init() {
 
 	rootCmd.PersistentFlags().String("provider", "", "OAuth provider, default: google (or JENNAH_PROVIDER env var)")
 	rootCmd.PersistentFlags().String("gateway", "", "Gateway URL (or JENNAH_GATEWAY env var)")
+	rootCmd.PersistentFlags().String("org", "", "Organization to act in (or JENNAH_ORG env var; see 'jennah org use')")
 	rootCmd.PersistentFlags().MarkHidden("provider")
 	rootCmd.PersistentFlags().MarkHidden("gateway")
 
@@ -28,9 +29,13 @@ func init() {
 
 	rootCmd.AddCommand(submitCmd)
 	rootCmd.AddCommand(getCmd)
+	rootCmd.AddCommand(logsCmd)
 	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(cancelCmd)
 	rootCmd.AddCommand(deleteCmd)
 	rootCmd.AddCommand(tenantCmd)
+	rootCmd.AddCommand(apiKeyCmd)
+	rootCmd.AddCommand(orgCmd)
 	rootCmd.AddCommand(loginCmd)
 	rootCmd.AddCommand(logoutCmd)
 }
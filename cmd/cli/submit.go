@@ -1,6 +1,8 @@
 package main
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -50,6 +52,9 @@ Routing tiers (decided automatically by the gateway using Gemini AI):
 			"boot_disk_size_gb": "bootDiskSizeGb",
 			"use_spot_vms":      "useSpotVms",
 			"service_account":   "serviceAccount",
+			"max_retries":       "maxRetries",
+			"retry_policy":      "retryPolicy",
+			"idempotency_key":   "idempotencyKey",
 		}
 		for snake, camel := range snakeToCamel {
 			if _, hasCamel := body[camel]; !hasCamel {
@@ -77,6 +82,24 @@ Routing tiers (decided automatically by the gateway using Gemini AI):
 		if v, _ := cmd.Flags().GetBool("spot"); v {
 			body["useSpotVms"] = true
 		}
+		if cmd.Flags().Changed("max-retries") {
+			v, _ := cmd.Flags().GetInt64("max-retries")
+			body["maxRetries"] = v
+		}
+		if v, _ := cmd.Flags().GetString("retry-policy"); v != "" {
+			body["retryPolicy"] = v
+		}
+		// --label merges into the job file's labels.
+		if flagLabels, _ := cmd.Flags().GetStringToString("label"); len(flagLabels) > 0 {
+			labels, _ := body["labels"].(map[string]interface{})
+			if labels == nil {
+				labels = map[string]interface{}{}
+			}
+			for k, v := range flagLabels {
+				labels[k] = v
+			}
+			body["labels"] = labels
+		}
 
 		// --instances: inject JENNAH_TASK_COUNT + JENNAH_PARALLELISM into envVars
 		if instances, _ := cmd.Flags().GetInt64("instances"); instances > 1 {
@@ -116,13 +139,30 @@ Routing tiers (decided automatically by the gateway using Gemini AI):
 			body["resourceOverride"] = override
 		}
 
+		// Every attempt below carries the same key, so a retry after a network
+		// error returns the job the first attempt created instead of a new one.
+		if v, _ := cmd.Flags().GetString("idempotency-key"); v != "" {
+			body["idempotencyKey"] = v
+		}
+		if key, _ := body["idempotencyKey"].(string); key == "" {
+			key, err := newIdempotencyKey()
+			if err != nil {
+				return fmt.Errorf("failed to generate idempotency key: %w", err)
+			}
+			body["idempotencyKey"] = key
+		}
+
 		// --- Print submission header ---
 		profile, _ := body["resourceProfile"].(string)
 		machineType, _ := body["machineType"].(string)
 
 		fmt.Printf("Gateway URL:  %s\n", gw.baseURL)
-		fmt.Printf("User ID:      %s\n", gw.userID)
-		fmt.Printf("Tenant ID:    %s\n", gw.tenantID)
+		if gw.userID != "" {
+			fmt.Printf("User ID:      %s\n", gw.userID)
+			fmt.Printf("Tenant ID:    %s\n", gw.tenantID)
+		} else {
+			fmt.Printf("Auth:         API key (JENNAH_API_KEY)\n")
+		}
 		if profile != "" {
 			fmt.Printf("Profile:      %s\n", profile)
 		}
@@ -287,6 +327,15 @@ func friendlyService(s string) string {
 	}
 }
 
+// newIdempotencyKey returns a random key identifying one submission.
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 func init() {
 	submitCmd.Flags().Bool("wait", false, "Block until the job completes (polls every 5s)")
 	submitCmd.Flags().String("machine-type", "", "GCP machine type — routes to Cloud Batch (e.g. e2-standard-4, n1-standard-16)")
@@ -297,5 +346,9 @@ func init() {
 	submitCmd.Flags().String("name", "", "Optional human-readable job name")
 	submitCmd.Flags().String("service-account", "", "Custom GCP service account email")
 	submitCmd.Flags().Bool("spot", false, "Use Spot VMs (cheaper, preemptible)")
+	submitCmd.Flags().Int64("max-retries", 3, "Automatic resubmissions after a failed attempt (0 disables retries)")
+	submitCmd.Flags().String("retry-policy", "", "Which failures to retry: on_failure (default), on_preemption, or never")
+	submitCmd.Flags().StringToString("label", nil, "Job label as key=value, repeatable (e.g. --label team=billing --label env=prod)")
 	submitCmd.Flags().Int64("instances", 0, "Number of parallel instances (e.g. 4) — sets JENNAH_TASK_COUNT")
+	submitCmd.Flags().String("idempotency-key", "", "Key that makes re-running the same submit return the original job (default: random per run)")
 }
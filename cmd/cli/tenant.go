@@ -31,6 +31,11 @@ var tenantWhoamiCmd = &cobra.Command{
 			UserEmail     string `json:"userEmail"`
 			OAuthProvider string `json:"oauthProvider"`
 			CreatedAt     string `json:"createdAt"`
+			Role          string `json:"role"`
+			Organization  *struct {
+				OrganizationID string `json:"organizationId"`
+				Name           string `json:"name"`
+			} `json:"organization"`
 		}
 		if err := gw.post("/jennah.v1.DeploymentService/GetCurrentTenant", map[string]interface{}{}, &result); err != nil {
 			return fmt.Errorf("failed to get tenant: %w", err)
@@ -41,6 +46,12 @@ var tenantWhoamiCmd = &cobra.Command{
 		fmt.Printf("Tenant ID: %s\n", result.TenantID)
 		fmt.Printf("Email:     %s\n", result.UserEmail)
 		fmt.Printf("Provider:  %s\n", result.OAuthProvider)
+		if result.Organization != nil {
+			fmt.Printf("Org:       %s (%s)\n", result.Organization.Name, result.Organization.OrganizationID)
+		}
+		if result.Role != "" {
+			fmt.Printf("Role:      %s\n", result.Role)
+		}
 
 		createdAt := result.CreatedAt
 		if t, err := time.Parse(time.RFC3339, result.CreatedAt); err == nil {
@@ -52,6 +63,74 @@ var tenantWhoamiCmd = &cobra.Command{
 	},
 }
 
+var tenantQuotaCmd = &cobra.Command{
+	Use:   "quota",
+	Short: "Show your tenant's quota and usage",
+	Long: "jennah tenant quota\n\n" +
+		"Jobs over a limit are rejected, or QUEUED until capacity frees up when\n" +
+		"queueing is enabled. A limit of 0 means unlimited.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gw, err := newGatewayClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		// int64 fields are JSON strings in the Connect protocol.
+		var result struct {
+			Quota struct {
+				MaxConcurrentJobs   int64    `json:"maxConcurrentJobs,string"`
+				MaxCpuMillis        int64    `json:"maxCpuMillis,string"`
+				MaxMemoryMib        int64    `json:"maxMemoryMib,string"`
+				MaxJobsPerDay       int64    `json:"maxJobsPerDay,string"`
+				AllowedMachineTypes []string `json:"allowedMachineTypes"`
+				AllowSpotVms        bool     `json:"allowSpotVms"`
+				QueueWhenBusy       bool     `json:"queueWhenBusy"`
+			} `json:"quota"`
+			Usage struct {
+				ActiveJobs int64 `json:"activeJobs,string"`
+				QueuedJobs int64 `json:"queuedJobs,string"`
+				CpuMillis  int64 `json:"cpuMillis,string"`
+				MemoryMib  int64 `json:"memoryMib,string"`
+				JobsToday  int64 `json:"jobsToday,string"`
+			} `json:"usage"`
+			Enforced bool `json:"enforced"`
+		}
+		if err := gw.post("/jennah.v1.DeploymentService/GetTenantQuota", map[string]interface{}{}, &result); err != nil {
+			return fmt.Errorf("failed to get quota: %w", err)
+		}
+
+		if !result.Enforced {
+			fmt.Println("This gateway does not enforce quotas.")
+			return nil
+		}
+
+		limit := func(n int64) string {
+			if n == 0 {
+				return "unlimited"
+			}
+			return fmt.Sprint(n)
+		}
+		q, u := result.Quota, result.Usage
+		fmt.Printf("%-20s  %-10s  %s\n", "LIMIT", "USED", "MAX")
+		fmt.Println(strings.Repeat("─", 50))
+		fmt.Printf("%-20s  %-10d  %s\n", "Concurrent jobs", u.ActiveJobs, limit(q.MaxConcurrentJobs))
+		fmt.Printf("%-20s  %-10d  %s\n", "vCPU (millis)", u.CpuMillis, limit(q.MaxCpuMillis))
+		fmt.Printf("%-20s  %-10d  %s\n", "Memory (MiB)", u.MemoryMib, limit(q.MaxMemoryMib))
+		fmt.Printf("%-20s  %-10d  %s\n", "Jobs today (UTC)", u.JobsToday, limit(q.MaxJobsPerDay))
+		fmt.Println()
+
+		machineTypes := "any"
+		if len(q.AllowedMachineTypes) > 0 {
+			machineTypes = strings.Join(q.AllowedMachineTypes, ", ")
+		}
+		fmt.Printf("Machine types:   %s\n", machineTypes)
+		fmt.Printf("Spot VMs:        %t\n", q.AllowSpotVms)
+		fmt.Printf("Queue when busy: %t (%d queued)\n", q.QueueWhenBusy, u.QueuedJobs)
+		return nil
+	},
+}
+
 func init() {
 	tenantCmd.AddCommand(tenantWhoamiCmd)
+	tenantCmd.AddCommand(tenantQuotaCmd)
 }
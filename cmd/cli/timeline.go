@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimelineEntry is one state a job entered, as returned by GetJobTimeline.
+type TimelineEntry struct {
+	FromStatus      string      `json:"fromStatus,omitempty"`
+	ToStatus        string      `json:"toStatus"`
+	TransitionedAt  string      `json:"transitionedAt"`
+	DurationSeconds json.Number `json:"durationSeconds,omitempty"`
+	WorkerID        string      `json:"workerId,omitempty"`
+	Reason          string      `json:"reason,omitempty"`
+	ProviderDetail  string      `json:"providerDetail,omitempty"`
+}
+
+// printJobTimeline fetches a job's timeline and prints one row per state,
+// with how long the job stayed in it.
+func printJobTimeline(gw *GatewayClient, jobID, outputFmt string) error {
+	var result struct {
+		JobID   string          `json:"jobId"`
+		Status  string          `json:"status"`
+		Entries []TimelineEntry `json:"entries"`
+	}
+	if err := gw.post("/jennah.v1.DeploymentService/GetJobTimeline", map[string]interface{}{"jobId": jobID}, &result); err != nil {
+		return fmt.Errorf("failed to fetch timeline: %w", err)
+	}
+
+	if outputFmt == "json" {
+		b, _ := json.MarshalIndent(result.Entries, "", "  ")
+		fmt.Println(string(b))
+		return nil
+	}
+
+	fmt.Printf("Timeline of %s (%s)\n", result.JobID, result.Status)
+	fmt.Printf("%-19s  %-10s  %-10s  %-20s  %s\n", "TIME", "STATE", "FOR", "WORKER", "DETAIL")
+	for _, e := range result.Entries {
+		at := e.TransitionedAt
+		if t, err := time.Parse(time.RFC3339, e.TransitionedAt); err == nil {
+			at = t.Local().Format("2006-01-02 15:04:05")
+		}
+		duration := "—"
+		if secs, err := e.DurationSeconds.Int64(); err == nil && secs > 0 {
+			duration = (time.Duration(secs) * time.Second).String()
+		}
+		worker := e.WorkerID
+		if worker == "" {
+			worker = "—"
+		}
+		fmt.Printf("%-19s  %-10s  %-10s  %-20s  %s\n", at, e.ToStatus, duration, worker, timelineDetail(e))
+	}
+	return nil
+}
+
+// timelineDetail joins the recorded reason and what the provider reported.
+func timelineDetail(e TimelineEntry) string {
+	var parts []string
+	for _, s := range []string{e.Reason, e.ProviderDetail} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	if len(parts) == 0 {
+		return "—"
+	}
+	return strings.Join(parts, " — ")
+}
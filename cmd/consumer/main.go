@@ -12,6 +12,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/alphauslabs/jennah/internal/config"
 	"github.com/alphauslabs/jennah/internal/database"
 	"github.com/alphauslabs/jennah/internal/notifier"
 	"github.com/google/uuid"
@@ -32,19 +33,36 @@ func main() {
 	defer stop()
 
 	// --- Database setup ---
+	dbProvider := os.Getenv("DB_PROVIDER")
+	if dbProvider == "" {
+		dbProvider = "spanner"
+	}
 	dbProject := os.Getenv("DB_PROJECT_ID")
 	dbInstance := os.Getenv("DB_INSTANCE")
 	dbDatabase := os.Getenv("DB_DATABASE")
-	if dbProject == "" || dbInstance == "" || dbDatabase == "" {
+	if dbProvider == "spanner" && (dbProject == "" || dbInstance == "" || dbDatabase == "") {
 		log.Fatal("DB_PROJECT_ID, DB_INSTANCE, and DB_DATABASE must be set")
 	}
+	dbEndpoint := os.Getenv("DB_ENDPOINT")
+	if dbProvider == "postgres" && dbEndpoint == "" {
+		log.Fatal("DB_ENDPOINT must be set for postgres")
+	}
 
-	dbClient, err := database.NewClient(ctx, dbProject, dbInstance, dbDatabase)
+	dbConfig := config.DatabaseConfig{
+		Provider:  dbProvider,
+		ProjectID: dbProject,
+		Instance:  dbInstance,
+		Database:  dbDatabase,
+		ProviderOptions: map[string]string{
+			"endpoint": dbEndpoint,
+		},
+	}
+	dbClient, err := database.NewStore(ctx, dbConfig)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer dbClient.Close()
-	log.Printf("Connected to Spanner: projects/%s/instances/%s/databases/%s", dbProject, dbInstance, dbDatabase)
+	log.Printf("Connected to %s database: %s", dbProvider, dbConfig.Description())
 
 	// --- HTTP handler ---
 	mux := http.NewServeMux()
@@ -52,7 +70,14 @@ func main() {
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprintln(w, "ok")
 	})
-	mux.HandleFunc("/pubsub/push", makePushHandler(dbClient))
+	// Only terminal events become notifications unless CONSUMER_EVENT_TYPES
+	// asks for more, e.g. "job.terminal,job.retrying" or "*".
+	eventTypes := notifier.ParseEventTypes(os.Getenv("CONSUMER_EVENT_TYPES"))
+	if len(eventTypes) == 0 {
+		eventTypes = []string{notifier.EventTypeTerminal}
+	}
+	log.Printf("Storing notifications for event types %v", eventTypes)
+	mux.HandleFunc("/pubsub/push", makePushHandler(dbClient, eventTypes))
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -78,8 +103,10 @@ func main() {
 	_ = srv.Shutdown(shutdownCtx)
 }
 
-// makePushHandler returns the HTTP handler that processes Pub/Sub push deliveries.
-func makePushHandler(db *database.Client) http.HandlerFunc {
+// makePushHandler returns the HTTP handler that processes Pub/Sub push
+// deliveries. Events whose type is not in eventTypes are acknowledged and
+// dropped.
+func makePushHandler(db database.Store, eventTypes []string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -101,13 +128,17 @@ func makePushHandler(db *database.Client) http.HandlerFunc {
 			return
 		}
 
-		var event notifier.JobTerminalEvent
+		var event notifier.JobEvent
 		if err := json.Unmarshal(rawData, &event); err != nil {
-			log.Printf("Failed to unmarshal JobTerminalEvent: %v", err)
+			log.Printf("Failed to unmarshal JobEvent: %v", err)
 			// Ack anyway — malformed messages would loop forever.
 			w.WriteHeader(http.StatusOK)
 			return
 		}
+		if !notifier.MatchesEventType(eventTypes, event.Type()) {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
 
 		if err := saveNotification(r.Context(), db, msg.Message.MessageID, event); err != nil {
 			log.Printf("Failed to save notification for job %s: %v", event.JobID, err)
@@ -116,12 +147,21 @@ func makePushHandler(db *database.Client) http.HandlerFunc {
 			return
 		}
 
-		log.Printf("Saved notification for job %s tenant %s status %s", event.JobID, event.TenantID, event.FinalStatus)
+		log.Printf("Saved %s notification for job %s tenant %s status %s", event.Type(), event.JobID, event.TenantID, eventStatus(event))
 		w.WriteHeader(http.StatusOK)
 	}
 }
 
-func saveNotification(ctx context.Context, db *database.Client, messageID string, event notifier.JobTerminalEvent) error {
+// eventStatus returns the job's status after the event. Events published
+// before event types existed carry final_status only.
+func eventStatus(event notifier.JobEvent) string {
+	if event.Status != "" {
+		return event.Status
+	}
+	return event.FinalStatus
+}
+
+func saveNotification(ctx context.Context, db database.Store, messageID string, event notifier.JobEvent) error {
 	// Use EventID from the payload for deduplication. Fall back to messageID.
 	notifID := event.EventID
 	if notifID == "" {
@@ -140,9 +180,11 @@ func saveNotification(ctx context.Context, db *database.Client, messageID string
 		TenantId:       event.TenantID,
 		NotificationId: notifID,
 		JobId:          event.JobID,
-		FinalStatus:    event.FinalStatus,
+		FinalStatus:    eventStatus(event),
 		OccurredAt:     occurredAt,
 	}
+	eventType := event.Type()
+	n.EventType = &eventType
 	if event.JobName != "" {
 		n.JobName = &event.JobName
 	}
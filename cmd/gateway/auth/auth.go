@@ -0,0 +1,76 @@
+// Package auth verifies the bearer tokens callers present to the gateway and
+// turns them into the identity a tenant is resolved from.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Identity is a user identity vouched for by an identity provider.
+type Identity struct {
+	Provider string // "google" or "github"
+	UserId   string // Stable user ID within the provider
+	Email    string
+}
+
+var (
+	// ErrMissingToken is returned when a request carries no bearer token.
+	ErrMissingToken = errors.New("missing bearer token")
+	// ErrInvalidToken is returned when a token is malformed, expired, or
+	// rejected by its identity provider.
+	ErrInvalidToken = errors.New("invalid bearer token")
+)
+
+// Verifier verifies tokens issued by one identity provider.
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Identity, error)
+}
+
+// Authenticator verifies the bearer token of a request with the verifier of
+// the provider that issued it.
+type Authenticator struct {
+	verifiers map[string]Verifier // Key: provider name
+}
+
+// NewAuthenticator creates an Authenticator for the given providers.
+func NewAuthenticator(verifiers map[string]Verifier) *Authenticator {
+	return &Authenticator{verifiers: verifiers}
+}
+
+// Authenticate verifies the "Authorization: Bearer" token in header. The
+// provider is taken from the X-OAuth-Provider header; without it, JWTs are
+// taken to be Google ID tokens and anything else a GitHub access token.
+func (a *Authenticator) Authenticate(ctx context.Context, header http.Header) (*Identity, error) {
+	token := BearerToken(header)
+	if token == "" {
+		return nil, ErrMissingToken
+	}
+
+	provider := header.Get("X-OAuth-Provider")
+	if provider == "" {
+		provider = "github"
+		if strings.Count(token, ".") == 2 {
+			provider = "google"
+		}
+	}
+
+	verifier, ok := a.verifiers[provider]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported provider %q", ErrInvalidToken, provider)
+	}
+	return verifier.Verify(ctx, token)
+}
+
+// BearerToken returns the token of an "Authorization: Bearer" header, or ""
+// if there is none.
+func BearerToken(header http.Header) string {
+	scheme, token, ok := strings.Cut(header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
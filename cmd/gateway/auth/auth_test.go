@@ -0,0 +1,320 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// jwksServer serves the public halves of its signing keys as a JWKS.
+type jwksServer struct {
+	*httptest.Server
+	keys    map[string]*rsa.PrivateKey
+	fetches atomic.Int32
+}
+
+func newJWKSServer(t *testing.T, kids ...string) *jwksServer {
+	t.Helper()
+	s := &jwksServer{keys: map[string]*rsa.PrivateKey{}}
+	for _, kid := range kids {
+		s.addKey(t, kid)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+		var set struct {
+			Keys []map[string]string `json:"keys"`
+		}
+		for kid, key := range s.keys {
+			set.Keys = append(set.Keys, map[string]string{
+				"kty": "RSA",
+				"kid": kid,
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *jwksServer) addKey(t *testing.T, kid string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	s.keys[kid] = key
+}
+
+// sign returns an RS256 JWT over claims, signed with the key kid.
+func (s *jwksServer) sign(t *testing.T, kid string, claims map[string]any) string {
+	t.Helper()
+	segment := func(v any) string {
+		raw, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(raw)
+	}
+	signed := segment(map[string]string{"alg": "RS256", "kid": kid, "typ": "JWT"}) + "." + segment(claims)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.keys[kid], crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("SignPKCS1v15: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func googleTestClaims() map[string]any {
+	now := time.Now()
+	return map[string]any{
+		"iss":            "https://accounts.google.com",
+		"aud":            "jennah-ui",
+		"sub":            "1234567890",
+		"email":          "dev@example.com",
+		"email_verified": true,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	}
+}
+
+func TestGoogleVerifierAcceptsValidToken(t *testing.T) {
+	jwks := newJWKSServer(t, "key-1")
+	v := NewGoogleVerifier(NewKeySet(jwks.URL, nil), []string{"jennah-ui"})
+
+	identity, err := v.Verify(context.Background(), jwks.sign(t, "key-1", googleTestClaims()))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if *identity != (Identity{Provider: "google", UserId: "1234567890", Email: "dev@example.com"}) {
+		t.Errorf("identity = %+v", identity)
+	}
+
+	// Keys are cached between verifications.
+	if _, err := v.Verify(context.Background(), jwks.sign(t, "key-1", googleTestClaims())); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if n := jwks.fetches.Load(); n != 1 {
+		t.Errorf("JWKS fetched %d times, want 1", n)
+	}
+}
+
+func TestGoogleVerifierRejectsBadTokens(t *testing.T) {
+	jwks := newJWKSServer(t, "key-1")
+	other := newJWKSServer(t, "key-1", "key-9")
+	v := NewGoogleVerifier(NewKeySet(jwks.URL, nil), []string{"jennah-ui"})
+
+	with := func(name string, value any) map[string]any {
+		claims := googleTestClaims()
+		claims[name] = value
+		return claims
+	}
+	tests := map[string]string{
+		"not a jwt":           "opaque-token",
+		"foreign signature":   other.sign(t, "key-1", googleTestClaims()),
+		"wrong audience":      jwks.sign(t, "key-1", with("aud", "someone-else")),
+		"wrong issuer":        jwks.sign(t, "key-1", with("iss", "https://evil.example.com")),
+		"expired":             jwks.sign(t, "key-1", with("exp", time.Now().Add(-time.Hour).Unix())),
+		"unverified email":    jwks.sign(t, "key-1", with("email_verified", false)),
+		"unknown signing key": other.sign(t, "key-9", googleTestClaims()),
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := v.Verify(context.Background(), token); !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("Verify = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestKeySetRefetchesOnKeyRotation(t *testing.T) {
+	jwks := newJWKSServer(t, "key-1")
+	keys := NewKeySet(jwks.URL, nil)
+	clock := time.Now()
+	keys.now = func() time.Time { return clock }
+	v := NewGoogleVerifier(keys, []string{"jennah-ui"})
+	if _, err := v.Verify(context.Background(), jwks.sign(t, "key-1", googleTestClaims())); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	jwks.addKey(t, "key-2")
+	clock = clock.Add(time.Minute)
+	if _, err := v.Verify(context.Background(), jwks.sign(t, "key-2", googleTestClaims())); err != nil {
+		t.Fatalf("Verify with rotated key: %v", err)
+	}
+	if n := jwks.fetches.Load(); n != 2 {
+		t.Errorf("JWKS fetched %d times, want 2", n)
+	}
+
+	// Unknown key IDs right after a refresh do not trigger another fetch.
+	other := newJWKSServer(t, "key-3")
+	if _, err := v.Verify(context.Background(), other.sign(t, "key-3", googleTestClaims())); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify with unknown key = %v, want ErrInvalidToken", err)
+	}
+	if n := jwks.fetches.Load(); n != 2 {
+		t.Errorf("JWKS fetched %d times, want 2", n)
+	}
+}
+
+func TestKeySetServesCachedKeysDuringFetch(t *testing.T) {
+	jwks := newJWKSServer(t, "key-1")
+	fetching, release := make(chan struct{}), make(chan struct{})
+	var fetches atomic.Int32
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fetches.Add(1) > 1 {
+			close(fetching)
+			<-release
+		}
+		jwks.Config.Handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(slow.Close)
+	var unblock sync.Once
+	t.Cleanup(func() { unblock.Do(func() { close(release) }) })
+
+	keys := NewKeySet(slow.URL, nil)
+	clock := time.Now()
+	keys.now = func() time.Time { return clock }
+	if _, err := keys.Key(context.Background(), "key-1"); err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+
+	// Once the cache expires, one lookup refetches and hangs on the server.
+	clock = clock.Add(2 * time.Hour)
+	refreshed := make(chan error, 1)
+	go func() {
+		_, err := keys.Key(context.Background(), "key-1")
+		refreshed <- err
+	}()
+	<-fetching
+
+	cached := make(chan error, 1)
+	go func() {
+		_, err := keys.Key(context.Background(), "key-1")
+		cached <- err
+	}()
+	select {
+	case err := <-cached:
+		if err != nil {
+			t.Errorf("Key of a cached key during a fetch: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Key of a cached key blocked on the fetch")
+	}
+
+	// An unknown key waits for the fetch, up to its context.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := keys.Key(ctx, "key-2"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Key of an unknown key during a fetch = %v, want DeadlineExceeded", err)
+	}
+
+	unblock.Do(func() { close(release) })
+	if err := <-refreshed; err != nil {
+		t.Errorf("refetching Key: %v", err)
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("JWKS fetched %d times, want 2", n)
+	}
+}
+
+func newGitHubServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	authorized := func(w http.ResponseWriter, r *http.Request) bool {
+		calls.Add(1)
+		if r.Header.Get("Authorization") != "Bearer gho_valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return false
+		}
+		return true
+	}
+	mux.HandleFunc("GET /user", func(w http.ResponseWriter, r *http.Request) {
+		if authorized(w, r) {
+			json.NewEncoder(w).Encode(map[string]any{"id": 42, "login": "octocat"})
+		}
+	})
+	mux.HandleFunc("GET /user/emails", func(w http.ResponseWriter, r *http.Request) {
+		if authorized(w, r) {
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"email": "old@example.com", "primary": false, "verified": true},
+				{"email": "octocat@example.com", "primary": true, "verified": true},
+			})
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGitHubVerifier(t *testing.T) {
+	var calls atomic.Int32
+	server := newGitHubServer(t, &calls)
+	v := NewGitHubVerifier(server.URL+"/user", nil)
+
+	identity, err := v.Verify(context.Background(), "gho_valid")
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if *identity != (Identity{Provider: "github", UserId: "octocat", Email: "octocat@example.com"}) {
+		t.Errorf("identity = %+v", identity)
+	}
+
+	// Verified tokens are cached.
+	if _, err := v.Verify(context.Background(), "gho_valid"); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("GitHub called %d times, want 2 (user and emails once)", n)
+	}
+
+	if _, err := v.Verify(context.Background(), "gho_revoked"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify revoked token = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestAuthenticatorSelectsVerifier(t *testing.T) {
+	var calls atomic.Int32
+	github := newGitHubServer(t, &calls)
+	jwks := newJWKSServer(t, "key-1")
+	a := NewAuthenticator(map[string]Verifier{
+		"github": NewGitHubVerifier(github.URL+"/user", nil),
+		"google": NewGoogleVerifier(NewKeySet(jwks.URL, nil), []string{"jennah-ui"}),
+	})
+
+	header := http.Header{}
+	if _, err := a.Authenticate(context.Background(), header); !errors.Is(err, ErrMissingToken) {
+		t.Errorf("Authenticate without token = %v, want ErrMissingToken", err)
+	}
+
+	header.Set("Authorization", "Bearer "+jwks.sign(t, "key-1", googleTestClaims()))
+	if identity, err := a.Authenticate(context.Background(), header); err != nil || identity.Provider != "google" {
+		t.Errorf("Authenticate JWT = %+v, %v", identity, err)
+	}
+
+	header.Set("Authorization", "Bearer gho_valid")
+	if identity, err := a.Authenticate(context.Background(), header); err != nil || identity.Provider != "github" {
+		t.Errorf("Authenticate GitHub token = %+v, %v", identity, err)
+	}
+
+	// Identity headers next to the token are ignored.
+	header.Set("X-OAuth-UserId", "someone-else")
+	if identity, err := a.Authenticate(context.Background(), header); err != nil || identity.UserId != "octocat" {
+		t.Errorf("Authenticate = %+v, %v", identity, err)
+	}
+
+	header.Set("X-OAuth-Provider", "gitlab")
+	if _, err := a.Authenticate(context.Background(), header); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Authenticate with unknown provider = %v, want ErrInvalidToken", err)
+	}
+}
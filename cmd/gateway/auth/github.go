@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultGitHubUserURL is the GitHub API endpoint describing a token's user.
+const DefaultGitHubUserURL = "https://api.github.com/user"
+
+// githubTokenTTL is how long a verified GitHub token is trusted before the
+// user endpoint is asked again. GitHub access tokens are opaque, so every
+// verification is an API call; caching keeps callers clear of rate limits.
+const githubTokenTTL = 5 * time.Minute
+
+// GitHubVerifier verifies GitHub access tokens by asking GitHub (or a
+// GitHub Enterprise server) which user they belong to.
+type GitHubVerifier struct {
+	userURL string
+	client  *http.Client
+	now     func() time.Time
+
+	mu       sync.Mutex
+	verified map[[sha256.Size]byte]cachedIdentity // Key: token hash
+}
+
+type cachedIdentity struct {
+	identity *Identity
+	expires  time.Time
+}
+
+// NewGitHubVerifier creates a verifier that looks tokens up at userURL.
+func NewGitHubVerifier(userURL string, client *http.Client) *GitHubVerifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &GitHubVerifier{
+		userURL:  userURL,
+		client:   client,
+		now:      time.Now,
+		verified: make(map[[sha256.Size]byte]cachedIdentity),
+	}
+}
+
+// Verify returns the identity of the user the token belongs to. The user ID
+// is the GitHub login, which is what tenants created through the CLI were
+// registered with.
+func (v *GitHubVerifier) Verify(ctx context.Context, token string) (*Identity, error) {
+	key := sha256.Sum256([]byte(token))
+	now := v.now()
+
+	v.mu.Lock()
+	cached, ok := v.verified[key]
+	v.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.identity, nil
+	}
+
+	var user struct {
+		Login string `json:"login"`
+		Email string `json:"email"` // Empty if the user keeps it private
+	}
+	if err := v.get(ctx, v.userURL, token, &user); err != nil {
+		return nil, err
+	}
+	if user.Login == "" {
+		return nil, fmt.Errorf("%w: GitHub user has no login", ErrInvalidToken)
+	}
+
+	email := user.Email
+	if email == "" {
+		var emails []struct {
+			Email    string `json:"email"`
+			Primary  bool   `json:"primary"`
+			Verified bool   `json:"verified"`
+		}
+		if err := v.get(ctx, v.userURL+"/emails", token, &emails); err != nil {
+			return nil, err
+		}
+		for _, e := range emails {
+			if e.Primary && e.Verified {
+				email = e.Email
+				break
+			}
+		}
+		if email == "" {
+			return nil, fmt.Errorf("%w: GitHub account has no primary verified email", ErrInvalidToken)
+		}
+	}
+
+	identity := &Identity{Provider: "github", UserId: user.Login, Email: email}
+	v.mu.Lock()
+	for k, c := range v.verified {
+		if now.After(c.expires) {
+			delete(v.verified, k)
+		}
+	}
+	v.verified[key] = cachedIdentity{identity: identity, expires: now.Add(githubTokenTTL)}
+	v.mu.Unlock()
+	return identity, nil
+}
+
+func (v *GitHubVerifier) get(ctx context.Context, url, token string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach GitHub: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return fmt.Errorf("%w: rejected by GitHub", ErrInvalidToken)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("GitHub %s returned %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode GitHub response: %w", err)
+	}
+	return nil
+}
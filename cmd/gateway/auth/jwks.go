@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultKeySetTTL is how long keys are cached when the JWKS response
+	// carries no Cache-Control max-age.
+	defaultKeySetTTL = time.Hour
+	// minKeySetRefresh rate-limits refetches triggered by unknown key IDs,
+	// so tokens with made-up key IDs cannot hammer the JWKS endpoint.
+	minKeySetRefresh = 30 * time.Second
+)
+
+// KeySet is a cached JSON Web Key Set of RSA signing keys.
+type KeySet struct {
+	url    string
+	client *http.Client
+	now    func() time.Time
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey // Key: key ID
+	expires   time.Time
+	lastFetch time.Time
+	fetch     *keySetFetch // nil: no fetch in progress
+}
+
+// keySetFetch is a JWKS fetch in progress. done is closed once it finishes
+// and err is set.
+type keySetFetch struct {
+	done chan struct{}
+	err  error
+}
+
+// NewKeySet creates a KeySet that fetches keys from url.
+func NewKeySet(url string, client *http.Client) *KeySet {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &KeySet{url: url, client: client, now: time.Now}
+}
+
+// Key returns the key with the given ID, refetching the key set when the
+// cache has expired or the key is unknown (keys are rotated regularly). Only
+// one lookup fetches at a time, outside the lock: meanwhile cached keys are
+// still served, even expired ones, and unknown keys wait for the fetch.
+func (s *KeySet) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	now := s.now()
+	key, ok := s.keys[kid]
+	if ok && now.Before(s.expires) {
+		s.mu.Unlock()
+		return key, nil
+	}
+	if now.Before(s.expires) && now.Sub(s.lastFetch) < minKeySetRefresh {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+	}
+
+	if fetch := s.fetch; fetch != nil {
+		s.mu.Unlock()
+		if ok {
+			return key, nil
+		}
+		select {
+		case <-fetch.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if fetch.err != nil {
+			return nil, fetch.err
+		}
+	} else {
+		fetch = &keySetFetch{done: make(chan struct{})}
+		s.fetch = fetch
+		s.mu.Unlock()
+
+		keys, ttl, err := s.fetchKeys(ctx)
+		s.mu.Lock()
+		if err == nil {
+			s.keys = keys
+			s.lastFetch = now
+			s.expires = now.Add(ttl)
+		}
+		s.fetch = nil
+		s.mu.Unlock()
+		fetch.err = err
+		close(fetch.done)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	key, ok = s.keys[kid]
+	s.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+	}
+	return key, nil
+}
+
+// fetchKeys fetches the key set and how long it may be cached.
+func (s *KeySet) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+
+	return keys, maxAge(resp.Header.Get("Cache-Control")), nil
+}
+
+// maxAge returns the max-age directive of a Cache-Control header.
+func maxAge(cacheControl string) time.Duration {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		if strings.EqualFold(name, "max-age") {
+			if secs, err := strconv.Atoi(value); err == nil && secs > 0 {
+				return time.Duration(secs) * time.Second
+			}
+		}
+	}
+	return defaultKeySetTTL
+}
@@ -13,20 +13,36 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/alphauslabs/jennah/cmd/gateway/auth"
 	"github.com/alphauslabs/jennah/cmd/gateway/middleware"
 	"github.com/alphauslabs/jennah/cmd/gateway/service"
 	jennahv1connect "github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	"github.com/alphauslabs/jennah/internal/config"
 	"github.com/alphauslabs/jennah/internal/database"
 	"github.com/alphauslabs/jennah/internal/hashing"
+	"github.com/alphauslabs/jennah/internal/quota"
 )
 
 var (
 	port           string
 	workerIPs      string
+	dbProvider     string
 	dbProjectID    string
 	dbInstance     string
 	dbDatabase     string
+	dbEndpoint     string
 	allowedOrigins string
+
+	googleClientIDs   string
+	googleJWKSURL     string
+	githubUserURL     string
+	trustOAuthHeaders bool
+
+	idempotencyKeyTTL string
+
+	workerRegistry        bool
+	workerRegistryRefresh string
+	workerHeartbeatTTL    string
 )
 
 var serveCmd = &cobra.Command{
@@ -39,27 +55,78 @@ var serveCmd = &cobra.Command{
 func init() {
 	serveCmd.Flags().StringVar(&port, "port", "8080", "Port to listen on")
 	serveCmd.Flags().StringVar(&workerIPs, "worker-ips", "10.146.0.26,10.146.0.44,10.146.0.81", "Comma-separated list of worker IPs")
+	serveCmd.Flags().StringVar(&dbProvider, "db-provider", "spanner", "Database provider (spanner, postgres, memory)")
 	serveCmd.Flags().StringVar(&dbProjectID, "db-project-id", "labs-169405", "Database project ID (GCP project for Spanner)")
 	serveCmd.Flags().StringVar(&dbInstance, "db-instance", "alphaus-dev", "Database instance (Spanner instance name)")
 	serveCmd.Flags().StringVar(&dbDatabase, "db-database", "main", "Database name")
+	serveCmd.Flags().StringVar(&dbEndpoint, "db-endpoint", os.Getenv("DB_ENDPOINT"), "Database endpoint (PostgreSQL host:port or postgres:// URL)")
 	defaultOrigins := os.Getenv("ALLOWED_ORIGINS")
 	if defaultOrigins == "" {
 		// Include both production and localhost by default
 		defaultOrigins = "https://jennah-ui-382915581671.asia-northeast1.run.app,http://localhost:5173"
 	}
 	serveCmd.Flags().StringVar(&allowedOrigins, "allowed-origins", defaultOrigins, "Comma-separated list of allowed CORS origins")
+
+	serveCmd.Flags().StringVar(&googleClientIDs, "google-client-ids", os.Getenv("GOOGLE_OAUTH_CLIENT_IDS"), "Comma-separated OAuth client IDs accepted as Google ID token audiences")
+	serveCmd.Flags().StringVar(&googleJWKSURL, "google-jwks-url", envOrDefault("GOOGLE_JWKS_URL", auth.DefaultGoogleJWKSURL), "JWKS endpoint for Google ID token signing keys")
+	serveCmd.Flags().StringVar(&githubUserURL, "github-user-url", envOrDefault("GITHUB_USER_URL", auth.DefaultGitHubUserURL), "GitHub API endpoint used to verify GitHub access tokens")
+	serveCmd.Flags().StringVar(&idempotencyKeyTTL, "idempotency-key-ttl", envOrDefault("IDEMPOTENCY_KEY_TTL", service.DefaultIdempotencyKeyTTL.String()), "How long a SubmitJob idempotency key is honoured (e.g. 24h)")
+	serveCmd.Flags().BoolVar(&workerRegistry, "worker-registry", os.Getenv("WORKER_REGISTRY") == "true", "Route to the workers heartbeating into the Workers table instead of --worker-ips")
+	serveCmd.Flags().StringVar(&workerRegistryRefresh, "worker-registry-refresh", envOrDefault("WORKER_REGISTRY_REFRESH", "10s"), "How often the worker ring is rebuilt from the Workers table")
+	serveCmd.Flags().StringVar(&workerHeartbeatTTL, "worker-heartbeat-ttl", envOrDefault("WORKER_HEARTBEAT_TTL", "30s"), "How old a worker's last heartbeat may be before it leaves the ring")
+	serveCmd.Flags().BoolVar(&trustOAuthHeaders, "trust-oauth-headers", os.Getenv("TRUST_OAUTH_HEADERS") == "true", "Trust unverified X-OAuth-* headers instead of bearer tokens (local development only)")
+}
+
+func envOrDefault(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// newAuthenticator builds the bearer token authenticator from the auth flags.
+// Google ID tokens are only accepted when client IDs are configured.
+func newAuthenticator() *auth.Authenticator {
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	verifiers := map[string]auth.Verifier{
+		"github": auth.NewGitHubVerifier(githubUserURL, httpClient),
+	}
+
+	var audiences []string
+	for _, id := range strings.Split(googleClientIDs, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			audiences = append(audiences, id)
+		}
+	}
+	if len(audiences) > 0 {
+		verifiers["google"] = auth.NewGoogleVerifier(auth.NewKeySet(googleJWKSURL, httpClient), audiences)
+		log.Printf("Google ID tokens accepted for client IDs: %v", audiences)
+	} else {
+		log.Println("WARNING: no Google OAuth client IDs configured (set GOOGLE_OAUTH_CLIENT_IDS) — Google ID tokens will be rejected")
+	}
+	log.Printf("GitHub access tokens verified against %s", githubUserURL)
+	return auth.NewAuthenticator(verifiers)
 }
 
 func runServe(cmd *cobra.Command, args []string) error {
 	log.Printf("Starting gateway")
 
 	ctx := context.Background()
-	dbClient, err := database.NewClient(ctx, dbProjectID, dbInstance, dbDatabase)
+	dbConfig := config.DatabaseConfig{
+		Provider:  dbProvider,
+		ProjectID: dbProjectID,
+		Instance:  dbInstance,
+		Database:  dbDatabase,
+		ProviderOptions: map[string]string{
+			"endpoint": dbEndpoint,
+		},
+	}
+	dbClient, err := database.NewStore(ctx, dbConfig)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database client: %w", err)
 	}
 	defer dbClient.Close()
-	log.Printf("Connected to database: %s/%s/%s", dbProjectID, dbInstance, dbDatabase)
+	log.Printf("Connected to %s database: %s", dbProvider, dbConfig.Description())
 
 	workers := strings.Split(workerIPs, ",")
 	for i, ip := range workers {
@@ -71,22 +138,59 @@ func runServe(cmd *cobra.Command, args []string) error {
 	log.Printf("Initialized consistent hashing router with workers: %v", workers)
 
 	workerClients := make(map[string]jennahv1connect.DeploymentServiceClient)
-	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
-	}
+	// Bound the wait for a worker's response headers rather than the whole
+	// call, so StreamJobLogs can keep following a job for as long as it runs.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = 30 * time.Second
+	httpClient := &http.Client{Transport: transport}
 	for _, workerIP := range workers {
-		workerURL := fmt.Sprintf("http://%s:8081", workerIP)
-		workerClients[workerIP] = jennahv1connect.NewDeploymentServiceClient(httpClient, workerURL)
-		log.Printf("Created client for worker at %s", workerURL)
+		workerClients[workerIP] = service.NewWorkerClient(httpClient, workerIP)
+		log.Printf("Created client for worker at %s", workerIP)
+	}
+
+	var authenticator *auth.Authenticator
+	if trustOAuthHeaders {
+		log.Println("WARNING: trusting unverified X-OAuth-* headers — never enable this outside local development")
+	} else {
+		authenticator = newAuthenticator()
 	}
 
+	quotas, err := newQuotaChecker(dbClient)
+	if err != nil {
+		return err
+	}
+
+	keyTTL, err := time.ParseDuration(idempotencyKeyTTL)
+	if err != nil || keyTTL <= 0 {
+		return fmt.Errorf("invalid idempotency key TTL %q: must be a positive duration", idempotencyKeyTTL)
+	}
+	log.Printf("SubmitJob idempotency keys are honoured for %s", keyTTL)
+
 	gatewayService := service.NewGatewayService(
 		router,
 		workerClients,
 		dbClient,
 		os.Getenv("DEFAULT_DWP_IMAGE_URI"),
+		authenticator,
+		quotas,
+		keyTTL,
 	)
 
+	if workerRegistry {
+		refresh, err := time.ParseDuration(workerRegistryRefresh)
+		if err != nil || refresh <= 0 {
+			return fmt.Errorf("invalid worker registry refresh %q: must be a positive duration", workerRegistryRefresh)
+		}
+		ttl, err := time.ParseDuration(workerHeartbeatTTL)
+		if err != nil || ttl <= 0 {
+			return fmt.Errorf("invalid worker heartbeat TTL %q: must be a positive duration", workerHeartbeatTTL)
+		}
+		if err := gatewayService.StartWorkerRegistry(ctx, httpClient, refresh, ttl); err != nil {
+			return fmt.Errorf("failed to load the worker registry: %w", err)
+		}
+		log.Printf("Routing to registered workers (refresh every %s, heartbeat TTL %s); --worker-ips is the fallback until one registers", refresh, ttl)
+	}
+
 	origins := strings.Split(allowedOrigins, ",")
 	for i, origin := range origins {
 		origins[i] = strings.TrimSpace(origin)
@@ -149,3 +253,29 @@ func runServe(cmd *cobra.Command, args []string) error {
 	log.Println("Gateway stopped")
 	return nil
 }
+
+// newQuotaChecker builds the admission checker from the QUOTA_* environment
+// variables. Jobs are sized with the worker's job config when JOB_CONFIG_PATH
+// (default config/job-config.json) is readable, else with the built-in
+// resource profiles.
+func newQuotaChecker(store database.Store) (*quota.Checker, error) {
+	limits, err := quota.LimitsFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("invalid quota configuration: %w", err)
+	}
+
+	jobConfigPath := os.Getenv("JOB_CONFIG_PATH")
+	if jobConfigPath == "" {
+		jobConfigPath = "config/job-config.json"
+	}
+	jobConfig, err := config.LoadJobConfig(jobConfigPath)
+	if err != nil {
+		log.Printf("Quota checks use the built-in resource profiles: %v", err)
+		jobConfig = nil
+	}
+
+	log.Printf("Quota defaults: concurrentJobs=%d, cpuMillis=%d, memoryMib=%d, jobsPerDay=%d, machineTypes=%v, spot=%t, queueWhenBusy=%t",
+		limits.MaxConcurrentJobs, limits.MaxCpuMillis, limits.MaxMemoryMib, limits.MaxJobsPerDay,
+		limits.AllowedMachineTypes, limits.AllowSpotVms, limits.QueueWhenBusy)
+	return quota.NewChecker(store, limits, jobConfig), nil
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+const (
+	// apiKeyPrefix marks bearer tokens that are Jennah API keys rather than
+	// OAuth tokens.
+	apiKeyPrefix = "jennah_"
+	// apiKeyDisplayLen is how much of a key is stored in the clear to tell
+	// keys apart in listings.
+	apiKeyDisplayLen = len(apiKeyPrefix) + 6
+)
+
+// isApiKey reports whether a bearer token is a Jennah API key.
+func isApiKey(token string) bool {
+	return strings.HasPrefix(token, apiKeyPrefix)
+}
+
+// generateApiKey returns a new random API key.
+func generateApiKey() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashApiKey returns the hex SHA-256 an API key is stored and looked up by.
+func hashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeApiKeyScopes validates requested scopes and returns them
+// lower-cased and deduplicated, in the order of database.ApiKeyScopes.
+func normalizeApiKeyScopes(scopes []string) ([]string, error) {
+	requested := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !slices.Contains(database.ApiKeyScopes, scope) {
+			return nil, fmt.Errorf("invalid scope %q: want submit, read, cancel or admin", scope)
+		}
+		requested[scope] = true
+	}
+	if len(requested) == 0 {
+		return nil, errors.New("at least one scope is required")
+	}
+
+	var normalized []string
+	for _, scope := range database.ApiKeyScopes {
+		if requested[scope] {
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
+
+// resolveApiKey returns a valid, unexpired and unrevoked API key.
+func (s *GatewayService) resolveApiKey(ctx context.Context, key string) (*database.ApiKey, error) {
+	apiKey, err := s.dbClient.GetApiKeyByHash(ctx, hashApiKey(key))
+	if err != nil {
+		log.Printf("Failed to look up API key: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to verify API key"))
+	}
+
+	switch {
+	case apiKey == nil:
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid API key"))
+	case apiKey.RevokedAt != nil:
+		log.Printf("Rejected revoked API key %s of tenant %s", apiKey.ApiKeyId, apiKey.TenantId)
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("API key has been revoked"))
+	case apiKey.ExpiresAt != nil && time.Now().After(*apiKey.ExpiresAt):
+		log.Printf("Rejected expired API key %s of tenant %s", apiKey.ApiKeyId, apiKey.TenantId)
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("API key has expired"))
+	}
+	return apiKey, nil
+}
+
+func dbApiKeyToProto(k *database.ApiKey) *jennahv1.ApiKey {
+	p := &jennahv1.ApiKey{
+		ApiKeyId:  k.ApiKeyId,
+		TenantId:  k.TenantId,
+		KeyPrefix: k.KeyPrefix,
+		Scopes:    k.Scopes,
+		CreatedAt: k.CreatedAt.Format(time.RFC3339),
+	}
+	if k.Name != nil {
+		p.Name = *k.Name
+	}
+	if k.ExpiresAt != nil {
+		p.ExpiresAt = k.ExpiresAt.Format(time.RFC3339)
+	}
+	if k.RevokedAt != nil {
+		p.RevokedAt = k.RevokedAt.Format(time.RFC3339)
+	}
+	return p
+}
+
+func (s *GatewayService) CreateApiKey(
+	ctx context.Context,
+	req *connect.Request[jennahv1.CreateApiKeyRequest],
+) (*connect.Response[jennahv1.CreateApiKeyResponse], error) {
+	log.Printf("Received create API key request")
+
+	scopes, err := normalizeApiKeyScopes(req.Msg.Scopes)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if req.Msg.TtlSeconds < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("ttl_seconds must not be negative"))
+	}
+
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := generateApiKey()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate API key: %w", err))
+	}
+	var name *string
+	if n := strings.TrimSpace(req.Msg.Name); n != "" {
+		name = &n
+	}
+	var expiresAt *time.Time
+	if req.Msg.TtlSeconds > 0 {
+		t := time.Now().UTC().Add(time.Duration(req.Msg.TtlSeconds) * time.Second)
+		expiresAt = &t
+	}
+	apiKey := &database.ApiKey{
+		TenantId:  tenantId,
+		ApiKeyId:  uuid.NewString(),
+		Name:      name,
+		KeyPrefix: key[:apiKeyDisplayLen],
+		KeyHash:   hashApiKey(key),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.dbClient.InsertApiKey(ctx, apiKey); err != nil {
+		log.Printf("Failed to insert API key for tenant %s: %v", tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create API key: %w", err))
+	}
+
+	created, err := s.getApiKey(ctx, tenantId, apiKey.ApiKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("API key created: apiKeyId=%s, tenantId=%s, scopes=%v", created.ApiKeyId, tenantId, scopes)
+	return connect.NewResponse(&jennahv1.CreateApiKeyResponse{ApiKey: dbApiKeyToProto(created), Key: key}), nil
+}
+
+func (s *GatewayService) ListApiKeys(
+	ctx context.Context,
+	req *connect.Request[jennahv1.ListApiKeysRequest],
+) (*connect.Response[jennahv1.ListApiKeysResponse], error) {
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.dbClient.ListApiKeys(ctx, tenantId)
+	if err != nil {
+		log.Printf("Failed to list API keys for tenant %s: %v", tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list API keys: %w", err))
+	}
+
+	protoKeys := make([]*jennahv1.ApiKey, 0, len(keys))
+	for _, k := range keys {
+		protoKeys = append(protoKeys, dbApiKeyToProto(k))
+	}
+	return connect.NewResponse(&jennahv1.ListApiKeysResponse{ApiKeys: protoKeys}), nil
+}
+
+func (s *GatewayService) RevokeApiKey(
+	ctx context.Context,
+	req *connect.Request[jennahv1.RevokeApiKeyRequest],
+) (*connect.Response[jennahv1.RevokeApiKeyResponse], error) {
+	if req.Msg.ApiKeyId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("api_key_id is required"))
+	}
+
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := s.getApiKey(ctx, tenantId, req.Msg.ApiKeyId)
+	if err != nil {
+		return nil, err
+	}
+	// Revoking twice keeps the original revocation time.
+	if apiKey.RevokedAt == nil {
+		if err := s.dbClient.RevokeApiKey(ctx, tenantId, apiKey.ApiKeyId); err != nil {
+			log.Printf("Failed to revoke API key %s for tenant %s: %v", apiKey.ApiKeyId, tenantId, err)
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to revoke API key: %w", err))
+		}
+		if apiKey, err = s.getApiKey(ctx, tenantId, apiKey.ApiKeyId); err != nil {
+			return nil, err
+		}
+		log.Printf("API key revoked: apiKeyId=%s, tenantId=%s", apiKey.ApiKeyId, tenantId)
+	}
+
+	return connect.NewResponse(&jennahv1.RevokeApiKeyResponse{ApiKey: dbApiKeyToProto(apiKey)}), nil
+}
+
+// getApiKey loads a tenant's API key, mapping a missing row to NotFound.
+func (s *GatewayService) getApiKey(ctx context.Context, tenantId, apiKeyId string) (*database.ApiKey, error) {
+	apiKey, err := s.dbClient.GetApiKey(ctx, tenantId, apiKeyId)
+	if err != nil {
+		if spanner.ErrCode(err) == codes.NotFound {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("API key not found: %s", apiKeyId))
+		}
+		log.Printf("Failed to get API key %s for tenant %s: %v", apiKeyId, tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get API key: %w", err))
+	}
+	return apiKey, nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+func withApiKey[T any](msg *T, key string) *connect.Request[T] {
+	req := connect.NewRequest(msg)
+	req.Header().Set("Authorization", "Bearer "+key)
+	return req
+}
+
+func TestNormalizeApiKeyScopes(t *testing.T) {
+	got, err := normalizeApiKeyScopes([]string{" Read", "submit", "read"})
+	if err != nil || strings.Join(got, ",") != "submit,read" {
+		t.Errorf("normalizeApiKeyScopes = %v, %v; want [submit read]", got, err)
+	}
+	if _, err := normalizeApiKeyScopes(nil); err == nil {
+		t.Error("expected an error without scopes")
+	}
+	if _, err := normalizeApiKeyScopes([]string{"write"}); err == nil {
+		t.Error("expected an error for an unknown scope")
+	}
+}
+
+func TestGatewayApiKeyLifecycle(t *testing.T) {
+	ctx := context.Background()
+	gw, store := newTestGateway(t)
+
+	created, err := gw.CreateApiKey(ctx, withOAuth(&jennahv1.CreateApiKeyRequest{
+		Name:       "ci",
+		Scopes:     []string{"submit", "read"},
+		TtlSeconds: 3600,
+	}))
+	if err != nil {
+		t.Fatalf("CreateApiKey: %v", err)
+	}
+	key := created.Msg.Key
+	apiKey := created.Msg.ApiKey
+	if !strings.HasPrefix(key, apiKeyPrefix) || !strings.HasPrefix(key, apiKey.KeyPrefix) || apiKey.ExpiresAt == "" {
+		t.Fatalf("unexpected key %q / %+v", key, apiKey)
+	}
+
+	// Only the hash is stored.
+	stored, err := store.GetApiKey(ctx, apiKey.TenantId, apiKey.ApiKeyId)
+	if err != nil || stored.KeyHash != hashApiKey(key) || strings.Contains(stored.KeyHash, key) {
+		t.Fatalf("stored key = %+v, %v", stored, err)
+	}
+
+	// The key resolves to the tenant that created it.
+	tenant, err := gw.GetCurrentTenant(ctx, withApiKey(&jennahv1.GetCurrentTenantRequest{}, key))
+	if err != nil || tenant.Msg.TenantId != apiKey.TenantId {
+		t.Fatalf("GetCurrentTenant with API key = %+v, %v", tenant, err)
+	}
+
+	// Scopes are enforced.
+	_, err = gw.CancelJob(ctx, withApiKey(&jennahv1.CancelJobRequest{JobId: "job-1"}, key))
+	if connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("CancelJob without the cancel scope: got %v, want PermissionDenied", err)
+	}
+	_, err = gw.ListApiKeys(ctx, withApiKey(&jennahv1.ListApiKeysRequest{}, key))
+	if connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("ListApiKeys without the admin scope: got %v, want PermissionDenied", err)
+	}
+
+	revoked, err := gw.RevokeApiKey(ctx, withOAuth(&jennahv1.RevokeApiKeyRequest{ApiKeyId: apiKey.ApiKeyId}))
+	if err != nil || revoked.Msg.ApiKey.RevokedAt == "" {
+		t.Fatalf("RevokeApiKey: %+v, %v", revoked, err)
+	}
+	_, err = gw.ListJobs(ctx, withApiKey(&jennahv1.ListJobsRequest{}, key))
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("ListJobs with a revoked key: got %v, want Unauthenticated", err)
+	}
+
+	list, err := gw.ListApiKeys(ctx, withOAuth(&jennahv1.ListApiKeysRequest{}))
+	if err != nil || len(list.Msg.ApiKeys) != 1 || list.Msg.ApiKeys[0].RevokedAt == "" {
+		t.Fatalf("ListApiKeys: %+v, %v", list, err)
+	}
+
+	_, err = gw.RevokeApiKey(ctx, withOAuth(&jennahv1.RevokeApiKeyRequest{ApiKeyId: "missing"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("RevokeApiKey on a missing key: got %v, want NotFound", err)
+	}
+}
+
+func TestGatewayRejectsUnknownAndExpiredApiKeys(t *testing.T) {
+	ctx := context.Background()
+	gw, store := newTestGateway(t)
+
+	_, err := gw.ListJobs(ctx, withApiKey(&jennahv1.ListJobsRequest{}, apiKeyPrefix+"unknown"))
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("unknown key: got %v, want Unauthenticated", err)
+	}
+
+	if err := store.InsertTenant(ctx, "tenant-1", "ci@example.com", "github", "ci"); err != nil {
+		t.Fatalf("InsertTenant: %v", err)
+	}
+	expired := time.Now().Add(-time.Minute)
+	key := apiKeyPrefix + "expired"
+	err = store.InsertApiKey(ctx, &database.ApiKey{
+		TenantId: "tenant-1", ApiKeyId: "k1", KeyPrefix: key[:apiKeyDisplayLen],
+		KeyHash: hashApiKey(key), Scopes: []string{database.ApiKeyScopeAdmin}, ExpiresAt: &expired,
+	})
+	if err != nil {
+		t.Fatalf("InsertApiKey: %v", err)
+	}
+	_, err = gw.ListJobs(ctx, withApiKey(&jennahv1.ListJobsRequest{}, key))
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expired key: got %v, want Unauthenticated", err)
+	}
+}
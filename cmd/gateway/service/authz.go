@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"cloud.google.com/go/spanner"
+	"connectrpc.com/connect"
+	"google.golang.org/grpc/codes"
+
+	"github.com/alphauslabs/jennah/cmd/gateway/auth"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+// OrganizationHeader names the organization a request acts in. Without it,
+// requests act in the caller's personal tenant.
+const OrganizationHeader = "X-Jennah-Organization"
+
+// roleScopes lists the scopes each organization role grants. Callers acting
+// in their personal tenant are its admin.
+var roleScopes = map[string][]string{
+	database.RoleViewer:    {database.ApiKeyScopeRead},
+	database.RoleSubmitter: {database.ApiKeyScopeRead, database.ApiKeyScopeSubmit, database.ApiKeyScopeCancel},
+	database.RoleAdmin:     {database.ApiKeyScopeAdmin},
+}
+
+// caller is an authenticated principal and the tenant its request acts in.
+type caller struct {
+	tenantId string
+	user     *OAuthUser             // nil for API keys
+	apiKey   *database.ApiKey       // nil for OAuth users
+	org      *database.Organization // nil outside organizations
+	role     string                 // Empty for API keys
+	scopes   []string
+}
+
+// principal names the caller in audit fields such as AddedBy.
+func (c *caller) principal() string {
+	if c.apiKey != nil {
+		return "apikey:" + c.apiKey.ApiKeyId
+	}
+	return normalizeEmail(c.user.Email)
+}
+
+// scopesAllow reports whether scopes grant scope. The admin scope grants
+// everything.
+func scopesAllow(scopes []string, scope string) bool {
+	return slices.Contains(scopes, scope) || slices.Contains(scopes, database.ApiKeyScopeAdmin)
+}
+
+// normalizeEmail returns the form member emails are stored in.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// authorize authenticates a request, resolves the tenant it acts in and
+// checks that the caller's role (or API key scopes) there grant scope. Every
+// tenant-scoped RPC goes through it.
+func (s *GatewayService) authorize(ctx context.Context, header http.Header, scope string) (*caller, error) {
+	c, err := s.resolveCaller(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+	if !scopesAllow(c.scopes, scope) {
+		if c.apiKey != nil {
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("API key lacks the %q scope", scope))
+		}
+		log.Printf("Denied %q to %s (role %s) in tenant %s", scope, c.user.Email, c.role, c.tenantId)
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("role %q does not allow %q", c.role, scope))
+	}
+	return c, nil
+}
+
+func (s *GatewayService) resolveCaller(ctx context.Context, header http.Header) (*caller, error) {
+	orgId := strings.TrimSpace(header.Get(OrganizationHeader))
+
+	if token := auth.BearerToken(header); isApiKey(token) {
+		apiKey, err := s.resolveApiKey(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		if orgId != "" && orgId != apiKey.TenantId {
+			return nil, connect.NewError(connect.CodePermissionDenied, errors.New("API key belongs to another tenant"))
+		}
+		return &caller{tenantId: apiKey.TenantId, apiKey: apiKey, scopes: apiKey.Scopes}, nil
+	}
+
+	user, err := s.authenticateUser(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgId == "" {
+		tenantId, err := s.getOrCreateTenant(user)
+		if err != nil {
+			log.Printf("Failed to get or create tenant: %v", err)
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		return &caller{tenantId: tenantId, user: user, role: database.RoleAdmin, scopes: roleScopes[database.RoleAdmin]}, nil
+	}
+
+	member, err := s.dbClient.GetOrganizationMember(ctx, orgId, normalizeEmail(user.Email))
+	if err != nil {
+		if spanner.ErrCode(err) == codes.NotFound {
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("not a member of organization %s", orgId))
+		}
+		log.Printf("Failed to get membership of %s in organization %s: %v", user.Email, orgId, err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to check organization membership"))
+	}
+	org, err := s.dbClient.GetOrganization(ctx, orgId)
+	if err != nil {
+		log.Printf("Failed to get organization %s: %v", orgId, err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get organization"))
+	}
+	return &caller{tenantId: orgId, user: user, org: org, role: member.Role, scopes: roleScopes[member.Role]}, nil
+}
+
+// authenticateUser returns the OAuth user behind a request. API keys are not
+// users and are rejected.
+func (s *GatewayService) authenticateUser(ctx context.Context, header http.Header) (*OAuthUser, error) {
+	if isApiKey(auth.BearerToken(header)) {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("API keys cannot be used for this operation"))
+	}
+
+	user, err := s.authenticate(ctx, header)
+	if err != nil {
+		log.Printf("OAuth authentication failed: %v", err)
+		if s.authenticator != nil && !errors.Is(err, auth.ErrMissingToken) && !errors.Is(err, auth.ErrInvalidToken) {
+			// The identity provider could not be reached; the caller may retry.
+			return nil, connect.NewError(connect.CodeUnavailable, errors.New("failed to verify credentials"))
+		}
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing or invalid credentials"))
+	}
+	return user, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+// cancellableStatuses are the statuses the worker's CancelJob accepts.
+var cancellableStatuses = []string{
+	database.JobStatusQueued, database.JobStatusPending, database.JobStatusScheduled,
+	database.JobStatusRunning, database.JobStatusRetrying,
+}
+
+// jobsBySelector returns every job of the tenant in one of statuses (any
+// status when empty) whose labels match selector. The selector is required
+// so a bulk operation never applies to all jobs by accident.
+func (s *GatewayService) jobsBySelector(ctx context.Context, tenantId, selector string, statuses []string) ([]*database.Job, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("label_selector is required"))
+	}
+	labels, err := parseLabelSelector(selector)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	// Collect every match before acting, so cancelling or deleting jobs does
+	// not shift the pages still to be read.
+	filter := database.JobFilter{Statuses: statuses, Labels: labels, Limit: maxJobsPageSize}
+	var jobs []*database.Job
+	for {
+		page, err := s.dbClient.ListJobsFiltered(ctx, tenantId, filter)
+		if err != nil {
+			log.Printf("Failed to list jobs by selector for tenant %s: %v", tenantId, err)
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list jobs: %w", err))
+		}
+		jobs = append(jobs, page...)
+		if len(page) < maxJobsPageSize {
+			return jobs, nil
+		}
+		last := page[len(page)-1]
+		filter.After = &database.JobCursor{CreatedAt: last.CreatedAt, JobId: last.JobId}
+	}
+}
+
+// BulkCancelJobs cancels every active job matching a label selector. Jobs
+// that fail to cancel are reported without stopping the others.
+func (s *GatewayService) BulkCancelJobs(
+	ctx context.Context,
+	req *connect.Request[jennahv1.BulkCancelJobsRequest],
+) (*connect.Response[jennahv1.BulkCancelJobsResponse], error) {
+	log.Printf("Received bulk cancel jobs request")
+
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeCancel)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.jobsBySelector(ctx, tenantId, req.Msg.LabelSelector, cancellableStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &jennahv1.BulkCancelJobsResponse{Failures: map[string]string{}}
+	for _, job := range jobs {
+		if _, err := s.cancelJob(ctx, tenantId, job.JobId); err != nil {
+			response.Failures[job.JobId] = err.Error()
+			continue
+		}
+		response.JobIds = append(response.JobIds, job.JobId)
+	}
+
+	log.Printf("Bulk cancel for tenant %s (selector %q): %d cancelled, %d failed",
+		tenantId, req.Msg.LabelSelector, len(response.JobIds), len(response.Failures))
+	return connect.NewResponse(response), nil
+}
+
+// BulkDeleteJobs deletes every job matching a label selector. Jobs that fail
+// to delete are reported without stopping the others.
+func (s *GatewayService) BulkDeleteJobs(
+	ctx context.Context,
+	req *connect.Request[jennahv1.BulkDeleteJobsRequest],
+) (*connect.Response[jennahv1.BulkDeleteJobsResponse], error) {
+	log.Printf("Received bulk delete jobs request")
+
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeCancel)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.jobsBySelector(ctx, tenantId, req.Msg.LabelSelector, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &jennahv1.BulkDeleteJobsResponse{Failures: map[string]string{}}
+	for _, job := range jobs {
+		if _, err := s.deleteJob(ctx, tenantId, job.JobId); err != nil {
+			response.Failures[job.JobId] = err.Error()
+			continue
+		}
+		response.JobIds = append(response.JobIds, job.JobId)
+	}
+
+	log.Printf("Bulk delete for tenant %s (selector %q): %d deleted, %d failed",
+		tenantId, req.Msg.LabelSelector, len(response.JobIds), len(response.Failures))
+	return connect.NewResponse(response), nil
+}
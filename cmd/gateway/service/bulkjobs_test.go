@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/hashing"
+)
+
+// cancellingWorker cancels and deletes jobs in the store the way the worker
+// does, failing for the job IDs in fail.
+type cancellingWorker struct {
+	jennahv1connect.UnimplementedDeploymentServiceHandler
+	store database.Store
+	fail  []string
+}
+
+func (w *cancellingWorker) CancelJob(
+	ctx context.Context,
+	req *connect.Request[jennahv1.CancelJobRequest],
+) (*connect.Response[jennahv1.CancelJobResponse], error) {
+	if slices.Contains(w.fail, req.Msg.JobId) {
+		return nil, connect.NewError(connect.CodeUnavailable, errors.New("provider unavailable"))
+	}
+	if err := w.store.UpdateJobStatus(ctx, req.Header().Get("X-Tenant-Id"), req.Msg.JobId, database.JobStatusCancelled); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&jennahv1.CancelJobResponse{JobId: req.Msg.JobId, Status: database.JobStatusCancelled}), nil
+}
+
+func (w *cancellingWorker) DeleteJob(
+	ctx context.Context,
+	req *connect.Request[jennahv1.DeleteJobRequest],
+) (*connect.Response[jennahv1.DeleteJobResponse], error) {
+	if err := w.store.DeleteJob(ctx, req.Header().Get("X-Tenant-Id"), req.Msg.JobId); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&jennahv1.DeleteJobResponse{JobId: req.Msg.JobId}), nil
+}
+
+func TestGatewayBulkCancelAndDeleteBySelector(t *testing.T) {
+	ctx := context.Background()
+	gw, store := newTestGateway(t)
+	tenantResp, err := gw.GetCurrentTenant(ctx, withOAuth(&jennahv1.GetCurrentTenantRequest{}))
+	if err != nil {
+		t.Fatalf("GetCurrentTenant: %v", err)
+	}
+	tenantID := tenantResp.Msg.TenantId
+
+	worker := &cancellingWorker{store: store, fail: []string{"job-2"}}
+	workerMux := http.NewServeMux()
+	workerMux.Handle(jennahv1connect.NewDeploymentServiceHandler(worker))
+	server := httptest.NewServer(workerMux)
+	defer server.Close()
+	gw.router = hashing.NewRouter([]string{"worker-1"})
+	gw.workerClients = map[string]jennahv1connect.DeploymentServiceClient{
+		"worker-1": jennahv1connect.NewDeploymentServiceClient(server.Client(), server.URL),
+	}
+
+	// job-0..3 belong to team billing; job-3 has already finished.
+	for i := range 5 {
+		labels := map[string]string{"team": "billing"}
+		if i == 4 {
+			labels = map[string]string{"team": "search"}
+		}
+		status := database.JobStatusRunning
+		if i == 3 {
+			status = database.JobStatusCompleted
+		}
+		job := &database.Job{TenantId: tenantID, JobId: fmt.Sprintf("job-%d", i), Status: status, ImageUri: "img", LabelsJson: database.EncodeJobLabels(labels)}
+		if err := store.InsertJobFull(ctx, job); err != nil {
+			t.Fatalf("InsertJobFull: %v", err)
+		}
+	}
+
+	if _, err := gw.BulkCancelJobs(ctx, withOAuth(&jennahv1.BulkCancelJobsRequest{})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("BulkCancelJobs without a selector: got %v, want InvalidArgument", err)
+	}
+
+	cancelled, err := gw.BulkCancelJobs(ctx, withOAuth(&jennahv1.BulkCancelJobsRequest{LabelSelector: "team=billing"}))
+	if err != nil {
+		t.Fatalf("BulkCancelJobs: %v", err)
+	}
+	if got := fmt.Sprint(cancelled.Msg.JobIds); got != "[job-1 job-0]" {
+		t.Fatalf("cancelled = %s, want [job-1 job-0]", got)
+	}
+	if _, ok := cancelled.Msg.Failures["job-2"]; !ok || len(cancelled.Msg.Failures) != 1 {
+		t.Fatalf("failures = %v, want job-2 only", cancelled.Msg.Failures)
+	}
+	if job, _ := store.GetJob(ctx, tenantID, "job-4"); job.Status != database.JobStatusRunning {
+		t.Fatalf("job-4 of team search is %s, want RUNNING", job.Status)
+	}
+
+	deleted, err := gw.BulkDeleteJobs(ctx, withOAuth(&jennahv1.BulkDeleteJobsRequest{LabelSelector: "team!=search"}))
+	if err != nil {
+		t.Fatalf("BulkDeleteJobs: %v", err)
+	}
+	if got := fmt.Sprint(deleted.Msg.JobIds); got != "[job-3 job-2 job-1 job-0]" || len(deleted.Msg.Failures) != 0 {
+		t.Fatalf("deleted = %s, failures %v; want job-3..job-0", got, deleted.Msg.Failures)
+	}
+	resp, err := gw.ListJobs(ctx, withOAuth(&jennahv1.ListJobsRequest{}))
+	if err != nil {
+		t.Fatalf("ListJobs: %v", err)
+	}
+	if len(resp.Msg.Jobs) != 1 || resp.Msg.Jobs[0].JobId != "job-4" || resp.Msg.Jobs[0].Labels["team"] != "search" {
+		t.Fatalf("remaining jobs = %v, want job-4 labelled team=search", resp.Msg.Jobs)
+	}
+}
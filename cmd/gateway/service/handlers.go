@@ -14,34 +14,32 @@ import (
 
 	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
 	jennahv1connect "github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
 	"github.com/alphauslabs/jennah/internal/database"
 	"github.com/alphauslabs/jennah/internal/router"
 )
 
-func (s *GatewayService) resolveTenant(header http.Header) (string, error) {
-	oauthUser, err := extractOAuthUser(header)
+// resolveTenant authorizes a request for scope (see authorize) and returns
+// the tenant it acts in.
+func (s *GatewayService) resolveTenant(ctx context.Context, header http.Header, scope string) (string, error) {
+	c, err := s.authorize(ctx, header, scope)
 	if err != nil {
-		log.Printf("OAuth authentication failed: %v", err)
-		return "", connect.NewError(connect.CodeUnauthenticated, errors.New("missing or invalid OAuth headers"))
+		return "", err
 	}
-
-	tenantId, err := s.getOrCreateTenant(oauthUser)
-	if err != nil {
-		log.Printf("Failed to get or create tenant: %v", err)
-		return "", connect.NewError(connect.CodeInternal, err)
-	}
-
-	return tenantId, nil
+	return c.tenantId, nil
 }
 
+// getWorkerClient returns the worker routingKey maps to, skipping workers
+// that recently could not be reached.
 func (s *GatewayService) getWorkerClient(routingKey string) (string, jennahv1connect.DeploymentServiceClient, error) {
-	workerIP := s.router.GetWorkerIP(routingKey)
-	if workerIP == "" {
+	candidates := s.candidateWorkers(routingKey, false)
+	if len(candidates) == 0 {
 		log.Printf("No worker found for routingKey: %s", routingKey)
 		return "", nil, connect.NewError(connect.CodeInternal, errors.New("no worker found for routing key"))
 	}
+	workerIP := candidates[0]
 
-	workerClient, exists := s.workerClients[workerIP]
+	workerClient, exists := s.workerClient(workerIP)
 	if !exists {
 		log.Printf("No worker client found for IP: %s", workerIP)
 		return "", nil, connect.NewError(connect.CodeInternal, fmt.Errorf("no worker client found for IP: %s", workerIP))
@@ -117,6 +115,27 @@ func dbJobToProto(job *database.Job) *jennahv1.Job {
 	if job.MaxRunDurationSeconds != nil {
 		p.MaxRunDurationSeconds = *job.MaxRunDurationSeconds
 	}
+	if job.RetryPolicy != nil {
+		p.RetryPolicy = *job.RetryPolicy
+	}
+	if job.WorkflowId != nil {
+		p.WorkflowId = *job.WorkflowId
+	}
+	if job.WorkflowNodeId != nil {
+		p.WorkflowNodeId = *job.WorkflowNodeId
+	}
+	deps, err := database.JobDependencies(job)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	for _, dep := range deps {
+		p.DependsOn = append(p.DependsOn, &jennahv1.WorkflowDependency{NodeId: dep.NodeId, Condition: dep.Condition})
+	}
+	labels, err := database.JobLabels(job)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	p.Labels = labels
 
 	return p
 }
@@ -125,10 +144,11 @@ func (s *GatewayService) GetCurrentTenant(
 	ctx context.Context,
 	req *connect.Request[jennahv1.GetCurrentTenantRequest],
 ) (*connect.Response[jennahv1.GetCurrentTenantResponse], error) {
-	tenantId, err := s.resolveTenant(req.Header())
+	c, err := s.authorize(ctx, req.Header(), database.ApiKeyScopeRead)
 	if err != nil {
 		return nil, err
 	}
+	tenantId := c.tenantId
 
 	tenant, err := s.dbClient.GetTenant(ctx, tenantId)
 	if err != nil {
@@ -136,12 +156,20 @@ func (s *GatewayService) GetCurrentTenant(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to fetch tenant: %w", err))
 	}
 
-	response := connect.NewResponse(&jennahv1.GetCurrentTenantResponse{
+	resp := &jennahv1.GetCurrentTenantResponse{
 		TenantId:      tenant.TenantId,
 		UserEmail:     tenant.UserEmail,
 		OauthProvider: tenant.OAuthProvider,
 		CreatedAt:     tenant.CreatedAt.Format(time.RFC3339),
-	})
+		Role:          c.role,
+	}
+	// In an organization, report who is calling rather than who created it.
+	if c.org != nil {
+		resp.UserEmail = c.user.Email
+		resp.OauthProvider = c.user.Provider
+		resp.Organization = dbOrganizationToProto(c.org, c.role)
+	}
+	response := connect.NewResponse(resp)
 
 	log.Printf("Retrieved tenant info for user %s: tenantId=%s", tenant.UserEmail, tenantId)
 	return response, nil
@@ -153,7 +181,7 @@ func (s *GatewayService) SubmitJob(
 ) (*connect.Response[jennahv1.SubmitJobResponse], error) {
 	log.Printf("Received job submission")
 
-	tenantId, err := s.resolveTenant(req.Header())
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeSubmit)
 	if err != nil {
 		return nil, err
 	}
@@ -167,12 +195,39 @@ func (s *GatewayService) SubmitJob(
 		log.Printf("Distributed job image override: requested %q, using %q", req.Msg.GetImageUri(), resolvedImageURI)
 	}
 
-	gatewayJobID := uuid.NewString()
-	workerIP, workerClient, err := s.getWorkerClient(gatewayJobID)
+	if err := batch.ValidateJobLabels(req.Msg.GetLabels()); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	// Retries of an idempotent submission get the original response back
+	// before quota admission, so they neither dispatch nor count again.
+	idempotencyKey := strings.TrimSpace(req.Msg.GetIdempotencyKey())
+	var requestHash string
+	if idempotencyKey != "" {
+		if len(idempotencyKey) > maxIdempotencyKeyLength {
+			return nil, connect.NewError(connect.CodeInvalidArgument,
+				fmt.Errorf("idempotency_key must be at most %d characters", maxIdempotencyKeyLength))
+		}
+		requestHash, err = submitRequestHash(req.Msg)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to hash request: %w", err))
+		}
+		replayed, err := s.replaySubmit(ctx, tenantId, idempotencyKey, requestHash)
+		if err != nil {
+			return nil, err
+		}
+		if replayed != nil {
+			log.Printf("Replaying submission of job %s for idempotency key %q", replayed.JobId, idempotencyKey)
+			return connect.NewResponse(replayed), nil
+		}
+	}
+
+	queueReason, err := s.admitJob(ctx, tenantId, req.Msg)
 	if err != nil {
 		return nil, err
 	}
-	log.Printf("Selected worker: %s for tenant (routing key: %s)", workerIP, gatewayJobID)
+
+	gatewayJobID := uuid.NewString()
 
 	routingDecision := router.EvaluateJobComplexityWithGemini(ctx, req.Msg)
 	log.Printf("Routing decision: complexity=%s, service=%s, reason=%s",
@@ -190,11 +245,36 @@ func (s *GatewayService) SubmitJob(
 		UseSpotVms:       req.Msg.UseSpotVms,
 		ServiceAccount:   req.Msg.ServiceAccount,
 		Commands:         req.Msg.Commands,
+		MaxRetries:       req.Msg.MaxRetries,
+		RetryPolicy:      req.Msg.RetryPolicy,
+		IdempotencyKey:   idempotencyKey,
+		Labels:           req.Msg.Labels,
 	})
 	workerReq.Header().Set("X-Tenant-Id", tenantId)
+	if queueReason != "" {
+		workerReq.Header().Set(QueueReasonHeader, queueReason)
+	}
+	if idempotencyKey != "" {
+		workerReq.Header().Set(RequestHashHeader, requestHash)
+	}
 
-	response, err := workerClient.SubmitJob(ctx, workerReq)
+	var response *connect.Response[jennahv1.SubmitJobResponse]
+	workerIP, err := s.callWorker(gatewayJobID, true, func(client jennahv1connect.DeploymentServiceClient) error {
+		resp, err := client.SubmitJob(ctx, workerReq)
+		response = resp
+		return err
+	})
 	if err != nil {
+		if idempotencyKey != "" && connect.CodeOf(err) == connect.CodeAlreadyExists {
+			// A concurrent retry stored the key first; answer as it did.
+			replayed, replayErr := s.replaySubmit(ctx, tenantId, idempotencyKey, requestHash)
+			if replayErr != nil {
+				return nil, replayErr
+			}
+			if replayed != nil {
+				return connect.NewResponse(replayed), nil
+			}
+		}
 		log.Printf("ERROR: Worker %s failed: %v", workerIP, err)
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("worker failed: %w", err))
 	}
@@ -203,6 +283,9 @@ func (s *GatewayService) SubmitJob(
 	response.Msg.ComplexityLevel = routingDecision.Complexity.String()
 	response.Msg.AssignedService = routingDecision.AssignedService.String()
 	response.Msg.RoutingReason = routingDecision.Reason
+	if idempotencyKey != "" {
+		s.saveSubmitResponse(ctx, tenantId, response.Msg)
+	}
 	log.Printf("Job submitted successfully: jobId=%s, worker=%s, status=%s, complexity=%s, service=%s",
 		response.Msg.JobId, workerIP, response.Msg.Status,
 		response.Msg.ComplexityLevel, response.Msg.AssignedService)
@@ -216,23 +299,49 @@ func (s *GatewayService) ListJobs(
 ) (*connect.Response[jennahv1.ListJobsResponse], error) {
 	log.Printf("Received list jobs request")
 
-	tenantId, err := s.resolveTenant(req.Header())
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeRead)
 	if err != nil {
 		return nil, err
 	}
 
-	jobs, err := s.dbClient.ListJobs(ctx, tenantId)
+	filter, err := jobFilterFromRequest(req.Msg)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	pageSize, err := jobsPageSize(req.Msg)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	fingerprint, err := jobsFilterFingerprint(req.Msg)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to encode filters: %w", err))
+	}
+	if token := req.Msg.GetPageToken(); token != "" {
+		if filter.After, err = decodeJobsPageToken(token, fingerprint); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+	// One extra row tells whether another page follows.
+	filter.Limit = pageSize + 1
+
+	jobs, err := s.dbClient.ListJobsFiltered(ctx, tenantId, filter)
 	if err != nil {
 		log.Printf("Failed to list jobs from database for tenant %s: %v", tenantId, err)
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list jobs: %w", err))
 	}
 
+	var nextPageToken string
+	if len(jobs) > pageSize {
+		jobs = jobs[:pageSize]
+		nextPageToken = encodeJobsPageToken(jobs[pageSize-1], fingerprint)
+	}
+
 	protoJobs := make([]*jennahv1.Job, 0, len(jobs))
 	for _, job := range jobs {
 		protoJobs = append(protoJobs, dbJobToProto(job))
 	}
 
-	response := connect.NewResponse(&jennahv1.ListJobsResponse{Jobs: protoJobs})
+	response := connect.NewResponse(&jennahv1.ListJobsResponse{Jobs: protoJobs, NextPageToken: nextPageToken})
 
 	log.Printf("Successfully listed %d jobs for tenant %s directly from database", len(response.Msg.Jobs), tenantId)
 	return response, nil
@@ -248,26 +357,30 @@ func (s *GatewayService) CancelJob(
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("job_id is required"))
 	}
 
-	tenantId, err := s.resolveTenant(req.Header())
-	if err != nil {
-		return nil, err
-	}
-
-	workerIP, workerClient, err := s.getWorkerClient(req.Msg.JobId)
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeCancel)
 	if err != nil {
 		return nil, err
 	}
+	return s.cancelJob(ctx, tenantId, req.Msg.JobId)
+}
 
-	workerReq := connect.NewRequest(&jennahv1.CancelJobRequest{JobId: req.Msg.JobId})
+// cancelJob forwards a CancelJob request to the worker that owns jobId.
+func (s *GatewayService) cancelJob(ctx context.Context, tenantId, jobId string) (*connect.Response[jennahv1.CancelJobResponse], error) {
+	workerReq := connect.NewRequest(&jennahv1.CancelJobRequest{JobId: jobId})
 	workerReq.Header().Set("X-Tenant-Id", tenantId)
 
-	response, err := workerClient.CancelJob(ctx, workerReq)
+	var response *connect.Response[jennahv1.CancelJobResponse]
+	workerIP, err := s.callJobWorker(ctx, tenantId, jobId, func(client jennahv1connect.DeploymentServiceClient) error {
+		resp, err := client.CancelJob(ctx, workerReq)
+		response = resp
+		return err
+	})
 	if err != nil {
-		log.Printf("ERROR: Worker %s CancelJob failed for job %s: %v", workerIP, req.Msg.JobId, err)
+		log.Printf("ERROR: Worker %s CancelJob failed for job %s: %v", workerIP, jobId, err)
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("worker failed: %w", err))
 	}
 
-	log.Printf("Job cancelled successfully: jobId=%s, tenantId=%s, worker=%s", req.Msg.JobId, tenantId, workerIP)
+	log.Printf("Job cancelled successfully: jobId=%s, tenantId=%s, worker=%s", jobId, tenantId, workerIP)
 	return response, nil
 }
 
@@ -281,26 +394,30 @@ func (s *GatewayService) DeleteJob(
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("job_id is required"))
 	}
 
-	tenantId, err := s.resolveTenant(req.Header())
-	if err != nil {
-		return nil, err
-	}
-
-	workerIP, workerClient, err := s.getWorkerClient(req.Msg.JobId)
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeCancel)
 	if err != nil {
 		return nil, err
 	}
+	return s.deleteJob(ctx, tenantId, req.Msg.JobId)
+}
 
-	workerReq := connect.NewRequest(&jennahv1.DeleteJobRequest{JobId: req.Msg.JobId})
+// deleteJob forwards a DeleteJob request to the worker that owns jobId.
+func (s *GatewayService) deleteJob(ctx context.Context, tenantId, jobId string) (*connect.Response[jennahv1.DeleteJobResponse], error) {
+	workerReq := connect.NewRequest(&jennahv1.DeleteJobRequest{JobId: jobId})
 	workerReq.Header().Set("X-Tenant-Id", tenantId)
 
-	response, err := workerClient.DeleteJob(ctx, workerReq)
+	var response *connect.Response[jennahv1.DeleteJobResponse]
+	workerIP, err := s.callJobWorker(ctx, tenantId, jobId, func(client jennahv1connect.DeploymentServiceClient) error {
+		resp, err := client.DeleteJob(ctx, workerReq)
+		response = resp
+		return err
+	})
 	if err != nil {
-		log.Printf("ERROR: Worker %s DeleteJob failed for job %s: %v", workerIP, req.Msg.JobId, err)
+		log.Printf("ERROR: Worker %s DeleteJob failed for job %s: %v", workerIP, jobId, err)
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("worker failed: %w", err))
 	}
 
-	log.Printf("Job deleted successfully: jobId=%s, tenantId=%s, worker=%s", req.Msg.JobId, tenantId, workerIP)
+	log.Printf("Job deleted successfully: jobId=%s, tenantId=%s, worker=%s", jobId, tenantId, workerIP)
 	return response, nil
 }
 
@@ -314,12 +431,7 @@ func (s *GatewayService) GetJob(
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("job_id is required"))
 	}
 
-	tenantId, err := s.resolveTenant(req.Header())
-	if err != nil {
-		return nil, err
-	}
-
-	workerIP, workerClient, err := s.getWorkerClient(req.Msg.JobId)
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeRead)
 	if err != nil {
 		return nil, err
 	}
@@ -327,7 +439,12 @@ func (s *GatewayService) GetJob(
 	workerReq := connect.NewRequest(&jennahv1.GetJobRequest{JobId: req.Msg.JobId})
 	workerReq.Header().Set("X-Tenant-Id", tenantId)
 
-	response, err := workerClient.GetJob(ctx, workerReq)
+	var response *connect.Response[jennahv1.GetJobResponse]
+	workerIP, err := s.callJobWorker(ctx, tenantId, req.Msg.JobId, func(client jennahv1connect.DeploymentServiceClient) error {
+		resp, err := client.GetJob(ctx, workerReq)
+		response = resp
+		return err
+	})
 	if err != nil {
 		log.Printf("ERROR: Worker %s GetJob failed for job %s: %v", workerIP, req.Msg.JobId, err)
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("worker failed: %w", err))
@@ -341,7 +458,7 @@ func (s *GatewayService) ListNotifications(
 	ctx context.Context,
 	req *connect.Request[jennahv1.ListNotificationsRequest],
 ) (*connect.Response[jennahv1.ListNotificationsResponse], error) {
-	tenantId, err := s.resolveTenant(req.Header())
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeRead)
 	if err != nil {
 		return nil, err
 	}
@@ -377,7 +494,7 @@ func (s *GatewayService) AckNotification(
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("notification_id is required"))
 	}
 
-	tenantId, err := s.resolveTenant(req.Header())
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeRead)
 	if err != nil {
 		return nil, err
 	}
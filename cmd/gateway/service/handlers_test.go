@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	"github.com/alphauslabs/jennah/cmd/gateway/auth"
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+func newTestGateway(t *testing.T) (*GatewayService, *database.MemoryStore) {
+	t.Helper()
+	store := database.NewMemoryStore()
+	return NewGatewayService(nil, nil, store, "", nil, nil, 0), store
+}
+
+func withOAuth[T any](msg *T) *connect.Request[T] {
+	req := connect.NewRequest(msg)
+	req.Header().Set("X-OAuth-Email", "dev@example.com")
+	req.Header().Set("X-OAuth-UserId", "user-1")
+	req.Header().Set("X-OAuth-Provider", "google")
+	return req
+}
+
+func TestGatewayListJobs_UsesResolvedTenant(t *testing.T) {
+	ctx := context.Background()
+	gw, store := newTestGateway(t)
+
+	tenantResp, err := gw.GetCurrentTenant(ctx, withOAuth(&jennahv1.GetCurrentTenantRequest{}))
+	if err != nil {
+		t.Fatalf("GetCurrentTenant: %v", err)
+	}
+	tenantID := tenantResp.Msg.TenantId
+
+	if err := store.InsertJob(ctx, tenantID, "job-1", "gcr.io/p/img:1", nil); err != nil {
+		t.Fatalf("InsertJob: %v", err)
+	}
+	if err := store.InsertTenant(ctx, "other", "x@example.com", "google", "user-2"); err != nil {
+		t.Fatalf("InsertTenant: %v", err)
+	}
+	if err := store.InsertJob(ctx, "other", "job-2", "gcr.io/p/img:1", nil); err != nil {
+		t.Fatalf("InsertJob: %v", err)
+	}
+
+	resp, err := gw.ListJobs(ctx, withOAuth(&jennahv1.ListJobsRequest{}))
+	if err != nil {
+		t.Fatalf("ListJobs: %v", err)
+	}
+	if len(resp.Msg.Jobs) != 1 || resp.Msg.Jobs[0].JobId != "job-1" {
+		t.Fatalf("expected only the caller's job, got %+v", resp.Msg.Jobs)
+	}
+}
+
+func TestGatewayRejectsMissingOAuthHeaders(t *testing.T) {
+	gw, _ := newTestGateway(t)
+
+	_, err := gw.ListJobs(context.Background(), connect.NewRequest(&jennahv1.ListJobsRequest{}))
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", err)
+	}
+}
+
+// stubVerifier accepts the single token it was given.
+type stubVerifier struct {
+	token    string
+	identity auth.Identity
+}
+
+func (v stubVerifier) Verify(ctx context.Context, token string) (*auth.Identity, error) {
+	if token != v.token {
+		return nil, auth.ErrInvalidToken
+	}
+	return &v.identity, nil
+}
+
+func TestGatewayResolvesTenantFromVerifiedToken(t *testing.T) {
+	ctx := context.Background()
+	store := database.NewMemoryStore()
+	gw := NewGatewayService(nil, nil, store, "", auth.NewAuthenticator(map[string]auth.Verifier{
+		"github": stubVerifier{token: "gho_valid", identity: auth.Identity{Provider: "github", UserId: "octocat", Email: "octocat@example.com"}},
+	}), nil, 0)
+
+	// Identity headers alone are no longer trusted.
+	if _, err := gw.GetCurrentTenant(ctx, withOAuth(&jennahv1.GetCurrentTenantRequest{})); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expected Unauthenticated without a token, got %v", err)
+	}
+
+	req := withOAuth(&jennahv1.GetCurrentTenantRequest{})
+	req.Header().Set("Authorization", "Bearer gho_forged")
+	if _, err := gw.GetCurrentTenant(ctx, req); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expected Unauthenticated for an invalid token, got %v", err)
+	}
+
+	req = connect.NewRequest(&jennahv1.GetCurrentTenantRequest{})
+	req.Header().Set("Authorization", "Bearer gho_valid")
+	resp, err := gw.GetCurrentTenant(ctx, req)
+	if err != nil {
+		t.Fatalf("GetCurrentTenant: %v", err)
+	}
+	tenant, err := store.GetTenantByOAuth(ctx, "github", "octocat")
+	if err != nil || tenant == nil || tenant.TenantId != resp.Msg.TenantId || tenant.UserEmail != "octocat@example.com" {
+		t.Fatalf("tenant = %+v, %v; want the verified identity's tenant %s", tenant, err, resp.Msg.TenantId)
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+)
+
+// RequestHashHeader carries the hash of an idempotent SubmitJob request to
+// the worker, which stores it with the job's idempotency key.
+const RequestHashHeader = "X-Request-Hash"
+
+// DefaultIdempotencyKeyTTL is how long a SubmitJob idempotency key is
+// honoured when no retention window is configured.
+const DefaultIdempotencyKeyTTL = 24 * time.Hour
+
+// maxIdempotencyKeyLength matches the IdempotencyKey column.
+const maxIdempotencyKeyLength = 255
+
+// submitRequestHash returns the hex SHA-256 of a SubmitJob request as the
+// client sent it, so a retry can be told apart from a different request
+// reusing the key.
+func submitRequestHash(req *jennahv1.SubmitJobRequest) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// replaySubmit looks up the job an earlier SubmitJob created with the same
+// idempotency key and returns that submission's response. It returns nil
+// when the key is unused or older than the retention window, and fails with
+// AlreadyExists when the key was used for a different request.
+func (s *GatewayService) replaySubmit(ctx context.Context, tenantId, idempotencyKey, requestHash string) (*jennahv1.SubmitJobResponse, error) {
+	job, err := s.dbClient.GetJobByIdempotencyKey(ctx, tenantId, idempotencyKey)
+	if err != nil {
+		log.Printf("Failed to look up idempotency key for tenant %s: %v", tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to look up idempotency key"))
+	}
+	if job == nil {
+		return nil, nil
+	}
+
+	if time.Since(job.CreatedAt) > s.idempotencyKeyTTL {
+		// Expired: free the key for this submission; the old job is kept.
+		if err := s.dbClient.ClearJobIdempotencyKey(ctx, tenantId, job.JobId); err != nil {
+			log.Printf("Failed to release idempotency key of job %s: %v", job.JobId, err)
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to release idempotency key"))
+		}
+		return nil, nil
+	}
+
+	if job.RequestHash == nil || *job.RequestHash != requestHash {
+		return nil, connect.NewError(connect.CodeAlreadyExists,
+			fmt.Errorf("idempotency key %q was already used for a different request (job %s)", idempotencyKey, job.JobId))
+	}
+
+	if job.SubmitResponseJson != nil {
+		response := &jennahv1.SubmitJobResponse{}
+		if err := protojson.Unmarshal([]byte(*job.SubmitResponseJson), response); err == nil {
+			return response, nil
+		}
+		log.Printf("Failed to decode stored submit response of job %s: %v", job.JobId, err)
+	}
+
+	// The original submission is still in flight, or failed after the worker
+	// stored the job: answer from the job row.
+	response := &jennahv1.SubmitJobResponse{
+		JobId:  job.JobId,
+		Status: job.Status,
+	}
+	if s.router != nil {
+		response.WorkerAssigned = s.router.GetWorkerIP(job.JobId)
+	}
+	if job.AssignedService != nil {
+		response.AssignedService = *job.AssignedService
+	}
+	return response, nil
+}
+
+// saveSubmitResponse stores a response for replay to retries of the same
+// submission. Failures are logged only: retries then answer from the job row.
+func (s *GatewayService) saveSubmitResponse(ctx context.Context, tenantId string, response *jennahv1.SubmitJobResponse) {
+	b, err := protojson.Marshal(response)
+	if err != nil {
+		log.Printf("Failed to encode submit response of job %s: %v", response.JobId, err)
+		return
+	}
+	if err := s.dbClient.SetJobSubmitResponse(ctx, tenantId, response.JobId, string(b)); err != nil {
+		log.Printf("Failed to store submit response of job %s: %v", response.JobId, err)
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/hashing"
+)
+
+// storingWorker inserts submitted jobs the way the worker does, so the
+// gateway can find them by idempotency key.
+type storingWorker struct {
+	jennahv1connect.UnimplementedDeploymentServiceHandler
+	store     database.Store
+	submitted int
+}
+
+func (w *storingWorker) SubmitJob(
+	ctx context.Context,
+	req *connect.Request[jennahv1.SubmitJobRequest],
+) (*connect.Response[jennahv1.SubmitJobResponse], error) {
+	w.submitted++
+	job := &database.Job{
+		TenantId: req.Header().Get("X-Tenant-Id"),
+		JobId:    req.Msg.JobId,
+		Status:   database.JobStatusRunning,
+		ImageUri: req.Msg.ImageUri,
+	}
+	if key := req.Msg.IdempotencyKey; key != "" {
+		hash := req.Header().Get(RequestHashHeader)
+		job.IdempotencyKey, job.RequestHash = &key, &hash
+	}
+	if err := w.store.InsertJobFull(ctx, job); err != nil {
+		return nil, connect.NewError(connect.CodeAlreadyExists, err)
+	}
+	return connect.NewResponse(&jennahv1.SubmitJobResponse{JobId: req.Msg.JobId, Status: job.Status}), nil
+}
+
+func TestGatewaySubmitJobIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	gw, store := newTestGateway(t)
+	tenantResp, err := gw.GetCurrentTenant(ctx, withOAuth(&jennahv1.GetCurrentTenantRequest{}))
+	if err != nil {
+		t.Fatalf("GetCurrentTenant: %v", err)
+	}
+	tenantID := tenantResp.Msg.TenantId
+
+	worker := &storingWorker{store: store}
+	workerMux := http.NewServeMux()
+	workerMux.Handle(jennahv1connect.NewDeploymentServiceHandler(worker))
+	server := httptest.NewServer(workerMux)
+	defer server.Close()
+	gw.router = hashing.NewRouter([]string{"worker-1"})
+	gw.workerClients = map[string]jennahv1connect.DeploymentServiceClient{
+		"worker-1": jennahv1connect.NewDeploymentServiceClient(server.Client(), server.URL),
+	}
+
+	submit := func(req *jennahv1.SubmitJobRequest) (*jennahv1.SubmitJobResponse, error) {
+		resp, err := gw.SubmitJob(ctx, withOAuth(req))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	first, err := submit(&jennahv1.SubmitJobRequest{ImageUri: "gcr.io/p/img:1", IdempotencyKey: "retry-1"})
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	retried, err := submit(&jennahv1.SubmitJobRequest{ImageUri: "gcr.io/p/img:1", IdempotencyKey: "retry-1"})
+	if err != nil {
+		t.Fatalf("SubmitJob retry: %v", err)
+	}
+	if retried.JobId != first.JobId || retried.RoutingReason != first.RoutingReason || worker.submitted != 1 {
+		t.Fatalf("retry = %+v after %+v with %d dispatches; want the original response and one dispatch", retried, first, worker.submitted)
+	}
+
+	_, err = submit(&jennahv1.SubmitJobRequest{ImageUri: "gcr.io/p/img:2", IdempotencyKey: "retry-1"})
+	if connect.CodeOf(err) != connect.CodeAlreadyExists {
+		t.Fatalf("SubmitJob with a reused key: got %v, want AlreadyExists", err)
+	}
+
+	// Without a key every submission is a new job.
+	a, err := submit(&jennahv1.SubmitJobRequest{ImageUri: "gcr.io/p/img:1"})
+	if err != nil {
+		t.Fatalf("SubmitJob without key: %v", err)
+	}
+	b, err := submit(&jennahv1.SubmitJobRequest{ImageUri: "gcr.io/p/img:1"})
+	if err != nil {
+		t.Fatalf("SubmitJob without key: %v", err)
+	}
+	if a.JobId == b.JobId || worker.submitted != 3 {
+		t.Fatalf("submissions without a key were deduplicated: %s, %s", a.JobId, b.JobId)
+	}
+
+	// Past the retention window the key is released and the request runs again.
+	gw.idempotencyKeyTTL = time.Nanosecond
+	again, err := submit(&jennahv1.SubmitJobRequest{ImageUri: "gcr.io/p/img:2", IdempotencyKey: "retry-1"})
+	if err != nil {
+		t.Fatalf("SubmitJob after expiry: %v", err)
+	}
+	if again.JobId == first.JobId || worker.submitted != 4 {
+		t.Fatalf("expired key replayed job %s", again.JobId)
+	}
+	old, err := store.GetJob(ctx, tenantID, first.JobId)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if old.IdempotencyKey != nil {
+		t.Fatalf("expired job still holds key %q", *old.IdempotencyKey)
+	}
+}
+
+func TestGatewaySubmitJobRejectsLongIdempotencyKey(t *testing.T) {
+	gw, _ := newTestGateway(t)
+	key := strings.Repeat("k", maxIdempotencyKeyLength+1)
+	_, err := gw.SubmitJob(context.Background(), withOAuth(&jennahv1.SubmitJobRequest{
+		ImageUri:       "gcr.io/p/img:1",
+		IdempotencyKey: key,
+	}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("SubmitJob with a %d-character key: got %v, want InvalidArgument", len(key), err)
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+const (
+	defaultJobsPageSize = 100
+	maxJobsPageSize     = 1000
+)
+
+var jobStatuses = []string{
+	database.JobStatusQueued, database.JobStatusWaiting, database.JobStatusPending,
+	database.JobStatusScheduled, database.JobStatusRunning, database.JobStatusRetrying,
+	database.JobStatusCompleted, database.JobStatusFailed, database.JobStatusCancelled,
+	database.JobStatusSkipped,
+}
+
+// jobsPageToken is a decoded ListJobs page_token: the last job of the
+// previous page and a fingerprint of the filters that listed it.
+type jobsPageToken struct {
+	CreatedAt time.Time `json:"c"`
+	JobId     string    `json:"j"`
+	Filter    string    `json:"f"`
+}
+
+// jobFilterFromRequest validates the filters of a ListJobs request. The page
+// size and token are handled by the caller.
+func jobFilterFromRequest(req *jennahv1.ListJobsRequest) (database.JobFilter, error) {
+	var filter database.JobFilter
+
+	for _, status := range req.GetStatuses() {
+		status = strings.ToUpper(strings.TrimSpace(status))
+		if !slices.Contains(jobStatuses, status) {
+			return filter, fmt.Errorf("unknown status %q", status)
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	var err error
+	if v := strings.TrimSpace(req.GetCreatedAfter()); v != "" {
+		if filter.CreatedAfter, err = time.Parse(time.RFC3339, v); err != nil {
+			return filter, fmt.Errorf("created_after must be an RFC3339 time: %w", err)
+		}
+	}
+	if v := strings.TrimSpace(req.GetCreatedBefore()); v != "" {
+		if filter.CreatedBefore, err = time.Parse(time.RFC3339, v); err != nil {
+			return filter, fmt.Errorf("created_before must be an RFC3339 time: %w", err)
+		}
+	}
+
+	filter.NamePrefix = req.GetNamePrefix()
+
+	if v := strings.ToUpper(strings.TrimSpace(req.GetAssignedService())); v != "" {
+		if v != router.AssignedServiceCloudRunJob.String() && v != router.AssignedServiceCloudBatch.String() {
+			return filter, fmt.Errorf("assigned_service must be %s or %s", router.AssignedServiceCloudRunJob, router.AssignedServiceCloudBatch)
+		}
+		filter.AssignedService = v
+	}
+
+	if filter.Labels, err = parseLabelSelector(req.GetLabelSelector()); err != nil {
+		return filter, err
+	}
+
+	switch strings.Join(strings.Fields(strings.ToLower(req.GetOrderBy())), " ") {
+	case "", "created_at desc":
+	case "created_at", "created_at asc":
+		filter.Ascending = true
+	default:
+		return filter, fmt.Errorf("order_by must be \"created_at desc\" or \"created_at asc\", got %q", req.GetOrderBy())
+	}
+	return filter, nil
+}
+
+// parseLabelSelector parses a comma-separated label selector such as
+// "team=billing,env!=dev,owner,!temp". Every term must hold.
+func parseLabelSelector(selector string) ([]database.LabelRequirement, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+	var reqs []database.LabelRequirement
+	for _, term := range strings.Split(selector, ",") {
+		term = strings.TrimSpace(term)
+		var r database.LabelRequirement
+		switch {
+		case strings.Contains(term, "!="):
+			r.Key, r.Value, _ = strings.Cut(term, "!=")
+			r.Operator = database.LabelNotEquals
+		case strings.Contains(term, "="):
+			r.Key, r.Value, _ = strings.Cut(term, "=")
+			r.Operator = database.LabelEquals
+		case strings.HasPrefix(term, "!"):
+			r.Key = strings.TrimPrefix(term, "!")
+			r.Operator = database.LabelDoesNotExist
+		default:
+			r.Key = term
+			r.Operator = database.LabelExists
+		}
+		r.Key, r.Value = strings.TrimSpace(r.Key), strings.TrimSpace(r.Value)
+		if err := batch.ValidateLabelKey(r.Key); err != nil {
+			return nil, fmt.Errorf("label_selector term %q: %w", term, err)
+		}
+		reqs = append(reqs, r)
+	}
+	return reqs, nil
+}
+
+// jobsPageSize returns the page size a ListJobs request asks for.
+func jobsPageSize(req *jennahv1.ListJobsRequest) (int, error) {
+	switch size := req.GetPageSize(); {
+	case size < 0:
+		return 0, errors.New("page_size must not be negative")
+	case size == 0:
+		return defaultJobsPageSize, nil
+	case size > maxJobsPageSize:
+		return maxJobsPageSize, nil
+	default:
+		return int(size), nil
+	}
+}
+
+// jobsFilterFingerprint identifies the filters of a ListJobs request, so a
+// page token is only accepted with the filters it was issued for.
+func jobsFilterFingerprint(req *jennahv1.ListJobsRequest) (string, error) {
+	filters := proto.Clone(req).(*jennahv1.ListJobsRequest)
+	filters.PageSize = 0
+	filters.PageToken = ""
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(filters)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+func encodeJobsPageToken(last *database.Job, fingerprint string) string {
+	b, _ := json.Marshal(jobsPageToken{CreatedAt: last.CreatedAt, JobId: last.JobId, Filter: fingerprint})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeJobsPageToken(token, fingerprint string) (*database.JobCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("invalid page_token")
+	}
+	var t jobsPageToken
+	if err := json.Unmarshal(b, &t); err != nil || t.JobId == "" {
+		return nil, errors.New("invalid page_token")
+	}
+	if t.Filter != fingerprint {
+		return nil, errors.New("page_token was issued for different filters")
+	}
+	return &database.JobCursor{CreatedAt: t.CreatedAt, JobId: t.JobId}, nil
+}
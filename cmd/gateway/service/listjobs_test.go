@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+func TestGatewayListJobsPagesAndFilters(t *testing.T) {
+	ctx := context.Background()
+	gw, store := newTestGateway(t)
+	tenantResp, err := gw.GetCurrentTenant(ctx, withOAuth(&jennahv1.GetCurrentTenantRequest{}))
+	if err != nil {
+		t.Fatalf("GetCurrentTenant: %v", err)
+	}
+	tenantID := tenantResp.Msg.TenantId
+
+	// job-0 is the oldest; odd jobs run on Cloud Batch; job-0..2 belong to
+	// team billing and job-0 also to env prod.
+	for i := range 5 {
+		name := fmt.Sprintf("nightly-%d", i)
+		if i == 4 {
+			name = "adhoc"
+		}
+		service := "CLOUD_RUN_JOB"
+		status := database.JobStatusCompleted
+		if i%2 == 1 {
+			service = "CLOUD_BATCH"
+			status = database.JobStatusRunning
+		}
+		var labels map[string]string
+		switch {
+		case i == 0:
+			labels = map[string]string{"team": "billing", "env": "prod"}
+		case i < 3:
+			labels = map[string]string{"team": "billing"}
+		}
+		job := &database.Job{TenantId: tenantID, JobId: fmt.Sprintf("job-%d", i), Status: status, ImageUri: "img", Name: &name, AssignedService: &service, LabelsJson: database.EncodeJobLabels(labels)}
+		if err := store.InsertJobFull(ctx, job); err != nil {
+			t.Fatalf("InsertJobFull: %v", err)
+		}
+	}
+
+	list := func(req *jennahv1.ListJobsRequest) ([]string, string, error) {
+		resp, err := gw.ListJobs(ctx, withOAuth(req))
+		if err != nil {
+			return nil, "", err
+		}
+		var ids []string
+		for _, j := range resp.Msg.Jobs {
+			ids = append(ids, j.JobId)
+		}
+		return ids, resp.Msg.NextPageToken, nil
+	}
+
+	var all []string
+	req := &jennahv1.ListJobsRequest{PageSize: 2}
+	for pages := 0; ; pages++ {
+		ids, next, err := list(req)
+		if err != nil {
+			t.Fatalf("ListJobs page %d: %v", pages, err)
+		}
+		all = append(all, ids...)
+		if next == "" {
+			if pages != 2 {
+				t.Fatalf("got %d pages, want 3", pages+1)
+			}
+			break
+		}
+		req.PageToken = next
+	}
+	if fmt.Sprint(all) != "[job-4 job-3 job-2 job-1 job-0]" {
+		t.Fatalf("paged listing = %v, want newest first", all)
+	}
+
+	tests := []struct {
+		name string
+		req  *jennahv1.ListJobsRequest
+		want string
+	}{
+		{"status", &jennahv1.ListJobsRequest{Statuses: []string{"running"}}, "[job-3 job-1]"},
+		{"name prefix", &jennahv1.ListJobsRequest{NamePrefix: "nightly-"}, "[job-3 job-2 job-1 job-0]"},
+		{"assigned service", &jennahv1.ListJobsRequest{AssignedService: "cloud_run_job"}, "[job-4 job-2 job-0]"},
+		{"oldest first", &jennahv1.ListJobsRequest{OrderBy: "created_at asc", PageSize: 2}, "[job-0 job-1]"},
+		{"created range", &jennahv1.ListJobsRequest{CreatedBefore: "2000-01-01T00:00:00Z"}, "[]"},
+		{"label selector", &jennahv1.ListJobsRequest{LabelSelector: "team=billing, env=prod"}, "[job-0]"},
+		{"label not equal", &jennahv1.ListJobsRequest{LabelSelector: "env!=prod,team"}, "[job-2 job-1]"},
+		{"label absent", &jennahv1.ListJobsRequest{LabelSelector: "!team"}, "[job-4 job-3]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, _, err := list(tt.req)
+			if err != nil {
+				t.Fatalf("ListJobs: %v", err)
+			}
+			if fmt.Sprint(ids) != tt.want {
+				t.Fatalf("ListJobs = %v, want %s", ids, tt.want)
+			}
+		})
+	}
+
+	// A token only continues the listing it came from.
+	_, next, err := list(&jennahv1.ListJobsRequest{PageSize: 1})
+	if err != nil || next == "" {
+		t.Fatalf("ListJobs = %q, %v", next, err)
+	}
+	_, _, err = list(&jennahv1.ListJobsRequest{PageSize: 1, PageToken: next, Statuses: []string{"RUNNING"}})
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("ListJobs with a token for other filters: got %v, want InvalidArgument", err)
+	}
+
+	for _, bad := range []*jennahv1.ListJobsRequest{
+		{Statuses: []string{"DONE"}},
+		{CreatedAfter: "yesterday"},
+		{AssignedService: "LAMBDA"},
+		{OrderBy: "name"},
+		{LabelSelector: "Team=billing"},
+		{LabelSelector: "team=billing,,env=prod"},
+		{PageSize: -1},
+		{PageToken: "not-a-token"},
+	} {
+		if _, _, err := list(bad); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("ListJobs(%v): got %v, want InvalidArgument", bad, err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	jennahv1connect "github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+// GetJobLogs forwards a log page read to the worker that owns the job.
+func (s *GatewayService) GetJobLogs(
+	ctx context.Context,
+	req *connect.Request[jennahv1.GetJobLogsRequest],
+) (*connect.Response[jennahv1.GetJobLogsResponse], error) {
+	if req.Msg.JobId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("job_id is required"))
+	}
+
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	workerReq := connect.NewRequest(&jennahv1.GetJobLogsRequest{
+		JobId:     req.Msg.JobId,
+		PageSize:  req.Msg.PageSize,
+		PageToken: req.Msg.PageToken,
+	})
+	workerReq.Header().Set("X-Tenant-Id", tenantId)
+
+	var response *connect.Response[jennahv1.GetJobLogsResponse]
+	workerIP, err := s.callJobWorker(ctx, tenantId, req.Msg.JobId, func(client jennahv1connect.DeploymentServiceClient) error {
+		resp, err := client.GetJobLogs(ctx, workerReq)
+		response = resp
+		return err
+	})
+	if err != nil {
+		log.Printf("ERROR: Worker %s GetJobLogs failed for job %s: %v", workerIP, req.Msg.JobId, err)
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("worker failed: %w", err))
+	}
+	return response, nil
+}
+
+// StreamJobLogs relays the log stream of the worker that owns the job.
+func (s *GatewayService) StreamJobLogs(
+	ctx context.Context,
+	req *connect.Request[jennahv1.StreamJobLogsRequest],
+	stream *connect.ServerStream[jennahv1.StreamJobLogsResponse],
+) error {
+	if req.Msg.JobId == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("job_id is required"))
+	}
+
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeRead)
+	if err != nil {
+		return err
+	}
+
+	workerReq := connect.NewRequest(&jennahv1.StreamJobLogsRequest{
+		JobId:  req.Msg.JobId,
+		Follow: req.Msg.Follow,
+	})
+	workerReq.Header().Set("X-Tenant-Id", tenantId)
+
+	var workerStream *connect.ServerStreamForClient[jennahv1.StreamJobLogsResponse]
+	workerIP, err := s.callJobWorker(ctx, tenantId, req.Msg.JobId, func(client jennahv1connect.DeploymentServiceClient) error {
+		stream, err := client.StreamJobLogs(ctx, workerReq)
+		workerStream = stream
+		return err
+	})
+	if err != nil {
+		log.Printf("ERROR: Worker %s StreamJobLogs failed for job %s: %v", workerIP, req.Msg.JobId, err)
+		return connect.NewError(connect.CodeOf(err), fmt.Errorf("worker failed: %w", err))
+	}
+	defer workerStream.Close()
+
+	for workerStream.Receive() {
+		if err := stream.Send(workerStream.Msg()); err != nil {
+			return err
+		}
+	}
+	if err := workerStream.Err(); err != nil {
+		log.Printf("ERROR: Worker %s log stream failed for job %s: %v", workerIP, req.Msg.JobId, err)
+		return connect.NewError(connect.CodeOf(err), fmt.Errorf("worker failed: %w", err))
+	}
+	return nil
+}
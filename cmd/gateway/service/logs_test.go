@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	"github.com/alphauslabs/jennah/internal/hashing"
+)
+
+// logWorker is a worker that streams two log messages for any job of the
+// tenant it expects.
+type logWorker struct {
+	jennahv1connect.UnimplementedDeploymentServiceHandler
+	tenantID string
+}
+
+func (w *logWorker) StreamJobLogs(
+	ctx context.Context,
+	req *connect.Request[jennahv1.StreamJobLogsRequest],
+	stream *connect.ServerStream[jennahv1.StreamJobLogsResponse],
+) error {
+	if req.Header().Get("X-Tenant-Id") != w.tenantID {
+		return connect.NewError(connect.CodeNotFound, errors.New("job not found"))
+	}
+	for _, m := range []string{"hello", "bye"} {
+		if err := stream.Send(&jennahv1.StreamJobLogsResponse{Entries: []*jennahv1.LogEntry{{Message: m}}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestGatewayStreamJobLogsRelaysWorkerStream(t *testing.T) {
+	ctx := context.Background()
+	gw, _ := newTestGateway(t)
+	tenantResp, err := gw.GetCurrentTenant(ctx, withOAuth(&jennahv1.GetCurrentTenantRequest{}))
+	if err != nil {
+		t.Fatalf("GetCurrentTenant: %v", err)
+	}
+
+	workerMux := http.NewServeMux()
+	workerMux.Handle(jennahv1connect.NewDeploymentServiceHandler(&logWorker{tenantID: tenantResp.Msg.TenantId}))
+	worker := httptest.NewServer(workerMux)
+	defer worker.Close()
+	gw.router = hashing.NewRouter([]string{"worker-1"})
+	gw.workerClients = map[string]jennahv1connect.DeploymentServiceClient{
+		"worker-1": jennahv1connect.NewDeploymentServiceClient(worker.Client(), worker.URL),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(jennahv1connect.NewDeploymentServiceHandler(gw))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := jennahv1connect.NewDeploymentServiceClient(server.Client(), server.URL)
+
+	stream, err := client.StreamJobLogs(ctx, withOAuth(&jennahv1.StreamJobLogsRequest{JobId: "job-1"}))
+	if err != nil {
+		t.Fatalf("StreamJobLogs: %v", err)
+	}
+	var got []string
+	for stream.Receive() {
+		for _, e := range stream.Msg().Entries {
+			got = append(got, e.Message)
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	if len(got) != 2 || got[0] != "hello" || got[1] != "bye" {
+		t.Errorf("relayed entries = %v", got)
+	}
+}
+
+func TestGatewayGetJobLogsRequiresJobID(t *testing.T) {
+	gw, _ := newTestGateway(t)
+
+	_, err := gw.GetJobLogs(context.Background(), withOAuth(&jennahv1.GetJobLogsRequest{}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
@@ -11,6 +11,24 @@ import (
 	"google.golang.org/grpc/codes"
 )
 
+// authenticate returns the verified identity behind a request's bearer
+// token. Without an authenticator (local development), the X-OAuth-*
+// headers are trusted as they are.
+func (s *GatewayService) authenticate(ctx context.Context, headers http.Header) (*OAuthUser, error) {
+	if s.authenticator == nil {
+		return extractOAuthUser(headers)
+	}
+	identity, err := s.authenticator.Authenticate(ctx, headers)
+	if err != nil {
+		return nil, err
+	}
+	return &OAuthUser{
+		Email:    identity.Email,
+		UserId:   identity.UserId,
+		Provider: identity.Provider,
+	}, nil
+}
+
 func extractOAuthUser(headers http.Header) (*OAuthUser, error) {
 	email := headers.Get("X-OAuth-Email")
 	oauthUserId := headers.Get("X-OAuth-UserId")
@@ -29,10 +47,12 @@ func extractOAuthUser(headers http.Header) (*OAuthUser, error) {
 
 func (s *GatewayService) getOrCreateTenant(oauthUser *OAuthUser) (string, error) {
 	ctx := context.Background()
+	// User IDs are only unique within their provider.
+	cacheKey := oauthUser.Provider + "/" + oauthUser.UserId
 
 	// Check in-memory cache first (fast path)
 	s.mu.RLock()
-	tenantId, exists := s.oauthToTenant[oauthUser.UserId]
+	tenantId, exists := s.oauthToTenant[cacheKey]
 	s.mu.RUnlock()
 
 	if exists {
@@ -51,7 +71,7 @@ func (s *GatewayService) getOrCreateTenant(oauthUser *OAuthUser) (string, error)
 	if tenant != nil {
 		// Found existing tenant in database
 		s.mu.Lock()
-		s.oauthToTenant[oauthUser.UserId] = tenant.TenantId
+		s.oauthToTenant[cacheKey] = tenant.TenantId
 		s.mu.Unlock()
 
 		log.Printf("Found existing tenant in database for user %s: tenantId=%s",
@@ -64,7 +84,7 @@ func (s *GatewayService) getOrCreateTenant(oauthUser *OAuthUser) (string, error)
 	defer s.mu.Unlock()
 
 	// Double-check cache after acquiring write lock
-	if tenantId, exists = s.oauthToTenant[oauthUser.UserId]; exists {
+	if tenantId, exists = s.oauthToTenant[cacheKey]; exists {
 		log.Printf("Found tenant created by another request: tenantId=%s", tenantId)
 		return tenantId, nil
 	}
@@ -79,7 +99,7 @@ func (s *GatewayService) getOrCreateTenant(oauthUser *OAuthUser) (string, error)
 		if spanner.ErrCode(err) == codes.AlreadyExists {
 			log.Printf("Tenant %s already exists in database (created by another instance)", tenantId)
 			// Cache it and return
-			s.oauthToTenant[oauthUser.UserId] = tenantId
+			s.oauthToTenant[cacheKey] = tenantId
 			return tenantId, nil
 		}
 		log.Printf("Failed to insert tenant into database: %v", err)
@@ -87,7 +107,7 @@ func (s *GatewayService) getOrCreateTenant(oauthUser *OAuthUser) (string, error)
 	}
 
 	// Cache the OAuth -> Tenant mapping
-	s.oauthToTenant[oauthUser.UserId] = tenantId
+	s.oauthToTenant[cacheKey] = tenantId
 
 	log.Printf("Created new tenant for user %s (provider: %s): tenantId=%s (persisted to database)",
 		oauthUser.Email, oauthUser.Provider, tenantId)
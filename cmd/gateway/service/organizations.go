@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+// normalizeRole validates a role and returns it lower-cased.
+func normalizeRole(role string) (string, error) {
+	role = strings.ToLower(strings.TrimSpace(role))
+	if !slices.Contains(database.Roles, role) {
+		return "", fmt.Errorf("invalid role %q: want viewer, submitter or admin", role)
+	}
+	return role, nil
+}
+
+func dbOrganizationToProto(org *database.Organization, role string) *jennahv1.Organization {
+	return &jennahv1.Organization{
+		OrganizationId: org.TenantId,
+		Name:           org.Name,
+		Role:           role,
+		CreatedBy:      org.CreatedBy,
+		CreatedAt:      org.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func dbMemberToProto(m *database.OrganizationMember) *jennahv1.OrganizationMember {
+	p := &jennahv1.OrganizationMember{
+		Email:     m.Email,
+		Role:      m.Role,
+		CreatedAt: m.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: m.UpdatedAt.Format(time.RFC3339),
+	}
+	if m.AddedBy != nil {
+		p.AddedBy = *m.AddedBy
+	}
+	return p
+}
+
+func (s *GatewayService) CreateOrganization(
+	ctx context.Context,
+	req *connect.Request[jennahv1.CreateOrganizationRequest],
+) (*connect.Response[jennahv1.CreateOrganizationResponse], error) {
+	log.Printf("Received create organization request")
+
+	name := strings.TrimSpace(req.Msg.Name)
+	if name == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("name is required"))
+	}
+
+	user, err := s.authenticateUser(ctx, req.Header())
+	if err != nil {
+		return nil, err
+	}
+	email := normalizeEmail(user.Email)
+
+	org := &database.Organization{
+		TenantId:  uuid.NewString(),
+		Name:      name,
+		CreatedBy: email,
+	}
+	admin := &database.OrganizationMember{
+		TenantId: org.TenantId,
+		Email:    email,
+		Role:     database.RoleAdmin,
+		AddedBy:  &email,
+	}
+	if err := s.dbClient.InsertOrganization(ctx, org, admin); err != nil {
+		log.Printf("Failed to insert organization for %s: %v", email, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create organization: %w", err))
+	}
+
+	created, err := s.dbClient.GetOrganization(ctx, org.TenantId)
+	if err != nil {
+		log.Printf("Failed to get organization %s: %v", org.TenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get organization: %w", err))
+	}
+
+	log.Printf("Organization created: organizationId=%s, name=%q, admin=%s", created.TenantId, name, email)
+	return connect.NewResponse(&jennahv1.CreateOrganizationResponse{
+		Organization: dbOrganizationToProto(created, database.RoleAdmin),
+	}), nil
+}
+
+func (s *GatewayService) ListOrganizations(
+	ctx context.Context,
+	req *connect.Request[jennahv1.ListOrganizationsRequest],
+) (*connect.Response[jennahv1.ListOrganizationsResponse], error) {
+	user, err := s.authenticateUser(ctx, req.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := s.dbClient.ListMemberships(ctx, normalizeEmail(user.Email))
+	if err != nil {
+		log.Printf("Failed to list memberships of %s: %v", user.Email, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list organizations: %w", err))
+	}
+
+	orgs := make([]*jennahv1.Organization, 0, len(memberships))
+	for _, m := range memberships {
+		org, err := s.dbClient.GetOrganization(ctx, m.TenantId)
+		if err != nil {
+			log.Printf("Failed to get organization %s: %v", m.TenantId, err)
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get organization: %w", err))
+		}
+		orgs = append(orgs, dbOrganizationToProto(org, m.Role))
+	}
+	return connect.NewResponse(&jennahv1.ListOrganizationsResponse{Organizations: orgs}), nil
+}
+
+func (s *GatewayService) ListOrganizationMembers(
+	ctx context.Context,
+	req *connect.Request[jennahv1.ListOrganizationMembersRequest],
+) (*connect.Response[jennahv1.ListOrganizationMembersResponse], error) {
+	c, err := s.authorizeOrganization(ctx, req.Header(), database.ApiKeyScopeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.dbClient.ListOrganizationMembers(ctx, c.tenantId)
+	if err != nil {
+		log.Printf("Failed to list members of organization %s: %v", c.tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list members: %w", err))
+	}
+
+	protoMembers := make([]*jennahv1.OrganizationMember, 0, len(members))
+	for _, m := range members {
+		protoMembers = append(protoMembers, dbMemberToProto(m))
+	}
+	return connect.NewResponse(&jennahv1.ListOrganizationMembersResponse{Members: protoMembers}), nil
+}
+
+func (s *GatewayService) AddOrganizationMember(
+	ctx context.Context,
+	req *connect.Request[jennahv1.AddOrganizationMemberRequest],
+) (*connect.Response[jennahv1.AddOrganizationMemberResponse], error) {
+	email := normalizeEmail(req.Msg.Email)
+	if !strings.Contains(email, "@") {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("a valid email is required"))
+	}
+	role, err := normalizeRole(req.Msg.Role)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	c, err := s.authorizeOrganization(ctx, req.Header(), database.ApiKeyScopeAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.getOrganizationMember(ctx, c.tenantId, email)
+	switch {
+	case connect.CodeOf(err) == connect.CodeNotFound:
+		addedBy := c.principal()
+		member := &database.OrganizationMember{
+			TenantId: c.tenantId,
+			Email:    email,
+			Role:     role,
+			AddedBy:  &addedBy,
+		}
+		if err := s.dbClient.InsertOrganizationMember(ctx, member); err != nil {
+			log.Printf("Failed to add %s to organization %s: %v", email, c.tenantId, err)
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to add member: %w", err))
+		}
+		log.Printf("Organization member added: organizationId=%s, email=%s, role=%s", c.tenantId, email, role)
+	case err != nil:
+		return nil, err
+	case existing.Role != role:
+		if existing.Role == database.RoleAdmin {
+			if err := s.checkNotLastAdmin(ctx, c.tenantId, email); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.dbClient.UpdateOrganizationMemberRole(ctx, c.tenantId, email, role); err != nil {
+			log.Printf("Failed to change role of %s in organization %s: %v", email, c.tenantId, err)
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to change role: %w", err))
+		}
+		log.Printf("Organization member role changed: organizationId=%s, email=%s, role=%s -> %s", c.tenantId, email, existing.Role, role)
+	}
+
+	member, err := s.getOrganizationMember(ctx, c.tenantId, email)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&jennahv1.AddOrganizationMemberResponse{Member: dbMemberToProto(member)}), nil
+}
+
+func (s *GatewayService) RemoveOrganizationMember(
+	ctx context.Context,
+	req *connect.Request[jennahv1.RemoveOrganizationMemberRequest],
+) (*connect.Response[jennahv1.RemoveOrganizationMemberResponse], error) {
+	email := normalizeEmail(req.Msg.Email)
+	if email == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("email is required"))
+	}
+
+	c, err := s.authorizeOrganization(ctx, req.Header(), database.ApiKeyScopeAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.getOrganizationMember(ctx, c.tenantId, email)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role == database.RoleAdmin {
+		if err := s.checkNotLastAdmin(ctx, c.tenantId, email); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.dbClient.DeleteOrganizationMember(ctx, c.tenantId, email); err != nil {
+		log.Printf("Failed to remove %s from organization %s: %v", email, c.tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to remove member: %w", err))
+	}
+
+	log.Printf("Organization member removed: organizationId=%s, email=%s", c.tenantId, email)
+	return connect.NewResponse(&jennahv1.RemoveOrganizationMemberResponse{Email: email}), nil
+}
+
+// authorizeOrganization is authorize for RPCs that only make sense in an
+// organization. API keys of an organization qualify.
+func (s *GatewayService) authorizeOrganization(ctx context.Context, header http.Header, scope string) (*caller, error) {
+	c, err := s.authorize(ctx, header, scope)
+	if err != nil {
+		return nil, err
+	}
+	if c.org == nil && c.apiKey != nil {
+		org, err := s.dbClient.GetOrganization(ctx, c.tenantId)
+		if err != nil && spanner.ErrCode(err) != codes.NotFound {
+			log.Printf("Failed to get organization %s: %v", c.tenantId, err)
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get organization: %w", err))
+		}
+		c.org = org
+	}
+	if c.org == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("no organization selected: set the %s header", OrganizationHeader))
+	}
+	return c, nil
+}
+
+// checkNotLastAdmin refuses to demote or remove an organization's only
+// admin, which would leave nobody able to manage it.
+func (s *GatewayService) checkNotLastAdmin(ctx context.Context, tenantId, email string) error {
+	members, err := s.dbClient.ListOrganizationMembers(ctx, tenantId)
+	if err != nil {
+		log.Printf("Failed to list members of organization %s: %v", tenantId, err)
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list members: %w", err))
+	}
+	for _, m := range members {
+		if m.Role == database.RoleAdmin && m.Email != email {
+			return nil
+		}
+	}
+	return connect.NewError(connect.CodeFailedPrecondition, errors.New("an organization must keep at least one admin"))
+}
+
+// getOrganizationMember loads an organization member, mapping a missing row
+// to NotFound.
+func (s *GatewayService) getOrganizationMember(ctx context.Context, tenantId, email string) (*database.OrganizationMember, error) {
+	member, err := s.dbClient.GetOrganizationMember(ctx, tenantId, email)
+	if err != nil {
+		if spanner.ErrCode(err) == codes.NotFound {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("member not found: %s", email))
+		}
+		log.Printf("Failed to get member %s of organization %s: %v", email, tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get member: %w", err))
+	}
+	return member, nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+)
+
+// asMember builds a request from email acting in organization orgId.
+func asMember[T any](msg *T, email, orgId string) *connect.Request[T] {
+	req := connect.NewRequest(msg)
+	req.Header().Set("X-OAuth-Email", email)
+	req.Header().Set("X-OAuth-UserId", email)
+	req.Header().Set("X-OAuth-Provider", "google")
+	if orgId != "" {
+		req.Header().Set(OrganizationHeader, orgId)
+	}
+	return req
+}
+
+func TestGatewayOrganizationRoles(t *testing.T) {
+	ctx := context.Background()
+	gw, store := newTestGateway(t)
+
+	created, err := gw.CreateOrganization(ctx, withOAuth(&jennahv1.CreateOrganizationRequest{Name: "Platform"}))
+	if err != nil {
+		t.Fatalf("CreateOrganization: %v", err)
+	}
+	orgId := created.Msg.Organization.OrganizationId
+	if created.Msg.Organization.Role != "admin" {
+		t.Fatalf("creator role = %q, want admin", created.Msg.Organization.Role)
+	}
+
+	const admin = "dev@example.com"
+	if _, err := gw.AddOrganizationMember(ctx, asMember(&jennahv1.AddOrganizationMemberRequest{Email: "Viewer@Example.com", Role: "viewer"}, admin, orgId)); err != nil {
+		t.Fatalf("AddOrganizationMember(viewer): %v", err)
+	}
+	if _, err := gw.AddOrganizationMember(ctx, asMember(&jennahv1.AddOrganizationMemberRequest{Email: "ops@example.com", Role: "submitter"}, admin, orgId)); err != nil {
+		t.Fatalf("AddOrganizationMember(submitter): %v", err)
+	}
+
+	// Jobs in the organization are shared by its members.
+	if err := store.InsertJob(ctx, orgId, "job-1", "gcr.io/p/img:1", nil); err != nil {
+		t.Fatalf("InsertJob: %v", err)
+	}
+	list, err := gw.ListJobs(ctx, asMember(&jennahv1.ListJobsRequest{}, "viewer@example.com", orgId))
+	if err != nil || len(list.Msg.Jobs) != 1 {
+		t.Fatalf("viewer ListJobs = %+v, %v", list, err)
+	}
+
+	tenant, err := gw.GetCurrentTenant(ctx, asMember(&jennahv1.GetCurrentTenantRequest{}, "viewer@example.com", orgId))
+	if err != nil {
+		t.Fatalf("GetCurrentTenant: %v", err)
+	}
+	if tenant.Msg.TenantId != orgId || tenant.Msg.Role != "viewer" || tenant.Msg.UserEmail != "viewer@example.com" ||
+		tenant.Msg.Organization.GetName() != "Platform" {
+		t.Fatalf("GetCurrentTenant = %+v", tenant.Msg)
+	}
+
+	// Viewers cannot submit, cancel or manage members.
+	_, err = gw.SubmitJob(ctx, asMember(&jennahv1.SubmitJobRequest{ImageUri: "gcr.io/p/img:1"}, "viewer@example.com", orgId))
+	if connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("viewer SubmitJob: got %v, want PermissionDenied", err)
+	}
+	_, err = gw.CancelJob(ctx, asMember(&jennahv1.CancelJobRequest{JobId: "job-1"}, "viewer@example.com", orgId))
+	if connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("viewer CancelJob: got %v, want PermissionDenied", err)
+	}
+	_, err = gw.AddOrganizationMember(ctx, asMember(&jennahv1.AddOrganizationMemberRequest{Email: "x@example.com", Role: "admin"}, "ops@example.com", orgId))
+	if connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("submitter AddOrganizationMember: got %v, want PermissionDenied", err)
+	}
+
+	// Non-members are refused.
+	_, err = gw.ListJobs(ctx, asMember(&jennahv1.ListJobsRequest{}, "stranger@example.com", orgId))
+	if connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("non-member ListJobs: got %v, want PermissionDenied", err)
+	}
+
+	orgs, err := gw.ListOrganizations(ctx, asMember(&jennahv1.ListOrganizationsRequest{}, "ops@example.com", ""))
+	if err != nil || len(orgs.Msg.Organizations) != 1 || orgs.Msg.Organizations[0].Role != "submitter" {
+		t.Fatalf("ListOrganizations = %+v, %v", orgs, err)
+	}
+}
+
+func TestGatewayOrganizationKeepsAnAdmin(t *testing.T) {
+	ctx := context.Background()
+	gw, _ := newTestGateway(t)
+
+	created, err := gw.CreateOrganization(ctx, withOAuth(&jennahv1.CreateOrganizationRequest{Name: "Platform"}))
+	if err != nil {
+		t.Fatalf("CreateOrganization: %v", err)
+	}
+	orgId := created.Msg.Organization.OrganizationId
+	const admin = "dev@example.com"
+
+	_, err = gw.RemoveOrganizationMember(ctx, asMember(&jennahv1.RemoveOrganizationMemberRequest{Email: admin}, admin, orgId))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("removing the last admin: got %v, want FailedPrecondition", err)
+	}
+	_, err = gw.AddOrganizationMember(ctx, asMember(&jennahv1.AddOrganizationMemberRequest{Email: admin, Role: "viewer"}, admin, orgId))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("demoting the last admin: got %v, want FailedPrecondition", err)
+	}
+
+	// With a second admin, the first may leave.
+	if _, err := gw.AddOrganizationMember(ctx, asMember(&jennahv1.AddOrganizationMemberRequest{Email: "lead@example.com", Role: "admin"}, admin, orgId)); err != nil {
+		t.Fatalf("AddOrganizationMember: %v", err)
+	}
+	if _, err := gw.RemoveOrganizationMember(ctx, asMember(&jennahv1.RemoveOrganizationMemberRequest{Email: admin}, admin, orgId)); err != nil {
+		t.Fatalf("RemoveOrganizationMember: %v", err)
+	}
+	members, err := gw.ListOrganizationMembers(ctx, asMember(&jennahv1.ListOrganizationMembersRequest{}, "lead@example.com", orgId))
+	if err != nil || len(members.Msg.Members) != 1 || members.Msg.Members[0].Email != "lead@example.com" {
+		t.Fatalf("ListOrganizationMembers = %+v, %v", members, err)
+	}
+
+	// Membership RPCs need an organization.
+	_, err = gw.ListOrganizationMembers(ctx, withOAuth(&jennahv1.ListOrganizationMembersRequest{}))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("ListOrganizationMembers without an organization: got %v, want FailedPrecondition", err)
+	}
+}
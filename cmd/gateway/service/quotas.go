@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/quota"
+)
+
+// QueueReasonHeader tells the worker to store a submitted job as QUEUED
+// rather than start it. Its value is the limit the job is waiting on.
+const QueueReasonHeader = "X-Queue-Reason"
+
+// admitJob checks a submission against the tenant's quota. It returns the
+// reason to queue the job for, or "" to start it now; jobs that may not run
+// at all fail with ResourceExhausted.
+func (s *GatewayService) admitJob(ctx context.Context, tenantId string, req *jennahv1.SubmitJobRequest) (string, error) {
+	if s.quotas == nil {
+		return "", nil
+	}
+
+	decision, msg, err := s.quotas.Admit(ctx, tenantId, req, time.Now())
+	if err != nil {
+		log.Printf("Failed to check quota of tenant %s: %v", tenantId, err)
+		return "", connect.NewError(connect.CodeInternal, errors.New("failed to check quota"))
+	}
+	switch decision {
+	case quota.Queue:
+		log.Printf("Queueing job for tenant %s: %s", tenantId, msg)
+		return msg, nil
+	case quota.Reject:
+		log.Printf("Rejected job for tenant %s: %s", tenantId, msg)
+		return "", connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("quota exceeded: %s", msg))
+	}
+	return "", nil
+}
+
+// checkLaterJobs vets jobs that start later, the nodes of a workflow or the
+// template of a schedule, against the tenant's quota. A job the quota would
+// never let run fails the call with ResourceExhausted, as does a workflow
+// whose nodes go over the daily limit; capacity is checked when each job
+// starts. newJobs is how many jobs the call creates now.
+func (s *GatewayService) checkLaterJobs(ctx context.Context, tenantId string, reqs []*jennahv1.SubmitJobRequest, newJobs int64) error {
+	if s.quotas == nil {
+		return nil
+	}
+
+	for _, req := range reqs {
+		msg, err := s.quotas.CheckPolicy(ctx, tenantId, req)
+		if err != nil {
+			log.Printf("Failed to check quota of tenant %s: %v", tenantId, err)
+			return connect.NewError(connect.CodeInternal, errors.New("failed to check quota"))
+		}
+		if msg != "" {
+			log.Printf("Rejected jobs for tenant %s: %s", tenantId, msg)
+			return connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("quota exceeded: %s", msg))
+		}
+	}
+	if newJobs == 0 {
+		return nil
+	}
+	msg, err := s.quotas.CheckDailyLimit(ctx, tenantId, newJobs, time.Now())
+	if err != nil {
+		log.Printf("Failed to check quota of tenant %s: %v", tenantId, err)
+		return connect.NewError(connect.CodeInternal, errors.New("failed to check quota"))
+	}
+	if msg != "" {
+		log.Printf("Rejected jobs for tenant %s: %s", tenantId, msg)
+		return connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("quota exceeded: %s", msg))
+	}
+	return nil
+}
+
+func (s *GatewayService) GetTenantQuota(
+	ctx context.Context,
+	req *connect.Request[jennahv1.GetTenantQuotaRequest],
+) (*connect.Response[jennahv1.GetTenantQuotaResponse], error) {
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeRead)
+	if err != nil {
+		return nil, err
+	}
+	if s.quotas == nil {
+		return connect.NewResponse(&jennahv1.GetTenantQuotaResponse{}), nil
+	}
+
+	limits, err := s.quotas.Limits(ctx, tenantId)
+	if err != nil {
+		log.Printf("Failed to get quota of tenant %s: %v", tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get quota: %w", err))
+	}
+	usage, err := s.quotas.Usage(ctx, tenantId, time.Now())
+	if err != nil {
+		log.Printf("Failed to count jobs of tenant %s: %v", tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to count jobs: %w", err))
+	}
+
+	return connect.NewResponse(&jennahv1.GetTenantQuotaResponse{
+		Quota: &jennahv1.TenantQuota{
+			MaxConcurrentJobs:   limits.MaxConcurrentJobs,
+			MaxCpuMillis:        limits.MaxCpuMillis,
+			MaxMemoryMib:        limits.MaxMemoryMib,
+			MaxJobsPerDay:       limits.MaxJobsPerDay,
+			AllowedMachineTypes: limits.AllowedMachineTypes,
+			AllowSpotVms:        limits.AllowSpotVms,
+			QueueWhenBusy:       limits.QueueWhenBusy,
+		},
+		Usage: &jennahv1.TenantUsage{
+			ActiveJobs: usage.ActiveJobs,
+			QueuedJobs: usage.QueuedJobs,
+			CpuMillis:  usage.CpuMillis,
+			MemoryMib:  usage.MemoryMib,
+			JobsToday:  usage.JobsToday,
+		},
+		Enforced: true,
+	}), nil
+}
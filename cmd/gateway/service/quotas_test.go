@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/hashing"
+	"github.com/alphauslabs/jennah/internal/quota"
+)
+
+// queueWorker records the queue reason of the jobs submitted to it.
+type queueWorker struct {
+	jennahv1connect.UnimplementedDeploymentServiceHandler
+	reasons []string
+}
+
+func (w *queueWorker) SubmitJob(
+	ctx context.Context,
+	req *connect.Request[jennahv1.SubmitJobRequest],
+) (*connect.Response[jennahv1.SubmitJobResponse], error) {
+	reason := req.Header().Get(QueueReasonHeader)
+	w.reasons = append(w.reasons, reason)
+	status := database.JobStatusRunning
+	if reason != "" {
+		status = database.JobStatusQueued
+	}
+	return connect.NewResponse(&jennahv1.SubmitJobResponse{JobId: req.Msg.JobId, Status: status}), nil
+}
+
+func TestGatewaySubmitJobEnforcesQuota(t *testing.T) {
+	ctx := context.Background()
+	gw, store := newTestGateway(t)
+	tenantResp, err := gw.GetCurrentTenant(ctx, withOAuth(&jennahv1.GetCurrentTenantRequest{}))
+	if err != nil {
+		t.Fatalf("GetCurrentTenant: %v", err)
+	}
+	tenantID := tenantResp.Msg.TenantId
+	if err := store.InsertJobFull(ctx, &database.Job{TenantId: tenantID, JobId: "running", Status: database.JobStatusRunning, ImageUri: "img"}); err != nil {
+		t.Fatalf("InsertJobFull: %v", err)
+	}
+
+	worker := &queueWorker{}
+	workerMux := http.NewServeMux()
+	workerMux.Handle(jennahv1connect.NewDeploymentServiceHandler(worker))
+	server := httptest.NewServer(workerMux)
+	defer server.Close()
+	gw.router = hashing.NewRouter([]string{"worker-1"})
+	gw.workerClients = map[string]jennahv1connect.DeploymentServiceClient{
+		"worker-1": jennahv1connect.NewDeploymentServiceClient(server.Client(), server.URL),
+	}
+	gw.quotas = quota.NewChecker(store, quota.Limits{MaxConcurrentJobs: 1}, nil)
+
+	_, err = gw.SubmitJob(ctx, withOAuth(&jennahv1.SubmitJobRequest{ImageUri: "gcr.io/p/img:1"}))
+	if connect.CodeOf(err) != connect.CodeResourceExhausted || !strings.Contains(err.Error(), "1 of 1 concurrent jobs") {
+		t.Fatalf("SubmitJob over the concurrency limit: got %v, want ResourceExhausted", err)
+	}
+	_, err = gw.SubmitJob(ctx, withOAuth(&jennahv1.SubmitJobRequest{ImageUri: "gcr.io/p/img:1", UseSpotVms: true}))
+	if connect.CodeOf(err) != connect.CodeResourceExhausted || !strings.Contains(err.Error(), "spot VMs") {
+		t.Fatalf("SubmitJob with spot VMs: got %v, want ResourceExhausted", err)
+	}
+	if len(worker.reasons) != 0 {
+		t.Fatalf("rejected jobs reached the worker: %v", worker.reasons)
+	}
+
+	queue := true
+	if err := store.UpsertTenantQuota(ctx, &database.TenantQuota{TenantId: tenantID, QueueWhenBusy: &queue}); err != nil {
+		t.Fatalf("UpsertTenantQuota: %v", err)
+	}
+	resp, err := gw.SubmitJob(ctx, withOAuth(&jennahv1.SubmitJobRequest{ImageUri: "gcr.io/p/img:1"}))
+	if err != nil {
+		t.Fatalf("SubmitJob with queueing: %v", err)
+	}
+	if resp.Msg.Status != database.JobStatusQueued || len(worker.reasons) != 1 || !strings.Contains(worker.reasons[0], "concurrent jobs") {
+		t.Fatalf("SubmitJob = %+v, worker saw %v; want the job queued", resp.Msg, worker.reasons)
+	}
+
+	got, err := gw.GetTenantQuota(ctx, withOAuth(&jennahv1.GetTenantQuotaRequest{}))
+	if err != nil {
+		t.Fatalf("GetTenantQuota: %v", err)
+	}
+	if !got.Msg.Enforced || got.Msg.Quota.MaxConcurrentJobs != 1 || !got.Msg.Quota.QueueWhenBusy || got.Msg.Usage.ActiveJobs != 1 {
+		t.Fatalf("GetTenantQuota = %+v", got.Msg)
+	}
+}
+
+func TestGatewayChecksWorkflowsAndSchedulesAgainstQuota(t *testing.T) {
+	ctx := context.Background()
+	gw, store := newTestGateway(t)
+	gw.quotas = quota.NewChecker(store, quota.Limits{AllowedMachineTypes: []string{"e2-standard-4"}, MaxJobsPerDay: 2}, nil)
+
+	spot := &jennahv1.SubmitJobRequest{ImageUri: "gcr.io/p/img:1", UseSpotVms: true}
+	_, err := gw.CreateSchedule(ctx, withOAuth(&jennahv1.CreateScheduleRequest{CronExpression: "* * * * *", JobTemplate: spot}))
+	if connect.CodeOf(err) != connect.CodeResourceExhausted || !strings.Contains(err.Error(), "spot VMs") {
+		t.Fatalf("CreateSchedule with spot VMs: got %v, want ResourceExhausted", err)
+	}
+
+	node := func(id, machineType string) *jennahv1.WorkflowNode {
+		return &jennahv1.WorkflowNode{NodeId: id, Job: &jennahv1.SubmitJobRequest{ImageUri: "gcr.io/p/img:1", MachineType: machineType}}
+	}
+	_, err = gw.SubmitWorkflow(ctx, withOAuth(&jennahv1.SubmitWorkflowRequest{Nodes: []*jennahv1.WorkflowNode{node("a", ""), node("b", "n2-highmem-64")}}))
+	if connect.CodeOf(err) != connect.CodeResourceExhausted || !strings.Contains(err.Error(), `machine type "n2-highmem-64"`) {
+		t.Fatalf("SubmitWorkflow with a disallowed machine type: got %v, want ResourceExhausted", err)
+	}
+	_, err = gw.SubmitWorkflow(ctx, withOAuth(&jennahv1.SubmitWorkflowRequest{Nodes: []*jennahv1.WorkflowNode{node("a", ""), node("b", ""), node("c", "")}}))
+	if connect.CodeOf(err) != connect.CodeResourceExhausted || !strings.Contains(err.Error(), "daily jobs") {
+		t.Fatalf("SubmitWorkflow over the daily limit: got %v, want ResourceExhausted", err)
+	}
+}
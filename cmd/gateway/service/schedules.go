@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/cron"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+// normalizeOverlapPolicy maps the user-facing overlap policy onto the stored
+// constant. Empty means skip.
+func normalizeOverlapPolicy(policy string) (string, error) {
+	switch strings.ToUpper(strings.TrimSpace(policy)) {
+	case "", database.OverlapPolicySkip:
+		return database.OverlapPolicySkip, nil
+	case database.OverlapPolicyQueue:
+		return database.OverlapPolicyQueue, nil
+	case database.OverlapPolicyReplace:
+		return database.OverlapPolicyReplace, nil
+	default:
+		return "", fmt.Errorf("invalid overlap_policy %q: want skip, queue or replace", policy)
+	}
+}
+
+func dbScheduleToProto(sc *database.Schedule) *jennahv1.Schedule {
+	p := &jennahv1.Schedule{
+		ScheduleId:     sc.ScheduleId,
+		TenantId:       sc.TenantId,
+		CronExpression: sc.CronExpression,
+		Timezone:       sc.TimeZone,
+		OverlapPolicy:  sc.OverlapPolicy,
+		Paused:         sc.Paused,
+		NextRunAt:      sc.NextRunAt.Format(time.RFC3339),
+		CreatedAt:      sc.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      sc.UpdatedAt.Format(time.RFC3339),
+	}
+	if sc.Name != nil {
+		p.Name = *sc.Name
+	}
+	if sc.LastRunAt != nil {
+		p.LastRunAt = sc.LastRunAt.Format(time.RFC3339)
+	}
+	if sc.LastJobId != nil {
+		p.LastJobId = *sc.LastJobId
+	}
+	template := &jennahv1.SubmitJobRequest{}
+	if err := protojson.Unmarshal([]byte(sc.JobTemplateJson), template); err != nil {
+		log.Printf("Failed to decode job template for schedule %s: %v", sc.ScheduleId, err)
+	} else {
+		p.JobTemplate = template
+	}
+	return p
+}
+
+func (s *GatewayService) CreateSchedule(
+	ctx context.Context,
+	req *connect.Request[jennahv1.CreateScheduleRequest],
+) (*connect.Response[jennahv1.CreateScheduleResponse], error) {
+	log.Printf("Received create schedule request")
+
+	if req.Msg.JobTemplate == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("job_template is required"))
+	}
+	timeZone := strings.TrimSpace(req.Msg.Timezone)
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	nextRunAt, err := cron.NextInZone(req.Msg.CronExpression, timeZone, time.Now())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	overlapPolicy, err := normalizeOverlapPolicy(req.Msg.OverlapPolicy)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := batch.ValidateJobLabels(req.Msg.JobTemplate.GetLabels()); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeSubmit)
+	if err != nil {
+		return nil, err
+	}
+
+	template := req.Msg.JobTemplate
+	template.JobId = ""
+	template.IdempotencyKey = "" // every run is a new job
+	template.ImageUri, err = resolveSubmittedImageURI(template.GetImageUri(), template.GetEnvVars(), s.defaultDWPImageURI)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := s.checkLaterJobs(ctx, tenantId, []*jennahv1.SubmitJobRequest{template}, 0); err != nil {
+		return nil, err
+	}
+	templateJson, err := protojson.Marshal(template)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to encode job template: %w", err))
+	}
+
+	var name *string
+	if n := strings.TrimSpace(req.Msg.Name); n != "" {
+		name = &n
+	}
+	schedule := &database.Schedule{
+		TenantId:        tenantId,
+		ScheduleId:      uuid.NewString(),
+		Name:            name,
+		CronExpression:  strings.TrimSpace(req.Msg.CronExpression),
+		TimeZone:        timeZone,
+		OverlapPolicy:   overlapPolicy,
+		JobTemplateJson: string(templateJson),
+		NextRunAt:       nextRunAt,
+	}
+	if err := s.dbClient.InsertSchedule(ctx, schedule); err != nil {
+		log.Printf("Failed to insert schedule for tenant %s: %v", tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create schedule: %w", err))
+	}
+
+	created, err := s.dbClient.GetSchedule(ctx, tenantId, schedule.ScheduleId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to fetch schedule: %w", err))
+	}
+
+	log.Printf("Schedule created: scheduleId=%s, tenantId=%s, cron=%q, tz=%s, next=%s",
+		created.ScheduleId, tenantId, created.CronExpression, timeZone, nextRunAt.Format(time.RFC3339))
+	return connect.NewResponse(&jennahv1.CreateScheduleResponse{Schedule: dbScheduleToProto(created)}), nil
+}
+
+func (s *GatewayService) ListSchedules(
+	ctx context.Context,
+	req *connect.Request[jennahv1.ListSchedulesRequest],
+) (*connect.Response[jennahv1.ListSchedulesResponse], error) {
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.dbClient.ListSchedules(ctx, tenantId)
+	if err != nil {
+		log.Printf("Failed to list schedules for tenant %s: %v", tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list schedules: %w", err))
+	}
+
+	protoSchedules := make([]*jennahv1.Schedule, 0, len(schedules))
+	for _, sc := range schedules {
+		protoSchedules = append(protoSchedules, dbScheduleToProto(sc))
+	}
+	return connect.NewResponse(&jennahv1.ListSchedulesResponse{Schedules: protoSchedules}), nil
+}
+
+func (s *GatewayService) PauseSchedule(
+	ctx context.Context,
+	req *connect.Request[jennahv1.PauseScheduleRequest],
+) (*connect.Response[jennahv1.PauseScheduleResponse], error) {
+	if req.Msg.ScheduleId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("schedule_id is required"))
+	}
+
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeSubmit)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.getSchedule(ctx, tenantId, req.Msg.ScheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resuming starts from the next fire after now; fires missed while
+	// paused are not replayed.
+	nextRunAt := schedule.NextRunAt
+	if req.Msg.Resume {
+		nextRunAt, err = cron.NextInZone(schedule.CronExpression, schedule.TimeZone, time.Now())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+		}
+	}
+
+	if err := s.dbClient.SetSchedulePaused(ctx, tenantId, schedule.ScheduleId, !req.Msg.Resume, nextRunAt); err != nil {
+		log.Printf("Failed to update schedule %s for tenant %s: %v", schedule.ScheduleId, tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update schedule: %w", err))
+	}
+
+	updated, err := s.getSchedule(ctx, tenantId, schedule.ScheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	action := "paused"
+	if req.Msg.Resume {
+		action = "resumed"
+	}
+	log.Printf("Schedule %s: scheduleId=%s, tenantId=%s", action, schedule.ScheduleId, tenantId)
+	return connect.NewResponse(&jennahv1.PauseScheduleResponse{Schedule: dbScheduleToProto(updated)}), nil
+}
+
+func (s *GatewayService) DeleteSchedule(
+	ctx context.Context,
+	req *connect.Request[jennahv1.DeleteScheduleRequest],
+) (*connect.Response[jennahv1.DeleteScheduleResponse], error) {
+	if req.Msg.ScheduleId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("schedule_id is required"))
+	}
+
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeCancel)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getSchedule(ctx, tenantId, req.Msg.ScheduleId); err != nil {
+		return nil, err
+	}
+	if err := s.dbClient.DeleteSchedule(ctx, tenantId, req.Msg.ScheduleId); err != nil {
+		log.Printf("Failed to delete schedule %s for tenant %s: %v", req.Msg.ScheduleId, tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete schedule: %w", err))
+	}
+
+	log.Printf("Schedule deleted: scheduleId=%s, tenantId=%s", req.Msg.ScheduleId, tenantId)
+	return connect.NewResponse(&jennahv1.DeleteScheduleResponse{ScheduleId: req.Msg.ScheduleId}), nil
+}
+
+// getSchedule loads a tenant's schedule, mapping a missing row to NotFound.
+func (s *GatewayService) getSchedule(ctx context.Context, tenantId, scheduleId string) (*database.Schedule, error) {
+	schedule, err := s.dbClient.GetSchedule(ctx, tenantId, scheduleId)
+	if err != nil {
+		if spanner.ErrCode(err) == codes.NotFound {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("schedule not found: %s", scheduleId))
+		}
+		log.Printf("Failed to get schedule %s for tenant %s: %v", scheduleId, tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get schedule: %w", err))
+	}
+	return schedule, nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+func TestNormalizeOverlapPolicy(t *testing.T) {
+	cases := map[string]string{
+		"":        database.OverlapPolicySkip,
+		"skip":    database.OverlapPolicySkip,
+		" Queue ": database.OverlapPolicyQueue,
+		"REPLACE": database.OverlapPolicyReplace,
+	}
+	for in, want := range cases {
+		if got, err := normalizeOverlapPolicy(in); err != nil || got != want {
+			t.Errorf("normalizeOverlapPolicy(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := normalizeOverlapPolicy("parallel"); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
+
+func TestGatewayScheduleLifecycle(t *testing.T) {
+	ctx := context.Background()
+	gw, _ := newTestGateway(t)
+
+	created, err := gw.CreateSchedule(ctx, withOAuth(&jennahv1.CreateScheduleRequest{
+		Name:           "nightly",
+		CronExpression: "0 2 * * *",
+		Timezone:       "Asia/Tokyo",
+		OverlapPolicy:  "queue",
+		JobTemplate:    &jennahv1.SubmitJobRequest{JobId: "ignored", ImageUri: "gcr.io/p/img:1", Commands: []string{"run"}},
+	}))
+	if err != nil {
+		t.Fatalf("CreateSchedule: %v", err)
+	}
+	schedule := created.Msg.Schedule
+	if schedule.OverlapPolicy != database.OverlapPolicyQueue || schedule.Paused {
+		t.Errorf("unexpected schedule: %+v", schedule)
+	}
+	if schedule.JobTemplate.GetImageUri() != "gcr.io/p/img:1" || schedule.JobTemplate.GetJobId() != "" {
+		t.Errorf("job template = %+v", schedule.JobTemplate)
+	}
+	next, err := time.Parse(time.RFC3339, schedule.NextRunAt)
+	if err != nil {
+		t.Fatalf("NextRunAt %q: %v", schedule.NextRunAt, err)
+	}
+	if next.UTC().Hour() != 17 || next.Minute() != 0 { // 02:00 JST
+		t.Errorf("NextRunAt = %s, want 17:00 UTC", next.UTC())
+	}
+
+	paused, err := gw.PauseSchedule(ctx, withOAuth(&jennahv1.PauseScheduleRequest{ScheduleId: schedule.ScheduleId}))
+	if err != nil || !paused.Msg.Schedule.Paused {
+		t.Fatalf("PauseSchedule: %v, %+v", err, paused)
+	}
+	resumed, err := gw.PauseSchedule(ctx, withOAuth(&jennahv1.PauseScheduleRequest{ScheduleId: schedule.ScheduleId, Resume: true}))
+	if err != nil || resumed.Msg.Schedule.Paused {
+		t.Fatalf("resume: %v, %+v", err, resumed)
+	}
+
+	list, err := gw.ListSchedules(ctx, withOAuth(&jennahv1.ListSchedulesRequest{}))
+	if err != nil || len(list.Msg.Schedules) != 1 {
+		t.Fatalf("ListSchedules: %v, %+v", err, list)
+	}
+
+	if _, err := gw.DeleteSchedule(ctx, withOAuth(&jennahv1.DeleteScheduleRequest{ScheduleId: schedule.ScheduleId})); err != nil {
+		t.Fatalf("DeleteSchedule: %v", err)
+	}
+	_, err = gw.DeleteSchedule(ctx, withOAuth(&jennahv1.DeleteScheduleRequest{ScheduleId: schedule.ScheduleId}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("second delete: got %v, want NotFound", err)
+	}
+}
+
+func TestGatewayCreateScheduleValidation(t *testing.T) {
+	gw, _ := newTestGateway(t)
+	template := &jennahv1.SubmitJobRequest{ImageUri: "img"}
+
+	for name, req := range map[string]*jennahv1.CreateScheduleRequest{
+		"bad cron":      {CronExpression: "every day", JobTemplate: template},
+		"bad time zone": {CronExpression: "@daily", Timezone: "Nowhere/City", JobTemplate: template},
+		"bad policy":    {CronExpression: "@daily", OverlapPolicy: "parallel", JobTemplate: template},
+		"no template":   {CronExpression: "@daily"},
+	} {
+		_, err := gw.CreateSchedule(context.Background(), withOAuth(req))
+		if connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("%s: got %v, want InvalidArgument", name, err)
+		}
+	}
+}
@@ -3,37 +3,57 @@ package service
 import (
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/alphauslabs/jennah/cmd/gateway/auth"
 	jennahv1connect "github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
 	"github.com/alphauslabs/jennah/internal/database"
 	"github.com/alphauslabs/jennah/internal/hashing"
+	"github.com/alphauslabs/jennah/internal/quota"
 )
 
 type GatewayService struct {
 	jennahv1connect.UnimplementedDeploymentServiceHandler
 	router             *hashing.Router
-	workerClients      map[string]jennahv1connect.DeploymentServiceClient
-	dbClient           *database.Client
+	workerClients      map[string]jennahv1connect.DeploymentServiceClient // Key: worker address
+	workersMu          sync.RWMutex                                       // guards workerClients, unhealthyWorkers and fullWorkers
+	unhealthyWorkers   map[string]time.Time                               // until when an unreachable worker is skipped
+	fullWorkers        map[string]bool                                    // workers at capacity, skipped for new jobs
+	workerAddresses    map[string]string                                  // Key: registered worker ID
+	newWorkerClient    func(address string) jennahv1connect.DeploymentServiceClient
+	dbClient           database.Store
 	defaultDWPImageURI string
+	authenticator      *auth.Authenticator // nil trusts the X-OAuth-* headers (local development only)
+	quotas             *quota.Checker      // nil admits every job
+	idempotencyKeyTTL  time.Duration       // how long SubmitJob idempotency keys are honoured
 	mu                 sync.RWMutex
-	oauthToTenant      map[string]string
+	oauthToTenant      map[string]string // Key: "provider/userId"
 }
 
 func NewGatewayService(
 	router *hashing.Router,
 	workerClients map[string]jennahv1connect.DeploymentServiceClient,
-	dbClient *database.Client,
+	dbClient database.Store,
 	defaultDWPImageURI string,
+	authenticator *auth.Authenticator,
+	quotas *quota.Checker,
+	idempotencyKeyTTL time.Duration,
 ) *GatewayService {
 	if strings.TrimSpace(defaultDWPImageURI) == "" {
 		defaultDWPImageURI = DefaultDWPImageURI
 	}
+	if idempotencyKeyTTL <= 0 {
+		idempotencyKeyTTL = DefaultIdempotencyKeyTTL
+	}
 
 	return &GatewayService{
 		router:             router,
 		workerClients:      workerClients,
 		dbClient:           dbClient,
 		defaultDWPImageURI: defaultDWPImageURI,
+		authenticator:      authenticator,
+		quotas:             quotas,
+		idempotencyKeyTTL:  idempotencyKeyTTL,
 		oauthToTenant:      make(map[string]string),
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/notifier"
 )
 
 const (
@@ -22,6 +23,7 @@ type sseNotification struct {
 	ID              string `json:"id"`
 	JobID           string `json:"job_id"`
 	JobName         string `json:"job_name,omitempty"`
+	EventType       string `json:"event_type"`
 	FinalStatus     string `json:"final_status"`
 	ServiceTier     string `json:"service_tier,omitempty"`
 	AssignedService string `json:"assigned_service,omitempty"`
@@ -34,6 +36,7 @@ func dbNotifToSSE(n *database.Notification) sseNotification {
 	s := sseNotification{
 		ID:          n.NotificationId,
 		JobID:       n.JobId,
+		EventType:   notificationEventType(n),
 		FinalStatus: n.FinalStatus,
 		OccurredAt:  n.OccurredAt.Unix(),
 		IsRead:      n.IsRead,
@@ -53,12 +56,23 @@ func dbNotifToSSE(n *database.Notification) sseNotification {
 	return s
 }
 
+// notificationEventType returns the type of the event a notification came
+// from. Notifications stored before event types existed are terminal.
+func notificationEventType(n *database.Notification) string {
+	if n.EventType == nil || *n.EventType == "" {
+		return notifier.EventTypeTerminal
+	}
+	return *n.EventType
+}
+
 // SSENotificationsHandler returns an http.Handler that streams real-time
 // notifications to the authenticated frontend client via Server-Sent Events.
 //
 // The handler resolves the tenant from OAuth headers, then polls Spanner for
 // new notifications and writes them as SSE "notification" events. A keepalive
-// comment is sent periodically to prevent connection timeouts.
+// comment is sent periodically to prevent connection timeouts. Only
+// job.terminal notifications are streamed unless the event_types query
+// parameter lists other types (comma-separated, "*" for all).
 //
 // The stream ends when the client disconnects or the server shuts down.
 func (s *GatewayService) SSENotificationsHandler() http.Handler {
@@ -83,6 +97,11 @@ func (s *GatewayService) SSENotificationsHandler() http.Handler {
 		w.Header().Set("X-Accel-Buffering", "no") // disable nginx buffering
 		flusher.Flush()
 
+		eventTypes := notifier.ParseEventTypes(r.URL.Query().Get("event_types"))
+		if len(eventTypes) == 0 {
+			eventTypes = []string{notifier.EventTypeTerminal}
+		}
+
 		log.Printf("SSE stream opened for tenant %s", tenantID)
 		defer log.Printf("SSE stream closed for tenant %s", tenantID)
 
@@ -121,6 +140,9 @@ func (s *GatewayService) SSENotificationsHandler() http.Handler {
 				}
 
 				for _, n := range notifications {
+					if !notifier.MatchesEventType(eventTypes, notificationEventType(n)) {
+						continue
+					}
 					payload := dbNotifToSSE(n)
 					data, err := json.Marshal(payload)
 					if err != nil {
@@ -143,13 +165,22 @@ func (s *GatewayService) SSENotificationsHandler() http.Handler {
 	})
 }
 
-// resolveTenantFromHTTP extracts OAuth headers from an *http.Request (not a
-// ConnectRPC request) and resolves or creates the tenant. This mirrors
-// resolveTenant but works with raw HTTP handlers.
+// resolveTenantFromHTTP authenticates an *http.Request (not a ConnectRPC
+// request) and resolves or creates the tenant through resolveTenant, for
+// raw HTTP handlers. Browsers cannot set headers on an
+// EventSource, so the bearer token may also be passed as the access_token
+// query parameter (the provider as provider, and the organization as
+// organization).
 func (s *GatewayService) resolveTenantFromHTTP(r *http.Request) (string, error) {
-	oauthUser, err := extractOAuthUser(r.Header)
-	if err != nil {
-		return "", err
+	header := r.Header.Clone()
+	if token := r.URL.Query().Get("access_token"); token != "" && header.Get("Authorization") == "" {
+		header.Set("Authorization", "Bearer "+token)
+		if provider := r.URL.Query().Get("provider"); provider != "" {
+			header.Set("X-OAuth-Provider", provider)
+		}
+	}
+	if org := r.URL.Query().Get("organization"); org != "" && header.Get(OrganizationHeader) == "" {
+		header.Set(OrganizationHeader, org)
 	}
-	return s.getOrCreateTenant(oauthUser)
+	return s.resolveTenant(r.Context(), header, database.ApiKeyScopeRead)
 }
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"connectrpc.com/connect"
+	"google.golang.org/grpc/codes"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+// terminalStatuses are the statuses a job never leaves.
+var terminalStatuses = []string{
+	database.JobStatusCompleted, database.JobStatusFailed,
+	database.JobStatusCancelled, database.JobStatusSkipped,
+}
+
+// GetJobTimeline lists a job's state transitions oldest first, with how long
+// the job stayed in each state, the worker that observed each change and what
+// the provider reported with it.
+func (s *GatewayService) GetJobTimeline(
+	ctx context.Context,
+	req *connect.Request[jennahv1.GetJobTimelineRequest],
+) (*connect.Response[jennahv1.GetJobTimelineResponse], error) {
+	log.Printf("Received get job timeline request")
+
+	if req.Msg.JobId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("job_id is required"))
+	}
+
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.dbClient.GetJob(ctx, tenantId, req.Msg.JobId)
+	if err != nil {
+		if spanner.ErrCode(err) == codes.NotFound {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("job not found: %s", req.Msg.JobId))
+		}
+		log.Printf("Failed to get job %s for tenant %s: %v", req.Msg.JobId, tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get job: %w", err))
+	}
+
+	transitions, err := s.dbClient.GetJobTransitions(ctx, tenantId, req.Msg.JobId)
+	if err != nil {
+		log.Printf("Failed to get transitions of job %s for tenant %s: %v", req.Msg.JobId, tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get job transitions: %w", err))
+	}
+
+	return connect.NewResponse(&jennahv1.GetJobTimelineResponse{
+		JobId:   job.JobId,
+		Status:  job.Status,
+		Entries: jobTimeline(job, transitions, time.Now()),
+	}), nil
+}
+
+// jobTimeline orders transitions oldest first and derives the time spent in
+// each state. Jobs are created in their initial status without a transition,
+// so that status is added as the first entry, starting at CreatedAt.
+func jobTimeline(job *database.Job, transitions []*database.JobStateTransition, now time.Time) []*jennahv1.JobTimelineEntry {
+	transitions = slices.Clone(transitions)
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].TransitionedAt.Before(transitions[j].TransitionedAt)
+	})
+
+	var entries []*jennahv1.JobTimelineEntry
+	var enteredAt []time.Time
+	add := func(entry *jennahv1.JobTimelineEntry, at time.Time) {
+		entry.TransitionedAt = at.Format(time.RFC3339)
+		entries = append(entries, entry)
+		enteredAt = append(enteredAt, at)
+	}
+
+	switch {
+	case len(transitions) == 0:
+		add(&jennahv1.JobTimelineEntry{ToStatus: job.Status, Reason: "Job created"}, job.CreatedAt)
+	case transitions[0].FromStatus != nil:
+		add(&jennahv1.JobTimelineEntry{ToStatus: *transitions[0].FromStatus, Reason: "Job created"}, job.CreatedAt)
+	}
+	for _, t := range transitions {
+		add(dbTransitionToTimelineEntry(t), t.TransitionedAt)
+	}
+
+	for i, entry := range entries {
+		var until time.Time
+		switch {
+		case i+1 < len(entries):
+			until = enteredAt[i+1]
+		case !slices.Contains(terminalStatuses, entry.ToStatus):
+			until = now
+		default:
+			continue
+		}
+		if d := until.Sub(enteredAt[i]); d > 0 {
+			entry.DurationSeconds = int64(d / time.Second)
+		}
+	}
+	return entries
+}
+
+func dbTransitionToTimelineEntry(t *database.JobStateTransition) *jennahv1.JobTimelineEntry {
+	entry := &jennahv1.JobTimelineEntry{ToStatus: t.ToStatus}
+	if t.FromStatus != nil {
+		entry.FromStatus = *t.FromStatus
+	}
+	if t.WorkerId != nil {
+		entry.WorkerId = *t.WorkerId
+	}
+	if t.Reason != nil {
+		entry.Reason = *t.Reason
+	}
+	if t.ProviderDetail != nil {
+		entry.ProviderDetail = *t.ProviderDetail
+	}
+	return entry
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+func TestJobTimelineDurations(t *testing.T) {
+	created := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	job := &database.Job{JobId: "job-1", Status: database.JobStatusRunning, CreatedAt: created}
+	scheduled, worker, detail := database.JobStatusScheduled, "worker-1", "VM quota exhausted in us-central1"
+	// Newest first, as the store returns them.
+	transitions := []*database.JobStateTransition{{
+		FromStatus:     &scheduled,
+		ToStatus:       database.JobStatusRunning,
+		TransitionedAt: created.Add(20 * time.Minute),
+		WorkerId:       &worker,
+		ProviderDetail: &detail,
+	}}
+
+	entries := jobTimeline(job, transitions, created.Add(25*time.Minute))
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	first, second := entries[0], entries[1]
+	if first.ToStatus != database.JobStatusScheduled || first.FromStatus != "" || first.DurationSeconds != 1200 {
+		t.Errorf("first entry = %v, want SCHEDULED for 1200s", first)
+	}
+	if second.FromStatus != database.JobStatusScheduled || second.ToStatus != database.JobStatusRunning ||
+		second.DurationSeconds != 300 || second.WorkerId != worker || second.ProviderDetail != detail {
+		t.Errorf("second entry = %v, want RUNNING for 300s observed by worker-1", second)
+	}
+
+	// A job in a terminal state spends no time counted in it.
+	job.Status = database.JobStatusCompleted
+	running := database.JobStatusRunning
+	transitions = append([]*database.JobStateTransition{{
+		FromStatus:     &running,
+		ToStatus:       database.JobStatusCompleted,
+		TransitionedAt: created.Add(30 * time.Minute),
+	}}, transitions...)
+	entries = jobTimeline(job, transitions, created.Add(time.Hour))
+	if len(entries) != 3 || entries[1].DurationSeconds != 600 || entries[2].DurationSeconds != 0 {
+		t.Fatalf("entries = %v, want RUNNING for 600s then COMPLETED", entries)
+	}
+}
+
+func TestGatewayGetJobTimeline(t *testing.T) {
+	ctx := context.Background()
+	gw, store := newTestGateway(t)
+	tenantResp, err := gw.GetCurrentTenant(ctx, withOAuth(&jennahv1.GetCurrentTenantRequest{}))
+	if err != nil {
+		t.Fatalf("GetCurrentTenant: %v", err)
+	}
+	tenantID := tenantResp.Msg.TenantId
+
+	job := &database.Job{TenantId: tenantID, JobId: "job-1", Status: database.JobStatusRunning, ImageUri: "img"}
+	if err := store.InsertJobFull(ctx, job); err != nil {
+		t.Fatalf("InsertJobFull: %v", err)
+	}
+	worker := "worker-1"
+	for i, to := range []string{database.JobStatusScheduled, database.JobStatusRunning} {
+		from := database.JobStatusPending
+		if i > 0 {
+			from = database.JobStatusScheduled
+		}
+		if err := store.RecordStateTransition(ctx, tenantID, "job-1", to, &from, to, nil, &worker, nil); err != nil {
+			t.Fatalf("RecordStateTransition: %v", err)
+		}
+	}
+
+	resp, err := gw.GetJobTimeline(ctx, withOAuth(&jennahv1.GetJobTimelineRequest{JobId: "job-1"}))
+	if err != nil {
+		t.Fatalf("GetJobTimeline: %v", err)
+	}
+	var path []string
+	for _, e := range resp.Msg.Entries {
+		path = append(path, e.ToStatus)
+	}
+	if len(path) != 3 || path[0] != "PENDING" || path[1] != "SCHEDULED" || path[2] != "RUNNING" {
+		t.Fatalf("timeline = %v, want [PENDING SCHEDULED RUNNING]", path)
+	}
+	if resp.Msg.Status != database.JobStatusRunning || resp.Msg.Entries[2].WorkerId != worker {
+		t.Fatalf("response = %v, want status RUNNING with worker-1 on the last entry", resp.Msg)
+	}
+
+	if _, err := gw.GetJobTimeline(ctx, withOAuth(&jennahv1.GetJobTimelineRequest{JobId: "missing"})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("GetJobTimeline of a missing job: got %v, want NotFound", err)
+	}
+	if _, err := gw.GetJobTimeline(ctx, withOAuth(&jennahv1.GetJobTimelineRequest{})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("GetJobTimeline without a job_id: got %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1connect "github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+const (
+	// DefaultWorkerPort is the port of worker addresses given without one.
+	DefaultWorkerPort = "8081"
+
+	// maxWorkerAttempts bounds how many ring members a request tries.
+	maxWorkerAttempts = 3
+
+	// workerUnhealthyFor is how long a worker that could not be reached is
+	// skipped before requests try it again.
+	workerUnhealthyFor = 30 * time.Second
+
+	// WorkerDrainingHeader marks the errors of a worker that rejects submits
+	// while it drains.
+	WorkerDrainingHeader = "X-Worker-Draining"
+)
+
+// NewWorkerClient returns a client for the worker at address, a host or
+// host:port (DefaultWorkerPort when the port is missing).
+func NewWorkerClient(httpClient *http.Client, address string) jennahv1connect.DeploymentServiceClient {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, DefaultWorkerPort)
+	}
+	return jennahv1connect.NewDeploymentServiceClient(httpClient, "http://"+address)
+}
+
+// StartWorkerRegistry routes to the workers registered in the database
+// instead of a fixed list. Every interval the ring is rebuilt from the workers
+// whose last heartbeat is newer than ttl; the others are dropped.
+func (s *GatewayService) StartWorkerRegistry(ctx context.Context, httpClient *http.Client, interval, ttl time.Duration) error {
+	s.workersMu.Lock()
+	s.newWorkerClient = func(address string) jennahv1connect.DeploymentServiceClient {
+		return NewWorkerClient(httpClient, address)
+	}
+	s.workersMu.Unlock()
+
+	if err := s.refreshWorkers(ctx, ttl); err != nil {
+		return err
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.refreshWorkers(ctx, ttl); err != nil {
+					log.Printf("Worker registry refresh failed: %v", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// refreshWorkers puts the live registered workers on the ring. A registry
+// without live workers leaves the ring as it is, so a database hiccup does
+// not stop all routing.
+func (s *GatewayService) refreshWorkers(ctx context.Context, ttl time.Duration) error {
+	workers, err := s.dbClient.ListWorkers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list workers: %w", err)
+	}
+
+	var live []*database.Worker
+	for _, w := range workers {
+		if time.Since(w.LastHeartbeatAt) <= ttl {
+			live = append(live, w)
+		}
+	}
+	if len(live) == 0 {
+		log.Printf("Worker registry has no live workers; keeping %v", s.router.Members())
+		return nil
+	}
+	s.setWorkers(live)
+	return nil
+}
+
+// setWorkers makes workers the members of the ring, creating clients for new
+// ones. Workers at capacity stay on the ring but receive no new jobs.
+func (s *GatewayService) setWorkers(workers []*database.Worker) {
+	s.workersMu.Lock()
+	defer s.workersMu.Unlock()
+
+	addresses := make([]string, 0, len(workers))
+	clients := make(map[string]jennahv1connect.DeploymentServiceClient, len(workers))
+	ids := make(map[string]string, len(workers))
+	full := make(map[string]bool)
+	for _, w := range workers {
+		addresses = append(addresses, w.Address)
+		client, ok := s.workerClients[w.Address]
+		if !ok {
+			client = s.newWorkerClient(w.Address)
+		}
+		clients[w.Address] = client
+		ids[w.WorkerId] = w.Address
+		if w.Capacity > 0 && w.ActiveJobs >= w.Capacity {
+			full[w.Address] = true
+		}
+	}
+
+	current := s.router.Members()
+	if !slices.Equal(current, slices.Sorted(slices.Values(addresses))) {
+		log.Printf("Worker ring: %v → %v", current, addresses)
+	}
+	s.router.SetMembers(addresses)
+	s.workerClients = clients
+	s.workerAddresses = ids
+	s.fullWorkers = full
+}
+
+// candidateWorkers returns the workers to try for routingKey, in order: the
+// key's ring owner and the next members, skipping workers marked unhealthy
+// and, for new jobs, workers at capacity. When every candidate is skipped
+// they are all returned, since trying beats failing outright.
+func (s *GatewayService) candidateWorkers(routingKey string, newJob bool) []string {
+	s.workersMu.RLock()
+	defer s.workersMu.RUnlock()
+
+	ips := s.router.GetWorkerIPs(routingKey, maxWorkerAttempts)
+	var usable []string
+	for _, ip := range ips {
+		if time.Now().Before(s.unhealthyWorkers[ip]) || (newJob && s.fullWorkers[ip]) {
+			continue
+		}
+		usable = append(usable, ip)
+	}
+	if len(usable) == 0 {
+		return ips
+	}
+	return usable
+}
+
+func (s *GatewayService) workerClient(workerIP string) (jennahv1connect.DeploymentServiceClient, bool) {
+	s.workersMu.RLock()
+	defer s.workersMu.RUnlock()
+	client, ok := s.workerClients[workerIP]
+	return client, ok
+}
+
+// markWorkerUnhealthy skips a worker for workerUnhealthyFor.
+func (s *GatewayService) markWorkerUnhealthy(workerIP string) {
+	s.workersMu.Lock()
+	defer s.workersMu.Unlock()
+	if s.unhealthyWorkers == nil {
+		s.unhealthyWorkers = make(map[string]time.Time)
+	}
+	s.unhealthyWorkers[workerIP] = time.Now().Add(workerUnhealthyFor)
+}
+
+func (s *GatewayService) markWorkerHealthy(workerIP string) {
+	s.workersMu.Lock()
+	defer s.workersMu.Unlock()
+	delete(s.unhealthyWorkers, workerIP)
+}
+
+// callWorker calls call with the first candidate worker for routingKey. A
+// worker that cannot be reached is marked unhealthy and the next candidate is
+// tried, up to maxWorkerAttempts workers. Other errors are returned as they
+// are. It returns the worker that answered.
+func (s *GatewayService) callWorker(routingKey string, newJob bool, call func(client jennahv1connect.DeploymentServiceClient) error) (string, error) {
+	return s.callWorkers(routingKey, s.candidateWorkers(routingKey, newJob), call)
+}
+
+// callJobWorker calls call with the worker that holds the lease of the job,
+// or, when the job has no live owner, with the job's healthy ring members,
+// which claim the lease when the call reaches them.
+func (s *GatewayService) callJobWorker(ctx context.Context, tenantId, jobId string, call func(client jennahv1connect.DeploymentServiceClient) error) (string, error) {
+	candidates := s.candidateWorkers(jobId, false)
+	job, err := s.dbClient.GetJob(ctx, tenantId, jobId)
+	if err != nil {
+		// The worker reports a job that does not exist.
+		return s.callWorkers(jobId, candidates, call)
+	}
+	if owner := s.ownerWorker(job); owner != "" {
+		candidates = append([]string{owner}, slices.DeleteFunc(candidates, func(ip string) bool { return ip == owner })...)
+	} else if job.OwnerWorkerId != nil && *job.OwnerWorkerId != "" {
+		log.Printf("Owner %s of job %s is gone; routing to %v", *job.OwnerWorkerId, jobId, candidates)
+	}
+	return s.callWorkers(jobId, candidates, call)
+}
+
+// ownerWorker returns the address of the worker holding the job's lease, or
+// "" when the lease has expired or the owner is unknown or unreachable. Owners
+// are found in the worker registry; without it, only workers whose ID is
+// their address in --worker-ips are found.
+func (s *GatewayService) ownerWorker(job *database.Job) string {
+	if job.OwnerWorkerId == nil || *job.OwnerWorkerId == "" {
+		return ""
+	}
+	if job.LeaseExpiresAt == nil || job.LeaseExpiresAt.Before(time.Now()) {
+		return ""
+	}
+
+	s.workersMu.RLock()
+	defer s.workersMu.RUnlock()
+	address, ok := s.workerAddresses[*job.OwnerWorkerId]
+	if !ok {
+		address = *job.OwnerWorkerId
+	}
+	if _, ok := s.workerClients[address]; !ok || time.Now().Before(s.unhealthyWorkers[address]) {
+		return ""
+	}
+	return address
+}
+
+func (s *GatewayService) callWorkers(routingKey string, candidates []string, call func(client jennahv1connect.DeploymentServiceClient) error) (string, error) {
+	if len(candidates) == 0 {
+		log.Printf("No worker found for routingKey: %s", routingKey)
+		return "", connect.NewError(connect.CodeInternal, errors.New("no worker found for routing key"))
+	}
+
+	var err error
+	for _, workerIP := range candidates {
+		client, ok := s.workerClient(workerIP)
+		if !ok {
+			log.Printf("No worker client found for IP: %s", workerIP)
+			err = connect.NewError(connect.CodeInternal, fmt.Errorf("no worker client found for IP: %s", workerIP))
+			continue
+		}
+		err = call(client)
+		if !isWorkerUnreachable(err) {
+			s.markWorkerHealthy(workerIP)
+			return workerIP, err
+		}
+		log.Printf("Worker %s unreachable, trying the next ring member: %v", workerIP, err)
+		s.markWorkerUnhealthy(workerIP)
+	}
+	return "", err
+}
+
+// isWorkerUnreachable reports whether err means the request never reached
+// the worker, or the worker turned it away because it is draining, so it is
+// safe to send it to another one.
+func isWorkerUnreachable(err error) bool {
+	if connect.CodeOf(err) != connect.CodeUnavailable {
+		return false
+	}
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) && connectErr.Meta().Get(WorkerDrainingHeader) == "true" {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
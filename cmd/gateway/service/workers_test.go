@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/hashing"
+)
+
+func TestGatewayRefreshWorkers(t *testing.T) {
+	ctx := context.Background()
+	gw, store := newTestGateway(t)
+	gw.router = hashing.NewRouter([]string{"static-1"})
+	gw.newWorkerClient = func(address string) jennahv1connect.DeploymentServiceClient {
+		return NewWorkerClient(http.DefaultClient, address)
+	}
+
+	// An empty registry keeps the static workers.
+	if err := gw.refreshWorkers(ctx, time.Minute); err != nil {
+		t.Fatalf("refreshWorkers: %v", err)
+	}
+	if got := gw.router.Members(); !slices.Equal(got, []string{"static-1"}) {
+		t.Fatalf("members = %v, want the static worker kept", got)
+	}
+
+	if err := store.UpsertWorker(ctx, &database.Worker{WorkerId: "w-stale", Address: "10.0.0.1:8081"}); err != nil {
+		t.Fatalf("UpsertWorker: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	for _, w := range []*database.Worker{
+		{WorkerId: "w-1", Address: "10.0.0.2:8081", Capacity: 10, ActiveJobs: 3},
+		{WorkerId: "w-2", Address: "10.0.0.3:8081", Capacity: 2, ActiveJobs: 2},
+	} {
+		if err := store.UpsertWorker(ctx, w); err != nil {
+			t.Fatalf("UpsertWorker: %v", err)
+		}
+	}
+
+	if err := gw.refreshWorkers(ctx, 100*time.Millisecond); err != nil {
+		t.Fatalf("refreshWorkers: %v", err)
+	}
+	if got := gw.router.Members(); !slices.Equal(got, []string{"10.0.0.2:8081", "10.0.0.3:8081"}) {
+		t.Fatalf("members = %v, want the two live workers", got)
+	}
+	if _, ok := gw.workerClient("static-1"); ok {
+		t.Fatal("client for the removed static worker was kept")
+	}
+	if _, ok := gw.workerClient("10.0.0.3:8081"); !ok {
+		t.Fatal("no client for a registered worker")
+	}
+
+	// The full worker keeps its jobs but receives no new ones.
+	for i := range 50 {
+		key := fmt.Sprintf("job-%d", i)
+		if slices.Contains(gw.candidateWorkers(key, true), "10.0.0.3:8081") {
+			t.Fatalf("new job %s routed to the full worker", key)
+		}
+		if gw.router.GetWorkerIP(key) == "10.0.0.3:8081" && gw.candidateWorkers(key, false)[0] != "10.0.0.3:8081" {
+			t.Fatalf("existing job %s routed away from its full worker", key)
+		}
+	}
+}
+
+func TestGatewayCallWorkerSkipsUnreachableWorker(t *testing.T) {
+	ctx := context.Background()
+	gw, store := newTestGateway(t)
+	if err := store.InsertTenant(ctx, "tenant-1", "dev@example.com", "google", "user-1"); err != nil {
+		t.Fatalf("InsertTenant: %v", err)
+	}
+
+	worker := &storingWorker{store: store}
+	workerMux := http.NewServeMux()
+	workerMux.Handle(jennahv1connect.NewDeploymentServiceHandler(worker))
+	live := httptest.NewServer(workerMux)
+	defer live.Close()
+	dead := httptest.NewServer(http.NotFoundHandler())
+	dead.Close()
+
+	gw.router = hashing.NewRouter([]string{"dead", "live"})
+	gw.workerClients = map[string]jennahv1connect.DeploymentServiceClient{
+		"dead": jennahv1connect.NewDeploymentServiceClient(dead.Client(), dead.URL),
+		"live": jennahv1connect.NewDeploymentServiceClient(live.Client(), live.URL),
+	}
+
+	var key string
+	for i := 0; key == ""; i++ {
+		if k := fmt.Sprintf("job-%d", i); gw.router.GetWorkerIP(k) == "dead" {
+			key = k
+		}
+	}
+	submit := func(client jennahv1connect.DeploymentServiceClient) error {
+		req := connect.NewRequest(&jennahv1.SubmitJobRequest{JobId: key, ImageUri: "gcr.io/p/img:1"})
+		req.Header().Set("X-Tenant-Id", "tenant-1")
+		_, err := client.SubmitJob(ctx, req)
+		return err
+	}
+
+	workerIP, err := gw.callWorker(key, true, submit)
+	if err != nil || workerIP != "live" || worker.submitted != 1 {
+		t.Fatalf("callWorker = %q, %v with %d submits; want the live worker once", workerIP, err, worker.submitted)
+	}
+	if got := gw.candidateWorkers(key, false); !slices.Equal(got, []string{"live"}) {
+		t.Fatalf("candidates = %v, want the unreachable worker skipped", got)
+	}
+
+	// Errors from a worker that was reached are not retried elsewhere.
+	workerIP, err = gw.callWorker(key, true, submit)
+	if connect.CodeOf(err) != connect.CodeAlreadyExists || workerIP != "live" || worker.submitted != 2 {
+		t.Fatalf("callWorker = %q, %v with %d submits; want AlreadyExists from the live worker", workerIP, err, worker.submitted)
+	}
+}
+
+// drainingWorker turns every submit away the way a draining worker does.
+type drainingWorker struct {
+	jennahv1connect.UnimplementedDeploymentServiceHandler
+}
+
+func (w *drainingWorker) SubmitJob(
+	ctx context.Context,
+	req *connect.Request[jennahv1.SubmitJobRequest],
+) (*connect.Response[jennahv1.SubmitJobResponse], error) {
+	err := connect.NewError(connect.CodeUnavailable, errors.New("worker is draining"))
+	err.Meta().Set(WorkerDrainingHeader, "true")
+	return nil, err
+}
+
+func TestGatewayCallWorkerSkipsDrainingWorker(t *testing.T) {
+	ctx := context.Background()
+	gw, store := newTestGateway(t)
+	if err := store.InsertTenant(ctx, "tenant-1", "dev@example.com", "google", "user-1"); err != nil {
+		t.Fatalf("InsertTenant: %v", err)
+	}
+
+	worker := &storingWorker{store: store}
+	gw.router = hashing.NewRouter([]string{"draining", "live"})
+	gw.workerClients = make(map[string]jennahv1connect.DeploymentServiceClient)
+	for name, handler := range map[string]jennahv1connect.DeploymentServiceHandler{"draining": &drainingWorker{}, "live": worker} {
+		workerMux := http.NewServeMux()
+		workerMux.Handle(jennahv1connect.NewDeploymentServiceHandler(handler))
+		server := httptest.NewServer(workerMux)
+		defer server.Close()
+		gw.workerClients[name] = jennahv1connect.NewDeploymentServiceClient(server.Client(), server.URL)
+	}
+
+	var key string
+	for i := 0; key == ""; i++ {
+		if k := fmt.Sprintf("job-%d", i); gw.router.GetWorkerIP(k) == "draining" {
+			key = k
+		}
+	}
+	workerIP, err := gw.callWorker(key, true, func(client jennahv1connect.DeploymentServiceClient) error {
+		req := connect.NewRequest(&jennahv1.SubmitJobRequest{JobId: key, ImageUri: "gcr.io/p/img:1"})
+		req.Header().Set("X-Tenant-Id", "tenant-1")
+		_, err := client.SubmitJob(ctx, req)
+		return err
+	})
+	if err != nil || workerIP != "live" || worker.submitted != 1 {
+		t.Fatalf("callWorker = %q, %v with %d submits; want the live worker once", workerIP, err, worker.submitted)
+	}
+}
+
+// jobWorker answers GetJob and records that it did.
+type jobWorker struct {
+	jennahv1connect.UnimplementedDeploymentServiceHandler
+	name   string
+	served *[]string
+}
+
+func (w *jobWorker) GetJob(
+	ctx context.Context,
+	req *connect.Request[jennahv1.GetJobRequest],
+) (*connect.Response[jennahv1.GetJobResponse], error) {
+	*w.served = append(*w.served, w.name)
+	return connect.NewResponse(&jennahv1.GetJobResponse{Job: &jennahv1.Job{JobId: req.Msg.JobId}}), nil
+}
+
+func TestGatewayRoutesJobToLeaseOwner(t *testing.T) {
+	ctx := context.Background()
+	gw, store := newTestGateway(t)
+	tenantResp, err := gw.GetCurrentTenant(ctx, withOAuth(&jennahv1.GetCurrentTenantRequest{}))
+	if err != nil {
+		t.Fatalf("GetCurrentTenant: %v", err)
+	}
+	tenantID := tenantResp.Msg.TenantId
+
+	var served []string
+	gw.router = hashing.NewRouter([]string{"10.0.0.1:8081", "10.0.0.2:8081"})
+	gw.workerClients = make(map[string]jennahv1connect.DeploymentServiceClient)
+	for _, ip := range gw.router.Members() {
+		workerMux := http.NewServeMux()
+		workerMux.Handle(jennahv1connect.NewDeploymentServiceHandler(&jobWorker{name: ip, served: &served}))
+		server := httptest.NewServer(workerMux)
+		defer server.Close()
+		gw.workerClients[ip] = jennahv1connect.NewDeploymentServiceClient(server.Client(), server.URL)
+	}
+
+	if err := store.InsertJob(ctx, tenantID, "job-1", "gcr.io/p/img:1", nil); err != nil {
+		t.Fatalf("InsertJob: %v", err)
+	}
+	hashed := gw.router.GetWorkerIP("job-1")
+	owner := "10.0.0.1:8081"
+	if hashed == owner {
+		owner = "10.0.0.2:8081"
+	}
+	gw.workerAddresses = map[string]string{"worker-b": owner}
+	getJob := func() string {
+		t.Helper()
+		served = nil
+		if _, err := gw.GetJob(ctx, withOAuth(&jennahv1.GetJobRequest{JobId: "job-1"})); err != nil {
+			t.Fatalf("GetJob: %v", err)
+		}
+		return served[0]
+	}
+
+	if got := getJob(); got != hashed {
+		t.Fatalf("unowned job served by %s, want its ring owner %s", got, hashed)
+	}
+
+	if _, err := store.TryClaimOrRenewJobLease(ctx, tenantID, "job-1", "worker-b", time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("TryClaimOrRenewJobLease: %v", err)
+	}
+	if got := getJob(); got != owner {
+		t.Fatalf("job served by %s, want its lease owner %s", got, owner)
+	}
+
+	// An unreachable owner or an expired lease leaves the job to the ring.
+	gw.markWorkerUnhealthy(owner)
+	if got := getJob(); got != hashed {
+		t.Fatalf("job of an unhealthy owner served by %s, want %s", got, hashed)
+	}
+	gw.markWorkerHealthy(owner)
+	if _, err := store.TryClaimOrRenewJobLease(ctx, tenantID, "job-1", "worker-b", time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("TryClaimOrRenewJobLease: %v", err)
+	}
+	if got := getJob(); got != hashed {
+		t.Fatalf("job with an expired lease served by %s, want %s", got, hashed)
+	}
+}
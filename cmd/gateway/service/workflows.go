@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	jennahv1connect "github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+func dbWorkflowToProto(wf *database.Workflow, nodes []*database.Job) *jennahv1.Workflow {
+	p := &jennahv1.Workflow{
+		WorkflowId: wf.WorkflowId,
+		TenantId:   wf.TenantId,
+		Status:     database.WorkflowStatus(nodes),
+		CreatedAt:  wf.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  wf.UpdatedAt.Format(time.RFC3339),
+		Nodes:      make([]*jennahv1.Job, 0, len(nodes)),
+	}
+	if wf.Name != nil {
+		p.Name = *wf.Name
+	}
+	for _, node := range nodes {
+		p.Nodes = append(p.Nodes, dbJobToProto(node))
+	}
+	return p
+}
+
+func (s *GatewayService) SubmitWorkflow(
+	ctx context.Context,
+	req *connect.Request[jennahv1.SubmitWorkflowRequest],
+) (*connect.Response[jennahv1.SubmitWorkflowResponse], error) {
+	log.Printf("Received workflow submission")
+
+	if len(req.Msg.Nodes) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one node is required"))
+	}
+
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeSubmit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range req.Msg.Nodes {
+		if node.Job == nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("node %q: job is required", node.NodeId))
+		}
+		node.Job.JobId = ""
+		node.Job.ImageUri, err = resolveSubmittedImageURI(node.Job.GetImageUri(), node.Job.GetEnvVars(), s.defaultDWPImageURI)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("node %q: %w", node.NodeId, err))
+		}
+	}
+
+	jobs := make([]*jennahv1.SubmitJobRequest, len(req.Msg.Nodes))
+	for i, node := range req.Msg.Nodes {
+		jobs[i] = node.Job
+	}
+	if err := s.checkLaterJobs(ctx, tenantId, jobs, int64(len(jobs))); err != nil {
+		return nil, err
+	}
+
+	workflowId := uuid.NewString()
+
+	workerReq := connect.NewRequest(&jennahv1.SubmitWorkflowRequest{
+		WorkflowId: workflowId,
+		Name:       req.Msg.Name,
+		Nodes:      req.Msg.Nodes,
+	})
+	workerReq.Header().Set("X-Tenant-Id", tenantId)
+
+	var response *connect.Response[jennahv1.SubmitWorkflowResponse]
+	workerIP, err := s.callWorker(workflowId, true, func(client jennahv1connect.DeploymentServiceClient) error {
+		resp, err := client.SubmitWorkflow(ctx, workerReq)
+		response = resp
+		return err
+	})
+	if err != nil {
+		log.Printf("ERROR: Worker %s SubmitWorkflow failed: %v", workerIP, err)
+		if connect.CodeOf(err) == connect.CodeInvalidArgument {
+			return nil, err
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("worker failed: %w", err))
+	}
+
+	response.Msg.WorkerAssigned = workerIP
+	log.Printf("Workflow submitted successfully: workflowId=%s, nodes=%d, worker=%s, status=%s",
+		response.Msg.WorkflowId, len(response.Msg.Nodes), workerIP, response.Msg.Status)
+	return response, nil
+}
+
+func (s *GatewayService) GetWorkflow(
+	ctx context.Context,
+	req *connect.Request[jennahv1.GetWorkflowRequest],
+) (*connect.Response[jennahv1.GetWorkflowResponse], error) {
+	if req.Msg.WorkflowId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("workflow_id is required"))
+	}
+
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.dbClient.GetWorkflow(ctx, tenantId, req.Msg.WorkflowId)
+	if err != nil {
+		if spanner.ErrCode(err) == codes.NotFound {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("workflow not found: %s", req.Msg.WorkflowId))
+		}
+		log.Printf("Failed to get workflow %s for tenant %s: %v", req.Msg.WorkflowId, tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get workflow: %w", err))
+	}
+
+	nodes, err := s.dbClient.ListWorkflowJobs(ctx, tenantId, req.Msg.WorkflowId)
+	if err != nil {
+		log.Printf("Failed to list nodes of workflow %s for tenant %s: %v", req.Msg.WorkflowId, tenantId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list workflow nodes: %w", err))
+	}
+
+	return connect.NewResponse(&jennahv1.GetWorkflowResponse{Workflow: dbWorkflowToProto(workflow, nodes)}), nil
+}
+
+func (s *GatewayService) CancelWorkflow(
+	ctx context.Context,
+	req *connect.Request[jennahv1.CancelWorkflowRequest],
+) (*connect.Response[jennahv1.CancelWorkflowResponse], error) {
+	log.Printf("Received cancel workflow request")
+
+	if req.Msg.WorkflowId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("workflow_id is required"))
+	}
+
+	tenantId, err := s.resolveTenant(ctx, req.Header(), database.ApiKeyScopeCancel)
+	if err != nil {
+		return nil, err
+	}
+
+	workerIP, workerClient, err := s.getWorkerClient(req.Msg.WorkflowId)
+	if err != nil {
+		return nil, err
+	}
+
+	workerReq := connect.NewRequest(&jennahv1.CancelWorkflowRequest{WorkflowId: req.Msg.WorkflowId})
+	workerReq.Header().Set("X-Tenant-Id", tenantId)
+
+	response, err := workerClient.CancelWorkflow(ctx, workerReq)
+	if err != nil {
+		log.Printf("ERROR: Worker %s CancelWorkflow failed for workflow %s: %v", workerIP, req.Msg.WorkflowId, err)
+		if connect.CodeOf(err) == connect.CodeNotFound {
+			return nil, err
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("worker failed: %w", err))
+	}
+
+	log.Printf("Workflow cancelled successfully: workflowId=%s, tenantId=%s, worker=%s, status=%s",
+		req.Msg.WorkflowId, tenantId, workerIP, response.Msg.Status)
+	return response, nil
+}
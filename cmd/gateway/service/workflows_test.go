@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+func TestGatewayGetWorkflow(t *testing.T) {
+	ctx := context.Background()
+	gw, store := newTestGateway(t)
+
+	tenantResp, err := gw.GetCurrentTenant(ctx, withOAuth(&jennahv1.GetCurrentTenantRequest{}))
+	if err != nil {
+		t.Fatalf("GetCurrentTenant: %v", err)
+	}
+	tenantID := tenantResp.Msg.TenantId
+
+	workflowID, deps := "wf-1", `[{"node_id":"split","condition":"ON_SUCCESS"}]`
+	split, merge := "split", "merge"
+	err = store.InsertWorkflow(ctx, &database.Workflow{TenantId: tenantID, WorkflowId: workflowID}, []*database.Job{
+		{TenantId: tenantID, JobId: "job-1", Status: database.JobStatusCompleted, ImageUri: "img", WorkflowId: &workflowID, WorkflowNodeId: &split},
+		{TenantId: tenantID, JobId: "job-2", Status: database.JobStatusWaiting, ImageUri: "img", WorkflowId: &workflowID, WorkflowNodeId: &merge, DependsOnJson: &deps},
+	})
+	if err != nil {
+		t.Fatalf("InsertWorkflow: %v", err)
+	}
+
+	resp, err := gw.GetWorkflow(ctx, withOAuth(&jennahv1.GetWorkflowRequest{WorkflowId: workflowID}))
+	if err != nil {
+		t.Fatalf("GetWorkflow: %v", err)
+	}
+	wf := resp.Msg.Workflow
+	if wf.Status != database.JobStatusRunning || len(wf.Nodes) != 2 {
+		t.Fatalf("workflow = %+v, want RUNNING with 2 nodes", wf)
+	}
+	if merge := wf.Nodes[0]; merge.WorkflowNodeId != "merge" || len(merge.DependsOn) != 1 || merge.DependsOn[0].NodeId != "split" {
+		t.Errorf("merge node = %+v", merge)
+	}
+
+	_, err = gw.GetWorkflow(ctx, withOAuth(&jennahv1.GetWorkflowRequest{WorkflowId: "missing"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("unknown workflow: got %v, want NotFound", err)
+	}
+}
+
+func TestGatewaySubmitWorkflowValidation(t *testing.T) {
+	gw, _ := newTestGateway(t)
+
+	for name, req := range map[string]*jennahv1.SubmitWorkflowRequest{
+		"no nodes": {},
+		"no job":   {Nodes: []*jennahv1.WorkflowNode{{NodeId: "a"}}},
+	} {
+		_, err := gw.SubmitWorkflow(context.Background(), withOAuth(req))
+		if connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("%s: got %v, want InvalidArgument", name, err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var planTo int64
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Print the statements `up` would run, without changing the database",
+	RunE:  runPlan,
+}
+
+func init() {
+	planCmd.Flags().Int64Var(&planTo, "to", 0, "Stop at this version (0 = latest)")
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	runner, closeFn, err := newRunner(cmd.Context())
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	pending, err := runner.Plan(cmd.Context(), planTo)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		fmt.Println("Schema is up to date.")
+		return nil
+	}
+
+	for _, m := range pending {
+		fmt.Printf("-- %s\n", m.Label())
+		for _, b := range m.Batches {
+			fmt.Printf("-- %s batch (%d statement(s))\n", b.Kind, len(b.Statements))
+			for _, stmt := range b.Statements {
+				fmt.Println(strings.TrimSpace(stmt) + ";")
+			}
+		}
+		fmt.Println()
+	}
+	fmt.Printf("%d migration(s) pending\n", len(pending))
+	return nil
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alphauslabs/jennah/database/migrations"
+	"github.com/alphauslabs/jennah/internal/migrate"
+)
+
+var (
+	dbProjectID   string
+	dbInstance    string
+	dbDatabase    string
+	migrationsDir string
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "jennah-migrate",
+	Short: "Jennah schema migrations",
+	Long: `Apply the versioned migrations in database/migrations to a Spanner database.
+
+Applied versions are tracked in the SchemaMigrations table. Statements in each
+file are split into DDL (run as one schema update) and DML (run in one
+transaction) automatically.`,
+	SilenceUsage: true,
+}
+
+// Execute runs the root command.
+func Execute() error {
+	return rootCmd.Execute()
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&dbProjectID, "db-project-id", os.Getenv("DB_PROJECT_ID"), "Database project ID (GCP project for Spanner)")
+	rootCmd.PersistentFlags().StringVar(&dbInstance, "db-instance", os.Getenv("DB_INSTANCE"), "Database instance (Spanner instance name)")
+	rootCmd.PersistentFlags().StringVar(&dbDatabase, "db-database", os.Getenv("DB_DATABASE"), "Database name")
+	rootCmd.PersistentFlags().StringVar(&migrationsDir, "dir", "", "Read migrations from this directory instead of the embedded set")
+
+	rootCmd.AddCommand(statusCmd, planCmd, upCmd)
+}
+
+// loadMigrations returns the embedded migrations, or those in --dir.
+func loadMigrations() ([]*migrate.Migration, error) {
+	if migrationsDir != "" {
+		return migrate.Load(os.DirFS(migrationsDir))
+	}
+	return migrate.Load(migrations.FS)
+}
+
+// newRunner connects to Spanner and loads the migrations. The returned
+// function closes the connection.
+func newRunner(ctx context.Context) (*migrate.Runner, func(), error) {
+	if dbProjectID == "" || dbInstance == "" || dbDatabase == "" {
+		return nil, nil, fmt.Errorf("--db-project-id, --db-instance and --db-database (or DB_PROJECT_ID, DB_INSTANCE, DB_DATABASE) are required")
+	}
+
+	ms, err := loadMigrations()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	target, err := migrate.NewSpannerTarget(ctx, dbProjectID, dbInstance, dbDatabase)
+	if err != nil {
+		return nil, nil, err
+	}
+	fmt.Fprintf(os.Stderr, "database: %s\n", target.DatabasePath())
+	return migrate.NewRunner(target, ms, os.Stdout), target.Close, nil
+}
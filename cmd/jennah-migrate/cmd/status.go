@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alphauslabs/jennah/internal/migrate"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show applied and pending migrations",
+	RunE:  runStatus,
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	runner, closeFn, err := newRunner(cmd.Context())
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	entries, err := runner.Status(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	pending := 0
+	for _, e := range entries {
+		appliedAt := "-"
+		if e.AppliedAt != nil {
+			appliedAt = e.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		if e.State == migrate.StatePending {
+			pending++
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", e.Version, e.Name, e.State, appliedAt)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\n%d pending\n", pending)
+	return nil
+}
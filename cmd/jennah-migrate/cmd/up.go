@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var upTo int64
+
+var upCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply pending migrations",
+	RunE:  runUp,
+}
+
+func init() {
+	upCmd.Flags().Int64Var(&upTo, "to", 0, "Stop at this version (0 = latest)")
+}
+
+func runUp(cmd *cobra.Command, args []string) error {
+	runner, closeFn, err := newRunner(cmd.Context())
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	n, err := runner.Up(cmd.Context(), upTo)
+	if err != nil {
+		return fmt.Errorf("applied %d migration(s) before failing: %w", n, err)
+	}
+	fmt.Printf("Applied %d migration(s).\n", n)
+	return nil
+}
@@ -2,12 +2,15 @@ package cmd
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -18,11 +21,16 @@ import (
 	"github.com/alphauslabs/jennah/cmd/worker/service"
 	"github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
 	batch "github.com/alphauslabs/jennah/internal/cloudexec"
-	_ "github.com/alphauslabs/jennah/internal/cloudexec/gcp" // Register GCP providers (Cloud Batch, Cloud Run)
+	_ "github.com/alphauslabs/jennah/internal/cloudexec/aws"        // Register AWS Batch provider
+	_ "github.com/alphauslabs/jennah/internal/cloudexec/docker"     // Register local Docker provider
+	_ "github.com/alphauslabs/jennah/internal/cloudexec/gcp"        // Register GCP providers (Cloud Batch, Cloud Run)
+	_ "github.com/alphauslabs/jennah/internal/cloudexec/kubernetes" // Register Kubernetes Jobs provider
 	"github.com/alphauslabs/jennah/internal/config"
 	"github.com/alphauslabs/jennah/internal/database"
 	"github.com/alphauslabs/jennah/internal/dispatcher"
 	"github.com/alphauslabs/jennah/internal/notifier"
+	"github.com/alphauslabs/jennah/internal/quota"
+	"github.com/alphauslabs/jennah/internal/statusevents"
 )
 
 var serveCmd = &cobra.Command{
@@ -46,13 +54,12 @@ func runServe(cmd *cobra.Command, args []string) error {
 		cfg.BatchProvider.Provider, cfg.BatchProvider.Region)
 
 	// Initialize database client.
-	dbClient, err := database.NewClient(ctx, cfg.Database.ProjectID, cfg.Database.Instance, cfg.Database.Database)
+	dbClient, err := database.NewStore(ctx, cfg.Database)
 	if err != nil {
 		return fmt.Errorf("failed to create database client: %w", err)
 	}
 	defer dbClient.Close()
-	log.Printf("Connected to database: %s/%s/%s",
-		cfg.Database.ProjectID, cfg.Database.Instance, cfg.Database.Database)
+	log.Printf("Connected to %s database: %s", cfg.Database.Provider, cfg.Database.Description())
 
 	// Initialize batch provider (Cloud Batch — always required for COMPLEX jobs).
 	batchProvider, err := batch.NewProvider(ctx, cfg.BatchProvider)
@@ -85,6 +92,10 @@ func runServe(cmd *cobra.Command, args []string) error {
 			dispatcherOpts = append(dispatcherOpts, dispatcher.WithCloudRunJobs(crProvider))
 			log.Printf("Initialized Cloud Run Jobs provider in region: %s", cfg.CloudRun.Region)
 		}
+	} else if p := cfg.BatchProvider.Provider; p == "local-docker" || p == "aws" || p == "kubernetes" {
+		// Local Docker, AWS Batch and Kubernetes run every tier, so SIMPLE jobs need no Cloud Run.
+		dispatcherOpts = append(dispatcherOpts, dispatcher.WithCloudRunJobs(batchProvider))
+		log.Printf("%s batch provider also serves SIMPLE jobs", cfg.BatchProvider.Provider)
 	} else {
 		log.Println("WARNING: Cloud Run Jobs provider not configured (set CLOUD_RUN_ENABLED=true) — SIMPLE jobs will be rejected")
 		log.Println("WARNING: Cloud Run Jobs provider not configured (set CLOUD_RUN_ENABLED=true) — SIMPLE jobs will be rejected")
@@ -112,13 +123,16 @@ func runServe(cmd *cobra.Command, args []string) error {
 		jobConfig.DefaultResources.MemoryMiB,
 		jobConfig.DefaultResources.MaxRunDurationSeconds)
 
-	// Initialize GCP Batch client.
-	gcpBatchClient, err := gcpbatch.NewClient(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to create GCP Batch client: %w", err)
+	// Initialize GCP Batch client (GCP providers only).
+	var gcpBatchClient *gcpbatch.Client
+	if strings.HasPrefix(cfg.BatchProvider.Provider, "gcp") {
+		gcpBatchClient, err = gcpbatch.NewClient(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create GCP Batch client: %w", err)
+		}
+		defer gcpBatchClient.Close()
+		log.Println("Initialized GCP Batch client")
 	}
-	defer gcpBatchClient.Close()
-	log.Println("Initialized GCP Batch client")
 
 	// Initialize Pub/Sub notifier (feature-flagged, shared topic).
 	var jobNotifier notifier.Notifier
@@ -151,15 +165,57 @@ func runServe(cmd *cobra.Command, args []string) error {
 
 	leaseTTLSeconds := getEnvAsIntOrDefault("WORKER_LEASE_TTL_SECONDS", 30)
 	claimIntervalSeconds := getEnvAsIntOrDefault("WORKER_CLAIM_INTERVAL_SECONDS", 5)
+	schedulerIntervalSeconds := getEnvAsIntOrDefault("WORKER_SCHEDULER_INTERVAL_SECONDS", 15)
+	outboxIntervalSeconds := getEnvAsIntOrDefault("WORKER_OUTBOX_INTERVAL_SECONDS", 5)
 	leaseTTL := time.Duration(leaseTTLSeconds) * time.Second
 	claimInterval := time.Duration(claimIntervalSeconds) * time.Second
 
-	workerService := service.NewWorkerService(dbClient, batchProvider, d, jobConfig, gcpBatchClient, workerID, leaseTTL, claimInterval, jobNotifier)
+	// QUEUED jobs are released against the same limits the gateway admits with.
+	quotaLimits, err := quota.LimitsFromEnv()
+	if err != nil {
+		return fmt.Errorf("invalid quota configuration: %w", err)
+	}
+	quotas := quota.NewChecker(dbClient, quotaLimits, jobConfig)
+
+	reconcilerConfig, err := service.ReconcilerConfigFromEnv()
+	if err != nil {
+		return fmt.Errorf("invalid status reconciler configuration: %w", err)
+	}
+
+	workerService := service.NewWorkerService(dbClient, batchProvider, d, jobConfig, gcpBatchClient, workerID, leaseTTL, claimInterval, jobNotifier, quotas)
+	workerService.ConfigureReconciler(reconcilerConfig)
 	log.Printf("Worker identity: %s (lease_ttl=%s, claim_interval=%s)", workerID, leaseTTL, claimInterval)
+	log.Printf("Status reconciler: every %s-%s, concurrency %d, rate limits %v",
+		reconcilerConfig.FastInterval, reconcilerConfig.SlowInterval, reconcilerConfig.Concurrency, reconcilerConfig.RateLimits)
+
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Resume polling for active jobs from before restart.
-	if err := service.ResumeActiveJobPollers(ctx, workerService, dbClient); err != nil {
-		log.Printf("Warning: failed to resume job pollers on startup: %v", err)
+	// Subscribe to provider status events (feature-flagged). Each worker
+	// reads every event from its own subscription; scheduled status checks
+	// become the reconciliation fallback.
+	if cfg.StatusEvents.Enabled {
+		eventsClient, err := pubsub.NewClient(ctx, cfg.StatusEvents.ProjectID)
+		if err != nil {
+			return fmt.Errorf("failed to create status events Pub/Sub client: %w", err)
+		}
+		defer eventsClient.Close()
+		subscriptionID := cfg.StatusEvents.SubscriptionPrefix + "-" + workerID
+		subscriber, err := statusevents.NewSubscriber(ctx, eventsClient, cfg.StatusEvents.TopicID, subscriptionID)
+		if err != nil {
+			return fmt.Errorf("failed to subscribe to status events: %w", err)
+		}
+		reconcileInterval := time.Duration(cfg.StatusEvents.ReconcileIntervalSeconds) * time.Second
+		workerService.StartStatusEvents(sigCtx, subscriber, reconcileInterval)
+		log.Printf("Initialized status events (project: %s, topic: %s, subscription: %s)",
+			cfg.StatusEvents.ProjectID, cfg.StatusEvents.TopicID, subscriptionID)
+	} else {
+		log.Println("Status events disabled; checking every job on schedule (set STATUS_EVENTS_ENABLED=true to enable)")
+	}
+
+	// Resume tracking active jobs from before restart.
+	if err := service.ResumeActiveJobs(ctx, workerService); err != nil {
+		log.Printf("Warning: failed to resume active jobs on startup: %v", err)
 	}
 
 	mux := http.NewServeMux()
@@ -173,16 +229,37 @@ func runServe(cmd *cobra.Command, args []string) error {
 	})
 	log.Println("Health check endpoint: /health")
 
+	mux.Handle("/debug/vars", expvar.Handler())
+	log.Println("Metrics endpoint: /debug/vars")
+
+	mux.Handle("/admin/drain", workerService.DrainHandler())
+	log.Println("Drain endpoint: POST /admin/drain")
+
 	addr := fmt.Sprintf("0.0.0.0:%s", cfg.ServerPort)
 	server := &http.Server{
 		Addr:    addr,
 		Handler: mux,
 	}
 
-	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
-	defer stop()
-
 	workerService.StartLeaseReconciler(sigCtx)
+	workerService.StartScheduler(sigCtx, time.Duration(schedulerIntervalSeconds)*time.Second)
+	workerService.StartOutboxRelay(sigCtx, time.Duration(outboxIntervalSeconds)*time.Second)
+
+	// Heartbeat into the worker registry the gateway routes by (opt-in).
+	if os.Getenv("WORKER_REGISTRY") == "true" {
+		advertiseAddress := os.Getenv("WORKER_ADVERTISE_ADDRESS")
+		if advertiseAddress == "" {
+			hostname, err := os.Hostname()
+			if err != nil || hostname == "" {
+				return fmt.Errorf("cannot determine the worker address: set WORKER_ADVERTISE_ADDRESS")
+			}
+			advertiseAddress = net.JoinHostPort(hostname, cfg.ServerPort)
+		}
+		capacity := getEnvAsIntOrDefault("WORKER_CAPACITY", 0)
+		heartbeatInterval := time.Duration(getEnvAsIntOrDefault("WORKER_HEARTBEAT_INTERVAL_SECONDS", 10)) * time.Second
+		workerService.StartHeartbeat(sigCtx, advertiseAddress, int64(capacity), heartbeatInterval)
+		log.Printf("Registered as %s at %s (capacity %d, heartbeat every %s)", workerID, advertiseAddress, capacity, heartbeatInterval)
+	}
 
 	go func() {
 		log.Printf("Worker listening on %s", addr)
@@ -202,12 +279,15 @@ func runServe(cmd *cobra.Command, args []string) error {
 	<-sigCtx.Done()
 	log.Println("Shutdown signal received, gracefully shutting down...")
 
-	// Stop all active job pollers.
-	workerService.StopAllPollers()
-
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// Hand tracked jobs to peers, then stop reconciling job status.
+	if _, err := workerService.Drain(shutdownCtx); err != nil {
+		log.Printf("Drain incomplete: %v", err)
+	}
+	workerService.StopReconciler()
+
 	if err := server.Shutdown(shutdownCtx); err != nil {
 		log.Printf("Error during server shutdown: %v", err)
 	}
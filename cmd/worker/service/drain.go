@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// errDraining is returned to submits that arrive while the worker drains. The
+// X-Worker-Draining header tells the gateway to send them to another worker.
+func errDraining() error {
+	err := connect.NewError(connect.CodeUnavailable, errors.New("worker is draining"))
+	err.Meta().Set("X-Worker-Draining", "true")
+	return err
+}
+
+// beginSubmit admits a SubmitJob or SubmitWorkflow call, unless the worker is
+// draining. Admitted calls must call endSubmit when they finish.
+func (s *WorkerService) beginSubmit() error {
+	s.drainMutex.Lock()
+	defer s.drainMutex.Unlock()
+	if s.draining {
+		return errDraining()
+	}
+	s.submits.Add(1)
+	return nil
+}
+
+func (s *WorkerService) endSubmit() {
+	s.submits.Done()
+}
+
+// isDraining reports whether Drain was called. A draining worker claims no
+// jobs and starts no queued or scheduled ones.
+func (s *WorkerService) isDraining() bool {
+	s.drainMutex.Lock()
+	defer s.drainMutex.Unlock()
+	return s.draining
+}
+
+// DrainResult summarises a drain.
+type DrainResult struct {
+	HandedOff int      `json:"handedOff"` // jobs whose lease was released
+	Peers     []string `json:"peers"`     // workers named to take the jobs over
+}
+
+// drainTimeout bounds a drain started through DrainHandler.
+const drainTimeout = 30 * time.Second
+
+// Drain prepares the worker to stop without gaps in job tracking. It rejects
+// new submits, leaves the worker registry, waits for in-flight submits, then
+// releases the lease of every job it tracks or is about to retry, naming a
+// live peer from the registry as each job's preferred worker so the peer
+// takes it over on its next lease claim. Without live peers the leases are
+// released for any worker to claim. Jobs the worker takes on after that are
+// released right away (see releaseDrainedJob).
+//
+// If ctx ends before in-flight submits finish, the drain is abandoned: the
+// worker accepts submits again and rejoins the registry on its next
+// heartbeat.
+func (s *WorkerService) Drain(ctx context.Context) (*DrainResult, error) {
+	s.drainMutex.Lock()
+	s.draining = true
+	s.drainMutex.Unlock()
+	log.Println("Draining: rejecting new submits")
+
+	if err := s.dbClient.DeleteWorker(ctx, s.workerID); err != nil {
+		log.Printf("Draining: failed to leave the worker registry: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.submits.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.drainMutex.Lock()
+		s.draining = false
+		s.drainMutex.Unlock()
+		log.Println("Drain abandoned: accepting submits again")
+		return nil, fmt.Errorf("waiting for in-flight submits: %w", ctx.Err())
+	}
+
+	// Stop tracking every job before releasing any lease. Taking renewMutex
+	// waits out reconciler renewals already under way, and later checks see
+	// their job untracked, so no renewal takes a released job back. The jobs
+	// are listed under renewMutex, after draining is set: trackJob and
+	// scheduleRetry take on no job once it is.
+	s.renewMutex.Lock()
+	keys := s.ownedJobKeys()
+	for _, key := range keys {
+		tenantID, jobID, _ := strings.Cut(key, "/")
+		s.untrackJob(tenantID, jobID)
+		s.cancelRetry(tenantID, jobID)
+	}
+	s.renewMutex.Unlock()
+
+	// The jobs are untracked now, so their leases are released even if ctx
+	// ends; otherwise they would sit leased to no one until the TTL.
+	ctx = context.WithoutCancel(ctx)
+	peers := s.livePeers(ctx)
+	result := &DrainResult{Peers: peers}
+	for i, key := range keys {
+		tenantID, jobID, _ := strings.Cut(key, "/")
+		var preferred string
+		if len(peers) > 0 {
+			preferred = peers[i%len(peers)]
+		}
+		released, err := s.dbClient.ReleaseJobLease(ctx, tenantID, jobID, s.workerID, preferred)
+		if err != nil {
+			log.Printf("Draining: failed to release job %s: %v", jobID, err)
+			continue
+		}
+		if released {
+			result.HandedOff++
+		}
+	}
+	log.Printf("Drained: released %d job(s) to peers %v", result.HandedOff, peers)
+	return result, nil
+}
+
+// releaseDrainedJob releases the lease of a job the worker took on while
+// draining, e.g. an in-flight submit, a retry or a queued job started just
+// then, naming a live peer to take it over when there is one.
+func (s *WorkerService) releaseDrainedJob(tenantID, jobID string) {
+	ctx := context.Background()
+	var preferred string
+	if peers := s.livePeers(ctx); len(peers) > 0 {
+		preferred = peers[0]
+	}
+	log.Printf("Draining: releasing job %s instead of taking it on", jobID)
+	if _, err := s.dbClient.ReleaseJobLease(ctx, tenantID, jobID, s.workerID, preferred); err != nil {
+		log.Printf("Draining: failed to release job %s: %v", jobID, err)
+	}
+}
+
+// livePeers returns the other workers whose registry heartbeat is newer than
+// the lease TTL.
+func (s *WorkerService) livePeers(ctx context.Context) []string {
+	workers, err := s.dbClient.ListWorkers(ctx)
+	if err != nil {
+		log.Printf("Draining: failed to list peers: %v", err)
+		return nil
+	}
+	var peers []string
+	for _, w := range workers {
+		if w.WorkerId != s.workerID && time.Since(w.LastHeartbeatAt) <= s.leaseTTL {
+			peers = append(peers, w.WorkerId)
+		}
+	}
+	return peers
+}
+
+// ownedJobKeys returns the "tenantID/jobID" keys of the jobs the worker
+// tracks or has a retry scheduled for.
+func (s *WorkerService) ownedJobKeys() []string {
+	var keys []string
+	s.trackedMutex.Lock()
+	for key := range s.tracked {
+		keys = append(keys, key)
+	}
+	s.trackedMutex.Unlock()
+
+	s.retryMutex.Lock()
+	for key := range s.retryTimers {
+		keys = append(keys, key)
+	}
+	s.retryMutex.Unlock()
+	return keys
+}
+
+// DrainHandler serves POST requests that drain the worker, answering with
+// the DrainResult once the jobs are handed off.
+func (s *WorkerService) DrainHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		// The drain outlives the request: a caller that hangs up must not
+		// leave the worker half drained.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), drainTimeout)
+		defer cancel()
+		result, err := s.Drain(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	})
+}
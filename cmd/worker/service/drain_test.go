@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+func TestDrainHandsJobsToPeers(t *testing.T) {
+	ctx := context.Background()
+	path := "jobs/" + runningJobID
+	s, store := newRetryTestService(t, &fakeProvider{}, &database.Job{JobId: runningJobID, Status: database.JobStatusRunning, ImageUri: "img", GcpBatchJobPath: &path})
+	s.ConfigureReconciler(ReconcilerConfig{FastInterval: time.Hour, SlowInterval: time.Hour, Concurrency: 1})
+	if _, err := store.TryClaimOrRenewJobLease(ctx, "tenant-1", runningJobID, "worker-1", time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("TryClaimOrRenewJobLease: %v", err)
+	}
+	s.trackJob("tenant-1", runningJobID, path, database.JobStatusRunning, database.ServiceTierComplex, router.AssignedServiceUnspecified, time.Now())
+	for _, id := range []string{"worker-1", "worker-2"} {
+		if err := store.UpsertWorker(ctx, &database.Worker{WorkerId: id, Address: id + ":8081"}); err != nil {
+			t.Fatalf("UpsertWorker: %v", err)
+		}
+	}
+
+	// A submit in flight holds the drain back; new ones are turned away.
+	if err := s.beginSubmit(); err != nil {
+		t.Fatalf("beginSubmit: %v", err)
+	}
+	type drained struct {
+		result *DrainResult
+		err    error
+	}
+	done := make(chan drained, 1)
+	go func() {
+		result, err := s.Drain(ctx)
+		done <- drained{result, err}
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for !s.isDraining() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	req := connect.NewRequest(&jennahv1.SubmitJobRequest{JobId: "job-new", ImageUri: "img"})
+	req.Header().Set("X-Tenant-Id", "tenant-1")
+	_, err := s.SubmitJob(ctx, req)
+	var connectErr *connect.Error
+	if connect.CodeOf(err) != connect.CodeUnavailable || !errors.As(err, &connectErr) || connectErr.Meta().Get("X-Worker-Draining") != "true" {
+		t.Fatalf("SubmitJob while draining: got %v, want Unavailable marked as draining", err)
+	}
+	select {
+	case <-done:
+		t.Fatal("Drain returned before the in-flight submit finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+	s.endSubmit()
+
+	d := <-done
+	if d.err != nil {
+		t.Fatalf("Drain: %v", d.err)
+	}
+	if d.result.HandedOff != 1 || !slices.Equal(d.result.Peers, []string{"worker-2"}) {
+		t.Fatalf("Drain = %+v, want one job handed to worker-2", d.result)
+	}
+	job, err := store.GetJob(ctx, "tenant-1", runningJobID)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if job.OwnerWorkerId != nil || ptrToString(job.PreferredWorkerId) != "worker-2" || s.trackedJobCount() != 0 {
+		t.Fatalf("after drain: owner=%v preferred=%v tracked=%d", job.OwnerWorkerId, job.PreferredWorkerId, s.trackedJobCount())
+	}
+	workers, _ := store.ListWorkers(ctx)
+	if len(workers) != 1 || workers[0].WorkerId != "worker-2" {
+		t.Fatalf("registry = %v, want the drained worker gone", workers)
+	}
+
+	// The drained worker claims nothing back.
+	if err := s.reconcileActiveJobLeases(ctx, false); err != nil {
+		t.Fatalf("reconcileActiveJobLeases: %v", err)
+	}
+	if s.trackedJobCount() != 0 {
+		t.Fatal("drained worker reclaimed a job")
+	}
+}
+
+// renewBlockingStore holds lease renewals until proceed is closed.
+type renewBlockingStore struct {
+	database.Store
+	entered chan struct{}
+	proceed chan struct{}
+}
+
+func (s *renewBlockingStore) TryClaimOrRenewJobLease(ctx context.Context, tenantID, jobID, workerID string, leaseUntil time.Time) (bool, error) {
+	s.entered <- struct{}{}
+	<-s.proceed
+	return s.Store.TryClaimOrRenewJobLease(ctx, tenantID, jobID, workerID, leaseUntil)
+}
+
+func TestDrainWaitsForInFlightRenewal(t *testing.T) {
+	ctx := context.Background()
+	path := "jobs/" + runningJobID
+	s, store := newRetryTestService(t, &fakeProvider{}, &database.Job{JobId: runningJobID, Status: database.JobStatusRunning, ImageUri: "img", GcpBatchJobPath: &path})
+	s.ConfigureReconciler(ReconcilerConfig{FastInterval: time.Hour, SlowInterval: time.Hour, Concurrency: 1})
+	if _, err := store.TryClaimOrRenewJobLease(ctx, "tenant-1", runningJobID, "worker-1", time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("TryClaimOrRenewJobLease: %v", err)
+	}
+	s.trackJob("tenant-1", runningJobID, path, database.JobStatusRunning, database.ServiceTierComplex, router.AssignedServiceUnspecified, time.Now())
+	s.trackedMutex.Lock()
+	job := s.tracked["tenant-1/"+runningJobID]
+	s.trackedMutex.Unlock()
+
+	blocking := &renewBlockingStore{Store: store, entered: make(chan struct{}), proceed: make(chan struct{})}
+	s.dbClient = blocking
+	go s.finishCheck(ctx, job, batch.JobStatusRunning, nil)
+	<-blocking.entered
+
+	// The renewal passed its untracked check; Drain must not release the
+	// lease until it lands.
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.Drain(ctx)
+		done <- err
+	}()
+	select {
+	case <-done:
+		t.Fatal("Drain returned during an in-flight lease renewal")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(blocking.proceed)
+	if err := <-done; err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+
+	got, err := store.GetJob(ctx, "tenant-1", runningJobID)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if got.OwnerWorkerId != nil {
+		t.Fatalf("owner after drain = %q, want the lease released", *got.OwnerWorkerId)
+	}
+}
+
+func TestDrainReleasesJobsTakenOnWhileDraining(t *testing.T) {
+	ctx := context.Background()
+	path := "jobs/" + runningJobID
+	s, store := newRetryTestService(t, &fakeProvider{}, &database.Job{JobId: runningJobID, Status: database.JobStatusRunning, ImageUri: "img", GcpBatchJobPath: &path})
+	if _, err := s.Drain(ctx); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+
+	// A submit, retry or queued job that finishes after Drain listed the
+	// jobs to hand off hands its lease back instead of keeping it.
+	for _, takeOn := range []func(){
+		func() {
+			s.trackJob("tenant-1", runningJobID, path, database.JobStatusRunning, database.ServiceTierComplex, router.AssignedServiceUnspecified, time.Now())
+		},
+		func() { s.scheduleRetry("tenant-1", runningJobID, time.Hour) },
+	} {
+		if _, err := store.TryClaimOrRenewJobLease(ctx, "tenant-1", runningJobID, "worker-1", time.Now().Add(time.Minute)); err != nil {
+			t.Fatalf("TryClaimOrRenewJobLease: %v", err)
+		}
+		takeOn()
+		job, err := store.GetJob(ctx, "tenant-1", runningJobID)
+		if err != nil {
+			t.Fatalf("GetJob: %v", err)
+		}
+		if job.OwnerWorkerId != nil || len(s.ownedJobKeys()) != 0 {
+			t.Fatalf("draining worker kept the job: owner=%v owned=%v", job.OwnerWorkerId, s.ownedJobKeys())
+		}
+	}
+}
+
+func TestDrainAbandonedWhenSubmitsOutlastContext(t *testing.T) {
+	s, _ := newRetryTestService(t, &fakeProvider{}, &database.Job{JobId: runningJobID, Status: database.JobStatusRunning, ImageUri: "img"})
+	if err := s.beginSubmit(); err != nil {
+		t.Fatalf("beginSubmit: %v", err)
+	}
+	defer s.endSubmit()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.Drain(ctx); err == nil {
+		t.Fatal("Drain succeeded with a submit still in flight")
+	}
+	if s.isDraining() {
+		t.Fatal("abandoned drain left the worker draining")
+	}
+}
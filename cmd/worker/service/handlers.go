@@ -9,8 +9,10 @@ import (
 	"strings"
 	"time"
 
+	"cloud.google.com/go/spanner"
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
 
 	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
 	batch "github.com/alphauslabs/jennah/internal/cloudexec"
@@ -88,6 +90,27 @@ func dbJobToProto(job *database.Job) *jennahv1.Job {
 	if job.MaxRunDurationSeconds != nil {
 		p.MaxRunDurationSeconds = *job.MaxRunDurationSeconds
 	}
+	if job.RetryPolicy != nil {
+		p.RetryPolicy = *job.RetryPolicy
+	}
+	if job.WorkflowId != nil {
+		p.WorkflowId = *job.WorkflowId
+	}
+	if job.WorkflowNodeId != nil {
+		p.WorkflowNodeId = *job.WorkflowNodeId
+	}
+	deps, err := database.JobDependencies(job)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	for _, dep := range deps {
+		p.DependsOn = append(p.DependsOn, &jennahv1.WorkflowDependency{NodeId: dep.NodeId, Condition: dep.Condition})
+	}
+	labels, err := database.JobLabels(job)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	p.Labels = labels
 
 	return p
 }
@@ -97,6 +120,11 @@ func (s *WorkerService) SubmitJob(
 	ctx context.Context,
 	req *connect.Request[jennahv1.SubmitJobRequest],
 ) (*connect.Response[jennahv1.SubmitJobResponse], error) {
+	if err := s.beginSubmit(); err != nil {
+		return nil, err
+	}
+	defer s.endSubmit()
+
 	tenantID := req.Header().Get("X-Tenant-Id")
 	log.Printf("Received SubmitJob request for tenant: %s", tenantID)
 
@@ -110,6 +138,21 @@ func (s *WorkerService) SubmitJob(
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("image_uri is required"))
 	}
 
+	retryPolicy, err := normalizeRetryPolicy(req.Msg.RetryPolicy)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := batch.ValidateJobLabels(req.Msg.GetLabels()); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	maxRetries := defaultMaxRetries
+	if req.Msg.MaxRetries != nil {
+		if *req.Msg.MaxRetries < 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("max_retries must not be negative"))
+		}
+		maxRetries = *req.Msg.MaxRetries
+	}
+
 	// Normalize env vars and auto-resolve distributed INPUT_DATA_SIZE when omitted.
 	req.Msg.EnvVars = cloneEnvVars(req.Msg.GetEnvVars())
 	if err := ensureDistributedInputDataSize(ctx, req.Msg.EnvVars, getGCSObjectSize); err != nil {
@@ -144,17 +187,22 @@ func (s *WorkerService) SubmitJob(
 		envVarsJson = &s
 	}
 
-	// Insert job record with PENDING status and advanced config.
+	// The gateway asks to queue jobs that are over the tenant's quota; they
+	// are stored unowned and started by releaseQueuedJobs.
+	queueReason := req.Header().Get("X-Queue-Reason")
+
+	// Insert job record with PENDING (or QUEUED) status and advanced config.
 	now := time.Now().UTC()
 	leaseUntil := now.Add(s.leaseTTL)
-	err := s.dbClient.InsertJobFull(ctx, &database.Job{
+	job := &database.Job{
 		TenantId:              tenantID,
 		JobId:                 internalJobID,
 		Status:                database.JobStatusPending,
 		ImageUri:              req.Msg.ImageUri,
 		Commands:              req.Msg.Commands,
 		RetryCount:            0,
-		MaxRetries:            3,
+		MaxRetries:            maxRetries,
+		RetryPolicy:           &retryPolicy,
 		EnvVarsJson:           envVarsJson,
 		Name:                  ptrStringOrNil(req.Msg.Name),
 		ResourceProfile:       ptrStringOrNil(req.Msg.ResourceProfile),
@@ -169,15 +217,50 @@ func (s *WorkerService) SubmitJob(
 		PreferredWorkerId:     &s.workerID,
 		LeaseExpiresAt:        &leaseUntil,
 		LastHeartbeatAt:       &now,
-	})
+		// The gateway hashes idempotent submissions so retries can be matched.
+		IdempotencyKey: ptrStringOrNil(req.Msg.IdempotencyKey),
+		RequestHash:    ptrStringOrNil(req.Header().Get("X-Request-Hash")),
+		LabelsJson:     database.EncodeJobLabels(req.Msg.GetLabels()),
+	}
+	if queueReason != "" {
+		job.Status = database.JobStatusQueued
+		job.OwnerWorkerId, job.LeaseExpiresAt, job.LastHeartbeatAt = nil, nil, nil
+	}
+	err = s.dbClient.InsertJobFull(ctx, job)
 	if err != nil {
 		log.Printf("Error inserting job to database: %v", err)
+		// AlreadyExists tells the gateway another submission holds the
+		// idempotency key (or job ID), so it can replay that one.
+		code := connect.CodeInternal
+		if spanner.ErrCode(err) == codes.AlreadyExists {
+			code = connect.CodeAlreadyExists
+		}
 		return nil, connect.NewError(
-			connect.CodeInternal,
+			code,
 			fmt.Errorf("failed to create job record: %w", err),
 		)
 	}
-	log.Printf("Job %s saved to database with PENDING status", internalJobID)
+	log.Printf("Job %s saved to database with %s status", internalJobID, job.Status)
+
+	if queueReason != "" {
+		transitionID := uuid.New().String()
+		reason := "Queued: " + queueReason
+		err = s.changeJobStatus(ctx, &database.JobStatusChange{
+			TenantId:     tenantID,
+			JobId:        internalJobID,
+			TransitionId: transitionID,
+			ToStatus:     database.JobStatusQueued,
+			Reason:       &reason,
+			WorkerId:     &s.workerID,
+		}, notifier.BuildStatusEvent(transitionID, tenantID, internalJobID, database.JobStatusQueued, ""))
+		if err != nil {
+			log.Printf("Error recording state transition: %v", err)
+		}
+		return connect.NewResponse(&jennahv1.SubmitJobResponse{
+			JobId:  internalJobID,
+			Status: database.JobStatusQueued,
+		}), nil
+	}
 
 	// Submit job to cloud batch provider.
 	// Use the navigator to classify the job and build configuration, then
@@ -185,14 +268,9 @@ func (s *WorkerService) SubmitJob(
 	plan, err := navigator.Navigate(req.Msg, internalJobID, s.jobConfig)
 	if err != nil {
 		log.Printf("Error building navigation plan: %v", err)
-		failErr := s.dbClient.FailJob(ctx, tenantID, internalJobID, err.Error())
-		if failErr != nil {
+		if failErr := s.failSubmittedJob(ctx, tenantID, internalJobID, err.Error()); failErr != nil {
 			log.Printf("Error updating job status to FAILED: %v", failErr)
 		}
-		// Publish terminal event for immediate failure.
-		event := notifier.BuildEvent(uuid.New().String(), tenantID, internalJobID, database.JobStatusFailed, database.JobStatusPending)
-		event.ErrorMessage = err.Error()
-		s.publishTerminalEvent(ctx, event, tenantID)
 		return nil, connect.NewError(
 			connect.CodeInternal,
 			fmt.Errorf("failed to build execution plan: %w", err),
@@ -205,23 +283,12 @@ func (s *WorkerService) SubmitJob(
 	plan.Config.RequestID = internalJobID
 	plan.Config.TenantID = tenantID
 
-	var jobResult *batch.JobResult
-	if s.dispatcher != nil {
-		jobResult, err = s.dispatcher.SubmitJob(ctx, plan.AssignedService, plan.Config)
-	} else {
-		// Fallback: use the single batchProvider if dispatcher is not configured.
-		jobResult, err = s.batchProvider.SubmitJob(ctx, plan.Config)
-	}
+	jobResult, err := s.dispatchPlan(ctx, plan)
 	if err != nil {
 		log.Printf("Error submitting job to batch provider: %v", err)
-		failErr := s.dbClient.FailJob(ctx, tenantID, internalJobID, err.Error())
-		if failErr != nil {
+		if failErr := s.failSubmittedJob(ctx, tenantID, internalJobID, err.Error()); failErr != nil {
 			log.Printf("Error updating job status to FAILED: %v", failErr)
 		}
-		// Publish terminal event for submission failure.
-		event := notifier.BuildEvent(uuid.New().String(), tenantID, internalJobID, database.JobStatusFailed, database.JobStatusPending)
-		event.ErrorMessage = err.Error()
-		s.publishTerminalEvent(ctx, event, tenantID)
 		return nil, connect.NewError(
 			connect.CodeInternal,
 			fmt.Errorf("failed to submit batch job: %w", err),
@@ -235,7 +302,8 @@ func (s *WorkerService) SubmitJob(
 		statusToSet = database.JobStatusRunning
 	}
 
-	err = s.dbClient.UpdateJobStatusAndGcpBatchJobPath(ctx, tenantID, internalJobID, statusToSet, jobResult.CloudResourcePath, serviceTierFromPlan(plan), plan.AssignedService.String())
+	reason := fmt.Sprintf("Submitted: %s", jobResult.CloudResourcePath)
+	err = s.recordSubmission(ctx, tenantID, internalJobID, database.JobStatusPending, statusToSet, reason, plan, jobResult.CloudResourcePath, 0)
 	if err != nil {
 		log.Printf("Error updating job status to %s: %v", statusToSet, err)
 		return nil, connect.NewError(
@@ -249,7 +317,7 @@ func (s *WorkerService) SubmitJob(
 	time.Sleep(2 * time.Second)
 
 	// Start background polling goroutine to track job status.
-	s.startJobPollerWithService(ctx, tenantID, internalJobID, jobResult.CloudResourcePath, statusToSet, serviceTierFromPlan(plan), plan.AssignedService)
+	s.trackJob(tenantID, internalJobID, jobResult.CloudResourcePath, statusToSet, serviceTierFromPlan(plan), plan.AssignedService, time.Now())
 
 	response := connect.NewResponse(&jennahv1.SubmitJobResponse{
 		JobId:  internalJobID,
@@ -260,6 +328,31 @@ func (s *WorkerService) SubmitJob(
 	return response, nil
 }
 
+// failSubmittedJob fails a PENDING job whose submission failed.
+func (s *WorkerService) failSubmittedJob(ctx context.Context, tenantID, jobID, errorMessage string) error {
+	transitionID := uuid.New().String()
+	fromStatus := database.JobStatusPending
+	reason := "Submission failed"
+	event := notifier.BuildEvent(transitionID, tenantID, jobID, database.JobStatusFailed, database.JobStatusPending)
+	event.ErrorMessage = errorMessage
+	err := s.changeJobStatus(ctx, &database.JobStatusChange{
+		TenantId:       tenantID,
+		JobId:          jobID,
+		TransitionId:   transitionID,
+		FromStatus:     &fromStatus,
+		ToStatus:       database.JobStatusFailed,
+		ErrorMessage:   &errorMessage,
+		Reason:         &reason,
+		WorkerId:       &s.workerID,
+		ProviderDetail: &errorMessage,
+	}, event)
+	if err != nil {
+		return err
+	}
+	s.jobFinished(ctx, tenantID, jobID)
+	return nil
+}
+
 // ListJobs returns all jobs for the tenant.
 func (s *WorkerService) ListJobs(
 	ctx context.Context,
@@ -321,16 +414,37 @@ func (s *WorkerService) CancelJob(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("job not found: %w", err))
 	}
 
-	// Check if job can be cancelled (only PENDING, SCHEDULED, RUNNING).
+	// Check if job can be cancelled (only PENDING, SCHEDULED, RUNNING, RETRYING).
 	if !isCancellableStatus(job.Status) {
 		return nil, connect.NewError(
 			connect.CodeInvalidArgument,
-			fmt.Errorf("cannot cancel job with status %s; only PENDING, SCHEDULED, or RUNNING jobs can be cancelled", job.Status),
+			fmt.Errorf("cannot cancel job with status %s; only QUEUED, PENDING, SCHEDULED, RUNNING, or RETRYING jobs can be cancelled", job.Status),
 		)
 	}
 
-	// Cancel job in cloud provider.
-	if job.GcpBatchJobPath != nil {
+	s.adoptJob(ctx, job)
+	if err := s.cancelActiveJob(ctx, job, "Job cancelled by user request"); err != nil {
+		return nil, err
+	}
+
+	response := connect.NewResponse(&jennahv1.CancelJobResponse{
+		JobId:  jobID,
+		Status: database.JobStatusCancelled,
+	})
+
+	log.Printf("Successfully cancelled job %s", jobID)
+	return response, nil
+}
+
+// cancelActiveJob cancels a cancellable job in its provider, marks it
+// CANCELLED and queues the terminal event. reason is recorded on the
+// state transition.
+func (s *WorkerService) cancelActiveJob(ctx context.Context, job *database.Job, reason string) error {
+	tenantID, jobID := job.TenantId, job.JobId
+
+	// Cancel job in cloud provider. A RETRYING job's last attempt has already
+	// failed, so there is nothing running to cancel.
+	if job.GcpBatchJobPath != nil && job.Status != database.JobStatusRetrying {
 		// Determine which provider to use based on AssignedService.
 		// Default to Cloud Batch for backward compatibility with jobs that don't have AssignedService set.
 		assignedService := router.AssignedServiceCloudBatch
@@ -348,30 +462,21 @@ func (s *WorkerService) CancelJob(
 		}
 
 		// Route to the appropriate provider.
-		err = s.dispatcher.CancelJob(ctx, assignedService, *job.GcpBatchJobPath)
+		var err error
+		if s.dispatcher != nil {
+			err = s.dispatcher.CancelJob(ctx, assignedService, *job.GcpBatchJobPath)
+		} else {
+			err = s.batchProvider.CancelJob(ctx, *job.GcpBatchJobPath)
+		}
 		if err != nil {
 			log.Printf("Error cancelling job in provider: %v", err)
-			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to cancel job in provider: %w", err))
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to cancel job in provider: %w", err))
 		}
 		log.Printf("Job %s cancelled in provider (%s)", jobID, assignedService)
 	}
 
-	// Update job status to CANCELLED in database.
-	err = s.dbClient.UpdateJobStatus(ctx, tenantID, jobID, database.JobStatusCancelled)
-	if err != nil {
-		log.Printf("Error updating job status to CANCELLED: %v", err)
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update job status: %w", err))
-	}
-
-	// Record state transition.
+	// Update job status to CANCELLED in database, queueing the terminal event.
 	transitionID := uuid.New().String()
-	reason := "Job cancelled by user request"
-	err = s.dbClient.RecordStateTransition(ctx, tenantID, jobID, transitionID, &job.Status, database.JobStatusCancelled, &reason)
-	if err != nil {
-		log.Printf("Error recording state transition: %v", err)
-	}
-
-	// Publish terminal event for cancellation.
 	event := notifier.BuildEvent(transitionID, tenantID, jobID, database.JobStatusCancelled, job.Status)
 	if job.GcpBatchJobPath != nil {
 		event.CloudResourcePath = *job.GcpBatchJobPath
@@ -382,18 +487,26 @@ func (s *WorkerService) CancelJob(
 	if job.AssignedService != nil {
 		event.AssignedService = *job.AssignedService
 	}
-	s.publishTerminalEvent(ctx, event, tenantID)
+	err := s.changeJobStatus(ctx, &database.JobStatusChange{
+		TenantId:     tenantID,
+		JobId:        jobID,
+		TransitionId: transitionID,
+		FromStatus:   &job.Status,
+		ToStatus:     database.JobStatusCancelled,
+		Reason:       &reason,
+		WorkerId:     &s.workerID,
+	}, event)
+	if err != nil {
+		log.Printf("Error updating job status to CANCELLED: %v", err)
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update job status: %w", err))
+	}
+	s.jobFinished(ctx, tenantID, jobID)
 
-	// Stop the poller for this job.
-	s.stopPollerForJob(tenantID, jobID)
+	// Stop tracking the job and any scheduled retry for it.
+	s.untrackJob(tenantID, jobID)
+	s.cancelRetry(tenantID, jobID)
 
-	response := connect.NewResponse(&jennahv1.CancelJobResponse{
-		JobId:  jobID,
-		Status: database.JobStatusCancelled,
-	})
-
-	log.Printf("Successfully cancelled job %s", jobID)
-	return response, nil
+	return nil
 }
 
 // DeleteJob deletes a job from the cloud provider and the database.
@@ -456,8 +569,9 @@ func (s *WorkerService) DeleteJob(
 	}
 	log.Printf("Job %s deleted from database", jobID)
 
-	// Stop the poller for this job.
-	s.stopPollerForJob(tenantID, jobID)
+	// Stop tracking the job and any scheduled retry for it.
+	s.untrackJob(tenantID, jobID)
+	s.cancelRetry(tenantID, jobID)
 
 	response := connect.NewResponse(&jennahv1.DeleteJobResponse{
 		JobId:   jobID,
@@ -491,11 +605,23 @@ func (s *WorkerService) GetJob(
 		log.Printf("Error retrieving job: %v", err)
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("job not found: %w", err))
 	}
+	s.adoptJob(ctx, job)
 
 	response := connect.NewResponse(&jennahv1.GetJobResponse{
 		Job: dbJobToProto(job),
 	})
 
+	// Workflow nodes come with the whole workflow so callers can see the
+	// status of the other nodes.
+	if job.WorkflowId != nil {
+		workflow, err := s.workflowToProto(ctx, tenantID, *job.WorkflowId)
+		if err != nil {
+			log.Printf("Error loading workflow %s of job %s: %v", *job.WorkflowId, jobID, err)
+		} else {
+			response.Msg.Workflow = workflow
+		}
+	}
+
 	log.Printf("Successfully retrieved job %s for tenant %s", jobID, tenantID)
 	return response, nil
 }
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+func TestSubmitJobStoresIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	provider := &fakeProvider{}
+	s, store := newRetryTestService(t, provider, &database.Job{
+		JobId:          runningJobID,
+		Status:         database.JobStatusRunning,
+		ImageUri:       "img",
+		IdempotencyKey: strPtr("retry-1"),
+	})
+
+	// A concurrent retry lost the race for the key: nothing is dispatched
+	// and the gateway is told to replay the other submission.
+	req := connect.NewRequest(&jennahv1.SubmitJobRequest{JobId: queuedJobID, ImageUri: "img", IdempotencyKey: "retry-1"})
+	req.Header().Set("X-Tenant-Id", "tenant-1")
+	req.Header().Set("X-Request-Hash", "abc")
+	if _, err := s.SubmitJob(ctx, req); connect.CodeOf(err) != connect.CodeAlreadyExists {
+		t.Fatalf("SubmitJob with a used key: got %v, want AlreadyExists", err)
+	}
+	if len(provider.submissions()) != 0 {
+		t.Fatalf("duplicate submission reached the provider")
+	}
+
+	req = connect.NewRequest(&jennahv1.SubmitJobRequest{JobId: queuedJobID, ImageUri: "img", IdempotencyKey: "retry-2", Labels: map[string]string{"team": "billing"}})
+	req.Header().Set("X-Tenant-Id", "tenant-1")
+	req.Header().Set("X-Request-Hash", "abc")
+	if _, err := s.SubmitJob(ctx, req); err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	job, err := store.GetJobByIdempotencyKey(ctx, "tenant-1", "retry-2")
+	if err != nil || job == nil || job.JobId != queuedJobID || job.RequestHash == nil || *job.RequestHash != "abc" {
+		t.Fatalf("GetJobByIdempotencyKey = %+v, %v", job, err)
+	}
+	if labels, _ := database.JobLabels(job); labels["team"] != "billing" {
+		t.Fatalf("stored labels = %v, want team=billing", labels)
+	}
+	if subs := provider.submissions(); len(subs) != 1 || subs[0].JobLabels["team"] != "billing" {
+		t.Fatalf("provider submissions = %+v, want one labelled team=billing", subs)
+	}
+}
+
+func TestSubmitJobRejectsInvalidLabels(t *testing.T) {
+	provider := &fakeProvider{}
+	s, _ := newRetryTestService(t, provider, &database.Job{JobId: runningJobID, Status: database.JobStatusRunning, ImageUri: "img"})
+
+	for _, labels := range []map[string]string{
+		{"Team": "billing"},
+		{"team": "Billing"},
+		{"goog-team": "billing"},
+		{"1team": "billing"},
+	} {
+		req := connect.NewRequest(&jennahv1.SubmitJobRequest{JobId: queuedJobID, ImageUri: "img", Labels: labels})
+		req.Header().Set("X-Tenant-Id", "tenant-1")
+		if _, err := s.SubmitJob(context.Background(), req); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("SubmitJob with labels %v: got %v, want InvalidArgument", labels, err)
+		}
+	}
+	if len(provider.submissions()) != 0 {
+		t.Fatalf("a job with invalid labels reached the provider")
+	}
+}
+
+func TestGetJobAdoptsJobOfGoneOwner(t *testing.T) {
+	ctx := context.Background()
+	path := "jobs/" + runningJobID
+	s, store := newRetryTestService(t, &fakeProvider{}, &database.Job{JobId: runningJobID, Status: database.JobStatusRunning, ImageUri: "img", GcpBatchJobPath: &path})
+	s.ConfigureReconciler(ReconcilerConfig{FastInterval: time.Hour, SlowInterval: time.Hour, Concurrency: 1})
+
+	get := func() {
+		t.Helper()
+		req := connect.NewRequest(&jennahv1.GetJobRequest{JobId: runningJobID})
+		req.Header().Set("X-Tenant-Id", "tenant-1")
+		if _, err := s.GetJob(ctx, req); err != nil {
+			t.Fatalf("GetJob: %v", err)
+		}
+	}
+	owner := func() string {
+		t.Helper()
+		job, err := store.GetJob(ctx, "tenant-1", runningJobID)
+		if err != nil {
+			t.Fatalf("GetJob: %v", err)
+		}
+		return ptrToString(job.OwnerWorkerId)
+	}
+
+	// A live lease of another worker is left alone.
+	if _, err := store.TryClaimOrRenewJobLease(ctx, "tenant-1", runningJobID, "worker-2", time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("TryClaimOrRenewJobLease: %v", err)
+	}
+	get()
+	if got := owner(); got != "worker-2" || s.trackedJobCount() != 0 {
+		t.Fatalf("owner = %s with %d tracked jobs; want worker-2 to keep the job", got, s.trackedJobCount())
+	}
+
+	// Once that lease expires the worker answering for the job claims it.
+	if _, err := store.TryClaimOrRenewJobLease(ctx, "tenant-1", runningJobID, "worker-2", time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("TryClaimOrRenewJobLease: %v", err)
+	}
+	get()
+	if got := owner(); got != "worker-1" || s.trackedJobCount() != 1 {
+		t.Fatalf("owner = %s with %d tracked jobs; want worker-1 tracking the job", got, s.trackedJobCount())
+	}
+}
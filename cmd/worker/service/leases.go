@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/notifier"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+// describeStatus asks the provider to explain the job's current state, for
+// the transition the reconciler is about to record. It returns nil when the
+// provider cannot tell.
+func describeStatus(ctx context.Context, provider batch.Provider, cloudResourcePath string) *string {
+	inspector, ok := provider.(batch.StatusInspector)
+	if !ok || cloudResourcePath == "" {
+		return nil
+	}
+	detail, err := inspector.GetJobStatusDetail(ctx, cloudResourcePath)
+	if err != nil {
+		log.Printf("Warning: could not inspect status of %s: %v", cloudResourcePath, err)
+		return nil
+	}
+	return ptrStringOrNil(detail)
+}
+
+// ResumeActiveJobs claims the leases of active jobs left by this or a dead
+// worker and starts tracking them.
+func ResumeActiveJobs(ctx context.Context, server *WorkerService) error {
+	return server.reconcileActiveJobLeases(ctx, true)
+}
+
+// StartLeaseReconciler continuously claims/renews active job ownership so jobs fail over across workers.
+func (s *WorkerService) StartLeaseReconciler(ctx context.Context) {
+	go func() {
+		if err := s.reconcileActiveJobLeases(ctx, true); err != nil {
+			log.Printf("Initial lease reconcile failed: %v", err)
+		}
+
+		ticker := time.NewTicker(s.claimInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("Lease reconciler stopped")
+				return
+			case <-ticker.C:
+				if err := s.reconcileActiveJobLeases(context.Background(), false); err != nil {
+					log.Printf("Lease reconcile tick failed: %v", err)
+				}
+				// Catches capacity freed by other workers or by raised limits.
+				if err := s.releaseAllQueuedJobs(context.Background()); err != nil {
+					log.Printf("Releasing queued jobs failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (s *WorkerService) reconcileActiveJobLeases(ctx context.Context, startup bool) error {
+	if s.isDraining() {
+		return nil
+	}
+	if startup {
+		log.Println("Scanning active jobs to claim leases...")
+	}
+
+	jobs, err := s.dbClient.ListActiveJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list active jobs: %w", err)
+	}
+
+	claimedCount := 0
+	for _, job := range jobs {
+		if job.GcpBatchJobPath == nil {
+			continue
+		}
+
+		// Note: We don't skip SIMPLE tier jobs anymore because Cloud Run jobs need status checks.
+		// Cloud Tasks jobs are rare and will just fail their status checks gracefully if encountered.
+
+		owned, err := s.claimJob(ctx, job)
+		if err != nil {
+			log.Printf("Lease claim failed for job %s: %v", job.JobId, err)
+			continue
+		}
+		if owned {
+			claimedCount++
+		}
+	}
+
+	if startup {
+		log.Printf("Lease reconcile complete: %d job(s) owned by worker %s", claimedCount, s.workerID)
+	}
+
+	return nil
+}
+
+// claimJob claims or renews the lease of an active job and, when this worker
+// owns it, tracks the job (or resumes its retry backoff). It reports whether
+// this worker owns the job.
+func (s *WorkerService) claimJob(ctx context.Context, job *database.Job) (bool, error) {
+	owned, err := s.dbClient.TryClaimOrRenewJobLease(ctx, job.TenantId, job.JobId, s.workerID, time.Now().UTC().Add(s.leaseTTL))
+	if err != nil || !owned {
+		return false, err
+	}
+	if previous := ptrToString(job.OwnerWorkerId); previous != "" && previous != s.workerID {
+		event := notifier.BuildLeaseOwnerChangedEvent(uuid.New().String(), job.TenantId, job.JobId, job.Status, previous, s.workerID)
+		event.CloudResourcePath = ptrToString(job.GcpBatchJobPath)
+		if err := s.queueJobEvent(ctx, event); err != nil {
+			log.Printf("Error queueing lease owner change of job %s: %v", job.JobId, err)
+		}
+	}
+
+	if job.Status == database.JobStatusRetrying {
+		// The previous owner may have died before resubmitting; resume
+		// whatever is left of the backoff.
+		s.scheduleRetry(job.TenantId, job.JobId, s.retryDelayLeft(ctx, job))
+		return true, nil
+	}
+
+	// Jobs resumed after a restart are checked as often as the time
+	// since their last update warrants.
+	s.trackJob(job.TenantId, job.JobId, *job.GcpBatchJobPath, job.Status, ptrToString(job.ServiceTier), router.AssignedServiceUnspecified, job.UpdatedAt)
+	return true, nil
+}
+
+// adoptJob claims an active job the gateway routed here whose owner is gone,
+// so the job is tracked by the worker answering for it. Jobs another worker
+// still holds the lease of are left alone.
+func (s *WorkerService) adoptJob(ctx context.Context, job *database.Job) {
+	if job.GcpBatchJobPath == nil || isTerminalStatus(job.Status) || s.isDraining() {
+		return
+	}
+	if job.OwnerWorkerId != nil && *job.OwnerWorkerId == s.workerID {
+		return
+	}
+	owned, err := s.claimJob(ctx, job)
+	if err != nil {
+		log.Printf("Lease claim failed for job %s: %v", job.JobId, err)
+		return
+	}
+	if owned {
+		log.Printf("Adopted job %s from worker %s", job.JobId, ptrToString(job.OwnerWorkerId))
+	}
+}
+
+// mapBatchStatusToDBStatus converts batch provider JobStatus to database status constants.
+func mapBatchStatusToDBStatus(status batch.JobStatus) string {
+	switch status {
+	case batch.JobStatusPending:
+		return database.JobStatusPending
+	case batch.JobStatusScheduled:
+		return database.JobStatusScheduled
+	case batch.JobStatusRunning:
+		return database.JobStatusRunning
+	case batch.JobStatusCompleted:
+		return database.JobStatusCompleted
+	case batch.JobStatusFailed:
+		return database.JobStatusFailed
+	case batch.JobStatusCancelled:
+		return database.JobStatusCancelled
+	default:
+		return database.JobStatusPending
+	}
+}
+
+// isTerminalStatus checks if a status is a terminal state (no further transitions expected).
+func isTerminalStatus(status string) bool {
+	return status == database.JobStatusCompleted ||
+		status == database.JobStatusFailed ||
+		status == database.JobStatusCancelled ||
+		status == database.JobStatusSkipped
+}
+
+// isCancellableStatus checks if a job can be cancelled in its current status.
+func isCancellableStatus(status string) bool {
+	return status == database.JobStatusQueued ||
+		status == database.JobStatusPending ||
+		status == database.JobStatusScheduled ||
+		status == database.JobStatusRunning ||
+		status == database.JobStatusRetrying
+}
+
+// ptrToString safely dereferences a *string, returning "" if nil.
+func ptrToString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
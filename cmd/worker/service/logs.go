@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+// defaultLogPollInterval is how often StreamJobLogs checks a followed job for
+// new output.
+const defaultLogPollInterval = 2 * time.Second
+
+// logSourceForJob returns the provider that runs job, as a LogSource.
+func (s *WorkerService) logSourceForJob(job *database.Job) (batch.LogSource, error) {
+	provider := s.batchProvider
+	if s.dispatcher != nil {
+		assignedService := router.AssignedServiceCloudBatch
+		if job.AssignedService != nil && *job.AssignedService == "CLOUD_RUN_JOB" {
+			assignedService = router.AssignedServiceCloudRunJob
+		}
+		p, err := s.dispatcher.ProviderFor(assignedService)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to resolve provider: %w", err))
+		}
+		provider = p
+	}
+
+	source, ok := provider.(batch.LogSource)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("%s provider does not support reading job logs", provider.ServiceType()))
+	}
+	return source, nil
+}
+
+// readJobLogs reads a page of the logs of job's current attempt. Jobs that
+// have not been submitted to a provider yet have no logs.
+func (s *WorkerService) readJobLogs(ctx context.Context, job *database.Job, pageToken string, pageSize int) (*batch.LogPage, error) {
+	if job.GcpBatchJobPath == nil {
+		return &batch.LogPage{}, nil
+	}
+	source, err := s.logSourceForJob(job)
+	if err != nil {
+		return nil, err
+	}
+	page, err := source.GetJobLogs(ctx, *job.GcpBatchJobPath, pageToken, pageSize)
+	if err != nil {
+		log.Printf("Error reading logs of job %s: %v", job.JobId, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to read job logs: %w", err))
+	}
+	return page, nil
+}
+
+func logEntriesToProto(entries []batch.LogEntry) []*jennahv1.LogEntry {
+	out := make([]*jennahv1.LogEntry, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, &jennahv1.LogEntry{
+			Timestamp: e.Timestamp.UTC().Format(time.RFC3339Nano),
+			TaskIndex: e.TaskIndex,
+			Message:   e.Message,
+		})
+	}
+	return out
+}
+
+// GetJobLogs returns a page of a job's container logs.
+func (s *WorkerService) GetJobLogs(
+	ctx context.Context,
+	req *connect.Request[jennahv1.GetJobLogsRequest],
+) (*connect.Response[jennahv1.GetJobLogsResponse], error) {
+	tenantID := req.Header().Get("X-Tenant-Id")
+	jobID := req.Msg.JobId
+
+	if tenantID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("X-Tenant-Id header is required"))
+	}
+
+	if jobID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("job_id is required"))
+	}
+
+	if req.Msg.PageSize < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("page_size must not be negative"))
+	}
+
+	job, err := s.dbClient.GetJob(ctx, tenantID, jobID)
+	if err != nil {
+		log.Printf("Error retrieving job: %v", err)
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("job not found: %w", err))
+	}
+
+	page, err := s.readJobLogs(ctx, job, req.Msg.PageToken, int(req.Msg.PageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &jennahv1.GetJobLogsResponse{
+		JobId:   jobID,
+		Entries: logEntriesToProto(page.Entries),
+	}
+	if page.More {
+		response.NextPageToken = page.NextPageToken
+	}
+	return connect.NewResponse(response), nil
+}
+
+// StreamJobLogs streams a job's container logs. With follow set, it keeps
+// sending new output until the job reaches a terminal state.
+func (s *WorkerService) StreamJobLogs(
+	ctx context.Context,
+	req *connect.Request[jennahv1.StreamJobLogsRequest],
+	stream *connect.ServerStream[jennahv1.StreamJobLogsResponse],
+) error {
+	tenantID := req.Header().Get("X-Tenant-Id")
+	jobID := req.Msg.JobId
+
+	if tenantID == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("X-Tenant-Id header is required"))
+	}
+
+	if jobID == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("job_id is required"))
+	}
+
+	log.Printf("Received StreamJobLogs request for job %s (tenant: %s, follow: %t)", jobID, tenantID, req.Msg.Follow)
+
+	var path, pageToken string
+	for {
+		// The status is read before draining the logs, so a terminal job
+		// is always drained once more after it finished.
+		job, err := s.dbClient.GetJob(ctx, tenantID, jobID)
+		if err != nil {
+			log.Printf("Error retrieving job: %v", err)
+			return connect.NewError(connect.CodeNotFound, fmt.Errorf("job not found: %w", err))
+		}
+
+		// A retried job runs under a new resource path, whose logs start
+		// from the beginning.
+		if job.GcpBatchJobPath != nil && *job.GcpBatchJobPath != path {
+			path, pageToken = *job.GcpBatchJobPath, ""
+		}
+
+		for more := true; more; {
+			page, err := s.readJobLogs(ctx, job, pageToken, batch.DefaultLogPageSize)
+			if err != nil {
+				return err
+			}
+			if len(page.Entries) > 0 {
+				if err := stream.Send(&jennahv1.StreamJobLogsResponse{Entries: logEntriesToProto(page.Entries)}); err != nil {
+					return err
+				}
+			}
+			if page.NextPageToken != "" {
+				pageToken = page.NextPageToken
+			}
+			more = page.More
+		}
+
+		if !req.Msg.Follow || isTerminalStatus(job.Status) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.logPollInterval):
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+// fakeLogProvider is a fakeProvider whose jobs write the lines in logs.
+type fakeLogProvider struct {
+	fakeProvider
+	logMu sync.Mutex
+	logs  []batch.LogEntry
+}
+
+func (p *fakeLogProvider) write(messages ...string) {
+	p.logMu.Lock()
+	defer p.logMu.Unlock()
+	for _, m := range messages {
+		p.logs = append(p.logs, batch.LogEntry{
+			Timestamp: time.Date(2026, 1, 1, 0, 0, len(p.logs), 0, time.UTC),
+			Message:   m,
+		})
+	}
+}
+
+func (p *fakeLogProvider) GetJobLogs(ctx context.Context, path, pageToken string, pageSize int) (*batch.LogPage, error) {
+	p.logMu.Lock()
+	defer p.logMu.Unlock()
+	return batch.PageLogEntries(append([]batch.LogEntry(nil), p.logs...), pageToken, pageSize)
+}
+
+func newLogsTestJob(status string) *database.Job {
+	return &database.Job{
+		JobId:           "job-1",
+		Status:          status,
+		ImageUri:        "busybox",
+		GcpBatchJobPath: strPtr("jobs/job-1"),
+	}
+}
+
+func TestGetJobLogsPages(t *testing.T) {
+	provider := &fakeLogProvider{}
+	provider.write("one", "two", "three")
+	s, _ := newRetryTestService(t, provider, newLogsTestJob(database.JobStatusCompleted))
+
+	var got []string
+	token := ""
+	for pages := 0; ; pages++ {
+		req := connect.NewRequest(&jennahv1.GetJobLogsRequest{JobId: "job-1", PageSize: 2, PageToken: token})
+		req.Header().Set("X-Tenant-Id", "tenant-1")
+		resp, err := s.GetJobLogs(context.Background(), req)
+		if err != nil {
+			t.Fatalf("GetJobLogs: %v", err)
+		}
+		for _, e := range resp.Msg.Entries {
+			got = append(got, e.Message)
+		}
+		if token = resp.Msg.NextPageToken; token == "" {
+			if pages != 1 {
+				t.Errorf("read %d pages, want 2", pages+1)
+			}
+			break
+		}
+	}
+	if len(got) != 3 || got[0] != "one" || got[2] != "three" {
+		t.Errorf("entries = %v", got)
+	}
+}
+
+func TestGetJobLogsUnsupportedProvider(t *testing.T) {
+	s, _ := newRetryTestService(t, &fakeProvider{}, newLogsTestJob(database.JobStatusRunning))
+
+	req := connect.NewRequest(&jennahv1.GetJobLogsRequest{JobId: "job-1"})
+	req.Header().Set("X-Tenant-Id", "tenant-1")
+	_, err := s.GetJobLogs(context.Background(), req)
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeUnimplemented {
+		t.Errorf("err = %v, want Unimplemented", err)
+	}
+}
+
+func TestStreamJobLogsFollowsUntilTerminal(t *testing.T) {
+	provider := &fakeLogProvider{}
+	provider.write("starting")
+	s, store := newRetryTestService(t, provider, newLogsTestJob(database.JobStatusRunning))
+	s.logPollInterval = 10 * time.Millisecond
+
+	mux := http.NewServeMux()
+	mux.Handle(jennahv1connect.NewDeploymentServiceHandler(s))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := jennahv1connect.NewDeploymentServiceClient(server.Client(), server.URL)
+
+	req := connect.NewRequest(&jennahv1.StreamJobLogsRequest{JobId: "job-1", Follow: true})
+	req.Header().Set("X-Tenant-Id", "tenant-1")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.StreamJobLogs(ctx, req)
+	if err != nil {
+		t.Fatalf("StreamJobLogs: %v", err)
+	}
+
+	var got []string
+	for stream.Receive() {
+		for _, e := range stream.Msg().Entries {
+			got = append(got, e.Message)
+		}
+		if len(got) == 1 {
+			// The job writes more and finishes while it is being followed.
+			provider.write("working", "done")
+			if err := store.UpdateJobStatus(ctx, "tenant-1", "job-1", database.JobStatusCompleted); err != nil {
+				t.Fatalf("UpdateJobStatus: %v", err)
+			}
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	if len(got) != 3 || got[0] != "starting" || got[1] != "working" || got[2] != "done" {
+		t.Errorf("streamed entries = %v", got)
+	}
+}
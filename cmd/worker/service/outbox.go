@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/navigator"
+	"github.com/alphauslabs/jennah/internal/notifier"
+)
+
+const (
+	// outboxBatchSize is the most events the relay claims at once.
+	outboxBatchSize = 100
+
+	// outboxRetention is how long delivered and dead-lettered events are
+	// kept before pruning.
+	outboxRetention = 7 * 24 * time.Hour
+
+	// outboxPruneInterval is how often the relay prunes the outbox.
+	outboxPruneInterval = time.Hour
+
+	// maxOutboxAttempts is how many times an event is published before it is
+	// dead-lettered; about 25 minutes with the default lease TTL.
+	maxOutboxAttempts = 50
+)
+
+// Outbox relay metrics, served at /debug/vars.
+var (
+	outboxPublished    = expvar.NewInt("outbox_published")     // events published
+	outboxErrors       = expvar.NewInt("outbox_errors")        // failed publishes, retried after the lease
+	outboxDeadLettered = expvar.NewInt("outbox_dead_lettered") // events no longer retried
+)
+
+// errUndecodableEvent marks an outbox payload that no retry can publish.
+var errUndecodableEvent = errors.New("undecodable event")
+
+// changeJobStatus moves a job to a new status. The status, its transition
+// and the event announcing it are written in one transaction, so the event is
+// published by the outbox relay exactly when the change is committed.
+// change.TransitionId doubles as the event ID consumers deduplicate on.
+func (s *WorkerService) changeJobStatus(ctx context.Context, change *database.JobStatusChange, event notifier.JobEvent) error {
+	e, err := s.outboxEvent(ctx, event)
+	if err != nil {
+		return err
+	}
+	change.Event = e
+	if err := s.dbClient.ChangeJobStatus(ctx, change); err != nil {
+		return err
+	}
+	s.wakeOutboxRelay()
+	return nil
+}
+
+// queueJobEvent puts an event that is not written with a status change in
+// the outbox, e.g. a lease owner change.
+func (s *WorkerService) queueJobEvent(ctx context.Context, event notifier.JobEvent) error {
+	e, err := s.outboxEvent(ctx, event)
+	if err != nil {
+		return err
+	}
+	if err := s.dbClient.InsertOutboxEvent(ctx, e); err != nil {
+		return err
+	}
+	s.wakeOutboxRelay()
+	return nil
+}
+
+// releaseJob moves a QUEUED or WAITING job to PENDING ahead of its
+// submission. The change is announced as job.status_changed; job.submitted
+// follows once the provider accepts the job.
+func (s *WorkerService) releaseJob(ctx context.Context, job *database.Job, reason string) error {
+	transitionID := uuid.New().String()
+	fromStatus := job.Status
+	event := notifier.BuildStatusEvent(transitionID, job.TenantId, job.JobId, database.JobStatusPending, fromStatus)
+	event.EventType = notifier.EventTypeStatusChanged
+	return s.changeJobStatus(ctx, &database.JobStatusChange{
+		TenantId:     job.TenantId,
+		JobId:        job.JobId,
+		TransitionId: transitionID,
+		FromStatus:   &fromStatus,
+		ToStatus:     database.JobStatusPending,
+		Reason:       &reason,
+		WorkerId:     &s.workerID,
+	}, event)
+}
+
+// recordSubmission moves a job its provider accepted from fromStatus to
+// status. The cloud resource and placement, the transition and the
+// job.submitted event are written together through changeJobStatus.
+func (s *WorkerService) recordSubmission(ctx context.Context, tenantID, jobID, fromStatus, status, reason string, plan *navigator.NavigationPlan, cloudResourcePath string, retryCount int64) error {
+	transitionID := uuid.New().String()
+	serviceTier := serviceTierFromPlan(plan)
+	assignedService := plan.AssignedService.String()
+	event := notifier.BuildSubmittedEvent(transitionID, tenantID, jobID, status, fromStatus)
+	event.CloudResourcePath = cloudResourcePath
+	event.ServiceTier = serviceTier
+	event.AssignedService = assignedService
+	event.OwnerWorkerID = s.workerID
+	event.RetryCount = retryCount
+	return s.changeJobStatus(ctx, &database.JobStatusChange{
+		TenantId:        tenantID,
+		JobId:           jobID,
+		TransitionId:    transitionID,
+		FromStatus:      &fromStatus,
+		ToStatus:        status,
+		GcpBatchJobPath: &cloudResourcePath,
+		ServiceTier:     &serviceTier,
+		AssignedService: &assignedService,
+		Reason:          &reason,
+		WorkerId:        &s.workerID,
+	}, event)
+}
+
+// outboxEvent returns the outbox row of event, enriched with the submitter's
+// email.
+func (s *WorkerService) outboxEvent(ctx context.Context, event notifier.JobEvent) (*database.OutboxEvent, error) {
+	// Enrich event with submitter email from tenant record.
+	tenant, err := s.dbClient.GetTenant(ctx, event.TenantID)
+	if err != nil {
+		log.Printf("Warning: could not look up tenant %s for event enrichment: %v", event.TenantID, err)
+	} else {
+		event.UserEmail = tenant.UserEmail
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job event: %w", err)
+	}
+	return &database.OutboxEvent{
+		EventId:   event.EventID,
+		TenantId:  event.TenantID,
+		JobId:     event.JobID,
+		EventType: event.Type(),
+		Payload:   string(payload),
+	}, nil
+}
+
+// wakeOutboxRelay asks the relay to publish new events now rather than on
+// its next tick.
+func (s *WorkerService) wakeOutboxRelay() {
+	select {
+	case s.outboxWake <- struct{}{}:
+	default:
+	}
+}
+
+// StartOutboxRelay publishes the events in the outbox every interval, and
+// right after this worker adds one, until ctx is done. Relays on several
+// workers share the outbox through leases; an event whose publish failed, or
+// whose relay died, is published again once its lease expires, until it is
+// dead-lettered. Delivered and dead-lettered events are pruned after
+// outboxRetention.
+func (s *WorkerService) StartOutboxRelay(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var lastPrune time.Time
+		for {
+			for s.relayOutbox(ctx) == outboxBatchSize {
+			}
+			if time.Since(lastPrune) >= outboxPruneInterval {
+				lastPrune = time.Now()
+				if n, err := s.dbClient.PruneOutboxEvents(ctx, lastPrune.Add(-outboxRetention)); err != nil {
+					log.Printf("Outbox prune failed: %v", err)
+				} else if n > 0 {
+					log.Printf("Pruned %d delivered or dead-lettered outbox event(s)", n)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				log.Println("Outbox relay stopped")
+				return
+			case <-ticker.C:
+			case <-s.outboxWake:
+			}
+		}
+	}()
+}
+
+// relayOutbox claims a batch of events and publishes them, returning how many
+// were claimed.
+func (s *WorkerService) relayOutbox(ctx context.Context) int {
+	events, err := s.dbClient.ClaimOutboxEvents(ctx, s.workerID, time.Now().UTC().Add(s.leaseTTL), outboxBatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Error claiming outbox events: %v", err)
+		}
+		return 0
+	}
+
+	for _, e := range events {
+		if err := s.publishOutboxEvent(ctx, e); err != nil {
+			outboxErrors.Add(1)
+			attempt := e.Attempts + 1
+			if attempt >= maxOutboxAttempts || errors.Is(err, errUndecodableEvent) {
+				outboxDeadLettered.Add(1)
+				log.Printf("Dead-lettering event %s (%s) for job %s of tenant %s after %d attempt(s): %v", e.EventId, e.EventType, e.JobId, e.TenantId, attempt, err)
+				if err := s.dbClient.DeadLetterOutboxEvent(ctx, e.EventId, err.Error()); err != nil {
+					log.Printf("Error dead-lettering event %s: %v", e.EventId, err)
+				}
+				continue
+			}
+			log.Printf("Error publishing event %s for job %s (attempt %d): %v", e.EventId, e.JobId, attempt, err)
+			if err := s.dbClient.MarkOutboxEventFailed(ctx, e.EventId, err.Error()); err != nil {
+				log.Printf("Error recording failed publish of event %s: %v", e.EventId, err)
+			}
+			continue
+		}
+		outboxPublished.Add(1)
+		// An event published but not marked is published again; consumers
+		// deduplicate by event ID.
+		if err := s.dbClient.MarkOutboxEventDelivered(ctx, e.EventId); err != nil {
+			log.Printf("Error marking event %s delivered: %v", e.EventId, err)
+		}
+	}
+	return len(events)
+}
+
+func (s *WorkerService) publishOutboxEvent(ctx context.Context, e *database.OutboxEvent) error {
+	var event notifier.JobEvent
+	if err := json.Unmarshal([]byte(e.Payload), &event); err != nil {
+		return fmt.Errorf("%w: %v", errUndecodableEvent, err)
+	}
+	return s.notifier.PublishJobEvent(ctx, event)
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/notifier"
+)
+
+// recordingNotifier records published events, failing while err is set.
+type recordingNotifier struct {
+	mu        sync.Mutex
+	err       error
+	published []notifier.JobEvent
+}
+
+func (n *recordingNotifier) PublishJobEvent(ctx context.Context, event notifier.JobEvent) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.err != nil {
+		return n.err
+	}
+	n.published = append(n.published, event)
+	return nil
+}
+
+func (n *recordingNotifier) Close() error { return nil }
+
+func TestOutboxRelayPublishesTerminalEvents(t *testing.T) {
+	ctx := context.Background()
+	s, store := newRetryTestService(t, &fakeProvider{}, &database.Job{JobId: runningJobID, Status: database.JobStatusRunning, ImageUri: "img"})
+	n := &recordingNotifier{err: errors.New("pubsub unavailable")}
+	s.notifier = n
+
+	fromStatus := database.JobStatusRunning
+	event := notifier.BuildEvent("t1", "tenant-1", runningJobID, database.JobStatusCompleted, fromStatus)
+	change := &database.JobStatusChange{
+		TenantId: "tenant-1", JobId: runningJobID, TransitionId: "t1",
+		FromStatus: &fromStatus, ToStatus: database.JobStatusCompleted, WorkerId: &s.workerID,
+	}
+	if err := s.changeJobStatus(ctx, change, event); err != nil {
+		t.Fatalf("changeJobStatus: %v", err)
+	}
+	waitForStatus(t, store, runningJobID, database.JobStatusCompleted)
+
+	// A failed publish keeps the event until its lease expires.
+	s.leaseTTL = time.Millisecond
+	if claimed := s.relayOutbox(ctx); claimed != 1 || len(n.published) != 0 {
+		t.Fatalf("relay with a failing notifier claimed %d and published %d, want 1 and 0", claimed, len(n.published))
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	n.err = nil
+	s.leaseTTL = time.Minute
+	if claimed := s.relayOutbox(ctx); claimed != 1 {
+		t.Fatalf("relay after the lease expired claimed %d, want the event again", claimed)
+	}
+	if len(n.published) != 1 {
+		t.Fatalf("published %d events, want 1", len(n.published))
+	}
+	got := n.published[0]
+	if got.EventID != "t1" || got.FinalStatus != database.JobStatusCompleted || got.UserEmail != "a@example.com" {
+		t.Fatalf("published %+v, want event t1 for COMPLETED sent to the tenant's email", got)
+	}
+
+	// Delivered events are not published again.
+	if claimed := s.relayOutbox(ctx); claimed != 0 || len(n.published) != 1 {
+		t.Fatalf("relay after delivery claimed %d and published %d, want nothing more", claimed, len(n.published))
+	}
+
+	// A status change that fails queues no event.
+	change.JobId, change.TransitionId = "missing-job", "t2"
+	if err := s.changeJobStatus(ctx, change, notifier.BuildEvent("t2", "tenant-1", "missing-job", database.JobStatusCompleted, fromStatus)); err == nil {
+		t.Fatal("changeJobStatus of a missing job succeeded")
+	}
+	if claimed := s.relayOutbox(ctx); claimed != 0 {
+		t.Fatalf("relay claimed %d events of a failed status change, want 0", claimed)
+	}
+}
+
+func TestOutboxRelayDeadLettersEvents(t *testing.T) {
+	ctx := context.Background()
+	s, store := newRetryTestService(t, &fakeProvider{}, &database.Job{JobId: runningJobID, Status: database.JobStatusRunning, ImageUri: "img"})
+	n := &recordingNotifier{err: errors.New("pubsub unavailable")}
+	s.notifier = n
+
+	// An event that keeps failing is dead-lettered on its last attempt; one
+	// that cannot be decoded, on its first.
+	for _, e := range []*database.OutboxEvent{
+		{EventId: "worn", TenantId: "tenant-1", JobId: runningJobID, EventType: "job.terminal", Payload: "{}"},
+		{EventId: "garbled", TenantId: "tenant-1", JobId: runningJobID, EventType: "job.terminal", Payload: "{"},
+	} {
+		if err := store.InsertOutboxEvent(ctx, e); err != nil {
+			t.Fatalf("InsertOutboxEvent: %v", err)
+		}
+	}
+	for i := 0; i < maxOutboxAttempts-1; i++ {
+		if err := store.MarkOutboxEventFailed(ctx, "worn", "pubsub unavailable"); err != nil {
+			t.Fatalf("MarkOutboxEventFailed: %v", err)
+		}
+	}
+
+	before := outboxDeadLettered.Value()
+	s.leaseTTL = time.Millisecond
+	if claimed := s.relayOutbox(ctx); claimed != 2 {
+		t.Fatalf("relay claimed %d, want both events", claimed)
+	}
+	if got := outboxDeadLettered.Value() - before; got != 2 {
+		t.Errorf("outbox_dead_lettered grew by %d, want 2", got)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	n.err = nil
+	if claimed := s.relayOutbox(ctx); claimed != 0 || len(n.published) != 0 {
+		t.Fatalf("relay claimed %d and published %d dead-lettered events, want none", claimed, len(n.published))
+	}
+	if deleted, err := store.PruneOutboxEvents(ctx, time.Now().Add(time.Minute)); err != nil || deleted != 2 {
+		t.Fatalf("PruneOutboxEvents = %d, %v; want both dead-lettered events", deleted, err)
+	}
+}
+
+func TestOutboxRelayPublishesLifecycleEvents(t *testing.T) {
+	ctx := context.Background()
+	s, store := newRetryTestService(t, &fakeProvider{}, &database.Job{JobId: runningJobID, Status: database.JobStatusScheduled, ImageUri: "img"})
+	n := &recordingNotifier{}
+	s.notifier = n
+
+	tracked := &trackedJob{
+		tenantID: "tenant-1", jobID: runningJobID, cloudResourcePath: "jobs/" + runningJobID,
+		currentStatus: database.JobStatusScheduled, provider: &fakeProvider{},
+	}
+	if s.applyStatus(ctx, tracked, batch.JobStatusRunning) {
+		t.Fatal("applyStatus of RUNNING reported the job done")
+	}
+	waitForStatus(t, store, runningJobID, database.JobStatusRunning)
+
+	// A worker taking over an expired lease announces the new owner.
+	path, previous, expired := "jobs/adopted", "worker-0", time.Now().Add(-time.Minute)
+	adopted := &database.Job{
+		TenantId: "tenant-1", JobId: "adopted-job", Status: database.JobStatusRunning, ImageUri: "img",
+		GcpBatchJobPath: &path, OwnerWorkerId: &previous, LeaseExpiresAt: &expired,
+	}
+	if err := store.InsertJobFull(ctx, adopted); err != nil {
+		t.Fatalf("InsertJobFull: %v", err)
+	}
+	if owned, err := s.claimJob(ctx, adopted); err != nil || !owned {
+		t.Fatalf("claimJob = %v, %v; want the expired lease claimed", owned, err)
+	}
+
+	if claimed := s.relayOutbox(ctx); claimed != 2 {
+		t.Fatalf("relay claimed %d events, want 2", claimed)
+	}
+	running, moved := n.published[0], n.published[1]
+	if running.Type() != notifier.EventTypeRunning || running.Status != database.JobStatusRunning ||
+		running.PreviousStatus != database.JobStatusScheduled || running.FinalStatus != "" || running.Version != notifier.JobEventVersion {
+		t.Fatalf("published %+v, want a versioned job.running event from SCHEDULED", running)
+	}
+	if moved.Type() != notifier.EventTypeLeaseOwnerChanged || moved.OwnerWorkerID != "worker-1" || moved.PreviousOwnerWorkerID != "worker-0" {
+		t.Fatalf("published %+v, want the lease moved from worker-0 to worker-1", moved)
+	}
+
+	// Renewing its own lease announces nothing.
+	adopted.OwnerWorkerId = &s.workerID
+	if _, err := s.claimJob(ctx, adopted); err != nil {
+		t.Fatalf("claimJob: %v", err)
+	}
+	if claimed := s.relayOutbox(ctx); claimed != 0 {
+		t.Fatalf("relay claimed %d events after a lease renewal, want 0", claimed)
+	}
+}
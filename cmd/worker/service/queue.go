@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/navigator"
+	"github.com/alphauslabs/jennah/internal/notifier"
+)
+
+// releaseAllQueuedJobs releases the QUEUED jobs of every tenant that has any.
+// The reconciler runs it so capacity freed on other workers, or by a raised
+// quota, is not missed.
+func (s *WorkerService) releaseAllQueuedJobs(ctx context.Context) error {
+	jobs, err := s.dbClient.ListQueuedJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list queued jobs: %w", err)
+	}
+
+	var tenants []string
+	for _, job := range jobs {
+		if !slices.Contains(tenants, job.TenantId) {
+			tenants = append(tenants, job.TenantId)
+		}
+	}
+	for _, tenantID := range tenants {
+		s.releaseQueuedJobs(ctx, tenantID)
+	}
+	return nil
+}
+
+// releaseQueuedJobs starts a tenant's QUEUED jobs, oldest first, for as long
+// as the next one fits in the tenant's quota.
+//
+// Limits are counted under the lease of the job about to start, after the
+// previous job has left QUEUED. Workers releasing for the same tenant at once
+// contend for the lease of the oldest queued job and the losers stop, so only
+// one of them decides at a time.
+func (s *WorkerService) releaseQueuedJobs(ctx context.Context, tenantID string) {
+	if s.isDraining() {
+		return
+	}
+	s.queueMutex.Lock()
+	defer s.queueMutex.Unlock()
+
+	jobs, err := s.dbClient.ListJobsByStatus(ctx, tenantID, database.JobStatusQueued)
+	if err != nil {
+		log.Printf("Error listing queued jobs of tenant %s: %v", tenantID, err)
+		return
+	}
+	slices.SortFunc(jobs, func(a, b *database.Job) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	for _, job := range jobs {
+		if !s.startQueuedJob(ctx, job) {
+			// The job does not fit, or another worker holds it; leave the
+			// rest of the queue for later or to that worker.
+			return
+		}
+	}
+}
+
+// startQueuedJob claims a QUEUED job and, if it fits in the tenant's quota,
+// submits it. It returns false when the job does not fit, or another worker
+// owns the job or has already started it.
+func (s *WorkerService) startQueuedJob(ctx context.Context, queued *database.Job) bool {
+	owned, err := s.dbClient.TryClaimOrRenewJobLease(ctx, queued.TenantId, queued.JobId, s.workerID, time.Now().UTC().Add(s.leaseTTL))
+	if err != nil {
+		log.Printf("Error claiming lease to start queued job %s: %v", queued.JobId, err)
+		return false
+	}
+	if !owned {
+		return false
+	}
+
+	// Re-read under the lease: another worker may have started it since the scan.
+	job, err := s.dbClient.GetJob(ctx, queued.TenantId, queued.JobId)
+	if err != nil {
+		log.Printf("Error loading queued job %s: %v", queued.JobId, err)
+		return false
+	}
+	if job.Status != database.JobStatusQueued {
+		return false
+	}
+	if s.workflowCancelled(ctx, job) {
+		// CancelWorkflow cancels the node; move on to the rest of the queue.
+		if _, err := s.dbClient.ReleaseJobLease(ctx, job.TenantId, job.JobId, s.workerID, ""); err != nil {
+			log.Printf("Error releasing lease of queued job %s: %v", job.JobId, err)
+		}
+		return true
+	}
+	if s.quotas != nil {
+		reason, err := s.quotas.CanStart(ctx, job, time.Now())
+		if err != nil || reason != "" {
+			if err != nil {
+				log.Printf("Error checking quota of tenant %s: %v", job.TenantId, err)
+			}
+			// Queued jobs wait unowned, so any worker can start them later.
+			if _, err := s.dbClient.ReleaseJobLease(ctx, job.TenantId, job.JobId, s.workerID, ""); err != nil {
+				log.Printf("Error releasing lease of queued job %s: %v", job.JobId, err)
+			}
+			return false
+		}
+	}
+
+	if err := s.releaseJob(ctx, job, "Released from the queue"); err != nil {
+		log.Printf("Error updating queued job %s to PENDING: %v", job.JobId, err)
+		return false
+	}
+
+	req, err := submitRequestFromJob(job)
+	if err != nil {
+		s.failQueuedJob(ctx, job, err.Error())
+		return true
+	}
+	plan, err := navigator.Navigate(req, job.JobId, s.jobConfig)
+	if err != nil {
+		s.failQueuedJob(ctx, job, fmt.Sprintf("failed to build execution plan: %v", err))
+		return true
+	}
+	plan.Config.JobID = generateProviderJobID(req.Name, job.JobId)
+	plan.Config.RequestID = job.JobId
+	plan.Config.TenantID = job.TenantId
+
+	jobResult, err := s.dispatchPlan(ctx, plan)
+	if err != nil {
+		s.failQueuedJob(ctx, job, fmt.Sprintf("failed to submit batch job: %v", err))
+		return true
+	}
+
+	statusToSet := string(jobResult.InitialStatus)
+	if statusToSet == "" || statusToSet == string(batch.JobStatusUnknown) {
+		statusToSet = database.JobStatusRunning
+	}
+	reason := fmt.Sprintf("Submitted from the queue: %s", jobResult.CloudResourcePath)
+	err = s.recordSubmission(ctx, job.TenantId, job.JobId, database.JobStatusPending, statusToSet, reason, plan, jobResult.CloudResourcePath, job.RetryCount)
+	if err != nil {
+		log.Printf("Error updating queued job %s to %s: %v", job.JobId, statusToSet, err)
+		return true
+	}
+
+	log.Printf("Queued job %s of tenant %s started (%s)", job.JobId, job.TenantId, jobResult.CloudResourcePath)
+	s.trackJob(job.TenantId, job.JobId, jobResult.CloudResourcePath, statusToSet, serviceTierFromPlan(plan), plan.AssignedService, time.Now())
+	return true
+}
+
+// failQueuedJob fails a released job that could not be submitted. The event
+// is queued without re-entering releaseQueuedJobs, which is still running. A
+// failed workflow node advances its workflow, so its dependents are started
+// or skipped.
+func (s *WorkerService) failQueuedJob(ctx context.Context, job *database.Job, errorMessage string) {
+	log.Printf("Error starting queued job %s: %s", job.JobId, errorMessage)
+	transitionID := uuid.New().String()
+	fromStatus := database.JobStatusPending
+	event := notifier.BuildEvent(transitionID, job.TenantId, job.JobId, database.JobStatusFailed, database.JobStatusPending)
+	event.ErrorMessage = errorMessage
+	err := s.changeJobStatus(ctx, &database.JobStatusChange{
+		TenantId:     job.TenantId,
+		JobId:        job.JobId,
+		TransitionId: transitionID,
+		FromStatus:   &fromStatus,
+		ToStatus:     database.JobStatusFailed,
+		ErrorMessage: &errorMessage,
+		Reason:       &errorMessage,
+		WorkerId:     &s.workerID,
+	}, event)
+	if err != nil {
+		log.Printf("Error updating job status to FAILED: %v", err)
+		return
+	}
+	if job.WorkflowId != nil {
+		s.advanceWorkflow(ctx, job.TenantId, *job.WorkflowId)
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/notifier"
+	"github.com/alphauslabs/jennah/internal/quota"
+)
+
+// Job IDs are UUIDs; generateProviderJobID needs at least eight characters.
+const (
+	runningJobID = "aaaaaaaa-0000-0000-0000-000000000001"
+	queuedJobID  = "bbbbbbbb-0000-0000-0000-000000000002"
+)
+
+func TestQueuedJobIsReleasedWhenCapacityFrees(t *testing.T) {
+	ctx := context.Background()
+	provider := &fakeProvider{}
+	s, store := newRetryTestService(t, provider, &database.Job{
+		JobId:           runningJobID,
+		Status:          database.JobStatusRunning,
+		ImageUri:        "img",
+		GcpBatchJobPath: strPtr("jobs/" + runningJobID),
+	})
+	s.quotas = quota.NewChecker(store, quota.Limits{MaxConcurrentJobs: 1, QueueWhenBusy: true}, nil)
+
+	req := connect.NewRequest(&jennahv1.SubmitJobRequest{JobId: queuedJobID, ImageUri: "img"})
+	req.Header().Set("X-Tenant-Id", "tenant-1")
+	req.Header().Set("X-Queue-Reason", "1 of 1 concurrent jobs are active")
+	resp, err := s.SubmitJob(ctx, req)
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	if resp.Msg.Status != database.JobStatusQueued || len(provider.submissions()) != 0 {
+		t.Fatalf("SubmitJob = %+v with %d submissions; want QUEUED and nothing submitted", resp.Msg, len(provider.submissions()))
+	}
+
+	// Still no room: the job stays queued, and unowned for any worker.
+	if err := s.releaseAllQueuedJobs(ctx); err != nil {
+		t.Fatalf("releaseAllQueuedJobs: %v", err)
+	}
+	if job := waitForStatus(t, store, queuedJobID, database.JobStatusQueued); job.OwnerWorkerId != nil {
+		t.Fatalf("queued job kept by %s after its quota check failed", *job.OwnerWorkerId)
+	}
+
+	// A worker that cannot take the oldest queued job's lease releases nothing.
+	if _, err := store.TryClaimOrRenewJobLease(ctx, "tenant-1", queuedJobID, "worker-2", time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("TryClaimOrRenewJobLease: %v", err)
+	}
+	s.quotas = nil
+	s.releaseQueuedJobs(ctx, "tenant-1")
+	if len(provider.submissions()) != 0 {
+		t.Fatal("queued job leased by another worker was started")
+	}
+	if _, err := store.ReleaseJobLease(ctx, "tenant-1", queuedJobID, "worker-2", ""); err != nil {
+		t.Fatalf("ReleaseJobLease: %v", err)
+	}
+	s.quotas = quota.NewChecker(store, quota.Limits{MaxConcurrentJobs: 1, QueueWhenBusy: true}, nil)
+
+	if err := store.CompleteJob(ctx, "tenant-1", runningJobID); err != nil {
+		t.Fatalf("CompleteJob: %v", err)
+	}
+	s.jobFinished(ctx, "tenant-1", runningJobID)
+
+	job := waitForStatus(t, store, queuedJobID, database.JobStatusScheduled)
+	if job.GcpBatchJobPath == nil || len(provider.submissions()) != 1 {
+		t.Fatalf("released job = %+v with %d submissions", job, len(provider.submissions()))
+	}
+	// Newest first.
+	transitions, _ := store.GetJobTransitions(ctx, "tenant-1", queuedJobID)
+	if len(transitions) != 3 || transitions[2].ToStatus != database.JobStatusQueued || transitions[1].ToStatus != database.JobStatusPending {
+		t.Fatalf("got %d transitions, want SCHEDULED, PENDING, QUEUED", len(transitions))
+	}
+
+	// Every transition is announced by exactly one outbox event with its ID.
+	events, err := store.ClaimOutboxEvents(ctx, "test", time.Now().Add(time.Minute), 100)
+	if err != nil {
+		t.Fatalf("ClaimOutboxEvents: %v", err)
+	}
+	perTransition := make(map[string]int)
+	eventTypes := make(map[string]string)
+	for _, e := range events {
+		if e.JobId == queuedJobID {
+			perTransition[e.EventId]++
+			eventTypes[e.EventId] = e.EventType
+		}
+	}
+	wantTypes := map[string]string{
+		database.JobStatusQueued:    notifier.EventTypeStatusChanged,
+		database.JobStatusPending:   notifier.EventTypeStatusChanged,
+		database.JobStatusScheduled: notifier.EventTypeSubmitted,
+	}
+	if len(perTransition) != len(transitions) {
+		t.Fatalf("got events for %d transitions, want %d", len(perTransition), len(transitions))
+	}
+	for _, tr := range transitions {
+		if perTransition[tr.TransitionId] != 1 || eventTypes[tr.TransitionId] != wantTypes[tr.ToStatus] {
+			t.Fatalf("transition to %s has %d event(s) of type %q, want one %s", tr.ToStatus, perTransition[tr.TransitionId], eventTypes[tr.TransitionId], wantTypes[tr.ToStatus])
+		}
+	}
+}
+
+func TestCancelQueuedJob(t *testing.T) {
+	ctx := context.Background()
+	provider := &fakeProvider{}
+	s, store := newRetryTestService(t, provider, &database.Job{JobId: queuedJobID, Status: database.JobStatusQueued, ImageUri: "img"})
+
+	req := connect.NewRequest(&jennahv1.CancelJobRequest{JobId: queuedJobID})
+	req.Header().Set("X-Tenant-Id", "tenant-1")
+	if _, err := s.CancelJob(ctx, req); err != nil {
+		t.Fatalf("CancelJob: %v", err)
+	}
+	waitForStatus(t, store, queuedJobID, database.JobStatusCancelled)
+
+	// A cancelled job is never released.
+	if err := s.releaseAllQueuedJobs(ctx); err != nil {
+		t.Fatalf("releaseAllQueuedJobs: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if len(provider.submissions()) != 0 {
+		t.Fatalf("cancelled job was submitted: %+v", provider.submissions())
+	}
+}
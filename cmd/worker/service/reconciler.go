@@ -0,0 +1,590 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/notifier"
+	"github.com/alphauslabs/jennah/internal/router"
+	"github.com/alphauslabs/jennah/internal/statusevents"
+)
+
+const (
+	// reconcileTick is how often the reconciler looks for jobs due a check.
+	reconcileTick = time.Second
+
+	// statusBatchSize is the most jobs read in one request from a provider
+	// that can read several at once.
+	statusBatchSize = 50
+
+	// maxFailedChecks is how many status reads in a row may fail before the
+	// job is dropped. The lease reconciler tracks it again while it is active.
+	maxFailedChecks = 10
+)
+
+// Reconciler metrics, served at /debug/vars.
+var (
+	reconcileTrackedJobs = expvar.NewInt("reconcile_tracked_jobs")
+	reconcileLag         = expvar.NewMap("reconcile_lag_seconds") // provider → how late the most overdue check of the last round started
+	reconcileChecks      = expvar.NewMap("reconcile_checks")      // provider → job statuses read
+	reconcileRequests    = expvar.NewMap("reconcile_requests")    // provider → status requests sent
+	reconcileErrors      = expvar.NewMap("reconcile_errors")      // provider → failed status requests
+)
+
+// ReconcilerConfig tunes how often tracked jobs are checked and how hard the
+// providers are asked.
+type ReconcilerConfig struct {
+	// FastInterval is the check interval right after a job is submitted or
+	// changes status. The interval grows with the time the job has spent in
+	// its status, up to SlowInterval.
+	FastInterval time.Duration
+	SlowInterval time.Duration
+
+	// Concurrency bounds the status requests in flight.
+	Concurrency int
+
+	// RateLimits caps status requests per second by provider service type,
+	// e.g. "CLOUD_BATCH". Providers without an entry are not limited.
+	RateLimits map[string]float64
+}
+
+// DefaultReconcilerConfig returns the configuration used when none is set.
+func DefaultReconcilerConfig() ReconcilerConfig {
+	return ReconcilerConfig{
+		FastInterval: 5 * time.Second,
+		SlowInterval: time.Minute,
+		Concurrency:  8,
+	}
+}
+
+// ReconcilerConfigFromEnv reads WORKER_RECONCILE_FAST_INTERVAL_SECONDS,
+// WORKER_RECONCILE_SLOW_INTERVAL_SECONDS, WORKER_RECONCILE_CONCURRENCY and
+// WORKER_RECONCILE_RATE_LIMITS (e.g. "CLOUD_BATCH=5,CLOUD_RUN_JOB=10").
+// Unset variables keep their defaults.
+func ReconcilerConfigFromEnv() (ReconcilerConfig, error) {
+	cfg := DefaultReconcilerConfig()
+	ints := []struct {
+		key string
+		dst *int
+	}{
+		{"WORKER_RECONCILE_CONCURRENCY", &cfg.Concurrency},
+	}
+	durations := []struct {
+		key string
+		dst *time.Duration
+	}{
+		{"WORKER_RECONCILE_FAST_INTERVAL_SECONDS", &cfg.FastInterval},
+		{"WORKER_RECONCILE_SLOW_INTERVAL_SECONDS", &cfg.SlowInterval},
+	}
+	for _, v := range ints {
+		raw := strings.TrimSpace(os.Getenv(v.key))
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return ReconcilerConfig{}, fmt.Errorf("invalid %s %q: want a positive integer", v.key, raw)
+		}
+		*v.dst = n
+	}
+	for _, v := range durations {
+		raw := strings.TrimSpace(os.Getenv(v.key))
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return ReconcilerConfig{}, fmt.Errorf("invalid %s %q: want a positive number of seconds", v.key, raw)
+		}
+		*v.dst = time.Duration(n) * time.Second
+	}
+	if cfg.SlowInterval < cfg.FastInterval {
+		return ReconcilerConfig{}, fmt.Errorf("WORKER_RECONCILE_SLOW_INTERVAL_SECONDS (%s) is shorter than WORKER_RECONCILE_FAST_INTERVAL_SECONDS (%s)", cfg.SlowInterval, cfg.FastInterval)
+	}
+	limits, err := parseRateLimits(os.Getenv("WORKER_RECONCILE_RATE_LIMITS"))
+	if err != nil {
+		return ReconcilerConfig{}, fmt.Errorf("invalid WORKER_RECONCILE_RATE_LIMITS: %w", err)
+	}
+	cfg.RateLimits = limits
+	return cfg, nil
+}
+
+// parseRateLimits parses comma-separated SERVICE_TYPE=requests-per-second pairs.
+func parseRateLimits(raw string) (map[string]float64, error) {
+	limits := make(map[string]float64)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		serviceType, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q: want SERVICE_TYPE=requests-per-second", pair)
+		}
+		perSecond, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || perSecond <= 0 {
+			return nil, fmt.Errorf("%q: want a positive number of requests per second", pair)
+		}
+		limits[strings.ToUpper(strings.TrimSpace(serviceType))] = perSecond
+	}
+	return limits, nil
+}
+
+// trackedJob is an active job whose status this worker reconciles. Its
+// fields are guarded by trackedMutex, except that a check in flight owns the
+// job until it reschedules it.
+type trackedJob struct {
+	tenantID          string
+	jobID             string
+	cloudResourcePath string
+	currentStatus     string
+	serviceTier       string
+	assignedService   router.AssignedService
+	provider          batch.Provider
+	lastChange        time.Time // submission or last status change
+	nextCheck         time.Time
+	failedChecks      int
+	checking          bool // a check is in flight
+	recheck           bool // checkJobNow was called during the check
+	untracked         bool
+}
+
+// ConfigureReconciler replaces the reconciler configuration. Jobs already
+// tracked keep their next check time, so call it before tracking any.
+func (s *WorkerService) ConfigureReconciler(cfg ReconcilerConfig) {
+	s.trackedMutex.Lock()
+	defer s.trackedMutex.Unlock()
+	s.reconciler = cfg
+	s.limiters = nil
+}
+
+// trackJob starts reconciling a job's status. since is when the job was
+// submitted or last changed status; the longer ago, the less often it is
+// checked. Tracking a job twice has no effect.
+func (s *WorkerService) trackJob(tenantID, jobID, cloudResourcePath, status, serviceTier string, assignedService router.AssignedService, since time.Time) {
+	key := fmt.Sprintf("%s/%s", tenantID, jobID)
+
+	if assignedService == router.AssignedServiceUnspecified {
+		// Infer from service tier (for recovered jobs).
+		if serviceTier == database.ServiceTierComplex {
+			assignedService = router.AssignedServiceCloudBatch
+		} else {
+			// SIMPLE tier - default to Cloud Run (Cloud Tasks don't need polling)
+			assignedService = router.AssignedServiceCloudRunJob
+		}
+	}
+
+	provider := s.batchProvider
+	if s.dispatcher != nil {
+		p, err := s.dispatcher.ProviderFor(assignedService)
+		if err != nil {
+			log.Printf("Failed to get provider for service %s, falling back to batchProvider: %v", assignedService, err)
+		} else {
+			provider = p
+		}
+	}
+
+	// Drain lists the jobs to hand off once draining is set; a job tracked
+	// after that would keep its lease until the TTL, so it is released.
+	s.drainMutex.Lock()
+	if s.draining {
+		s.drainMutex.Unlock()
+		s.releaseDrainedJob(tenantID, jobID)
+		return
+	}
+	defer s.drainMutex.Unlock()
+
+	s.trackedMutex.Lock()
+	defer s.trackedMutex.Unlock()
+	if s.tracked == nil {
+		s.tracked = make(map[string]*trackedJob)
+	}
+	if _, exists := s.tracked[key]; exists {
+		return
+	}
+	s.tracked[key] = &trackedJob{
+		tenantID:          tenantID,
+		jobID:             jobID,
+		cloudResourcePath: cloudResourcePath,
+		currentStatus:     status,
+		serviceTier:       serviceTier,
+		assignedService:   assignedService,
+		provider:          provider,
+		lastChange:        since,
+		nextCheck:         time.Now().Add(s.reconciler.FastInterval),
+	}
+	reconcileTrackedJobs.Set(int64(len(s.tracked)))
+	log.Printf("Tracking job %s (tenant: %s, service: %s, provider: %s)", jobID, tenantID, assignedService, provider.ServiceType())
+
+	if s.reconcilerStop == nil {
+		s.reconcilerStop = make(chan struct{})
+		go s.runReconciler(s.reconcilerStop)
+	}
+}
+
+// untrackJob stops reconciling a job's status.
+func (s *WorkerService) untrackJob(tenantID, jobID string) {
+	s.trackedMutex.Lock()
+	defer s.trackedMutex.Unlock()
+
+	key := fmt.Sprintf("%s/%s", tenantID, jobID)
+	if job, exists := s.tracked[key]; exists {
+		log.Printf("Untracking job %s", jobID)
+		job.untracked = true
+		delete(s.tracked, key)
+		reconcileTrackedJobs.Set(int64(len(s.tracked)))
+	}
+}
+
+// checkJobNow makes the job with the given cloud resource path due for a
+// check, and reports whether this worker tracks it.
+func (s *WorkerService) checkJobNow(cloudResourcePath string) (string, bool) {
+	s.trackedMutex.Lock()
+	defer s.trackedMutex.Unlock()
+
+	for _, job := range s.tracked {
+		if !statusevents.SameJob(job.cloudResourcePath, cloudResourcePath) {
+			continue
+		}
+		if job.checking {
+			job.recheck = true
+		} else {
+			job.nextCheck = time.Now()
+			s.wakeReconciler()
+		}
+		return job.jobID, true
+	}
+	return "", false
+}
+
+func (s *WorkerService) wakeReconciler() {
+	select {
+	case s.reconcilerWake <- struct{}{}:
+	default:
+	}
+}
+
+// StopReconciler stops reconciling all tracked jobs and cancels scheduled
+// retries.
+func (s *WorkerService) StopReconciler() {
+	s.trackedMutex.Lock()
+	log.Printf("Stopping status reconciler with %d tracked job(s)", len(s.tracked))
+	for _, job := range s.tracked {
+		job.untracked = true
+	}
+	s.tracked = make(map[string]*trackedJob)
+	reconcileTrackedJobs.Set(0)
+	if s.reconcilerStop != nil {
+		close(s.reconcilerStop)
+		s.reconcilerStop = nil
+	}
+	s.trackedMutex.Unlock()
+
+	s.stopAllRetries()
+}
+
+// runReconciler checks the tracked jobs that are due until stop is closed.
+func (s *WorkerService) runReconciler(stop chan struct{}) {
+	ticker := time.NewTicker(reconcileTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			log.Println("Status reconciler stopped")
+			return
+		case <-ticker.C:
+		case <-s.reconcilerWake:
+		}
+		s.reconcileDueJobs(context.Background())
+	}
+}
+
+// reconcileDueJobs reads the status of every job due a check, one request
+// per batch of jobs for providers that can read several at once, and applies
+// the changes. It returns once all checks are done.
+func (s *WorkerService) reconcileDueJobs(ctx context.Context) {
+	now := time.Now()
+	due := make(map[batch.Provider][]*trackedJob)
+	lag := make(map[string]time.Duration)
+
+	s.trackedMutex.Lock()
+	concurrency := max(s.reconciler.Concurrency, 1)
+	for _, job := range s.tracked {
+		if job.checking {
+			continue
+		}
+		serviceType := job.provider.ServiceType()
+		if now.Before(job.nextCheck) {
+			if _, ok := lag[serviceType]; !ok {
+				lag[serviceType] = 0
+			}
+			continue
+		}
+		job.checking = true
+		due[job.provider] = append(due[job.provider], job)
+		lag[serviceType] = max(lag[serviceType], now.Sub(job.nextCheck))
+	}
+	s.trackedMutex.Unlock()
+
+	for serviceType, late := range lag {
+		v := new(expvar.Float)
+		v.Set(late.Seconds())
+		reconcileLag.Set(serviceType, v)
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	run := func(check func()) {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			check()
+		}()
+	}
+	for provider, jobs := range due {
+		lister, ok := provider.(batch.StatusLister)
+		if !ok || len(jobs) == 1 {
+			for _, job := range jobs {
+				run(func() { s.checkJob(ctx, job) })
+			}
+			continue
+		}
+		for chunk := range slices.Chunk(jobs, statusBatchSize) {
+			run(func() { s.checkJobs(ctx, provider, lister, chunk) })
+		}
+	}
+	wg.Wait()
+}
+
+// checkJobs reads the status of several jobs of one provider in one request.
+// Jobs the provider did not return are checked one by one.
+func (s *WorkerService) checkJobs(ctx context.Context, provider batch.Provider, lister batch.StatusLister, jobs []*trackedJob) {
+	serviceType := provider.ServiceType()
+	paths := make([]string, len(jobs))
+	for i, job := range jobs {
+		paths[i] = job.cloudResourcePath
+	}
+
+	if err := s.waitForProvider(ctx, serviceType); err != nil {
+		for _, job := range jobs {
+			s.finishCheck(ctx, job, batch.JobStatusUnknown, err)
+		}
+		return
+	}
+	reconcileRequests.Add(serviceType, 1)
+	statuses, err := lister.GetJobStatuses(ctx, paths)
+	if err != nil {
+		reconcileErrors.Add(serviceType, 1)
+		for _, job := range jobs {
+			s.finishCheck(ctx, job, batch.JobStatusUnknown, err)
+		}
+		return
+	}
+	for _, job := range jobs {
+		status, ok := statuses[job.cloudResourcePath]
+		if !ok {
+			s.checkJob(ctx, job)
+			continue
+		}
+		reconcileChecks.Add(serviceType, 1)
+		s.finishCheck(ctx, job, status, nil)
+	}
+}
+
+// checkJob reads one job's status.
+func (s *WorkerService) checkJob(ctx context.Context, job *trackedJob) {
+	serviceType := job.provider.ServiceType()
+	if err := s.waitForProvider(ctx, serviceType); err != nil {
+		s.finishCheck(ctx, job, batch.JobStatusUnknown, err)
+		return
+	}
+	reconcileRequests.Add(serviceType, 1)
+	status, err := job.provider.GetJobStatus(ctx, job.cloudResourcePath)
+	if err != nil {
+		reconcileErrors.Add(serviceType, 1)
+
+		// If this is a SIMPLE tier job failing with Cloud Run provider, it might actually be a Cloud Batch job
+		// (e.g., created before the dispatcher was implemented). Try falling back to Cloud Batch.
+		if job.serviceTier == database.ServiceTierSimple && job.assignedService == router.AssignedServiceCloudRunJob && s.dispatcher != nil {
+			if batchProvider, perr := s.dispatcher.ProviderFor(router.AssignedServiceCloudBatch); perr == nil {
+				log.Printf("Retrying job %s with Cloud Batch provider (fallback)", job.jobID)
+				if status, perr = batchProvider.GetJobStatus(ctx, job.cloudResourcePath); perr == nil {
+					log.Printf("Job %s is actually a Cloud Batch job, tracking it there", job.jobID)
+					s.trackedMutex.Lock()
+					job.provider = batchProvider
+					job.assignedService = router.AssignedServiceCloudBatch
+					s.trackedMutex.Unlock()
+					err = nil
+				} else {
+					log.Printf("Cloud Batch fallback also failed for job %s: %v", job.jobID, perr)
+				}
+			}
+		}
+	}
+	if err == nil {
+		reconcileChecks.Add(job.provider.ServiceType(), 1)
+	}
+	s.finishCheck(ctx, job, status, err)
+}
+
+// waitForProvider blocks until the provider's rate limit allows another
+// request.
+func (s *WorkerService) waitForProvider(ctx context.Context, serviceType string) error {
+	s.trackedMutex.Lock()
+	if s.limiters == nil {
+		s.limiters = make(map[string]*rate.Limiter)
+	}
+	limiter, ok := s.limiters[serviceType]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Inf, 0)
+		if perSecond, limited := s.reconciler.RateLimits[serviceType]; limited {
+			limiter = rate.NewLimiter(rate.Limit(perSecond), max(1, int(perSecond)))
+		}
+		s.limiters[serviceType] = limiter
+	}
+	s.trackedMutex.Unlock()
+	return limiter.Wait(ctx)
+}
+
+// finishCheck applies a status read (or its failure) to the job and
+// schedules the next check.
+func (s *WorkerService) finishCheck(ctx context.Context, job *trackedJob, status batch.JobStatus, err error) {
+	if err != nil {
+		job.failedChecks++
+		log.Printf("Error checking job %s (attempt %d/%d) [service=%s, tier=%s, path=%s]: %v",
+			job.jobID, job.failedChecks, maxFailedChecks,
+			job.assignedService, job.serviceTier, job.cloudResourcePath, err)
+		if job.failedChecks >= maxFailedChecks {
+			log.Printf("Max failed checks reached for job %s, untracking it", job.jobID)
+			s.untrackJob(job.tenantID, job.jobID)
+			return
+		}
+		s.scheduleNextCheck(job)
+		return
+	}
+	job.failedChecks = 0
+
+	// A drained job's lease was released; renewing it would take it back.
+	// Drain untracks jobs under renewMutex, so a renewal that passed this
+	// check finishes before Drain releases the lease.
+	s.renewMutex.RLock()
+	s.trackedMutex.Lock()
+	untracked := job.untracked
+	s.trackedMutex.Unlock()
+	if untracked {
+		s.renewMutex.RUnlock()
+		return
+	}
+	owned, err := s.dbClient.TryClaimOrRenewJobLease(ctx, job.tenantID, job.jobID, s.workerID, time.Now().UTC().Add(s.leaseTTL))
+	s.renewMutex.RUnlock()
+	if err != nil {
+		log.Printf("Error renewing lease for job %s: %v", job.jobID, err)
+		s.scheduleNextCheck(job)
+		return
+	}
+	if !owned {
+		log.Printf("Lease ownership lost for job %s; untracking it", job.jobID)
+		s.untrackJob(job.tenantID, job.jobID)
+		return
+	}
+
+	s.trackedMutex.Lock()
+	untracked = job.untracked
+	s.trackedMutex.Unlock()
+	if untracked {
+		return
+	}
+
+	if s.applyStatus(ctx, job, status) {
+		s.untrackJob(job.tenantID, job.jobID)
+		return
+	}
+	s.scheduleNextCheck(job)
+}
+
+// applyStatus records a job's status if it changed. It returns true when the
+// job no longer needs checking: it finished, or failed and will be retried.
+func (s *WorkerService) applyStatus(ctx context.Context, job *trackedJob, status batch.JobStatus) bool {
+	dbStatus := mapBatchStatusToDBStatus(status)
+	if dbStatus == job.currentStatus {
+		return false
+	}
+	oldStatus := job.currentStatus
+	job.currentStatus = dbStatus
+	job.lastChange = time.Now()
+
+	log.Printf("Job %s status changed: %s → %s", job.jobID, oldStatus, dbStatus)
+
+	// A failed attempt may be resubmitted instead of ending the job.
+	if dbStatus == database.JobStatusFailed && s.retryJobAfterFailure(ctx, job, oldStatus) {
+		return true
+	}
+
+	// The status, its transition and the event announcing it are written
+	// together; on failure the change is retried on the next check.
+	transitionID := uuid.New().String()
+	reason := "Status updated from " + job.provider.ServiceType()
+	change := &database.JobStatusChange{
+		TenantId:       job.tenantID,
+		JobId:          job.jobID,
+		TransitionId:   transitionID,
+		FromStatus:     &oldStatus,
+		ToStatus:       dbStatus,
+		Reason:         &reason,
+		WorkerId:       &s.workerID,
+		ProviderDetail: describeStatus(ctx, job.provider, job.cloudResourcePath),
+	}
+	event := notifier.BuildStatusEvent(transitionID, job.tenantID, job.jobID, dbStatus, oldStatus)
+	event.CloudResourcePath = job.cloudResourcePath
+	event.ServiceTier = job.serviceTier
+	event.AssignedService = job.assignedService.String()
+	event.OwnerWorkerID = s.workerID
+	if err := s.changeJobStatus(ctx, change, event); err != nil {
+		log.Printf("Error updating job status in database: %v", err)
+		job.currentStatus = oldStatus
+		return false
+	}
+	if !isTerminalStatus(dbStatus) {
+		return false
+	}
+	log.Printf("Job %s reached terminal status %s, untracking it", job.jobID, dbStatus)
+	s.jobFinished(ctx, job.tenantID, job.jobID)
+	return true
+}
+
+// scheduleNextCheck ends the job's check. Jobs are checked every FastInterval
+// right after they change, and less often the longer they keep a status: a
+// tenth of the time since the last change, capped at SlowInterval.
+func (s *WorkerService) scheduleNextCheck(job *trackedJob) {
+	s.trackedMutex.Lock()
+	defer s.trackedMutex.Unlock()
+
+	job.checking = false
+	if job.recheck {
+		job.recheck = false
+		job.nextCheck = time.Now()
+		s.wakeReconciler()
+		return
+	}
+	job.nextCheck = time.Now().Add(s.checkInterval(time.Since(job.lastChange)))
+}
+
+func (s *WorkerService) checkInterval(sinceChange time.Duration) time.Duration {
+	return min(max(sinceChange/10, s.reconciler.FastInterval), s.reconciler.SlowInterval)
+}
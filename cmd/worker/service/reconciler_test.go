@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+// listingProvider reads statuses in batches but never returns the jobs in
+// missing, which are left to GetJobStatus.
+type listingProvider struct {
+	fakeProvider
+	mu      sync.Mutex
+	lists   [][]string
+	missing string
+}
+
+func (p *listingProvider) GetJobStatuses(ctx context.Context, paths []string) (map[string]batch.JobStatus, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lists = append(p.lists, paths)
+	statuses := make(map[string]batch.JobStatus)
+	for _, path := range paths {
+		if path != p.missing {
+			statuses[path] = batch.JobStatusRunning
+		}
+	}
+	return statuses, nil
+}
+
+func TestReconcilerBatchesStatusReads(t *testing.T) {
+	ctx := context.Background()
+	provider := &listingProvider{missing: "jobs/job-2"}
+	job := &database.Job{JobId: "job-0", Status: database.JobStatusScheduled, ImageUri: "img"}
+	s, store := newRetryTestService(t, provider, job)
+	s.ConfigureReconciler(ReconcilerConfig{FastInterval: time.Hour, SlowInterval: time.Hour, Concurrency: 2})
+
+	for i := range 3 {
+		jobID := fmt.Sprintf("job-%d", i)
+		if i > 0 {
+			if err := store.InsertJobFull(ctx, &database.Job{TenantId: "tenant-1", JobId: jobID, Status: database.JobStatusScheduled, ImageUri: "img"}); err != nil {
+				t.Fatalf("InsertJobFull: %v", err)
+			}
+		}
+		s.trackJob("tenant-1", jobID, "jobs/"+jobID, database.JobStatusScheduled, database.ServiceTierComplex, router.AssignedServiceUnspecified, time.Now())
+	}
+
+	s.trackedMutex.Lock()
+	for _, job := range s.tracked {
+		job.nextCheck = time.Time{}
+	}
+	s.trackedMutex.Unlock()
+	s.reconcileDueJobs(ctx)
+
+	for i := range 3 {
+		waitForStatus(t, store, fmt.Sprintf("job-%d", i), database.JobStatusRunning)
+	}
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	if len(provider.lists) != 1 || len(provider.lists[0]) != 3 {
+		t.Fatalf("status lists = %v, want one request for all three jobs", provider.lists)
+	}
+
+	s.trackedMutex.Lock()
+	defer s.trackedMutex.Unlock()
+	for key, job := range s.tracked {
+		if job.checking || time.Until(job.nextCheck) < 30*time.Minute {
+			t.Errorf("job %s: checking=%v, next check in %s; want rescheduled an hour out", key, job.checking, time.Until(job.nextCheck))
+		}
+	}
+}
+
+func TestReconcilerCheckInterval(t *testing.T) {
+	s := &WorkerService{reconciler: ReconcilerConfig{FastInterval: 5 * time.Second, SlowInterval: time.Minute}}
+	cases := map[time.Duration]time.Duration{
+		0:                5 * time.Second,
+		2 * time.Minute:  12 * time.Second,
+		time.Hour:        time.Minute,
+		24 * time.Hour:   time.Minute,
+		10 * time.Second: 5 * time.Second,
+	}
+	for since, want := range cases {
+		if got := s.checkInterval(since); got != want {
+			t.Errorf("checkInterval(%s) = %s, want %s", since, got, want)
+		}
+	}
+}
+
+func TestParseRateLimits(t *testing.T) {
+	limits, err := parseRateLimits(" cloud_batch=5, CLOUD_RUN_JOB=0.5 ,")
+	if err != nil {
+		t.Fatalf("parseRateLimits: %v", err)
+	}
+	if len(limits) != 2 || limits["CLOUD_BATCH"] != 5 || limits["CLOUD_RUN_JOB"] != 0.5 {
+		t.Fatalf("limits = %v, want CLOUD_BATCH=5 CLOUD_RUN_JOB=0.5", limits)
+	}
+	for _, raw := range []string{"CLOUD_BATCH", "CLOUD_BATCH=0", "CLOUD_BATCH=fast"} {
+		if _, err := parseRateLimits(raw); err == nil {
+			t.Errorf("parseRateLimits(%q): expected an error", raw)
+		}
+	}
+	if limits, err := parseRateLimits(""); err != nil || len(limits) != 0 {
+		t.Errorf("parseRateLimits(\"\") = %v, %v; want no limits", limits, err)
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+// StartHeartbeat registers the worker in the worker registry at address and
+// renews the entry every interval until ctx is done, when the worker leaves
+// the registry so the gateway stops routing to it right away. capacity is the
+// number of active jobs the worker takes on; 0 is unlimited.
+func (s *WorkerService) StartHeartbeat(ctx context.Context, address string, capacity int64, interval time.Duration) {
+	worker := &database.Worker{
+		WorkerId:  s.workerID,
+		Address:   address,
+		Capacity:  capacity,
+		StartedAt: time.Now(),
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			// A draining worker has left the registry.
+			if !s.isDraining() {
+				worker.ActiveJobs = int64(s.trackedJobCount())
+				if err := s.dbClient.UpsertWorker(ctx, worker); err != nil && ctx.Err() == nil {
+					log.Printf("Worker heartbeat failed: %v", err)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				if err := s.dbClient.DeleteWorker(context.Background(), s.workerID); err != nil {
+					log.Printf("Failed to leave the worker registry: %v", err)
+				} else {
+					log.Println("Left the worker registry")
+				}
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// trackedJobCount returns the number of active jobs the worker tracks.
+func (s *WorkerService) trackedJobCount() int {
+	s.trackedMutex.Lock()
+	defer s.trackedMutex.Unlock()
+	return len(s.tracked)
+}
@@ -0,0 +1,393 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/navigator"
+	"github.com/alphauslabs/jennah/internal/notifier"
+)
+
+const (
+	// defaultMaxRetries applies when SubmitJobRequest.max_retries is unset.
+	defaultMaxRetries int64 = 3
+
+	// defaultRetryBaseDelay is the wait before the first retry; each further
+	// retry doubles it, up to defaultRetryMaxDelay.
+	defaultRetryBaseDelay = 30 * time.Second
+	defaultRetryMaxDelay  = 10 * time.Minute
+)
+
+// normalizeRetryPolicy maps the API spelling ("on_failure", "on_preemption",
+// "never") to the database constant. Empty means the default policy.
+func normalizeRetryPolicy(policy string) (string, error) {
+	switch strings.ToUpper(strings.TrimSpace(policy)) {
+	case "", database.RetryPolicyOnFailure:
+		return database.RetryPolicyOnFailure, nil
+	case database.RetryPolicyOnPreemption:
+		return database.RetryPolicyOnPreemption, nil
+	case database.RetryPolicyNever:
+		return database.RetryPolicyNever, nil
+	default:
+		return "", fmt.Errorf("invalid retry_policy %q: must be on_failure, on_preemption or never", policy)
+	}
+}
+
+// shouldRetry decides whether a failed attempt is resubmitted. The returned
+// string explains the decision for logs and the audit trail.
+func shouldRetry(job *database.Job, failure *batch.FailureDetail) (bool, string) {
+	policy := database.RetryPolicyOnFailure
+	if job.RetryPolicy != nil && *job.RetryPolicy != "" {
+		policy = *job.RetryPolicy
+	}
+
+	switch {
+	case policy == database.RetryPolicyNever:
+		return false, "retry policy is NEVER"
+	case job.RetryCount >= job.MaxRetries:
+		return false, fmt.Sprintf("retries exhausted (%d/%d)", job.RetryCount, job.MaxRetries)
+	case policy == database.RetryPolicyOnPreemption && failure.Cause != batch.FailureCausePreempted:
+		return false, "retry policy is ON_PREEMPTION and the failure was not a preemption"
+	}
+	return true, fmt.Sprintf("retry %d/%d", job.RetryCount+1, job.MaxRetries)
+}
+
+// retryBackoff returns the wait before retry number `attempt` (1-based).
+func (s *WorkerService) retryBackoff(attempt int64) time.Duration {
+	delay := s.retryBaseDelay
+	for i := int64(1); i < attempt && delay < s.retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.retryMaxDelay {
+		delay = s.retryMaxDelay
+	}
+	return delay
+}
+
+// retryDelayLeft returns how much of a RETRYING job's backoff is left. The
+// backoff runs from the job's transition into RETRYING; UpdatedAt cannot be
+// used because every lease renewal bumps it. Jobs whose transition cannot be
+// read fall back to UpdatedAt.
+func (s *WorkerService) retryDelayLeft(ctx context.Context, job *database.Job) time.Duration {
+	since := job.UpdatedAt
+	transitions, err := s.dbClient.GetJobTransitions(ctx, job.TenantId, job.JobId)
+	if err != nil {
+		log.Printf("Warning: could not read transitions of job %s: %v", job.JobId, err)
+	}
+	// Transitions are newest first.
+	for _, t := range transitions {
+		if t.ToStatus == database.JobStatusRetrying {
+			since = t.TransitionedAt
+			break
+		}
+	}
+	return max(s.retryBackoff(job.RetryCount)-time.Since(since), 0)
+}
+
+// describeFailure asks the provider why the job failed. Providers that cannot
+// tell are reported as FailureCauseUnknown.
+func describeFailure(ctx context.Context, provider batch.Provider, cloudResourcePath string) *batch.FailureDetail {
+	inspector, ok := provider.(batch.FailureInspector)
+	if !ok || cloudResourcePath == "" {
+		return &batch.FailureDetail{Cause: batch.FailureCauseUnknown}
+	}
+	detail, err := inspector.GetJobFailure(ctx, cloudResourcePath)
+	if err != nil || detail == nil {
+		if err != nil {
+			log.Printf("Warning: could not inspect failure of %s: %v", cloudResourcePath, err)
+		}
+		return &batch.FailureDetail{Cause: batch.FailureCauseUnknown}
+	}
+	return detail
+}
+
+// retryFailedAttempt moves a job whose latest attempt failed to RETRYING and
+// schedules its resubmission. It returns false, leaving the job untouched,
+// when the job's retry policy or budget does not allow another attempt.
+func (s *WorkerService) retryFailedAttempt(ctx context.Context, job *database.Job, fromStatus string, failure *batch.FailureDetail) bool {
+	retry, why := shouldRetry(job, failure)
+	if !retry {
+		log.Printf("Not retrying job %s: %s", job.JobId, why)
+		return false
+	}
+
+	attempt := job.RetryCount + 1
+	delay := s.retryBackoff(attempt)
+	message := failure.Message
+	if message == "" {
+		message = "job failed"
+	}
+
+	transitionID := uuid.New().String()
+	reason := fmt.Sprintf("Attempt failed (cause: %s); %s in %s", failure.Cause, why, delay)
+	event := notifier.BuildStatusEvent(transitionID, job.TenantId, job.JobId, database.JobStatusRetrying, fromStatus)
+	event.ErrorMessage = message
+	event.RetryCount = attempt
+	event.CloudResourcePath = ptrToString(job.GcpBatchJobPath)
+	err := s.changeJobStatus(ctx, &database.JobStatusChange{
+		TenantId:       job.TenantId,
+		JobId:          job.JobId,
+		TransitionId:   transitionID,
+		FromStatus:     &fromStatus,
+		ToStatus:       database.JobStatusRetrying,
+		ErrorMessage:   &message,
+		RetryCount:     &attempt,
+		Reason:         &reason,
+		WorkerId:       &s.workerID,
+		ProviderDetail: ptrStringOrNil(failure.Message),
+	}, event)
+	if err != nil {
+		log.Printf("Error marking job %s for retry: %v", job.JobId, err)
+		return false
+	}
+
+	log.Printf("Job %s failed (cause: %s); %s in %s", job.JobId, failure.Cause, why, delay)
+	s.scheduleRetry(job.TenantId, job.JobId, delay)
+	return true
+}
+
+// retryJobAfterFailure is called by the reconciler when it observes FAILED.
+// It returns true when the job was moved to RETRYING and should no longer be
+// tracked, without publishing a terminal event.
+func (s *WorkerService) retryJobAfterFailure(ctx context.Context, tracked *trackedJob, fromStatus string) bool {
+	job, err := s.dbClient.GetJob(ctx, tracked.tenantID, tracked.jobID)
+	if err != nil {
+		log.Printf("Error loading job %s to evaluate retry: %v", tracked.jobID, err)
+		return false
+	}
+	failure := describeFailure(ctx, tracked.provider, tracked.cloudResourcePath)
+	return s.retryFailedAttempt(ctx, job, fromStatus, failure)
+}
+
+// scheduleRetry resubmits the job after delay. Only one retry timer exists
+// per job on this worker.
+func (s *WorkerService) scheduleRetry(tenantID, jobID string, delay time.Duration) {
+	key := fmt.Sprintf("%s/%s", tenantID, jobID)
+
+	// As in trackJob, a draining worker hands the retry to a peer instead.
+	s.drainMutex.Lock()
+	if s.draining {
+		s.drainMutex.Unlock()
+		s.releaseDrainedJob(tenantID, jobID)
+		return
+	}
+	defer s.drainMutex.Unlock()
+
+	s.retryMutex.Lock()
+	defer s.retryMutex.Unlock()
+	if s.retryTimers == nil {
+		s.retryTimers = make(map[string]*time.Timer)
+	}
+	if _, exists := s.retryTimers[key]; exists {
+		return
+	}
+	s.retryTimers[key] = time.AfterFunc(delay, func() {
+		s.retryMutex.Lock()
+		delete(s.retryTimers, key)
+		s.retryMutex.Unlock()
+
+		s.resubmitJob(context.Background(), tenantID, jobID)
+	})
+}
+
+// cancelRetry stops a pending retry timer for the job, if any.
+func (s *WorkerService) cancelRetry(tenantID, jobID string) {
+	key := fmt.Sprintf("%s/%s", tenantID, jobID)
+
+	s.retryMutex.Lock()
+	defer s.retryMutex.Unlock()
+	if timer, exists := s.retryTimers[key]; exists {
+		log.Printf("Cancelling scheduled retry for job %s", jobID)
+		timer.Stop()
+		delete(s.retryTimers, key)
+	}
+}
+
+// stopAllRetries stops every pending retry timer. The jobs stay RETRYING and
+// are picked up again by whichever worker claims their lease next.
+func (s *WorkerService) stopAllRetries() {
+	s.retryMutex.Lock()
+	defer s.retryMutex.Unlock()
+
+	for _, timer := range s.retryTimers {
+		timer.Stop()
+	}
+	s.retryTimers = make(map[string]*time.Timer)
+}
+
+// resubmitJob submits a new attempt of a RETRYING job to its provider and
+// resumes polling. The job is skipped if it left RETRYING (e.g. was
+// cancelled) or another worker now owns its lease.
+func (s *WorkerService) resubmitJob(ctx context.Context, tenantID, jobID string) {
+	owned, err := s.dbClient.TryClaimOrRenewJobLease(ctx, tenantID, jobID, s.workerID, time.Now().UTC().Add(s.leaseTTL))
+	if err != nil {
+		log.Printf("Error claiming lease to retry job %s: %v", jobID, err)
+		return
+	}
+	if !owned {
+		log.Printf("Job %s is owned by another worker; skipping retry", jobID)
+		return
+	}
+
+	job, err := s.dbClient.GetJob(ctx, tenantID, jobID)
+	if err != nil {
+		log.Printf("Error loading job %s for retry: %v", jobID, err)
+		return
+	}
+	if job.Status != database.JobStatusRetrying {
+		log.Printf("Job %s is %s, no longer retrying", jobID, job.Status)
+		return
+	}
+
+	log.Printf("Resubmitting job %s (retry %d/%d)", jobID, job.RetryCount, job.MaxRetries)
+
+	plan, err := s.planRetry(job)
+	if err == nil {
+		var jobResult *batch.JobResult
+		jobResult, err = s.dispatchPlan(ctx, plan)
+		if err == nil {
+			s.resumeRetriedJob(ctx, job, plan, jobResult)
+			return
+		}
+	}
+
+	log.Printf("Error resubmitting job %s: %v", jobID, err)
+	failure := &batch.FailureDetail{Cause: batch.FailureCauseUnknown, Message: err.Error()}
+	if s.retryFailedAttempt(ctx, job, database.JobStatusRetrying, failure) {
+		return
+	}
+	s.failRetryingJob(ctx, job, err.Error())
+}
+
+// planRetry rebuilds the job's submission from its stored row and runs it
+// through the navigator again.
+func (s *WorkerService) planRetry(job *database.Job) (*navigator.NavigationPlan, error) {
+	req, err := submitRequestFromJob(job)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := navigator.Navigate(req, job.JobId, s.jobConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build execution plan: %w", err)
+	}
+
+	// Provider job IDs must be unique, so each attempt gets its own suffix.
+	providerJobID := generateProviderJobID(req.Name, job.JobId)
+	suffix := fmt.Sprintf("-r%d", job.RetryCount)
+	if len(providerJobID)+len(suffix) > 64 {
+		providerJobID = providerJobID[:64-len(suffix)]
+	}
+	plan.Config.JobID = providerJobID + suffix
+	plan.Config.RequestID = uuid.New().String()
+	plan.Config.TenantID = job.TenantId
+	return plan, nil
+}
+
+// submitRequestFromJob rebuilds the SubmitJobRequest a job row was created
+// from.
+func submitRequestFromJob(job *database.Job) (*jennahv1.SubmitJobRequest, error) {
+	req := &jennahv1.SubmitJobRequest{
+		JobId:           job.JobId,
+		ImageUri:        job.ImageUri,
+		Commands:        job.Commands,
+		Name:            ptrToString(job.Name),
+		ResourceProfile: ptrToString(job.ResourceProfile),
+		MachineType:     ptrToString(job.MachineType),
+		ServiceAccount:  ptrToString(job.ServiceAccount),
+	}
+	if job.EnvVarsJson != nil {
+		if err := json.Unmarshal([]byte(*job.EnvVarsJson), &req.EnvVars); err != nil {
+			return nil, fmt.Errorf("failed to parse stored env vars: %w", err)
+		}
+	}
+	labels, err := database.JobLabels(job)
+	if err != nil {
+		return nil, err
+	}
+	req.Labels = labels
+	if job.BootDiskSizeGb != nil {
+		req.BootDiskSizeGb = *job.BootDiskSizeGb
+	}
+	if job.UseSpotVms != nil {
+		req.UseSpotVms = *job.UseSpotVms
+	}
+	if job.MemoryMib != nil || job.CpuMillis != nil || job.MaxRunDurationSeconds != nil {
+		req.ResourceOverride = &jennahv1.ResourceOverride{}
+		if job.MemoryMib != nil {
+			req.ResourceOverride.MemoryMib = *job.MemoryMib
+		}
+		if job.CpuMillis != nil {
+			req.ResourceOverride.CpuMillis = *job.CpuMillis
+		}
+		if job.MaxRunDurationSeconds != nil {
+			req.ResourceOverride.MaxRunDurationSeconds = *job.MaxRunDurationSeconds
+		}
+	}
+	return req, nil
+}
+
+// dispatchPlan submits the plan through the dispatcher, or the single
+// batchProvider when no dispatcher is configured.
+func (s *WorkerService) dispatchPlan(ctx context.Context, plan *navigator.NavigationPlan) (*batch.JobResult, error) {
+	if s.dispatcher != nil {
+		return s.dispatcher.SubmitJob(ctx, plan.AssignedService, plan.Config)
+	}
+	return s.batchProvider.SubmitJob(ctx, plan.Config)
+}
+
+// resumeRetriedJob records the new attempt's cloud resource and starts
+// polling it.
+func (s *WorkerService) resumeRetriedJob(ctx context.Context, job *database.Job, plan *navigator.NavigationPlan, jobResult *batch.JobResult) {
+	statusToSet := string(jobResult.InitialStatus)
+	if statusToSet == "" || statusToSet == string(batch.JobStatusUnknown) {
+		statusToSet = database.JobStatusRunning
+	}
+
+	reason := fmt.Sprintf("Resubmitted as retry %d/%d: %s", job.RetryCount, job.MaxRetries, jobResult.CloudResourcePath)
+	err := s.recordSubmission(ctx, job.TenantId, job.JobId, database.JobStatusRetrying, statusToSet, reason, plan, jobResult.CloudResourcePath, job.RetryCount)
+	if err != nil {
+		log.Printf("Error updating retried job %s: %v", job.JobId, err)
+		return
+	}
+
+	log.Printf("Job %s resubmitted: %s", job.JobId, jobResult.CloudResourcePath)
+	s.trackJob(job.TenantId, job.JobId, jobResult.CloudResourcePath, statusToSet, serviceTierFromPlan(plan), plan.AssignedService, time.Now())
+}
+
+// failRetryingJob gives up on a RETRYING job and queues its terminal event.
+func (s *WorkerService) failRetryingJob(ctx context.Context, job *database.Job, errorMessage string) {
+	transitionID := uuid.New().String()
+	fromStatus := database.JobStatusRetrying
+	reason := "Retry could not be submitted"
+	event := notifier.BuildEvent(transitionID, job.TenantId, job.JobId, database.JobStatusFailed, database.JobStatusRetrying)
+	event.ErrorMessage = errorMessage
+	event.CloudResourcePath = ptrToString(job.GcpBatchJobPath)
+	event.ServiceTier = ptrToString(job.ServiceTier)
+	event.AssignedService = ptrToString(job.AssignedService)
+	err := s.changeJobStatus(ctx, &database.JobStatusChange{
+		TenantId:       job.TenantId,
+		JobId:          job.JobId,
+		TransitionId:   transitionID,
+		FromStatus:     &fromStatus,
+		ToStatus:       database.JobStatusFailed,
+		ErrorMessage:   &errorMessage,
+		Reason:         &reason,
+		WorkerId:       &s.workerID,
+		ProviderDetail: &errorMessage,
+	}, event)
+	if err != nil {
+		log.Printf("Error updating job status to FAILED: %v", err)
+		return
+	}
+	s.jobFinished(ctx, job.TenantId, job.JobId)
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/notifier"
+)
+
+// fakeProvider records submissions and reports a fixed failure cause.
+type fakeProvider struct {
+	mu        sync.Mutex
+	submitted []batch.JobConfig
+	submitErr error
+	failure   *batch.FailureDetail
+}
+
+func (p *fakeProvider) SubmitJob(ctx context.Context, config batch.JobConfig) (*batch.JobResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.submitErr != nil {
+		return nil, p.submitErr
+	}
+	p.submitted = append(p.submitted, config)
+	return &batch.JobResult{CloudResourcePath: "jobs/" + config.JobID, InitialStatus: batch.JobStatusScheduled}, nil
+}
+
+func (p *fakeProvider) GetJobStatus(ctx context.Context, path string) (batch.JobStatus, error) {
+	return batch.JobStatusRunning, nil
+}
+
+func (p *fakeProvider) CancelJob(ctx context.Context, path string) error { return nil }
+func (p *fakeProvider) DeleteJob(ctx context.Context, path string) error { return nil }
+func (p *fakeProvider) ListJobs(ctx context.Context) ([]string, error)   { return nil, nil }
+func (p *fakeProvider) ServiceType() string                              { return "CLOUD_BATCH" }
+
+func (p *fakeProvider) GetJobFailure(ctx context.Context, path string) (*batch.FailureDetail, error) {
+	return p.failure, nil
+}
+
+func (p *fakeProvider) submissions() []batch.JobConfig {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]batch.JobConfig(nil), p.submitted...)
+}
+
+func newRetryTestService(t *testing.T, provider batch.Provider, job *database.Job) (*WorkerService, *database.MemoryStore) {
+	t.Helper()
+	ctx := context.Background()
+	store := database.NewMemoryStore()
+	if err := store.InsertTenant(ctx, "tenant-1", "a@example.com", "google", "u1"); err != nil {
+		t.Fatalf("InsertTenant: %v", err)
+	}
+	job.TenantId = "tenant-1"
+	if err := store.InsertJobFull(ctx, job); err != nil {
+		t.Fatalf("InsertJobFull: %v", err)
+	}
+
+	s := NewWorkerService(store, provider, nil, nil, nil, "worker-1", time.Minute, time.Minute, &notifier.NoopNotifier{}, nil)
+	s.retryBaseDelay = time.Millisecond
+	s.retryMaxDelay = 4 * time.Millisecond
+	t.Cleanup(s.StopReconciler)
+	return s, store
+}
+
+func waitForStatus(t *testing.T, store database.Store, jobID, want string) *database.Job {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		job, err := store.GetJob(context.Background(), "tenant-1", jobID)
+		if err != nil {
+			t.Fatalf("GetJob: %v", err)
+		}
+		if job.Status == want {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job %s status = %s, want %s", jobID, job.Status, want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestNormalizeRetryPolicy(t *testing.T) {
+	cases := map[string]string{
+		"":              database.RetryPolicyOnFailure,
+		"on_failure":    database.RetryPolicyOnFailure,
+		"On_Preemption": database.RetryPolicyOnPreemption,
+		" never ":       database.RetryPolicyNever,
+	}
+	for in, want := range cases {
+		got, err := normalizeRetryPolicy(in)
+		if err != nil || got != want {
+			t.Errorf("normalizeRetryPolicy(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := normalizeRetryPolicy("always"); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	preempted := &batch.FailureDetail{Cause: batch.FailureCausePreempted}
+	crashed := &batch.FailureDetail{Cause: batch.FailureCauseUnknown}
+
+	cases := []struct {
+		name    string
+		job     database.Job
+		failure *batch.FailureDetail
+		want    bool
+	}{
+		{"default policy", database.Job{MaxRetries: 3}, crashed, true},
+		{"budget exhausted", database.Job{RetryCount: 3, MaxRetries: 3}, crashed, false},
+		{"retries disabled", database.Job{MaxRetries: 0}, crashed, false},
+		{"never", database.Job{MaxRetries: 3, RetryPolicy: strPtr(database.RetryPolicyNever)}, preempted, false},
+		{"preemption only, preempted", database.Job{MaxRetries: 3, RetryPolicy: strPtr(database.RetryPolicyOnPreemption)}, preempted, true},
+		{"preemption only, crashed", database.Job{MaxRetries: 3, RetryPolicy: strPtr(database.RetryPolicyOnPreemption)}, crashed, false},
+	}
+	for _, tc := range cases {
+		if got, why := shouldRetry(&tc.job, tc.failure); got != tc.want {
+			t.Errorf("%s: shouldRetry = %v (%s), want %v", tc.name, got, why, tc.want)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	s := &WorkerService{retryBaseDelay: 30 * time.Second, retryMaxDelay: 10 * time.Minute}
+	want := []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 10 * time.Minute, 10 * time.Minute}
+	for i, w := range want {
+		if got := s.retryBackoff(int64(i + 1)); got != w {
+			t.Errorf("retryBackoff(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+}
+
+func TestDescribeFailure(t *testing.T) {
+	p := &fakeProvider{failure: &batch.FailureDetail{Cause: batch.FailureCausePreempted, Message: "spot reclaimed"}}
+	if got := describeFailure(context.Background(), p, "jobs/a"); got.Cause != batch.FailureCausePreempted {
+		t.Errorf("cause = %s, want PREEMPTED", got.Cause)
+	}
+	p.failure = nil
+	if got := describeFailure(context.Background(), p, "jobs/a"); got.Cause != batch.FailureCauseUnknown {
+		t.Errorf("cause = %s, want UNKNOWN when the provider has no detail", got.Cause)
+	}
+}
+
+func TestRetryFailedAttemptResubmits(t *testing.T) {
+	ctx := context.Background()
+	provider := &fakeProvider{}
+	s, store := newRetryTestService(t, provider, &database.Job{
+		JobId:           "0b7a6c2e-1111-2222-3333-444455556666",
+		Status:          database.JobStatusRunning,
+		ImageUri:        "img",
+		Commands:        []string{"run"},
+		MaxRetries:      2,
+		GcpBatchJobPath: strPtr("jobs/first"),
+		EnvVarsJson:     strPtr(`{"A":"1"}`),
+	})
+
+	job, _ := store.GetJob(ctx, "tenant-1", "0b7a6c2e-1111-2222-3333-444455556666")
+	failure := &batch.FailureDetail{Cause: batch.FailureCausePreempted, Message: "spot reclaimed"}
+	if !s.retryFailedAttempt(ctx, job, database.JobStatusRunning, failure) {
+		t.Fatal("expected the failed attempt to be retried")
+	}
+
+	job = waitForStatus(t, store, job.JobId, database.JobStatusScheduled)
+	if job.RetryCount != 1 || ptrToString(job.ErrorMessage) != "spot reclaimed" {
+		t.Errorf("RetryCount = %d, ErrorMessage = %q", job.RetryCount, ptrToString(job.ErrorMessage))
+	}
+
+	subs := provider.submissions()
+	if len(subs) != 1 {
+		t.Fatalf("got %d submissions, want 1", len(subs))
+	}
+	if subs[0].JobID != "jennah-0b7a6c2e-r1" || subs[0].EnvVars["A"] != "1" {
+		t.Errorf("resubmitted config = %+v", subs[0])
+	}
+	if ptrToString(job.GcpBatchJobPath) != "jobs/jennah-0b7a6c2e-r1" {
+		t.Errorf("GcpBatchJobPath = %q", ptrToString(job.GcpBatchJobPath))
+	}
+
+	transitions, err := store.GetJobTransitions(ctx, "tenant-1", job.JobId)
+	if err != nil {
+		t.Fatalf("GetJobTransitions: %v", err)
+	}
+	var path []string
+	for _, tr := range transitions {
+		path = append(path, tr.ToStatus)
+	}
+	if len(path) != 2 || path[0] != database.JobStatusScheduled || path[1] != database.JobStatusRetrying {
+		t.Fatalf("transitions = %v, want [SCHEDULED RETRYING] (newest first)", path)
+	}
+	if retrying := transitions[1]; ptrToString(retrying.WorkerId) != "worker-1" || ptrToString(retrying.ProviderDetail) != "spot reclaimed" {
+		t.Errorf("RETRYING transition worker = %q, provider detail = %q; want worker-1, spot reclaimed",
+			ptrToString(retrying.WorkerId), ptrToString(retrying.ProviderDetail))
+	}
+}
+
+func TestRetryDelayLeftIgnoresLeaseRenewals(t *testing.T) {
+	ctx := context.Background()
+	s, store := newRetryTestService(t, &fakeProvider{}, &database.Job{JobId: runningJobID, Status: database.JobStatusRunning, ImageUri: "img"})
+	s.retryBaseDelay, s.retryMaxDelay = 300*time.Millisecond, time.Second
+
+	fromStatus, attempt := database.JobStatusRunning, int64(1)
+	if err := store.ChangeJobStatus(ctx, &database.JobStatusChange{
+		TenantId: "tenant-1", JobId: runningJobID, TransitionId: "t1",
+		FromStatus: &fromStatus, ToStatus: database.JobStatusRetrying, RetryCount: &attempt,
+	}); err != nil {
+		t.Fatalf("ChangeJobStatus: %v", err)
+	}
+	time.Sleep(150 * time.Millisecond)
+
+	// Renewing the lease bumps UpdatedAt but not the backoff's start.
+	if _, err := store.TryClaimOrRenewJobLease(ctx, "tenant-1", runningJobID, "worker-2", time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("TryClaimOrRenewJobLease: %v", err)
+	}
+	job, _ := store.GetJob(ctx, "tenant-1", runningJobID)
+	if left := s.retryDelayLeft(ctx, job); left <= 0 || left > 150*time.Millisecond {
+		t.Fatalf("retry delay left = %s, want at most the 150ms remaining since RETRYING", left)
+	}
+}
+
+func TestResubmitFailureExhaustsRetries(t *testing.T) {
+	ctx := context.Background()
+	provider := &fakeProvider{submitErr: errors.New("quota exceeded")}
+	s, store := newRetryTestService(t, provider, &database.Job{
+		JobId:           "7d1e9a40-aaaa-bbbb-cccc-ddddeeeeffff",
+		Status:          database.JobStatusRunning,
+		ImageUri:        "img",
+		MaxRetries:      1,
+		GcpBatchJobPath: strPtr("jobs/first"),
+	})
+
+	job, _ := store.GetJob(ctx, "tenant-1", "7d1e9a40-aaaa-bbbb-cccc-ddddeeeeffff")
+	if !s.retryFailedAttempt(ctx, job, database.JobStatusRunning, &batch.FailureDetail{Cause: batch.FailureCauseUnknown}) {
+		t.Fatal("expected the first failure to be retried")
+	}
+
+	job = waitForStatus(t, store, "7d1e9a40-aaaa-bbbb-cccc-ddddeeeeffff", database.JobStatusFailed)
+	if ptrToString(job.ErrorMessage) != "quota exceeded" {
+		t.Errorf("ErrorMessage = %q", ptrToString(job.ErrorMessage))
+	}
+}
+
+func TestCancelledJobIsNotResubmitted(t *testing.T) {
+	ctx := context.Background()
+	provider := &fakeProvider{}
+	s, store := newRetryTestService(t, provider, &database.Job{
+		JobId:           "7d1e9a40-aaaa-bbbb-cccc-ddddeeeeffff",
+		Status:          database.JobStatusRetrying,
+		ImageUri:        "img",
+		RetryCount:      1,
+		MaxRetries:      3,
+		GcpBatchJobPath: strPtr("jobs/first"),
+	})
+	if err := store.UpdateJobStatus(ctx, "tenant-1", "7d1e9a40-aaaa-bbbb-cccc-ddddeeeeffff", database.JobStatusCancelled); err != nil {
+		t.Fatalf("UpdateJobStatus: %v", err)
+	}
+
+	s.resubmitJob(ctx, "tenant-1", "7d1e9a40-aaaa-bbbb-cccc-ddddeeeeffff")
+	if n := len(provider.submissions()); n != 0 {
+		t.Errorf("cancelled job was resubmitted %d time(s)", n)
+	}
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/cron"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/quota"
+)
+
+// scheduledJobNamespace seeds the deterministic job IDs of schedule fires.
+var scheduledJobNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/alphauslabs/jennah/schedules"))
+
+// scheduledJobID returns the job ID for one fire of a schedule. The same fire
+// always maps to the same ID, so a worker that takes over a schedule after a
+// crash finds the job its predecessor created instead of submitting another.
+func scheduledJobID(scheduleID string, fireAt time.Time) string {
+	return uuid.NewSHA1(scheduledJobNamespace, []byte(scheduleID+"/"+fireAt.UTC().Format(time.RFC3339))).String()
+}
+
+// StartScheduler runs a background loop that fires due cron schedules every
+// interval. Each schedule is claimed with a lease before it fires, so only one
+// worker materializes a given fire.
+func (s *WorkerService) StartScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("Scheduler stopped")
+				return
+			case <-ticker.C:
+				if err := s.runDueSchedules(context.Background(), time.Now().UTC()); err != nil {
+					log.Printf("Scheduler tick failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (s *WorkerService) runDueSchedules(ctx context.Context, now time.Time) error {
+	if s.isDraining() {
+		return nil
+	}
+	schedules, err := s.dbClient.ListDueSchedules(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to list due schedules: %w", err)
+	}
+
+	for _, schedule := range schedules {
+		if err := s.fireSchedule(ctx, schedule, now); err != nil {
+			log.Printf("Error firing schedule %s (tenant %s): %v", schedule.ScheduleId, schedule.TenantId, err)
+		}
+	}
+	return nil
+}
+
+// fireSchedule claims a due schedule, applies its overlap policy and submits
+// the templated job. The schedule is then advanced to its next fire after now,
+// so fires missed while no worker was running collapse into one.
+func (s *WorkerService) fireSchedule(ctx context.Context, due *database.Schedule, now time.Time) error {
+	claimed, err := s.dbClient.TryClaimOrRenewScheduleLease(ctx, due.TenantId, due.ScheduleId, s.workerID, now.Add(s.leaseTTL))
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	// Re-read under the lease: another worker may have fired it since the scan.
+	schedule, err := s.dbClient.GetSchedule(ctx, due.TenantId, due.ScheduleId)
+	if err != nil {
+		return err
+	}
+	if schedule.Paused || schedule.NextRunAt.After(now) {
+		return nil
+	}
+
+	next, err := cron.NextInZone(schedule.CronExpression, schedule.TimeZone, now)
+	if err != nil {
+		log.Printf("Pausing schedule %s: %v", schedule.ScheduleId, err)
+		return s.dbClient.SetSchedulePaused(ctx, schedule.TenantId, schedule.ScheduleId, true, schedule.NextRunAt)
+	}
+
+	if previous := s.activeScheduledJob(ctx, schedule); previous != nil {
+		switch schedule.OverlapPolicy {
+		case database.OverlapPolicyQueue:
+			// Leave NextRunAt in the past; the fire happens on the first tick
+			// after the previous job finishes.
+			return nil
+		case database.OverlapPolicyReplace:
+			reason := fmt.Sprintf("Replaced by schedule %s", schedule.ScheduleId)
+			if err := s.cancelActiveJob(ctx, previous, reason); err != nil {
+				return fmt.Errorf("failed to cancel previous job %s: %w", previous.JobId, err)
+			}
+		default:
+			log.Printf("Schedule %s: previous job %s is %s, skipping fire at %s",
+				schedule.ScheduleId, previous.JobId, previous.Status, schedule.NextRunAt.Format(time.RFC3339))
+			return s.dbClient.AdvanceSchedule(ctx, schedule.TenantId, schedule.ScheduleId, next, nil)
+		}
+	}
+
+	jobID := scheduledJobID(schedule.ScheduleId, schedule.NextRunAt)
+	if err := s.submitScheduledJob(ctx, schedule, jobID); err != nil {
+		log.Printf("Schedule %s: failed to submit job %s: %v", schedule.ScheduleId, jobID, err)
+	}
+
+	var lastJobID *string
+	if _, err := s.dbClient.GetJob(ctx, schedule.TenantId, jobID); err == nil {
+		lastJobID = &jobID
+	}
+	if err := s.dbClient.AdvanceSchedule(ctx, schedule.TenantId, schedule.ScheduleId, next, lastJobID); err != nil {
+		return err
+	}
+	log.Printf("Schedule %s fired: job=%s, next=%s", schedule.ScheduleId, jobID, next.Format(time.RFC3339))
+	return nil
+}
+
+// activeScheduledJob returns the job from the schedule's previous fire when it
+// has not reached a terminal status.
+func (s *WorkerService) activeScheduledJob(ctx context.Context, schedule *database.Schedule) *database.Job {
+	if schedule.LastJobId == nil {
+		return nil
+	}
+	job, err := s.dbClient.GetJob(ctx, schedule.TenantId, *schedule.LastJobId)
+	if err != nil {
+		if spanner.ErrCode(err) != codes.NotFound {
+			log.Printf("Schedule %s: could not read previous job %s: %v", schedule.ScheduleId, *schedule.LastJobId, err)
+		}
+		return nil
+	}
+	if isTerminalStatus(job.Status) {
+		return nil
+	}
+	return job
+}
+
+// submitScheduledJob materializes the schedule's template as a normal job
+// through SubmitJob. A job that already exists under jobID means this fire was
+// submitted before, and is left alone.
+func (s *WorkerService) submitScheduledJob(ctx context.Context, schedule *database.Schedule, jobID string) error {
+	if _, err := s.dbClient.GetJob(ctx, schedule.TenantId, jobID); err == nil {
+		return nil
+	}
+
+	template := &jennahv1.SubmitJobRequest{}
+	if err := protojson.Unmarshal([]byte(schedule.JobTemplateJson), template); err != nil {
+		return fmt.Errorf("failed to decode job template: %w", err)
+	}
+	template.JobId = jobID
+
+	req := connect.NewRequest(template)
+	req.Header().Set("X-Tenant-Id", schedule.TenantId)
+
+	// Scheduled jobs are admitted as the gateway admits submitted ones: a fire
+	// over the quota is skipped, or queued when the tenant queues.
+	if s.quotas != nil {
+		decision, msg, err := s.quotas.Admit(ctx, schedule.TenantId, template, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to check quota: %w", err)
+		}
+		switch decision {
+		case quota.Reject:
+			return fmt.Errorf("quota exceeded: %s", msg)
+		case quota.Queue:
+			req.Header().Set("X-Queue-Reason", msg)
+		}
+	}
+	if _, err := s.SubmitJob(ctx, req); err != nil {
+		// SubmitJob reports a job ID that is already taken as AlreadyExists.
+		if connect.CodeOf(err) == connect.CodeAlreadyExists {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
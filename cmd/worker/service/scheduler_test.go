@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/notifier"
+	"github.com/alphauslabs/jennah/internal/quota"
+)
+
+func newSchedulerTestService(t *testing.T, provider *fakeProvider, workerID string, store *database.MemoryStore) *WorkerService {
+	t.Helper()
+	s := NewWorkerService(store, provider, nil, nil, nil, workerID, time.Minute, time.Minute, &notifier.NoopNotifier{}, nil)
+	t.Cleanup(s.StopReconciler)
+	return s
+}
+
+func insertTestSchedule(t *testing.T, store *database.MemoryStore, policy string, nextRunAt time.Time) *database.Schedule {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := store.GetTenant(ctx, "tenant-1"); err != nil {
+		if err := store.InsertTenant(ctx, "tenant-1", "a@example.com", "google", "u1"); err != nil {
+			t.Fatalf("InsertTenant: %v", err)
+		}
+	}
+	template, err := protojson.Marshal(&jennahv1.SubmitJobRequest{ImageUri: "img", Commands: []string{"run"}})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	schedule := &database.Schedule{
+		TenantId:        "tenant-1",
+		ScheduleId:      "sched-1",
+		CronExpression:  "*/5 * * * *",
+		TimeZone:        "UTC",
+		OverlapPolicy:   policy,
+		JobTemplateJson: string(template),
+		NextRunAt:       nextRunAt,
+	}
+	if err := store.InsertSchedule(ctx, schedule); err != nil {
+		t.Fatalf("InsertSchedule: %v", err)
+	}
+	return schedule
+}
+
+func getTestSchedule(t *testing.T, store database.Store) *database.Schedule {
+	t.Helper()
+	schedule, err := store.GetSchedule(context.Background(), "tenant-1", "sched-1")
+	if err != nil {
+		t.Fatalf("GetSchedule: %v", err)
+	}
+	return schedule
+}
+
+func TestScheduledJobIDIsDeterministic(t *testing.T) {
+	fire := time.Date(2026, 3, 14, 10, 5, 0, 0, time.UTC)
+	a := scheduledJobID("sched-1", fire)
+	if a != scheduledJobID("sched-1", fire.In(time.FixedZone("x", 3600))) {
+		t.Error("the same fire should map to the same job ID")
+	}
+	if a == scheduledJobID("sched-1", fire.Add(5*time.Minute)) || a == scheduledJobID("sched-2", fire) {
+		t.Error("different fires should map to different job IDs")
+	}
+}
+
+func TestSchedulerFiresDueScheduleOnce(t *testing.T) {
+	ctx := context.Background()
+	store := database.NewMemoryStore()
+	now := time.Date(2026, 3, 14, 10, 7, 0, 0, time.UTC)
+	fireAt := time.Date(2026, 3, 14, 10, 5, 0, 0, time.UTC)
+	insertTestSchedule(t, store, database.OverlapPolicySkip, fireAt)
+
+	provider := &fakeProvider{}
+	a := newSchedulerTestService(t, provider, "worker-a", store)
+	b := newSchedulerTestService(t, provider, "worker-b", store)
+
+	if err := a.runDueSchedules(ctx, now); err != nil {
+		t.Fatalf("runDueSchedules: %v", err)
+	}
+	if err := b.runDueSchedules(ctx, now); err != nil {
+		t.Fatalf("runDueSchedules: %v", err)
+	}
+
+	if n := len(provider.submissions()); n != 1 {
+		t.Fatalf("got %d submissions across two workers, want 1", n)
+	}
+	jobID := scheduledJobID("sched-1", fireAt)
+	job, err := store.GetJob(ctx, "tenant-1", jobID)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if job.ImageUri != "img" || len(job.Commands) != 1 {
+		t.Errorf("job was not built from the template: %+v", job)
+	}
+
+	schedule := getTestSchedule(t, store)
+	if want := time.Date(2026, 3, 14, 10, 10, 0, 0, time.UTC); !schedule.NextRunAt.Equal(want) {
+		t.Errorf("NextRunAt = %s, want %s", schedule.NextRunAt, want)
+	}
+	if ptrToString(schedule.LastJobId) != jobID || schedule.LastRunAt == nil {
+		t.Errorf("LastJobId = %q, LastRunAt = %v", ptrToString(schedule.LastJobId), schedule.LastRunAt)
+	}
+}
+
+func TestSchedulerResumesInterruptedFire(t *testing.T) {
+	ctx := context.Background()
+	store := database.NewMemoryStore()
+	fireAt := time.Date(2026, 3, 14, 10, 5, 0, 0, time.UTC)
+	insertTestSchedule(t, store, database.OverlapPolicySkip, fireAt)
+
+	// A previous owner submitted the job but died before advancing the schedule.
+	jobID := scheduledJobID("sched-1", fireAt)
+	if err := store.InsertJob(ctx, "tenant-1", jobID, "img", nil); err != nil {
+		t.Fatalf("InsertJob: %v", err)
+	}
+
+	provider := &fakeProvider{}
+	s := newSchedulerTestService(t, provider, "worker-a", store)
+	if err := s.runDueSchedules(ctx, fireAt.Add(time.Minute)); err != nil {
+		t.Fatalf("runDueSchedules: %v", err)
+	}
+
+	if n := len(provider.submissions()); n != 0 {
+		t.Errorf("fire was submitted again (%d submissions)", n)
+	}
+	if schedule := getTestSchedule(t, store); ptrToString(schedule.LastJobId) != jobID {
+		t.Errorf("LastJobId = %q, want %q", ptrToString(schedule.LastJobId), jobID)
+	}
+}
+
+// staleJobStore misses every job in GetJob, as a read racing another worker's
+// insert of the same job does.
+type staleJobStore struct{ *database.MemoryStore }
+
+func (staleJobStore) GetJob(ctx context.Context, tenantID, jobID string) (*database.Job, error) {
+	return nil, errors.New("job not found")
+}
+
+func TestSchedulerToleratesConcurrentFire(t *testing.T) {
+	ctx := context.Background()
+	store := database.NewMemoryStore()
+	fireAt := time.Date(2026, 3, 14, 10, 5, 0, 0, time.UTC)
+	schedule := insertTestSchedule(t, store, database.OverlapPolicySkip, fireAt)
+
+	// Another worker inserted the fire's job after this one checked for it.
+	jobID := scheduledJobID("sched-1", fireAt)
+	if err := store.InsertJob(ctx, "tenant-1", jobID, "img", nil); err != nil {
+		t.Fatalf("InsertJob: %v", err)
+	}
+
+	provider := &fakeProvider{}
+	s := NewWorkerService(staleJobStore{store}, provider, nil, nil, nil, "worker-a", time.Minute, time.Minute, &notifier.NoopNotifier{}, nil)
+	t.Cleanup(s.StopReconciler)
+	if err := s.submitScheduledJob(ctx, schedule, jobID); err != nil {
+		t.Fatalf("submitScheduledJob of an existing job: %v", err)
+	}
+	if n := len(provider.submissions()); n != 0 {
+		t.Errorf("fire was submitted again (%d submissions)", n)
+	}
+}
+
+func TestSchedulerOverlapPolicies(t *testing.T) {
+	cases := []struct {
+		policy        string
+		wantSubmitted int
+		wantAdvanced  bool
+		wantPrevious  string
+	}{
+		{database.OverlapPolicySkip, 0, true, database.JobStatusRunning},
+		{database.OverlapPolicyQueue, 0, false, database.JobStatusRunning},
+		{database.OverlapPolicyReplace, 1, true, database.JobStatusCancelled},
+	}
+	for _, tc := range cases {
+		t.Run(tc.policy, func(t *testing.T) {
+			ctx := context.Background()
+			store := database.NewMemoryStore()
+			fireAt := time.Date(2026, 3, 14, 10, 5, 0, 0, time.UTC)
+			insertTestSchedule(t, store, tc.policy, fireAt)
+
+			previous := "0b7a6c2e-1111-2222-3333-444455556666"
+			if err := store.InsertJobFull(ctx, &database.Job{
+				TenantId:        "tenant-1",
+				JobId:           previous,
+				Status:          database.JobStatusRunning,
+				ImageUri:        "img",
+				GcpBatchJobPath: strPtr("jobs/previous"),
+			}); err != nil {
+				t.Fatalf("InsertJobFull: %v", err)
+			}
+			if err := store.AdvanceSchedule(ctx, "tenant-1", "sched-1", fireAt, &previous); err != nil {
+				t.Fatalf("AdvanceSchedule: %v", err)
+			}
+
+			provider := &fakeProvider{}
+			s := newSchedulerTestService(t, provider, "worker-a", store)
+			if err := s.runDueSchedules(ctx, fireAt.Add(time.Minute)); err != nil {
+				t.Fatalf("runDueSchedules: %v", err)
+			}
+
+			if n := len(provider.submissions()); n != tc.wantSubmitted {
+				t.Errorf("submissions = %d, want %d", n, tc.wantSubmitted)
+			}
+			if advanced := getTestSchedule(t, store).NextRunAt.After(fireAt); advanced != tc.wantAdvanced {
+				t.Errorf("schedule advanced = %v, want %v", advanced, tc.wantAdvanced)
+			}
+			job, _ := store.GetJob(ctx, "tenant-1", previous)
+			if job.Status != tc.wantPrevious {
+				t.Errorf("previous job status = %s, want %s", job.Status, tc.wantPrevious)
+			}
+		})
+	}
+}
+
+func TestSchedulerIgnoresPausedSchedule(t *testing.T) {
+	ctx := context.Background()
+	store := database.NewMemoryStore()
+	fireAt := time.Date(2026, 3, 14, 10, 5, 0, 0, time.UTC)
+	insertTestSchedule(t, store, database.OverlapPolicySkip, fireAt)
+	if err := store.SetSchedulePaused(ctx, "tenant-1", "sched-1", true, fireAt); err != nil {
+		t.Fatalf("SetSchedulePaused: %v", err)
+	}
+
+	provider := &fakeProvider{}
+	s := newSchedulerTestService(t, provider, "worker-a", store)
+	if err := s.runDueSchedules(ctx, fireAt.Add(time.Hour)); err != nil {
+		t.Fatalf("runDueSchedules: %v", err)
+	}
+	if n := len(provider.submissions()); n != 0 {
+		t.Errorf("paused schedule fired %d time(s)", n)
+	}
+}
+
+func TestSchedulerAppliesQuota(t *testing.T) {
+	ctx := context.Background()
+	store := database.NewMemoryStore()
+	now := time.Date(2026, 3, 14, 10, 7, 0, 0, time.UTC)
+	fireAt := time.Date(2026, 3, 14, 10, 5, 0, 0, time.UTC)
+	insertTestSchedule(t, store, database.OverlapPolicySkip, fireAt)
+	if err := store.InsertJobFull(ctx, &database.Job{TenantId: "tenant-1", JobId: "running", Status: database.JobStatusRunning, ImageUri: "img"}); err != nil {
+		t.Fatalf("InsertJobFull: %v", err)
+	}
+
+	provider := &fakeProvider{}
+	s := newSchedulerTestService(t, provider, "worker-a", store)
+	s.quotas = quota.NewChecker(store, quota.Limits{MaxConcurrentJobs: 1}, nil)
+
+	// Over the limit without queueing, the fire is skipped.
+	if err := s.runDueSchedules(ctx, now); err != nil {
+		t.Fatalf("runDueSchedules: %v", err)
+	}
+	if _, err := store.GetJob(ctx, "tenant-1", scheduledJobID("sched-1", fireAt)); err == nil || len(provider.submissions()) != 0 {
+		t.Fatal("fire over the quota submitted a job")
+	}
+
+	// With queueing, the next fire's job is queued.
+	s.quotas = quota.NewChecker(store, quota.Limits{MaxConcurrentJobs: 1, QueueWhenBusy: true}, nil)
+	next := getTestSchedule(t, store).NextRunAt
+	if err := s.runDueSchedules(ctx, next); err != nil {
+		t.Fatalf("runDueSchedules: %v", err)
+	}
+	job, err := store.GetJob(ctx, "tenant-1", scheduledJobID("sched-1", next))
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if job.Status != database.JobStatusQueued || len(provider.submissions()) != 0 {
+		t.Fatalf("queued fire: status %s with %d submissions, want QUEUED and none", job.Status, len(provider.submissions()))
+	}
+}
@@ -2,11 +2,11 @@ package service
 
 import (
 	"context"
-	"log"
 	"sync"
 	"time"
 
 	gcpbatch "cloud.google.com/go/batch/apiv1"
+	"golang.org/x/time/rate"
 
 	"github.com/alphauslabs/jennah/gen/proto/jennahv1connect"
 	batch "github.com/alphauslabs/jennah/internal/cloudexec"
@@ -14,27 +14,45 @@ import (
 	"github.com/alphauslabs/jennah/internal/database"
 	"github.com/alphauslabs/jennah/internal/dispatcher"
 	"github.com/alphauslabs/jennah/internal/notifier"
+	"github.com/alphauslabs/jennah/internal/quota"
 )
 
 // WorkerService implements the DeploymentService RPC handlers for the worker.
 type WorkerService struct {
 	jennahv1connect.UnimplementedDeploymentServiceHandler
-	dbClient       *database.Client
-	batchProvider  batch.Provider
-	dispatcher     *dispatcher.Dispatcher
-	jobConfig      *config.JobConfigFile
-	workerID       string
-	leaseTTL       time.Duration
-	claimInterval  time.Duration
-	pollers        map[string]*JobPoller // Key: "tenantID/jobID"
-	pollersMutex   sync.Mutex
-	gcpBatchClient *gcpbatch.Client
-	notifier       notifier.Notifier
+	dbClient        database.Store
+	batchProvider   batch.Provider
+	dispatcher      *dispatcher.Dispatcher
+	jobConfig       *config.JobConfigFile
+	workerID        string
+	leaseTTL        time.Duration
+	claimInterval   time.Duration
+	tracked         map[string]*trackedJob // Key: "tenantID/jobID"
+	trackedMutex    sync.Mutex
+	reconciler      ReconcilerConfig
+	reconcilerStop  chan struct{} // nil until the first job is tracked
+	reconcilerWake  chan struct{}
+	limiters        map[string]*rate.Limiter // Key: provider service type
+	retryTimers     map[string]*time.Timer   // Key: "tenantID/jobID"
+	retryMutex      sync.Mutex
+	retryBaseDelay  time.Duration
+	retryMaxDelay   time.Duration
+	logPollInterval time.Duration
+	workflowMutex   sync.Mutex     // Serializes workflow advancement on this worker.
+	queueMutex      sync.Mutex     // Serializes releasing QUEUED jobs on this worker.
+	quotas          *quota.Checker // nil releases QUEUED jobs without checking limits
+	drainMutex      sync.Mutex
+	draining        bool           // Drain was called; guarded by drainMutex
+	submits         sync.WaitGroup // In-flight SubmitJob and SubmitWorkflow calls.
+	renewMutex      sync.RWMutex   // Read-held by reconciler lease renewals; Drain waits them out.
+	outboxWake      chan struct{}
+	gcpBatchClient  *gcpbatch.Client
+	notifier        notifier.Notifier
 }
 
 // NewWorkerService creates a new WorkerService with the given dependencies.
 func NewWorkerService(
-	dbClient *database.Client,
+	dbClient database.Store,
 	batchProvider batch.Provider,
 	d *dispatcher.Dispatcher,
 	jobConfig *config.JobConfigFile,
@@ -43,33 +61,34 @@ func NewWorkerService(
 	leaseTTL time.Duration,
 	claimInterval time.Duration,
 	n notifier.Notifier,
+	quotas *quota.Checker,
 ) *WorkerService {
 	return &WorkerService{
-		dbClient:       dbClient,
-		batchProvider:  batchProvider,
-		dispatcher:     d,
-		jobConfig:      jobConfig,
-		workerID:       workerID,
-		leaseTTL:       leaseTTL,
-		claimInterval:  claimInterval,
-		pollers:        make(map[string]*JobPoller),
-		gcpBatchClient: gcpBatchClient,
-		notifier:       n,
+		dbClient:        dbClient,
+		batchProvider:   batchProvider,
+		dispatcher:      d,
+		jobConfig:       jobConfig,
+		workerID:        workerID,
+		leaseTTL:        leaseTTL,
+		claimInterval:   claimInterval,
+		tracked:         make(map[string]*trackedJob),
+		reconciler:      DefaultReconcilerConfig(),
+		reconcilerWake:  make(chan struct{}, 1),
+		outboxWake:      make(chan struct{}, 1),
+		retryTimers:     make(map[string]*time.Timer),
+		retryBaseDelay:  defaultRetryBaseDelay,
+		retryMaxDelay:   defaultRetryMaxDelay,
+		logPollInterval: defaultLogPollInterval,
+		gcpBatchClient:  gcpBatchClient,
+		notifier:        n,
+		quotas:          quotas,
 	}
 }
 
-// publishTerminalEvent enriches the event with tenant metadata and publishes it.
-// Publish failures are logged but do not propagate — database state remains the source of truth.
-func (s *WorkerService) publishTerminalEvent(ctx context.Context, event notifier.JobTerminalEvent, tenantID string) {
-	// Enrich event with submitter email from tenant record.
-	tenant, err := s.dbClient.GetTenant(ctx, tenantID)
-	if err != nil {
-		log.Printf("Warning: could not look up tenant %s for event enrichment: %v", tenantID, err)
-	} else {
-		event.UserEmail = tenant.UserEmail
-	}
-
-	if err := s.notifier.PublishJobTerminalEvent(ctx, event); err != nil {
-		log.Printf("Error publishing terminal event for job %s: %v", event.JobID, err)
-	}
+// jobFinished follows up on a job that reached a terminal status: when the
+// job is a workflow node, it starts or skips the nodes that were waiting on
+// it, and the capacity it frees goes to the tenant's QUEUED jobs.
+func (s *WorkerService) jobFinished(ctx context.Context, tenantID, jobID string) {
+	s.advanceWorkflowOfJob(ctx, tenantID, jobID)
+	s.releaseQueuedJobs(ctx, tenantID)
 }
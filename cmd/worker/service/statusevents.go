@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/alphauslabs/jennah/internal/statusevents"
+)
+
+// StartStatusEvents checks a job's status as soon as its provider reports a
+// change, instead of waiting for its next scheduled check. Tracked jobs are
+// still checked every reconcileInterval to catch anything an event missed,
+// so call it before tracking any.
+func (s *WorkerService) StartStatusEvents(ctx context.Context, sub *statusevents.Subscriber, reconcileInterval time.Duration) {
+	s.trackedMutex.Lock()
+	s.reconciler.FastInterval = reconcileInterval
+	s.reconciler.SlowInterval = max(s.reconciler.SlowInterval, reconcileInterval)
+	s.trackedMutex.Unlock()
+
+	go func() {
+		log.Printf("Receiving status events; reconciling job status every %s", reconcileInterval)
+		if err := sub.Receive(ctx, s.handleStatusEvent); err != nil {
+			log.Printf("Status event subscription stopped: %v", err)
+		}
+	}()
+}
+
+// handleStatusEvent makes the job an event is about due for a check. Events
+// for jobs this worker does not track are ignored: the worker holding the
+// job's lease receives them on its own subscription.
+func (s *WorkerService) handleStatusEvent(ctx context.Context, event statusevents.Event) {
+	if jobID, ok := s.checkJobNow(event.CloudResourcePath); ok {
+		log.Printf("Status event for job %s from %s (state: %s)", jobID, event.Source, event.State)
+	}
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/router"
+	"github.com/alphauslabs/jennah/internal/statusevents"
+)
+
+func TestStatusEventChecksJobNow(t *testing.T) {
+	ctx := context.Background()
+	path := "projects/my-project/locations/us-central1/jobs/jennah-event"
+	job := &database.Job{JobId: "job-event", Status: database.JobStatusScheduled, ImageUri: "img", GcpBatchJobPath: &path}
+	s, store := newRetryTestService(t, &fakeProvider{}, job)
+
+	// Without an event the job would not be checked for an hour.
+	s.ConfigureReconciler(ReconcilerConfig{FastInterval: time.Hour, SlowInterval: time.Hour, Concurrency: 1})
+	s.trackJob("tenant-1", job.JobId, path, database.JobStatusScheduled, database.ServiceTierComplex, router.AssignedServiceUnspecified, time.Now())
+
+	s.handleStatusEvent(ctx, statusevents.Event{Source: statusevents.SourceCloudRun, CloudResourcePath: "projects/p/locations/l/jobs/jennah-other"})
+	s.handleStatusEvent(ctx, statusevents.Event{
+		Source:            statusevents.SourceCloudBatch,
+		CloudResourcePath: "projects/123456/locations/us-central1/jobs/jennah-event",
+		State:             "RUNNING",
+	})
+	waitForStatus(t, store, job.JobId, database.JobStatusRunning)
+}
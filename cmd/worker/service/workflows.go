@@ -0,0 +1,663 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/navigator"
+	"github.com/alphauslabs/jennah/internal/notifier"
+	"github.com/alphauslabs/jennah/internal/quota"
+)
+
+// maxWorkflowNodeIDLength matches the WorkflowNodeId column.
+const maxWorkflowNodeIDLength = 128
+
+// normalizeDependencyCondition maps a user-facing depends_on condition onto
+// the stored constant. Empty means on_success.
+func normalizeDependencyCondition(condition string) (string, error) {
+	switch strings.ToUpper(strings.TrimSpace(condition)) {
+	case "", database.DependencyOnSuccess:
+		return database.DependencyOnSuccess, nil
+	case database.DependencyOnFailure:
+		return database.DependencyOnFailure, nil
+	case database.DependencyAlways:
+		return database.DependencyAlways, nil
+	default:
+		return "", fmt.Errorf("invalid condition %q: want on_success, on_failure or always", condition)
+	}
+}
+
+// validateWorkflowGraph checks that node IDs are unique, every edge points at
+// another node of the workflow and the edges form no cycle.
+func validateWorkflowGraph(nodes []*jennahv1.WorkflowNode) error {
+	parents := make(map[string][]string, len(nodes))
+	for _, node := range nodes {
+		id := node.GetNodeId()
+		if id == "" {
+			return errors.New("node_id is required on every node")
+		}
+		if len(id) > maxWorkflowNodeIDLength {
+			return fmt.Errorf("node_id %q is longer than %d characters", id, maxWorkflowNodeIDLength)
+		}
+		if _, dup := parents[id]; dup {
+			return fmt.Errorf("duplicate node_id %q", id)
+		}
+		parents[id] = nil
+	}
+	for _, node := range nodes {
+		for _, dep := range node.GetDependsOn() {
+			if dep.GetNodeId() == node.GetNodeId() {
+				return fmt.Errorf("node %q depends on itself", node.GetNodeId())
+			}
+			if _, ok := parents[dep.GetNodeId()]; !ok {
+				return fmt.Errorf("node %q depends on unknown node %q", node.GetNodeId(), dep.GetNodeId())
+			}
+			parents[node.GetNodeId()] = append(parents[node.GetNodeId()], dep.GetNodeId())
+		}
+	}
+
+	// Depth-first search; reaching a node that is still on the stack means a cycle.
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(nodes))
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case visiting:
+			return fmt.Errorf("dependency cycle through node %q", id)
+		case done:
+			return nil
+		}
+		state[id] = visiting
+		for _, parent := range parents[id] {
+			if err := visit(parent); err != nil {
+				return err
+			}
+		}
+		state[id] = done
+		return nil
+	}
+	for _, node := range nodes {
+		if err := visit(node.GetNodeId()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dependencySatisfied reports whether a parent that finished with status
+// releases a child along an edge with the given condition.
+func dependencySatisfied(condition, status string) bool {
+	switch condition {
+	case database.DependencyOnFailure:
+		return status == database.JobStatusFailed
+	case database.DependencyAlways:
+		return true
+	default:
+		return status == database.JobStatusCompleted
+	}
+}
+
+// nodeReadiness decides what to do with a WAITING node. ready is true once
+// every parent is terminal and satisfies its edge; blocked explains why the
+// node can never start because a finished parent does not satisfy its edge.
+func nodeReadiness(deps []database.WorkflowDependency, byNode map[string]*database.Job) (ready bool, blocked string) {
+	ready = true
+	for _, dep := range deps {
+		parent, ok := byNode[dep.NodeId]
+		if !ok {
+			return false, fmt.Sprintf("Dependency %s does not exist", dep.NodeId)
+		}
+		if !isTerminalStatus(parent.Status) {
+			ready = false
+			continue
+		}
+		if !dependencySatisfied(dep.Condition, parent.Status) {
+			return false, fmt.Sprintf("Dependency %s finished %s; condition %s not met", dep.NodeId, parent.Status, dep.Condition)
+		}
+	}
+	return ready, ""
+}
+
+// SubmitWorkflow stores a DAG of jobs and starts every node without
+// dependencies. The remaining nodes wait in WAITING until their parents reach
+// terminal states.
+func (s *WorkerService) SubmitWorkflow(
+	ctx context.Context,
+	req *connect.Request[jennahv1.SubmitWorkflowRequest],
+) (*connect.Response[jennahv1.SubmitWorkflowResponse], error) {
+	if err := s.beginSubmit(); err != nil {
+		return nil, err
+	}
+	defer s.endSubmit()
+
+	tenantID := req.Header().Get("X-Tenant-Id")
+	log.Printf("Received SubmitWorkflow request for tenant: %s", tenantID)
+
+	if tenantID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("X-Tenant-Id header is required"))
+	}
+	if len(req.Msg.Nodes) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one node is required"))
+	}
+	if err := validateWorkflowGraph(req.Msg.Nodes); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	workflowID := req.Msg.WorkflowId
+	if workflowID == "" {
+		workflowID = uuid.New().String()
+	}
+
+	jobs := make([]*database.Job, 0, len(req.Msg.Nodes))
+	for _, node := range req.Msg.Nodes {
+		job, err := s.workflowNodeJob(ctx, tenantID, workflowID, node)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("node %q: %w", node.NodeId, err))
+		}
+		jobs = append(jobs, job)
+	}
+
+	var name *string
+	if n := strings.TrimSpace(req.Msg.Name); n != "" {
+		name = &n
+	}
+	err := s.dbClient.InsertWorkflow(ctx, &database.Workflow{
+		TenantId:   tenantID,
+		WorkflowId: workflowID,
+		Name:       name,
+	}, jobs)
+	if err != nil {
+		log.Printf("Error inserting workflow to database: %v", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create workflow: %w", err))
+	}
+	log.Printf("Workflow %s saved with %d node(s)", workflowID, len(jobs))
+
+	s.advanceWorkflow(ctx, tenantID, workflowID)
+
+	nodes, err := s.dbClient.ListWorkflowJobs(ctx, tenantID, workflowID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list workflow nodes: %w", err))
+	}
+	protoNodes := make([]*jennahv1.Job, 0, len(nodes))
+	for _, node := range nodes {
+		protoNodes = append(protoNodes, dbJobToProto(node))
+	}
+
+	log.Printf("Successfully submitted workflow %s for tenant %s", workflowID, tenantID)
+	return connect.NewResponse(&jennahv1.SubmitWorkflowResponse{
+		WorkflowId: workflowID,
+		Status:     database.WorkflowStatus(nodes),
+		Nodes:      protoNodes,
+	}), nil
+}
+
+// workflowNodeJob validates a node's job and builds its WAITING row, applying
+// the same defaults as SubmitJob.
+func (s *WorkerService) workflowNodeJob(ctx context.Context, tenantID, workflowID string, node *jennahv1.WorkflowNode) (*database.Job, error) {
+	spec := node.GetJob()
+	if spec.GetImageUri() == "" {
+		return nil, errors.New("image_uri is required")
+	}
+
+	retryPolicy, err := normalizeRetryPolicy(spec.RetryPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if err := batch.ValidateJobLabels(spec.GetLabels()); err != nil {
+		return nil, err
+	}
+	maxRetries := defaultMaxRetries
+	if spec.MaxRetries != nil {
+		if *spec.MaxRetries < 0 {
+			return nil, errors.New("max_retries must not be negative")
+		}
+		maxRetries = *spec.MaxRetries
+	}
+
+	envVars := cloneEnvVars(spec.GetEnvVars())
+	if err := ensureDistributedInputDataSize(ctx, envVars, getGCSObjectSize); err != nil {
+		return nil, err
+	}
+	var envVarsJson *string
+	if len(envVars) > 0 {
+		envBytes, err := json.Marshal(envVars)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize env vars: %w", err)
+		}
+		s := string(envBytes)
+		envVarsJson = &s
+	}
+
+	deps := make([]database.WorkflowDependency, 0, len(node.GetDependsOn()))
+	for _, dep := range node.GetDependsOn() {
+		condition, err := normalizeDependencyCondition(dep.GetCondition())
+		if err != nil {
+			return nil, err
+		}
+		deps = append(deps, database.WorkflowDependency{NodeId: dep.GetNodeId(), Condition: condition})
+	}
+	depsBytes, err := json.Marshal(deps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize dependencies: %w", err)
+	}
+	dependsOnJson := string(depsBytes)
+	nodeID := node.GetNodeId()
+
+	return &database.Job{
+		TenantId:              tenantID,
+		JobId:                 uuid.New().String(),
+		Status:                database.JobStatusWaiting,
+		ImageUri:              spec.ImageUri,
+		Commands:              spec.Commands,
+		MaxRetries:            maxRetries,
+		RetryPolicy:           &retryPolicy,
+		EnvVarsJson:           envVarsJson,
+		Name:                  ptrStringOrNil(spec.Name),
+		ResourceProfile:       ptrStringOrNil(spec.ResourceProfile),
+		MachineType:           ptrStringOrNil(spec.MachineType),
+		BootDiskSizeGb:        ptrInt64OrNil(spec.BootDiskSizeGb),
+		UseSpotVms:            ptrBoolOrNil(spec.UseSpotVms),
+		ServiceAccount:        ptrStringOrNil(spec.ServiceAccount),
+		MemoryMib:             ptrInt64OrNil(spec.GetResourceOverride().GetMemoryMib()),
+		CpuMillis:             ptrInt64OrNil(spec.GetResourceOverride().GetCpuMillis()),
+		MaxRunDurationSeconds: ptrInt64OrNil(spec.GetResourceOverride().GetMaxRunDurationSeconds()),
+		WorkflowId:            &workflowID,
+		WorkflowNodeId:        &nodeID,
+		DependsOnJson:         &dependsOnJson,
+		LabelsJson:            database.EncodeJobLabels(spec.GetLabels()),
+	}, nil
+}
+
+// advanceWorkflowOfJob advances the workflow a job belongs to, if any.
+func (s *WorkerService) advanceWorkflowOfJob(ctx context.Context, tenantID, jobID string) {
+	job, err := s.dbClient.GetJob(ctx, tenantID, jobID)
+	if err != nil {
+		if spanner.ErrCode(err) != codes.NotFound {
+			log.Printf("Error loading job %s to advance its workflow: %v", jobID, err)
+		}
+		return
+	}
+	if job.WorkflowId != nil {
+		s.advanceWorkflow(ctx, tenantID, *job.WorkflowId)
+	}
+}
+
+// advanceWorkflow starts every WAITING node whose dependencies are satisfied
+// and skips every WAITING node that can no longer start, repeating until no
+// node changes so skips and immediate failures cascade. A cancelled workflow
+// is left to CancelWorkflow.
+func (s *WorkerService) advanceWorkflow(ctx context.Context, tenantID, workflowID string) {
+	s.workflowMutex.Lock()
+	defer s.workflowMutex.Unlock()
+
+	wf, err := s.dbClient.GetWorkflow(ctx, tenantID, workflowID)
+	if err != nil {
+		log.Printf("Error loading workflow %s: %v", workflowID, err)
+		return
+	}
+	if wf.CancelledAt != nil {
+		return
+	}
+
+	for {
+		nodes, err := s.dbClient.ListWorkflowJobs(ctx, tenantID, workflowID)
+		if err != nil {
+			log.Printf("Error listing nodes of workflow %s: %v", workflowID, err)
+			return
+		}
+		byNode := make(map[string]*database.Job, len(nodes))
+		for _, node := range nodes {
+			byNode[ptrToString(node.WorkflowNodeId)] = node
+		}
+
+		progressed := false
+		for _, node := range nodes {
+			if node.Status != database.JobStatusWaiting {
+				continue
+			}
+			deps, err := database.JobDependencies(node)
+			if err != nil {
+				log.Printf("Workflow %s: %v", workflowID, err)
+				continue
+			}
+			ready, blocked := nodeReadiness(deps, byNode)
+			switch {
+			case blocked != "":
+				progressed = s.skipWorkflowNode(ctx, node, blocked) || progressed
+			case ready:
+				progressed = s.startWorkflowNode(ctx, node) || progressed
+			}
+		}
+		if !progressed {
+			return
+		}
+	}
+}
+
+// skipWorkflowNode marks a WAITING node SKIPPED. It reports whether the node
+// changed.
+func (s *WorkerService) skipWorkflowNode(ctx context.Context, node *database.Job, reason string) bool {
+	transitionID := uuid.New().String()
+	fromStatus := database.JobStatusWaiting
+	err := s.changeJobStatus(ctx, &database.JobStatusChange{
+		TenantId:     node.TenantId,
+		JobId:        node.JobId,
+		TransitionId: transitionID,
+		FromStatus:   &fromStatus,
+		ToStatus:     database.JobStatusSkipped,
+		Reason:       &reason,
+		WorkerId:     &s.workerID,
+	}, notifier.BuildEvent(transitionID, node.TenantId, node.JobId, database.JobStatusSkipped, fromStatus))
+	if err != nil {
+		log.Printf("Error skipping workflow node %s: %v", node.JobId, err)
+		return false
+	}
+	log.Printf("Workflow %s: node %s skipped: %s", ptrToString(node.WorkflowId), ptrToString(node.WorkflowNodeId), reason)
+	return true
+}
+
+// startWorkflowNode submits a WAITING node through the dispatcher and starts
+// polling it. The node's lease keeps two workers from starting it at once. It
+// reports whether the node changed.
+func (s *WorkerService) startWorkflowNode(ctx context.Context, node *database.Job) bool {
+	owned, err := s.dbClient.TryClaimOrRenewJobLease(ctx, node.TenantId, node.JobId, s.workerID, time.Now().UTC().Add(s.leaseTTL))
+	if err != nil {
+		log.Printf("Error claiming lease to start workflow node %s: %v", node.JobId, err)
+		return false
+	}
+	if !owned {
+		return false
+	}
+
+	// Re-read under the lease: another worker may have started it since the scan.
+	job, err := s.dbClient.GetJob(ctx, node.TenantId, node.JobId)
+	if err != nil {
+		log.Printf("Error loading workflow node %s: %v", node.JobId, err)
+		return false
+	}
+	if job.Status != database.JobStatusWaiting {
+		return false
+	}
+	if s.workflowCancelled(ctx, job) {
+		if _, err := s.dbClient.ReleaseJobLease(ctx, job.TenantId, job.JobId, s.workerID, ""); err != nil {
+			log.Printf("Error releasing lease of workflow node %s: %v", job.JobId, err)
+		}
+		return false
+	}
+
+	// Nodes start under the tenant's quota like submitted jobs. A node over
+	// the concurrency limits joins the queue, which starts it once capacity
+	// frees up; a node the quota does not allow fails.
+	if s.quotas != nil {
+		decision, msg, err := s.quotas.AdmitJob(ctx, job, time.Now())
+		if err != nil {
+			log.Printf("Error checking quota of tenant %s: %v", job.TenantId, err)
+			if _, err := s.dbClient.ReleaseJobLease(ctx, job.TenantId, job.JobId, s.workerID, ""); err != nil {
+				log.Printf("Error releasing lease of workflow node %s: %v", job.JobId, err)
+			}
+			return false
+		}
+		switch decision {
+		case quota.Queue:
+			return s.queueWorkflowNode(ctx, job, msg)
+		case quota.Reject:
+			s.failWorkflowNode(ctx, job, database.JobStatusWaiting, "quota exceeded: "+msg)
+			return true
+		}
+	}
+
+	if err := s.releaseJob(ctx, job, "Workflow dependencies satisfied"); err != nil {
+		log.Printf("Error updating workflow node %s to PENDING: %v", job.JobId, err)
+		return false
+	}
+
+	req, err := submitRequestFromJob(job)
+	if err != nil {
+		s.failWorkflowNode(ctx, job, database.JobStatusPending, err.Error())
+		return true
+	}
+	plan, err := navigator.Navigate(req, job.JobId, s.jobConfig)
+	if err != nil {
+		s.failWorkflowNode(ctx, job, database.JobStatusPending, fmt.Sprintf("failed to build execution plan: %v", err))
+		return true
+	}
+	plan.Config.JobID = generateProviderJobID(req.Name, job.JobId)
+	plan.Config.RequestID = job.JobId
+	plan.Config.TenantID = job.TenantId
+
+	jobResult, err := s.dispatchPlan(ctx, plan)
+	if err != nil {
+		s.failWorkflowNode(ctx, job, database.JobStatusPending, fmt.Sprintf("failed to submit batch job: %v", err))
+		return true
+	}
+
+	statusToSet := string(jobResult.InitialStatus)
+	if statusToSet == "" || statusToSet == string(batch.JobStatusUnknown) {
+		statusToSet = database.JobStatusRunning
+	}
+	reason := fmt.Sprintf("Submitted as workflow node %s: %s", ptrToString(job.WorkflowNodeId), jobResult.CloudResourcePath)
+	err = s.recordSubmission(ctx, job.TenantId, job.JobId, database.JobStatusPending, statusToSet, reason, plan, jobResult.CloudResourcePath, job.RetryCount)
+	if err != nil {
+		log.Printf("Error updating workflow node %s to %s: %v", job.JobId, statusToSet, err)
+		return true
+	}
+
+	log.Printf("Workflow %s: node %s started as job %s (%s)", ptrToString(job.WorkflowId), ptrToString(job.WorkflowNodeId), job.JobId, jobResult.CloudResourcePath)
+	s.trackJob(job.TenantId, job.JobId, jobResult.CloudResourcePath, statusToSet, serviceTierFromPlan(plan), plan.AssignedService, time.Now())
+	return true
+}
+
+// workflowCancelled reports whether job is a node of a cancelled workflow and
+// must not start. It is checked under the node's lease, which CancelWorkflow
+// claims after setting CancelledAt, so a node either sees the cancellation or
+// makes CancelWorkflow fail. A workflow that cannot be read counts as
+// cancelled; the node is started on a later pass.
+func (s *WorkerService) workflowCancelled(ctx context.Context, job *database.Job) bool {
+	if job.WorkflowId == nil {
+		return false
+	}
+	wf, err := s.dbClient.GetWorkflow(ctx, job.TenantId, *job.WorkflowId)
+	if err != nil {
+		log.Printf("Error loading workflow %s of job %s: %v", *job.WorkflowId, job.JobId, err)
+		return true
+	}
+	return wf.CancelledAt != nil
+}
+
+// queueWorkflowNode moves a WAITING node over the tenant's quota to QUEUED,
+// unowned, for releaseQueuedJobs to start. It reports whether the node
+// changed.
+func (s *WorkerService) queueWorkflowNode(ctx context.Context, job *database.Job, queueReason string) bool {
+	transitionID := uuid.New().String()
+	fromStatus := database.JobStatusWaiting
+	reason := "Queued: " + queueReason
+	err := s.changeJobStatus(ctx, &database.JobStatusChange{
+		TenantId:     job.TenantId,
+		JobId:        job.JobId,
+		TransitionId: transitionID,
+		FromStatus:   &fromStatus,
+		ToStatus:     database.JobStatusQueued,
+		Reason:       &reason,
+		WorkerId:     &s.workerID,
+	}, notifier.BuildStatusEvent(transitionID, job.TenantId, job.JobId, database.JobStatusQueued, fromStatus))
+	if err != nil {
+		log.Printf("Error queueing workflow node %s: %v", job.JobId, err)
+		return false
+	}
+	if _, err := s.dbClient.ReleaseJobLease(ctx, job.TenantId, job.JobId, s.workerID, ""); err != nil {
+		log.Printf("Error releasing lease of workflow node %s: %v", job.JobId, err)
+	}
+	log.Printf("Workflow %s: node %s queued: %s", ptrToString(job.WorkflowId), ptrToString(job.WorkflowNodeId), queueReason)
+	return true
+}
+
+// failWorkflowNode fails a node that could not be submitted from fromStatus.
+// The terminal event is queued without re-entering advanceWorkflow, whose loop
+// picks the failure up itself.
+func (s *WorkerService) failWorkflowNode(ctx context.Context, job *database.Job, fromStatus, errorMessage string) {
+	log.Printf("Error starting workflow node %s: %s", job.JobId, errorMessage)
+	transitionID := uuid.New().String()
+	event := notifier.BuildEvent(transitionID, job.TenantId, job.JobId, database.JobStatusFailed, fromStatus)
+	event.ErrorMessage = errorMessage
+	err := s.changeJobStatus(ctx, &database.JobStatusChange{
+		TenantId:     job.TenantId,
+		JobId:        job.JobId,
+		TransitionId: transitionID,
+		FromStatus:   &fromStatus,
+		ToStatus:     database.JobStatusFailed,
+		ErrorMessage: &errorMessage,
+		Reason:       &errorMessage,
+		WorkerId:     &s.workerID,
+	}, event)
+	if err != nil {
+		log.Printf("Error updating job status to FAILED: %v", err)
+	}
+}
+
+// CancelWorkflow cancels every unfinished node of a workflow. The workflow is
+// marked cancelled first so that no worker starts another node, then WAITING
+// nodes are cancelled before the active ones.
+func (s *WorkerService) CancelWorkflow(
+	ctx context.Context,
+	req *connect.Request[jennahv1.CancelWorkflowRequest],
+) (*connect.Response[jennahv1.CancelWorkflowResponse], error) {
+	tenantID := req.Header().Get("X-Tenant-Id")
+	workflowID := req.Msg.WorkflowId
+
+	if tenantID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("X-Tenant-Id header is required"))
+	}
+	if workflowID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("workflow_id is required"))
+	}
+
+	log.Printf("Received CancelWorkflow request for workflow %s (tenant: %s)", workflowID, tenantID)
+
+	if _, err := s.dbClient.GetWorkflow(ctx, tenantID, workflowID); err != nil {
+		if spanner.ErrCode(err) == codes.NotFound {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("workflow not found: %s", workflowID))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get workflow: %w", err))
+	}
+	if err := s.dbClient.CancelWorkflow(ctx, tenantID, workflowID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to cancel workflow: %w", err))
+	}
+
+	if err := s.cancelWaitingNodes(ctx, tenantID, workflowID); err != nil {
+		return nil, err
+	}
+
+	nodes, err := s.dbClient.ListWorkflowJobs(ctx, tenantID, workflowID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list workflow nodes: %w", err))
+	}
+	for _, node := range nodes {
+		if !isCancellableStatus(node.Status) {
+			continue
+		}
+		if err := s.cancelActiveJob(ctx, node, fmt.Sprintf("Workflow %s cancelled", workflowID)); err != nil {
+			return nil, err
+		}
+	}
+
+	nodes, err = s.dbClient.ListWorkflowJobs(ctx, tenantID, workflowID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list workflow nodes: %w", err))
+	}
+
+	log.Printf("Successfully cancelled workflow %s", workflowID)
+	return connect.NewResponse(&jennahv1.CancelWorkflowResponse{
+		WorkflowId: workflowID,
+		Status:     database.WorkflowStatus(nodes),
+	}), nil
+}
+
+// cancelWaitingNodes marks the workflow's WAITING nodes CANCELLED. Each node
+// is claimed first; a node whose lease another worker holds may be starting
+// there, so the call fails with Aborted and can be retried, by which time the
+// node is either active or, having seen CancelledAt, still WAITING.
+func (s *WorkerService) cancelWaitingNodes(ctx context.Context, tenantID, workflowID string) error {
+	s.workflowMutex.Lock()
+	defer s.workflowMutex.Unlock()
+
+	nodes, err := s.dbClient.ListWorkflowJobs(ctx, tenantID, workflowID)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list workflow nodes: %w", err))
+	}
+	for _, node := range nodes {
+		if node.Status != database.JobStatusWaiting {
+			continue
+		}
+		owned, err := s.dbClient.TryClaimOrRenewJobLease(ctx, tenantID, node.JobId, s.workerID, time.Now().UTC().Add(s.leaseTTL))
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to claim workflow node %s: %w", ptrToString(node.WorkflowNodeId), err))
+		}
+		if !owned {
+			return connect.NewError(connect.CodeAborted, fmt.Errorf("workflow node %s is held by another worker; retry the cancellation", ptrToString(node.WorkflowNodeId)))
+		}
+		transitionID := uuid.New().String()
+		fromStatus := database.JobStatusWaiting
+		reason := fmt.Sprintf("Workflow %s cancelled", workflowID)
+		err = s.changeJobStatus(ctx, &database.JobStatusChange{
+			TenantId:     tenantID,
+			JobId:        node.JobId,
+			TransitionId: transitionID,
+			FromStatus:   &fromStatus,
+			ToStatus:     database.JobStatusCancelled,
+			Reason:       &reason,
+			WorkerId:     &s.workerID,
+		}, notifier.BuildEvent(transitionID, tenantID, node.JobId, database.JobStatusCancelled, fromStatus))
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update job status: %w", err))
+		}
+	}
+	return nil
+}
+
+// workflowToProto loads a workflow and its nodes for the API.
+func (s *WorkerService) workflowToProto(ctx context.Context, tenantID, workflowID string) (*jennahv1.Workflow, error) {
+	wf, err := s.dbClient.GetWorkflow(ctx, tenantID, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := s.dbClient.ListWorkflowJobs(ctx, tenantID, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	return dbWorkflowToProto(wf, nodes), nil
+}
+
+func dbWorkflowToProto(wf *database.Workflow, nodes []*database.Job) *jennahv1.Workflow {
+	p := &jennahv1.Workflow{
+		WorkflowId: wf.WorkflowId,
+		TenantId:   wf.TenantId,
+		Status:     database.WorkflowStatus(nodes),
+		CreatedAt:  wf.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  wf.UpdatedAt.Format(time.RFC3339),
+		Nodes:      make([]*jennahv1.Job, 0, len(nodes)),
+	}
+	if wf.Name != nil {
+		p.Name = *wf.Name
+	}
+	for _, node := range nodes {
+		p.Nodes = append(p.Nodes, dbJobToProto(node))
+	}
+	return p
+}
@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/quota"
+)
+
+func workflowNode(id string, deps ...*jennahv1.WorkflowDependency) *jennahv1.WorkflowNode {
+	return &jennahv1.WorkflowNode{
+		NodeId:    id,
+		Job:       &jennahv1.SubmitJobRequest{ImageUri: "img", Name: id, Commands: []string{id}},
+		DependsOn: deps,
+	}
+}
+
+func dependsOn(nodeID, condition string) *jennahv1.WorkflowDependency {
+	return &jennahv1.WorkflowDependency{NodeId: nodeID, Condition: condition}
+}
+
+func submitTestWorkflow(t *testing.T, s *WorkerService, nodes ...*jennahv1.WorkflowNode) string {
+	t.Helper()
+	req := connect.NewRequest(&jennahv1.SubmitWorkflowRequest{WorkflowId: "wf-1", Name: "pipeline", Nodes: nodes})
+	req.Header().Set("X-Tenant-Id", "tenant-1")
+	resp, err := s.SubmitWorkflow(context.Background(), req)
+	if err != nil {
+		t.Fatalf("SubmitWorkflow: %v", err)
+	}
+	return resp.Msg.WorkflowId
+}
+
+// workflowJobs maps each node of wf-1 to its job.
+func workflowJobs(t *testing.T, store database.Store) map[string]*database.Job {
+	t.Helper()
+	jobs, err := store.ListWorkflowJobs(context.Background(), "tenant-1", "wf-1")
+	if err != nil {
+		t.Fatalf("ListWorkflowJobs: %v", err)
+	}
+	byNode := make(map[string]*database.Job, len(jobs))
+	for _, job := range jobs {
+		byNode[*job.WorkflowNodeId] = job
+	}
+	return byNode
+}
+
+// finishNode moves a node to a terminal status the way the reconciler does.
+func finishNode(t *testing.T, s *WorkerService, store database.Store, nodeID, status string) {
+	t.Helper()
+	ctx := context.Background()
+	job := workflowJobs(t, store)[nodeID]
+	s.untrackJob("tenant-1", job.JobId)
+	if err := store.UpdateJobStatus(ctx, "tenant-1", job.JobId, status); err != nil {
+		t.Fatalf("UpdateJobStatus: %v", err)
+	}
+	s.jobFinished(ctx, "tenant-1", job.JobId)
+}
+
+func assertNodeStatuses(t *testing.T, store database.Store, want map[string]string) {
+	t.Helper()
+	jobs := workflowJobs(t, store)
+	for nodeID, status := range want {
+		if got := jobs[nodeID].Status; got != status {
+			t.Errorf("node %s status = %s, want %s", nodeID, got, status)
+		}
+	}
+}
+
+func TestValidateWorkflowGraph(t *testing.T) {
+	valid := []*jennahv1.WorkflowNode{
+		workflowNode("split"),
+		workflowNode("fanout", dependsOn("split", "")),
+		workflowNode("merge", dependsOn("fanout", "on_success"), dependsOn("split", "always")),
+	}
+	if err := validateWorkflowGraph(valid); err != nil {
+		t.Fatalf("valid graph rejected: %v", err)
+	}
+
+	for name, nodes := range map[string][]*jennahv1.WorkflowNode{
+		"missing id":   {workflowNode("")},
+		"duplicate id": {workflowNode("a"), workflowNode("a")},
+		"unknown dep":  {workflowNode("a", dependsOn("b", ""))},
+		"self dep":     {workflowNode("a", dependsOn("a", ""))},
+		"cycle": {
+			workflowNode("a", dependsOn("c", "")),
+			workflowNode("b", dependsOn("a", "")),
+			workflowNode("c", dependsOn("b", "")),
+		},
+	} {
+		if err := validateWorkflowGraph(nodes); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestNodeReadiness(t *testing.T) {
+	parent := func(status string) map[string]*database.Job {
+		return map[string]*database.Job{"p": {Status: status}}
+	}
+	cases := []struct {
+		condition, parentStatus string
+		wantReady, wantBlocked  bool
+	}{
+		{database.DependencyOnSuccess, database.JobStatusRunning, false, false},
+		{database.DependencyOnSuccess, database.JobStatusCompleted, true, false},
+		{database.DependencyOnSuccess, database.JobStatusFailed, false, true},
+		{database.DependencyOnSuccess, database.JobStatusSkipped, false, true},
+		{database.DependencyOnFailure, database.JobStatusFailed, true, false},
+		{database.DependencyOnFailure, database.JobStatusCompleted, false, true},
+		{database.DependencyAlways, database.JobStatusCancelled, true, false},
+		{database.DependencyAlways, database.JobStatusWaiting, false, false},
+	}
+	for _, tc := range cases {
+		deps := []database.WorkflowDependency{{NodeId: "p", Condition: tc.condition}}
+		ready, blocked := nodeReadiness(deps, parent(tc.parentStatus))
+		if ready != tc.wantReady || (blocked != "") != tc.wantBlocked {
+			t.Errorf("%s after %s: ready=%v blocked=%q, want ready=%v blocked=%v",
+				tc.condition, tc.parentStatus, ready, blocked, tc.wantReady, tc.wantBlocked)
+		}
+	}
+}
+
+func TestWorkflowRunsNodesAsParentsFinish(t *testing.T) {
+	ctx := context.Background()
+	store := database.NewMemoryStore()
+	if err := store.InsertTenant(ctx, "tenant-1", "a@example.com", "google", "u1"); err != nil {
+		t.Fatalf("InsertTenant: %v", err)
+	}
+	provider := &fakeProvider{}
+	s := newSchedulerTestService(t, provider, "worker-a", store)
+
+	submitTestWorkflow(t, s,
+		workflowNode("split"),
+		workflowNode("fanout", dependsOn("split", "on_success")),
+		workflowNode("alert", dependsOn("fanout", "on_failure")),
+		workflowNode("merge", dependsOn("fanout", "on_success")),
+		workflowNode("cleanup", dependsOn("merge", "always")),
+	)
+	if n := len(provider.submissions()); n != 1 {
+		t.Fatalf("submissions after submit = %d, want only the root", n)
+	}
+	assertNodeStatuses(t, store, map[string]string{
+		"split":  database.JobStatusScheduled,
+		"fanout": database.JobStatusWaiting,
+		"merge":  database.JobStatusWaiting,
+	})
+
+	finishNode(t, s, store, "split", database.JobStatusCompleted)
+	assertNodeStatuses(t, store, map[string]string{"fanout": database.JobStatusScheduled})
+
+	finishNode(t, s, store, "fanout", database.JobStatusCompleted)
+	assertNodeStatuses(t, store, map[string]string{
+		"alert": database.JobStatusSkipped,
+		"merge": database.JobStatusScheduled,
+	})
+
+	finishNode(t, s, store, "merge", database.JobStatusFailed)
+	assertNodeStatuses(t, store, map[string]string{"cleanup": database.JobStatusScheduled})
+
+	finishNode(t, s, store, "cleanup", database.JobStatusCompleted)
+	if n := len(provider.submissions()); n != 4 {
+		t.Errorf("submissions = %d, want 4", n)
+	}
+
+	req := connect.NewRequest(&jennahv1.GetJobRequest{JobId: workflowJobs(t, store)["merge"].JobId})
+	req.Header().Set("X-Tenant-Id", "tenant-1")
+	resp, err := s.GetJob(ctx, req)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if resp.Msg.Job.WorkflowNodeId != "merge" || len(resp.Msg.Job.DependsOn) != 1 {
+		t.Errorf("job = %+v, want the merge node with its dependency", resp.Msg.Job)
+	}
+	if wf := resp.Msg.Workflow; wf == nil || wf.Status != database.JobStatusFailed || len(wf.Nodes) != 5 {
+		t.Errorf("workflow view = %+v, want FAILED with 5 nodes", wf)
+	}
+}
+
+func TestWorkflowNodesStartWithinQuota(t *testing.T) {
+	ctx := context.Background()
+	store := database.NewMemoryStore()
+	if err := store.InsertTenant(ctx, "tenant-1", "a@example.com", "google", "u1"); err != nil {
+		t.Fatalf("InsertTenant: %v", err)
+	}
+	provider := &fakeProvider{}
+	s := newSchedulerTestService(t, provider, "worker-a", store)
+	s.quotas = quota.NewChecker(store, quota.Limits{MaxConcurrentJobs: 1, QueueWhenBusy: true}, nil)
+
+	spot := workflowNode("spot")
+	spot.Job.UseSpotVms = true
+	submitTestWorkflow(t, s, workflowNode("first"), workflowNode("second"), spot)
+	assertNodeStatuses(t, store, map[string]string{
+		"first":  database.JobStatusScheduled,
+		"second": database.JobStatusQueued,
+		"spot":   database.JobStatusFailed,
+	})
+	if n := len(provider.submissions()); n != 1 {
+		t.Fatalf("submissions = %d, want only the node that fits", n)
+	}
+
+	// The queued node starts once the first frees its slot.
+	finishNode(t, s, store, "first", database.JobStatusCompleted)
+	assertNodeStatuses(t, store, map[string]string{"second": database.JobStatusScheduled})
+}
+
+func TestWorkflowSkipsCascade(t *testing.T) {
+	ctx := context.Background()
+	store := database.NewMemoryStore()
+	if err := store.InsertTenant(ctx, "tenant-1", "a@example.com", "google", "u1"); err != nil {
+		t.Fatalf("InsertTenant: %v", err)
+	}
+	s := newSchedulerTestService(t, &fakeProvider{}, "worker-a", store)
+
+	submitTestWorkflow(t, s,
+		workflowNode("split"),
+		workflowNode("fanout", dependsOn("split", "")),
+		workflowNode("merge", dependsOn("fanout", "")),
+		workflowNode("report", dependsOn("merge", "always")),
+	)
+	finishNode(t, s, store, "split", database.JobStatusFailed)
+
+	assertNodeStatuses(t, store, map[string]string{
+		"fanout": database.JobStatusSkipped,
+		"merge":  database.JobStatusSkipped,
+		"report": database.JobStatusScheduled,
+	})
+}
+
+func TestCancelWorkflowCascades(t *testing.T) {
+	ctx := context.Background()
+	store := database.NewMemoryStore()
+	if err := store.InsertTenant(ctx, "tenant-1", "a@example.com", "google", "u1"); err != nil {
+		t.Fatalf("InsertTenant: %v", err)
+	}
+	provider := &fakeProvider{}
+	s := newSchedulerTestService(t, provider, "worker-a", store)
+
+	submitTestWorkflow(t, s,
+		workflowNode("split"),
+		workflowNode("merge", dependsOn("split", "")),
+		workflowNode("cleanup", dependsOn("merge", "always")),
+	)
+
+	req := connect.NewRequest(&jennahv1.CancelWorkflowRequest{WorkflowId: "wf-1"})
+	req.Header().Set("X-Tenant-Id", "tenant-1")
+	resp, err := s.CancelWorkflow(ctx, req)
+	if err != nil {
+		t.Fatalf("CancelWorkflow: %v", err)
+	}
+	if resp.Msg.Status != database.JobStatusCancelled {
+		t.Errorf("workflow status = %s, want CANCELLED", resp.Msg.Status)
+	}
+	assertNodeStatuses(t, store, map[string]string{
+		"split":   database.JobStatusCancelled,
+		"merge":   database.JobStatusCancelled,
+		"cleanup": database.JobStatusCancelled,
+	})
+	if n := len(provider.submissions()); n != 1 {
+		t.Errorf("submissions = %d, want only the root", n)
+	}
+
+	req = connect.NewRequest(&jennahv1.CancelWorkflowRequest{WorkflowId: "missing"})
+	req.Header().Set("X-Tenant-Id", "tenant-1")
+	if _, err := s.CancelWorkflow(ctx, req); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("cancel of unknown workflow: got %v, want NotFound", err)
+	}
+}
+
+func TestCancelWorkflowFailsWhileANodeIsHeld(t *testing.T) {
+	ctx := context.Background()
+	store := database.NewMemoryStore()
+	if err := store.InsertTenant(ctx, "tenant-1", "a@example.com", "google", "u1"); err != nil {
+		t.Fatalf("InsertTenant: %v", err)
+	}
+	provider := &fakeProvider{}
+	s := newSchedulerTestService(t, provider, "worker-a", store)
+
+	submitTestWorkflow(t, s,
+		workflowNode("split"),
+		workflowNode("cleanup", dependsOn("split", "always")),
+	)
+	// Another worker holds cleanup's lease, as if it were starting the node.
+	cleanup := workflowJobs(t, store)["cleanup"]
+	if _, err := store.TryClaimOrRenewJobLease(ctx, "tenant-1", cleanup.JobId, "worker-b", time.Now().UTC().Add(time.Minute)); err != nil {
+		t.Fatalf("TryClaimOrRenewJobLease: %v", err)
+	}
+
+	req := connect.NewRequest(&jennahv1.CancelWorkflowRequest{WorkflowId: "wf-1"})
+	req.Header().Set("X-Tenant-Id", "tenant-1")
+	if _, err := s.CancelWorkflow(ctx, req); connect.CodeOf(err) != connect.CodeAborted {
+		t.Fatalf("CancelWorkflow with a held node: got %v, want Aborted", err)
+	}
+
+	// The cancellation is recorded, so the always node does not start when
+	// its parent finishes.
+	if _, err := store.ReleaseJobLease(ctx, "tenant-1", cleanup.JobId, "worker-b", ""); err != nil {
+		t.Fatalf("ReleaseJobLease: %v", err)
+	}
+	finishNode(t, s, store, "split", database.JobStatusFailed)
+	assertNodeStatuses(t, store, map[string]string{"cleanup": database.JobStatusWaiting})
+	if n := len(provider.submissions()); n != 1 {
+		t.Errorf("submissions = %d, want only the root", n)
+	}
+
+	if _, err := s.CancelWorkflow(ctx, req); err != nil {
+		t.Fatalf("retried CancelWorkflow: %v", err)
+	}
+	assertNodeStatuses(t, store, map[string]string{"cleanup": database.JobStatusCancelled})
+}
+
+func TestSubmitWorkflowRejectsInvalidNodes(t *testing.T) {
+	store := database.NewMemoryStore()
+	s := newSchedulerTestService(t, &fakeProvider{}, "worker-a", store)
+
+	for name, nodes := range map[string][]*jennahv1.WorkflowNode{
+		"no nodes":      nil,
+		"cycle":         {workflowNode("a", dependsOn("b", "")), workflowNode("b", dependsOn("a", ""))},
+		"bad condition": {workflowNode("a"), workflowNode("b", dependsOn("a", "sometimes"))},
+		"no image":      {{NodeId: "a", Job: &jennahv1.SubmitJobRequest{}}},
+	} {
+		req := connect.NewRequest(&jennahv1.SubmitWorkflowRequest{Nodes: nodes})
+		req.Header().Set("X-Tenant-Id", "tenant-1")
+		if _, err := s.SubmitWorkflow(context.Background(), req); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("%s: got %v, want InvalidArgument", name, err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+-- Baseline schema: Tenants, Jobs and JobStateTransitions as in schema.sql.
+-- IF NOT EXISTS lets environments created by hand adopt jennah-migrate: on
+-- those databases this migration only records itself.
+
+CREATE TABLE IF NOT EXISTS Tenants (
+  TenantId STRING(36) NOT NULL,
+  UserEmail STRING(255) NOT NULL,
+  OAuthProvider STRING(50) NOT NULL,
+  OAuthUserId STRING(255) NOT NULL,
+  CreatedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp=true),
+  UpdatedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (TenantId);
+
+CREATE INDEX IF NOT EXISTS TenantsByOAuth ON Tenants(OAuthProvider, OAuthUserId);
+
+CREATE TABLE IF NOT EXISTS Jobs (
+  TenantId STRING(36) NOT NULL,
+  JobId STRING(36) NOT NULL,
+  Status STRING(50) NOT NULL,
+  ImageUri STRING(1024),
+  Commands ARRAY<STRING(MAX)>,
+  CreatedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp=true),
+  UpdatedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp=true),
+  ScheduledAt TIMESTAMP,
+  StartedAt TIMESTAMP,
+  CompletedAt TIMESTAMP,
+  RetryCount INT64 NOT NULL DEFAULT (0),
+  MaxRetries INT64 NOT NULL DEFAULT (3),
+  ErrorMessage STRING(MAX),
+  GcpBatchJobPath STRING(1024),
+  GcpBatchTaskGroup STRING(1024),
+  EnvVarsJson STRING(MAX),
+  OwnerWorkerId STRING(128),
+  PreferredWorkerId STRING(128),
+  LeaseExpiresAt TIMESTAMP,
+  LastHeartbeatAt TIMESTAMP,
+) PRIMARY KEY (TenantId, JobId),
+  INTERLEAVE IN PARENT Tenants ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS JobsByStatus ON Jobs(TenantId, Status, CreatedAt DESC);
+
+CREATE TABLE IF NOT EXISTS JobStateTransitions (
+  TenantId STRING(36) NOT NULL,
+  JobId STRING(36) NOT NULL,
+  TransitionId STRING(36) NOT NULL,
+  FromStatus STRING(50),
+  ToStatus STRING(50) NOT NULL,
+  TransitionedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp=true),
+  Reason STRING(MAX),
+) PRIMARY KEY (TenantId, JobId, TransitionId),
+  INTERLEAVE IN PARENT Jobs ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS TransitionsByJob ON JobStateTransitions(TenantId, JobId, TransitionedAt DESC);
@@ -0,0 +1,10 @@
+-- Advanced job configuration (replaces migrate-advanced-config.sql).
+
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS Name STRING(255);
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS ResourceProfile STRING(50);
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS MachineType STRING(255);
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS BootDiskSizeGb INT64;
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS UseSpotVms BOOL;
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS ServiceAccount STRING(1024);
+
+CREATE INDEX IF NOT EXISTS IdxJobsByName ON Jobs(TenantId, Name);
@@ -0,0 +1,12 @@
+-- Service routing: which executor ran the job and the resources the router
+-- resolved for it (replaces migrate-service-tier.sql).
+
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS ServiceTier STRING(20);
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS AssignedService STRING(50);
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS MemoryMib INT64;
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS CpuMillis INT64;
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS MaxRunDurationSeconds INT64;
+
+-- Cloud Tasks was removed; MEDIUM jobs now run on Cloud Run Jobs (SIMPLE).
+-- (replaces migrate-remove-medium-tier.sql)
+UPDATE Jobs SET ServiceTier = 'SIMPLE' WHERE ServiceTier = 'MEDIUM';
@@ -0,0 +1,4 @@
+-- Automatic retries: which failed attempts the worker resubmits.
+-- NULL means ON_FAILURE; the other values are ON_PREEMPTION and NEVER.
+
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS RetryPolicy STRING(20);
@@ -0,0 +1,24 @@
+-- Cron schedules: each fire materializes a job from JobTemplateJson (a
+-- protojson SubmitJobRequest). OwnerWorkerId/LeaseExpiresAt use the same
+-- lease rules as Jobs so only one worker fires a schedule at a time.
+
+CREATE TABLE IF NOT EXISTS Schedules (
+  TenantId        STRING(36)   NOT NULL,
+  ScheduleId      STRING(36)   NOT NULL,
+  Name            STRING(255),
+  CronExpression  STRING(255)  NOT NULL,
+  TimeZone        STRING(64)   NOT NULL,
+  OverlapPolicy   STRING(20)   NOT NULL,  -- SKIP | QUEUE | REPLACE
+  JobTemplateJson STRING(MAX)  NOT NULL,
+  Paused          BOOL         NOT NULL DEFAULT (FALSE),
+  NextRunAt       TIMESTAMP    NOT NULL,
+  LastRunAt       TIMESTAMP    OPTIONS (allow_commit_timestamp=true),
+  LastJobId       STRING(36),
+  OwnerWorkerId   STRING(128),
+  LeaseExpiresAt  TIMESTAMP,
+  CreatedAt       TIMESTAMP    NOT NULL OPTIONS (allow_commit_timestamp=true),
+  UpdatedAt       TIMESTAMP    NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (TenantId, ScheduleId),
+  INTERLEAVE IN PARENT Tenants ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS SchedulesByNextRunAt ON Schedules(Paused, NextRunAt);
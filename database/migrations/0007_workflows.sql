@@ -0,0 +1,18 @@
+-- Workflows: DAGs of jobs submitted together. Each node is a Jobs row that
+-- starts WAITING and is submitted once its depends_on edges are satisfied.
+-- DependsOnJson holds [{"node_id": "...", "condition": "ON_SUCCESS"}, ...].
+
+CREATE TABLE IF NOT EXISTS Workflows (
+  TenantId   STRING(36)  NOT NULL,
+  WorkflowId STRING(36)  NOT NULL,
+  Name       STRING(255),
+  CreatedAt  TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+  UpdatedAt  TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (TenantId, WorkflowId),
+  INTERLEAVE IN PARENT Tenants ON DELETE CASCADE;
+
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS WorkflowId STRING(36);
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS WorkflowNodeId STRING(128);
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS DependsOnJson STRING(MAX);
+
+CREATE INDEX IF NOT EXISTS JobsByWorkflow ON Jobs(TenantId, WorkflowId, WorkflowNodeId);
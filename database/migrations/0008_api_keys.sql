@@ -0,0 +1,19 @@
+-- Tenant API keys for CI pipelines and service-to-service callers. Only the
+-- SHA-256 of a key is stored; KeyPrefix keeps its first characters so keys
+-- can be told apart in listings. Scopes are any of submit, read, cancel and
+-- admin. A key stops working once ExpiresAt passes or RevokedAt is set.
+
+CREATE TABLE IF NOT EXISTS ApiKeys (
+  TenantId  STRING(36)        NOT NULL,
+  ApiKeyId  STRING(36)        NOT NULL,
+  Name      STRING(255),
+  KeyPrefix STRING(16)        NOT NULL,
+  KeyHash   STRING(64)        NOT NULL,  -- hex SHA-256 of the key
+  Scopes    ARRAY<STRING(20)> NOT NULL,
+  ExpiresAt TIMESTAMP,
+  RevokedAt TIMESTAMP         OPTIONS (allow_commit_timestamp=true),
+  CreatedAt TIMESTAMP         NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (TenantId, ApiKeyId),
+  INTERLEAVE IN PARENT Tenants ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS ApiKeysByHash ON ApiKeys(KeyHash);
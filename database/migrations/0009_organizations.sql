@@ -0,0 +1,25 @@
+-- Organizations: tenants shared by several users. An organization's Tenants
+-- row has OAuthProvider 'organization' (so it never matches a login) and owns
+-- jobs like any other tenant; members are matched by their verified email and
+-- hold one of the roles viewer, submitter or admin.
+
+CREATE TABLE IF NOT EXISTS Organizations (
+  TenantId  STRING(36)  NOT NULL,
+  Name      STRING(255) NOT NULL,
+  CreatedBy STRING(255) NOT NULL,  -- email of the creator
+  CreatedAt TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+  UpdatedAt TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (TenantId),
+  INTERLEAVE IN PARENT Tenants ON DELETE CASCADE;
+
+CREATE TABLE IF NOT EXISTS OrganizationMembers (
+  TenantId  STRING(36)  NOT NULL,
+  Email     STRING(255) NOT NULL,  -- lower-case verified email
+  Role      STRING(20)  NOT NULL,  -- viewer | submitter | admin
+  AddedBy   STRING(255),
+  CreatedAt TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+  UpdatedAt TIMESTAMP   NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (TenantId, Email),
+  INTERLEAVE IN PARENT Organizations ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS OrganizationMembersByEmail ON OrganizationMembers(Email);
@@ -0,0 +1,19 @@
+-- Per-tenant admission limits enforced by the gateway at SubmitJob. A NULL
+-- column falls back to the gateway's QUOTA_* default; 0 means unlimited.
+-- AllowedMachineTypes NULL or empty allows every machine type.
+-- QueueWhenBusy holds jobs over the concurrency, vCPU or memory limit in the
+-- QUEUED state instead of rejecting them; workers release them in submission
+-- order as capacity frees up.
+
+CREATE TABLE IF NOT EXISTS TenantQuotas (
+  TenantId            STRING(36)         NOT NULL,
+  MaxConcurrentJobs   INT64,
+  MaxCpuMillis        INT64,
+  MaxMemoryMib        INT64,
+  MaxJobsPerDay       INT64,
+  AllowedMachineTypes ARRAY<STRING(64)>,
+  AllowSpotVms        BOOL,
+  QueueWhenBusy       BOOL,
+  UpdatedAt           TIMESTAMP          NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (TenantId),
+  INTERLEAVE IN PARENT Tenants ON DELETE CASCADE;
@@ -0,0 +1,12 @@
+-- Idempotent SubmitJob: a client-supplied key is stored on the job it
+-- created, together with a hash of the request and the response the gateway
+-- returned. A retried submit with the same key gets that response back
+-- instead of creating another job; the same key with a different request is
+-- rejected. The gateway clears keys older than its retention window when
+-- they are reused.
+
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS IdempotencyKey STRING(255);
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS RequestHash STRING(64);
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS SubmitResponseJson STRING(MAX);
+
+CREATE UNIQUE NULL_FILTERED INDEX IF NOT EXISTS JobsByIdempotencyKey ON Jobs(TenantId, IdempotencyKey);
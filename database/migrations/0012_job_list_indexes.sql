@@ -0,0 +1,6 @@
+-- Paginated ListJobs: pages are keyset-ordered by (CreatedAt, JobId). JobId
+-- is part of the primary key, so every index below already ends with it.
+-- The status filter uses JobsByStatus and the name prefix IdxJobsByName.
+
+CREATE INDEX IF NOT EXISTS JobsByCreatedAt ON Jobs(TenantId, CreatedAt DESC);
+CREATE INDEX IF NOT EXISTS JobsByAssignedService ON Jobs(TenantId, AssignedService, CreatedAt DESC);
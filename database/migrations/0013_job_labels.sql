@@ -0,0 +1,5 @@
+-- User-defined job labels, stored as a JSON object and propagated to the
+-- Cloud Batch or Cloud Run job. ListJobs and the bulk cancel/delete RPCs
+-- match them with label selectors such as "team=billing,env=prod".
+
+ALTER TABLE Jobs ADD COLUMN IF NOT EXISTS LabelsJson STRING(MAX);
@@ -0,0 +1,6 @@
+-- Who observed each state change and what the provider said about it, so
+-- GetJobTimeline can explain why a job sat in a state (for example the
+-- Cloud Batch status event behind a long SCHEDULED).
+
+ALTER TABLE JobStateTransitions ADD COLUMN IF NOT EXISTS WorkerId STRING(128);
+ALTER TABLE JobStateTransitions ADD COLUMN IF NOT EXISTS ProviderDetail STRING(MAX);
@@ -0,0 +1,13 @@
+-- Worker registry. Every worker upserts its row on a heartbeat with the
+-- address the gateway reaches it at; the gateway builds its routing ring from
+-- the workers whose heartbeat is recent and drops the others, so workers can
+-- be added and removed without redeploying the gateway.
+
+CREATE TABLE IF NOT EXISTS Workers (
+  WorkerId        STRING(128)  NOT NULL,
+  Address         STRING(256)  NOT NULL,
+  Capacity        INT64        NOT NULL,
+  ActiveJobs      INT64        NOT NULL,
+  StartedAt       TIMESTAMP    NOT NULL,
+  LastHeartbeatAt TIMESTAMP    NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (WorkerId);
@@ -0,0 +1,21 @@
+-- Transactional outbox. Job events are written in the same transaction as
+-- the status change they announce; a relay on the workers publishes them and
+-- marks them delivered, so an event is neither lost when a worker dies after
+-- the write nor announced for a change that was never committed. Relays
+-- claim rows with a lease, like jobs, and retry them once the lease expires.
+
+CREATE TABLE IF NOT EXISTS Outbox (
+  EventId        STRING(36)   NOT NULL,
+  TenantId       STRING(36)   NOT NULL,
+  JobId          STRING(36)   NOT NULL,
+  EventType      STRING(64)   NOT NULL,
+  Payload        STRING(MAX)  NOT NULL,
+  CreatedAt      TIMESTAMP    NOT NULL OPTIONS (allow_commit_timestamp=true),
+  DeliveredAt    TIMESTAMP    OPTIONS (allow_commit_timestamp=true),
+  Attempts       INT64        NOT NULL,
+  LastError      STRING(MAX),
+  OwnerWorkerId  STRING(128),
+  LeaseExpiresAt TIMESTAMP,
+) PRIMARY KEY (EventId);
+
+CREATE INDEX IF NOT EXISTS OutboxByDelivery ON Outbox(DeliveredAt, CreatedAt);
@@ -0,0 +1,5 @@
+-- Job event types. Workers publish an event on every job state transition,
+-- not only terminal ones; the consumer records the type it stored so feeds
+-- can filter on it. NULL is job.terminal, the only type before this.
+
+ALTER TABLE Notifications ADD COLUMN IF NOT EXISTS EventType STRING(64);
@@ -0,0 +1,5 @@
+-- Workflow cancellation. CancelWorkflow sets CancelledAt before it cancels
+-- the nodes, and no worker starts a node of a workflow that has it, so an
+-- `always` node cannot start while the cancellation is in progress.
+
+ALTER TABLE Workflows ADD COLUMN IF NOT EXISTS CancelledAt TIMESTAMP OPTIONS (allow_commit_timestamp=true);
@@ -0,0 +1,6 @@
+-- Outbox dead letters. A relay stops retrying an event whose publish keeps
+-- failing, or whose payload cannot be decoded, and sets DeadLetteredAt; the
+-- row is kept, like a delivered one, until the relay prunes it. Clearing
+-- DeadLetteredAt and Attempts queues the event again.
+
+ALTER TABLE Outbox ADD COLUMN IF NOT EXISTS DeadLetteredAt TIMESTAMP OPTIONS (allow_commit_timestamp=true);
@@ -0,0 +1,10 @@
+// Package migrations embeds the versioned Spanner migrations so jennah-migrate
+// always ships with the schema it was built against.
+package migrations
+
+import "embed"
+
+// FS holds every NNNN_description.sql file in this directory.
+//
+//go:embed *.sql
+var FS embed.FS
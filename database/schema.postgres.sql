@@ -0,0 +1,221 @@
+-- PostgreSQL schema for Jennah (DB_PROVIDER=postgres).
+--
+-- Mirrors schema.sql plus every applied Spanner migration, so both backends
+-- expose the same tables and columns. Differences from Spanner:
+--   - INTERLEAVE IN PARENT ... ON DELETE CASCADE becomes a FOREIGN KEY with
+--     ON DELETE CASCADE.
+--   - Commit timestamps are written with clock_timestamp() (statement time),
+--     not now(), which is the transaction start time.
+--   - Identifiers are left unquoted, so PostgreSQL stores them lower-case;
+--     queries use the same CamelCase spelling as the Spanner code.
+
+CREATE TABLE IF NOT EXISTS Tenants (
+  TenantId      VARCHAR(36)  NOT NULL PRIMARY KEY,
+  UserEmail     VARCHAR(255) NOT NULL,
+  OAuthProvider VARCHAR(50)  NOT NULL,
+  OAuthUserId   VARCHAR(255) NOT NULL,
+  CreatedAt     TIMESTAMPTZ  NOT NULL,
+  UpdatedAt     TIMESTAMPTZ  NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS TenantsByOAuth ON Tenants(OAuthProvider, OAuthUserId);
+
+CREATE TABLE IF NOT EXISTS Jobs (
+  TenantId    VARCHAR(36)   NOT NULL REFERENCES Tenants(TenantId) ON DELETE CASCADE,
+  JobId       VARCHAR(36)   NOT NULL,
+  Status      VARCHAR(50)   NOT NULL,
+  ImageUri    VARCHAR(1024),
+  Commands    TEXT[],
+  CreatedAt   TIMESTAMPTZ   NOT NULL,
+  UpdatedAt   TIMESTAMPTZ   NOT NULL,
+  -- Job Lifecycle Timestamps
+  ScheduledAt TIMESTAMPTZ,
+  StartedAt   TIMESTAMPTZ,
+  CompletedAt TIMESTAMPTZ,
+  -- Retry and Error Handling
+  RetryCount   BIGINT NOT NULL DEFAULT 0,
+  MaxRetries   BIGINT NOT NULL DEFAULT 3,
+  ErrorMessage TEXT,
+  -- Cloud provider integration
+  GcpBatchJobPath   VARCHAR(1024),
+  GcpBatchTaskGroup VARCHAR(1024),
+  EnvVarsJson       TEXT,
+  -- Advanced configuration
+  Name                  VARCHAR(255),
+  ResourceProfile       VARCHAR(50),
+  MachineType           VARCHAR(255),
+  BootDiskSizeGb        BIGINT,
+  UseSpotVms            BOOLEAN,
+  ServiceAccount        VARCHAR(1024),
+  ServiceTier           VARCHAR(20),
+  AssignedService       VARCHAR(50),
+  MemoryMib             BIGINT,
+  CpuMillis             BIGINT,
+  MaxRunDurationSeconds BIGINT,
+  -- Worker lease ownership for failover
+  OwnerWorkerId     VARCHAR(128),
+  PreferredWorkerId VARCHAR(128),
+  LeaseExpiresAt    TIMESTAMPTZ,
+  LastHeartbeatAt   TIMESTAMPTZ,
+  -- Automatic retry policy: ON_FAILURE | ON_PREEMPTION | NEVER
+  RetryPolicy       VARCHAR(20),
+  -- Workflow membership: node ID and depends_on edges as JSON
+  WorkflowId        VARCHAR(36),
+  WorkflowNodeId    VARCHAR(128),
+  DependsOnJson     TEXT,
+  -- Idempotent SubmitJob: client key, SHA-256 of the request, replayed response
+  IdempotencyKey     VARCHAR(255),
+  RequestHash        VARCHAR(64),
+  SubmitResponseJson TEXT,
+  -- User labels as a JSON object, matched by label selectors
+  LabelsJson         TEXT,
+  PRIMARY KEY (TenantId, JobId)
+);
+
+CREATE INDEX IF NOT EXISTS JobsByStatus ON Jobs(TenantId, Status, CreatedAt DESC);
+CREATE INDEX IF NOT EXISTS IdxJobsByName ON Jobs(TenantId, Name);
+CREATE INDEX IF NOT EXISTS JobsByWorkflow ON Jobs(TenantId, WorkflowId, WorkflowNodeId);
+CREATE INDEX IF NOT EXISTS JobsByCreatedAt ON Jobs(TenantId, CreatedAt DESC, JobId DESC);
+CREATE INDEX IF NOT EXISTS JobsByAssignedService ON Jobs(TenantId, AssignedService, CreatedAt DESC, JobId DESC);
+CREATE UNIQUE INDEX IF NOT EXISTS JobsByIdempotencyKey ON Jobs(TenantId, IdempotencyKey)
+  WHERE IdempotencyKey IS NOT NULL;
+CREATE INDEX IF NOT EXISTS JobsByLabels ON Jobs USING GIN ((LabelsJson::jsonb));
+
+CREATE TABLE IF NOT EXISTS Workflows (
+  TenantId    VARCHAR(36)  NOT NULL REFERENCES Tenants(TenantId) ON DELETE CASCADE,
+  WorkflowId  VARCHAR(36)  NOT NULL,
+  Name        VARCHAR(255),
+  CreatedAt   TIMESTAMPTZ  NOT NULL,
+  UpdatedAt   TIMESTAMPTZ  NOT NULL,
+  CancelledAt TIMESTAMPTZ,             -- NULL: not cancelled
+  PRIMARY KEY (TenantId, WorkflowId)
+);
+
+CREATE TABLE IF NOT EXISTS JobStateTransitions (
+  TenantId       VARCHAR(36) NOT NULL,
+  JobId          VARCHAR(36) NOT NULL,
+  TransitionId   VARCHAR(36) NOT NULL,
+  FromStatus     VARCHAR(50),
+  ToStatus       VARCHAR(50) NOT NULL,
+  TransitionedAt TIMESTAMPTZ NOT NULL,
+  Reason         TEXT,
+  WorkerId       VARCHAR(128),
+  ProviderDetail TEXT,
+  PRIMARY KEY (TenantId, JobId, TransitionId),
+  FOREIGN KEY (TenantId, JobId) REFERENCES Jobs(TenantId, JobId) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS TransitionsByJob ON JobStateTransitions(TenantId, JobId, TransitionedAt DESC);
+
+CREATE TABLE IF NOT EXISTS Notifications (
+  TenantId        VARCHAR(36)  NOT NULL REFERENCES Tenants(TenantId) ON DELETE CASCADE,
+  NotificationId  VARCHAR(36)  NOT NULL,
+  JobId           VARCHAR(36)  NOT NULL,
+  JobName         VARCHAR(255),
+  FinalStatus     VARCHAR(50)  NOT NULL,  -- COMPLETED | FAILED | CANCELLED
+  ServiceTier     VARCHAR(50),            -- SIMPLE | COMPLEX
+  AssignedService VARCHAR(50),            -- CLOUD_RUN_JOB | CLOUD_BATCH
+  OccurredAt      TIMESTAMPTZ  NOT NULL,
+  ErrorMessage    TEXT,
+  IsRead          BOOLEAN      NOT NULL DEFAULT FALSE,
+  CreatedAt       TIMESTAMPTZ  NOT NULL,
+  EventType       VARCHAR(64),            -- NULL: job.terminal
+  PRIMARY KEY (TenantId, NotificationId)
+);
+
+CREATE INDEX IF NOT EXISTS NotificationsByTenant ON Notifications(TenantId, IsRead, OccurredAt DESC);
+CREATE INDEX IF NOT EXISTS NotificationsByCreatedAt ON Notifications(TenantId, CreatedAt);
+
+CREATE TABLE IF NOT EXISTS Schedules (
+  TenantId        VARCHAR(36)  NOT NULL REFERENCES Tenants(TenantId) ON DELETE CASCADE,
+  ScheduleId      VARCHAR(36)  NOT NULL,
+  Name            VARCHAR(255),
+  CronExpression  VARCHAR(255) NOT NULL,
+  TimeZone        VARCHAR(64)  NOT NULL,
+  OverlapPolicy   VARCHAR(20)  NOT NULL,  -- SKIP | QUEUE | REPLACE
+  JobTemplateJson TEXT         NOT NULL,  -- protojson SubmitJobRequest
+  Paused          BOOLEAN      NOT NULL DEFAULT FALSE,
+  NextRunAt       TIMESTAMPTZ  NOT NULL,
+  LastRunAt       TIMESTAMPTZ,
+  LastJobId       VARCHAR(36),
+  OwnerWorkerId   VARCHAR(128),
+  LeaseExpiresAt  TIMESTAMPTZ,
+  CreatedAt       TIMESTAMPTZ  NOT NULL,
+  UpdatedAt       TIMESTAMPTZ  NOT NULL,
+  PRIMARY KEY (TenantId, ScheduleId)
+);
+
+CREATE INDEX IF NOT EXISTS SchedulesByNextRunAt ON Schedules(Paused, NextRunAt);
+
+CREATE TABLE IF NOT EXISTS ApiKeys (
+  TenantId  VARCHAR(36)  NOT NULL REFERENCES Tenants(TenantId) ON DELETE CASCADE,
+  ApiKeyId  VARCHAR(36)  NOT NULL,
+  Name      VARCHAR(255),
+  KeyPrefix VARCHAR(16)  NOT NULL,
+  KeyHash   VARCHAR(64)  NOT NULL,  -- hex SHA-256 of the key
+  Scopes    TEXT[]       NOT NULL,  -- submit | read | cancel | admin
+  ExpiresAt TIMESTAMPTZ,
+  RevokedAt TIMESTAMPTZ,
+  CreatedAt TIMESTAMPTZ  NOT NULL,
+  PRIMARY KEY (TenantId, ApiKeyId)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ApiKeysByHash ON ApiKeys(KeyHash);
+
+CREATE TABLE IF NOT EXISTS Organizations (
+  TenantId  VARCHAR(36)  NOT NULL PRIMARY KEY REFERENCES Tenants(TenantId) ON DELETE CASCADE,
+  Name      VARCHAR(255) NOT NULL,
+  CreatedBy VARCHAR(255) NOT NULL,  -- email of the creator
+  CreatedAt TIMESTAMPTZ  NOT NULL,
+  UpdatedAt TIMESTAMPTZ  NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS OrganizationMembers (
+  TenantId  VARCHAR(36)  NOT NULL REFERENCES Organizations(TenantId) ON DELETE CASCADE,
+  Email     VARCHAR(255) NOT NULL,  -- lower-case verified email
+  Role      VARCHAR(20)  NOT NULL,  -- viewer | submitter | admin
+  AddedBy   VARCHAR(255),
+  CreatedAt TIMESTAMPTZ  NOT NULL,
+  UpdatedAt TIMESTAMPTZ  NOT NULL,
+  PRIMARY KEY (TenantId, Email)
+);
+
+CREATE INDEX IF NOT EXISTS OrganizationMembersByEmail ON OrganizationMembers(Email);
+
+CREATE TABLE IF NOT EXISTS TenantQuotas (
+  TenantId            VARCHAR(36)  NOT NULL PRIMARY KEY REFERENCES Tenants(TenantId) ON DELETE CASCADE,
+  MaxConcurrentJobs   BIGINT,       -- NULL: gateway default; 0: unlimited
+  MaxCpuMillis        BIGINT,
+  MaxMemoryMib        BIGINT,
+  MaxJobsPerDay       BIGINT,
+  AllowedMachineTypes TEXT[],
+  AllowSpotVms        BOOLEAN,
+  QueueWhenBusy       BOOLEAN,
+  UpdatedAt           TIMESTAMPTZ  NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS Workers (
+  WorkerId        VARCHAR(128) NOT NULL PRIMARY KEY,
+  Address         VARCHAR(256) NOT NULL,  -- host:port the gateway calls
+  Capacity        BIGINT       NOT NULL,  -- 0: unlimited
+  ActiveJobs      BIGINT       NOT NULL,
+  StartedAt       TIMESTAMPTZ  NOT NULL,
+  LastHeartbeatAt TIMESTAMPTZ  NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS Outbox (
+  EventId        VARCHAR(36)  NOT NULL PRIMARY KEY,
+  TenantId       VARCHAR(36)  NOT NULL,
+  JobId          VARCHAR(36)  NOT NULL,
+  EventType      VARCHAR(64)  NOT NULL,
+  Payload        TEXT         NOT NULL,  -- JSON event as published
+  CreatedAt      TIMESTAMPTZ  NOT NULL,
+  DeliveredAt    TIMESTAMPTZ,            -- NULL: not yet published
+  Attempts       BIGINT       NOT NULL,
+  LastError      TEXT,
+  OwnerWorkerId  VARCHAR(128),           -- relay holding the lease
+  LeaseExpiresAt TIMESTAMPTZ,
+  DeadLetteredAt TIMESTAMPTZ             -- NULL: still retried
+);
+
+CREATE INDEX IF NOT EXISTS OutboxByDelivery ON Outbox(DeliveredAt, CreatedAt);
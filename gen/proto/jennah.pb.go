@@ -215,7 +215,24 @@ type SubmitJobRequest struct {
 	// Custom service account email (optional).
 	ServiceAccount string `protobuf:"bytes,10,opt,name=service_account,json=serviceAccount,proto3" json:"service_account,omitempty"`
 	// Commands to execute in the container.
-	Commands      []string `protobuf:"bytes,11,rep,name=commands,proto3" json:"commands,omitempty"`
+	Commands []string `protobuf:"bytes,11,rep,name=commands,proto3" json:"commands,omitempty"`
+	// Automatic resubmissions after a FAILED attempt. Unset uses the default
+	// of 3; 0 disables retries.
+	MaxRetries *int64 `protobuf:"varint,12,opt,name=max_retries,json=maxRetries,proto3,oneof" json:"max_retries,omitempty"`
+	// Which failures are retried: "on_failure" (default, any failure),
+	// "on_preemption" (only when a Spot VM was reclaimed) or "never".
+	RetryPolicy string `protobuf:"bytes,13,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	// Optional client-chosen key (at most 255 characters) that makes the
+	// submission safe to retry: a repeat with the same key and request within
+	// the gateway's retention window (default 24h) returns the original
+	// response without creating another job. Reusing a key for a different
+	// request fails with ALREADY_EXISTS.
+	IdempotencyKey string `protobuf:"bytes,14,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// User labels, e.g. { "team": "billing", "env": "prod" }, stored with the
+	// job and attached to the Cloud Batch or Cloud Run job. At most 64; keys
+	// start with a lowercase letter, and keys and values hold at most 63
+	// lowercase letters, digits, underscores or hyphens.
+	Labels        map[string]string `protobuf:"bytes,15,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -327,6 +344,34 @@ func (x *SubmitJobRequest) GetCommands() []string {
 	return nil
 }
 
+func (x *SubmitJobRequest) GetMaxRetries() int64 {
+	if x != nil && x.MaxRetries != nil {
+		return *x.MaxRetries
+	}
+	return 0
+}
+
+func (x *SubmitJobRequest) GetRetryPolicy() string {
+	if x != nil {
+		return x.RetryPolicy
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type SubmitJobResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	JobId          string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
@@ -415,7 +460,28 @@ func (x *SubmitJobResponse) GetRoutingReason() string {
 }
 
 type ListJobsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum jobs per page: default 100, at most 1000.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page. The filters and order_by must be
+	// the same as in the request that returned it.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Only jobs in one of these statuses, e.g. ["RUNNING", "QUEUED"].
+	Statuses []string `protobuf:"bytes,3,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	// Only jobs created at or after created_after and before created_before
+	// (RFC3339).
+	CreatedAfter  string `protobuf:"bytes,4,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore string `protobuf:"bytes,5,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	// Only jobs whose name starts with name_prefix.
+	NamePrefix string `protobuf:"bytes,6,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	// Only jobs executed by this service: CLOUD_RUN_JOB or CLOUD_BATCH.
+	AssignedService string `protobuf:"bytes,7,opt,name=assigned_service,json=assignedService,proto3" json:"assigned_service,omitempty"`
+	// "created_at desc" (default, newest first) or "created_at asc".
+	OrderBy string `protobuf:"bytes,8,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// Only jobs whose labels match, e.g. "team=billing,env=prod". Terms are
+	// comma-separated and all must hold: "key=value", "key!=value" (also
+	// matches jobs without the key), "key" (has the label) or "!key".
+	LabelSelector string `protobuf:"bytes,9,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -450,9 +516,74 @@ func (*ListJobsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_jennah_proto_rawDescGZIP(), []int{3}
 }
 
+func (x *ListJobsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListJobsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListJobsRequest) GetCreatedAfter() string {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetCreatedBefore() string {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetAssignedService() string {
+	if x != nil {
+		return x.AssignedService
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
+}
+
 type ListJobsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Jobs  []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	// Token for the next page; empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -494,6 +625,13 @@ func (x *ListJobsResponse) GetJobs() []*Job {
 	return nil
 }
 
+func (x *ListJobsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type Job struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	JobId             string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
@@ -532,8 +670,16 @@ type Job struct {
 	MemoryMib             int64 `protobuf:"varint,25,opt,name=memory_mib,json=memoryMib,proto3" json:"memory_mib,omitempty"`
 	CpuMillis             int64 `protobuf:"varint,26,opt,name=cpu_millis,json=cpuMillis,proto3" json:"cpu_millis,omitempty"`
 	MaxRunDurationSeconds int64 `protobuf:"varint,27,opt,name=max_run_duration_seconds,json=maxRunDurationSeconds,proto3" json:"max_run_duration_seconds,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+	// Retry policy: ON_FAILURE, ON_PREEMPTION or NEVER.
+	RetryPolicy string `protobuf:"bytes,28,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	// Set when the job is a workflow node.
+	WorkflowId     string                `protobuf:"bytes,29,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	WorkflowNodeId string                `protobuf:"bytes,30,opt,name=workflow_node_id,json=workflowNodeId,proto3" json:"workflow_node_id,omitempty"`
+	DependsOn      []*WorkflowDependency `protobuf:"bytes,31,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	// User labels given at submission.
+	Labels        map[string]string `protobuf:"bytes,32,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Job) Reset() {
@@ -755,6 +901,41 @@ func (x *Job) GetMaxRunDurationSeconds() int64 {
 	return 0
 }
 
+func (x *Job) GetRetryPolicy() string {
+	if x != nil {
+		return x.RetryPolicy
+	}
+	return ""
+}
+
+func (x *Job) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *Job) GetWorkflowNodeId() string {
+	if x != nil {
+		return x.WorkflowNodeId
+	}
+	return ""
+}
+
+func (x *Job) GetDependsOn() []*WorkflowDependency {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *Job) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type GetCurrentTenantRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -797,6 +978,11 @@ type GetCurrentTenantResponse struct {
 	UserEmail     string                 `protobuf:"bytes,2,opt,name=user_email,json=userEmail,proto3" json:"user_email,omitempty"`
 	OauthProvider string                 `protobuf:"bytes,3,opt,name=oauth_provider,json=oauthProvider,proto3" json:"oauth_provider,omitempty"` // "google", "github"
 	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// The active organization; unset for the caller's personal tenant.
+	Organization *Organization `protobuf:"bytes,5,opt,name=organization,proto3" json:"organization,omitempty"`
+	// The caller's role in the tenant: "viewer", "submitter" or "admin". Empty
+	// for API keys, whose scopes apply instead.
+	Role          string `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -859,6 +1045,20 @@ func (x *GetCurrentTenantResponse) GetCreatedAt() string {
 	return ""
 }
 
+func (x *GetCurrentTenantResponse) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+func (x *GetCurrentTenantResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
 type CancelJobRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
@@ -1051,27 +1251,28 @@ func (x *DeleteJobResponse) GetMessage() string {
 	return ""
 }
 
-type GetJobRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+type BulkCancelJobsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required label selector, in the ListJobs syntax.
+	LabelSelector string `protobuf:"bytes,1,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetJobRequest) Reset() {
-	*x = GetJobRequest{}
+func (x *BulkCancelJobsRequest) Reset() {
+	*x = BulkCancelJobsRequest{}
 	mi := &file_proto_jennah_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetJobRequest) String() string {
+func (x *BulkCancelJobsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobRequest) ProtoMessage() {}
+func (*BulkCancelJobsRequest) ProtoMessage() {}
 
-func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+func (x *BulkCancelJobsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_jennah_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1083,39 +1284,42 @@ func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
-func (*GetJobRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BulkCancelJobsRequest.ProtoReflect.Descriptor instead.
+func (*BulkCancelJobsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_jennah_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GetJobRequest) GetJobId() string {
+func (x *BulkCancelJobsRequest) GetLabelSelector() string {
 	if x != nil {
-		return x.JobId
+		return x.LabelSelector
 	}
 	return ""
 }
 
-type GetJobResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+type BulkCancelJobsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Jobs that were cancelled.
+	JobIds []string `protobuf:"bytes,1,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty"`
+	// Error message per job that could not be cancelled.
+	Failures      map[string]string `protobuf:"bytes,2,rep,name=failures,proto3" json:"failures,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetJobResponse) Reset() {
-	*x = GetJobResponse{}
+func (x *BulkCancelJobsResponse) Reset() {
+	*x = BulkCancelJobsResponse{}
 	mi := &file_proto_jennah_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetJobResponse) String() string {
+func (x *BulkCancelJobsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobResponse) ProtoMessage() {}
+func (*BulkCancelJobsResponse) ProtoMessage() {}
 
-func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
+func (x *BulkCancelJobsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_jennah_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1127,51 +1331,47 @@ func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
-func (*GetJobResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BulkCancelJobsResponse.ProtoReflect.Descriptor instead.
+func (*BulkCancelJobsResponse) Descriptor() ([]byte, []int) {
 	return file_proto_jennah_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GetJobResponse) GetJob() *Job {
+func (x *BulkCancelJobsResponse) GetJobIds() []string {
 	if x != nil {
-		return x.Job
+		return x.JobIds
 	}
 	return nil
 }
 
-// A single in-app notification produced from a job.terminal Pub/Sub event.
-type Notification struct {
+func (x *BulkCancelJobsResponse) GetFailures() map[string]string {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type BulkDeleteJobsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Stable event ID from the Pub/Sub payload — used for deduplication.
-	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	JobId   string `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	JobName string `protobuf:"bytes,3,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
-	// final_status: COMPLETED | FAILED | CANCELLED
-	FinalStatus     string `protobuf:"bytes,4,opt,name=final_status,json=finalStatus,proto3" json:"final_status,omitempty"`
-	ServiceTier     string `protobuf:"bytes,5,opt,name=service_tier,json=serviceTier,proto3" json:"service_tier,omitempty"`             // SIMPLE | COMPLEX
-	AssignedService string `protobuf:"bytes,6,opt,name=assigned_service,json=assignedService,proto3" json:"assigned_service,omitempty"` // CLOUD_RUN_JOB | CLOUD_BATCH
-	// Unix timestamp (seconds) of when the terminal event occurred.
-	OccurredAt    int64  `protobuf:"varint,7,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
-	ErrorMessage  string `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	IsRead        bool   `protobuf:"varint,9,opt,name=is_read,json=isRead,proto3" json:"is_read,omitempty"`
+	// Required label selector, in the ListJobs syntax.
+	LabelSelector string `protobuf:"bytes,1,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Notification) Reset() {
-	*x = Notification{}
+func (x *BulkDeleteJobsRequest) Reset() {
+	*x = BulkDeleteJobsRequest{}
 	mi := &file_proto_jennah_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Notification) String() string {
+func (x *BulkDeleteJobsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Notification) ProtoMessage() {}
+func (*BulkDeleteJobsRequest) ProtoMessage() {}
 
-func (x *Notification) ProtoReflect() protoreflect.Message {
+func (x *BulkDeleteJobsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_jennah_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1183,96 +1383,42 @@ func (x *Notification) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
-func (*Notification) Descriptor() ([]byte, []int) {
+// Deprecated: Use BulkDeleteJobsRequest.ProtoReflect.Descriptor instead.
+func (*BulkDeleteJobsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_jennah_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *Notification) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *Notification) GetJobId() string {
-	if x != nil {
-		return x.JobId
-	}
-	return ""
-}
-
-func (x *Notification) GetJobName() string {
-	if x != nil {
-		return x.JobName
-	}
-	return ""
-}
-
-func (x *Notification) GetFinalStatus() string {
-	if x != nil {
-		return x.FinalStatus
-	}
-	return ""
-}
-
-func (x *Notification) GetServiceTier() string {
-	if x != nil {
-		return x.ServiceTier
-	}
-	return ""
-}
-
-func (x *Notification) GetAssignedService() string {
-	if x != nil {
-		return x.AssignedService
-	}
-	return ""
-}
-
-func (x *Notification) GetOccurredAt() int64 {
-	if x != nil {
-		return x.OccurredAt
-	}
-	return 0
-}
-
-func (x *Notification) GetErrorMessage() string {
+func (x *BulkDeleteJobsRequest) GetLabelSelector() string {
 	if x != nil {
-		return x.ErrorMessage
+		return x.LabelSelector
 	}
 	return ""
 }
 
-func (x *Notification) GetIsRead() bool {
-	if x != nil {
-		return x.IsRead
-	}
-	return false
-}
-
-type ListNotificationsRequest struct {
+type BulkDeleteJobsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Maximum number of notifications to return (default 20).
-	Limit         int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Jobs that were deleted.
+	JobIds []string `protobuf:"bytes,1,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty"`
+	// Error message per job that could not be deleted.
+	Failures      map[string]string `protobuf:"bytes,2,rep,name=failures,proto3" json:"failures,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNotificationsRequest) Reset() {
-	*x = ListNotificationsRequest{}
+func (x *BulkDeleteJobsResponse) Reset() {
+	*x = BulkDeleteJobsResponse{}
 	mi := &file_proto_jennah_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNotificationsRequest) String() string {
+func (x *BulkDeleteJobsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNotificationsRequest) ProtoMessage() {}
+func (*BulkDeleteJobsResponse) ProtoMessage() {}
 
-func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+func (x *BulkDeleteJobsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_jennah_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1284,40 +1430,46 @@ func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
-func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BulkDeleteJobsResponse.ProtoReflect.Descriptor instead.
+func (*BulkDeleteJobsResponse) Descriptor() ([]byte, []int) {
 	return file_proto_jennah_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ListNotificationsRequest) GetLimit() int32 {
+func (x *BulkDeleteJobsResponse) GetJobIds() []string {
 	if x != nil {
-		return x.Limit
+		return x.JobIds
 	}
-	return 0
+	return nil
 }
 
-type ListNotificationsResponse struct {
+func (x *BulkDeleteJobsResponse) GetFailures() map[string]string {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type GetJobRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
-	UnreadCount   int32                  `protobuf:"varint,2,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNotificationsResponse) Reset() {
-	*x = ListNotificationsResponse{}
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
 	mi := &file_proto_jennah_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNotificationsResponse) String() string {
+func (x *GetJobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNotificationsResponse) ProtoMessage() {}
+func (*GetJobRequest) ProtoMessage() {}
 
-func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_jennah_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1329,47 +1481,2871 @@ func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
-func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
 	return file_proto_jennah_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *ListNotificationsResponse) GetNotifications() []*Notification {
+func (x *GetJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type GetJobResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Job   *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	// The job's workflow with all of its nodes, when the job is a workflow node.
+	Workflow      *Workflow `protobuf:"bytes,2,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobResponse) Reset() {
+	*x = GetJobResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobResponse) ProtoMessage() {}
+
+func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
+func (*GetJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *GetJobResponse) GetWorkflow() *Workflow {
+	if x != nil {
+		return x.Workflow
+	}
+	return nil
+}
+
+type GetJobTimelineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobTimelineRequest) Reset() {
+	*x = GetJobTimelineRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobTimelineRequest) ProtoMessage() {}
+
+func (x *GetJobTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobTimelineRequest.ProtoReflect.Descriptor instead.
+func (*GetJobTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetJobTimelineRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// One state the job entered. The first entry is the status the job was
+// created in.
+type JobTimelineEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty for the first entry.
+	FromStatus string `protobuf:"bytes,1,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	ToStatus   string `protobuf:"bytes,2,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	// RFC3339 timestamp.
+	TransitionedAt string `protobuf:"bytes,3,opt,name=transitioned_at,json=transitionedAt,proto3" json:"transitioned_at,omitempty"`
+	// Time spent in to_status: until the next entry, or until now while the
+	// job is still in it. Zero once the job has reached a terminal state.
+	DurationSeconds int64 `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	// Worker that observed the change; empty for changes recorded before
+	// workers reported it.
+	WorkerId string `protobuf:"bytes,5,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	Reason   string `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	// What the provider reported with the change, e.g. the latest Cloud Batch
+	// status event.
+	ProviderDetail string `protobuf:"bytes,7,opt,name=provider_detail,json=providerDetail,proto3" json:"provider_detail,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *JobTimelineEntry) Reset() {
+	*x = JobTimelineEntry{}
+	mi := &file_proto_jennah_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobTimelineEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobTimelineEntry) ProtoMessage() {}
+
+func (x *JobTimelineEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobTimelineEntry.ProtoReflect.Descriptor instead.
+func (*JobTimelineEntry) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *JobTimelineEntry) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *JobTimelineEntry) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *JobTimelineEntry) GetTransitionedAt() string {
+	if x != nil {
+		return x.TransitionedAt
+	}
+	return ""
+}
+
+func (x *JobTimelineEntry) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *JobTimelineEntry) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *JobTimelineEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *JobTimelineEntry) GetProviderDetail() string {
+	if x != nil {
+		return x.ProviderDetail
+	}
+	return ""
+}
+
+type GetJobTimelineResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	JobId string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Current status of the job.
+	Status        string              `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Entries       []*JobTimelineEntry `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobTimelineResponse) Reset() {
+	*x = GetJobTimelineResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobTimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobTimelineResponse) ProtoMessage() {}
+
+func (x *GetJobTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobTimelineResponse.ProtoReflect.Descriptor instead.
+func (*GetJobTimelineResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetJobTimelineResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *GetJobTimelineResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetJobTimelineResponse) GetEntries() []*JobTimelineEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// A single in-app notification produced from a job.terminal Pub/Sub event.
+type Notification struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Stable event ID from the Pub/Sub payload — used for deduplication.
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	JobId   string `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	JobName string `protobuf:"bytes,3,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	// final_status: COMPLETED | FAILED | CANCELLED
+	FinalStatus     string `protobuf:"bytes,4,opt,name=final_status,json=finalStatus,proto3" json:"final_status,omitempty"`
+	ServiceTier     string `protobuf:"bytes,5,opt,name=service_tier,json=serviceTier,proto3" json:"service_tier,omitempty"`             // SIMPLE | COMPLEX
+	AssignedService string `protobuf:"bytes,6,opt,name=assigned_service,json=assignedService,proto3" json:"assigned_service,omitempty"` // CLOUD_RUN_JOB | CLOUD_BATCH
+	// Unix timestamp (seconds) of when the terminal event occurred.
+	OccurredAt    int64  `protobuf:"varint,7,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	ErrorMessage  string `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	IsRead        bool   `protobuf:"varint,9,opt,name=is_read,json=isRead,proto3" json:"is_read,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_proto_jennah_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notification) ProtoMessage() {}
+
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Notification) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Notification) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *Notification) GetJobName() string {
+	if x != nil {
+		return x.JobName
+	}
+	return ""
+}
+
+func (x *Notification) GetFinalStatus() string {
+	if x != nil {
+		return x.FinalStatus
+	}
+	return ""
+}
+
+func (x *Notification) GetServiceTier() string {
+	if x != nil {
+		return x.ServiceTier
+	}
+	return ""
+}
+
+func (x *Notification) GetAssignedService() string {
+	if x != nil {
+		return x.AssignedService
+	}
+	return ""
+}
+
+func (x *Notification) GetOccurredAt() int64 {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return 0
+}
+
+func (x *Notification) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *Notification) GetIsRead() bool {
+	if x != nil {
+		return x.IsRead
+	}
+	return false
+}
+
+type ListNotificationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of notifications to return (default 20).
+	Limit         int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsRequest) ProtoMessage() {}
+
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListNotificationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListNotificationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	UnreadCount   int32                  `protobuf:"varint,2,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsResponse) ProtoMessage() {}
+
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListNotificationsResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *ListNotificationsResponse) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+type AckNotificationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AckNotificationRequest) Reset() {
+	*x = AckNotificationRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckNotificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckNotificationRequest) ProtoMessage() {}
+
+func (x *AckNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckNotificationRequest.ProtoReflect.Descriptor instead.
+func (*AckNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AckNotificationRequest) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+type AckNotificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckNotificationResponse) Reset() {
+	*x = AckNotificationResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckNotificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckNotificationResponse) ProtoMessage() {}
+
+func (x *AckNotificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckNotificationResponse.ProtoReflect.Descriptor instead.
+func (*AckNotificationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AckNotificationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type Schedule struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	TenantId   string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Name       string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Five-field cron expression ("*/15 * * * *") or macro ("@daily").
+	CronExpression string `protobuf:"bytes,4,opt,name=cron_expression,json=cronExpression,proto3" json:"cron_expression,omitempty"`
+	// IANA time zone the expression is evaluated in, e.g. "Asia/Tokyo".
+	Timezone string `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// What a fire does while the previous job is still active: SKIP, QUEUE or REPLACE.
+	OverlapPolicy string `protobuf:"bytes,6,opt,name=overlap_policy,json=overlapPolicy,proto3" json:"overlap_policy,omitempty"`
+	Paused        bool   `protobuf:"varint,7,opt,name=paused,proto3" json:"paused,omitempty"`
+	// Job submitted on every fire. job_id is ignored; each fire gets its own.
+	JobTemplate   *SubmitJobRequest `protobuf:"bytes,8,opt,name=job_template,json=jobTemplate,proto3" json:"job_template,omitempty"`
+	NextRunAt     string            `protobuf:"bytes,9,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	LastRunAt     string            `protobuf:"bytes,10,opt,name=last_run_at,json=lastRunAt,proto3" json:"last_run_at,omitempty"`
+	LastJobId     string            `protobuf:"bytes,11,opt,name=last_job_id,json=lastJobId,proto3" json:"last_job_id,omitempty"`
+	CreatedAt     string            `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string            `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Schedule) Reset() {
+	*x = Schedule{}
+	mi := &file_proto_jennah_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Schedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schedule) ProtoMessage() {}
+
+func (x *Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Schedule) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *Schedule) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Schedule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Schedule) GetCronExpression() string {
+	if x != nil {
+		return x.CronExpression
+	}
+	return ""
+}
+
+func (x *Schedule) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Schedule) GetOverlapPolicy() string {
+	if x != nil {
+		return x.OverlapPolicy
+	}
+	return ""
+}
+
+func (x *Schedule) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *Schedule) GetJobTemplate() *SubmitJobRequest {
+	if x != nil {
+		return x.JobTemplate
+	}
+	return nil
+}
+
+func (x *Schedule) GetNextRunAt() string {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return ""
+}
+
+func (x *Schedule) GetLastRunAt() string {
+	if x != nil {
+		return x.LastRunAt
+	}
+	return ""
+}
+
+func (x *Schedule) GetLastJobId() string {
+	if x != nil {
+		return x.LastJobId
+	}
+	return ""
+}
+
+func (x *Schedule) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Schedule) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type CreateScheduleRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CronExpression string                 `protobuf:"bytes,2,opt,name=cron_expression,json=cronExpression,proto3" json:"cron_expression,omitempty"`
+	// IANA time zone name. Defaults to "UTC".
+	Timezone string `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// "skip" (default) drops a fire while the previous job is active, "queue"
+	// fires once it finishes, "replace" cancels it and fires.
+	OverlapPolicy string            `protobuf:"bytes,4,opt,name=overlap_policy,json=overlapPolicy,proto3" json:"overlap_policy,omitempty"`
+	JobTemplate   *SubmitJobRequest `protobuf:"bytes,5,opt,name=job_template,json=jobTemplate,proto3" json:"job_template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateScheduleRequest) Reset() {
+	*x = CreateScheduleRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScheduleRequest) ProtoMessage() {}
+
+func (x *CreateScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScheduleRequest.ProtoReflect.Descriptor instead.
+func (*CreateScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateScheduleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetCronExpression() string {
+	if x != nil {
+		return x.CronExpression
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetOverlapPolicy() string {
+	if x != nil {
+		return x.OverlapPolicy
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetJobTemplate() *SubmitJobRequest {
+	if x != nil {
+		return x.JobTemplate
+	}
+	return nil
+}
+
+type CreateScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateScheduleResponse) Reset() {
+	*x = CreateScheduleResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScheduleResponse) ProtoMessage() {}
+
+func (x *CreateScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScheduleResponse.ProtoReflect.Descriptor instead.
+func (*CreateScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateScheduleResponse) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type ListSchedulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesRequest) Reset() {
+	*x = ListSchedulesRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesRequest) ProtoMessage() {}
+
+func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{29}
+}
+
+type ListSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*Schedule            `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesResponse) Reset() {
+	*x = ListSchedulesResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesResponse) ProtoMessage() {}
+
+func (x *ListSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListSchedulesResponse) GetSchedules() []*Schedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type PauseScheduleRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	// Resume a paused schedule instead of pausing it. Missed fires are not replayed.
+	Resume        bool `protobuf:"varint,2,opt,name=resume,proto3" json:"resume,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseScheduleRequest) Reset() {
+	*x = PauseScheduleRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseScheduleRequest) ProtoMessage() {}
+
+func (x *PauseScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseScheduleRequest.ProtoReflect.Descriptor instead.
+func (*PauseScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PauseScheduleRequest) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *PauseScheduleRequest) GetResume() bool {
+	if x != nil {
+		return x.Resume
+	}
+	return false
+}
+
+type PauseScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseScheduleResponse) Reset() {
+	*x = PauseScheduleResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseScheduleResponse) ProtoMessage() {}
+
+func (x *PauseScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseScheduleResponse.ProtoReflect.Descriptor instead.
+func (*PauseScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PauseScheduleResponse) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type DeleteScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId    string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteScheduleRequest) Reset() {
+	*x = DeleteScheduleRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScheduleRequest) ProtoMessage() {}
+
+func (x *DeleteScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteScheduleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteScheduleRequest) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+type DeleteScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId    string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteScheduleResponse) Reset() {
+	*x = DeleteScheduleResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScheduleResponse) ProtoMessage() {}
+
+func (x *DeleteScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteScheduleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteScheduleResponse) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+type WorkflowDependency struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// node_id of the parent node in the same workflow.
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// When the edge is satisfied: "on_success" (default, parent COMPLETED),
+	// "on_failure" (parent FAILED) or "always" (any terminal status).
+	Condition     string `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowDependency) Reset() {
+	*x = WorkflowDependency{}
+	mi := &file_proto_jennah_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowDependency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowDependency) ProtoMessage() {}
+
+func (x *WorkflowDependency) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowDependency.ProtoReflect.Descriptor instead.
+func (*WorkflowDependency) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *WorkflowDependency) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *WorkflowDependency) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+type WorkflowNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique name of the node within the workflow, referenced by depends_on.
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// Job submitted when the node starts. job_id is ignored.
+	Job *SubmitJobRequest `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	// A node starts once every edge is satisfied. If a parent finishes in a
+	// way its edge does not accept, the node is SKIPPED.
+	DependsOn     []*WorkflowDependency `protobuf:"bytes,3,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowNode) Reset() {
+	*x = WorkflowNode{}
+	mi := &file_proto_jennah_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowNode) ProtoMessage() {}
+
+func (x *WorkflowNode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowNode.ProtoReflect.Descriptor instead.
+func (*WorkflowNode) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *WorkflowNode) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *WorkflowNode) GetJob() *SubmitJobRequest {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *WorkflowNode) GetDependsOn() []*WorkflowDependency {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+type SubmitWorkflowRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Canonical workflow ID generated by gateway.
+	WorkflowId    string          `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	Name          string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Nodes         []*WorkflowNode `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitWorkflowRequest) Reset() {
+	*x = SubmitWorkflowRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitWorkflowRequest) ProtoMessage() {}
+
+func (x *SubmitWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*SubmitWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SubmitWorkflowRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *SubmitWorkflowRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SubmitWorkflowRequest) GetNodes() []*WorkflowNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type SubmitWorkflowResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId     string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Nodes          []*Job                 `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	WorkerAssigned string                 `protobuf:"bytes,4,opt,name=worker_assigned,json=workerAssigned,proto3" json:"worker_assigned,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SubmitWorkflowResponse) Reset() {
+	*x = SubmitWorkflowResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitWorkflowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitWorkflowResponse) ProtoMessage() {}
+
+func (x *SubmitWorkflowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitWorkflowResponse.ProtoReflect.Descriptor instead.
+func (*SubmitWorkflowResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SubmitWorkflowResponse) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *SubmitWorkflowResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SubmitWorkflowResponse) GetNodes() []*Job {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *SubmitWorkflowResponse) GetWorkerAssigned() string {
+	if x != nil {
+		return x.WorkerAssigned
+	}
+	return ""
+}
+
+type Workflow struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	TenantId   string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Name       string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// RUNNING until every node is terminal, then FAILED, CANCELLED or COMPLETED.
+	Status        string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Nodes         []*Job `protobuf:"bytes,7,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Workflow) Reset() {
+	*x = Workflow{}
+	mi := &file_proto_jennah_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workflow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workflow) ProtoMessage() {}
+
+func (x *Workflow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workflow.ProtoReflect.Descriptor instead.
+func (*Workflow) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Workflow) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *Workflow) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Workflow) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Workflow) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Workflow) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Workflow) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Workflow) GetNodes() []*Job {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type GetWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkflowRequest) Reset() {
+	*x = GetWorkflowRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkflowRequest) ProtoMessage() {}
+
+func (x *GetWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*GetWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetWorkflowRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+type GetWorkflowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workflow      *Workflow              `protobuf:"bytes,1,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkflowResponse) Reset() {
+	*x = GetWorkflowResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkflowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkflowResponse) ProtoMessage() {}
+
+func (x *GetWorkflowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkflowResponse.ProtoReflect.Descriptor instead.
+func (*GetWorkflowResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetWorkflowResponse) GetWorkflow() *Workflow {
+	if x != nil {
+		return x.Workflow
+	}
+	return nil
+}
+
+type CancelWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelWorkflowRequest) Reset() {
+	*x = CancelWorkflowRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelWorkflowRequest) ProtoMessage() {}
+
+func (x *CancelWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*CancelWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CancelWorkflowRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+type CancelWorkflowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelWorkflowResponse) Reset() {
+	*x = CancelWorkflowResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelWorkflowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelWorkflowResponse) ProtoMessage() {}
+
+func (x *CancelWorkflowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelWorkflowResponse.ProtoReflect.Descriptor instead.
+func (*CancelWorkflowResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CancelWorkflowResponse) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *CancelWorkflowResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// One line of a job's container output.
+type LogEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RFC3339 timestamp the line was written at.
+	Timestamp string `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Index of the task that wrote the line (0 for single-task jobs).
+	TaskIndex     int64  `protobuf:"varint,2,opt,name=task_index,json=taskIndex,proto3" json:"task_index,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogEntry) Reset() {
+	*x = LogEntry{}
+	mi := &file_proto_jennah_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogEntry) ProtoMessage() {}
+
+func (x *LogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *LogEntry) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *LogEntry) GetTaskIndex() int64 {
+	if x != nil {
+		return x.TaskIndex
+	}
+	return 0
+}
+
+func (x *LogEntry) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetJobLogsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	JobId string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Maximum number of entries to return; defaults to 500.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page; empty to start from the beginning.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobLogsRequest) Reset() {
+	*x = GetJobLogsRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobLogsRequest) ProtoMessage() {}
+
+func (x *GetJobLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobLogsRequest.ProtoReflect.Descriptor instead.
+func (*GetJobLogsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetJobLogsRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *GetJobLogsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetJobLogsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetJobLogsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	JobId   string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Entries []*LogEntry            `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Empty once the logs written so far have all been returned.
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobLogsResponse) Reset() {
+	*x = GetJobLogsResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobLogsResponse) ProtoMessage() {}
+
+func (x *GetJobLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobLogsResponse.ProtoReflect.Descriptor instead.
+func (*GetJobLogsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetJobLogsResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *GetJobLogsResponse) GetEntries() []*LogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetJobLogsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type StreamJobLogsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	JobId string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Keep streaming new output until the job reaches a terminal state.
+	Follow        bool `protobuf:"varint,2,opt,name=follow,proto3" json:"follow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamJobLogsRequest) Reset() {
+	*x = StreamJobLogsRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamJobLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamJobLogsRequest) ProtoMessage() {}
+
+func (x *StreamJobLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamJobLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamJobLogsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *StreamJobLogsRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *StreamJobLogsRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+type StreamJobLogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LogEntry            `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamJobLogsResponse) Reset() {
+	*x = StreamJobLogsResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamJobLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamJobLogsResponse) ProtoMessage() {}
+
+func (x *StreamJobLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamJobLogsResponse.ProtoReflect.Descriptor instead.
+func (*StreamJobLogsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *StreamJobLogsResponse) GetEntries() []*LogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// A tenant credential for CI pipelines and other non-interactive callers,
+// sent as "Authorization: Bearer <key>".
+type ApiKey struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeyId string                 `protobuf:"bytes,1,opt,name=api_key_id,json=apiKeyId,proto3" json:"api_key_id,omitempty"`
+	TenantId string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Name     string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// First characters of the key, to tell keys apart.
+	KeyPrefix string `protobuf:"bytes,4,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+	// Any of "submit", "read", "cancel" and "admin".
+	Scopes []string `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Empty if the key never expires.
+	ExpiresAt string `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Empty unless the key was revoked.
+	RevokedAt     string `protobuf:"bytes,7,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	CreatedAt     string `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApiKey) Reset() {
+	*x = ApiKey{}
+	mi := &file_proto_jennah_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiKey) ProtoMessage() {}
+
+func (x *ApiKey) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiKey.ProtoReflect.Descriptor instead.
+func (*ApiKey) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ApiKey) GetApiKeyId() string {
+	if x != nil {
+		return x.ApiKeyId
+	}
+	return ""
+}
+
+func (x *ApiKey) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ApiKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApiKey) GetKeyPrefix() string {
+	if x != nil {
+		return x.KeyPrefix
+	}
+	return ""
+}
+
+func (x *ApiKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *ApiKey) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *ApiKey) GetRevokedAt() string {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return ""
+}
+
+func (x *ApiKey) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type CreateApiKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// At least one of "submit", "read", "cancel" and "admin".
+	Scopes []string `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Lifetime of the key in seconds; 0 means it never expires.
+	TtlSeconds    int64 `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiKeyRequest) Reset() {
+	*x = CreateApiKeyRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiKeyRequest) ProtoMessage() {}
+
+func (x *CreateApiKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateApiKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CreateApiKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateApiKeyRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateApiKeyRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type CreateApiKeyResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey *ApiKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// The secret key. It is not stored and cannot be retrieved again.
+	Key           string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiKeyResponse) Reset() {
+	*x = CreateApiKeyResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiKeyResponse) ProtoMessage() {}
+
+func (x *CreateApiKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateApiKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CreateApiKeyResponse) GetApiKey() *ApiKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+func (x *CreateApiKeyResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type ListApiKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiKeysRequest) Reset() {
+	*x = ListApiKeysRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiKeysRequest) ProtoMessage() {}
+
+func (x *ListApiKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListApiKeysRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{52}
+}
+
+type ListApiKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeys       []*ApiKey              `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiKeysResponse) Reset() {
+	*x = ListApiKeysResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiKeysResponse) ProtoMessage() {}
+
+func (x *ListApiKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListApiKeysResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListApiKeysResponse) GetApiKeys() []*ApiKey {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+type RevokeApiKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeyId      string                 `protobuf:"bytes,1,opt,name=api_key_id,json=apiKeyId,proto3" json:"api_key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeApiKeyRequest) Reset() {
+	*x = RevokeApiKeyRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeApiKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiKeyRequest) ProtoMessage() {}
+
+func (x *RevokeApiKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeApiKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeApiKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RevokeApiKeyRequest) GetApiKeyId() string {
+	if x != nil {
+		return x.ApiKeyId
+	}
+	return ""
+}
+
+type RevokeApiKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        *ApiKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeApiKeyResponse) Reset() {
+	*x = RevokeApiKeyResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeApiKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiKeyResponse) ProtoMessage() {}
+
+func (x *RevokeApiKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeApiKeyResponse.ProtoReflect.Descriptor instead.
+func (*RevokeApiKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RevokeApiKeyResponse) GetApiKey() *ApiKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+type Organization struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The organization's tenant ID, sent as X-Jennah-Organization.
+	OrganizationId string `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Name           string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The caller's role in the organization.
+	Role          string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	CreatedBy     string `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_proto_jennah_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *Organization) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *Organization) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organization) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Organization) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Organization) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type OrganizationMember struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Email string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// "viewer", "submitter" or "admin".
+	Role          string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	AddedBy       string `protobuf:"bytes,3,opt,name=added_by,json=addedBy,proto3" json:"added_by,omitempty"`
+	CreatedAt     string `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrganizationMember) Reset() {
+	*x = OrganizationMember{}
+	mi := &file_proto_jennah_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationMember) ProtoMessage() {}
+
+func (x *OrganizationMember) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationMember.ProtoReflect.Descriptor instead.
+func (*OrganizationMember) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *OrganizationMember) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetAddedBy() string {
+	if x != nil {
+		return x.AddedBy
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *OrganizationMember) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type CreateOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationRequest) Reset() {
+	*x = CreateOrganizationRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationRequest) ProtoMessage() {}
+
+func (x *CreateOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *CreateOrganizationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateOrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organization  *Organization          `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationResponse) Reset() {
+	*x = CreateOrganizationResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationResponse) ProtoMessage() {}
+
+func (x *CreateOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CreateOrganizationResponse) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+type ListOrganizationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsRequest) Reset() {
+	*x = ListOrganizationsRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsRequest) ProtoMessage() {}
+
+func (x *ListOrganizationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{60}
+}
+
+type ListOrganizationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organizations []*Organization        `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsResponse) Reset() {
+	*x = ListOrganizationsResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsResponse) ProtoMessage() {}
+
+func (x *ListOrganizationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListOrganizationsResponse) GetOrganizations() []*Organization {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+type ListOrganizationMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationMembersRequest) Reset() {
+	*x = ListOrganizationMembersRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationMembersRequest) ProtoMessage() {}
+
+func (x *ListOrganizationMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganizationMembersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{62}
+}
+
+type ListOrganizationMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*OrganizationMember  `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationMembersResponse) Reset() {
+	*x = ListOrganizationMembersResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationMembersResponse) ProtoMessage() {}
+
+func (x *ListOrganizationMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrganizationMembersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListOrganizationMembersResponse) GetMembers() []*OrganizationMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type AddOrganizationMemberRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Verified email of the user's Google or GitHub account.
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// "viewer", "submitter" or "admin".
+	Role          string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddOrganizationMemberRequest) Reset() {
+	*x = AddOrganizationMemberRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddOrganizationMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddOrganizationMemberRequest) ProtoMessage() {}
+
+func (x *AddOrganizationMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddOrganizationMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddOrganizationMemberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *AddOrganizationMemberRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *AddOrganizationMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type AddOrganizationMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *OrganizationMember    `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddOrganizationMemberResponse) Reset() {
+	*x = AddOrganizationMemberResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddOrganizationMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddOrganizationMemberResponse) ProtoMessage() {}
+
+func (x *AddOrganizationMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddOrganizationMemberResponse.ProtoReflect.Descriptor instead.
+func (*AddOrganizationMemberResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *AddOrganizationMemberResponse) GetMember() *OrganizationMember {
 	if x != nil {
-		return x.Notifications
+		return x.Member
 	}
 	return nil
 }
 
-func (x *ListNotificationsResponse) GetUnreadCount() int32 {
+type RemoveOrganizationMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveOrganizationMemberRequest) Reset() {
+	*x = RemoveOrganizationMemberRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveOrganizationMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveOrganizationMemberRequest) ProtoMessage() {}
+
+func (x *RemoveOrganizationMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[66]
 	if x != nil {
-		return x.UnreadCount
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-type AckNotificationRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// Deprecated: Use RemoveOrganizationMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveOrganizationMemberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *AckNotificationRequest) Reset() {
-	*x = AckNotificationRequest{}
-	mi := &file_proto_jennah_proto_msgTypes[17]
+func (x *RemoveOrganizationMemberRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type RemoveOrganizationMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveOrganizationMemberResponse) Reset() {
+	*x = RemoveOrganizationMemberResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AckNotificationRequest) String() string {
+func (x *RemoveOrganizationMemberResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AckNotificationRequest) ProtoMessage() {}
+func (*RemoveOrganizationMemberResponse) ProtoMessage() {}
 
-func (x *AckNotificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jennah_proto_msgTypes[17]
+func (x *RemoveOrganizationMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1380,40 +4356,215 @@ func (x *AckNotificationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AckNotificationRequest.ProtoReflect.Descriptor instead.
-func (*AckNotificationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_jennah_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use RemoveOrganizationMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveOrganizationMemberResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *AckNotificationRequest) GetNotificationId() string {
+func (x *RemoveOrganizationMemberResponse) GetEmail() string {
 	if x != nil {
-		return x.NotificationId
+		return x.Email
 	}
 	return ""
 }
 
-type AckNotificationResponse struct {
+// TenantQuota is a tenant's effective admission limits. 0 means unlimited.
+type TenantQuota struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	MaxConcurrentJobs int64                  `protobuf:"varint,1,opt,name=max_concurrent_jobs,json=maxConcurrentJobs,proto3" json:"max_concurrent_jobs,omitempty"`
+	// Total vCPU (milli-cores) and memory across active jobs.
+	MaxCpuMillis int64 `protobuf:"varint,2,opt,name=max_cpu_millis,json=maxCpuMillis,proto3" json:"max_cpu_millis,omitempty"`
+	MaxMemoryMib int64 `protobuf:"varint,3,opt,name=max_memory_mib,json=maxMemoryMib,proto3" json:"max_memory_mib,omitempty"`
+	// Jobs created since 00:00 UTC.
+	MaxJobsPerDay int64 `protobuf:"varint,4,opt,name=max_jobs_per_day,json=maxJobsPerDay,proto3" json:"max_jobs_per_day,omitempty"`
+	// Empty allows any machine type.
+	AllowedMachineTypes []string `protobuf:"bytes,5,rep,name=allowed_machine_types,json=allowedMachineTypes,proto3" json:"allowed_machine_types,omitempty"`
+	AllowSpotVms        bool     `protobuf:"varint,6,opt,name=allow_spot_vms,json=allowSpotVms,proto3" json:"allow_spot_vms,omitempty"`
+	// Jobs over the concurrency, vCPU or memory limits are QUEUED instead of
+	// rejected.
+	QueueWhenBusy bool `protobuf:"varint,7,opt,name=queue_when_busy,json=queueWhenBusy,proto3" json:"queue_when_busy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantQuota) Reset() {
+	*x = TenantQuota{}
+	mi := &file_proto_jennah_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantQuota) ProtoMessage() {}
+
+func (x *TenantQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantQuota.ProtoReflect.Descriptor instead.
+func (*TenantQuota) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *TenantQuota) GetMaxConcurrentJobs() int64 {
+	if x != nil {
+		return x.MaxConcurrentJobs
+	}
+	return 0
+}
+
+func (x *TenantQuota) GetMaxCpuMillis() int64 {
+	if x != nil {
+		return x.MaxCpuMillis
+	}
+	return 0
+}
+
+func (x *TenantQuota) GetMaxMemoryMib() int64 {
+	if x != nil {
+		return x.MaxMemoryMib
+	}
+	return 0
+}
+
+func (x *TenantQuota) GetMaxJobsPerDay() int64 {
+	if x != nil {
+		return x.MaxJobsPerDay
+	}
+	return 0
+}
+
+func (x *TenantQuota) GetAllowedMachineTypes() []string {
+	if x != nil {
+		return x.AllowedMachineTypes
+	}
+	return nil
+}
+
+func (x *TenantQuota) GetAllowSpotVms() bool {
+	if x != nil {
+		return x.AllowSpotVms
+	}
+	return false
+}
+
+func (x *TenantQuota) GetQueueWhenBusy() bool {
+	if x != nil {
+		return x.QueueWhenBusy
+	}
+	return false
+}
+
+// TenantUsage is what a tenant currently consumes against its quota.
+type TenantUsage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// PENDING, SCHEDULED, RUNNING and RETRYING jobs.
+	ActiveJobs    int64 `protobuf:"varint,1,opt,name=active_jobs,json=activeJobs,proto3" json:"active_jobs,omitempty"`
+	QueuedJobs    int64 `protobuf:"varint,2,opt,name=queued_jobs,json=queuedJobs,proto3" json:"queued_jobs,omitempty"`
+	CpuMillis     int64 `protobuf:"varint,3,opt,name=cpu_millis,json=cpuMillis,proto3" json:"cpu_millis,omitempty"`
+	MemoryMib     int64 `protobuf:"varint,4,opt,name=memory_mib,json=memoryMib,proto3" json:"memory_mib,omitempty"`
+	JobsToday     int64 `protobuf:"varint,5,opt,name=jobs_today,json=jobsToday,proto3" json:"jobs_today,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantUsage) Reset() {
+	*x = TenantUsage{}
+	mi := &file_proto_jennah_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantUsage) ProtoMessage() {}
+
+func (x *TenantUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantUsage.ProtoReflect.Descriptor instead.
+func (*TenantUsage) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *TenantUsage) GetActiveJobs() int64 {
+	if x != nil {
+		return x.ActiveJobs
+	}
+	return 0
+}
+
+func (x *TenantUsage) GetQueuedJobs() int64 {
+	if x != nil {
+		return x.QueuedJobs
+	}
+	return 0
+}
+
+func (x *TenantUsage) GetCpuMillis() int64 {
+	if x != nil {
+		return x.CpuMillis
+	}
+	return 0
+}
+
+func (x *TenantUsage) GetMemoryMib() int64 {
+	if x != nil {
+		return x.MemoryMib
+	}
+	return 0
+}
+
+func (x *TenantUsage) GetJobsToday() int64 {
+	if x != nil {
+		return x.JobsToday
+	}
+	return 0
+}
+
+type GetTenantQuotaRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AckNotificationResponse) Reset() {
-	*x = AckNotificationResponse{}
-	mi := &file_proto_jennah_proto_msgTypes[18]
+func (x *GetTenantQuotaRequest) Reset() {
+	*x = GetTenantQuotaRequest{}
+	mi := &file_proto_jennah_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AckNotificationResponse) String() string {
+func (x *GetTenantQuotaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AckNotificationResponse) ProtoMessage() {}
+func (*GetTenantQuotaRequest) ProtoMessage() {}
 
-func (x *AckNotificationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jennah_proto_msgTypes[18]
+func (x *GetTenantQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1424,14 +4575,68 @@ func (x *AckNotificationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AckNotificationResponse.ProtoReflect.Descriptor instead.
-func (*AckNotificationResponse) Descriptor() ([]byte, []int) {
-	return file_proto_jennah_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use GetTenantQuotaRequest.ProtoReflect.Descriptor instead.
+func (*GetTenantQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *AckNotificationResponse) GetSuccess() bool {
+type GetTenantQuotaResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Quota *TenantQuota           `protobuf:"bytes,1,opt,name=quota,proto3" json:"quota,omitempty"`
+	Usage *TenantUsage           `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`
+	// False when the gateway does not enforce quotas.
+	Enforced      bool `protobuf:"varint,3,opt,name=enforced,proto3" json:"enforced,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantQuotaResponse) Reset() {
+	*x = GetTenantQuotaResponse{}
+	mi := &file_proto_jennah_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantQuotaResponse) ProtoMessage() {}
+
+func (x *GetTenantQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jennah_proto_msgTypes[71]
 	if x != nil {
-		return x.Success
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantQuotaResponse.ProtoReflect.Descriptor instead.
+func (*GetTenantQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jennah_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetTenantQuotaResponse) GetQuota() *TenantQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *GetTenantQuotaResponse) GetUsage() *TenantUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *GetTenantQuotaResponse) GetEnforced() bool {
+	if x != nil {
+		return x.Enforced
 	}
 	return false
 }
@@ -1446,7 +4651,7 @@ const file_proto_jennah_proto_rawDesc = "" +
 	"cpu_millis\x18\x01 \x01(\x03R\tcpuMillis\x12\x1d\n" +
 	"\n" +
 	"memory_mib\x18\x02 \x01(\x03R\tmemoryMib\x127\n" +
-	"\x18max_run_duration_seconds\x18\x03 \x01(\x03R\x15maxRunDurationSeconds\"\x85\x04\n" +
+	"\x18max_run_duration_seconds\x18\x03 \x01(\x03R\x15maxRunDurationSeconds\"\x83\x06\n" +
 	"\x10SubmitJobRequest\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x1b\n" +
 	"\timage_uri\x18\x02 \x01(\tR\bimageUri\x12C\n" +
@@ -1460,20 +4665,41 @@ const file_proto_jennah_proto_rawDesc = "" +
 	"useSpotVms\x12'\n" +
 	"\x0fservice_account\x18\n" +
 	" \x01(\tR\x0eserviceAccount\x12\x1a\n" +
-	"\bcommands\x18\v \x03(\tR\bcommands\x1a:\n" +
+	"\bcommands\x18\v \x03(\tR\bcommands\x12$\n" +
+	"\vmax_retries\x18\f \x01(\x03H\x00R\n" +
+	"maxRetries\x88\x01\x01\x12!\n" +
+	"\fretry_policy\x18\r \x01(\tR\vretryPolicy\x12'\n" +
+	"\x0fidempotency_key\x18\x0e \x01(\tR\x0eidempotencyKey\x12?\n" +
+	"\x06labels\x18\x0f \x03(\v2'.jennah.v1.SubmitJobRequest.LabelsEntryR\x06labels\x1a:\n" +
 	"\fEnvVarsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe8\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
+	"\f_max_retries\"\xe8\x01\n" +
 	"\x11SubmitJobResponse\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12'\n" +
 	"\x0fworker_assigned\x18\x03 \x01(\tR\x0eworkerAssigned\x12)\n" +
 	"\x10complexity_level\x18\x04 \x01(\tR\x0fcomplexityLevel\x12)\n" +
 	"\x10assigned_service\x18\x05 \x01(\tR\x0fassignedService\x12%\n" +
-	"\x0erouting_reason\x18\x06 \x01(\tR\rroutingReason\"\x11\n" +
-	"\x0fListJobsRequest\"6\n" +
+	"\x0erouting_reason\x18\x06 \x01(\tR\rroutingReason\"\xc3\x02\n" +
+	"\x0fListJobsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x1a\n" +
+	"\bstatuses\x18\x03 \x03(\tR\bstatuses\x12#\n" +
+	"\rcreated_after\x18\x04 \x01(\tR\fcreatedAfter\x12%\n" +
+	"\x0ecreated_before\x18\x05 \x01(\tR\rcreatedBefore\x12\x1f\n" +
+	"\vname_prefix\x18\x06 \x01(\tR\n" +
+	"namePrefix\x12)\n" +
+	"\x10assigned_service\x18\a \x01(\tR\x0fassignedService\x12\x19\n" +
+	"\border_by\x18\b \x01(\tR\aorderBy\x12%\n" +
+	"\x0elabel_selector\x18\t \x01(\tR\rlabelSelector\"^\n" +
 	"\x10ListJobsResponse\x12\"\n" +
-	"\x04jobs\x18\x01 \x03(\v2\x0e.jennah.v1.JobR\x04jobs\"\xbb\a\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x0e.jennah.v1.JobR\x04jobs\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xd6\t\n" +
 	"\x03Job\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x1b\n" +
 	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x1b\n" +
@@ -1510,15 +4736,27 @@ const file_proto_jennah_proto_rawDesc = "" +
 	"memory_mib\x18\x19 \x01(\x03R\tmemoryMib\x12\x1d\n" +
 	"\n" +
 	"cpu_millis\x18\x1a \x01(\x03R\tcpuMillis\x127\n" +
-	"\x18max_run_duration_seconds\x18\x1b \x01(\x03R\x15maxRunDurationSeconds\"\x19\n" +
-	"\x17GetCurrentTenantRequest\"\x9c\x01\n" +
+	"\x18max_run_duration_seconds\x18\x1b \x01(\x03R\x15maxRunDurationSeconds\x12!\n" +
+	"\fretry_policy\x18\x1c \x01(\tR\vretryPolicy\x12\x1f\n" +
+	"\vworkflow_id\x18\x1d \x01(\tR\n" +
+	"workflowId\x12(\n" +
+	"\x10workflow_node_id\x18\x1e \x01(\tR\x0eworkflowNodeId\x12<\n" +
+	"\n" +
+	"depends_on\x18\x1f \x03(\v2\x1d.jennah.v1.WorkflowDependencyR\tdependsOn\x122\n" +
+	"\x06labels\x18  \x03(\v2\x1a.jennah.v1.Job.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x19\n" +
+	"\x17GetCurrentTenantRequest\"\xed\x01\n" +
 	"\x18GetCurrentTenantResponse\x12\x1b\n" +
 	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x1d\n" +
 	"\n" +
 	"user_email\x18\x02 \x01(\tR\tuserEmail\x12%\n" +
 	"\x0eoauth_provider\x18\x03 \x01(\tR\roauthProvider\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\x04 \x01(\tR\tcreatedAt\")\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12;\n" +
+	"\forganization\x18\x05 \x01(\v2\x17.jennah.v1.OrganizationR\forganization\x12\x12\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\")\n" +
 	"\x10CancelJobRequest\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"B\n" +
 	"\x11CancelJobResponse\x12\x15\n" +
@@ -1528,11 +4766,43 @@ const file_proto_jennah_proto_rawDesc = "" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"D\n" +
 	"\x11DeleteJobResponse\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"&\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\">\n" +
+	"\x15BulkCancelJobsRequest\x12%\n" +
+	"\x0elabel_selector\x18\x01 \x01(\tR\rlabelSelector\"\xbb\x01\n" +
+	"\x16BulkCancelJobsResponse\x12\x17\n" +
+	"\ajob_ids\x18\x01 \x03(\tR\x06jobIds\x12K\n" +
+	"\bfailures\x18\x02 \x03(\v2/.jennah.v1.BulkCancelJobsResponse.FailuresEntryR\bfailures\x1a;\n" +
+	"\rFailuresEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\">\n" +
+	"\x15BulkDeleteJobsRequest\x12%\n" +
+	"\x0elabel_selector\x18\x01 \x01(\tR\rlabelSelector\"\xbb\x01\n" +
+	"\x16BulkDeleteJobsResponse\x12\x17\n" +
+	"\ajob_ids\x18\x01 \x03(\tR\x06jobIds\x12K\n" +
+	"\bfailures\x18\x02 \x03(\v2/.jennah.v1.BulkDeleteJobsResponse.FailuresEntryR\bfailures\x1a;\n" +
+	"\rFailuresEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"&\n" +
 	"\rGetJobRequest\x12\x15\n" +
-	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"2\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"c\n" +
 	"\x0eGetJobResponse\x12 \n" +
-	"\x03job\x18\x01 \x01(\v2\x0e.jennah.v1.JobR\x03job\"\xa0\x02\n" +
+	"\x03job\x18\x01 \x01(\v2\x0e.jennah.v1.JobR\x03job\x12/\n" +
+	"\bworkflow\x18\x02 \x01(\v2\x13.jennah.v1.WorkflowR\bworkflow\".\n" +
+	"\x15GetJobTimelineRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\x82\x02\n" +
+	"\x10JobTimelineEntry\x12\x1f\n" +
+	"\vfrom_status\x18\x01 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\x02 \x01(\tR\btoStatus\x12'\n" +
+	"\x0ftransitioned_at\x18\x03 \x01(\tR\x0etransitionedAt\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x03R\x0fdurationSeconds\x12\x1b\n" +
+	"\tworker_id\x18\x05 \x01(\tR\bworkerId\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12'\n" +
+	"\x0fprovider_detail\x18\a \x01(\tR\x0eproviderDetail\"~\n" +
+	"\x16GetJobTimelineResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x125\n" +
+	"\aentries\x18\x03 \x03(\v2\x1b.jennah.v1.JobTimelineEntryR\aentries\"\xa0\x02\n" +
 	"\fNotification\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
 	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x19\n" +
@@ -1552,7 +4822,198 @@ const file_proto_jennah_proto_rawDesc = "" +
 	"\x16AckNotificationRequest\x12'\n" +
 	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\"3\n" +
 	"\x17AckNotificationResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess*\x8d\x01\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xbe\x03\n" +
+	"\bSchedule\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12'\n" +
+	"\x0fcron_expression\x18\x04 \x01(\tR\x0ecronExpression\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\x12%\n" +
+	"\x0eoverlap_policy\x18\x06 \x01(\tR\roverlapPolicy\x12\x16\n" +
+	"\x06paused\x18\a \x01(\bR\x06paused\x12>\n" +
+	"\fjob_template\x18\b \x01(\v2\x1b.jennah.v1.SubmitJobRequestR\vjobTemplate\x12\x1e\n" +
+	"\vnext_run_at\x18\t \x01(\tR\tnextRunAt\x12\x1e\n" +
+	"\vlast_run_at\x18\n" +
+	" \x01(\tR\tlastRunAt\x12\x1e\n" +
+	"\vlast_job_id\x18\v \x01(\tR\tlastJobId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\tR\tupdatedAt\"\xd7\x01\n" +
+	"\x15CreateScheduleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12'\n" +
+	"\x0fcron_expression\x18\x02 \x01(\tR\x0ecronExpression\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\x12%\n" +
+	"\x0eoverlap_policy\x18\x04 \x01(\tR\roverlapPolicy\x12>\n" +
+	"\fjob_template\x18\x05 \x01(\v2\x1b.jennah.v1.SubmitJobRequestR\vjobTemplate\"I\n" +
+	"\x16CreateScheduleResponse\x12/\n" +
+	"\bschedule\x18\x01 \x01(\v2\x13.jennah.v1.ScheduleR\bschedule\"\x16\n" +
+	"\x14ListSchedulesRequest\"J\n" +
+	"\x15ListSchedulesResponse\x121\n" +
+	"\tschedules\x18\x01 \x03(\v2\x13.jennah.v1.ScheduleR\tschedules\"O\n" +
+	"\x14PauseScheduleRequest\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\x12\x16\n" +
+	"\x06resume\x18\x02 \x01(\bR\x06resume\"H\n" +
+	"\x15PauseScheduleResponse\x12/\n" +
+	"\bschedule\x18\x01 \x01(\v2\x13.jennah.v1.ScheduleR\bschedule\"8\n" +
+	"\x15DeleteScheduleRequest\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\"9\n" +
+	"\x16DeleteScheduleResponse\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\"K\n" +
+	"\x12WorkflowDependency\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1c\n" +
+	"\tcondition\x18\x02 \x01(\tR\tcondition\"\x94\x01\n" +
+	"\fWorkflowNode\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12-\n" +
+	"\x03job\x18\x02 \x01(\v2\x1b.jennah.v1.SubmitJobRequestR\x03job\x12<\n" +
+	"\n" +
+	"depends_on\x18\x03 \x03(\v2\x1d.jennah.v1.WorkflowDependencyR\tdependsOn\"{\n" +
+	"\x15SubmitWorkflowRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12-\n" +
+	"\x05nodes\x18\x03 \x03(\v2\x17.jennah.v1.WorkflowNodeR\x05nodes\"\xa0\x01\n" +
+	"\x16SubmitWorkflowResponse\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12$\n" +
+	"\x05nodes\x18\x03 \x03(\v2\x0e.jennah.v1.JobR\x05nodes\x12'\n" +
+	"\x0fworker_assigned\x18\x04 \x01(\tR\x0eworkerAssigned\"\xd8\x01\n" +
+	"\bWorkflow\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAt\x12$\n" +
+	"\x05nodes\x18\a \x03(\v2\x0e.jennah.v1.JobR\x05nodes\"5\n" +
+	"\x12GetWorkflowRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\"F\n" +
+	"\x13GetWorkflowResponse\x12/\n" +
+	"\bworkflow\x18\x01 \x01(\v2\x13.jennah.v1.WorkflowR\bworkflow\"8\n" +
+	"\x15CancelWorkflowRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\"Q\n" +
+	"\x16CancelWorkflowResponse\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"a\n" +
+	"\bLogEntry\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\tR\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"task_index\x18\x02 \x01(\x03R\ttaskIndex\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"f\n" +
+	"\x11GetJobLogsRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x82\x01\n" +
+	"\x12GetJobLogsResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12-\n" +
+	"\aentries\x18\x02 \x03(\v2\x13.jennah.v1.LogEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"E\n" +
+	"\x14StreamJobLogsRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06follow\x18\x02 \x01(\bR\x06follow\"F\n" +
+	"\x15StreamJobLogsResponse\x12-\n" +
+	"\aentries\x18\x01 \x03(\v2\x13.jennah.v1.LogEntryR\aentries\"\xeb\x01\n" +
+	"\x06ApiKey\x12\x1c\n" +
+	"\n" +
+	"api_key_id\x18\x01 \x01(\tR\bapiKeyId\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"key_prefix\x18\x04 \x01(\tR\tkeyPrefix\x12\x16\n" +
+	"\x06scopes\x18\x05 \x03(\tR\x06scopes\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\tR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"revoked_at\x18\a \x01(\tR\trevokedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\"b\n" +
+	"\x13CreateApiKeyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes\x12\x1f\n" +
+	"\vttl_seconds\x18\x03 \x01(\x03R\n" +
+	"ttlSeconds\"T\n" +
+	"\x14CreateApiKeyResponse\x12*\n" +
+	"\aapi_key\x18\x01 \x01(\v2\x11.jennah.v1.ApiKeyR\x06apiKey\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\"\x14\n" +
+	"\x12ListApiKeysRequest\"C\n" +
+	"\x13ListApiKeysResponse\x12,\n" +
+	"\bapi_keys\x18\x01 \x03(\v2\x11.jennah.v1.ApiKeyR\aapiKeys\"3\n" +
+	"\x13RevokeApiKeyRequest\x12\x1c\n" +
+	"\n" +
+	"api_key_id\x18\x01 \x01(\tR\bapiKeyId\"B\n" +
+	"\x14RevokeApiKeyResponse\x12*\n" +
+	"\aapi_key\x18\x01 \x01(\v2\x11.jennah.v1.ApiKeyR\x06apiKey\"\x9d\x01\n" +
+	"\fOrganization\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\"\x97\x01\n" +
+	"\x12OrganizationMember\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x19\n" +
+	"\badded_by\x18\x03 \x01(\tR\aaddedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\tR\tupdatedAt\"/\n" +
+	"\x19CreateOrganizationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"Y\n" +
+	"\x1aCreateOrganizationResponse\x12;\n" +
+	"\forganization\x18\x01 \x01(\v2\x17.jennah.v1.OrganizationR\forganization\"\x1a\n" +
+	"\x18ListOrganizationsRequest\"Z\n" +
+	"\x19ListOrganizationsResponse\x12=\n" +
+	"\rorganizations\x18\x01 \x03(\v2\x17.jennah.v1.OrganizationR\rorganizations\" \n" +
+	"\x1eListOrganizationMembersRequest\"Z\n" +
+	"\x1fListOrganizationMembersResponse\x127\n" +
+	"\amembers\x18\x01 \x03(\v2\x1d.jennah.v1.OrganizationMemberR\amembers\"H\n" +
+	"\x1cAddOrganizationMemberRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"V\n" +
+	"\x1dAddOrganizationMemberResponse\x125\n" +
+	"\x06member\x18\x01 \x01(\v2\x1d.jennah.v1.OrganizationMemberR\x06member\"7\n" +
+	"\x1fRemoveOrganizationMemberRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"8\n" +
+	" RemoveOrganizationMemberResponse\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"\xb4\x02\n" +
+	"\vTenantQuota\x12.\n" +
+	"\x13max_concurrent_jobs\x18\x01 \x01(\x03R\x11maxConcurrentJobs\x12$\n" +
+	"\x0emax_cpu_millis\x18\x02 \x01(\x03R\fmaxCpuMillis\x12$\n" +
+	"\x0emax_memory_mib\x18\x03 \x01(\x03R\fmaxMemoryMib\x12'\n" +
+	"\x10max_jobs_per_day\x18\x04 \x01(\x03R\rmaxJobsPerDay\x122\n" +
+	"\x15allowed_machine_types\x18\x05 \x03(\tR\x13allowedMachineTypes\x12$\n" +
+	"\x0eallow_spot_vms\x18\x06 \x01(\bR\fallowSpotVms\x12&\n" +
+	"\x0fqueue_when_busy\x18\a \x01(\bR\rqueueWhenBusy\"\xac\x01\n" +
+	"\vTenantUsage\x12\x1f\n" +
+	"\vactive_jobs\x18\x01 \x01(\x03R\n" +
+	"activeJobs\x12\x1f\n" +
+	"\vqueued_jobs\x18\x02 \x01(\x03R\n" +
+	"queuedJobs\x12\x1d\n" +
+	"\n" +
+	"cpu_millis\x18\x03 \x01(\x03R\tcpuMillis\x12\x1d\n" +
+	"\n" +
+	"memory_mib\x18\x04 \x01(\x03R\tmemoryMib\x12\x1d\n" +
+	"\n" +
+	"jobs_today\x18\x05 \x01(\x03R\tjobsToday\"\x17\n" +
+	"\x15GetTenantQuotaRequest\"\x90\x01\n" +
+	"\x16GetTenantQuotaResponse\x12,\n" +
+	"\x05quota\x18\x01 \x01(\v2\x16.jennah.v1.TenantQuotaR\x05quota\x12,\n" +
+	"\x05usage\x18\x02 \x01(\v2\x16.jennah.v1.TenantUsageR\x05usage\x12\x1a\n" +
+	"\benforced\x18\x03 \x01(\bR\benforced*\x8d\x01\n" +
 	"\x0fComplexityLevel\x12 \n" +
 	"\x1cCOMPLEXITY_LEVEL_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17COMPLEXITY_LEVEL_SIMPLE\x10\x01\x12\x1c\n" +
@@ -1560,16 +5021,38 @@ const file_proto_jennah_proto_rawDesc = "" +
 	"\x0fAssignedService\x12 \n" +
 	"\x1cASSIGNED_SERVICE_UNSPECIFIED\x10\x00\x12\"\n" +
 	"\x1eASSIGNED_SERVICE_CLOUD_RUN_JOB\x10\x02\x12 \n" +
-	"\x1cASSIGNED_SERVICE_CLOUD_BATCH\x10\x03\"\x04\b\x01\x10\x01*\x1cASSIGNED_SERVICE_CLOUD_TASKS2\x86\x05\n" +
+	"\x1cASSIGNED_SERVICE_CLOUD_BATCH\x10\x03\"\x04\b\x01\x10\x01*\x1cASSIGNED_SERVICE_CLOUD_TASKS2\xdb\x13\n" +
 	"\x11DeploymentService\x12F\n" +
 	"\tSubmitJob\x12\x1b.jennah.v1.SubmitJobRequest\x1a\x1c.jennah.v1.SubmitJobResponse\x12C\n" +
 	"\bListJobs\x12\x1a.jennah.v1.ListJobsRequest\x1a\x1b.jennah.v1.ListJobsResponse\x12[\n" +
 	"\x10GetCurrentTenant\x12\".jennah.v1.GetCurrentTenantRequest\x1a#.jennah.v1.GetCurrentTenantResponse\x12F\n" +
 	"\tCancelJob\x12\x1b.jennah.v1.CancelJobRequest\x1a\x1c.jennah.v1.CancelJobResponse\x12F\n" +
-	"\tDeleteJob\x12\x1b.jennah.v1.DeleteJobRequest\x1a\x1c.jennah.v1.DeleteJobResponse\x12=\n" +
-	"\x06GetJob\x12\x18.jennah.v1.GetJobRequest\x1a\x19.jennah.v1.GetJobResponse\x12^\n" +
+	"\tDeleteJob\x12\x1b.jennah.v1.DeleteJobRequest\x1a\x1c.jennah.v1.DeleteJobResponse\x12U\n" +
+	"\x0eBulkCancelJobs\x12 .jennah.v1.BulkCancelJobsRequest\x1a!.jennah.v1.BulkCancelJobsResponse\x12U\n" +
+	"\x0eBulkDeleteJobs\x12 .jennah.v1.BulkDeleteJobsRequest\x1a!.jennah.v1.BulkDeleteJobsResponse\x12=\n" +
+	"\x06GetJob\x12\x18.jennah.v1.GetJobRequest\x1a\x19.jennah.v1.GetJobResponse\x12U\n" +
+	"\x0eGetJobTimeline\x12 .jennah.v1.GetJobTimelineRequest\x1a!.jennah.v1.GetJobTimelineResponse\x12^\n" +
 	"\x11ListNotifications\x12#.jennah.v1.ListNotificationsRequest\x1a$.jennah.v1.ListNotificationsResponse\x12X\n" +
-	"\x0fAckNotification\x12!.jennah.v1.AckNotificationRequest\x1a\".jennah.v1.AckNotificationResponseB2Z0github.com/alphauslabs/jennah/gen/proto;jennahv1b\x06proto3"
+	"\x0fAckNotification\x12!.jennah.v1.AckNotificationRequest\x1a\".jennah.v1.AckNotificationResponse\x12U\n" +
+	"\x0eCreateSchedule\x12 .jennah.v1.CreateScheduleRequest\x1a!.jennah.v1.CreateScheduleResponse\x12R\n" +
+	"\rListSchedules\x12\x1f.jennah.v1.ListSchedulesRequest\x1a .jennah.v1.ListSchedulesResponse\x12R\n" +
+	"\rPauseSchedule\x12\x1f.jennah.v1.PauseScheduleRequest\x1a .jennah.v1.PauseScheduleResponse\x12U\n" +
+	"\x0eDeleteSchedule\x12 .jennah.v1.DeleteScheduleRequest\x1a!.jennah.v1.DeleteScheduleResponse\x12U\n" +
+	"\x0eSubmitWorkflow\x12 .jennah.v1.SubmitWorkflowRequest\x1a!.jennah.v1.SubmitWorkflowResponse\x12L\n" +
+	"\vGetWorkflow\x12\x1d.jennah.v1.GetWorkflowRequest\x1a\x1e.jennah.v1.GetWorkflowResponse\x12U\n" +
+	"\x0eCancelWorkflow\x12 .jennah.v1.CancelWorkflowRequest\x1a!.jennah.v1.CancelWorkflowResponse\x12I\n" +
+	"\n" +
+	"GetJobLogs\x12\x1c.jennah.v1.GetJobLogsRequest\x1a\x1d.jennah.v1.GetJobLogsResponse\x12T\n" +
+	"\rStreamJobLogs\x12\x1f.jennah.v1.StreamJobLogsRequest\x1a .jennah.v1.StreamJobLogsResponse0\x01\x12O\n" +
+	"\fCreateApiKey\x12\x1e.jennah.v1.CreateApiKeyRequest\x1a\x1f.jennah.v1.CreateApiKeyResponse\x12L\n" +
+	"\vListApiKeys\x12\x1d.jennah.v1.ListApiKeysRequest\x1a\x1e.jennah.v1.ListApiKeysResponse\x12O\n" +
+	"\fRevokeApiKey\x12\x1e.jennah.v1.RevokeApiKeyRequest\x1a\x1f.jennah.v1.RevokeApiKeyResponse\x12a\n" +
+	"\x12CreateOrganization\x12$.jennah.v1.CreateOrganizationRequest\x1a%.jennah.v1.CreateOrganizationResponse\x12^\n" +
+	"\x11ListOrganizations\x12#.jennah.v1.ListOrganizationsRequest\x1a$.jennah.v1.ListOrganizationsResponse\x12p\n" +
+	"\x17ListOrganizationMembers\x12).jennah.v1.ListOrganizationMembersRequest\x1a*.jennah.v1.ListOrganizationMembersResponse\x12j\n" +
+	"\x15AddOrganizationMember\x12'.jennah.v1.AddOrganizationMemberRequest\x1a(.jennah.v1.AddOrganizationMemberResponse\x12s\n" +
+	"\x18RemoveOrganizationMember\x12*.jennah.v1.RemoveOrganizationMemberRequest\x1a+.jennah.v1.RemoveOrganizationMemberResponse\x12U\n" +
+	"\x0eGetTenantQuota\x12 .jennah.v1.GetTenantQuotaRequest\x1a!.jennah.v1.GetTenantQuotaResponseB2Z0github.com/alphauslabs/jennah/gen/proto;jennahv1b\x06proto3"
 
 var (
 	file_proto_jennah_proto_rawDescOnce sync.Once
@@ -1584,58 +5067,187 @@ func file_proto_jennah_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_jennah_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_jennah_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_proto_jennah_proto_msgTypes = make([]protoimpl.MessageInfo, 77)
 var file_proto_jennah_proto_goTypes = []any{
-	(ComplexityLevel)(0),              // 0: jennah.v1.ComplexityLevel
-	(AssignedService)(0),              // 1: jennah.v1.AssignedService
-	(*ResourceOverride)(nil),          // 2: jennah.v1.ResourceOverride
-	(*SubmitJobRequest)(nil),          // 3: jennah.v1.SubmitJobRequest
-	(*SubmitJobResponse)(nil),         // 4: jennah.v1.SubmitJobResponse
-	(*ListJobsRequest)(nil),           // 5: jennah.v1.ListJobsRequest
-	(*ListJobsResponse)(nil),          // 6: jennah.v1.ListJobsResponse
-	(*Job)(nil),                       // 7: jennah.v1.Job
-	(*GetCurrentTenantRequest)(nil),   // 8: jennah.v1.GetCurrentTenantRequest
-	(*GetCurrentTenantResponse)(nil),  // 9: jennah.v1.GetCurrentTenantResponse
-	(*CancelJobRequest)(nil),          // 10: jennah.v1.CancelJobRequest
-	(*CancelJobResponse)(nil),         // 11: jennah.v1.CancelJobResponse
-	(*DeleteJobRequest)(nil),          // 12: jennah.v1.DeleteJobRequest
-	(*DeleteJobResponse)(nil),         // 13: jennah.v1.DeleteJobResponse
-	(*GetJobRequest)(nil),             // 14: jennah.v1.GetJobRequest
-	(*GetJobResponse)(nil),            // 15: jennah.v1.GetJobResponse
-	(*Notification)(nil),              // 16: jennah.v1.Notification
-	(*ListNotificationsRequest)(nil),  // 17: jennah.v1.ListNotificationsRequest
-	(*ListNotificationsResponse)(nil), // 18: jennah.v1.ListNotificationsResponse
-	(*AckNotificationRequest)(nil),    // 19: jennah.v1.AckNotificationRequest
-	(*AckNotificationResponse)(nil),   // 20: jennah.v1.AckNotificationResponse
-	nil,                               // 21: jennah.v1.SubmitJobRequest.EnvVarsEntry
+	(ComplexityLevel)(0),                     // 0: jennah.v1.ComplexityLevel
+	(AssignedService)(0),                     // 1: jennah.v1.AssignedService
+	(*ResourceOverride)(nil),                 // 2: jennah.v1.ResourceOverride
+	(*SubmitJobRequest)(nil),                 // 3: jennah.v1.SubmitJobRequest
+	(*SubmitJobResponse)(nil),                // 4: jennah.v1.SubmitJobResponse
+	(*ListJobsRequest)(nil),                  // 5: jennah.v1.ListJobsRequest
+	(*ListJobsResponse)(nil),                 // 6: jennah.v1.ListJobsResponse
+	(*Job)(nil),                              // 7: jennah.v1.Job
+	(*GetCurrentTenantRequest)(nil),          // 8: jennah.v1.GetCurrentTenantRequest
+	(*GetCurrentTenantResponse)(nil),         // 9: jennah.v1.GetCurrentTenantResponse
+	(*CancelJobRequest)(nil),                 // 10: jennah.v1.CancelJobRequest
+	(*CancelJobResponse)(nil),                // 11: jennah.v1.CancelJobResponse
+	(*DeleteJobRequest)(nil),                 // 12: jennah.v1.DeleteJobRequest
+	(*DeleteJobResponse)(nil),                // 13: jennah.v1.DeleteJobResponse
+	(*BulkCancelJobsRequest)(nil),            // 14: jennah.v1.BulkCancelJobsRequest
+	(*BulkCancelJobsResponse)(nil),           // 15: jennah.v1.BulkCancelJobsResponse
+	(*BulkDeleteJobsRequest)(nil),            // 16: jennah.v1.BulkDeleteJobsRequest
+	(*BulkDeleteJobsResponse)(nil),           // 17: jennah.v1.BulkDeleteJobsResponse
+	(*GetJobRequest)(nil),                    // 18: jennah.v1.GetJobRequest
+	(*GetJobResponse)(nil),                   // 19: jennah.v1.GetJobResponse
+	(*GetJobTimelineRequest)(nil),            // 20: jennah.v1.GetJobTimelineRequest
+	(*JobTimelineEntry)(nil),                 // 21: jennah.v1.JobTimelineEntry
+	(*GetJobTimelineResponse)(nil),           // 22: jennah.v1.GetJobTimelineResponse
+	(*Notification)(nil),                     // 23: jennah.v1.Notification
+	(*ListNotificationsRequest)(nil),         // 24: jennah.v1.ListNotificationsRequest
+	(*ListNotificationsResponse)(nil),        // 25: jennah.v1.ListNotificationsResponse
+	(*AckNotificationRequest)(nil),           // 26: jennah.v1.AckNotificationRequest
+	(*AckNotificationResponse)(nil),          // 27: jennah.v1.AckNotificationResponse
+	(*Schedule)(nil),                         // 28: jennah.v1.Schedule
+	(*CreateScheduleRequest)(nil),            // 29: jennah.v1.CreateScheduleRequest
+	(*CreateScheduleResponse)(nil),           // 30: jennah.v1.CreateScheduleResponse
+	(*ListSchedulesRequest)(nil),             // 31: jennah.v1.ListSchedulesRequest
+	(*ListSchedulesResponse)(nil),            // 32: jennah.v1.ListSchedulesResponse
+	(*PauseScheduleRequest)(nil),             // 33: jennah.v1.PauseScheduleRequest
+	(*PauseScheduleResponse)(nil),            // 34: jennah.v1.PauseScheduleResponse
+	(*DeleteScheduleRequest)(nil),            // 35: jennah.v1.DeleteScheduleRequest
+	(*DeleteScheduleResponse)(nil),           // 36: jennah.v1.DeleteScheduleResponse
+	(*WorkflowDependency)(nil),               // 37: jennah.v1.WorkflowDependency
+	(*WorkflowNode)(nil),                     // 38: jennah.v1.WorkflowNode
+	(*SubmitWorkflowRequest)(nil),            // 39: jennah.v1.SubmitWorkflowRequest
+	(*SubmitWorkflowResponse)(nil),           // 40: jennah.v1.SubmitWorkflowResponse
+	(*Workflow)(nil),                         // 41: jennah.v1.Workflow
+	(*GetWorkflowRequest)(nil),               // 42: jennah.v1.GetWorkflowRequest
+	(*GetWorkflowResponse)(nil),              // 43: jennah.v1.GetWorkflowResponse
+	(*CancelWorkflowRequest)(nil),            // 44: jennah.v1.CancelWorkflowRequest
+	(*CancelWorkflowResponse)(nil),           // 45: jennah.v1.CancelWorkflowResponse
+	(*LogEntry)(nil),                         // 46: jennah.v1.LogEntry
+	(*GetJobLogsRequest)(nil),                // 47: jennah.v1.GetJobLogsRequest
+	(*GetJobLogsResponse)(nil),               // 48: jennah.v1.GetJobLogsResponse
+	(*StreamJobLogsRequest)(nil),             // 49: jennah.v1.StreamJobLogsRequest
+	(*StreamJobLogsResponse)(nil),            // 50: jennah.v1.StreamJobLogsResponse
+	(*ApiKey)(nil),                           // 51: jennah.v1.ApiKey
+	(*CreateApiKeyRequest)(nil),              // 52: jennah.v1.CreateApiKeyRequest
+	(*CreateApiKeyResponse)(nil),             // 53: jennah.v1.CreateApiKeyResponse
+	(*ListApiKeysRequest)(nil),               // 54: jennah.v1.ListApiKeysRequest
+	(*ListApiKeysResponse)(nil),              // 55: jennah.v1.ListApiKeysResponse
+	(*RevokeApiKeyRequest)(nil),              // 56: jennah.v1.RevokeApiKeyRequest
+	(*RevokeApiKeyResponse)(nil),             // 57: jennah.v1.RevokeApiKeyResponse
+	(*Organization)(nil),                     // 58: jennah.v1.Organization
+	(*OrganizationMember)(nil),               // 59: jennah.v1.OrganizationMember
+	(*CreateOrganizationRequest)(nil),        // 60: jennah.v1.CreateOrganizationRequest
+	(*CreateOrganizationResponse)(nil),       // 61: jennah.v1.CreateOrganizationResponse
+	(*ListOrganizationsRequest)(nil),         // 62: jennah.v1.ListOrganizationsRequest
+	(*ListOrganizationsResponse)(nil),        // 63: jennah.v1.ListOrganizationsResponse
+	(*ListOrganizationMembersRequest)(nil),   // 64: jennah.v1.ListOrganizationMembersRequest
+	(*ListOrganizationMembersResponse)(nil),  // 65: jennah.v1.ListOrganizationMembersResponse
+	(*AddOrganizationMemberRequest)(nil),     // 66: jennah.v1.AddOrganizationMemberRequest
+	(*AddOrganizationMemberResponse)(nil),    // 67: jennah.v1.AddOrganizationMemberResponse
+	(*RemoveOrganizationMemberRequest)(nil),  // 68: jennah.v1.RemoveOrganizationMemberRequest
+	(*RemoveOrganizationMemberResponse)(nil), // 69: jennah.v1.RemoveOrganizationMemberResponse
+	(*TenantQuota)(nil),                      // 70: jennah.v1.TenantQuota
+	(*TenantUsage)(nil),                      // 71: jennah.v1.TenantUsage
+	(*GetTenantQuotaRequest)(nil),            // 72: jennah.v1.GetTenantQuotaRequest
+	(*GetTenantQuotaResponse)(nil),           // 73: jennah.v1.GetTenantQuotaResponse
+	nil,                                      // 74: jennah.v1.SubmitJobRequest.EnvVarsEntry
+	nil,                                      // 75: jennah.v1.SubmitJobRequest.LabelsEntry
+	nil,                                      // 76: jennah.v1.Job.LabelsEntry
+	nil,                                      // 77: jennah.v1.BulkCancelJobsResponse.FailuresEntry
+	nil,                                      // 78: jennah.v1.BulkDeleteJobsResponse.FailuresEntry
 }
 var file_proto_jennah_proto_depIdxs = []int32{
-	21, // 0: jennah.v1.SubmitJobRequest.env_vars:type_name -> jennah.v1.SubmitJobRequest.EnvVarsEntry
+	74, // 0: jennah.v1.SubmitJobRequest.env_vars:type_name -> jennah.v1.SubmitJobRequest.EnvVarsEntry
 	2,  // 1: jennah.v1.SubmitJobRequest.resource_override:type_name -> jennah.v1.ResourceOverride
-	7,  // 2: jennah.v1.ListJobsResponse.jobs:type_name -> jennah.v1.Job
-	7,  // 3: jennah.v1.GetJobResponse.job:type_name -> jennah.v1.Job
-	16, // 4: jennah.v1.ListNotificationsResponse.notifications:type_name -> jennah.v1.Notification
-	3,  // 5: jennah.v1.DeploymentService.SubmitJob:input_type -> jennah.v1.SubmitJobRequest
-	5,  // 6: jennah.v1.DeploymentService.ListJobs:input_type -> jennah.v1.ListJobsRequest
-	8,  // 7: jennah.v1.DeploymentService.GetCurrentTenant:input_type -> jennah.v1.GetCurrentTenantRequest
-	10, // 8: jennah.v1.DeploymentService.CancelJob:input_type -> jennah.v1.CancelJobRequest
-	12, // 9: jennah.v1.DeploymentService.DeleteJob:input_type -> jennah.v1.DeleteJobRequest
-	14, // 10: jennah.v1.DeploymentService.GetJob:input_type -> jennah.v1.GetJobRequest
-	17, // 11: jennah.v1.DeploymentService.ListNotifications:input_type -> jennah.v1.ListNotificationsRequest
-	19, // 12: jennah.v1.DeploymentService.AckNotification:input_type -> jennah.v1.AckNotificationRequest
-	4,  // 13: jennah.v1.DeploymentService.SubmitJob:output_type -> jennah.v1.SubmitJobResponse
-	6,  // 14: jennah.v1.DeploymentService.ListJobs:output_type -> jennah.v1.ListJobsResponse
-	9,  // 15: jennah.v1.DeploymentService.GetCurrentTenant:output_type -> jennah.v1.GetCurrentTenantResponse
-	11, // 16: jennah.v1.DeploymentService.CancelJob:output_type -> jennah.v1.CancelJobResponse
-	13, // 17: jennah.v1.DeploymentService.DeleteJob:output_type -> jennah.v1.DeleteJobResponse
-	15, // 18: jennah.v1.DeploymentService.GetJob:output_type -> jennah.v1.GetJobResponse
-	18, // 19: jennah.v1.DeploymentService.ListNotifications:output_type -> jennah.v1.ListNotificationsResponse
-	20, // 20: jennah.v1.DeploymentService.AckNotification:output_type -> jennah.v1.AckNotificationResponse
-	13, // [13:21] is the sub-list for method output_type
-	5,  // [5:13] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	75, // 2: jennah.v1.SubmitJobRequest.labels:type_name -> jennah.v1.SubmitJobRequest.LabelsEntry
+	7,  // 3: jennah.v1.ListJobsResponse.jobs:type_name -> jennah.v1.Job
+	37, // 4: jennah.v1.Job.depends_on:type_name -> jennah.v1.WorkflowDependency
+	76, // 5: jennah.v1.Job.labels:type_name -> jennah.v1.Job.LabelsEntry
+	58, // 6: jennah.v1.GetCurrentTenantResponse.organization:type_name -> jennah.v1.Organization
+	77, // 7: jennah.v1.BulkCancelJobsResponse.failures:type_name -> jennah.v1.BulkCancelJobsResponse.FailuresEntry
+	78, // 8: jennah.v1.BulkDeleteJobsResponse.failures:type_name -> jennah.v1.BulkDeleteJobsResponse.FailuresEntry
+	7,  // 9: jennah.v1.GetJobResponse.job:type_name -> jennah.v1.Job
+	41, // 10: jennah.v1.GetJobResponse.workflow:type_name -> jennah.v1.Workflow
+	21, // 11: jennah.v1.GetJobTimelineResponse.entries:type_name -> jennah.v1.JobTimelineEntry
+	23, // 12: jennah.v1.ListNotificationsResponse.notifications:type_name -> jennah.v1.Notification
+	3,  // 13: jennah.v1.Schedule.job_template:type_name -> jennah.v1.SubmitJobRequest
+	3,  // 14: jennah.v1.CreateScheduleRequest.job_template:type_name -> jennah.v1.SubmitJobRequest
+	28, // 15: jennah.v1.CreateScheduleResponse.schedule:type_name -> jennah.v1.Schedule
+	28, // 16: jennah.v1.ListSchedulesResponse.schedules:type_name -> jennah.v1.Schedule
+	28, // 17: jennah.v1.PauseScheduleResponse.schedule:type_name -> jennah.v1.Schedule
+	3,  // 18: jennah.v1.WorkflowNode.job:type_name -> jennah.v1.SubmitJobRequest
+	37, // 19: jennah.v1.WorkflowNode.depends_on:type_name -> jennah.v1.WorkflowDependency
+	38, // 20: jennah.v1.SubmitWorkflowRequest.nodes:type_name -> jennah.v1.WorkflowNode
+	7,  // 21: jennah.v1.SubmitWorkflowResponse.nodes:type_name -> jennah.v1.Job
+	7,  // 22: jennah.v1.Workflow.nodes:type_name -> jennah.v1.Job
+	41, // 23: jennah.v1.GetWorkflowResponse.workflow:type_name -> jennah.v1.Workflow
+	46, // 24: jennah.v1.GetJobLogsResponse.entries:type_name -> jennah.v1.LogEntry
+	46, // 25: jennah.v1.StreamJobLogsResponse.entries:type_name -> jennah.v1.LogEntry
+	51, // 26: jennah.v1.CreateApiKeyResponse.api_key:type_name -> jennah.v1.ApiKey
+	51, // 27: jennah.v1.ListApiKeysResponse.api_keys:type_name -> jennah.v1.ApiKey
+	51, // 28: jennah.v1.RevokeApiKeyResponse.api_key:type_name -> jennah.v1.ApiKey
+	58, // 29: jennah.v1.CreateOrganizationResponse.organization:type_name -> jennah.v1.Organization
+	58, // 30: jennah.v1.ListOrganizationsResponse.organizations:type_name -> jennah.v1.Organization
+	59, // 31: jennah.v1.ListOrganizationMembersResponse.members:type_name -> jennah.v1.OrganizationMember
+	59, // 32: jennah.v1.AddOrganizationMemberResponse.member:type_name -> jennah.v1.OrganizationMember
+	70, // 33: jennah.v1.GetTenantQuotaResponse.quota:type_name -> jennah.v1.TenantQuota
+	71, // 34: jennah.v1.GetTenantQuotaResponse.usage:type_name -> jennah.v1.TenantUsage
+	3,  // 35: jennah.v1.DeploymentService.SubmitJob:input_type -> jennah.v1.SubmitJobRequest
+	5,  // 36: jennah.v1.DeploymentService.ListJobs:input_type -> jennah.v1.ListJobsRequest
+	8,  // 37: jennah.v1.DeploymentService.GetCurrentTenant:input_type -> jennah.v1.GetCurrentTenantRequest
+	10, // 38: jennah.v1.DeploymentService.CancelJob:input_type -> jennah.v1.CancelJobRequest
+	12, // 39: jennah.v1.DeploymentService.DeleteJob:input_type -> jennah.v1.DeleteJobRequest
+	14, // 40: jennah.v1.DeploymentService.BulkCancelJobs:input_type -> jennah.v1.BulkCancelJobsRequest
+	16, // 41: jennah.v1.DeploymentService.BulkDeleteJobs:input_type -> jennah.v1.BulkDeleteJobsRequest
+	18, // 42: jennah.v1.DeploymentService.GetJob:input_type -> jennah.v1.GetJobRequest
+	20, // 43: jennah.v1.DeploymentService.GetJobTimeline:input_type -> jennah.v1.GetJobTimelineRequest
+	24, // 44: jennah.v1.DeploymentService.ListNotifications:input_type -> jennah.v1.ListNotificationsRequest
+	26, // 45: jennah.v1.DeploymentService.AckNotification:input_type -> jennah.v1.AckNotificationRequest
+	29, // 46: jennah.v1.DeploymentService.CreateSchedule:input_type -> jennah.v1.CreateScheduleRequest
+	31, // 47: jennah.v1.DeploymentService.ListSchedules:input_type -> jennah.v1.ListSchedulesRequest
+	33, // 48: jennah.v1.DeploymentService.PauseSchedule:input_type -> jennah.v1.PauseScheduleRequest
+	35, // 49: jennah.v1.DeploymentService.DeleteSchedule:input_type -> jennah.v1.DeleteScheduleRequest
+	39, // 50: jennah.v1.DeploymentService.SubmitWorkflow:input_type -> jennah.v1.SubmitWorkflowRequest
+	42, // 51: jennah.v1.DeploymentService.GetWorkflow:input_type -> jennah.v1.GetWorkflowRequest
+	44, // 52: jennah.v1.DeploymentService.CancelWorkflow:input_type -> jennah.v1.CancelWorkflowRequest
+	47, // 53: jennah.v1.DeploymentService.GetJobLogs:input_type -> jennah.v1.GetJobLogsRequest
+	49, // 54: jennah.v1.DeploymentService.StreamJobLogs:input_type -> jennah.v1.StreamJobLogsRequest
+	52, // 55: jennah.v1.DeploymentService.CreateApiKey:input_type -> jennah.v1.CreateApiKeyRequest
+	54, // 56: jennah.v1.DeploymentService.ListApiKeys:input_type -> jennah.v1.ListApiKeysRequest
+	56, // 57: jennah.v1.DeploymentService.RevokeApiKey:input_type -> jennah.v1.RevokeApiKeyRequest
+	60, // 58: jennah.v1.DeploymentService.CreateOrganization:input_type -> jennah.v1.CreateOrganizationRequest
+	62, // 59: jennah.v1.DeploymentService.ListOrganizations:input_type -> jennah.v1.ListOrganizationsRequest
+	64, // 60: jennah.v1.DeploymentService.ListOrganizationMembers:input_type -> jennah.v1.ListOrganizationMembersRequest
+	66, // 61: jennah.v1.DeploymentService.AddOrganizationMember:input_type -> jennah.v1.AddOrganizationMemberRequest
+	68, // 62: jennah.v1.DeploymentService.RemoveOrganizationMember:input_type -> jennah.v1.RemoveOrganizationMemberRequest
+	72, // 63: jennah.v1.DeploymentService.GetTenantQuota:input_type -> jennah.v1.GetTenantQuotaRequest
+	4,  // 64: jennah.v1.DeploymentService.SubmitJob:output_type -> jennah.v1.SubmitJobResponse
+	6,  // 65: jennah.v1.DeploymentService.ListJobs:output_type -> jennah.v1.ListJobsResponse
+	9,  // 66: jennah.v1.DeploymentService.GetCurrentTenant:output_type -> jennah.v1.GetCurrentTenantResponse
+	11, // 67: jennah.v1.DeploymentService.CancelJob:output_type -> jennah.v1.CancelJobResponse
+	13, // 68: jennah.v1.DeploymentService.DeleteJob:output_type -> jennah.v1.DeleteJobResponse
+	15, // 69: jennah.v1.DeploymentService.BulkCancelJobs:output_type -> jennah.v1.BulkCancelJobsResponse
+	17, // 70: jennah.v1.DeploymentService.BulkDeleteJobs:output_type -> jennah.v1.BulkDeleteJobsResponse
+	19, // 71: jennah.v1.DeploymentService.GetJob:output_type -> jennah.v1.GetJobResponse
+	22, // 72: jennah.v1.DeploymentService.GetJobTimeline:output_type -> jennah.v1.GetJobTimelineResponse
+	25, // 73: jennah.v1.DeploymentService.ListNotifications:output_type -> jennah.v1.ListNotificationsResponse
+	27, // 74: jennah.v1.DeploymentService.AckNotification:output_type -> jennah.v1.AckNotificationResponse
+	30, // 75: jennah.v1.DeploymentService.CreateSchedule:output_type -> jennah.v1.CreateScheduleResponse
+	32, // 76: jennah.v1.DeploymentService.ListSchedules:output_type -> jennah.v1.ListSchedulesResponse
+	34, // 77: jennah.v1.DeploymentService.PauseSchedule:output_type -> jennah.v1.PauseScheduleResponse
+	36, // 78: jennah.v1.DeploymentService.DeleteSchedule:output_type -> jennah.v1.DeleteScheduleResponse
+	40, // 79: jennah.v1.DeploymentService.SubmitWorkflow:output_type -> jennah.v1.SubmitWorkflowResponse
+	43, // 80: jennah.v1.DeploymentService.GetWorkflow:output_type -> jennah.v1.GetWorkflowResponse
+	45, // 81: jennah.v1.DeploymentService.CancelWorkflow:output_type -> jennah.v1.CancelWorkflowResponse
+	48, // 82: jennah.v1.DeploymentService.GetJobLogs:output_type -> jennah.v1.GetJobLogsResponse
+	50, // 83: jennah.v1.DeploymentService.StreamJobLogs:output_type -> jennah.v1.StreamJobLogsResponse
+	53, // 84: jennah.v1.DeploymentService.CreateApiKey:output_type -> jennah.v1.CreateApiKeyResponse
+	55, // 85: jennah.v1.DeploymentService.ListApiKeys:output_type -> jennah.v1.ListApiKeysResponse
+	57, // 86: jennah.v1.DeploymentService.RevokeApiKey:output_type -> jennah.v1.RevokeApiKeyResponse
+	61, // 87: jennah.v1.DeploymentService.CreateOrganization:output_type -> jennah.v1.CreateOrganizationResponse
+	63, // 88: jennah.v1.DeploymentService.ListOrganizations:output_type -> jennah.v1.ListOrganizationsResponse
+	65, // 89: jennah.v1.DeploymentService.ListOrganizationMembers:output_type -> jennah.v1.ListOrganizationMembersResponse
+	67, // 90: jennah.v1.DeploymentService.AddOrganizationMember:output_type -> jennah.v1.AddOrganizationMemberResponse
+	69, // 91: jennah.v1.DeploymentService.RemoveOrganizationMember:output_type -> jennah.v1.RemoveOrganizationMemberResponse
+	73, // 92: jennah.v1.DeploymentService.GetTenantQuota:output_type -> jennah.v1.GetTenantQuotaResponse
+	64, // [64:93] is the sub-list for method output_type
+	35, // [35:64] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_proto_jennah_proto_init() }
@@ -1643,13 +5255,14 @@ func file_proto_jennah_proto_init() {
 	if File_proto_jennah_proto != nil {
 		return
 	}
+	file_proto_jennah_proto_msgTypes[1].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_jennah_proto_rawDesc), len(file_proto_jennah_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   20,
+			NumMessages:   77,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
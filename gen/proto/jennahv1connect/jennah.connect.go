@@ -48,15 +48,78 @@ const (
 	// DeploymentServiceDeleteJobProcedure is the fully-qualified name of the DeploymentService's
 	// DeleteJob RPC.
 	DeploymentServiceDeleteJobProcedure = "/jennah.v1.DeploymentService/DeleteJob"
+	// DeploymentServiceBulkCancelJobsProcedure is the fully-qualified name of the DeploymentService's
+	// BulkCancelJobs RPC.
+	DeploymentServiceBulkCancelJobsProcedure = "/jennah.v1.DeploymentService/BulkCancelJobs"
+	// DeploymentServiceBulkDeleteJobsProcedure is the fully-qualified name of the DeploymentService's
+	// BulkDeleteJobs RPC.
+	DeploymentServiceBulkDeleteJobsProcedure = "/jennah.v1.DeploymentService/BulkDeleteJobs"
 	// DeploymentServiceGetJobProcedure is the fully-qualified name of the DeploymentService's GetJob
 	// RPC.
 	DeploymentServiceGetJobProcedure = "/jennah.v1.DeploymentService/GetJob"
+	// DeploymentServiceGetJobTimelineProcedure is the fully-qualified name of the DeploymentService's
+	// GetJobTimeline RPC.
+	DeploymentServiceGetJobTimelineProcedure = "/jennah.v1.DeploymentService/GetJobTimeline"
 	// DeploymentServiceListNotificationsProcedure is the fully-qualified name of the
 	// DeploymentService's ListNotifications RPC.
 	DeploymentServiceListNotificationsProcedure = "/jennah.v1.DeploymentService/ListNotifications"
 	// DeploymentServiceAckNotificationProcedure is the fully-qualified name of the DeploymentService's
 	// AckNotification RPC.
 	DeploymentServiceAckNotificationProcedure = "/jennah.v1.DeploymentService/AckNotification"
+	// DeploymentServiceCreateScheduleProcedure is the fully-qualified name of the DeploymentService's
+	// CreateSchedule RPC.
+	DeploymentServiceCreateScheduleProcedure = "/jennah.v1.DeploymentService/CreateSchedule"
+	// DeploymentServiceListSchedulesProcedure is the fully-qualified name of the DeploymentService's
+	// ListSchedules RPC.
+	DeploymentServiceListSchedulesProcedure = "/jennah.v1.DeploymentService/ListSchedules"
+	// DeploymentServicePauseScheduleProcedure is the fully-qualified name of the DeploymentService's
+	// PauseSchedule RPC.
+	DeploymentServicePauseScheduleProcedure = "/jennah.v1.DeploymentService/PauseSchedule"
+	// DeploymentServiceDeleteScheduleProcedure is the fully-qualified name of the DeploymentService's
+	// DeleteSchedule RPC.
+	DeploymentServiceDeleteScheduleProcedure = "/jennah.v1.DeploymentService/DeleteSchedule"
+	// DeploymentServiceSubmitWorkflowProcedure is the fully-qualified name of the DeploymentService's
+	// SubmitWorkflow RPC.
+	DeploymentServiceSubmitWorkflowProcedure = "/jennah.v1.DeploymentService/SubmitWorkflow"
+	// DeploymentServiceGetWorkflowProcedure is the fully-qualified name of the DeploymentService's
+	// GetWorkflow RPC.
+	DeploymentServiceGetWorkflowProcedure = "/jennah.v1.DeploymentService/GetWorkflow"
+	// DeploymentServiceCancelWorkflowProcedure is the fully-qualified name of the DeploymentService's
+	// CancelWorkflow RPC.
+	DeploymentServiceCancelWorkflowProcedure = "/jennah.v1.DeploymentService/CancelWorkflow"
+	// DeploymentServiceGetJobLogsProcedure is the fully-qualified name of the DeploymentService's
+	// GetJobLogs RPC.
+	DeploymentServiceGetJobLogsProcedure = "/jennah.v1.DeploymentService/GetJobLogs"
+	// DeploymentServiceStreamJobLogsProcedure is the fully-qualified name of the DeploymentService's
+	// StreamJobLogs RPC.
+	DeploymentServiceStreamJobLogsProcedure = "/jennah.v1.DeploymentService/StreamJobLogs"
+	// DeploymentServiceCreateApiKeyProcedure is the fully-qualified name of the DeploymentService's
+	// CreateApiKey RPC.
+	DeploymentServiceCreateApiKeyProcedure = "/jennah.v1.DeploymentService/CreateApiKey"
+	// DeploymentServiceListApiKeysProcedure is the fully-qualified name of the DeploymentService's
+	// ListApiKeys RPC.
+	DeploymentServiceListApiKeysProcedure = "/jennah.v1.DeploymentService/ListApiKeys"
+	// DeploymentServiceRevokeApiKeyProcedure is the fully-qualified name of the DeploymentService's
+	// RevokeApiKey RPC.
+	DeploymentServiceRevokeApiKeyProcedure = "/jennah.v1.DeploymentService/RevokeApiKey"
+	// DeploymentServiceCreateOrganizationProcedure is the fully-qualified name of the
+	// DeploymentService's CreateOrganization RPC.
+	DeploymentServiceCreateOrganizationProcedure = "/jennah.v1.DeploymentService/CreateOrganization"
+	// DeploymentServiceListOrganizationsProcedure is the fully-qualified name of the
+	// DeploymentService's ListOrganizations RPC.
+	DeploymentServiceListOrganizationsProcedure = "/jennah.v1.DeploymentService/ListOrganizations"
+	// DeploymentServiceListOrganizationMembersProcedure is the fully-qualified name of the
+	// DeploymentService's ListOrganizationMembers RPC.
+	DeploymentServiceListOrganizationMembersProcedure = "/jennah.v1.DeploymentService/ListOrganizationMembers"
+	// DeploymentServiceAddOrganizationMemberProcedure is the fully-qualified name of the
+	// DeploymentService's AddOrganizationMember RPC.
+	DeploymentServiceAddOrganizationMemberProcedure = "/jennah.v1.DeploymentService/AddOrganizationMember"
+	// DeploymentServiceRemoveOrganizationMemberProcedure is the fully-qualified name of the
+	// DeploymentService's RemoveOrganizationMember RPC.
+	DeploymentServiceRemoveOrganizationMemberProcedure = "/jennah.v1.DeploymentService/RemoveOrganizationMember"
+	// DeploymentServiceGetTenantQuotaProcedure is the fully-qualified name of the DeploymentService's
+	// GetTenantQuota RPC.
+	DeploymentServiceGetTenantQuotaProcedure = "/jennah.v1.DeploymentService/GetTenantQuota"
 )
 
 // DeploymentServiceClient is a client for the jennah.v1.DeploymentService service.
@@ -67,16 +130,59 @@ type DeploymentServiceClient interface {
 	ListJobs(context.Context, *connect.Request[proto.ListJobsRequest]) (*connect.Response[proto.ListJobsResponse], error)
 	// Get the current tenant's information.
 	GetCurrentTenant(context.Context, *connect.Request[proto.GetCurrentTenantRequest]) (*connect.Response[proto.GetCurrentTenantResponse], error)
-	// Cancel a job (only for PENDING, SCHEDULED, or RUNNING states).
+	// Cancel a job (only for QUEUED, PENDING, SCHEDULED, or RUNNING states).
 	CancelJob(context.Context, *connect.Request[proto.CancelJobRequest]) (*connect.Response[proto.CancelJobResponse], error)
 	// Delete a job from the system.
 	DeleteJob(context.Context, *connect.Request[proto.DeleteJobRequest]) (*connect.Response[proto.DeleteJobResponse], error)
+	// Cancel every active job matching a label selector.
+	BulkCancelJobs(context.Context, *connect.Request[proto.BulkCancelJobsRequest]) (*connect.Response[proto.BulkCancelJobsResponse], error)
+	// Delete every job matching a label selector.
+	BulkDeleteJobs(context.Context, *connect.Request[proto.BulkDeleteJobsRequest]) (*connect.Response[proto.BulkDeleteJobsResponse], error)
 	// Get a single job's full details.
 	GetJob(context.Context, *connect.Request[proto.GetJobRequest]) (*connect.Response[proto.GetJobResponse], error)
+	// List a job's state changes, oldest first, with the time spent in each state.
+	GetJobTimeline(context.Context, *connect.Request[proto.GetJobTimelineRequest]) (*connect.Response[proto.GetJobTimelineResponse], error)
 	// List in-app notifications for the current tenant (saved by Pub/Sub consumer).
 	ListNotifications(context.Context, *connect.Request[proto.ListNotificationsRequest]) (*connect.Response[proto.ListNotificationsResponse], error)
 	// Mark a notification as read (ack).
 	AckNotification(context.Context, *connect.Request[proto.AckNotificationRequest]) (*connect.Response[proto.AckNotificationResponse], error)
+	// Create a cron schedule that submits a job from a template on every fire.
+	CreateSchedule(context.Context, *connect.Request[proto.CreateScheduleRequest]) (*connect.Response[proto.CreateScheduleResponse], error)
+	// List schedules for the current tenant.
+	ListSchedules(context.Context, *connect.Request[proto.ListSchedulesRequest]) (*connect.Response[proto.ListSchedulesResponse], error)
+	// Pause or resume a schedule.
+	PauseSchedule(context.Context, *connect.Request[proto.PauseScheduleRequest]) (*connect.Response[proto.PauseScheduleResponse], error)
+	// Delete a schedule. Jobs it already created are kept.
+	DeleteSchedule(context.Context, *connect.Request[proto.DeleteScheduleRequest]) (*connect.Response[proto.DeleteScheduleResponse], error)
+	// Submit a DAG of jobs; each node starts once its dependencies allow it.
+	SubmitWorkflow(context.Context, *connect.Request[proto.SubmitWorkflowRequest]) (*connect.Response[proto.SubmitWorkflowResponse], error)
+	// Get a workflow's status and every node job.
+	GetWorkflow(context.Context, *connect.Request[proto.GetWorkflowRequest]) (*connect.Response[proto.GetWorkflowResponse], error)
+	// Cancel every unfinished node of a workflow.
+	CancelWorkflow(context.Context, *connect.Request[proto.CancelWorkflowRequest]) (*connect.Response[proto.CancelWorkflowResponse], error)
+	// Read a page of a job's container logs.
+	GetJobLogs(context.Context, *connect.Request[proto.GetJobLogsRequest]) (*connect.Response[proto.GetJobLogsResponse], error)
+	// Stream a job's container logs, optionally following them until the job ends.
+	StreamJobLogs(context.Context, *connect.Request[proto.StreamJobLogsRequest]) (*connect.ServerStreamForClient[proto.StreamJobLogsResponse], error)
+	// Create a tenant API key. The key itself is only returned here.
+	CreateApiKey(context.Context, *connect.Request[proto.CreateApiKeyRequest]) (*connect.Response[proto.CreateApiKeyResponse], error)
+	// List the current tenant's API keys, revoked ones included.
+	ListApiKeys(context.Context, *connect.Request[proto.ListApiKeysRequest]) (*connect.Response[proto.ListApiKeysResponse], error)
+	// Revoke an API key; requests using it are rejected from then on.
+	RevokeApiKey(context.Context, *connect.Request[proto.RevokeApiKeyRequest]) (*connect.Response[proto.RevokeApiKeyResponse], error)
+	// Create an organization: a tenant shared by its members. The caller
+	// becomes its first admin.
+	CreateOrganization(context.Context, *connect.Request[proto.CreateOrganizationRequest]) (*connect.Response[proto.CreateOrganizationResponse], error)
+	// List the organizations the caller is a member of.
+	ListOrganizations(context.Context, *connect.Request[proto.ListOrganizationsRequest]) (*connect.Response[proto.ListOrganizationsResponse], error)
+	// List the members of the active organization.
+	ListOrganizationMembers(context.Context, *connect.Request[proto.ListOrganizationMembersRequest]) (*connect.Response[proto.ListOrganizationMembersResponse], error)
+	// Add a member to the active organization, or change a member's role.
+	AddOrganizationMember(context.Context, *connect.Request[proto.AddOrganizationMemberRequest]) (*connect.Response[proto.AddOrganizationMemberResponse], error)
+	// Remove a member from the active organization.
+	RemoveOrganizationMember(context.Context, *connect.Request[proto.RemoveOrganizationMemberRequest]) (*connect.Response[proto.RemoveOrganizationMemberResponse], error)
+	// Show the current tenant's quota and how much of it is in use.
+	GetTenantQuota(context.Context, *connect.Request[proto.GetTenantQuotaRequest]) (*connect.Response[proto.GetTenantQuotaResponse], error)
 }
 
 // NewDeploymentServiceClient constructs a client for the jennah.v1.DeploymentService service. By
@@ -120,12 +226,30 @@ func NewDeploymentServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(deploymentServiceMethods.ByName("DeleteJob")),
 			connect.WithClientOptions(opts...),
 		),
+		bulkCancelJobs: connect.NewClient[proto.BulkCancelJobsRequest, proto.BulkCancelJobsResponse](
+			httpClient,
+			baseURL+DeploymentServiceBulkCancelJobsProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("BulkCancelJobs")),
+			connect.WithClientOptions(opts...),
+		),
+		bulkDeleteJobs: connect.NewClient[proto.BulkDeleteJobsRequest, proto.BulkDeleteJobsResponse](
+			httpClient,
+			baseURL+DeploymentServiceBulkDeleteJobsProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("BulkDeleteJobs")),
+			connect.WithClientOptions(opts...),
+		),
 		getJob: connect.NewClient[proto.GetJobRequest, proto.GetJobResponse](
 			httpClient,
 			baseURL+DeploymentServiceGetJobProcedure,
 			connect.WithSchema(deploymentServiceMethods.ByName("GetJob")),
 			connect.WithClientOptions(opts...),
 		),
+		getJobTimeline: connect.NewClient[proto.GetJobTimelineRequest, proto.GetJobTimelineResponse](
+			httpClient,
+			baseURL+DeploymentServiceGetJobTimelineProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("GetJobTimeline")),
+			connect.WithClientOptions(opts...),
+		),
 		listNotifications: connect.NewClient[proto.ListNotificationsRequest, proto.ListNotificationsResponse](
 			httpClient,
 			baseURL+DeploymentServiceListNotificationsProcedure,
@@ -138,19 +262,148 @@ func NewDeploymentServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(deploymentServiceMethods.ByName("AckNotification")),
 			connect.WithClientOptions(opts...),
 		),
+		createSchedule: connect.NewClient[proto.CreateScheduleRequest, proto.CreateScheduleResponse](
+			httpClient,
+			baseURL+DeploymentServiceCreateScheduleProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("CreateSchedule")),
+			connect.WithClientOptions(opts...),
+		),
+		listSchedules: connect.NewClient[proto.ListSchedulesRequest, proto.ListSchedulesResponse](
+			httpClient,
+			baseURL+DeploymentServiceListSchedulesProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("ListSchedules")),
+			connect.WithClientOptions(opts...),
+		),
+		pauseSchedule: connect.NewClient[proto.PauseScheduleRequest, proto.PauseScheduleResponse](
+			httpClient,
+			baseURL+DeploymentServicePauseScheduleProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("PauseSchedule")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteSchedule: connect.NewClient[proto.DeleteScheduleRequest, proto.DeleteScheduleResponse](
+			httpClient,
+			baseURL+DeploymentServiceDeleteScheduleProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("DeleteSchedule")),
+			connect.WithClientOptions(opts...),
+		),
+		submitWorkflow: connect.NewClient[proto.SubmitWorkflowRequest, proto.SubmitWorkflowResponse](
+			httpClient,
+			baseURL+DeploymentServiceSubmitWorkflowProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("SubmitWorkflow")),
+			connect.WithClientOptions(opts...),
+		),
+		getWorkflow: connect.NewClient[proto.GetWorkflowRequest, proto.GetWorkflowResponse](
+			httpClient,
+			baseURL+DeploymentServiceGetWorkflowProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("GetWorkflow")),
+			connect.WithClientOptions(opts...),
+		),
+		cancelWorkflow: connect.NewClient[proto.CancelWorkflowRequest, proto.CancelWorkflowResponse](
+			httpClient,
+			baseURL+DeploymentServiceCancelWorkflowProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("CancelWorkflow")),
+			connect.WithClientOptions(opts...),
+		),
+		getJobLogs: connect.NewClient[proto.GetJobLogsRequest, proto.GetJobLogsResponse](
+			httpClient,
+			baseURL+DeploymentServiceGetJobLogsProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("GetJobLogs")),
+			connect.WithClientOptions(opts...),
+		),
+		streamJobLogs: connect.NewClient[proto.StreamJobLogsRequest, proto.StreamJobLogsResponse](
+			httpClient,
+			baseURL+DeploymentServiceStreamJobLogsProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("StreamJobLogs")),
+			connect.WithClientOptions(opts...),
+		),
+		createApiKey: connect.NewClient[proto.CreateApiKeyRequest, proto.CreateApiKeyResponse](
+			httpClient,
+			baseURL+DeploymentServiceCreateApiKeyProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("CreateApiKey")),
+			connect.WithClientOptions(opts...),
+		),
+		listApiKeys: connect.NewClient[proto.ListApiKeysRequest, proto.ListApiKeysResponse](
+			httpClient,
+			baseURL+DeploymentServiceListApiKeysProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("ListApiKeys")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeApiKey: connect.NewClient[proto.RevokeApiKeyRequest, proto.RevokeApiKeyResponse](
+			httpClient,
+			baseURL+DeploymentServiceRevokeApiKeyProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("RevokeApiKey")),
+			connect.WithClientOptions(opts...),
+		),
+		createOrganization: connect.NewClient[proto.CreateOrganizationRequest, proto.CreateOrganizationResponse](
+			httpClient,
+			baseURL+DeploymentServiceCreateOrganizationProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("CreateOrganization")),
+			connect.WithClientOptions(opts...),
+		),
+		listOrganizations: connect.NewClient[proto.ListOrganizationsRequest, proto.ListOrganizationsResponse](
+			httpClient,
+			baseURL+DeploymentServiceListOrganizationsProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("ListOrganizations")),
+			connect.WithClientOptions(opts...),
+		),
+		listOrganizationMembers: connect.NewClient[proto.ListOrganizationMembersRequest, proto.ListOrganizationMembersResponse](
+			httpClient,
+			baseURL+DeploymentServiceListOrganizationMembersProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("ListOrganizationMembers")),
+			connect.WithClientOptions(opts...),
+		),
+		addOrganizationMember: connect.NewClient[proto.AddOrganizationMemberRequest, proto.AddOrganizationMemberResponse](
+			httpClient,
+			baseURL+DeploymentServiceAddOrganizationMemberProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("AddOrganizationMember")),
+			connect.WithClientOptions(opts...),
+		),
+		removeOrganizationMember: connect.NewClient[proto.RemoveOrganizationMemberRequest, proto.RemoveOrganizationMemberResponse](
+			httpClient,
+			baseURL+DeploymentServiceRemoveOrganizationMemberProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("RemoveOrganizationMember")),
+			connect.WithClientOptions(opts...),
+		),
+		getTenantQuota: connect.NewClient[proto.GetTenantQuotaRequest, proto.GetTenantQuotaResponse](
+			httpClient,
+			baseURL+DeploymentServiceGetTenantQuotaProcedure,
+			connect.WithSchema(deploymentServiceMethods.ByName("GetTenantQuota")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // deploymentServiceClient implements DeploymentServiceClient.
 type deploymentServiceClient struct {
-	submitJob         *connect.Client[proto.SubmitJobRequest, proto.SubmitJobResponse]
-	listJobs          *connect.Client[proto.ListJobsRequest, proto.ListJobsResponse]
-	getCurrentTenant  *connect.Client[proto.GetCurrentTenantRequest, proto.GetCurrentTenantResponse]
-	cancelJob         *connect.Client[proto.CancelJobRequest, proto.CancelJobResponse]
-	deleteJob         *connect.Client[proto.DeleteJobRequest, proto.DeleteJobResponse]
-	getJob            *connect.Client[proto.GetJobRequest, proto.GetJobResponse]
-	listNotifications *connect.Client[proto.ListNotificationsRequest, proto.ListNotificationsResponse]
-	ackNotification   *connect.Client[proto.AckNotificationRequest, proto.AckNotificationResponse]
+	submitJob                *connect.Client[proto.SubmitJobRequest, proto.SubmitJobResponse]
+	listJobs                 *connect.Client[proto.ListJobsRequest, proto.ListJobsResponse]
+	getCurrentTenant         *connect.Client[proto.GetCurrentTenantRequest, proto.GetCurrentTenantResponse]
+	cancelJob                *connect.Client[proto.CancelJobRequest, proto.CancelJobResponse]
+	deleteJob                *connect.Client[proto.DeleteJobRequest, proto.DeleteJobResponse]
+	bulkCancelJobs           *connect.Client[proto.BulkCancelJobsRequest, proto.BulkCancelJobsResponse]
+	bulkDeleteJobs           *connect.Client[proto.BulkDeleteJobsRequest, proto.BulkDeleteJobsResponse]
+	getJob                   *connect.Client[proto.GetJobRequest, proto.GetJobResponse]
+	getJobTimeline           *connect.Client[proto.GetJobTimelineRequest, proto.GetJobTimelineResponse]
+	listNotifications        *connect.Client[proto.ListNotificationsRequest, proto.ListNotificationsResponse]
+	ackNotification          *connect.Client[proto.AckNotificationRequest, proto.AckNotificationResponse]
+	createSchedule           *connect.Client[proto.CreateScheduleRequest, proto.CreateScheduleResponse]
+	listSchedules            *connect.Client[proto.ListSchedulesRequest, proto.ListSchedulesResponse]
+	pauseSchedule            *connect.Client[proto.PauseScheduleRequest, proto.PauseScheduleResponse]
+	deleteSchedule           *connect.Client[proto.DeleteScheduleRequest, proto.DeleteScheduleResponse]
+	submitWorkflow           *connect.Client[proto.SubmitWorkflowRequest, proto.SubmitWorkflowResponse]
+	getWorkflow              *connect.Client[proto.GetWorkflowRequest, proto.GetWorkflowResponse]
+	cancelWorkflow           *connect.Client[proto.CancelWorkflowRequest, proto.CancelWorkflowResponse]
+	getJobLogs               *connect.Client[proto.GetJobLogsRequest, proto.GetJobLogsResponse]
+	streamJobLogs            *connect.Client[proto.StreamJobLogsRequest, proto.StreamJobLogsResponse]
+	createApiKey             *connect.Client[proto.CreateApiKeyRequest, proto.CreateApiKeyResponse]
+	listApiKeys              *connect.Client[proto.ListApiKeysRequest, proto.ListApiKeysResponse]
+	revokeApiKey             *connect.Client[proto.RevokeApiKeyRequest, proto.RevokeApiKeyResponse]
+	createOrganization       *connect.Client[proto.CreateOrganizationRequest, proto.CreateOrganizationResponse]
+	listOrganizations        *connect.Client[proto.ListOrganizationsRequest, proto.ListOrganizationsResponse]
+	listOrganizationMembers  *connect.Client[proto.ListOrganizationMembersRequest, proto.ListOrganizationMembersResponse]
+	addOrganizationMember    *connect.Client[proto.AddOrganizationMemberRequest, proto.AddOrganizationMemberResponse]
+	removeOrganizationMember *connect.Client[proto.RemoveOrganizationMemberRequest, proto.RemoveOrganizationMemberResponse]
+	getTenantQuota           *connect.Client[proto.GetTenantQuotaRequest, proto.GetTenantQuotaResponse]
 }
 
 // SubmitJob calls jennah.v1.DeploymentService.SubmitJob.
@@ -178,11 +431,26 @@ func (c *deploymentServiceClient) DeleteJob(ctx context.Context, req *connect.Re
 	return c.deleteJob.CallUnary(ctx, req)
 }
 
+// BulkCancelJobs calls jennah.v1.DeploymentService.BulkCancelJobs.
+func (c *deploymentServiceClient) BulkCancelJobs(ctx context.Context, req *connect.Request[proto.BulkCancelJobsRequest]) (*connect.Response[proto.BulkCancelJobsResponse], error) {
+	return c.bulkCancelJobs.CallUnary(ctx, req)
+}
+
+// BulkDeleteJobs calls jennah.v1.DeploymentService.BulkDeleteJobs.
+func (c *deploymentServiceClient) BulkDeleteJobs(ctx context.Context, req *connect.Request[proto.BulkDeleteJobsRequest]) (*connect.Response[proto.BulkDeleteJobsResponse], error) {
+	return c.bulkDeleteJobs.CallUnary(ctx, req)
+}
+
 // GetJob calls jennah.v1.DeploymentService.GetJob.
 func (c *deploymentServiceClient) GetJob(ctx context.Context, req *connect.Request[proto.GetJobRequest]) (*connect.Response[proto.GetJobResponse], error) {
 	return c.getJob.CallUnary(ctx, req)
 }
 
+// GetJobTimeline calls jennah.v1.DeploymentService.GetJobTimeline.
+func (c *deploymentServiceClient) GetJobTimeline(ctx context.Context, req *connect.Request[proto.GetJobTimelineRequest]) (*connect.Response[proto.GetJobTimelineResponse], error) {
+	return c.getJobTimeline.CallUnary(ctx, req)
+}
+
 // ListNotifications calls jennah.v1.DeploymentService.ListNotifications.
 func (c *deploymentServiceClient) ListNotifications(ctx context.Context, req *connect.Request[proto.ListNotificationsRequest]) (*connect.Response[proto.ListNotificationsResponse], error) {
 	return c.listNotifications.CallUnary(ctx, req)
@@ -193,6 +461,96 @@ func (c *deploymentServiceClient) AckNotification(ctx context.Context, req *conn
 	return c.ackNotification.CallUnary(ctx, req)
 }
 
+// CreateSchedule calls jennah.v1.DeploymentService.CreateSchedule.
+func (c *deploymentServiceClient) CreateSchedule(ctx context.Context, req *connect.Request[proto.CreateScheduleRequest]) (*connect.Response[proto.CreateScheduleResponse], error) {
+	return c.createSchedule.CallUnary(ctx, req)
+}
+
+// ListSchedules calls jennah.v1.DeploymentService.ListSchedules.
+func (c *deploymentServiceClient) ListSchedules(ctx context.Context, req *connect.Request[proto.ListSchedulesRequest]) (*connect.Response[proto.ListSchedulesResponse], error) {
+	return c.listSchedules.CallUnary(ctx, req)
+}
+
+// PauseSchedule calls jennah.v1.DeploymentService.PauseSchedule.
+func (c *deploymentServiceClient) PauseSchedule(ctx context.Context, req *connect.Request[proto.PauseScheduleRequest]) (*connect.Response[proto.PauseScheduleResponse], error) {
+	return c.pauseSchedule.CallUnary(ctx, req)
+}
+
+// DeleteSchedule calls jennah.v1.DeploymentService.DeleteSchedule.
+func (c *deploymentServiceClient) DeleteSchedule(ctx context.Context, req *connect.Request[proto.DeleteScheduleRequest]) (*connect.Response[proto.DeleteScheduleResponse], error) {
+	return c.deleteSchedule.CallUnary(ctx, req)
+}
+
+// SubmitWorkflow calls jennah.v1.DeploymentService.SubmitWorkflow.
+func (c *deploymentServiceClient) SubmitWorkflow(ctx context.Context, req *connect.Request[proto.SubmitWorkflowRequest]) (*connect.Response[proto.SubmitWorkflowResponse], error) {
+	return c.submitWorkflow.CallUnary(ctx, req)
+}
+
+// GetWorkflow calls jennah.v1.DeploymentService.GetWorkflow.
+func (c *deploymentServiceClient) GetWorkflow(ctx context.Context, req *connect.Request[proto.GetWorkflowRequest]) (*connect.Response[proto.GetWorkflowResponse], error) {
+	return c.getWorkflow.CallUnary(ctx, req)
+}
+
+// CancelWorkflow calls jennah.v1.DeploymentService.CancelWorkflow.
+func (c *deploymentServiceClient) CancelWorkflow(ctx context.Context, req *connect.Request[proto.CancelWorkflowRequest]) (*connect.Response[proto.CancelWorkflowResponse], error) {
+	return c.cancelWorkflow.CallUnary(ctx, req)
+}
+
+// GetJobLogs calls jennah.v1.DeploymentService.GetJobLogs.
+func (c *deploymentServiceClient) GetJobLogs(ctx context.Context, req *connect.Request[proto.GetJobLogsRequest]) (*connect.Response[proto.GetJobLogsResponse], error) {
+	return c.getJobLogs.CallUnary(ctx, req)
+}
+
+// StreamJobLogs calls jennah.v1.DeploymentService.StreamJobLogs.
+func (c *deploymentServiceClient) StreamJobLogs(ctx context.Context, req *connect.Request[proto.StreamJobLogsRequest]) (*connect.ServerStreamForClient[proto.StreamJobLogsResponse], error) {
+	return c.streamJobLogs.CallServerStream(ctx, req)
+}
+
+// CreateApiKey calls jennah.v1.DeploymentService.CreateApiKey.
+func (c *deploymentServiceClient) CreateApiKey(ctx context.Context, req *connect.Request[proto.CreateApiKeyRequest]) (*connect.Response[proto.CreateApiKeyResponse], error) {
+	return c.createApiKey.CallUnary(ctx, req)
+}
+
+// ListApiKeys calls jennah.v1.DeploymentService.ListApiKeys.
+func (c *deploymentServiceClient) ListApiKeys(ctx context.Context, req *connect.Request[proto.ListApiKeysRequest]) (*connect.Response[proto.ListApiKeysResponse], error) {
+	return c.listApiKeys.CallUnary(ctx, req)
+}
+
+// RevokeApiKey calls jennah.v1.DeploymentService.RevokeApiKey.
+func (c *deploymentServiceClient) RevokeApiKey(ctx context.Context, req *connect.Request[proto.RevokeApiKeyRequest]) (*connect.Response[proto.RevokeApiKeyResponse], error) {
+	return c.revokeApiKey.CallUnary(ctx, req)
+}
+
+// CreateOrganization calls jennah.v1.DeploymentService.CreateOrganization.
+func (c *deploymentServiceClient) CreateOrganization(ctx context.Context, req *connect.Request[proto.CreateOrganizationRequest]) (*connect.Response[proto.CreateOrganizationResponse], error) {
+	return c.createOrganization.CallUnary(ctx, req)
+}
+
+// ListOrganizations calls jennah.v1.DeploymentService.ListOrganizations.
+func (c *deploymentServiceClient) ListOrganizations(ctx context.Context, req *connect.Request[proto.ListOrganizationsRequest]) (*connect.Response[proto.ListOrganizationsResponse], error) {
+	return c.listOrganizations.CallUnary(ctx, req)
+}
+
+// ListOrganizationMembers calls jennah.v1.DeploymentService.ListOrganizationMembers.
+func (c *deploymentServiceClient) ListOrganizationMembers(ctx context.Context, req *connect.Request[proto.ListOrganizationMembersRequest]) (*connect.Response[proto.ListOrganizationMembersResponse], error) {
+	return c.listOrganizationMembers.CallUnary(ctx, req)
+}
+
+// AddOrganizationMember calls jennah.v1.DeploymentService.AddOrganizationMember.
+func (c *deploymentServiceClient) AddOrganizationMember(ctx context.Context, req *connect.Request[proto.AddOrganizationMemberRequest]) (*connect.Response[proto.AddOrganizationMemberResponse], error) {
+	return c.addOrganizationMember.CallUnary(ctx, req)
+}
+
+// RemoveOrganizationMember calls jennah.v1.DeploymentService.RemoveOrganizationMember.
+func (c *deploymentServiceClient) RemoveOrganizationMember(ctx context.Context, req *connect.Request[proto.RemoveOrganizationMemberRequest]) (*connect.Response[proto.RemoveOrganizationMemberResponse], error) {
+	return c.removeOrganizationMember.CallUnary(ctx, req)
+}
+
+// GetTenantQuota calls jennah.v1.DeploymentService.GetTenantQuota.
+func (c *deploymentServiceClient) GetTenantQuota(ctx context.Context, req *connect.Request[proto.GetTenantQuotaRequest]) (*connect.Response[proto.GetTenantQuotaResponse], error) {
+	return c.getTenantQuota.CallUnary(ctx, req)
+}
+
 // DeploymentServiceHandler is an implementation of the jennah.v1.DeploymentService service.
 type DeploymentServiceHandler interface {
 	// Submit a job for deployment.
@@ -201,16 +559,59 @@ type DeploymentServiceHandler interface {
 	ListJobs(context.Context, *connect.Request[proto.ListJobsRequest]) (*connect.Response[proto.ListJobsResponse], error)
 	// Get the current tenant's information.
 	GetCurrentTenant(context.Context, *connect.Request[proto.GetCurrentTenantRequest]) (*connect.Response[proto.GetCurrentTenantResponse], error)
-	// Cancel a job (only for PENDING, SCHEDULED, or RUNNING states).
+	// Cancel a job (only for QUEUED, PENDING, SCHEDULED, or RUNNING states).
 	CancelJob(context.Context, *connect.Request[proto.CancelJobRequest]) (*connect.Response[proto.CancelJobResponse], error)
 	// Delete a job from the system.
 	DeleteJob(context.Context, *connect.Request[proto.DeleteJobRequest]) (*connect.Response[proto.DeleteJobResponse], error)
+	// Cancel every active job matching a label selector.
+	BulkCancelJobs(context.Context, *connect.Request[proto.BulkCancelJobsRequest]) (*connect.Response[proto.BulkCancelJobsResponse], error)
+	// Delete every job matching a label selector.
+	BulkDeleteJobs(context.Context, *connect.Request[proto.BulkDeleteJobsRequest]) (*connect.Response[proto.BulkDeleteJobsResponse], error)
 	// Get a single job's full details.
 	GetJob(context.Context, *connect.Request[proto.GetJobRequest]) (*connect.Response[proto.GetJobResponse], error)
+	// List a job's state changes, oldest first, with the time spent in each state.
+	GetJobTimeline(context.Context, *connect.Request[proto.GetJobTimelineRequest]) (*connect.Response[proto.GetJobTimelineResponse], error)
 	// List in-app notifications for the current tenant (saved by Pub/Sub consumer).
 	ListNotifications(context.Context, *connect.Request[proto.ListNotificationsRequest]) (*connect.Response[proto.ListNotificationsResponse], error)
 	// Mark a notification as read (ack).
 	AckNotification(context.Context, *connect.Request[proto.AckNotificationRequest]) (*connect.Response[proto.AckNotificationResponse], error)
+	// Create a cron schedule that submits a job from a template on every fire.
+	CreateSchedule(context.Context, *connect.Request[proto.CreateScheduleRequest]) (*connect.Response[proto.CreateScheduleResponse], error)
+	// List schedules for the current tenant.
+	ListSchedules(context.Context, *connect.Request[proto.ListSchedulesRequest]) (*connect.Response[proto.ListSchedulesResponse], error)
+	// Pause or resume a schedule.
+	PauseSchedule(context.Context, *connect.Request[proto.PauseScheduleRequest]) (*connect.Response[proto.PauseScheduleResponse], error)
+	// Delete a schedule. Jobs it already created are kept.
+	DeleteSchedule(context.Context, *connect.Request[proto.DeleteScheduleRequest]) (*connect.Response[proto.DeleteScheduleResponse], error)
+	// Submit a DAG of jobs; each node starts once its dependencies allow it.
+	SubmitWorkflow(context.Context, *connect.Request[proto.SubmitWorkflowRequest]) (*connect.Response[proto.SubmitWorkflowResponse], error)
+	// Get a workflow's status and every node job.
+	GetWorkflow(context.Context, *connect.Request[proto.GetWorkflowRequest]) (*connect.Response[proto.GetWorkflowResponse], error)
+	// Cancel every unfinished node of a workflow.
+	CancelWorkflow(context.Context, *connect.Request[proto.CancelWorkflowRequest]) (*connect.Response[proto.CancelWorkflowResponse], error)
+	// Read a page of a job's container logs.
+	GetJobLogs(context.Context, *connect.Request[proto.GetJobLogsRequest]) (*connect.Response[proto.GetJobLogsResponse], error)
+	// Stream a job's container logs, optionally following them until the job ends.
+	StreamJobLogs(context.Context, *connect.Request[proto.StreamJobLogsRequest], *connect.ServerStream[proto.StreamJobLogsResponse]) error
+	// Create a tenant API key. The key itself is only returned here.
+	CreateApiKey(context.Context, *connect.Request[proto.CreateApiKeyRequest]) (*connect.Response[proto.CreateApiKeyResponse], error)
+	// List the current tenant's API keys, revoked ones included.
+	ListApiKeys(context.Context, *connect.Request[proto.ListApiKeysRequest]) (*connect.Response[proto.ListApiKeysResponse], error)
+	// Revoke an API key; requests using it are rejected from then on.
+	RevokeApiKey(context.Context, *connect.Request[proto.RevokeApiKeyRequest]) (*connect.Response[proto.RevokeApiKeyResponse], error)
+	// Create an organization: a tenant shared by its members. The caller
+	// becomes its first admin.
+	CreateOrganization(context.Context, *connect.Request[proto.CreateOrganizationRequest]) (*connect.Response[proto.CreateOrganizationResponse], error)
+	// List the organizations the caller is a member of.
+	ListOrganizations(context.Context, *connect.Request[proto.ListOrganizationsRequest]) (*connect.Response[proto.ListOrganizationsResponse], error)
+	// List the members of the active organization.
+	ListOrganizationMembers(context.Context, *connect.Request[proto.ListOrganizationMembersRequest]) (*connect.Response[proto.ListOrganizationMembersResponse], error)
+	// Add a member to the active organization, or change a member's role.
+	AddOrganizationMember(context.Context, *connect.Request[proto.AddOrganizationMemberRequest]) (*connect.Response[proto.AddOrganizationMemberResponse], error)
+	// Remove a member from the active organization.
+	RemoveOrganizationMember(context.Context, *connect.Request[proto.RemoveOrganizationMemberRequest]) (*connect.Response[proto.RemoveOrganizationMemberResponse], error)
+	// Show the current tenant's quota and how much of it is in use.
+	GetTenantQuota(context.Context, *connect.Request[proto.GetTenantQuotaRequest]) (*connect.Response[proto.GetTenantQuotaResponse], error)
 }
 
 // NewDeploymentServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -250,12 +651,30 @@ func NewDeploymentServiceHandler(svc DeploymentServiceHandler, opts ...connect.H
 		connect.WithSchema(deploymentServiceMethods.ByName("DeleteJob")),
 		connect.WithHandlerOptions(opts...),
 	)
+	deploymentServiceBulkCancelJobsHandler := connect.NewUnaryHandler(
+		DeploymentServiceBulkCancelJobsProcedure,
+		svc.BulkCancelJobs,
+		connect.WithSchema(deploymentServiceMethods.ByName("BulkCancelJobs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceBulkDeleteJobsHandler := connect.NewUnaryHandler(
+		DeploymentServiceBulkDeleteJobsProcedure,
+		svc.BulkDeleteJobs,
+		connect.WithSchema(deploymentServiceMethods.ByName("BulkDeleteJobs")),
+		connect.WithHandlerOptions(opts...),
+	)
 	deploymentServiceGetJobHandler := connect.NewUnaryHandler(
 		DeploymentServiceGetJobProcedure,
 		svc.GetJob,
 		connect.WithSchema(deploymentServiceMethods.ByName("GetJob")),
 		connect.WithHandlerOptions(opts...),
 	)
+	deploymentServiceGetJobTimelineHandler := connect.NewUnaryHandler(
+		DeploymentServiceGetJobTimelineProcedure,
+		svc.GetJobTimeline,
+		connect.WithSchema(deploymentServiceMethods.ByName("GetJobTimeline")),
+		connect.WithHandlerOptions(opts...),
+	)
 	deploymentServiceListNotificationsHandler := connect.NewUnaryHandler(
 		DeploymentServiceListNotificationsProcedure,
 		svc.ListNotifications,
@@ -268,6 +687,114 @@ func NewDeploymentServiceHandler(svc DeploymentServiceHandler, opts ...connect.H
 		connect.WithSchema(deploymentServiceMethods.ByName("AckNotification")),
 		connect.WithHandlerOptions(opts...),
 	)
+	deploymentServiceCreateScheduleHandler := connect.NewUnaryHandler(
+		DeploymentServiceCreateScheduleProcedure,
+		svc.CreateSchedule,
+		connect.WithSchema(deploymentServiceMethods.ByName("CreateSchedule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceListSchedulesHandler := connect.NewUnaryHandler(
+		DeploymentServiceListSchedulesProcedure,
+		svc.ListSchedules,
+		connect.WithSchema(deploymentServiceMethods.ByName("ListSchedules")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServicePauseScheduleHandler := connect.NewUnaryHandler(
+		DeploymentServicePauseScheduleProcedure,
+		svc.PauseSchedule,
+		connect.WithSchema(deploymentServiceMethods.ByName("PauseSchedule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceDeleteScheduleHandler := connect.NewUnaryHandler(
+		DeploymentServiceDeleteScheduleProcedure,
+		svc.DeleteSchedule,
+		connect.WithSchema(deploymentServiceMethods.ByName("DeleteSchedule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceSubmitWorkflowHandler := connect.NewUnaryHandler(
+		DeploymentServiceSubmitWorkflowProcedure,
+		svc.SubmitWorkflow,
+		connect.WithSchema(deploymentServiceMethods.ByName("SubmitWorkflow")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceGetWorkflowHandler := connect.NewUnaryHandler(
+		DeploymentServiceGetWorkflowProcedure,
+		svc.GetWorkflow,
+		connect.WithSchema(deploymentServiceMethods.ByName("GetWorkflow")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceCancelWorkflowHandler := connect.NewUnaryHandler(
+		DeploymentServiceCancelWorkflowProcedure,
+		svc.CancelWorkflow,
+		connect.WithSchema(deploymentServiceMethods.ByName("CancelWorkflow")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceGetJobLogsHandler := connect.NewUnaryHandler(
+		DeploymentServiceGetJobLogsProcedure,
+		svc.GetJobLogs,
+		connect.WithSchema(deploymentServiceMethods.ByName("GetJobLogs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceStreamJobLogsHandler := connect.NewServerStreamHandler(
+		DeploymentServiceStreamJobLogsProcedure,
+		svc.StreamJobLogs,
+		connect.WithSchema(deploymentServiceMethods.ByName("StreamJobLogs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceCreateApiKeyHandler := connect.NewUnaryHandler(
+		DeploymentServiceCreateApiKeyProcedure,
+		svc.CreateApiKey,
+		connect.WithSchema(deploymentServiceMethods.ByName("CreateApiKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceListApiKeysHandler := connect.NewUnaryHandler(
+		DeploymentServiceListApiKeysProcedure,
+		svc.ListApiKeys,
+		connect.WithSchema(deploymentServiceMethods.ByName("ListApiKeys")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceRevokeApiKeyHandler := connect.NewUnaryHandler(
+		DeploymentServiceRevokeApiKeyProcedure,
+		svc.RevokeApiKey,
+		connect.WithSchema(deploymentServiceMethods.ByName("RevokeApiKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceCreateOrganizationHandler := connect.NewUnaryHandler(
+		DeploymentServiceCreateOrganizationProcedure,
+		svc.CreateOrganization,
+		connect.WithSchema(deploymentServiceMethods.ByName("CreateOrganization")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceListOrganizationsHandler := connect.NewUnaryHandler(
+		DeploymentServiceListOrganizationsProcedure,
+		svc.ListOrganizations,
+		connect.WithSchema(deploymentServiceMethods.ByName("ListOrganizations")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceListOrganizationMembersHandler := connect.NewUnaryHandler(
+		DeploymentServiceListOrganizationMembersProcedure,
+		svc.ListOrganizationMembers,
+		connect.WithSchema(deploymentServiceMethods.ByName("ListOrganizationMembers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceAddOrganizationMemberHandler := connect.NewUnaryHandler(
+		DeploymentServiceAddOrganizationMemberProcedure,
+		svc.AddOrganizationMember,
+		connect.WithSchema(deploymentServiceMethods.ByName("AddOrganizationMember")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceRemoveOrganizationMemberHandler := connect.NewUnaryHandler(
+		DeploymentServiceRemoveOrganizationMemberProcedure,
+		svc.RemoveOrganizationMember,
+		connect.WithSchema(deploymentServiceMethods.ByName("RemoveOrganizationMember")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deploymentServiceGetTenantQuotaHandler := connect.NewUnaryHandler(
+		DeploymentServiceGetTenantQuotaProcedure,
+		svc.GetTenantQuota,
+		connect.WithSchema(deploymentServiceMethods.ByName("GetTenantQuota")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/jennah.v1.DeploymentService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case DeploymentServiceSubmitJobProcedure:
@@ -280,12 +807,54 @@ func NewDeploymentServiceHandler(svc DeploymentServiceHandler, opts ...connect.H
 			deploymentServiceCancelJobHandler.ServeHTTP(w, r)
 		case DeploymentServiceDeleteJobProcedure:
 			deploymentServiceDeleteJobHandler.ServeHTTP(w, r)
+		case DeploymentServiceBulkCancelJobsProcedure:
+			deploymentServiceBulkCancelJobsHandler.ServeHTTP(w, r)
+		case DeploymentServiceBulkDeleteJobsProcedure:
+			deploymentServiceBulkDeleteJobsHandler.ServeHTTP(w, r)
 		case DeploymentServiceGetJobProcedure:
 			deploymentServiceGetJobHandler.ServeHTTP(w, r)
+		case DeploymentServiceGetJobTimelineProcedure:
+			deploymentServiceGetJobTimelineHandler.ServeHTTP(w, r)
 		case DeploymentServiceListNotificationsProcedure:
 			deploymentServiceListNotificationsHandler.ServeHTTP(w, r)
 		case DeploymentServiceAckNotificationProcedure:
 			deploymentServiceAckNotificationHandler.ServeHTTP(w, r)
+		case DeploymentServiceCreateScheduleProcedure:
+			deploymentServiceCreateScheduleHandler.ServeHTTP(w, r)
+		case DeploymentServiceListSchedulesProcedure:
+			deploymentServiceListSchedulesHandler.ServeHTTP(w, r)
+		case DeploymentServicePauseScheduleProcedure:
+			deploymentServicePauseScheduleHandler.ServeHTTP(w, r)
+		case DeploymentServiceDeleteScheduleProcedure:
+			deploymentServiceDeleteScheduleHandler.ServeHTTP(w, r)
+		case DeploymentServiceSubmitWorkflowProcedure:
+			deploymentServiceSubmitWorkflowHandler.ServeHTTP(w, r)
+		case DeploymentServiceGetWorkflowProcedure:
+			deploymentServiceGetWorkflowHandler.ServeHTTP(w, r)
+		case DeploymentServiceCancelWorkflowProcedure:
+			deploymentServiceCancelWorkflowHandler.ServeHTTP(w, r)
+		case DeploymentServiceGetJobLogsProcedure:
+			deploymentServiceGetJobLogsHandler.ServeHTTP(w, r)
+		case DeploymentServiceStreamJobLogsProcedure:
+			deploymentServiceStreamJobLogsHandler.ServeHTTP(w, r)
+		case DeploymentServiceCreateApiKeyProcedure:
+			deploymentServiceCreateApiKeyHandler.ServeHTTP(w, r)
+		case DeploymentServiceListApiKeysProcedure:
+			deploymentServiceListApiKeysHandler.ServeHTTP(w, r)
+		case DeploymentServiceRevokeApiKeyProcedure:
+			deploymentServiceRevokeApiKeyHandler.ServeHTTP(w, r)
+		case DeploymentServiceCreateOrganizationProcedure:
+			deploymentServiceCreateOrganizationHandler.ServeHTTP(w, r)
+		case DeploymentServiceListOrganizationsProcedure:
+			deploymentServiceListOrganizationsHandler.ServeHTTP(w, r)
+		case DeploymentServiceListOrganizationMembersProcedure:
+			deploymentServiceListOrganizationMembersHandler.ServeHTTP(w, r)
+		case DeploymentServiceAddOrganizationMemberProcedure:
+			deploymentServiceAddOrganizationMemberHandler.ServeHTTP(w, r)
+		case DeploymentServiceRemoveOrganizationMemberProcedure:
+			deploymentServiceRemoveOrganizationMemberHandler.ServeHTTP(w, r)
+		case DeploymentServiceGetTenantQuotaProcedure:
+			deploymentServiceGetTenantQuotaHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -315,10 +884,22 @@ func (UnimplementedDeploymentServiceHandler) DeleteJob(context.Context, *connect
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.DeleteJob is not implemented"))
 }
 
+func (UnimplementedDeploymentServiceHandler) BulkCancelJobs(context.Context, *connect.Request[proto.BulkCancelJobsRequest]) (*connect.Response[proto.BulkCancelJobsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.BulkCancelJobs is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) BulkDeleteJobs(context.Context, *connect.Request[proto.BulkDeleteJobsRequest]) (*connect.Response[proto.BulkDeleteJobsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.BulkDeleteJobs is not implemented"))
+}
+
 func (UnimplementedDeploymentServiceHandler) GetJob(context.Context, *connect.Request[proto.GetJobRequest]) (*connect.Response[proto.GetJobResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.GetJob is not implemented"))
 }
 
+func (UnimplementedDeploymentServiceHandler) GetJobTimeline(context.Context, *connect.Request[proto.GetJobTimelineRequest]) (*connect.Response[proto.GetJobTimelineResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.GetJobTimeline is not implemented"))
+}
+
 func (UnimplementedDeploymentServiceHandler) ListNotifications(context.Context, *connect.Request[proto.ListNotificationsRequest]) (*connect.Response[proto.ListNotificationsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.ListNotifications is not implemented"))
 }
@@ -326,3 +907,75 @@ func (UnimplementedDeploymentServiceHandler) ListNotifications(context.Context,
 func (UnimplementedDeploymentServiceHandler) AckNotification(context.Context, *connect.Request[proto.AckNotificationRequest]) (*connect.Response[proto.AckNotificationResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.AckNotification is not implemented"))
 }
+
+func (UnimplementedDeploymentServiceHandler) CreateSchedule(context.Context, *connect.Request[proto.CreateScheduleRequest]) (*connect.Response[proto.CreateScheduleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.CreateSchedule is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) ListSchedules(context.Context, *connect.Request[proto.ListSchedulesRequest]) (*connect.Response[proto.ListSchedulesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.ListSchedules is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) PauseSchedule(context.Context, *connect.Request[proto.PauseScheduleRequest]) (*connect.Response[proto.PauseScheduleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.PauseSchedule is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) DeleteSchedule(context.Context, *connect.Request[proto.DeleteScheduleRequest]) (*connect.Response[proto.DeleteScheduleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.DeleteSchedule is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) SubmitWorkflow(context.Context, *connect.Request[proto.SubmitWorkflowRequest]) (*connect.Response[proto.SubmitWorkflowResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.SubmitWorkflow is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) GetWorkflow(context.Context, *connect.Request[proto.GetWorkflowRequest]) (*connect.Response[proto.GetWorkflowResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.GetWorkflow is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) CancelWorkflow(context.Context, *connect.Request[proto.CancelWorkflowRequest]) (*connect.Response[proto.CancelWorkflowResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.CancelWorkflow is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) GetJobLogs(context.Context, *connect.Request[proto.GetJobLogsRequest]) (*connect.Response[proto.GetJobLogsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.GetJobLogs is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) StreamJobLogs(context.Context, *connect.Request[proto.StreamJobLogsRequest], *connect.ServerStream[proto.StreamJobLogsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.StreamJobLogs is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) CreateApiKey(context.Context, *connect.Request[proto.CreateApiKeyRequest]) (*connect.Response[proto.CreateApiKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.CreateApiKey is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) ListApiKeys(context.Context, *connect.Request[proto.ListApiKeysRequest]) (*connect.Response[proto.ListApiKeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.ListApiKeys is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) RevokeApiKey(context.Context, *connect.Request[proto.RevokeApiKeyRequest]) (*connect.Response[proto.RevokeApiKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.RevokeApiKey is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) CreateOrganization(context.Context, *connect.Request[proto.CreateOrganizationRequest]) (*connect.Response[proto.CreateOrganizationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.CreateOrganization is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) ListOrganizations(context.Context, *connect.Request[proto.ListOrganizationsRequest]) (*connect.Response[proto.ListOrganizationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.ListOrganizations is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) ListOrganizationMembers(context.Context, *connect.Request[proto.ListOrganizationMembersRequest]) (*connect.Response[proto.ListOrganizationMembersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.ListOrganizationMembers is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) AddOrganizationMember(context.Context, *connect.Request[proto.AddOrganizationMemberRequest]) (*connect.Response[proto.AddOrganizationMemberResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.AddOrganizationMember is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) RemoveOrganizationMember(context.Context, *connect.Request[proto.RemoveOrganizationMemberRequest]) (*connect.Response[proto.RemoveOrganizationMemberResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.RemoveOrganizationMember is not implemented"))
+}
+
+func (UnimplementedDeploymentServiceHandler) GetTenantQuota(context.Context, *connect.Request[proto.GetTenantQuotaRequest]) (*connect.Response[proto.GetTenantQuotaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("jennah.v1.DeploymentService.GetTenantQuota is not implemented"))
+}
@@ -0,0 +1,258 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// signingName is the SigV4 service name of AWS Batch.
+const signingName = "batch"
+
+// batchAPI is a minimal client for the AWS Batch REST-JSON API, covering
+// only the operations the provider needs. Requests are signed with SigV4.
+//
+// See https://docs.aws.amazon.com/batch/latest/APIReference/.
+type batchAPI struct {
+	http        *http.Client
+	endpoint    string
+	region      string
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+}
+
+// newBatchAPI creates a client for endpoint, which defaults to the regional
+// AWS Batch endpoint when empty.
+func newBatchAPI(endpoint, region string, credentials aws.CredentialsProvider) *batchAPI {
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://batch.%s.amazonaws.com", region)
+	}
+	return &batchAPI{
+		http:        &http.Client{Timeout: 30 * time.Second},
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		region:      region,
+		credentials: credentials,
+		signer:      v4.NewSigner(),
+	}
+}
+
+type keyValuePair struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type resourceRequirement struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type containerProperties struct {
+	Image                string                `json:"image"`
+	Command              []string              `json:"command,omitempty"`
+	Environment          []keyValuePair        `json:"environment,omitempty"`
+	ResourceRequirements []resourceRequirement `json:"resourceRequirements,omitempty"`
+}
+
+type retryStrategy struct {
+	Attempts int32 `json:"attempts"`
+}
+
+type jobTimeout struct {
+	AttemptDurationSeconds int64 `json:"attemptDurationSeconds"`
+}
+
+type arrayProperties struct {
+	Size int64 `json:"size,omitempty"`
+}
+
+type registerJobDefinitionInput struct {
+	JobDefinitionName   string               `json:"jobDefinitionName"`
+	Type                string               `json:"type"`
+	ContainerProperties *containerProperties `json:"containerProperties"`
+	RetryStrategy       *retryStrategy       `json:"retryStrategy,omitempty"`
+	Timeout             *jobTimeout          `json:"timeout,omitempty"`
+	Tags                map[string]string    `json:"tags,omitempty"`
+	PropagateTags       bool                 `json:"propagateTags,omitempty"`
+}
+
+type registerJobDefinitionOutput struct {
+	JobDefinitionArn  string `json:"jobDefinitionArn"`
+	JobDefinitionName string `json:"jobDefinitionName"`
+	Revision          int64  `json:"revision"`
+}
+
+type submitJobInput struct {
+	JobName         string            `json:"jobName"`
+	JobQueue        string            `json:"jobQueue"`
+	JobDefinition   string            `json:"jobDefinition"`
+	ArrayProperties *arrayProperties  `json:"arrayProperties,omitempty"`
+	Tags            map[string]string `json:"tags,omitempty"`
+}
+
+type submitJobOutput struct {
+	JobArn  string `json:"jobArn"`
+	JobId   string `json:"jobId"`
+	JobName string `json:"jobName"`
+}
+
+type containerDetail struct {
+	ExitCode *int32 `json:"exitCode"`
+	Reason   string `json:"reason"`
+}
+
+type attemptDetail struct {
+	Container    *containerDetail `json:"container"`
+	StatusReason string           `json:"statusReason"`
+}
+
+type jobDetail struct {
+	JobArn        string           `json:"jobArn"`
+	JobId         string           `json:"jobId"`
+	JobName       string           `json:"jobName"`
+	JobDefinition string           `json:"jobDefinition"`
+	Status        string           `json:"status"`
+	StatusReason  string           `json:"statusReason"`
+	Container     *containerDetail `json:"container"`
+	Attempts      []attemptDetail  `json:"attempts"`
+}
+
+type jobSummary struct {
+	JobArn  string `json:"jobArn"`
+	JobId   string `json:"jobId"`
+	JobName string `json:"jobName"`
+	Status  string `json:"status"`
+}
+
+type listJobsFilter struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+type listJobsInput struct {
+	JobQueue  string           `json:"jobQueue"`
+	Filters   []listJobsFilter `json:"filters,omitempty"`
+	NextToken string           `json:"nextToken,omitempty"`
+}
+
+type listJobsOutput struct {
+	JobSummaryList []jobSummary `json:"jobSummaryList"`
+	NextToken      string       `json:"nextToken"`
+}
+
+func (c *batchAPI) registerJobDefinition(ctx context.Context, in *registerJobDefinitionInput) (*registerJobDefinitionOutput, error) {
+	var out registerJobDefinitionOutput
+	if err := c.call(ctx, "/v1/registerjobdefinition", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *batchAPI) deregisterJobDefinition(ctx context.Context, jobDefinition string) error {
+	return c.call(ctx, "/v1/deregisterjobdefinition", map[string]string{"jobDefinition": jobDefinition}, nil)
+}
+
+func (c *batchAPI) submitJob(ctx context.Context, in *submitJobInput) (*submitJobOutput, error) {
+	var out submitJobOutput
+	if err := c.call(ctx, "/v1/submitjob", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// describeJob returns the job with the given ID or ARN.
+func (c *batchAPI) describeJob(ctx context.Context, jobID string) (*jobDetail, error) {
+	var out struct {
+		Jobs []jobDetail `json:"jobs"`
+	}
+	if err := c.call(ctx, "/v1/describejobs", map[string][]string{"jobs": {jobID}}, &out); err != nil {
+		return nil, err
+	}
+	if len(out.Jobs) == 0 {
+		return nil, fmt.Errorf("AWS Batch job %s not found", jobID)
+	}
+	return &out.Jobs[0], nil
+}
+
+func (c *batchAPI) terminateJob(ctx context.Context, jobID, reason string) error {
+	return c.call(ctx, "/v1/terminatejob", map[string]string{"jobId": jobID, "reason": reason}, nil)
+}
+
+func (c *batchAPI) listJobs(ctx context.Context, in *listJobsInput) (*listJobsOutput, error) {
+	var out listJobsOutput
+	if err := c.call(ctx, "/v1/listjobs", in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// call POSTs in as JSON to path and decodes the response into out, if given.
+func (c *batchAPI) call(ctx context.Context, path string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	creds, err := c.credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+	payloadHash := sha256.Sum256(body)
+	if err := c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(payloadHash[:]), signingName, c.region, time.Now()); err != nil {
+		return fmt.Errorf("failed to sign AWS Batch request: %w", err)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("AWS Batch %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return apiError(resp, path)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("AWS Batch %s: failed to decode response: %w", path, err)
+	}
+	return nil
+}
+
+// apiError builds an error from an AWS error response, whose type is carried
+// in the X-Amzn-ErrorType header (e.g. "ClientException").
+func apiError(resp *http.Response, path string) error {
+	var msg struct {
+		Message      string `json:"message"`
+		MessageUpper string `json:"Message"`
+	}
+	b, _ := io.ReadAll(resp.Body)
+	_ = json.Unmarshal(b, &msg)
+	text := msg.Message
+	if text == "" {
+		text = msg.MessageUpper
+	}
+	if text == "" {
+		text = strings.TrimSpace(string(b))
+	}
+	errType, _, _ := strings.Cut(resp.Header.Get("X-Amzn-ErrorType"), ":")
+	if errType == "" {
+		errType = http.StatusText(resp.StatusCode)
+	}
+	return fmt.Errorf("AWS Batch %s: %s: %s (HTTP %d)", path, errType, text, resp.StatusCode)
+}
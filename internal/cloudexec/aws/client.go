@@ -3,6 +3,12 @@ package aws
 import (
 	"context"
 	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 
 	batchpkg "github.com/alphauslabs/jennah/internal/cloudexec"
 )
@@ -12,21 +18,44 @@ func init() {
 	batchpkg.RegisterAWSProvider(NewAWSBatchProvider)
 }
 
+// Reasons passed to TerminateJob. AWS Batch reports terminated jobs as FAILED
+// with the reason as statusReason, which is how cancellations are told apart
+// from real failures.
+const (
+	cancelReason = "Cancelled by Jennah"
+	deleteReason = "Deleted by Jennah"
+)
+
+// jobNamePrefix matches the names of the jobs Jennah submits (see
+// JobConfig.JobID), so ListJobs only returns those.
+const jobNamePrefix = "jennah-"
+
+// AWS Batch limits.
+const (
+	maxArraySize       = 10000
+	maxRetryAttempts   = 10
+	minAttemptDuration = 60
+)
+
 // AWSBatchProvider implements the batch.Provider interface for AWS Batch.
-// This is a stub implementation showing the structure for AWS Batch integration.
+//
+// Each job registers its own container job definition named after the job ID,
+// then submits it to the configured job queue. Spot capacity comes from the
+// compute environment behind the queue, so jobs with UseSpotVMs go to the
+// "spot_job_queue" option when it is set. Jobs with TaskGroup.TaskCount > 1
+// are submitted as array jobs; AWS sets AWS_BATCH_JOB_ARRAY_INDEX in each
+// child and the provider adds BATCH_TASK_COUNT.
 type AWSBatchProvider struct {
-	// AWS Batch client would be initialized here
-	// client    *batch.Client (from AWS SDK)
-	accountID string
-	region    string
-	jobQueue  string
-}
-
-// NewAWSBatchProvider creates a new AWS Batch provider.
-// NOTE: This is a stub implementation. Full implementation would require:
-// - AWS SDK for Go v2: github.com/aws/aws-sdk-go-v2/service/batch
-// - Proper AWS credentials configuration
-// - Job queue and compute environment setup
+	api          *batchAPI
+	accountID    string
+	region       string
+	jobQueue     string
+	spotJobQueue string
+}
+
+// NewAWSBatchProvider creates a new AWS Batch provider. Credentials come from
+// the default AWS chain (environment, shared config, IAM role). The "endpoint"
+// option overrides the Batch API endpoint, e.g. for a local stand-in.
 func NewAWSBatchProvider(ctx context.Context, config batchpkg.ProviderConfig) (batchpkg.Provider, error) {
 	accountID := config.ProviderOptions["account_id"]
 	if accountID == "" {
@@ -42,148 +71,281 @@ func NewAWSBatchProvider(ctx context.Context, config batchpkg.ProviderConfig) (b
 		return nil, fmt.Errorf("region is required for AWS batch provider")
 	}
 
-	// TODO: Initialize AWS Batch client
-	// cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(config.Region))
-	// if err != nil {
-	//     return nil, fmt.Errorf("failed to load AWS config: %w", err)
-	// }
-	// client := batch.NewFromConfig(cfg)
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(config.Region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
 
 	return &AWSBatchProvider{
-		accountID: accountID,
-		region:    config.Region,
-		jobQueue:  jobQueue,
+		api:          newBatchAPI(config.ProviderOptions["endpoint"], config.Region, cfg.Credentials),
+		accountID:    accountID,
+		region:       config.Region,
+		jobQueue:     jobQueue,
+		spotJobQueue: config.ProviderOptions["spot_job_queue"],
 	}, nil
 }
 
-// SubmitJob submits a new batch job to AWS Batch.
-// NOTE: Stub implementation - returns not implemented error.
+// SubmitJob registers a job definition for the job and submits it to AWS Batch.
+// The returned CloudResourcePath is the job ARN, e.g.
+// arn:aws:batch:us-east-1:123456789012:job/0f1e2d3c-....
 func (p *AWSBatchProvider) SubmitJob(ctx context.Context, config batchpkg.JobConfig) (*batchpkg.JobResult, error) {
-	// Full implementation would:
-	// 1. Create AWS Batch RegisterJobDefinition request with container properties
-	// 2. Submit job using SubmitJob API with job definition and job queue
-	// 3. Return job ARN as CloudResourcePath
-	//
-	// Example ARN format:
-	// arn:aws:batch:us-east-1:123456789012:job/jennah-abc12345
-
-	return nil, fmt.Errorf("AWS Batch provider not fully implemented yet")
-
-	// Example implementation sketch:
-	// jobDefinition := &batch.RegisterJobDefinitionInput{
-	//     JobDefinitionName: aws.String(config.JobID),
-	//     Type:              types.JobDefinitionTypeContainer,
-	//     ContainerProperties: &types.ContainerProperties{
-	//         Image: aws.String(config.ImageURI),
-	//         Environment: convertEnvVars(config.EnvVars),
-	//         ResourceRequirements: []types.ResourceRequirement{
-	//             {Type: types.ResourceTypeVcpu, Value: aws.String(fmt.Sprintf("%.1f", float64(config.Resources.CPUMillis)/1000))},
-	//             {Type: types.ResourceTypeMemory, Value: aws.String(fmt.Sprintf("%d", config.Resources.MemoryMiB))},
-	//         },
-	//     },
-	// }
-	//
-	// submitInput := &batch.SubmitJobInput{
-	//     JobName:       aws.String(config.JobID),
-	//     JobQueue:      aws.String(p.jobQueue),
-	//     JobDefinition: jobDefOutput.JobDefinitionArn,
-	// }
-	//
-	// result, err := p.client.SubmitJob(ctx, submitInput)
-	// return &batchpkg.JobResult{
-	//     CloudResourcePath: *result.JobArn,
-	//     InitialStatus:     batchpkg.JobStatusPending,
-	// }, nil
+	def := buildJobDefinition(config)
+	registered, err := p.api.registerJobDefinition(ctx, def)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register job definition: %w", err)
+	}
+
+	input := &submitJobInput{
+		JobName:       config.JobID,
+		JobQueue:      p.queueFor(config),
+		JobDefinition: registered.JobDefinitionArn,
+		Tags:          config.JobLabels,
+	}
+	if taskCount := taskCountOf(config); taskCount > 1 {
+		input.ArrayProperties = &arrayProperties{Size: taskCount}
+	}
+
+	submitted, err := p.api.submitJob(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit AWS Batch job: %w", err)
+	}
+
+	jobARN := submitted.JobArn
+	if jobARN == "" {
+		jobARN = fmt.Sprintf("arn:aws:batch:%s:%s:job/%s", p.region, p.accountID, submitted.JobId)
+	}
+	log.Printf("AWS Batch job submitted: %s (queue: %s)", jobARN, input.JobQueue)
+
+	return &batchpkg.JobResult{
+		CloudResourcePath: jobARN,
+		InitialStatus:     batchpkg.JobStatusPending,
+	}, nil
+}
+
+// buildJobDefinition maps a JobConfig onto a container job definition.
+func buildJobDefinition(config batchpkg.JobConfig) *registerJobDefinitionInput {
+	container := &containerProperties{
+		Image:   config.ImageURI,
+		Command: config.Commands,
+	}
+
+	envKeys := make([]string, 0, len(config.EnvVars))
+	for k := range config.EnvVars {
+		envKeys = append(envKeys, k)
+	}
+	sort.Strings(envKeys)
+	for _, k := range envKeys {
+		container.Environment = append(container.Environment, keyValuePair{Name: k, Value: config.EnvVars[k]})
+	}
+	if taskCount := taskCountOf(config); taskCount > 1 {
+		container.Environment = append(container.Environment, keyValuePair{Name: "BATCH_TASK_COUNT", Value: strconv.FormatInt(taskCount, 10)})
+	}
+
+	if config.ContainerEntrypoint != "" {
+		log.Printf("Warning: AWS Batch job definitions cannot override the entrypoint; ignoring %q for job %s", config.ContainerEntrypoint, config.JobID)
+	}
+	if config.TaskGroup != nil && config.TaskGroup.Parallelism > 0 {
+		log.Printf("Warning: AWS Batch array jobs have no parallelism limit; ignoring parallelism %d for job %s", config.TaskGroup.Parallelism, config.JobID)
+	}
+
+	def := &registerJobDefinitionInput{
+		JobDefinitionName:   config.JobID,
+		Type:                "container",
+		ContainerProperties: container,
+		Tags:                config.JobLabels,
+		PropagateTags:       len(config.JobLabels) > 0,
+	}
+
+	if r := config.Resources; r != nil {
+		if r.CPUMillis > 0 {
+			vcpus := strconv.FormatFloat(float64(r.CPUMillis)/1000, 'f', -1, 64)
+			container.ResourceRequirements = append(container.ResourceRequirements, resourceRequirement{Type: "VCPU", Value: vcpus})
+		}
+		if r.MemoryMiB > 0 {
+			container.ResourceRequirements = append(container.ResourceRequirements, resourceRequirement{Type: "MEMORY", Value: strconv.FormatInt(r.MemoryMiB, 10)})
+		}
+		if r.MaxRunDurationSeconds > 0 {
+			// AWS Batch rejects attempt timeouts under a minute.
+			def.Timeout = &jobTimeout{AttemptDurationSeconds: max(r.MaxRunDurationSeconds, minAttemptDuration)}
+		}
+	}
+	if a := config.Accelerators; a != nil && a.Count > 0 {
+		container.ResourceRequirements = append(container.ResourceRequirements, resourceRequirement{Type: "GPU", Value: strconv.FormatInt(a.Count, 10)})
+	}
+
+	if config.MaxRetryCount > 0 {
+		def.RetryStrategy = &retryStrategy{Attempts: min(config.MaxRetryCount+1, maxRetryAttempts)}
+	}
+
+	return def
+}
+
+func taskCountOf(config batchpkg.JobConfig) int64 {
+	if config.TaskGroup == nil || config.TaskGroup.TaskCount <= 1 {
+		return 1
+	}
+	return min(config.TaskGroup.TaskCount, maxArraySize)
+}
+
+// queueFor picks the job queue: the spot queue for UseSpotVMs jobs when one
+// is configured, the default queue otherwise.
+func (p *AWSBatchProvider) queueFor(config batchpkg.JobConfig) string {
+	if config.UseSpotVMs {
+		if p.spotJobQueue != "" {
+			return p.spotJobQueue
+		}
+		log.Printf("Warning: job %s requests spot capacity but no spot job queue is configured; using %s", config.JobID, p.jobQueue)
+	}
+	return p.jobQueue
 }
 
 // GetJobStatus retrieves the current status of an AWS Batch job.
-// NOTE: Stub implementation - returns not implemented error.
+// Jobs terminated by CancelJob are reported as cancelled rather than failed.
 func (p *AWSBatchProvider) GetJobStatus(ctx context.Context, cloudResourcePath string) (batchpkg.JobStatus, error) {
-	// Full implementation would:
-	// 1. Extract job ID from ARN
-	// 2. Call DescribeJobs API
-	// 3. Map AWS Batch status to Jennah status
-	//
-	// AWS Batch states: SUBMITTED, PENDING, RUNNABLE, STARTING, RUNNING, SUCCEEDED, FAILED
-	// Mapping:
-	//   SUBMITTED/PENDING -> JobStatusPending
-	//   RUNNABLE/STARTING -> JobStatusScheduled
-	//   RUNNING -> JobStatusRunning
-	//   SUCCEEDED -> JobStatusCompleted
-	//   FAILED -> JobStatusFailed
-
-	return batchpkg.JobStatusUnknown, fmt.Errorf("AWS Batch provider not fully implemented yet")
-}
-
-// CancelJob cancels a running AWS Batch job.
-// NOTE: Stub implementation - returns not implemented error.
-func (p *AWSBatchProvider) CancelJob(ctx context.Context, cloudResourcePath string) error {
-	// Full implementation would:
-	// 1. Extract job ID from ARN
-	// 2. Call TerminateJob API with reason
-	//
-	// input := &batch.TerminateJobInput{
-	//     JobId:  aws.String(jobID),
-	//     Reason: aws.String("Cancelled by user"),
-	// }
-	// _, err := p.client.TerminateJob(ctx, input)
+	job, err := p.api.describeJob(ctx, jobIDFromARN(cloudResourcePath))
+	if err != nil {
+		return batchpkg.JobStatusUnknown, fmt.Errorf("failed to get job status: %w", err)
+	}
 
-	return fmt.Errorf("AWS Batch provider not fully implemented yet")
+	status := mapAWSStatusToJennah(job.Status)
+	if status == batchpkg.JobStatusFailed && job.StatusReason == cancelReason {
+		return batchpkg.JobStatusCancelled, nil
+	}
+	return status, nil
+}
+
+// GetJobFailure explains why an AWS Batch job failed. Spot reclamation shows
+// up as "Host EC2 (instance i-...) terminated." and is reported as preemption.
+func (p *AWSBatchProvider) GetJobFailure(ctx context.Context, cloudResourcePath string) (*batchpkg.FailureDetail, error) {
+	job, err := p.api.describeJob(ctx, jobIDFromARN(cloudResourcePath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job failure: %w", err)
+	}
+
+	detail := &batchpkg.FailureDetail{Cause: batchpkg.FailureCauseUnknown}
+	reasons := []string{}
+	if job.StatusReason != "" {
+		reasons = append(reasons, job.StatusReason)
+	}
+
+	container := job.Container
+	if n := len(job.Attempts); n > 0 {
+		last := job.Attempts[n-1]
+		if last.StatusReason != "" && last.StatusReason != job.StatusReason {
+			reasons = append(reasons, last.StatusReason)
+		}
+		if last.Container != nil {
+			container = last.Container
+		}
+	}
+	if container != nil {
+		if container.Reason != "" {
+			reasons = append(reasons, container.Reason)
+		}
+		if container.ExitCode != nil {
+			reasons = append(reasons, fmt.Sprintf("exit code %d", *container.ExitCode))
+		}
+	}
+	detail.Message = strings.Join(reasons, "; ")
+
+	for _, r := range reasons {
+		if strings.HasPrefix(r, "Host EC2") && strings.Contains(r, "terminated") {
+			detail.Cause = batchpkg.FailureCausePreempted
+			break
+		}
+	}
+	return detail, nil
+}
+
+// CancelJob terminates an AWS Batch job. Jobs that have not started yet are
+// cancelled, running ones are stopped.
+func (p *AWSBatchProvider) CancelJob(ctx context.Context, cloudResourcePath string) error {
+	if err := p.api.terminateJob(ctx, jobIDFromARN(cloudResourcePath), cancelReason); err != nil {
+		return fmt.Errorf("failed to cancel job: %w", err)
+	}
+	log.Printf("AWS Batch job cancelled: %s", cloudResourcePath)
+	return nil
 }
 
-// DeleteJob deletes an AWS Batch job.
-// NOTE: Stub implementation - returns not implemented error.
+// DeleteJob terminates an AWS Batch job if it is still active and deregisters
+// its job definition. AWS Batch keeps finished jobs for a while and then
+// expires them; they cannot be deleted explicitly.
 func (p *AWSBatchProvider) DeleteJob(ctx context.Context, cloudResourcePath string) error {
-	// Full implementation would:
-	// 1. Extract job ID from ARN
-	// 2. Call TerminateJob if still running
-	// 3. Call DeleteJobDefinition to clean up job definition
-	//
-	// input := &batch.TerminateJobInput{
-	//     JobId:  aws.String(jobID),
-	//     Reason: aws.String("Deleted by user"),
-	// }
-	// _, err := p.client.TerminateJob(ctx, input)
-
-	return fmt.Errorf("AWS Batch provider not fully implemented yet")
-}
-
-// ListJobs lists all jobs in the AWS account/region.
-// NOTE: Stub implementation - returns not implemented error.
+	jobID := jobIDFromARN(cloudResourcePath)
+	job, err := p.api.describeJob(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to delete job: %w", err)
+	}
+
+	if job.Status != "SUCCEEDED" && job.Status != "FAILED" {
+		if err := p.api.terminateJob(ctx, jobID, deleteReason); err != nil {
+			return fmt.Errorf("failed to delete job: %w", err)
+		}
+	}
+	if job.JobDefinition != "" {
+		if err := p.api.deregisterJobDefinition(ctx, job.JobDefinition); err != nil {
+			return fmt.Errorf("failed to deregister job definition: %w", err)
+		}
+	}
+
+	log.Printf("AWS Batch job deleted: %s", cloudResourcePath)
+	return nil
+}
+
+// ListJobs lists the ARNs of Jennah jobs in the configured job queues, in
+// every status AWS Batch still retains.
 func (p *AWSBatchProvider) ListJobs(ctx context.Context) ([]string, error) {
-	// Full implementation would:
-	// 1. Call ListJobs API with job queue filter
-	// 2. Paginate through results
-	// 3. Return list of job ARNs
-	//
-	// input := &batch.ListJobsInput{
-	//     JobQueue: aws.String(p.jobQueue),
-	// }
-	// paginator := batch.NewListJobsPaginator(p.client, input)
-	// var jobARNs []string
-	// for paginator.HasMorePages() {
-	//     page, err := paginator.NextPage(ctx)
-	//     for _, job := range page.JobSummaryList {
-	//         jobARNs = append(jobARNs, *job.JobArn)
-	//     }
-	// }
-
-	return nil, fmt.Errorf("AWS Batch provider not fully implemented yet")
+	queues := []string{p.jobQueue}
+	if p.spotJobQueue != "" && p.spotJobQueue != p.jobQueue {
+		queues = append(queues, p.spotJobQueue)
+	}
+
+	var jobARNs []string
+	for _, queue := range queues {
+		// A JOB_NAME filter makes AWS Batch return jobs in all statuses
+		// instead of only RUNNING ones.
+		input := &listJobsInput{
+			JobQueue: queue,
+			Filters:  []listJobsFilter{{Name: "JOB_NAME", Values: []string{jobNamePrefix + "*"}}},
+		}
+		for {
+			page, err := p.api.listJobs(ctx, input)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list jobs: %w", err)
+			}
+			for _, job := range page.JobSummaryList {
+				jobARNs = append(jobARNs, job.JobArn)
+			}
+			if page.NextToken == "" {
+				break
+			}
+			input.NextToken = page.NextToken
+		}
+	}
+	return jobARNs, nil
 }
 
 // ServiceType returns the service type identifier for AWS Batch.
 func (p *AWSBatchProvider) ServiceType() string {
-	return "AWS_BATCH"
+	return batchpkg.ServiceTypeAWSBatch
 }
 
 // Close cleans up AWS Batch client resources.
 func (p *AWSBatchProvider) Close() error {
-	// AWS SDK v2 clients don't require explicit closing
+	// The HTTP client holds no resources that need explicit closing.
 	return nil
 }
 
+// jobIDFromARN extracts the job ID from a job ARN
+// (arn:aws:batch:<region>:<account>:job/<job-id>). Plain job IDs pass through.
+func jobIDFromARN(cloudResourcePath string) string {
+	if i := strings.LastIndex(cloudResourcePath, "job/"); i >= 0 {
+		return cloudResourcePath[i+len("job/"):]
+	}
+	return cloudResourcePath
+}
+
 // mapAWSStatusToJennah maps AWS Batch job states to Jennah status constants.
-// This function is provided for reference when implementing the full provider.
 func mapAWSStatusToJennah(awsStatus string) batchpkg.JobStatus {
 	switch awsStatus {
 	case "SUBMITTED", "PENDING":
@@ -201,24 +363,18 @@ func mapAWSStatusToJennah(awsStatus string) batchpkg.JobStatus {
 	}
 }
 
-// Implementation notes for future development:
-//
-// Required AWS SDK packages:
-//   go get github.com/aws/aws-sdk-go-v2/config
-//   go get github.com/aws/aws-sdk-go-v2/service/batch
-//
-// Prerequisites:
-// - AWS Batch job queue created
-// - Compute environment configured
-// - IAM permissions for batch:SubmitJob, batch:DescribeJobs, etc.
-// - Container image pushed to ECR
-//
 // Configuration example:
 //   BATCH_PROVIDER=aws
 //   BATCH_REGION=us-east-1
 //   AWS_ACCOUNT_ID=123456789012
 //   AWS_JOB_QUEUE=jennah-job-queue
+//   AWS_SPOT_JOB_QUEUE=jennah-spot-queue   # optional, for UseSpotVMs jobs
+//   AWS_BATCH_ENDPOINT=http://localhost:4566  # optional endpoint override
+//
+// Prerequisites:
+// - AWS Batch job queue(s) with compute environments (on-demand and spot)
+// - IAM permissions for batch:RegisterJobDefinition, batch:DeregisterJobDefinition,
+//   batch:SubmitJob, batch:DescribeJobs, batch:TerminateJob, batch:ListJobs
 //
 // References:
 // - AWS Batch API: https://docs.aws.amazon.com/batch/latest/APIReference/
-// - AWS SDK for Go v2: https://aws.github.io/aws-sdk-go-v2/docs/
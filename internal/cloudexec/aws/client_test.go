@@ -0,0 +1,337 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	batchpkg "github.com/alphauslabs/jennah/internal/cloudexec"
+)
+
+// fakeBatch is a local stand-in for the AWS Batch API.
+type fakeBatch struct {
+	t            *testing.T
+	mu           sync.Mutex
+	definitions  map[string]registerJobDefinitionInput
+	submitted    []submitJobInput
+	jobs         map[string]*jobDetail
+	queues       map[string][]string // queue -> job IDs
+	deregistered []string
+}
+
+func newFakeBatch(t *testing.T) (*fakeBatch, *AWSBatchProvider) {
+	t.Helper()
+	f := &fakeBatch{
+		t:           t,
+		definitions: map[string]registerJobDefinitionInput{},
+		jobs:        map[string]*jobDetail{},
+		queues:      map[string][]string{},
+	}
+	server := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(server.Close)
+
+	creds := aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+		return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+	})
+	p := &AWSBatchProvider{
+		api:          newBatchAPI(server.URL, "us-east-1", creds),
+		accountID:    "123456789012",
+		region:       "us-east-1",
+		jobQueue:     "on-demand",
+		spotJobQueue: "spot",
+	}
+	return f, p
+}
+
+func (f *fakeBatch) serve(w http.ResponseWriter, r *http.Request) {
+	if auth := r.Header.Get("Authorization"); !strings.HasPrefix(auth, "AWS4-HMAC-SHA256") || !strings.Contains(auth, "/us-east-1/batch/aws4_request") {
+		w.Header().Set("X-Amzn-ErrorType", "UnrecognizedClientException")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message":"missing signature"}`)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	decode := func(v any) {
+		if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+			f.t.Errorf("%s: bad request body: %v", r.URL.Path, err)
+		}
+	}
+	reply := func(v any) { _ = json.NewEncoder(w).Encode(v) }
+
+	switch r.URL.Path {
+	case "/v1/registerjobdefinition":
+		var in registerJobDefinitionInput
+		decode(&in)
+		f.definitions[in.JobDefinitionName] = in
+		reply(registerJobDefinitionOutput{
+			JobDefinitionArn:  "arn:aws:batch:us-east-1:123456789012:job-definition/" + in.JobDefinitionName + ":1",
+			JobDefinitionName: in.JobDefinitionName,
+			Revision:          1,
+		})
+	case "/v1/submitjob":
+		var in submitJobInput
+		decode(&in)
+		f.submitted = append(f.submitted, in)
+		id := fmt.Sprintf("id-%d", len(f.submitted))
+		arn := "arn:aws:batch:us-east-1:123456789012:job/" + id
+		f.jobs[id] = &jobDetail{JobArn: arn, JobId: id, JobName: in.JobName, JobDefinition: in.JobDefinition, Status: "SUBMITTED"}
+		f.queues[in.JobQueue] = append(f.queues[in.JobQueue], id)
+		reply(submitJobOutput{JobArn: arn, JobId: id, JobName: in.JobName})
+	case "/v1/describejobs":
+		var in struct{ Jobs []string }
+		decode(&in)
+		out := []jobDetail{}
+		for _, id := range in.Jobs {
+			if job, ok := f.jobs[id]; ok {
+				out = append(out, *job)
+			}
+		}
+		reply(map[string]any{"jobs": out})
+	case "/v1/terminatejob":
+		var in struct{ JobId, Reason string }
+		decode(&in)
+		job, ok := f.jobs[in.JobId]
+		if !ok {
+			w.Header().Set("X-Amzn-ErrorType", "ClientException")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message":"Job not found"}`)
+			return
+		}
+		job.Status, job.StatusReason = "FAILED", in.Reason
+		reply(struct{}{})
+	case "/v1/deregisterjobdefinition":
+		var in struct{ JobDefinition string }
+		decode(&in)
+		f.deregistered = append(f.deregistered, in.JobDefinition)
+		reply(struct{}{})
+	case "/v1/listjobs":
+		// One job per page, to exercise pagination.
+		var in listJobsInput
+		decode(&in)
+		if len(in.Filters) != 1 || in.Filters[0].Name != "JOB_NAME" {
+			f.t.Errorf("listjobs filters = %+v", in.Filters)
+		}
+		ids := f.queues[in.JobQueue]
+		start := 0
+		if in.NextToken != "" {
+			fmt.Sscanf(in.NextToken, "%d", &start)
+		}
+		out := listJobsOutput{JobSummaryList: []jobSummary{}}
+		if start < len(ids) {
+			job := f.jobs[ids[start]]
+			out.JobSummaryList = append(out.JobSummaryList, jobSummary{JobArn: job.JobArn, JobId: job.JobId, JobName: job.JobName, Status: job.Status})
+		}
+		if start+1 < len(ids) {
+			out.NextToken = fmt.Sprint(start + 1)
+		}
+		reply(out)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeBatch) setJob(id string, update func(*jobDetail)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	update(f.jobs[id])
+}
+
+func TestSubmitJobRegistersDefinitionAndArrayJob(t *testing.T) {
+	f, p := newFakeBatch(t)
+
+	result, err := p.SubmitJob(context.Background(), batchpkg.JobConfig{
+		JobID:         "jennah-abc12345",
+		ImageURI:      "123456789012.dkr.ecr.us-east-1.amazonaws.com/app:latest",
+		Commands:      []string{"python", "main.py"},
+		EnvVars:       map[string]string{"B": "2", "A": "1"},
+		Resources:     &batchpkg.ResourceRequirements{CPUMillis: 1500, MemoryMiB: 2048, MaxRunDurationSeconds: 30},
+		TaskGroup:     &batchpkg.TaskGroupConfig{TaskCount: 4},
+		MaxRetryCount: 2,
+		UseSpotVMs:    true,
+		JobLabels:     map[string]string{"team": "data"},
+	})
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	if result.CloudResourcePath != "arn:aws:batch:us-east-1:123456789012:job/id-1" || result.InitialStatus != batchpkg.JobStatusPending {
+		t.Errorf("result = %+v", result)
+	}
+
+	def := f.definitions["jennah-abc12345"]
+	if def.Type != "container" || def.ContainerProperties.Image != "123456789012.dkr.ecr.us-east-1.amazonaws.com/app:latest" {
+		t.Errorf("definition = %+v", def)
+	}
+	if got := strings.Join(def.ContainerProperties.Command, " "); got != "python main.py" {
+		t.Errorf("command = %q", got)
+	}
+	wantEnv := []keyValuePair{{"A", "1"}, {"B", "2"}, {"BATCH_TASK_COUNT", "4"}}
+	if fmt.Sprint(def.ContainerProperties.Environment) != fmt.Sprint(wantEnv) {
+		t.Errorf("environment = %v, want %v", def.ContainerProperties.Environment, wantEnv)
+	}
+	wantResources := []resourceRequirement{{"VCPU", "1.5"}, {"MEMORY", "2048"}}
+	if fmt.Sprint(def.ContainerProperties.ResourceRequirements) != fmt.Sprint(wantResources) {
+		t.Errorf("resources = %v, want %v", def.ContainerProperties.ResourceRequirements, wantResources)
+	}
+	if def.RetryStrategy == nil || def.RetryStrategy.Attempts != 3 {
+		t.Errorf("retry strategy = %+v, want 3 attempts", def.RetryStrategy)
+	}
+	if def.Timeout == nil || def.Timeout.AttemptDurationSeconds != minAttemptDuration {
+		t.Errorf("timeout = %+v, want the %ds minimum", def.Timeout, minAttemptDuration)
+	}
+
+	submitted := f.submitted[0]
+	if submitted.JobQueue != "spot" {
+		t.Errorf("queue = %q, want the spot queue", submitted.JobQueue)
+	}
+	if submitted.ArrayProperties == nil || submitted.ArrayProperties.Size != 4 {
+		t.Errorf("array properties = %+v, want size 4", submitted.ArrayProperties)
+	}
+	if submitted.JobDefinition != "arn:aws:batch:us-east-1:123456789012:job-definition/jennah-abc12345:1" || submitted.Tags["team"] != "data" {
+		t.Errorf("submitted = %+v", submitted)
+	}
+}
+
+func TestSubmitSingleTaskJobOnDefaultQueue(t *testing.T) {
+	f, p := newFakeBatch(t)
+
+	if _, err := p.SubmitJob(context.Background(), batchpkg.JobConfig{JobID: "jennah-single01", ImageURI: "busybox"}); err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	submitted := f.submitted[0]
+	if submitted.JobQueue != "on-demand" || submitted.ArrayProperties != nil {
+		t.Errorf("submitted = %+v, want the default queue and no array", submitted)
+	}
+	if def := f.definitions["jennah-single01"]; def.RetryStrategy != nil || def.Timeout != nil {
+		t.Errorf("definition = %+v, want no retry strategy or timeout", def)
+	}
+}
+
+func TestGetJobStatusMapsAWSStates(t *testing.T) {
+	ctx := context.Background()
+	f, p := newFakeBatch(t)
+
+	result, err := p.SubmitJob(ctx, batchpkg.JobConfig{JobID: "jennah-status01", ImageURI: "busybox"})
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+
+	for awsStatus, want := range map[string]batchpkg.JobStatus{
+		"SUBMITTED": batchpkg.JobStatusPending,
+		"PENDING":   batchpkg.JobStatusPending,
+		"RUNNABLE":  batchpkg.JobStatusScheduled,
+		"STARTING":  batchpkg.JobStatusScheduled,
+		"RUNNING":   batchpkg.JobStatusRunning,
+		"SUCCEEDED": batchpkg.JobStatusCompleted,
+		"FAILED":    batchpkg.JobStatusFailed,
+	} {
+		f.setJob("id-1", func(j *jobDetail) { j.Status = awsStatus })
+		got, err := p.GetJobStatus(ctx, result.CloudResourcePath)
+		if err != nil {
+			t.Fatalf("GetJobStatus(%s): %v", awsStatus, err)
+		}
+		if got != want {
+			t.Errorf("%s: status = %s, want %s", awsStatus, got, want)
+		}
+	}
+
+	if _, err := p.GetJobStatus(ctx, "arn:aws:batch:us-east-1:123456789012:job/missing"); err == nil {
+		t.Error("GetJobStatus of an unknown job succeeded")
+	}
+}
+
+func TestGetJobFailureDetectsSpotReclamation(t *testing.T) {
+	ctx := context.Background()
+	f, p := newFakeBatch(t)
+
+	result, err := p.SubmitJob(ctx, batchpkg.JobConfig{JobID: "jennah-spot0001", ImageURI: "busybox", UseSpotVMs: true})
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+
+	exitCode := int32(3)
+	f.setJob("id-1", func(j *jobDetail) {
+		j.Status, j.StatusReason = "FAILED", "Essential container in task exited"
+		j.Attempts = []attemptDetail{{Container: &containerDetail{ExitCode: &exitCode}}}
+	})
+	failure, err := p.GetJobFailure(ctx, result.CloudResourcePath)
+	if err != nil {
+		t.Fatalf("GetJobFailure: %v", err)
+	}
+	if failure.Cause != batchpkg.FailureCauseUnknown || failure.Message != "Essential container in task exited; exit code 3" {
+		t.Errorf("application failure = %+v", failure)
+	}
+
+	f.setJob("id-1", func(j *jobDetail) {
+		j.StatusReason = "Host EC2 (instance i-0abc) terminated."
+		j.Attempts = []attemptDetail{{StatusReason: "Host EC2 (instance i-0abc) terminated."}}
+	})
+	failure, err = p.GetJobFailure(ctx, result.CloudResourcePath)
+	if err != nil {
+		t.Fatalf("GetJobFailure: %v", err)
+	}
+	if failure.Cause != batchpkg.FailureCausePreempted {
+		t.Errorf("spot failure = %+v, want PREEMPTED", failure)
+	}
+}
+
+func TestCancelDeleteAndListJobs(t *testing.T) {
+	ctx := context.Background()
+	f, p := newFakeBatch(t)
+
+	var paths []string
+	for _, cfg := range []batchpkg.JobConfig{
+		{JobID: "jennah-list0001", ImageURI: "busybox"},
+		{JobID: "jennah-list0002", ImageURI: "busybox"},
+		{JobID: "jennah-list0003", ImageURI: "busybox", UseSpotVMs: true},
+	} {
+		result, err := p.SubmitJob(ctx, cfg)
+		if err != nil {
+			t.Fatalf("SubmitJob: %v", err)
+		}
+		paths = append(paths, result.CloudResourcePath)
+	}
+
+	listed, err := p.ListJobs(ctx)
+	if err != nil {
+		t.Fatalf("ListJobs: %v", err)
+	}
+	if strings.Join(listed, ",") != strings.Join(paths, ",") {
+		t.Errorf("ListJobs = %v, want %v", listed, paths)
+	}
+
+	if err := p.CancelJob(ctx, paths[0]); err != nil {
+		t.Fatalf("CancelJob: %v", err)
+	}
+	if status, err := p.GetJobStatus(ctx, paths[0]); err != nil || status != batchpkg.JobStatusCancelled {
+		t.Errorf("status after cancel = %s, %v; want CANCELLED", status, err)
+	}
+
+	f.setJob("id-2", func(j *jobDetail) { j.Status = "RUNNING" })
+	if err := p.DeleteJob(ctx, paths[1]); err != nil {
+		t.Fatalf("DeleteJob: %v", err)
+	}
+	f.mu.Lock()
+	deleted := *f.jobs["id-2"]
+	deregistered := f.deregistered
+	f.mu.Unlock()
+	if deleted.Status != "FAILED" || deleted.StatusReason != deleteReason {
+		t.Errorf("running job was not terminated on delete: %+v", deleted)
+	}
+	if len(deregistered) != 1 || !strings.HasSuffix(deregistered[0], "job-definition/jennah-list0002:1") {
+		t.Errorf("deregistered = %v", deregistered)
+	}
+
+	err = p.CancelJob(ctx, "arn:aws:batch:us-east-1:123456789012:job/missing")
+	if err == nil || !strings.Contains(err.Error(), "ClientException") {
+		t.Errorf("CancelJob of an unknown job: %v, want a ClientException", err)
+	}
+}
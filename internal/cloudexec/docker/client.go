@@ -0,0 +1,472 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	batchpkg "github.com/alphauslabs/jennah/internal/cloudexec"
+)
+
+func init() {
+	batchpkg.RegisterLocalDockerProvider(NewLocalDockerProvider)
+}
+
+// Labels attached to every task container. The job's state lives entirely in
+// these containers, so any worker can poll a job another worker submitted.
+const (
+	labelJob            = "jennah.job"
+	labelTaskIndex      = "jennah.task-index"
+	labelTaskCount      = "jennah.task-count"
+	labelParallelism    = "jennah.parallelism"
+	labelMaxRunDuration = "jennah.max-run-seconds"
+)
+
+// resourcePathPrefix prefixes the CloudResourcePath of local Docker jobs.
+const resourcePathPrefix = "local-docker/jobs/"
+
+// LocalDockerProvider implements the batch.Provider interface by running jobs
+// as containers on a local Docker Engine. It is meant for development and
+// end-to-end tests of the job lifecycle without cloud resources.
+//
+// A job with TaskGroup.TaskCount N becomes N containers named
+// "<jobID>-task-<i>", each with BATCH_TASK_INDEX and BATCH_TASK_COUNT set
+// like GCP Batch does. At most TaskGroup.Parallelism of them run at once; the
+// rest are started by GetJobStatus as running tasks finish.
+type LocalDockerProvider struct {
+	engine *engineClient
+
+	// cancelled remembers jobs cancelled through this provider, whose killed
+	// containers would otherwise look like failures. failures keeps the
+	// failure GetJobStatus observed, since stopping the remaining tasks
+	// leaves them looking failed as well.
+	mu        sync.Mutex
+	cancelled map[string]bool
+	failures  map[string]*batchpkg.FailureDetail
+}
+
+// NewLocalDockerProvider creates a provider for the Docker Engine at the
+// "docker_host" provider option, falling back to DOCKER_HOST and then the
+// default Unix socket.
+func NewLocalDockerProvider(ctx context.Context, config batchpkg.ProviderConfig) (batchpkg.Provider, error) {
+	host := config.ProviderOptions["docker_host"]
+	if host == "" {
+		host = os.Getenv("DOCKER_HOST")
+	}
+	if host == "" {
+		host = defaultDockerHost
+	}
+
+	engine, err := newEngineClient(host)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocalDockerProvider{
+		engine:    engine,
+		cancelled: make(map[string]bool),
+		failures:  make(map[string]*batchpkg.FailureDetail),
+	}, nil
+}
+
+// ServiceType returns the service type identifier for local Docker.
+func (p *LocalDockerProvider) ServiceType() string {
+	return batchpkg.ServiceTypeLocalDocker
+}
+
+// SubmitJob creates one container per task and starts as many as the job's
+// parallelism allows. The image is pulled when the engine does not have it.
+func (p *LocalDockerProvider) SubmitJob(ctx context.Context, config batchpkg.JobConfig) (*batchpkg.JobResult, error) {
+	if config.ImageURI == "" {
+		return nil, fmt.Errorf("image URI is required")
+	}
+
+	taskCount, parallelism := int64(1), int64(0)
+	if config.TaskGroup != nil {
+		if config.TaskGroup.TaskCount > 0 {
+			taskCount = config.TaskGroup.TaskCount
+		}
+		parallelism = config.TaskGroup.Parallelism
+	}
+	if parallelism <= 0 || parallelism > taskCount {
+		parallelism = taskCount
+	}
+
+	spec := containerSpec{
+		Image: config.ImageURI,
+		Cmd:   config.Commands,
+		Labels: map[string]string{
+			labelJob:         config.JobID,
+			labelTaskCount:   strconv.FormatInt(taskCount, 10),
+			labelParallelism: strconv.FormatInt(parallelism, 10),
+		},
+	}
+	if config.ContainerEntrypoint != "" {
+		spec.Entrypoint = []string{config.ContainerEntrypoint}
+	}
+	if r := config.Resources; r != nil {
+		spec.HostConfig.NanoCpus = r.CPUMillis * 1_000_000
+		spec.HostConfig.Memory = r.MemoryMiB * 1024 * 1024
+		if r.MaxRunDurationSeconds > 0 {
+			spec.Labels[labelMaxRunDuration] = strconv.FormatInt(r.MaxRunDurationSeconds, 10)
+		}
+	}
+	for k, v := range config.EnvVars {
+		spec.Env = append(spec.Env, k+"="+v)
+	}
+	sort.Strings(spec.Env)
+
+	ids := make([]string, 0, taskCount)
+	for i := int64(0); i < taskCount; i++ {
+		task := spec
+		task.Labels = cloneLabels(spec.Labels)
+		task.Labels[labelTaskIndex] = strconv.FormatInt(i, 10)
+		task.Env = append(append([]string(nil), spec.Env...),
+			"BATCH_TASK_INDEX="+strconv.FormatInt(i, 10),
+			"BATCH_TASK_COUNT="+strconv.FormatInt(taskCount, 10),
+		)
+
+		id, err := p.createTaskContainer(ctx, fmt.Sprintf("%s-task-%d", config.JobID, i), task)
+		if err != nil {
+			p.removeContainers(ctx, ids)
+			return nil, fmt.Errorf("failed to create container for task %d: %w", i, err)
+		}
+		ids = append(ids, id)
+	}
+
+	for _, id := range ids[:parallelism] {
+		if err := p.engine.startContainer(ctx, id); err != nil {
+			p.removeContainers(ctx, ids)
+			return nil, fmt.Errorf("failed to start container: %w", err)
+		}
+	}
+
+	log.Printf("Local Docker job %s started: %d task(s), parallelism %d, image %s", config.JobID, taskCount, parallelism, config.ImageURI)
+	return &batchpkg.JobResult{
+		CloudResourcePath: resourcePathPrefix + config.JobID,
+		InitialStatus:     batchpkg.JobStatusRunning,
+	}, nil
+}
+
+// createTaskContainer creates a container, pulling its image first if the
+// engine reports it missing.
+func (p *LocalDockerProvider) createTaskContainer(ctx context.Context, name string, spec containerSpec) (string, error) {
+	id, err := p.engine.createContainer(ctx, name, spec)
+	if errors.Is(err, errNotFound) {
+		log.Printf("Pulling image %s", spec.Image)
+		if err := p.engine.pullImage(ctx, spec.Image); err != nil {
+			return "", err
+		}
+		id, err = p.engine.createContainer(ctx, name, spec)
+	}
+	return id, err
+}
+
+// task is one task container with its inspected state.
+type task struct {
+	id     string
+	index  int
+	labels map[string]string
+	state  containerState
+}
+
+// tasks returns the job's task containers ordered by task index.
+func (p *LocalDockerProvider) tasks(ctx context.Context, jobID string) ([]task, error) {
+	containers, err := p.engine.listContainers(ctx, labelJob+"="+jobID)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]task, 0, len(containers))
+	for _, c := range containers {
+		inspect, err := p.engine.inspectContainer(ctx, c.Id)
+		if err != nil {
+			return nil, err
+		}
+		index, _ := strconv.Atoi(c.Labels[labelTaskIndex])
+		tasks = append(tasks, task{id: c.Id, index: index, labels: c.Labels, state: inspect.State})
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].index < tasks[j].index })
+	return tasks, nil
+}
+
+// GetJobStatus derives the job's status from its task containers:
+//   - any task exited non-zero, died or exceeded its max run duration: FAILED
+//     (the remaining tasks are stopped, like GCP Batch does);
+//   - every task exited 0: COMPLETED;
+//   - some task is running: RUNNING;
+//   - no task has started yet: SCHEDULED.
+//
+// Tasks held back by parallelism are started here as running ones finish.
+func (p *LocalDockerProvider) GetJobStatus(ctx context.Context, cloudResourcePath string) (batchpkg.JobStatus, error) {
+	jobID := strings.TrimPrefix(cloudResourcePath, resourcePathPrefix)
+	if p.isCancelled(jobID) {
+		return batchpkg.JobStatusCancelled, nil
+	}
+
+	tasks, err := p.tasks(ctx, jobID)
+	if err != nil {
+		return batchpkg.JobStatusUnknown, fmt.Errorf("failed to get job status: %w", err)
+	}
+	if len(tasks) == 0 {
+		return batchpkg.JobStatusUnknown, fmt.Errorf("failed to get job status: no containers for job %s", jobID)
+	}
+
+	running, succeeded, waiting := 0, 0, []task{}
+	for _, t := range tasks {
+		switch {
+		case taskFailed(t):
+			p.mu.Lock()
+			if p.failures[jobID] == nil {
+				p.failures[jobID] = describeFailure(t)
+			}
+			p.mu.Unlock()
+			p.stopTasks(ctx, tasks)
+			return batchpkg.JobStatusFailed, nil
+		case t.state.Status == "exited":
+			succeeded++
+		case t.state.Status == "created":
+			waiting = append(waiting, t)
+		default:
+			running++
+		}
+	}
+
+	parallelism, _ := strconv.Atoi(tasks[0].labels[labelParallelism])
+	if parallelism <= 0 {
+		parallelism = len(tasks)
+	}
+	for _, t := range waiting {
+		if running >= parallelism {
+			break
+		}
+		if err := p.engine.startContainer(ctx, t.id); err != nil {
+			return batchpkg.JobStatusUnknown, fmt.Errorf("failed to start task %d: %w", t.index, err)
+		}
+		running++
+	}
+
+	switch {
+	case succeeded == len(tasks):
+		return batchpkg.JobStatusCompleted, nil
+	case running > 0 || succeeded > 0:
+		return batchpkg.JobStatusRunning, nil
+	default:
+		return batchpkg.JobStatusScheduled, nil
+	}
+}
+
+// taskFailed reports whether a task container failed: a non-zero exit, a dead
+// container, or a running one past its max run duration.
+func taskFailed(t task) bool {
+	switch t.state.Status {
+	case "exited":
+		return t.state.ExitCode != 0
+	case "dead":
+		return true
+	case "running":
+		return overDeadline(t, time.Now())
+	}
+	return false
+}
+
+func overDeadline(t task, now time.Time) bool {
+	seconds, err := strconv.ParseInt(t.labels[labelMaxRunDuration], 10, 64)
+	started := t.state.startedAt()
+	if err != nil || seconds <= 0 || started.IsZero() {
+		return false
+	}
+	return now.Sub(started) > time.Duration(seconds)*time.Second
+}
+
+// GetJobFailure describes the task failure that failed the job.
+func (p *LocalDockerProvider) GetJobFailure(ctx context.Context, cloudResourcePath string) (*batchpkg.FailureDetail, error) {
+	jobID := strings.TrimPrefix(cloudResourcePath, resourcePathPrefix)
+	p.mu.Lock()
+	detail := p.failures[jobID]
+	p.mu.Unlock()
+	if detail != nil {
+		return detail, nil
+	}
+
+	// The failure was observed by another worker; report the task that
+	// finished first, which is the one that failed the job.
+	tasks, err := p.tasks(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job failure: %w", err)
+	}
+	var first *task
+	for i, t := range tasks {
+		if taskFailed(t) && (first == nil || t.state.FinishedAt < first.state.FinishedAt) {
+			first = &tasks[i]
+		}
+	}
+	if first == nil {
+		return &batchpkg.FailureDetail{Cause: batchpkg.FailureCauseUnknown}, nil
+	}
+	return describeFailure(*first), nil
+}
+
+func describeFailure(t task) *batchpkg.FailureDetail {
+	detail := &batchpkg.FailureDetail{Cause: batchpkg.FailureCauseUnknown}
+	switch {
+	case t.state.OOMKilled:
+		detail.Message = fmt.Sprintf("task %d was killed for exceeding its memory limit", t.index)
+	case t.state.Status == "running":
+		detail.Message = fmt.Sprintf("task %d exceeded its max run duration of %ss", t.index, t.labels[labelMaxRunDuration])
+	case t.state.Error != "":
+		detail.Message = fmt.Sprintf("task %d: %s", t.index, t.state.Error)
+	default:
+		detail.Message = fmt.Sprintf("task %d exited with code %d", t.index, t.state.ExitCode)
+	}
+	return detail
+}
+
+// GetJobLogs reads the output of all of the job's task containers.
+func (p *LocalDockerProvider) GetJobLogs(ctx context.Context, cloudResourcePath, pageToken string, pageSize int) (*batchpkg.LogPage, error) {
+	tasks, err := p.tasks(ctx, strings.TrimPrefix(cloudResourcePath, resourcePathPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job logs: %w", err)
+	}
+
+	var entries []batchpkg.LogEntry
+	for _, t := range tasks {
+		if t.state.Status == "created" {
+			continue
+		}
+		raw, err := p.engine.containerLogs(ctx, t.id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get logs of task %d: %w", t.index, err)
+		}
+		entries = append(entries, parseLogLines(raw, int64(t.index))...)
+	}
+	return batchpkg.PageLogEntries(entries, pageToken, pageSize)
+}
+
+// parseLogLines splits "<RFC3339Nano timestamp> <message>" lines.
+func parseLogLines(raw []byte, taskIndex int64) []batchpkg.LogEntry {
+	var entries []batchpkg.LogEntry
+	for _, line := range strings.Split(strings.TrimRight(string(raw), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		entry := batchpkg.LogEntry{TaskIndex: taskIndex, Message: line}
+		if ts, msg, ok := strings.Cut(line, " "); ok {
+			if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+				entry.Timestamp, entry.Message = t, msg
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// CancelJob kills the job's running tasks and removes the ones that never
+// started. The containers that ran are kept for inspection.
+func (p *LocalDockerProvider) CancelJob(ctx context.Context, cloudResourcePath string) error {
+	jobID := strings.TrimPrefix(cloudResourcePath, resourcePathPrefix)
+	tasks, err := p.tasks(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel job: %w", err)
+	}
+
+	p.mu.Lock()
+	p.cancelled[jobID] = true
+	p.mu.Unlock()
+
+	p.stopTasks(ctx, tasks)
+	log.Printf("Local Docker job %s cancelled", jobID)
+	return nil
+}
+
+// stopTasks kills running tasks and removes tasks that never started.
+func (p *LocalDockerProvider) stopTasks(ctx context.Context, tasks []task) {
+	for _, t := range tasks {
+		var err error
+		switch t.state.Status {
+		case "created":
+			err = p.engine.removeContainer(ctx, t.id)
+		case "running", "paused", "restarting":
+			err = p.engine.killContainer(ctx, t.id)
+		}
+		if err != nil && !errors.Is(err, errNotFound) {
+			log.Printf("Warning: failed to stop task container %s: %v", t.id, err)
+		}
+	}
+}
+
+// DeleteJob removes all of the job's containers, killing running ones.
+func (p *LocalDockerProvider) DeleteJob(ctx context.Context, cloudResourcePath string) error {
+	jobID := strings.TrimPrefix(cloudResourcePath, resourcePathPrefix)
+	containers, err := p.engine.listContainers(ctx, labelJob+"="+jobID)
+	if err != nil {
+		return fmt.Errorf("failed to delete job: %w", err)
+	}
+	ids := make([]string, 0, len(containers))
+	for _, c := range containers {
+		ids = append(ids, c.Id)
+	}
+	if err := p.removeContainers(ctx, ids); err != nil {
+		return fmt.Errorf("failed to delete job: %w", err)
+	}
+
+	p.mu.Lock()
+	delete(p.cancelled, jobID)
+	delete(p.failures, jobID)
+	p.mu.Unlock()
+	return nil
+}
+
+// ListJobs lists the resource paths of all jobs with containers on the engine.
+func (p *LocalDockerProvider) ListJobs(ctx context.Context) ([]string, error) {
+	containers, err := p.engine.listContainers(ctx, labelJob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	seen := make(map[string]bool)
+	var paths []string
+	for _, c := range containers {
+		jobID := c.Labels[labelJob]
+		if !seen[jobID] {
+			seen[jobID] = true
+			paths = append(paths, resourcePathPrefix+jobID)
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Close is a no-op; the engine client holds no resources that need releasing.
+func (p *LocalDockerProvider) Close() error {
+	return nil
+}
+
+func (p *LocalDockerProvider) isCancelled(jobID string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cancelled[jobID]
+}
+
+func (p *LocalDockerProvider) removeContainers(ctx context.Context, ids []string) error {
+	var firstErr error
+	for _, id := range ids {
+		if err := p.engine.removeContainer(ctx, id); err != nil && !errors.Is(err, errNotFound) && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func cloneLabels(in map[string]string) map[string]string {
+	out := make(map[string]string, len(in)+1)
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
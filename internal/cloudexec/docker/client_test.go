@@ -0,0 +1,351 @@
+package docker
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	batchpkg "github.com/alphauslabs/jennah/internal/cloudexec"
+)
+
+type fakeContainer struct {
+	id    string
+	name  string
+	spec  containerSpec
+	state containerState
+	logs  []string // timestamped lines, as served by the logs endpoint
+}
+
+// fakeEngine emulates the subset of the Docker Engine API the provider uses.
+type fakeEngine struct {
+	mu         sync.Mutex
+	images     map[string]bool
+	containers map[string]*fakeContainer
+	nextID     int
+}
+
+func newFakeEngine(t *testing.T) (*fakeEngine, *LocalDockerProvider) {
+	t.Helper()
+	e := &fakeEngine{images: map[string]bool{}, containers: map[string]*fakeContainer{}}
+	server := httptest.NewServer(e.handler())
+	t.Cleanup(server.Close)
+
+	p, err := NewLocalDockerProvider(context.Background(), batchpkg.ProviderConfig{
+		ProviderOptions: map[string]string{"docker_host": "tcp://" + strings.TrimPrefix(server.URL, "http://")},
+	})
+	if err != nil {
+		t.Fatalf("NewLocalDockerProvider: %v", err)
+	}
+	return e, p.(*LocalDockerProvider)
+}
+
+func (e *fakeEngine) handler() http.Handler {
+	mux := http.NewServeMux()
+	prefix := "/" + apiVersion
+
+	mux.HandleFunc("POST "+prefix+"/images/create", func(w http.ResponseWriter, r *http.Request) {
+		e.mu.Lock()
+		e.images[r.URL.Query().Get("fromImage")] = true
+		e.mu.Unlock()
+		fmt.Fprintln(w, `{"status":"Pulling"}`)
+		fmt.Fprintln(w, `{"status":"Downloaded"}`)
+	})
+	mux.HandleFunc("POST "+prefix+"/containers/create", func(w http.ResponseWriter, r *http.Request) {
+		var spec containerSpec
+		if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if !e.images[spec.Image] {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"message":"No such image: %s"}`, spec.Image)
+			return
+		}
+		e.nextID++
+		c := &fakeContainer{id: fmt.Sprintf("c%d", e.nextID), name: r.URL.Query().Get("name"), spec: spec, state: containerState{Status: "created"}}
+		e.containers[c.id] = c
+		fmt.Fprintf(w, `{"Id":%q}`, c.id)
+	})
+	mux.HandleFunc("GET "+prefix+"/containers/json", func(w http.ResponseWriter, r *http.Request) {
+		var filters map[string][]string
+		_ = json.Unmarshal([]byte(r.URL.Query().Get("filters")), &filters)
+		key, value, hasValue := strings.Cut(filters["label"][0], "=")
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		out := []containerSummary{}
+		for _, c := range e.containers {
+			if v, ok := c.spec.Labels[key]; ok && (!hasValue || v == value) {
+				out = append(out, containerSummary{Id: c.id, Labels: c.spec.Labels, State: c.state.Status})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(out)
+	})
+	withContainer := func(fn func(w http.ResponseWriter, c *fakeContainer)) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			e.mu.Lock()
+			defer e.mu.Unlock()
+			c, ok := e.containers[r.PathValue("id")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"message":"No such container"}`)
+				return
+			}
+			fn(w, c)
+		}
+	}
+	mux.HandleFunc("GET "+prefix+"/containers/{id}/json", withContainer(func(w http.ResponseWriter, c *fakeContainer) {
+		_ = json.NewEncoder(w).Encode(containerInspect{Id: c.id, State: c.state})
+	}))
+	mux.HandleFunc("POST "+prefix+"/containers/{id}/start", withContainer(func(w http.ResponseWriter, c *fakeContainer) {
+		c.state.Status, c.state.Running = "running", true
+		c.state.StartedAt = time.Now().UTC().Format(time.RFC3339Nano)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	mux.HandleFunc("POST "+prefix+"/containers/{id}/kill", withContainer(func(w http.ResponseWriter, c *fakeContainer) {
+		c.state.Status, c.state.Running, c.state.ExitCode = "exited", false, 137
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	mux.HandleFunc("GET "+prefix+"/containers/{id}/logs", withContainer(func(w http.ResponseWriter, c *fakeContainer) {
+		for _, line := range c.logs {
+			header := make([]byte, 8)
+			header[0] = 1 // stdout
+			binary.BigEndian.PutUint32(header[4:], uint32(len(line)+1))
+			w.Write(header)
+			fmt.Fprintln(w, line)
+		}
+	}))
+	mux.HandleFunc("DELETE "+prefix+"/containers/{id}", withContainer(func(w http.ResponseWriter, c *fakeContainer) {
+		delete(e.containers, c.id)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	return mux
+}
+
+// byName returns the container with the given name, or nil.
+func (e *fakeEngine) byName(name string) *fakeContainer {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, c := range e.containers {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (e *fakeEngine) exit(t *testing.T, name string, code int) {
+	t.Helper()
+	c := e.byName(name)
+	if c == nil {
+		t.Fatalf("no container %s", name)
+	}
+	e.mu.Lock()
+	c.state.Status, c.state.Running, c.state.ExitCode = "exited", false, code
+	e.mu.Unlock()
+}
+
+func (e *fakeEngine) status(name string) string {
+	if c := e.byName(name); c != nil {
+		return c.state.Status
+	}
+	return "removed"
+}
+
+func assertJobStatus(t *testing.T, p *LocalDockerProvider, path string, want batchpkg.JobStatus) {
+	t.Helper()
+	got, err := p.GetJobStatus(context.Background(), path)
+	if err != nil {
+		t.Fatalf("GetJobStatus: %v", err)
+	}
+	if got != want {
+		t.Fatalf("status = %s, want %s", got, want)
+	}
+}
+
+func TestSubmitJobRunsTasksUpToParallelism(t *testing.T) {
+	ctx := context.Background()
+	e, p := newFakeEngine(t)
+
+	result, err := p.SubmitJob(ctx, batchpkg.JobConfig{
+		JobID:     "jennah-abc12345",
+		ImageURI:  "alpine:3",
+		Commands:  []string{"sh", "-c", "echo hi"},
+		EnvVars:   map[string]string{"FOO": "bar"},
+		Resources: &batchpkg.ResourceRequirements{CPUMillis: 500, MemoryMiB: 256},
+		TaskGroup: &batchpkg.TaskGroupConfig{TaskCount: 3, Parallelism: 2},
+	})
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	if result.CloudResourcePath != "local-docker/jobs/jennah-abc12345" {
+		t.Errorf("CloudResourcePath = %q", result.CloudResourcePath)
+	}
+	if !e.images["alpine:3"] {
+		t.Error("missing image was not pulled")
+	}
+
+	task1 := e.byName("jennah-abc12345-task-1")
+	if task1 == nil {
+		t.Fatal("task 1 container was not created")
+	}
+	spec := task1.spec
+	if spec.HostConfig.NanoCpus != 500_000_000 || spec.HostConfig.Memory != 256<<20 {
+		t.Errorf("limits = %+v", spec.HostConfig)
+	}
+	if strings.Join(spec.Cmd, " ") != "sh -c echo hi" {
+		t.Errorf("Cmd = %v", spec.Cmd)
+	}
+	env := strings.Join(spec.Env, ",")
+	for _, want := range []string{"FOO=bar", "BATCH_TASK_INDEX=1", "BATCH_TASK_COUNT=3"} {
+		if !strings.Contains(env, want) {
+			t.Errorf("Env %v is missing %s", spec.Env, want)
+		}
+	}
+
+	if e.status("jennah-abc12345-task-0") != "running" || e.status("jennah-abc12345-task-2") != "created" {
+		t.Fatalf("parallelism not applied: task-0=%s task-2=%s", e.status("jennah-abc12345-task-0"), e.status("jennah-abc12345-task-2"))
+	}
+	assertJobStatus(t, p, result.CloudResourcePath, batchpkg.JobStatusRunning)
+
+	e.exit(t, "jennah-abc12345-task-0", 0)
+	assertJobStatus(t, p, result.CloudResourcePath, batchpkg.JobStatusRunning)
+	if got := e.status("jennah-abc12345-task-2"); got != "running" {
+		t.Fatalf("held-back task was not started when a slot freed up: %s", got)
+	}
+
+	e.exit(t, "jennah-abc12345-task-1", 0)
+	e.exit(t, "jennah-abc12345-task-2", 0)
+	assertJobStatus(t, p, result.CloudResourcePath, batchpkg.JobStatusCompleted)
+
+	paths, err := p.ListJobs(ctx)
+	if err != nil || len(paths) != 1 || paths[0] != result.CloudResourcePath {
+		t.Errorf("ListJobs = %v, %v", paths, err)
+	}
+}
+
+func TestGetJobStatusFailsOnNonZeroExit(t *testing.T) {
+	ctx := context.Background()
+	e, p := newFakeEngine(t)
+	e.images["alpine:3"] = true
+
+	result, err := p.SubmitJob(ctx, batchpkg.JobConfig{
+		JobID:     "jennah-fail0001",
+		ImageURI:  "alpine:3",
+		TaskGroup: &batchpkg.TaskGroupConfig{TaskCount: 2},
+	})
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+
+	e.exit(t, "jennah-fail0001-task-1", 3)
+	assertJobStatus(t, p, result.CloudResourcePath, batchpkg.JobStatusFailed)
+	if got := e.status("jennah-fail0001-task-0"); got != "exited" {
+		t.Errorf("sibling task was not stopped: %s", got)
+	}
+
+	failure, err := p.GetJobFailure(ctx, result.CloudResourcePath)
+	if err != nil {
+		t.Fatalf("GetJobFailure: %v", err)
+	}
+	if failure.Cause != batchpkg.FailureCauseUnknown || !strings.Contains(failure.Message, "task 1 exited with code 3") {
+		t.Errorf("failure = %+v", failure)
+	}
+}
+
+func TestGetJobStatusEnforcesMaxRunDuration(t *testing.T) {
+	e, p := newFakeEngine(t)
+	e.images["alpine:3"] = true
+
+	result, err := p.SubmitJob(context.Background(), batchpkg.JobConfig{
+		JobID:     "jennah-slow0001",
+		ImageURI:  "alpine:3",
+		Resources: &batchpkg.ResourceRequirements{MaxRunDurationSeconds: 60},
+	})
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	assertJobStatus(t, p, result.CloudResourcePath, batchpkg.JobStatusRunning)
+
+	c := e.byName("jennah-slow0001-task-0")
+	e.mu.Lock()
+	c.state.StartedAt = time.Now().Add(-2 * time.Minute).UTC().Format(time.RFC3339Nano)
+	e.mu.Unlock()
+	assertJobStatus(t, p, result.CloudResourcePath, batchpkg.JobStatusFailed)
+	if got := e.status("jennah-slow0001-task-0"); got != "exited" {
+		t.Errorf("timed-out task was not killed: %s", got)
+	}
+}
+
+func TestCancelJobStopsTasks(t *testing.T) {
+	ctx := context.Background()
+	e, p := newFakeEngine(t)
+	e.images["alpine:3"] = true
+
+	result, err := p.SubmitJob(ctx, batchpkg.JobConfig{
+		JobID:     "jennah-cncl0001",
+		ImageURI:  "alpine:3",
+		TaskGroup: &batchpkg.TaskGroupConfig{TaskCount: 2, Parallelism: 1},
+	})
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	if err := p.CancelJob(ctx, result.CloudResourcePath); err != nil {
+		t.Fatalf("CancelJob: %v", err)
+	}
+
+	if got := e.status("jennah-cncl0001-task-0"); got != "exited" {
+		t.Errorf("running task = %s, want killed", got)
+	}
+	if got := e.status("jennah-cncl0001-task-1"); got != "removed" {
+		t.Errorf("unstarted task = %s, want removed", got)
+	}
+	assertJobStatus(t, p, result.CloudResourcePath, batchpkg.JobStatusCancelled)
+
+	if err := p.DeleteJob(ctx, result.CloudResourcePath); err != nil {
+		t.Fatalf("DeleteJob: %v", err)
+	}
+	if got := e.status("jennah-cncl0001-task-0"); got != "removed" {
+		t.Errorf("DeleteJob left task 0 %s", got)
+	}
+}
+
+func TestGetJobLogsMergesTaskOutput(t *testing.T) {
+	ctx := context.Background()
+	e, p := newFakeEngine(t)
+	e.images["alpine:3"] = true
+
+	result, err := p.SubmitJob(ctx, batchpkg.JobConfig{
+		JobID:     "jennah-logs0001",
+		ImageURI:  "alpine:3",
+		TaskGroup: &batchpkg.TaskGroupConfig{TaskCount: 3, Parallelism: 2},
+	})
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	e.byName("jennah-logs0001-task-0").logs = []string{"2026-01-01T00:00:01Z first", "2026-01-01T00:00:03Z third"}
+	e.byName("jennah-logs0001-task-1").logs = []string{"2026-01-01T00:00:02Z second"}
+
+	page, err := p.GetJobLogs(ctx, result.CloudResourcePath, "", 2)
+	if err != nil {
+		t.Fatalf("GetJobLogs: %v", err)
+	}
+	if len(page.Entries) != 2 || !page.More || page.Entries[0].Message != "first" || page.Entries[1].TaskIndex != 1 {
+		t.Fatalf("first page = %+v", page)
+	}
+	page, err = p.GetJobLogs(ctx, result.CloudResourcePath, page.NextPageToken, 2)
+	if err != nil {
+		t.Fatalf("GetJobLogs: %v", err)
+	}
+	if len(page.Entries) != 1 || page.More || page.Entries[0].Message != "third" {
+		t.Errorf("second page = %+v", page)
+	}
+}
@@ -0,0 +1,275 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// apiVersion is the Docker Engine API version the provider speaks. v1.41
+// shipped with Docker 20.10, so any current engine accepts it.
+const apiVersion = "v1.41"
+
+// defaultDockerHost is used when neither the docker_host option nor
+// DOCKER_HOST is set.
+const defaultDockerHost = "unix:///var/run/docker.sock"
+
+// errNotFound is returned for HTTP 404 responses from the engine.
+var errNotFound = errors.New("not found")
+
+// engineClient is a minimal Docker Engine API client over HTTP, covering only
+// the container and image calls the provider needs.
+type engineClient struct {
+	http    *http.Client
+	baseURL string
+}
+
+// newEngineClient connects to host, which is either "unix:///path/to/socket"
+// or a "tcp://", "http://" or "https://" address.
+func newEngineClient(host string) (*engineClient, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid docker host %q: %w", host, err)
+	}
+
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		transport := &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		}
+		return &engineClient{http: &http.Client{Transport: transport}, baseURL: "http://docker/" + apiVersion}, nil
+	case "tcp", "http":
+		return &engineClient{http: &http.Client{}, baseURL: "http://" + u.Host + "/" + apiVersion}, nil
+	case "https":
+		return &engineClient{http: &http.Client{}, baseURL: "https://" + u.Host + "/" + apiVersion}, nil
+	default:
+		return nil, fmt.Errorf("unsupported docker host scheme %q", u.Scheme)
+	}
+}
+
+// containerSpec is the body of POST /containers/create.
+type containerSpec struct {
+	Image      string            `json:"Image"`
+	Cmd        []string          `json:"Cmd,omitempty"`
+	Entrypoint []string          `json:"Entrypoint,omitempty"`
+	Env        []string          `json:"Env,omitempty"`
+	Labels     map[string]string `json:"Labels,omitempty"`
+	HostConfig hostConfig        `json:"HostConfig"`
+}
+
+type hostConfig struct {
+	NanoCpus int64 `json:"NanoCpus,omitempty"`
+	Memory   int64 `json:"Memory,omitempty"`
+}
+
+// containerSummary is one entry of GET /containers/json.
+type containerSummary struct {
+	Id     string            `json:"Id"`
+	Labels map[string]string `json:"Labels"`
+	State  string            `json:"State"`
+}
+
+// containerState is the State object of GET /containers/{id}/json.
+type containerState struct {
+	Status     string `json:"Status"`
+	Running    bool   `json:"Running"`
+	OOMKilled  bool   `json:"OOMKilled"`
+	ExitCode   int    `json:"ExitCode"`
+	Error      string `json:"Error"`
+	StartedAt  string `json:"StartedAt"`
+	FinishedAt string `json:"FinishedAt"`
+}
+
+// startedAt parses StartedAt; the engine reports the zero time for containers
+// that never started.
+func (s containerState) startedAt() time.Time {
+	t, err := time.Parse(time.RFC3339Nano, s.StartedAt)
+	if err != nil || t.Year() <= 1 {
+		return time.Time{}
+	}
+	return t
+}
+
+type containerInspect struct {
+	Id    string         `json:"Id"`
+	State containerState `json:"State"`
+}
+
+func (c *engineClient) createContainer(ctx context.Context, name string, spec containerSpec) (string, error) {
+	var created struct {
+		Id string `json:"Id"`
+	}
+	path := "/containers/create?name=" + url.QueryEscape(name)
+	if err := c.do(ctx, http.MethodPost, path, spec, &created); err != nil {
+		return "", err
+	}
+	return created.Id, nil
+}
+
+func (c *engineClient) startContainer(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPost, "/containers/"+id+"/start", nil, nil)
+}
+
+func (c *engineClient) killContainer(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPost, "/containers/"+id+"/kill", nil, nil)
+}
+
+func (c *engineClient) removeContainer(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/containers/"+id+"?force=true", nil, nil)
+}
+
+func (c *engineClient) inspectContainer(ctx context.Context, id string) (*containerInspect, error) {
+	var inspect containerInspect
+	if err := c.do(ctx, http.MethodGet, "/containers/"+id+"/json", nil, &inspect); err != nil {
+		return nil, err
+	}
+	return &inspect, nil
+}
+
+// listContainers returns all containers, running or not, carrying label
+// (either "key" or "key=value").
+func (c *engineClient) listContainers(ctx context.Context, label string) ([]containerSummary, error) {
+	filters, err := json.Marshal(map[string][]string{"label": {label}})
+	if err != nil {
+		return nil, err
+	}
+	var containers []containerSummary
+	path := "/containers/json?all=true&filters=" + url.QueryEscape(string(filters))
+	if err := c.do(ctx, http.MethodGet, path, nil, &containers); err != nil {
+		return nil, err
+	}
+	return containers, nil
+}
+
+// containerLogs returns a container's stdout and stderr with a timestamp
+// prefix on every line. Containers without a TTY stream logs in frames of an
+// 8-byte header (stream type, padding, big-endian payload size) followed by
+// the payload; the frames are joined in order.
+func (c *engineClient) containerLogs(ctx context.Context, id string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/containers/"+id+"/logs?stdout=true&stderr=true&timestamps=true", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("docker logs %s: %w", id, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return nil, responseError(resp, "logs "+id)
+	}
+
+	var out bytes.Buffer
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(resp.Body, header); err == io.EOF {
+			return out.Bytes(), nil
+		} else if err != nil {
+			return nil, fmt.Errorf("docker logs %s: %w", id, err)
+		}
+		size := int64(binary.BigEndian.Uint32(header[4:]))
+		if _, err := io.CopyN(&out, resp.Body, size); err != nil {
+			return nil, fmt.Errorf("docker logs %s: %w", id, err)
+		}
+	}
+}
+
+// pullImage pulls image (which may carry a tag or digest). The engine streams
+// progress messages; an error is only reported inside that stream.
+func (c *engineClient) pullImage(ctx context.Context, image string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/images/create?fromImage="+url.QueryEscape(image), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("docker pull %s: %w", image, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return responseError(resp, "pull "+image)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var msg struct {
+			Error string `json:"error"`
+		}
+		if err := dec.Decode(&msg); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("docker pull %s: %w", image, err)
+		}
+		if msg.Error != "" {
+			return fmt.Errorf("docker pull %s: %s", image, msg.Error)
+		}
+	}
+}
+
+// do sends a JSON request and decodes a JSON response into out, if given.
+// 304 Not Modified (already started/stopped) counts as success.
+func (c *engineClient) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("docker %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	if resp.StatusCode >= 300 {
+		return responseError(resp, method+" "+path)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("docker %s %s: failed to decode response: %w", method, path, err)
+	}
+	return nil
+}
+
+func responseError(resp *http.Response, op string) error {
+	var msg struct {
+		Message string `json:"message"`
+	}
+	b, _ := io.ReadAll(resp.Body)
+	if json.Unmarshal(b, &msg) != nil || msg.Message == "" {
+		msg.Message = strings.TrimSpace(string(b))
+	}
+	err := fmt.Errorf("docker %s: %s (HTTP %d)", op, msg.Message, resp.StatusCode)
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %w", errNotFound, err)
+	}
+	return err
+}
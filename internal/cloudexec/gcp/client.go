@@ -3,10 +3,12 @@ package gcp
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	batch "cloud.google.com/go/batch/apiv1"
 	"cloud.google.com/go/batch/apiv1/batchpb"
+	"google.golang.org/api/iterator"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	batchpkg "github.com/alphauslabs/jennah/internal/cloudexec"
@@ -20,8 +22,13 @@ func init() {
 // GCPBatchProvider implements the batch.Provider interface for Google Cloud Batch.
 type GCPBatchProvider struct {
 	client    *batch.Client
+	logs      *cloudLogReader
 	projectID string
 	region    string
+
+	// notificationTopic, if set, receives every job's state changes
+	// (projects/<project>/topics/<topic>).
+	notificationTopic string
 }
 
 // ServiceType returns the service type identifier for GCP Batch.
@@ -43,10 +50,19 @@ func NewGCPBatchProvider(ctx context.Context, config batchpkg.ProviderConfig) (b
 		return nil, fmt.Errorf("failed to create GCP Batch client: %w", err)
 	}
 
+	logs, err := newCloudLogReader(ctx, config.ProjectID)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
 	return &GCPBatchProvider{
 		client:    client,
+		logs:      logs,
 		projectID: config.ProjectID,
 		region:    config.Region,
+
+		notificationTopic: config.ProviderOptions["notification_topic"],
 	}, nil
 }
 
@@ -262,6 +278,14 @@ func (p *GCPBatchProvider) SubmitJob(ctx context.Context, config batchpkg.JobCon
 		job.Labels = config.JobLabels
 	}
 
+	// Publish state changes so workers need not poll every job.
+	if p.notificationTopic != "" {
+		job.Notifications = []*batchpb.JobNotification{{
+			PubsubTopic: p.notificationTopic,
+			Message:     &batchpb.JobNotification_Message{Type: batchpb.JobNotification_JOB_STATE_CHANGED},
+		}}
+	}
+
 	// Create job submission request
 	req := &batchpb.CreateJobRequest{
 		Parent:    parent,
@@ -298,6 +322,92 @@ func (p *GCPBatchProvider) GetJobStatus(ctx context.Context, cloudResourcePath s
 	return mapGCPStatusToJennah(job.Status.State), nil
 }
 
+// GetJobStatuses retrieves the status of several GCP Batch jobs with a single
+// filtered ListJobs call. Jobs are filtered and matched by job ID, since the
+// API may name the project by number where the stored path has its ID.
+func (p *GCPBatchProvider) GetJobStatuses(ctx context.Context, cloudResourcePaths []string) (map[string]batchpkg.JobStatus, error) {
+	byJobID := make(map[string]string, len(cloudResourcePaths))
+	filters := make([]string, 0, len(cloudResourcePaths))
+	for _, path := range cloudResourcePaths {
+		jobID := path[strings.LastIndex(path, "/")+1:]
+		byJobID[jobID] = path
+		// A job ID may be a prefix of another's; extra matches are dropped
+		// below.
+		filters = append(filters, fmt.Sprintf("name:%q", "/jobs/"+jobID))
+	}
+
+	it := p.client.ListJobs(ctx, &batchpb.ListJobsRequest{
+		Parent: fmt.Sprintf("projects/%s/locations/%s", p.projectID, p.region),
+		Filter: strings.Join(filters, " OR "),
+	})
+	statuses := make(map[string]batchpkg.JobStatus, len(cloudResourcePaths))
+	for {
+		job, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list GCP Batch jobs: %w", err)
+		}
+		if path, ok := byJobID[job.Name[strings.LastIndex(job.Name, "/")+1:]]; ok {
+			statuses[path] = mapGCPStatusToJennah(job.GetStatus().GetState())
+		}
+	}
+	return statuses, nil
+}
+
+// GetJobStatusDetail returns the description of the GCP Batch job's latest
+// status event, e.g. why it is still waiting for VMs.
+func (p *GCPBatchProvider) GetJobStatusDetail(ctx context.Context, cloudResourcePath string) (string, error) {
+	job, err := p.client.GetJob(ctx, &batchpb.GetJobRequest{Name: cloudResourcePath})
+	if err != nil {
+		return "", fmt.Errorf("failed to get GCP Batch job: %w", err)
+	}
+	return latestStatusEvent(job.GetStatus().GetStatusEvents()), nil
+}
+
+// latestStatusEvent returns the newest non-empty event description.
+func latestStatusEvent(events []*batchpb.StatusEvent) string {
+	var latest *batchpb.StatusEvent
+	for _, ev := range events {
+		if ev.GetDescription() == "" {
+			continue
+		}
+		if latest == nil || !ev.GetEventTime().AsTime().Before(latest.GetEventTime().AsTime()) {
+			latest = ev
+		}
+	}
+	return latest.GetDescription()
+}
+
+// gcpPreemptionExitCode is the task exit code GCP Batch reports when a Spot VM
+// is preempted.
+const gcpPreemptionExitCode = 50001
+
+// GetJobFailure explains a failed GCP Batch job from its status events.
+func (p *GCPBatchProvider) GetJobFailure(ctx context.Context, cloudResourcePath string) (*batchpkg.FailureDetail, error) {
+	job, err := p.client.GetJob(ctx, &batchpb.GetJobRequest{Name: cloudResourcePath})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GCP Batch job: %w", err)
+	}
+	return failureFromStatusEvents(job.GetStatus().GetStatusEvents()), nil
+}
+
+// failureFromStatusEvents reports PREEMPTED if any task exited with the
+// preemption code, and carries the latest event description as the message.
+func failureFromStatusEvents(events []*batchpb.StatusEvent) *batchpkg.FailureDetail {
+	detail := &batchpkg.FailureDetail{Cause: batchpkg.FailureCauseUnknown}
+	for _, ev := range events {
+		if ev.GetTaskExecution().GetExitCode() == gcpPreemptionExitCode {
+			detail.Cause = batchpkg.FailureCausePreempted
+		}
+		if ev.GetDescription() != "" {
+			detail.Message = ev.GetDescription()
+		}
+	}
+	return detail
+}
+
 // CancelJob cancels a running GCP Batch job.
 func (p *GCPBatchProvider) CancelJob(ctx context.Context, cloudResourcePath string) error {
 	req := &batchpb.DeleteJobRequest{
@@ -0,0 +1,71 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	batch "cloud.google.com/go/batch/apiv1"
+	"cloud.google.com/go/batch/apiv1/batchpb"
+	"google.golang.org/api/option"
+
+	batchpkg "github.com/alphauslabs/jennah/internal/cloudexec"
+)
+
+// fakeBatch serves jobs.list for jobs named with the project number, keeping
+// the jobs whose name contains one of the filter's name:"..." terms.
+type fakeBatch struct {
+	jobs    []*batchpb.Job
+	filters []string
+}
+
+func (f *fakeBatch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	filter := r.URL.Query().Get("filter")
+	f.filters = append(f.filters, filter)
+
+	var matched []json.RawMessage
+	for _, job := range f.jobs {
+		for _, term := range strings.Split(filter, " OR ") {
+			if substr, ok := strings.CutPrefix(term, "name:"); ok && strings.Contains(job.Name, strings.Trim(substr, `"`)) {
+				data, _ := json.Marshal(map[string]any{"name": job.Name, "status": map[string]string{"state": job.GetStatus().GetState().String()}})
+				matched = append(matched, data)
+				break
+			}
+		}
+	}
+	json.NewEncoder(w).Encode(map[string]any{"jobs": matched})
+}
+
+func TestGetJobStatusesMatchesProjectNumberNames(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeBatch{jobs: []*batchpb.Job{
+		{Name: "projects/123456789/locations/asia-northeast1/jobs/jennah-a", Status: &batchpb.JobStatus{State: batchpb.JobStatus_RUNNING}},
+		{Name: "projects/123456789/locations/asia-northeast1/jobs/jennah-a-r1", Status: &batchpb.JobStatus{State: batchpb.JobStatus_QUEUED}},
+		{Name: "projects/123456789/locations/asia-northeast1/jobs/jennah-b", Status: &batchpb.JobStatus{State: batchpb.JobStatus_SUCCEEDED}},
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client, err := batch.NewRESTClient(ctx, option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("NewRESTClient: %v", err)
+	}
+	defer client.Close()
+	p := &GCPBatchProvider{client: client, projectID: "my-project", region: "asia-northeast1"}
+
+	a := "projects/my-project/locations/asia-northeast1/jobs/jennah-a"
+	b := "projects/my-project/locations/asia-northeast1/jobs/jennah-b"
+	statuses, err := p.GetJobStatuses(ctx, []string{a, b})
+	if err != nil {
+		t.Fatalf("GetJobStatuses: %v", err)
+	}
+	if len(fake.filters) != 1 || strings.Contains(fake.filters[0], "my-project") {
+		t.Fatalf("filters = %q, want one request filtering on job IDs only", fake.filters)
+	}
+	if len(statuses) != 2 || statuses[a] != batchpkg.JobStatusRunning || statuses[b] != batchpkg.JobStatusCompleted {
+		t.Fatalf("statuses = %v, want %s RUNNING and %s COMPLETED", statuses, a, b)
+	}
+}
@@ -27,6 +27,7 @@ func init() {
 type GCPCloudRunProvider struct {
 	jobsClient      *run.JobsClient
 	executionClient *run.ExecutionsClient
+	logs            *cloudLogReader
 	projectID       string
 	region          string
 }
@@ -56,9 +57,17 @@ func NewGCPCloudRunProvider(ctx context.Context, config batchpkg.ProviderConfig)
 		return nil, fmt.Errorf("failed to create Cloud Run Executions client: %w", err)
 	}
 
+	logs, err := newCloudLogReader(ctx, config.ProjectID)
+	if err != nil {
+		jobsClient.Close()
+		executionClient.Close()
+		return nil, err
+	}
+
 	return &GCPCloudRunProvider{
 		jobsClient:      jobsClient,
 		executionClient: executionClient,
+		logs:            logs,
 		projectID:       config.ProjectID,
 		region:          config.Region,
 	}, nil
@@ -153,6 +162,7 @@ func (p *GCPCloudRunProvider) SubmitJob(ctx context.Context, config batchpkg.Job
 		Parent: parent,
 		JobId:  config.JobID,
 		Job: &runpb.Job{
+			Labels:   config.JobLabels,
 			Template: executionTemplate,
 			LaunchStage: api.LaunchStage_GA,
 		},
@@ -0,0 +1,183 @@
+package gcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/batch/apiv1/batchpb"
+	logging "google.golang.org/api/logging/v2"
+	"google.golang.org/api/option"
+
+	batchpkg "github.com/alphauslabs/jennah/internal/cloudexec"
+)
+
+// cloudLogReader reads job output from Cloud Logging, where both GCP Batch and
+// Cloud Run Jobs send container stdout and stderr.
+type cloudLogReader struct {
+	service   *logging.Service
+	projectID string
+}
+
+func newCloudLogReader(ctx context.Context, projectID string, opts ...option.ClientOption) (*cloudLogReader, error) {
+	service, err := logging.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cloud Logging client: %w", err)
+	}
+	return &cloudLogReader{service: service, projectID: projectID}, nil
+}
+
+// logCursor is the decoded form of a log page token. After and Seen record
+// how far the reader has got: the timestamp of the last entry returned and the
+// insert IDs returned at that instant. Once Cloud Logging runs dry, the next
+// read starts a fresh query from After, skipping Seen so no line repeats.
+// While a query still has more entries, PageToken continues it, and From and
+// Skip hold the position that query was started from.
+type logCursor struct {
+	PageToken string   `json:"p,omitempty"`
+	From      string   `json:"f,omitempty"`
+	Skip      []string `json:"k,omitempty"`
+	After     string   `json:"a,omitempty"`
+	Seen      []string `json:"s,omitempty"`
+}
+
+func decodeLogCursor(token string) (logCursor, error) {
+	var c logCursor
+	if token == "" {
+		return c, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, fmt.Errorf("invalid log page token: %w", err)
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return c, fmt.Errorf("invalid log page token: %w", err)
+	}
+	return c, nil
+}
+
+func (c logCursor) encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// read returns the page of entries matching filter that follows pageToken.
+// taskIndex extracts the task index from an entry's labels.
+func (r *cloudLogReader) read(ctx context.Context, filter, pageToken string, pageSize int, taskIndex func(map[string]string) int64) (*batchpkg.LogPage, error) {
+	cursor, err := decodeLogCursor(pageToken)
+	if err != nil {
+		return nil, err
+	}
+	if pageSize <= 0 {
+		pageSize = batchpkg.DefaultLogPageSize
+	}
+	from, skip := cursor.From, cursor.Skip
+	if cursor.PageToken == "" {
+		from, skip = cursor.After, cursor.Seen
+	}
+	if from != "" {
+		filter += fmt.Sprintf(` AND timestamp>=%q`, from)
+	}
+
+	resp, err := r.service.Entries.List(&logging.ListLogEntriesRequest{
+		ResourceNames: []string{"projects/" + r.projectID},
+		Filter:        filter,
+		OrderBy:       "timestamp asc",
+		PageSize:      int64(pageSize),
+		PageToken:     cursor.PageToken,
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list log entries: %w", err)
+	}
+
+	page := &batchpkg.LogPage{More: resp.NextPageToken != ""}
+	next := logCursor{After: cursor.After, Seen: cursor.Seen}
+	for _, e := range resp.Entries {
+		if e.Timestamp == from && slices.Contains(skip, e.InsertId) {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339Nano, e.Timestamp)
+		if err != nil {
+			continue
+		}
+		page.Entries = append(page.Entries, batchpkg.LogEntry{
+			Timestamp: ts,
+			TaskIndex: taskIndex(e.Labels),
+			Message:   logMessage(e),
+		})
+		if e.Timestamp != next.After {
+			next.After, next.Seen = e.Timestamp, nil
+		}
+		next.Seen = append(next.Seen, e.InsertId)
+	}
+
+	if page.More {
+		next.PageToken, next.From, next.Skip = resp.NextPageToken, from, skip
+	}
+	page.NextPageToken = next.encode()
+	return page, nil
+}
+
+// logMessage returns the text of a log entry. Structured entries written as
+// JSON are reduced to their "message" field when they have one.
+func logMessage(e *logging.LogEntry) string {
+	if e.TextPayload != "" {
+		return strings.TrimRight(e.TextPayload, "\n")
+	}
+	if len(e.JsonPayload) > 0 {
+		var payload struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(e.JsonPayload, &payload); err == nil && payload.Message != "" {
+			return payload.Message
+		}
+		return string(e.JsonPayload)
+	}
+	return ""
+}
+
+// GetJobLogs reads the task logs of a GCP Batch job from Cloud Logging.
+func (p *GCPBatchProvider) GetJobLogs(ctx context.Context, cloudResourcePath, pageToken string, pageSize int) (*batchpkg.LogPage, error) {
+	job, err := p.client.GetJob(ctx, &batchpb.GetJobRequest{Name: cloudResourcePath})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GCP Batch job: %w", err)
+	}
+	filter := fmt.Sprintf(`logName="projects/%s/logs/batch_task_logs" AND labels.job_uid=%q`, p.projectID, job.GetUid())
+	return p.logs.read(ctx, filter, pageToken, pageSize, batchTaskIndex)
+}
+
+// batchTaskIndex parses the task index out of the task_id label GCP Batch
+// attaches to task logs, e.g. "task/<job-uid>-group0-3/0/0".
+func batchTaskIndex(labels map[string]string) int64 {
+	id := labels["task_id"]
+	i := strings.LastIndex(id, "-group0-")
+	if i < 0 {
+		return 0
+	}
+	id = id[i+len("-group0-"):]
+	if j := strings.IndexByte(id, '/'); j >= 0 {
+		id = id[:j]
+	}
+	n, _ := strconv.ParseInt(id, 10, 64)
+	return n
+}
+
+// GetJobLogs reads the container output of a Cloud Run Job from Cloud Logging.
+func (p *GCPCloudRunProvider) GetJobLogs(ctx context.Context, cloudResourcePath, pageToken string, pageSize int) (*batchpkg.LogPage, error) {
+	// cloudResourcePath is "projects/{project}/locations/{location}/jobs/{job}".
+	parts := strings.Split(cloudResourcePath, "/")
+	if len(parts) != 6 || parts[2] != "locations" || parts[4] != "jobs" {
+		return nil, fmt.Errorf("invalid Cloud Run job path %q", cloudResourcePath)
+	}
+	filter := fmt.Sprintf(`resource.type="cloud_run_job" AND resource.labels.location=%q AND resource.labels.job_name=%q`+
+		` AND (log_id("run.googleapis.com/stdout") OR log_id("run.googleapis.com/stderr"))`, parts[3], parts[5])
+	return p.logs.read(ctx, filter, pageToken, pageSize, func(labels map[string]string) int64 {
+		n, _ := strconv.ParseInt(labels["run.googleapis.com/task_index"], 10, 64)
+		return n
+	})
+}
@@ -0,0 +1,100 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	logging "google.golang.org/api/logging/v2"
+	"google.golang.org/api/option"
+)
+
+// fakeLogging serves entries.list from a fixed, timestamp-ordered log and
+// records the filters it was queried with.
+type fakeLogging struct {
+	entries []*logging.LogEntry
+	filters []string
+}
+
+func (f *fakeLogging) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req logging.ListLogEntriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.filters = append(f.filters, req.Filter)
+
+	var matched []*logging.LogEntry
+	for _, e := range f.entries {
+		if _, after, ok := strings.Cut(req.Filter, `timestamp>="`); ok && e.Timestamp < strings.TrimSuffix(after, `"`) {
+			continue
+		}
+		matched = append(matched, e)
+	}
+	offset := 0
+	if req.PageToken != "" {
+		json.Unmarshal([]byte(req.PageToken), &offset)
+	}
+	end := min(offset+int(req.PageSize), len(matched))
+	resp := logging.ListLogEntriesResponse{Entries: matched[offset:end]}
+	if end < len(matched) {
+		token, _ := json.Marshal(end)
+		resp.NextPageToken = string(token)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func TestCloudLogReaderResumesWithoutRepeats(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeLogging{entries: []*logging.LogEntry{
+		{InsertId: "a", Timestamp: "2026-01-01T00:00:01Z", TextPayload: "starting\n", Labels: map[string]string{"task_id": "task/uid-group0-0/0/0"}},
+		{InsertId: "b", Timestamp: "2026-01-01T00:00:02Z", JsonPayload: []byte(`{"message":"halfway"}`), Labels: map[string]string{"task_id": "task/uid-group0-1/0/0"}},
+		{InsertId: "c", Timestamp: "2026-01-01T00:00:02Z", TextPayload: "same instant", Labels: map[string]string{"task_id": "task/uid-group0-2/0/0"}},
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	reader, err := newCloudLogReader(ctx, "proj", option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("newCloudLogReader: %v", err)
+	}
+
+	page, err := reader.read(ctx, `labels.job_uid="uid"`, "", 2, batchTaskIndex)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(page.Entries) != 2 || !page.More || page.Entries[0].Message != "starting" || page.Entries[1].Message != "halfway" || page.Entries[1].TaskIndex != 1 {
+		t.Fatalf("first page = %+v", page)
+	}
+
+	page, err = reader.read(ctx, `labels.job_uid="uid"`, page.NextPageToken, 2, batchTaskIndex)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(page.Entries) != 1 || page.More || page.Entries[0].TaskIndex != 2 {
+		t.Fatalf("second page = %+v", page)
+	}
+
+	// Caught up: a new line at the same instant as the last one read is
+	// returned, the lines already read are not.
+	fake.entries = append(fake.entries, &logging.LogEntry{InsertId: "d", Timestamp: "2026-01-01T00:00:02Z", TextPayload: "late"})
+	var resumed []string
+	for more := true; more; more = page.More {
+		page, err = reader.read(ctx, `labels.job_uid="uid"`, page.NextPageToken, 2, batchTaskIndex)
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		for _, e := range page.Entries {
+			resumed = append(resumed, e.Message)
+		}
+	}
+	if len(resumed) != 1 || resumed[0] != "late" {
+		t.Fatalf("resumed entries = %v", resumed)
+	}
+	if last := fake.filters[len(fake.filters)-1]; !strings.HasSuffix(last, `timestamp>="2026-01-01T00:00:02Z"`) {
+		t.Errorf("resumed filter = %s", last)
+	}
+}
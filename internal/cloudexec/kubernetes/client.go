@@ -0,0 +1,409 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	batchpkg "github.com/alphauslabs/jennah/internal/cloudexec"
+)
+
+func init() {
+	batchpkg.RegisterKubernetesProvider(NewKubernetesProvider)
+}
+
+// Labels attached to every Job the provider creates.
+const (
+	labelManagedBy = "app.kubernetes.io/managed-by"
+	managedBy      = "jennah"
+	labelJobID     = "jennah.io/job-id"
+)
+
+// containerName is the name of the single container in each task pod.
+const containerName = "task"
+
+// gpuResource is the extended resource requested for Accelerators.
+const gpuResource corev1.ResourceName = "nvidia.com/gpu"
+
+// KubernetesProvider implements the batch.Provider interface by running jobs
+// as batch/v1 Jobs in a Kubernetes namespace.
+//
+// A job with TaskGroup.TaskCount N > 1 becomes an Indexed Job with N
+// completions and TaskGroup.Parallelism concurrent pods; each pod gets
+// BATCH_TASK_INDEX (from its completion index) and BATCH_TASK_COUNT like
+// GCP Batch does. Cancelled jobs are suspended, not deleted, so their status
+// stays readable until DeleteJob.
+type KubernetesProvider struct {
+	client    k8s.Interface
+	namespace string
+}
+
+// NewKubernetesProvider creates a provider for the cluster in the "kubeconfig"
+// provider option, or the in-cluster config when it is empty, falling back to
+// the default kubeconfig loading rules ($KUBECONFIG, ~/.kube/config). Jobs go
+// to the "namespace" option, "default" when empty.
+func NewKubernetesProvider(ctx context.Context, config batchpkg.ProviderConfig) (batchpkg.Provider, error) {
+	restConfig, err := loadRESTConfig(config.ProviderOptions["kubeconfig"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Kubernetes config: %w", err)
+	}
+
+	client, err := k8s.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	return newKubernetesProvider(client, config.ProviderOptions["namespace"]), nil
+}
+
+func newKubernetesProvider(client k8s.Interface, namespace string) *KubernetesProvider {
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	return &KubernetesProvider{client: client, namespace: namespace}
+}
+
+func loadRESTConfig(kubeconfig string) (*rest.Config, error) {
+	if kubeconfig != "" {
+		return clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	if cfg, err := rest.InClusterConfig(); err == nil {
+		return cfg, nil
+	}
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{}).ClientConfig()
+}
+
+// ServiceType returns the service type identifier for Kubernetes Jobs.
+func (p *KubernetesProvider) ServiceType() string {
+	return batchpkg.ServiceTypeKubernetesJob
+}
+
+// SubmitJob creates a batch/v1 Job for the job config.
+// The returned CloudResourcePath is "namespaces/<namespace>/jobs/<name>".
+func (p *KubernetesProvider) SubmitJob(ctx context.Context, config batchpkg.JobConfig) (*batchpkg.JobResult, error) {
+	job := buildJob(p.namespace, config)
+
+	created, err := p.client.BatchV1().Jobs(p.namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes job: %w", err)
+	}
+
+	path := resourcePath(created.Namespace, created.Name)
+	log.Printf("Kubernetes job created: %s", path)
+
+	return &batchpkg.JobResult{
+		CloudResourcePath: path,
+		InitialStatus:     batchpkg.JobStatusPending,
+	}, nil
+}
+
+// buildJob renders a JobConfig into a batch/v1 Job.
+func buildJob(namespace string, config batchpkg.JobConfig) *batchv1.Job {
+	labels := map[string]string{}
+	for k, v := range config.JobLabels {
+		labels[k] = v
+	}
+	labels[labelManagedBy] = managedBy
+	labels[labelJobID] = config.JobID
+
+	container := corev1.Container{
+		Name:  containerName,
+		Image: config.ImageURI,
+		Args:  config.Commands,
+	}
+	if config.ContainerEntrypoint != "" {
+		container.Command = []string{config.ContainerEntrypoint}
+	}
+
+	envKeys := make([]string, 0, len(config.EnvVars))
+	for k := range config.EnvVars {
+		envKeys = append(envKeys, k)
+	}
+	sort.Strings(envKeys)
+	for _, k := range envKeys {
+		container.Env = append(container.Env, corev1.EnvVar{Name: k, Value: config.EnvVars[k]})
+	}
+
+	if r := config.Resources; r != nil {
+		resources := corev1.ResourceList{}
+		if r.CPUMillis > 0 {
+			resources[corev1.ResourceCPU] = *resource.NewMilliQuantity(r.CPUMillis, resource.DecimalSI)
+		}
+		if r.MemoryMiB > 0 {
+			resources[corev1.ResourceMemory] = *resource.NewQuantity(r.MemoryMiB*1024*1024, resource.BinarySI)
+		}
+		if len(resources) > 0 {
+			container.Resources.Requests = resources
+			container.Resources.Limits = resources.DeepCopy()
+		}
+	}
+	if a := config.Accelerators; a != nil && a.Count > 0 {
+		if container.Resources.Limits == nil {
+			container.Resources.Limits = corev1.ResourceList{}
+		}
+		container.Resources.Limits[gpuResource] = *resource.NewQuantity(a.Count, resource.DecimalSI)
+	}
+
+	spec := batchv1.JobSpec{
+		// MaxRetryCount is the number of retries, so 0 means the first
+		// failed pod fails the job (Kubernetes defaults to 6).
+		BackoffLimit: int32Ptr(config.MaxRetryCount),
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{labelManagedBy: managedBy, labelJobID: config.JobID}},
+			Spec: corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				Containers:    []corev1.Container{container},
+			},
+		},
+	}
+	if config.Resources != nil && config.Resources.MaxRunDurationSeconds > 0 {
+		spec.ActiveDeadlineSeconds = &config.Resources.MaxRunDurationSeconds
+	}
+
+	if tg := config.TaskGroup; tg != nil {
+		if tg.TaskCount > 1 {
+			completions := int32(tg.TaskCount)
+			parallelism := completions
+			if tg.Parallelism > 0 && tg.Parallelism < tg.TaskCount {
+				parallelism = int32(tg.Parallelism)
+			}
+			mode := batchv1.IndexedCompletion
+			spec.CompletionMode = &mode
+			spec.Completions = &completions
+			spec.Parallelism = &parallelism
+
+			c := &spec.Template.Spec.Containers[0]
+			c.Env = append(c.Env,
+				corev1.EnvVar{
+					Name: "BATCH_TASK_INDEX",
+					ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: "metadata.annotations['" + batchv1.JobCompletionIndexAnnotation + "']",
+					}},
+				},
+				corev1.EnvVar{Name: "BATCH_TASK_COUNT", Value: strconv.FormatInt(tg.TaskCount, 10)},
+			)
+		}
+		if tg.RunAsNonRoot {
+			runAsNonRoot := true
+			spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{RunAsNonRoot: &runAsNonRoot}
+		}
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      config.JobID,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: spec,
+	}
+}
+
+// GetJobStatus derives the job's status from its conditions:
+//   - Complete: COMPLETED
+//   - Failed (backoff limit or deadline exceeded): FAILED
+//   - Suspended (by CancelJob): CANCELLED
+//
+// Without a terminal condition, the job is RUNNING once a pod is ready or has
+// finished, SCHEDULED while its pods are pending, and PENDING before any pod
+// has been created.
+func (p *KubernetesProvider) GetJobStatus(ctx context.Context, cloudResourcePath string) (batchpkg.JobStatus, error) {
+	job, err := p.getJob(ctx, cloudResourcePath)
+	if err != nil {
+		return batchpkg.JobStatusUnknown, fmt.Errorf("failed to get job status: %w", err)
+	}
+	return jobStatus(job), nil
+}
+
+func jobStatus(job *batchv1.Job) batchpkg.JobStatus {
+	if hasCondition(job, batchv1.JobComplete) {
+		return batchpkg.JobStatusCompleted
+	}
+	if hasCondition(job, batchv1.JobFailed) {
+		return batchpkg.JobStatusFailed
+	}
+	if hasCondition(job, batchv1.JobSuspended) {
+		return batchpkg.JobStatusCancelled
+	}
+
+	status := job.Status
+	switch {
+	case status.Ready != nil && *status.Ready > 0, status.Succeeded > 0, status.Failed > 0:
+		return batchpkg.JobStatusRunning
+	case status.Active > 0:
+		return batchpkg.JobStatusScheduled
+	default:
+		return batchpkg.JobStatusPending
+	}
+}
+
+// GetJobFailure reports the reason and message of the job's Failed condition.
+func (p *KubernetesProvider) GetJobFailure(ctx context.Context, cloudResourcePath string) (*batchpkg.FailureDetail, error) {
+	job, err := p.getJob(ctx, cloudResourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job failure: %w", err)
+	}
+
+	detail := &batchpkg.FailureDetail{Cause: batchpkg.FailureCauseUnknown}
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			detail.Message = strings.TrimPrefix(c.Reason+": "+c.Message, ": ")
+		}
+	}
+	return detail, nil
+}
+
+// GetJobLogs reads the output of the job's pods, including pods of retried
+// attempts. Pods that have not started yet have no logs and are skipped.
+func (p *KubernetesProvider) GetJobLogs(ctx context.Context, cloudResourcePath, pageToken string, pageSize int) (*batchpkg.LogPage, error) {
+	namespace, name, err := parseResourcePath(cloudResourcePath)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := p.client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelJobID + "=" + name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods of job %s: %w", name, err)
+	}
+
+	var entries []batchpkg.LogEntry
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodPending {
+			continue
+		}
+		taskIndex, _ := strconv.ParseInt(pod.Annotations[batchv1.JobCompletionIndexAnnotation], 10, 64)
+		raw, err := p.client.CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+			Container:  containerName,
+			Timestamps: true,
+		}).DoRaw(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get logs of pod %s: %w", pod.Name, err)
+		}
+		entries = append(entries, parseLogLines(raw, taskIndex)...)
+	}
+	return batchpkg.PageLogEntries(entries, pageToken, pageSize)
+}
+
+// parseLogLines splits "<RFC3339 timestamp> <message>" lines as written by
+// the kubelet when Timestamps is set.
+func parseLogLines(raw []byte, taskIndex int64) []batchpkg.LogEntry {
+	var entries []batchpkg.LogEntry
+	for _, line := range strings.Split(strings.TrimRight(string(raw), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		entry := batchpkg.LogEntry{TaskIndex: taskIndex, Message: line}
+		if ts, msg, ok := strings.Cut(line, " "); ok {
+			if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+				entry.Timestamp, entry.Message = t, msg
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// CancelJob suspends the job, which terminates its active pods and keeps the
+// Job object so its status reads as cancelled.
+func (p *KubernetesProvider) CancelJob(ctx context.Context, cloudResourcePath string) error {
+	namespace, name, err := parseResourcePath(cloudResourcePath)
+	if err != nil {
+		return err
+	}
+
+	patch := []byte(`{"spec":{"suspend":true}}`)
+	if _, err := p.client.BatchV1().Jobs(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to cancel job: %w", err)
+	}
+	log.Printf("Kubernetes job cancelled: %s", cloudResourcePath)
+	return nil
+}
+
+// DeleteJob deletes the Job and, in the background, its pods.
+func (p *KubernetesProvider) DeleteJob(ctx context.Context, cloudResourcePath string) error {
+	namespace, name, err := parseResourcePath(cloudResourcePath)
+	if err != nil {
+		return err
+	}
+
+	propagation := metav1.DeletePropagationBackground
+	err = p.client.BatchV1().Jobs(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete job: %w", err)
+	}
+	log.Printf("Kubernetes job deleted: %s", cloudResourcePath)
+	return nil
+}
+
+// ListJobs lists the Jobs created by Jennah in the provider's namespace.
+func (p *KubernetesProvider) ListJobs(ctx context.Context) ([]string, error) {
+	var paths []string
+	opts := metav1.ListOptions{LabelSelector: labelManagedBy + "=" + managedBy}
+	for {
+		list, err := p.client.BatchV1().Jobs(p.namespace).List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list jobs: %w", err)
+		}
+		for _, job := range list.Items {
+			paths = append(paths, resourcePath(job.Namespace, job.Name))
+		}
+		if list.Continue == "" {
+			return paths, nil
+		}
+		opts.Continue = list.Continue
+	}
+}
+
+// Close releases provider resources. The clientset holds none.
+func (p *KubernetesProvider) Close() error {
+	return nil
+}
+
+func (p *KubernetesProvider) getJob(ctx context.Context, cloudResourcePath string) (*batchv1.Job, error) {
+	namespace, name, err := parseResourcePath(cloudResourcePath)
+	if err != nil {
+		return nil, err
+	}
+	return p.client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func hasCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == conditionType && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func resourcePath(namespace, name string) string {
+	return "namespaces/" + namespace + "/jobs/" + name
+}
+
+// parseResourcePath splits "namespaces/<namespace>/jobs/<name>".
+func parseResourcePath(path string) (namespace, name string, err error) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 4 || parts[0] != "namespaces" || parts[2] != "jobs" {
+		return "", "", fmt.Errorf("invalid Kubernetes job path %q", path)
+	}
+	return parts[1], parts[3], nil
+}
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
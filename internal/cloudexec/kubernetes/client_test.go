@@ -0,0 +1,267 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	batchpkg "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/dispatcher"
+	"github.com/alphauslabs/jennah/internal/router"
+)
+
+func newTestProvider() (*fake.Clientset, *KubernetesProvider) {
+	client := fake.NewClientset()
+	return client, newKubernetesProvider(client, "jennah")
+}
+
+func getJob(t *testing.T, client *fake.Clientset, name string) *batchv1.Job {
+	t.Helper()
+	job, err := client.BatchV1().Jobs("jennah").Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get job %s: %v", name, err)
+	}
+	return job
+}
+
+func TestSubmitJobRendersIndexedJob(t *testing.T) {
+	client, p := newTestProvider()
+
+	result, err := p.SubmitJob(context.Background(), batchpkg.JobConfig{
+		JobID:               "jennah-abc12345",
+		ImageURI:            "gcr.io/project/app:latest",
+		Commands:            []string{"--input", "gs://bucket/data"},
+		ContainerEntrypoint: "/app/run",
+		EnvVars:             map[string]string{"B": "2", "A": "1"},
+		Resources:           &batchpkg.ResourceRequirements{CPUMillis: 1500, MemoryMiB: 512, MaxRunDurationSeconds: 600},
+		TaskGroup:           &batchpkg.TaskGroupConfig{TaskCount: 5, Parallelism: 2},
+		MaxRetryCount:       3,
+		JobLabels:           map[string]string{"team": "data"},
+	})
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	if result.CloudResourcePath != "namespaces/jennah/jobs/jennah-abc12345" || result.InitialStatus != batchpkg.JobStatusPending {
+		t.Errorf("result = %+v", result)
+	}
+
+	job := getJob(t, client, "jennah-abc12345")
+	spec := job.Spec
+	if *spec.BackoffLimit != 3 || *spec.ActiveDeadlineSeconds != 600 {
+		t.Errorf("backoffLimit = %d, activeDeadlineSeconds = %d", *spec.BackoffLimit, *spec.ActiveDeadlineSeconds)
+	}
+	if *spec.CompletionMode != batchv1.IndexedCompletion || *spec.Completions != 5 || *spec.Parallelism != 2 {
+		t.Errorf("completion mode = %s, completions = %d, parallelism = %d", *spec.CompletionMode, *spec.Completions, *spec.Parallelism)
+	}
+	if job.Labels["team"] != "data" || job.Labels[labelManagedBy] != managedBy {
+		t.Errorf("labels = %v", job.Labels)
+	}
+
+	pod := spec.Template.Spec
+	if pod.RestartPolicy != corev1.RestartPolicyNever {
+		t.Errorf("restart policy = %s", pod.RestartPolicy)
+	}
+	c := pod.Containers[0]
+	if c.Image != "gcr.io/project/app:latest" || len(c.Command) != 1 || c.Command[0] != "/app/run" || len(c.Args) != 2 {
+		t.Errorf("container = %+v", c)
+	}
+	if cpu := c.Resources.Limits[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("1500m")) != 0 {
+		t.Errorf("cpu limit = %s", cpu.String())
+	}
+	if mem := c.Resources.Requests[corev1.ResourceMemory]; mem.Cmp(resource.MustParse("512Mi")) != 0 {
+		t.Errorf("memory request = %s", mem.String())
+	}
+
+	env := map[string]corev1.EnvVar{}
+	var names []string
+	for _, e := range c.Env {
+		env[e.Name] = e
+		names = append(names, e.Name)
+	}
+	if names[0] != "A" || names[1] != "B" {
+		t.Errorf("env order = %v, want sorted user variables first", names)
+	}
+	if env["BATCH_TASK_COUNT"].Value != "5" {
+		t.Errorf("BATCH_TASK_COUNT = %q", env["BATCH_TASK_COUNT"].Value)
+	}
+	if ref := env["BATCH_TASK_INDEX"].ValueFrom; ref == nil || ref.FieldRef.FieldPath != "metadata.annotations['batch.kubernetes.io/job-completion-index']" {
+		t.Errorf("BATCH_TASK_INDEX = %+v", env["BATCH_TASK_INDEX"])
+	}
+}
+
+func TestSubmitSingleTaskJob(t *testing.T) {
+	client, p := newTestProvider()
+
+	if _, err := p.SubmitJob(context.Background(), batchpkg.JobConfig{JobID: "jennah-single01", ImageURI: "busybox"}); err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	spec := getJob(t, client, "jennah-single01").Spec
+	if spec.CompletionMode != nil || spec.ActiveDeadlineSeconds != nil || *spec.BackoffLimit != 0 {
+		t.Errorf("spec = %+v, want a non-indexed job without retries or deadline", spec)
+	}
+}
+
+func TestGetJobStatusFollowsConditions(t *testing.T) {
+	ready := int32(1)
+	tests := []struct {
+		name   string
+		status batchv1.JobStatus
+		want   batchpkg.JobStatus
+	}{
+		{"no pods yet", batchv1.JobStatus{}, batchpkg.JobStatusPending},
+		{"pods pending", batchv1.JobStatus{Active: 1}, batchpkg.JobStatusScheduled},
+		{"pod running", batchv1.JobStatus{Active: 1, Ready: &ready}, batchpkg.JobStatusRunning},
+		{"some tasks done", batchv1.JobStatus{Active: 1, Succeeded: 2}, batchpkg.JobStatusRunning},
+		{"complete", batchv1.JobStatus{Succeeded: 1, Conditions: []batchv1.JobCondition{
+			{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+		}}, batchpkg.JobStatusCompleted},
+		{"failed", batchv1.JobStatus{Failed: 1, Conditions: []batchv1.JobCondition{
+			{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"},
+		}}, batchpkg.JobStatusFailed},
+		{"suspended", batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+			{Type: batchv1.JobSuspended, Status: corev1.ConditionTrue},
+		}}, batchpkg.JobStatusCancelled},
+		{"resumed", batchv1.JobStatus{Active: 1, Conditions: []batchv1.JobCondition{
+			{Type: batchv1.JobSuspended, Status: corev1.ConditionFalse},
+		}}, batchpkg.JobStatusScheduled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, p := newTestProvider()
+			job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "jennah-status01", Namespace: "jennah"}, Status: tt.status}
+			if _, err := client.BatchV1().Jobs("jennah").Create(context.Background(), job, metav1.CreateOptions{}); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			got, err := p.GetJobStatus(context.Background(), "namespaces/jennah/jobs/jennah-status01")
+			if err != nil {
+				t.Fatalf("GetJobStatus: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("status = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetJobFailureReportsFailedCondition(t *testing.T) {
+	client, p := newTestProvider()
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "jennah-fail0001", Namespace: "jennah"},
+		Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{
+			Type: batchv1.JobFailed, Status: corev1.ConditionTrue,
+			Reason: "DeadlineExceeded", Message: "Job was active longer than specified deadline",
+		}}},
+	}
+	if _, err := client.BatchV1().Jobs("jennah").Create(context.Background(), job, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	failure, err := p.GetJobFailure(context.Background(), "namespaces/jennah/jobs/jennah-fail0001")
+	if err != nil {
+		t.Fatalf("GetJobFailure: %v", err)
+	}
+	if failure.Message != "DeadlineExceeded: Job was active longer than specified deadline" {
+		t.Errorf("failure = %+v", failure)
+	}
+}
+
+func TestCancelDeleteAndListJobs(t *testing.T) {
+	ctx := context.Background()
+	client, p := newTestProvider()
+
+	// Jobs not created by Jennah are left out of ListJobs.
+	other := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "jennah"}}
+	if _, err := client.BatchV1().Jobs("jennah").Create(ctx, other, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	result, err := p.SubmitJob(ctx, batchpkg.JobConfig{JobID: "jennah-cncl0001", ImageURI: "busybox"})
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+
+	paths, err := p.ListJobs(ctx)
+	if err != nil {
+		t.Fatalf("ListJobs: %v", err)
+	}
+	if len(paths) != 1 || paths[0] != result.CloudResourcePath {
+		t.Errorf("ListJobs = %v", paths)
+	}
+
+	if err := p.CancelJob(ctx, result.CloudResourcePath); err != nil {
+		t.Fatalf("CancelJob: %v", err)
+	}
+	if job := getJob(t, client, "jennah-cncl0001"); job.Spec.Suspend == nil || !*job.Spec.Suspend {
+		t.Error("cancelled job was not suspended")
+	}
+
+	if err := p.DeleteJob(ctx, result.CloudResourcePath); err != nil {
+		t.Fatalf("DeleteJob: %v", err)
+	}
+	if err := p.DeleteJob(ctx, result.CloudResourcePath); err != nil {
+		t.Errorf("deleting an already deleted job: %v", err)
+	}
+	if _, err := p.GetJobStatus(ctx, "jobs/broken"); err == nil {
+		t.Error("GetJobStatus accepted a malformed path")
+	}
+}
+
+func TestProviderRegistersInDispatcher(t *testing.T) {
+	client, p := newTestProvider()
+
+	d, err := dispatcher.New(dispatcher.WithCloudBatch(p), dispatcher.WithCloudRunJobs(p))
+	if err != nil {
+		t.Fatalf("dispatcher.New: %v", err)
+	}
+	result, err := d.SubmitJob(context.Background(), router.AssignedServiceCloudRunJob, batchpkg.JobConfig{JobID: "jennah-dsp00001", ImageURI: "busybox"})
+	if err != nil {
+		t.Fatalf("SubmitJob: %v", err)
+	}
+	getJob(t, client, "jennah-dsp00001")
+
+	status, err := d.GetJobStatus(context.Background(), router.AssignedServiceCloudRunJob, result.CloudResourcePath)
+	if err != nil || status != batchpkg.JobStatusPending {
+		t.Errorf("GetJobStatus = %s, %v; want PENDING", status, err)
+	}
+}
+
+func TestGetJobLogsReadsTaskPods(t *testing.T) {
+	ctx := context.Background()
+	client, p := newTestProvider()
+	for _, pod := range []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "jennah-logs0001-1-abcde", Namespace: "jennah",
+				Labels:      map[string]string{labelJobID: "jennah-logs0001"},
+				Annotations: map[string]string{batchv1.JobCompletionIndexAnnotation: "1"},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "jennah-logs0001-2-fghij", Namespace: "jennah",
+				Labels:      map[string]string{labelJobID: "jennah-logs0001"},
+				Annotations: map[string]string{batchv1.JobCompletionIndexAnnotation: "2"},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodPending},
+		},
+	} {
+		if _, err := client.CoreV1().Pods("jennah").Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("Create pod: %v", err)
+		}
+	}
+
+	page, err := p.GetJobLogs(ctx, "namespaces/jennah/jobs/jennah-logs0001", "", 0)
+	if err != nil {
+		t.Fatalf("GetJobLogs: %v", err)
+	}
+	// The fake clientset answers every log read with "fake logs"; the
+	// pending pod has not written anything yet.
+	if len(page.Entries) != 1 || page.Entries[0].TaskIndex != 1 || page.Entries[0].Message != "fake logs" || page.More {
+		t.Errorf("page = %+v", page)
+	}
+}
@@ -0,0 +1,45 @@
+package batch
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxJobLabels is the most labels a GCP resource accepts.
+const MaxJobLabels = 64
+
+// Job labels follow the GCP label rules shared by Cloud Batch and Cloud Run:
+// keys start with a lowercase letter, and keys and values use only lowercase
+// letters, digits, underscores and hyphens, at most 63 characters each.
+var (
+	labelKeyPattern   = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+	labelValuePattern = regexp.MustCompile(`^[a-z0-9_-]{0,63}$`)
+)
+
+// ValidateLabelKey checks a label key against the provider label rules.
+func ValidateLabelKey(key string) error {
+	if !labelKeyPattern.MatchString(key) {
+		return fmt.Errorf("label key %q must start with a lowercase letter and contain at most 63 lowercase letters, digits, underscores or hyphens", key)
+	}
+	if strings.HasPrefix(key, "goog") {
+		return fmt.Errorf("label key %q uses the reserved prefix \"goog\"", key)
+	}
+	return nil
+}
+
+// ValidateJobLabels checks user labels against the provider label rules.
+func ValidateJobLabels(labels map[string]string) error {
+	if len(labels) > MaxJobLabels {
+		return fmt.Errorf("at most %d labels are allowed, got %d", MaxJobLabels, len(labels))
+	}
+	for k, v := range labels {
+		if err := ValidateLabelKey(k); err != nil {
+			return err
+		}
+		if !labelValuePattern.MatchString(v) {
+			return fmt.Errorf("label %q: value %q must contain at most 63 lowercase letters, digits, underscores or hyphens", k, v)
+		}
+	}
+	return nil
+}
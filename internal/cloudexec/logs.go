@@ -0,0 +1,44 @@
+package batch
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// DefaultLogPageSize is used when a log read does not ask for a page size.
+const DefaultLogPageSize = 500
+
+// PageLogEntries pages through the complete log of a job, for providers that
+// can only read a job's whole output at once. Entries are ordered by time
+// (then task index), and the page token is the offset into that order: new
+// output sorts after what was already returned, so the token stays valid
+// while the job keeps writing.
+func PageLogEntries(entries []LogEntry, pageToken string, pageSize int) (*LogPage, error) {
+	offset := 0
+	if pageToken != "" {
+		n, err := strconv.Atoi(pageToken)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid log page token %q", pageToken)
+		}
+		offset = n
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultLogPageSize
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Timestamp.Equal(entries[j].Timestamp) {
+			return entries[i].Timestamp.Before(entries[j].Timestamp)
+		}
+		return entries[i].TaskIndex < entries[j].TaskIndex
+	})
+
+	offset = min(offset, len(entries))
+	end := min(offset+pageSize, len(entries))
+	return &LogPage{
+		Entries:       entries[offset:end],
+		NextPageToken: strconv.Itoa(end),
+		More:          end < len(entries),
+	}, nil
+}
@@ -3,6 +3,7 @@ package batch
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // Provider defines the interface for cloud batch service implementations.
@@ -133,7 +134,9 @@ type JobConfig struct {
 	// Priority is the job scheduling priority (0–100, backend-only).
 	Priority int64
 
-	// JobLabels are key-value labels attached to the GCP Batch job (backend-only).
+	// JobLabels are the user's labels, attached to the provider job (GCP Batch
+	// and Cloud Run job labels, AWS tags, Kubernetes labels). Validated with
+	// ValidateJobLabels.
 	JobLabels map[string]string
 }
 
@@ -231,9 +234,85 @@ const (
 	JobStatusUnknown JobStatus = "UNKNOWN"
 )
 
+// FailureCause classifies why a job failed, so retry policies can tell
+// infrastructure failures apart from the workload's own errors.
+type FailureCause string
+
+const (
+	// FailureCauseUnknown covers application errors and anything the provider
+	// cannot attribute.
+	FailureCauseUnknown FailureCause = "UNKNOWN"
+
+	// FailureCausePreempted indicates the Spot/preemptible VM was reclaimed.
+	// Maps to: GCP Batch task exit code 50001.
+	FailureCausePreempted FailureCause = "PREEMPTED"
+)
+
+// FailureDetail describes a failed job.
+type FailureDetail struct {
+	Cause FailureCause
+
+	// Message is the provider's description of the failure, if any.
+	Message string
+}
+
+// FailureInspector is an optional Provider capability for explaining why a
+// job failed. Providers that do not implement it are treated as reporting
+// FailureCauseUnknown.
+type FailureInspector interface {
+	GetJobFailure(ctx context.Context, cloudResourcePath string) (*FailureDetail, error)
+}
+
+// StatusInspector is an optional Provider capability for explaining a job's
+// current state, such as what a job still waiting for resources is waiting
+// on. Providers that do not implement it report no detail.
+type StatusInspector interface {
+	GetJobStatusDetail(ctx context.Context, cloudResourcePath string) (string, error)
+}
+
+// StatusLister is an optional Provider capability for reading the status of
+// many jobs in one request. The result is keyed by the requested paths; jobs
+// the provider did not return are missing from it. Providers that do not
+// implement it are asked with GetJobStatus one job at a time.
+type StatusLister interface {
+	GetJobStatuses(ctx context.Context, cloudResourcePaths []string) (map[string]JobStatus, error)
+}
+
+// LogEntry is one line of a job's container output.
+type LogEntry struct {
+	Timestamp time.Time
+
+	// TaskIndex is the index of the task that wrote the line (0 for
+	// single-task jobs).
+	TaskIndex int64
+
+	Message string
+}
+
+// LogPage is a page of log entries in chronological order.
+type LogPage struct {
+	Entries []LogEntry
+
+	// NextPageToken resumes reading after the last entry of this page. It is
+	// set even when no more entries are available yet, so callers can keep
+	// polling a running job for new output.
+	NextPageToken string
+
+	// More reports whether further entries were available when the page was
+	// read; when false, the caller has caught up with the job's output.
+	More bool
+}
+
+// LogSource is an optional Provider capability for reading a job's container
+// logs. pageToken is empty to start from the beginning or a NextPageToken of
+// an earlier page; pageSize bounds the number of entries returned.
+type LogSource interface {
+	GetJobLogs(ctx context.Context, cloudResourcePath, pageToken string, pageSize int) (*LogPage, error)
+}
+
 // ProviderConfig contains configuration for initializing a batch provider.
 type ProviderConfig struct {
-	// Provider is the cloud provider name ("gcp", "aws", "azure").
+	// Provider is the cloud provider name ("gcp", "aws", "azure", "local-docker", "kubernetes").
 	Provider string
 
 	// Region is the cloud region for batch operations.
@@ -244,16 +323,21 @@ type ProviderConfig struct {
 
 	// ProviderOptions contains provider-specific configuration.
 	// Examples:
-	//   - GCP: empty (uses projectID and region)
-	//   - AWS: {"account_id": "123456789", "job_queue": "my-queue"}
+	//   - GCP: {"notification_topic": "projects/my-project/topics/jennah-status-events"} (optional; Cloud Batch)
+	//   - AWS: {"account_id": "123456789", "job_queue": "my-queue", "spot_job_queue": "my-spot-queue", "endpoint": "http://localhost:4566"}
 	//   - Azure: {"subscription_id": "...", "resource_group": "..."}
+	//   - Local Docker: {"docker_host": "unix:///var/run/docker.sock"}
+	//   - Kubernetes: {"kubeconfig": "/path/to/kubeconfig", "namespace": "jennah"}
 	ProviderOptions map[string]string
 }
 
 // Service type constants used by Provider.ServiceType().
 const (
-	ServiceTypeCloudRunJob = "CLOUD_RUN_JOB"
-	ServiceTypeCloudBatch  = "CLOUD_BATCH"
+	ServiceTypeCloudRunJob   = "CLOUD_RUN_JOB"
+	ServiceTypeCloudBatch    = "CLOUD_BATCH"
+	ServiceTypeLocalDocker   = "LOCAL_DOCKER"
+	ServiceTypeAWSBatch      = "AWS_BATCH"
+	ServiceTypeKubernetesJob = "KUBERNETES_JOB"
 )
 
 // NewProvider creates a new batch provider based on the configuration.
@@ -267,6 +351,10 @@ func NewProvider(ctx context.Context, config ProviderConfig) (Provider, error) {
 		return newAWSProvider(ctx, config)
 	case "azure":
 		return newAzureProvider(ctx, config)
+	case "local-docker":
+		return newLocalDockerProvider(ctx, config)
+	case "kubernetes":
+		return newKubernetesProvider(ctx, config)
 	default:
 		return nil, fmt.Errorf("unsupported batch provider: %s", config.Provider)
 	}
@@ -278,6 +366,8 @@ var (
 	newGCPCloudRunProvider func(context.Context, ProviderConfig) (Provider, error)
 	newAWSProvider         func(context.Context, ProviderConfig) (Provider, error)
 	newAzureProvider       func(context.Context, ProviderConfig) (Provider, error)
+	newLocalDockerProvider func(context.Context, ProviderConfig) (Provider, error)
+	newKubernetesProvider  func(context.Context, ProviderConfig) (Provider, error)
 )
 
 // RegisterGCPProvider registers the GCP batch provider constructor.
@@ -299,3 +389,13 @@ func RegisterAWSProvider(fn func(context.Context, ProviderConfig) (Provider, err
 func RegisterAzureProvider(fn func(context.Context, ProviderConfig) (Provider, error)) {
 	newAzureProvider = fn
 }
+
+// RegisterLocalDockerProvider registers the local Docker Engine provider constructor.
+func RegisterLocalDockerProvider(fn func(context.Context, ProviderConfig) (Provider, error)) {
+	newLocalDockerProvider = fn
+}
+
+// RegisterKubernetesProvider registers the Kubernetes Jobs provider constructor.
+func RegisterKubernetesProvider(fn func(context.Context, ProviderConfig) (Provider, error)) {
+	newKubernetesProvider = fn
+}
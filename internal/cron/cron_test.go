@@ -0,0 +1,137 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s not available: %v", name, err)
+	}
+	return loc
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) should fail", expr)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	utc := time.UTC
+	base := time.Date(2026, 3, 14, 10, 7, 30, 0, utc) // Saturday
+
+	cases := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 3, 14, 10, 8, 0, 0, utc)},
+		{"*/15 * * * *", time.Date(2026, 3, 14, 10, 15, 0, 0, utc)},
+		{"0 * * * *", time.Date(2026, 3, 14, 11, 0, 0, 0, utc)},
+		{"@hourly", time.Date(2026, 3, 14, 11, 0, 0, 0, utc)},
+		{"30 2 * * *", time.Date(2026, 3, 15, 2, 30, 0, 0, utc)},
+		{"@daily", time.Date(2026, 3, 15, 0, 0, 0, 0, utc)},
+		{"0 9 * * MON-FRI", time.Date(2026, 3, 16, 9, 0, 0, 0, utc)},
+		{"0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, utc)},
+		{"0 0 1 JAN *", time.Date(2027, 1, 1, 0, 0, 0, 0, utc)},
+		{"0 0 * * 7", time.Date(2026, 3, 15, 0, 0, 0, 0, utc)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, utc)},
+		// Both day fields restricted: either may match (the 20th or a Monday).
+		{"0 0 20 * 1", time.Date(2026, 3, 16, 0, 0, 0, 0, utc)},
+		{"0,30 8-9 * * *", time.Date(2026, 3, 15, 8, 0, 0, 0, utc)},
+	}
+	for _, tc := range cases {
+		s, err := Parse(tc.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tc.expr, err)
+		}
+		if got := s.Next(base); !got.Equal(tc.want) {
+			t.Errorf("Next(%q) = %s, want %s", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestNextNeverFires(t *testing.T) {
+	s, err := Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Feb 30 should never fire, got %s", got)
+	}
+}
+
+func TestNextInTimeZone(t *testing.T) {
+	tokyo := mustLoad(t, "Asia/Tokyo")
+	s, _ := Parse("0 9 * * *")
+
+	// 01:00 UTC is 10:00 in Tokyo, so the next 09:00 there is tomorrow.
+	got := s.Next(time.Date(2026, 6, 1, 1, 0, 0, 0, time.UTC).In(tokyo))
+	want := time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("Next = %s, want %s", got.UTC(), want)
+	}
+
+	kolkata := mustLoad(t, "Asia/Kolkata") // UTC+5:30
+	s, _ = Parse("0 * * * *")
+	got = s.Next(time.Date(2026, 6, 1, 10, 10, 0, 0, kolkata))
+	if got.Hour() != 11 || got.Minute() != 0 {
+		t.Errorf("hourly in a half-hour zone = %s, want 11:00 local", got)
+	}
+}
+
+func TestNextAcrossDST(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+
+	// 2026-03-08 02:30 does not exist in New York; the job skips that day.
+	s, _ := Parse("30 2 * * *")
+	got := s.Next(time.Date(2026, 3, 7, 12, 0, 0, 0, ny))
+	if !got.Equal(time.Date(2026, 3, 7, 2, 30, 0, 0, ny).AddDate(0, 0, 2)) {
+		t.Errorf("spring forward: got %s", got)
+	}
+
+	// 01:30 happens twice on 2026-11-01; it fires once.
+	s, _ = Parse("30 1 * * *")
+	first := s.Next(time.Date(2026, 11, 1, 0, 0, 0, 0, ny))
+	second := s.Next(first)
+	if first.Day() != 1 || second.Day() != 2 {
+		t.Errorf("fall back: got %s then %s", first, second)
+	}
+}
+
+func TestNextInZone(t *testing.T) {
+	mustLoad(t, "Asia/Tokyo")
+	got, err := NextInZone("0 9 * * *", "Asia/Tokyo", time.Date(2026, 6, 1, 1, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NextInZone: %v", err)
+	}
+	if want := time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC); !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("NextInZone = %s, want %s", got, want)
+	}
+
+	for _, tc := range []struct{ expr, tz string }{
+		{"0 9 * * *", "Mars/Olympus"},
+		{"0 25 * * *", "UTC"},
+		{"0 0 30 2 *", "UTC"},
+	} {
+		if _, err := NextInZone(tc.expr, tc.tz, time.Now()); err == nil {
+			t.Errorf("NextInZone(%q, %q) should fail", tc.expr, tc.tz)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+)
+
+// ApiKey is a tenant credential for non-interactive callers such as CI
+// pipelines. Only the SHA-256 of the key is stored.
+type ApiKey struct {
+	TenantId  string     `spanner:"TenantId"`
+	ApiKeyId  string     `spanner:"ApiKeyId"`
+	Name      *string    `spanner:"Name"`
+	KeyPrefix string     `spanner:"KeyPrefix"` // First characters of the key, for display
+	KeyHash   string     `spanner:"KeyHash"`   // hex SHA-256 of the key
+	Scopes    []string   `spanner:"Scopes"`
+	ExpiresAt *time.Time `spanner:"ExpiresAt"` // nil: never expires
+	RevokedAt *time.Time `spanner:"RevokedAt"`
+	CreatedAt time.Time  `spanner:"CreatedAt"`
+}
+
+// ApiKey scopes. Each grants the RPCs of one kind; admin grants all of them
+// plus API key management.
+const (
+	ApiKeyScopeSubmit = "submit" // submit jobs, workflows and schedules
+	ApiKeyScopeRead   = "read"   // get and list jobs, logs and notifications
+	ApiKeyScopeCancel = "cancel" // cancel and delete jobs, workflows and schedules
+	ApiKeyScopeAdmin  = "admin"  // everything, including managing API keys
+)
+
+// ApiKeyScopes lists every valid scope.
+var ApiKeyScopes = []string{ApiKeyScopeSubmit, ApiKeyScopeRead, ApiKeyScopeCancel, ApiKeyScopeAdmin}
+
+var apiKeyColumns = []string{
+	"TenantId", "ApiKeyId", "Name", "KeyPrefix", "KeyHash",
+	"Scopes", "ExpiresAt", "RevokedAt", "CreatedAt",
+}
+
+// InsertApiKey creates an API key.
+func (c *Client) InsertApiKey(ctx context.Context, k *ApiKey) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Insert("ApiKeys",
+			[]string{"TenantId", "ApiKeyId", "Name", "KeyPrefix", "KeyHash", "Scopes", "ExpiresAt", "CreatedAt"},
+			[]interface{}{k.TenantId, k.ApiKeyId, k.Name, k.KeyPrefix, k.KeyHash, k.Scopes, k.ExpiresAt, spanner.CommitTimestamp},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert API key: %w", err)
+	}
+	return nil
+}
+
+// GetApiKey retrieves an API key by tenant ID and API key ID.
+func (c *Client) GetApiKey(ctx context.Context, tenantID, apiKeyID string) (*ApiKey, error) {
+	row, err := c.client.Single().ReadRow(ctx, "ApiKeys", spanner.Key{tenantID, apiKeyID}, apiKeyColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	var k ApiKey
+	if err := row.ToStruct(&k); err != nil {
+		return nil, fmt.Errorf("failed to parse API key: %w", err)
+	}
+	return &k, nil
+}
+
+// GetApiKeyByHash retrieves the API key with the given hash across tenants.
+// Returns nil, nil when no key matches.
+func (c *Client) GetApiKeyByHash(ctx context.Context, keyHash string) (*ApiKey, error) {
+	keys, err := c.queryApiKeys(ctx, spanner.Statement{
+		SQL: `SELECT ` + columnList(apiKeyColumns) + `
+		      FROM ApiKeys@{FORCE_INDEX=ApiKeysByHash}
+		      WHERE KeyHash = @keyHash
+		      LIMIT 1`,
+		Params: map[string]interface{}{"keyHash": keyHash},
+	})
+	if err != nil || len(keys) == 0 {
+		return nil, err
+	}
+	return keys[0], nil
+}
+
+// ListApiKeys returns all API keys of a tenant, revoked ones included,
+// newest first.
+func (c *Client) ListApiKeys(ctx context.Context, tenantID string) ([]*ApiKey, error) {
+	return c.queryApiKeys(ctx, spanner.Statement{
+		SQL: `SELECT ` + columnList(apiKeyColumns) + `
+		      FROM ApiKeys
+		      WHERE TenantId = @tenantId
+		      ORDER BY CreatedAt DESC`,
+		Params: map[string]interface{}{"tenantId": tenantID},
+	})
+}
+
+func (c *Client) queryApiKeys(ctx context.Context, stmt spanner.Statement) ([]*ApiKey, error) {
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var keys []*ApiKey
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate API keys: %w", err)
+		}
+		var k ApiKey
+		if err := row.ToStruct(&k); err != nil {
+			return nil, fmt.Errorf("failed to parse API key: %w", err)
+		}
+		keys = append(keys, &k)
+	}
+	return keys, nil
+}
+
+// RevokeApiKey marks an API key as revoked. The row is kept so listings
+// still show the key and when it was revoked.
+func (c *Client) RevokeApiKey(ctx context.Context, tenantID, apiKeyID string) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Update("ApiKeys",
+			[]string{"TenantId", "ApiKeyId", "RevokedAt"},
+			[]interface{}{tenantID, apiKeyID, spanner.CommitTimestamp},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/spanner"
@@ -22,36 +23,43 @@ func (c *Client) InsertJob(ctx context.Context, tenantID, jobID, imageUri string
 
 // InsertJobFull creates a new job with all fields including advanced configuration.
 func (c *Client) InsertJobFull(ctx context.Context, job *Job) error {
-	_, err := c.client.Apply(ctx, []*spanner.Mutation{
-		spanner.Insert("Jobs",
-			[]string{
-				"TenantId", "JobId", "Status", "ImageUri", "Commands",
-				"CreatedAt", "UpdatedAt", "RetryCount", "MaxRetries",
-				"GcpBatchJobPath", "GcpBatchTaskGroup", "EnvVarsJson",
-				"Name", "ResourceProfile", "MachineType",
-				"BootDiskSizeGb", "UseSpotVms", "ServiceAccount", "ServiceTier",
-				"AssignedService", "MemoryMib", "CpuMillis", "MaxRunDurationSeconds",
-				"OwnerWorkerId", "PreferredWorkerId", "LeaseExpiresAt", "LastHeartbeatAt",
-			},
-			[]interface{}{
-				job.TenantId, job.JobId, job.Status, job.ImageUri, job.Commands,
-				spanner.CommitTimestamp, spanner.CommitTimestamp, job.RetryCount, job.MaxRetries,
-				job.GcpBatchJobPath, job.GcpBatchTaskGroup, job.EnvVarsJson,
-				job.Name, job.ResourceProfile, job.MachineType,
-				job.BootDiskSizeGb, job.UseSpotVms, job.ServiceAccount, job.ServiceTier,
-				job.AssignedService, job.MemoryMib, job.CpuMillis, job.MaxRunDurationSeconds,
-				job.OwnerWorkerId, job.PreferredWorkerId, job.LeaseExpiresAt, job.LastHeartbeatAt,
-			},
-		),
-	})
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{insertJobMutation(job)})
 	return err
 }
 
+// insertJobMutation builds the insert for a job with all of its fields.
+func insertJobMutation(job *Job) *spanner.Mutation {
+	return spanner.Insert("Jobs",
+		[]string{
+			"TenantId", "JobId", "Status", "ImageUri", "Commands",
+			"CreatedAt", "UpdatedAt", "RetryCount", "MaxRetries",
+			"GcpBatchJobPath", "GcpBatchTaskGroup", "EnvVarsJson",
+			"Name", "ResourceProfile", "MachineType",
+			"BootDiskSizeGb", "UseSpotVms", "ServiceAccount", "ServiceTier",
+			"AssignedService", "MemoryMib", "CpuMillis", "MaxRunDurationSeconds",
+			"OwnerWorkerId", "PreferredWorkerId", "LeaseExpiresAt", "LastHeartbeatAt",
+			"RetryPolicy", "WorkflowId", "WorkflowNodeId", "DependsOnJson",
+			"IdempotencyKey", "RequestHash", "SubmitResponseJson", "LabelsJson",
+		},
+		[]interface{}{
+			job.TenantId, job.JobId, job.Status, job.ImageUri, job.Commands,
+			spanner.CommitTimestamp, spanner.CommitTimestamp, job.RetryCount, job.MaxRetries,
+			job.GcpBatchJobPath, job.GcpBatchTaskGroup, job.EnvVarsJson,
+			job.Name, job.ResourceProfile, job.MachineType,
+			job.BootDiskSizeGb, job.UseSpotVms, job.ServiceAccount, job.ServiceTier,
+			job.AssignedService, job.MemoryMib, job.CpuMillis, job.MaxRunDurationSeconds,
+			job.OwnerWorkerId, job.PreferredWorkerId, job.LeaseExpiresAt, job.LastHeartbeatAt,
+			job.RetryPolicy, job.WorkflowId, job.WorkflowNodeId, job.DependsOnJson,
+			job.IdempotencyKey, job.RequestHash, job.SubmitResponseJson, job.LabelsJson,
+		},
+	)
+}
+
 // GetJob retrieves a job by tenant ID and job ID
 func (c *Client) GetJob(ctx context.Context, tenantID, jobID string) (*Job, error) {
 	row, err := c.client.Single().ReadRow(ctx, "Jobs",
 		spanner.Key{tenantID, jobID},
-		[]string{"TenantId", "JobId", "Status", "ImageUri", "Commands", "CreatedAt", "UpdatedAt", "ScheduledAt", "StartedAt", "CompletedAt", "RetryCount", "MaxRetries", "ErrorMessage", "GcpBatchJobPath", "GcpBatchTaskGroup", "EnvVarsJson", "Name", "ResourceProfile", "MachineType", "BootDiskSizeGb", "UseSpotVms", "ServiceAccount", "ServiceTier", "AssignedService", "MemoryMib", "CpuMillis", "MaxRunDurationSeconds", "OwnerWorkerId", "PreferredWorkerId", "LeaseExpiresAt", "LastHeartbeatAt"},
+		[]string{"TenantId", "JobId", "Status", "ImageUri", "Commands", "CreatedAt", "UpdatedAt", "ScheduledAt", "StartedAt", "CompletedAt", "RetryCount", "MaxRetries", "ErrorMessage", "GcpBatchJobPath", "GcpBatchTaskGroup", "EnvVarsJson", "Name", "ResourceProfile", "MachineType", "BootDiskSizeGb", "UseSpotVms", "ServiceAccount", "ServiceTier", "AssignedService", "MemoryMib", "CpuMillis", "MaxRunDurationSeconds", "OwnerWorkerId", "PreferredWorkerId", "LeaseExpiresAt", "LastHeartbeatAt", "RetryPolicy", "WorkflowId", "WorkflowNodeId", "DependsOnJson", "IdempotencyKey", "RequestHash", "SubmitResponseJson", "LabelsJson"},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get job: %w", err)
@@ -68,7 +76,7 @@ func (c *Client) GetJob(ctx context.Context, tenantID, jobID string) (*Job, erro
 // ListJobs returns all jobs for a tenant
 func (c *Client) ListJobs(ctx context.Context, tenantID string) ([]*Job, error) {
 	stmt := spanner.Statement{
-		SQL: `SELECT TenantId, JobId, Status, ImageUri, Commands, CreatedAt, UpdatedAt, ScheduledAt, StartedAt, CompletedAt, RetryCount, MaxRetries, ErrorMessage, GcpBatchJobPath, GcpBatchTaskGroup, EnvVarsJson, Name, ResourceProfile, MachineType, BootDiskSizeGb, UseSpotVms, ServiceAccount, ServiceTier, AssignedService, MemoryMib, CpuMillis, MaxRunDurationSeconds, OwnerWorkerId, PreferredWorkerId, LeaseExpiresAt, LastHeartbeatAt
+		SQL: `SELECT TenantId, JobId, Status, ImageUri, Commands, CreatedAt, UpdatedAt, ScheduledAt, StartedAt, CompletedAt, RetryCount, MaxRetries, ErrorMessage, GcpBatchJobPath, GcpBatchTaskGroup, EnvVarsJson, Name, ResourceProfile, MachineType, BootDiskSizeGb, UseSpotVms, ServiceAccount, ServiceTier, AssignedService, MemoryMib, CpuMillis, MaxRunDurationSeconds, OwnerWorkerId, PreferredWorkerId, LeaseExpiresAt, LastHeartbeatAt, RetryPolicy, WorkflowId, WorkflowNodeId, DependsOnJson, IdempotencyKey, RequestHash, SubmitResponseJson, LabelsJson
 		      FROM Jobs 
 		      WHERE TenantId = @tenantId 
 		      ORDER BY CreatedAt DESC`,
@@ -100,10 +108,92 @@ func (c *Client) ListJobs(ctx context.Context, tenantID string) ([]*Job, error)
 	return jobs, nil
 }
 
+// ListJobsFiltered returns a page of a tenant's jobs matching filter, ordered
+// by CreatedAt then JobId, newest first unless filter.Ascending.
+func (c *Client) ListJobsFiltered(ctx context.Context, tenantID string, filter JobFilter) ([]*Job, error) {
+	conds := []string{"TenantId = @tenantId"}
+	params := map[string]interface{}{"tenantId": tenantID}
+
+	// Pick the index that narrows the scan most; each ends in CreatedAt.
+	index := "JobsByCreatedAt"
+	if len(filter.Statuses) > 0 {
+		conds = append(conds, "Status IN UNNEST(@statuses)")
+		params["statuses"] = filter.Statuses
+		index = "JobsByStatus"
+	}
+	if filter.AssignedService != "" {
+		conds = append(conds, "AssignedService = @assignedService")
+		params["assignedService"] = filter.AssignedService
+		index = "JobsByAssignedService"
+	}
+	if filter.NamePrefix != "" {
+		conds = append(conds, "STARTS_WITH(Name, @namePrefix)")
+		params["namePrefix"] = filter.NamePrefix
+		index = "IdxJobsByName"
+	}
+	if !filter.CreatedAfter.IsZero() {
+		conds = append(conds, "CreatedAt >= @createdAfter")
+		params["createdAfter"] = filter.CreatedAfter
+	}
+	if !filter.CreatedBefore.IsZero() {
+		conds = append(conds, "CreatedAt < @createdBefore")
+		params["createdBefore"] = filter.CreatedBefore
+	}
+	for i, r := range filter.Labels {
+		cond, err := spannerLabelCondition(r, fmt.Sprintf("label%d", i))
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, cond)
+		params[fmt.Sprintf("label%d", i)] = r.Value
+	}
+
+	order, cmp := "DESC", "<"
+	if filter.Ascending {
+		order, cmp = "ASC", ">"
+	}
+	if filter.After != nil {
+		conds = append(conds, fmt.Sprintf("(CreatedAt %[1]s @afterCreatedAt OR (CreatedAt = @afterCreatedAt AND JobId %[1]s @afterJobId))", cmp))
+		params["afterCreatedAt"] = filter.After.CreatedAt
+		params["afterJobId"] = filter.After.JobId
+	}
+
+	sql := `SELECT ` + columnList(jobColumns) + `
+	        FROM Jobs@{FORCE_INDEX=` + index + `}
+	        WHERE ` + strings.Join(conds, " AND ") + `
+	        ORDER BY CreatedAt ` + order + `, JobId ` + order
+	if filter.Limit > 0 {
+		sql += ` LIMIT @limit`
+		params["limit"] = int64(filter.Limit)
+	}
+
+	iter := c.client.Single().Query(ctx, spanner.Statement{SQL: sql, Params: params})
+	defer iter.Stop()
+
+	var jobs []*Job
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate jobs: %w", err)
+		}
+
+		var job Job
+		if err := row.ToStruct(&job); err != nil {
+			return nil, fmt.Errorf("failed to parse job: %w", err)
+		}
+		jobs = append(jobs, &job)
+	}
+
+	return jobs, nil
+}
+
 // ListJobsByStatus returns jobs for a tenant filtered by status
 func (c *Client) ListJobsByStatus(ctx context.Context, tenantID, status string) ([]*Job, error) {
 	stmt := spanner.Statement{
-		SQL: `SELECT TenantId, JobId, Status, ImageUri, Commands, CreatedAt, UpdatedAt, ScheduledAt, StartedAt, CompletedAt, RetryCount, MaxRetries, ErrorMessage, GcpBatchJobPath, GcpBatchTaskGroup, EnvVarsJson, Name, ResourceProfile, MachineType, BootDiskSizeGb, UseSpotVms, ServiceAccount, ServiceTier, AssignedService, MemoryMib, CpuMillis, MaxRunDurationSeconds, OwnerWorkerId, PreferredWorkerId, LeaseExpiresAt, LastHeartbeatAt
+		SQL: `SELECT TenantId, JobId, Status, ImageUri, Commands, CreatedAt, UpdatedAt, ScheduledAt, StartedAt, CompletedAt, RetryCount, MaxRetries, ErrorMessage, GcpBatchJobPath, GcpBatchTaskGroup, EnvVarsJson, Name, ResourceProfile, MachineType, BootDiskSizeGb, UseSpotVms, ServiceAccount, ServiceTier, AssignedService, MemoryMib, CpuMillis, MaxRunDurationSeconds, OwnerWorkerId, PreferredWorkerId, LeaseExpiresAt, LastHeartbeatAt, RetryPolicy, WorkflowId, WorkflowNodeId, DependsOnJson, IdempotencyKey, RequestHash, SubmitResponseJson, LabelsJson
 		      FROM Jobs@{FORCE_INDEX=JobsByStatus}
 		      WHERE TenantId = @tenantId AND Status = @status 
 		      ORDER BY CreatedAt DESC`,
@@ -194,6 +284,21 @@ func (c *Client) FailJob(ctx context.Context, tenantID, jobID, errorMessage stri
 	return nil
 }
 
+// RetryJob marks a failed job as RETRYING, recording the attempt number and
+// the error that triggered the retry.
+func (c *Client) RetryJob(ctx context.Context, tenantID, jobID string, retryCount int64, errorMessage string) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Update("Jobs",
+			[]string{"TenantId", "JobId", "Status", "RetryCount", "ErrorMessage", "UpdatedAt"},
+			[]any{tenantID, jobID, JobStatusRetrying, retryCount, errorMessage, spanner.CommitTimestamp},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark job for retry: %w", err)
+	}
+	return nil
+}
+
 // ScheduleJob marks a job as SCHEDULED with a scheduled timestamp
 func (c *Client) ScheduleJob(ctx context.Context, tenantID, jobID string) error {
 	now := time.Now()
@@ -251,17 +356,19 @@ func (c *Client) DeleteJob(ctx context.Context, tenantID, jobID string) error {
 }
 
 // ListActiveJobs returns all active (non-terminal) jobs across tenants that have a cloud resource path.
+// RETRYING jobs are included so a worker that takes over the lease can resume the retry.
 func (c *Client) ListActiveJobs(ctx context.Context) ([]*Job, error) {
 	stmt := spanner.Statement{
-		SQL: `SELECT TenantId, JobId, Status, ImageUri, Commands, CreatedAt, UpdatedAt, ScheduledAt, StartedAt, CompletedAt, RetryCount, MaxRetries, ErrorMessage, GcpBatchJobPath, GcpBatchTaskGroup, EnvVarsJson, Name, ResourceProfile, MachineType, BootDiskSizeGb, UseSpotVms, ServiceAccount, ServiceTier, AssignedService, MemoryMib, CpuMillis, MaxRunDurationSeconds, OwnerWorkerId, PreferredWorkerId, LeaseExpiresAt, LastHeartbeatAt
+		SQL: `SELECT TenantId, JobId, Status, ImageUri, Commands, CreatedAt, UpdatedAt, ScheduledAt, StartedAt, CompletedAt, RetryCount, MaxRetries, ErrorMessage, GcpBatchJobPath, GcpBatchTaskGroup, EnvVarsJson, Name, ResourceProfile, MachineType, BootDiskSizeGb, UseSpotVms, ServiceAccount, ServiceTier, AssignedService, MemoryMib, CpuMillis, MaxRunDurationSeconds, OwnerWorkerId, PreferredWorkerId, LeaseExpiresAt, LastHeartbeatAt, RetryPolicy, WorkflowId, WorkflowNodeId, DependsOnJson, IdempotencyKey, RequestHash, SubmitResponseJson, LabelsJson
 		      FROM Jobs
-		      WHERE Status IN (@pending, @scheduled, @running)
+		      WHERE Status IN (@pending, @scheduled, @running, @retrying)
 		        AND GcpBatchJobPath IS NOT NULL
 		      ORDER BY UpdatedAt DESC`,
 		Params: map[string]interface{}{
 			"pending":   JobStatusPending,
 			"scheduled": JobStatusScheduled,
 			"running":   JobStatusRunning,
+			"retrying":  JobStatusRetrying,
 		},
 	}
 
@@ -288,6 +395,137 @@ func (c *Client) ListActiveJobs(ctx context.Context) ([]*Job, error) {
 	return jobs, nil
 }
 
+// ListQueuedJobs returns every QUEUED job across tenants, oldest first, so
+// workers release them in submission order.
+func (c *Client) ListQueuedJobs(ctx context.Context) ([]*Job, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + columnList(jobColumns) + `
+		      FROM Jobs
+		      WHERE Status = @queued
+		      ORDER BY CreatedAt`,
+		Params: map[string]interface{}{"queued": JobStatusQueued},
+	}
+
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var jobs []*Job
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate queued jobs: %w", err)
+		}
+
+		var job Job
+		if err := row.ToStruct(&job); err != nil {
+			return nil, fmt.Errorf("failed to parse queued job: %w", err)
+		}
+		jobs = append(jobs, &job)
+	}
+
+	return jobs, nil
+}
+
+// CountJobsSince returns how many jobs a tenant created at or after since.
+func (c *Client) CountJobsSince(ctx context.Context, tenantID string, since time.Time) (int64, error) {
+	stmt := spanner.Statement{
+		SQL:    `SELECT COUNT(*) FROM Jobs WHERE TenantId = @tenantId AND CreatedAt >= @since`,
+		Params: map[string]interface{}{"tenantId": tenantID, "since": since},
+	}
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	row, err := iter.Next()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count jobs: %w", err)
+	}
+	var count int64
+	if err := row.Columns(&count); err != nil {
+		return 0, fmt.Errorf("failed to parse job count: %w", err)
+	}
+	return count, nil
+}
+
+// spannerLabelCondition returns the WHERE condition for one label
+// requirement. JSON paths must be literals, so the key is checked to hold
+// only label characters before it is embedded.
+func spannerLabelCondition(r LabelRequirement, param string) (string, error) {
+	if r.Key == "" || strings.Trim(r.Key, "abcdefghijklmnopqrstuvwxyz0123456789_-") != "" {
+		return "", fmt.Errorf("invalid label key %q", r.Key)
+	}
+	value := `JSON_VALUE(LabelsJson, '$."` + r.Key + `"')`
+	switch r.Operator {
+	case LabelEquals:
+		return value + " = @" + param, nil
+	case LabelNotEquals:
+		return "IFNULL(" + value + " != @" + param + ", TRUE)", nil
+	case LabelExists:
+		return value + " IS NOT NULL", nil
+	case LabelDoesNotExist:
+		return value + " IS NULL", nil
+	}
+	return "", fmt.Errorf("unknown label operator %q", r.Operator)
+}
+
+// GetJobByIdempotencyKey returns the tenant's job submitted with the given
+// idempotency key. It returns nil, nil when no job holds the key.
+func (c *Client) GetJobByIdempotencyKey(ctx context.Context, tenantID, idempotencyKey string) (*Job, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + columnList(jobColumns) + `
+		      FROM Jobs@{FORCE_INDEX=JobsByIdempotencyKey}
+		      WHERE TenantId = @tenantId AND IdempotencyKey = @idempotencyKey`,
+		Params: map[string]interface{}{"tenantId": tenantID, "idempotencyKey": idempotencyKey},
+	}
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	row, err := iter.Next()
+	if err == iterator.Done {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job by idempotency key: %w", err)
+	}
+	var job Job
+	if err := row.ToStruct(&job); err != nil {
+		return nil, fmt.Errorf("failed to parse job: %w", err)
+	}
+	return &job, nil
+}
+
+// SetJobSubmitResponse stores the SubmitJob response replayed to retries of
+// an idempotent submission.
+func (c *Client) SetJobSubmitResponse(ctx context.Context, tenantID, jobID, responseJson string) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Update("Jobs",
+			[]string{"TenantId", "JobId", "SubmitResponseJson"},
+			[]any{tenantID, jobID, responseJson},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set job submit response: %w", err)
+	}
+	return nil
+}
+
+// ClearJobIdempotencyKey releases a job's idempotency key so a new
+// submission may use it. The job itself is left unchanged.
+func (c *Client) ClearJobIdempotencyKey(ctx context.Context, tenantID, jobID string) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Update("Jobs",
+			[]string{"TenantId", "JobId", "IdempotencyKey", "RequestHash", "SubmitResponseJson"},
+			[]any{tenantID, jobID, nil, nil, nil},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clear job idempotency key: %w", err)
+	}
+	return nil
+}
+
 // TryClaimOrRenewJobLease attempts to claim/renew ownership for an active job.
 // Returns true when caller becomes/continues owner.
 func (c *Client) TryClaimOrRenewJobLease(ctx context.Context, tenantID, jobID, workerID string, leaseUntil time.Time) (bool, error) {
@@ -306,7 +544,7 @@ func (c *Client) TryClaimOrRenewJobLease(ctx context.Context, tenantID, jobID, w
 			return fmt.Errorf("failed to parse job lease state: %w", err)
 		}
 
-		if status == JobStatusCompleted || status == JobStatusFailed || status == JobStatusCancelled {
+		if status == JobStatusCompleted || status == JobStatusFailed || status == JobStatusCancelled || status == JobStatusSkipped {
 			return nil
 		}
 
@@ -338,3 +576,38 @@ func (c *Client) TryClaimOrRenewJobLease(ctx context.Context, tenantID, jobID, w
 
 	return claimed, nil
 }
+
+// ReleaseJobLease gives up workerID's lease on a job and names
+// preferredWorkerID (empty for none) as the worker to take it over. Returns
+// false when workerID does not own the job.
+func (c *Client) ReleaseJobLease(ctx context.Context, tenantID, jobID, workerID, preferredWorkerID string) (bool, error) {
+	released := false
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		row, err := txn.ReadRow(ctx, "Jobs", spanner.Key{tenantID, jobID}, []string{"OwnerWorkerId"})
+		if err != nil {
+			return fmt.Errorf("failed to read job lease state: %w", err)
+		}
+		var ownerWorkerID spanner.NullString
+		if err := row.Columns(&ownerWorkerID); err != nil {
+			return fmt.Errorf("failed to parse job lease state: %w", err)
+		}
+		if !ownerWorkerID.Valid || ownerWorkerID.StringVal != workerID {
+			return nil
+		}
+
+		preferred := spanner.NullString{StringVal: preferredWorkerID, Valid: preferredWorkerID != ""}
+		mutation := spanner.Update("Jobs",
+			[]string{"TenantId", "JobId", "OwnerWorkerId", "PreferredWorkerId", "LeaseExpiresAt", "UpdatedAt"},
+			[]interface{}{tenantID, jobID, nil, preferred, nil, spanner.CommitTimestamp},
+		)
+		if err := txn.BufferWrite([]*spanner.Mutation{mutation}); err != nil {
+			return fmt.Errorf("failed to buffer lease mutation: %w", err)
+		}
+		released = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to release lease: %w", err)
+	}
+	return released, nil
+}
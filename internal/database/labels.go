@@ -0,0 +1,61 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Label selector operators.
+const (
+	LabelEquals       = "="      // key=value
+	LabelNotEquals    = "!="     // key!=value; jobs without the key match
+	LabelExists       = "exists" // key
+	LabelDoesNotExist = "!"      // !key
+)
+
+// LabelRequirement is one term of a label selector such as "team=billing".
+type LabelRequirement struct {
+	Key      string
+	Operator string
+	Value    string // compared by LabelEquals and LabelNotEquals
+}
+
+// Matches reports whether a job with the given labels meets the requirement.
+func (r LabelRequirement) Matches(labels map[string]string) bool {
+	v, ok := labels[r.Key]
+	switch r.Operator {
+	case LabelEquals:
+		return ok && v == r.Value
+	case LabelNotEquals:
+		return !ok || v != r.Value
+	case LabelExists:
+		return ok
+	case LabelDoesNotExist:
+		return !ok
+	}
+	return false
+}
+
+// JobLabels decodes a job's user labels. Jobs submitted without labels have
+// none.
+func JobLabels(job *Job) (map[string]string, error) {
+	if job.LabelsJson == nil || *job.LabelsJson == "" {
+		return nil, nil
+	}
+	var labels map[string]string
+	if err := json.Unmarshal([]byte(*job.LabelsJson), &labels); err != nil {
+		return nil, fmt.Errorf("failed to parse labels of job %s: %w", job.JobId, err)
+	}
+	return labels, nil
+}
+
+// EncodeJobLabels returns the LabelsJson value for labels, nil when there
+// are none.
+func EncodeJobLabels(labels map[string]string) *string {
+	if len(labels) == 0 {
+		return nil
+	}
+	b, _ := json.Marshal(labels) // a map[string]string always encodes
+	s := string(b)
+	return &s
+}
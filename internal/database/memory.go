@@ -0,0 +1,1577 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MemoryStore is an in-process Store that mirrors the Spanner schema and the
+// semantics the services rely on: interleaved parents must exist, deletes
+// cascade to child rows, commit timestamps are strictly increasing, and
+// missing/duplicate rows surface as NotFound/AlreadyExists gRPC codes so
+// spanner.ErrCode keeps working for callers.
+//
+// Data lives only as long as the process, so it suits single-process local
+// runs and tests rather than a multi-instance deployment.
+type MemoryStore struct {
+	mu         sync.Mutex
+	lastCommit time.Time
+
+	tenants       map[string]*Tenant
+	jobs          map[jobKey]*Job
+	transitions   map[jobKey]map[string]*JobStateTransition
+	notifications map[string]map[string]*Notification // TenantId → NotificationId → row
+	schedules     map[scheduleKey]*Schedule
+	workflows     map[workflowKey]*Workflow
+	apiKeys       map[apiKeyKey]*ApiKey
+	organizations map[string]*Organization // Key: TenantId
+	members       map[memberKey]*OrganizationMember
+	quotas        map[string]*TenantQuota // Key: TenantId
+	workers       map[string]*Worker      // Key: WorkerId
+	outbox        map[string]*OutboxEvent // Key: EventId
+}
+
+type jobKey struct {
+	tenantID string
+	jobID    string
+}
+
+type scheduleKey struct {
+	tenantID   string
+	scheduleID string
+}
+
+type workflowKey struct {
+	tenantID   string
+	workflowID string
+}
+
+type apiKeyKey struct {
+	tenantID string
+	apiKeyID string
+}
+
+type memberKey struct {
+	tenantID string
+	email    string
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		tenants:       make(map[string]*Tenant),
+		jobs:          make(map[jobKey]*Job),
+		transitions:   make(map[jobKey]map[string]*JobStateTransition),
+		notifications: make(map[string]map[string]*Notification),
+		schedules:     make(map[scheduleKey]*Schedule),
+		workflows:     make(map[workflowKey]*Workflow),
+		apiKeys:       make(map[apiKeyKey]*ApiKey),
+		organizations: make(map[string]*Organization),
+		members:       make(map[memberKey]*OrganizationMember),
+		quotas:        make(map[string]*TenantQuota),
+		workers:       make(map[string]*Worker),
+		outbox:        make(map[string]*OutboxEvent),
+	}
+}
+
+// Close is a no-op; it exists to satisfy Store.
+func (m *MemoryStore) Close() {}
+
+// commitTimestamp emulates spanner.CommitTimestamp: microsecond precision and
+// strictly increasing across commits. Callers must hold m.mu.
+func (m *MemoryStore) commitTimestamp() time.Time {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	if !now.After(m.lastCommit) {
+		now = m.lastCommit.Add(time.Microsecond)
+	}
+	m.lastCommit = now
+	return now
+}
+
+func errRowNotFound(table string, key ...string) error {
+	return status.Errorf(codes.NotFound, "row %v in table %s is missing", key, table)
+}
+
+func errRowExists(table string, key ...string) error {
+	return status.Errorf(codes.AlreadyExists, "row %v in table %s already exists", key, table)
+}
+
+// ── Jobs ─────────────────────────────────────────────────────────────────────
+
+// InsertJob creates a new job with PENDING status
+func (m *MemoryStore) InsertJob(ctx context.Context, tenantID, jobID, imageUri string, commands []string) error {
+	return m.insertJob(&Job{
+		TenantId:   tenantID,
+		JobId:      jobID,
+		Status:     JobStatusPending,
+		ImageUri:   imageUri,
+		Commands:   commands,
+		RetryCount: 0,
+		MaxRetries: 3,
+	})
+}
+
+// InsertJobFull creates a new job with all fields including advanced configuration.
+func (m *MemoryStore) InsertJobFull(ctx context.Context, job *Job) error {
+	row := cloneJob(job)
+	// Lifecycle columns are not part of the insert and start out NULL.
+	row.ScheduledAt = nil
+	row.StartedAt = nil
+	row.CompletedAt = nil
+	row.ErrorMessage = nil
+	return m.insertJob(row)
+}
+
+func (m *MemoryStore) insertJob(row *Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkJobInsertLocked(row); err != nil {
+		return err
+	}
+	m.insertJobLocked(row, m.commitTimestamp())
+	return nil
+}
+
+func (m *MemoryStore) checkJobInsertLocked(row *Job) error {
+	if _, ok := m.tenants[row.TenantId]; !ok {
+		return errRowNotFound("Tenants", row.TenantId)
+	}
+	key := jobKey{row.TenantId, row.JobId}
+	if _, ok := m.jobs[key]; ok {
+		return errRowExists("Jobs", row.TenantId, row.JobId)
+	}
+	// JobsByIdempotencyKey is a unique, NULL-filtered index.
+	if row.IdempotencyKey != nil {
+		for k, job := range m.jobs {
+			if k.tenantID == row.TenantId && job.IdempotencyKey != nil && *job.IdempotencyKey == *row.IdempotencyKey {
+				return errRowExists("JobsByIdempotencyKey", row.TenantId, *row.IdempotencyKey)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStore) insertJobLocked(row *Job, ts time.Time) {
+	row.CreatedAt = ts
+	row.UpdatedAt = ts
+	m.jobs[jobKey{row.TenantId, row.JobId}] = row
+}
+
+// GetJob retrieves a job by tenant ID and job ID
+func (m *MemoryStore) GetJob(ctx context.Context, tenantID, jobID string) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[jobKey{tenantID, jobID}]
+	if !ok {
+		return nil, fmt.Errorf("failed to get job: %w", errRowNotFound("Jobs", tenantID, jobID))
+	}
+	return cloneJob(job), nil
+}
+
+// ListJobs returns all jobs for a tenant
+func (m *MemoryStore) ListJobs(ctx context.Context, tenantID string) ([]*Job, error) {
+	return m.selectJobs(func(j *Job) bool { return j.TenantId == tenantID }, byCreatedAtDesc), nil
+}
+
+// ListJobsFiltered returns a page of a tenant's jobs matching filter, ordered
+// by CreatedAt then JobId, newest first unless filter.Ascending.
+func (m *MemoryStore) ListJobsFiltered(ctx context.Context, tenantID string, filter JobFilter) ([]*Job, error) {
+	less := func(a, b *Job) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt) != filter.Ascending
+		}
+		return (a.JobId > b.JobId) != filter.Ascending
+	}
+	jobs := m.selectJobs(func(j *Job) bool { return j.TenantId == tenantID && filter.Matches(j) }, less)
+	if filter.Limit > 0 && len(jobs) > filter.Limit {
+		jobs = jobs[:filter.Limit]
+	}
+	return jobs, nil
+}
+
+// ListJobsByStatus returns jobs for a tenant filtered by status
+func (m *MemoryStore) ListJobsByStatus(ctx context.Context, tenantID, status string) ([]*Job, error) {
+	return m.selectJobs(func(j *Job) bool { return j.TenantId == tenantID && j.Status == status }, byCreatedAtDesc), nil
+}
+
+// ListActiveJobs returns all active (non-terminal) jobs across tenants that have a cloud resource path.
+func (m *MemoryStore) ListActiveJobs(ctx context.Context) ([]*Job, error) {
+	return m.selectJobs(func(j *Job) bool {
+		if j.GcpBatchJobPath == nil {
+			return false
+		}
+		return j.Status == JobStatusPending || j.Status == JobStatusScheduled || j.Status == JobStatusRunning || j.Status == JobStatusRetrying
+	}, byUpdatedAtDesc), nil
+}
+
+// ListQueuedJobs returns every QUEUED job across tenants, oldest first.
+func (m *MemoryStore) ListQueuedJobs(ctx context.Context) ([]*Job, error) {
+	return m.selectJobs(func(j *Job) bool { return j.Status == JobStatusQueued }, byCreatedAtAsc), nil
+}
+
+// CountJobsSince returns how many jobs a tenant created at or after since.
+func (m *MemoryStore) CountJobsSince(ctx context.Context, tenantID string, since time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var count int64
+	for key, job := range m.jobs {
+		if key.tenantID == tenantID && !job.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetJobByIdempotencyKey returns the tenant's job submitted with the given
+// idempotency key. It returns nil, nil when no job holds the key.
+func (m *MemoryStore) GetJobByIdempotencyKey(ctx context.Context, tenantID, idempotencyKey string) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, job := range m.jobs {
+		if key.tenantID == tenantID && job.IdempotencyKey != nil && *job.IdempotencyKey == idempotencyKey {
+			return cloneJob(job), nil
+		}
+	}
+	return nil, nil
+}
+
+// SetJobSubmitResponse stores the SubmitJob response replayed to retries of
+// an idempotent submission.
+func (m *MemoryStore) SetJobSubmitResponse(ctx context.Context, tenantID, jobID, responseJson string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[jobKey{tenantID, jobID}]
+	if !ok {
+		return fmt.Errorf("failed to set job submit response: %w", errRowNotFound("Jobs", tenantID, jobID))
+	}
+	job.SubmitResponseJson = &responseJson
+	return nil
+}
+
+// ClearJobIdempotencyKey releases a job's idempotency key so a new
+// submission may use it. The job itself is left unchanged.
+func (m *MemoryStore) ClearJobIdempotencyKey(ctx context.Context, tenantID, jobID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[jobKey{tenantID, jobID}]
+	if !ok {
+		return fmt.Errorf("failed to clear job idempotency key: %w", errRowNotFound("Jobs", tenantID, jobID))
+	}
+	job.IdempotencyKey = nil
+	job.RequestHash = nil
+	job.SubmitResponseJson = nil
+	return nil
+}
+
+func byCreatedAtDesc(a, b *Job) bool { return a.CreatedAt.After(b.CreatedAt) }
+func byCreatedAtAsc(a, b *Job) bool  { return a.CreatedAt.Before(b.CreatedAt) }
+func byUpdatedAtDesc(a, b *Job) bool { return a.UpdatedAt.After(b.UpdatedAt) }
+
+func (m *MemoryStore) selectJobs(match func(*Job) bool, less func(a, b *Job) bool) []*Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var jobs []*Job
+	for _, job := range m.jobs {
+		if match(job) {
+			jobs = append(jobs, cloneJob(job))
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return less(jobs[i], jobs[j]) })
+	return jobs
+}
+
+// updateJob applies fn to an existing job row and bumps UpdatedAt.
+// Like a Spanner Update mutation, it fails with NotFound when the row is missing.
+func (m *MemoryStore) updateJob(tenantID, jobID string, fn func(job *Job, ts time.Time)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[jobKey{tenantID, jobID}]
+	if !ok {
+		return errRowNotFound("Jobs", tenantID, jobID)
+	}
+	ts := m.commitTimestamp()
+	fn(job, ts)
+	job.UpdatedAt = ts
+	return nil
+}
+
+// UpdateJobStatus updates the status of a job
+func (m *MemoryStore) UpdateJobStatus(ctx context.Context, tenantID, jobID, status string) error {
+	err := m.updateJob(tenantID, jobID, func(job *Job, _ time.Time) {
+		job.Status = status
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update job status: %w", err)
+	}
+	return nil
+}
+
+// UpdateJobStatusAndGcpBatchJobPath updates the status, GCP Batch job path, service tier, and assigned service of a job.
+func (m *MemoryStore) UpdateJobStatusAndGcpBatchJobPath(ctx context.Context, tenantID, jobID, status, gcpBatchJobPath, serviceTier, assignedService string) error {
+	err := m.updateJob(tenantID, jobID, func(job *Job, _ time.Time) {
+		job.Status = status
+		job.GcpBatchJobPath = &gcpBatchJobPath
+		job.ServiceTier = &serviceTier
+		job.AssignedService = &assignedService
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update job status and GCP Batch job path: %w", err)
+	}
+	return nil
+}
+
+// CompleteJob marks a job as completed with a completion timestamp
+func (m *MemoryStore) CompleteJob(ctx context.Context, tenantID, jobID string) error {
+	now := time.Now()
+	err := m.updateJob(tenantID, jobID, func(job *Job, _ time.Time) {
+		job.Status = JobStatusCompleted
+		job.CompletedAt = &now
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete job: %w", err)
+	}
+	return nil
+}
+
+// FailJob marks a job as failed with an error message
+func (m *MemoryStore) FailJob(ctx context.Context, tenantID, jobID, errorMessage string) error {
+	now := time.Now()
+	err := m.updateJob(tenantID, jobID, func(job *Job, _ time.Time) {
+		job.Status = JobStatusFailed
+		job.ErrorMessage = &errorMessage
+		job.CompletedAt = &now
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fail job: %w", err)
+	}
+	return nil
+}
+
+// RetryJob marks a failed job as RETRYING, recording the attempt number and
+// the error that triggered the retry.
+func (m *MemoryStore) RetryJob(ctx context.Context, tenantID, jobID string, retryCount int64, errorMessage string) error {
+	err := m.updateJob(tenantID, jobID, func(job *Job, _ time.Time) {
+		job.Status = JobStatusRetrying
+		job.RetryCount = retryCount
+		job.ErrorMessage = &errorMessage
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark job for retry: %w", err)
+	}
+	return nil
+}
+
+// ScheduleJob marks a job as SCHEDULED with a scheduled timestamp
+func (m *MemoryStore) ScheduleJob(ctx context.Context, tenantID, jobID string) error {
+	now := time.Now()
+	err := m.updateJob(tenantID, jobID, func(job *Job, _ time.Time) {
+		job.Status = JobStatusScheduled
+		job.ScheduledAt = &now
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule job: %w", err)
+	}
+	return nil
+}
+
+// StartJob marks a job as RUNNING with a started timestamp
+func (m *MemoryStore) StartJob(ctx context.Context, tenantID, jobID string) error {
+	now := time.Now()
+	err := m.updateJob(tenantID, jobID, func(job *Job, _ time.Time) {
+		job.Status = JobStatusRunning
+		job.StartedAt = &now
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start job: %w", err)
+	}
+	return nil
+}
+
+// CancelJob marks a job as CANCELLED
+func (m *MemoryStore) CancelJob(ctx context.Context, tenantID, jobID string) error {
+	now := time.Now()
+	err := m.updateJob(tenantID, jobID, func(job *Job, _ time.Time) {
+		job.Status = JobStatusCancelled
+		job.CompletedAt = &now
+	})
+	if err != nil {
+		return fmt.Errorf("failed to cancel job: %w", err)
+	}
+	return nil
+}
+
+// DeleteJob removes a job and its state transitions. Deleting a missing job is not an error.
+func (m *MemoryStore) DeleteJob(ctx context.Context, tenantID, jobID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.deleteJobLocked(jobKey{tenantID, jobID})
+	return nil
+}
+
+func (m *MemoryStore) deleteJobLocked(key jobKey) {
+	delete(m.jobs, key)
+	delete(m.transitions, key)
+}
+
+// TryClaimOrRenewJobLease attempts to claim/renew ownership for an active job.
+// Returns true when caller becomes/continues owner.
+func (m *MemoryStore) TryClaimOrRenewJobLease(ctx context.Context, tenantID, jobID, workerID string, leaseUntil time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[jobKey{tenantID, jobID}]
+	if !ok {
+		return false, fmt.Errorf("failed to claim/renew lease: failed to read job lease state: %w", errRowNotFound("Jobs", tenantID, jobID))
+	}
+
+	if job.Status == JobStatusCompleted || job.Status == JobStatusFailed || job.Status == JobStatusCancelled || job.Status == JobStatusSkipped {
+		return false, nil
+	}
+
+	now := time.Now().UTC()
+	isOwner := job.OwnerWorkerId != nil && *job.OwnerWorkerId == workerID
+	leaseExpired := job.LeaseExpiresAt == nil || job.LeaseExpiresAt.Before(now)
+	isUnowned := job.OwnerWorkerId == nil || *job.OwnerWorkerId == ""
+	preferredTakeover := job.PreferredWorkerId != nil && *job.PreferredWorkerId == workerID && !isOwner
+
+	canClaim := isOwner || leaseExpired || isUnowned || preferredTakeover
+	if !canClaim {
+		return false, nil
+	}
+
+	owner := workerID
+	until := leaseUntil
+	job.OwnerWorkerId = &owner
+	job.LeaseExpiresAt = &until
+	job.LastHeartbeatAt = &now
+	job.UpdatedAt = m.commitTimestamp()
+	return true, nil
+}
+
+// ReleaseJobLease gives up workerID's lease on a job and names
+// preferredWorkerID (empty for none) as the worker to take it over. Returns
+// false when workerID does not own the job.
+func (m *MemoryStore) ReleaseJobLease(ctx context.Context, tenantID, jobID, workerID, preferredWorkerID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[jobKey{tenantID, jobID}]
+	if !ok {
+		return false, fmt.Errorf("failed to release lease: failed to read job lease state: %w", errRowNotFound("Jobs", tenantID, jobID))
+	}
+	if job.OwnerWorkerId == nil || *job.OwnerWorkerId != workerID {
+		return false, nil
+	}
+
+	job.OwnerWorkerId = nil
+	job.LeaseExpiresAt = nil
+	job.PreferredWorkerId = nil
+	if preferredWorkerID != "" {
+		job.PreferredWorkerId = &preferredWorkerID
+	}
+	job.UpdatedAt = m.commitTimestamp()
+	return true, nil
+}
+
+// ── Tenants ──────────────────────────────────────────────────────────────────
+
+// UpsertTenant creates a new tenant or updates it if it already exists.
+// Like the Spanner InsertOrUpdate it replaces every written column, including CreatedAt.
+func (m *MemoryStore) UpsertTenant(ctx context.Context, tenantID, userEmail, oauthProvider, oauthUserId string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ts := m.commitTimestamp()
+	m.tenants[tenantID] = &Tenant{
+		TenantId:      tenantID,
+		UserEmail:     userEmail,
+		OAuthProvider: oauthProvider,
+		OAuthUserId:   oauthUserId,
+		CreatedAt:     ts,
+		UpdatedAt:     ts,
+	}
+	return nil
+}
+
+// InsertTenant creates a new tenant
+func (m *MemoryStore) InsertTenant(ctx context.Context, tenantID, userEmail, oauthProvider, oauthUserId string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tenants[tenantID]; ok {
+		return fmt.Errorf("failed to insert tenant: %w", errRowExists("Tenants", tenantID))
+	}
+	ts := m.commitTimestamp()
+	m.tenants[tenantID] = &Tenant{
+		TenantId:      tenantID,
+		UserEmail:     userEmail,
+		OAuthProvider: oauthProvider,
+		OAuthUserId:   oauthUserId,
+		CreatedAt:     ts,
+		UpdatedAt:     ts,
+	}
+	return nil
+}
+
+// GetTenant retrieves a tenant by ID
+func (m *MemoryStore) GetTenant(ctx context.Context, tenantID string) (*Tenant, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tenant, ok := m.tenants[tenantID]
+	if !ok {
+		return nil, fmt.Errorf("failed to get tenant: %w", errRowNotFound("Tenants", tenantID))
+	}
+	t := *tenant
+	return &t, nil
+}
+
+// ListTenants returns all tenants
+func (m *MemoryStore) ListTenants(ctx context.Context) ([]*Tenant, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tenants := make([]*Tenant, 0, len(m.tenants))
+	for _, tenant := range m.tenants {
+		t := *tenant
+		tenants = append(tenants, &t)
+	}
+	sort.Slice(tenants, func(i, j int) bool { return tenants[i].CreatedAt.After(tenants[j].CreatedAt) })
+	return tenants, nil
+}
+
+// GetTenantByOAuth retrieves a tenant by OAuth provider and user ID.
+// Returns nil, nil when no tenant matches.
+func (m *MemoryStore) GetTenantByOAuth(ctx context.Context, oauthProvider, oauthUserId string) (*Tenant, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var found *Tenant
+	for _, tenant := range m.tenants {
+		if tenant.OAuthProvider != oauthProvider || tenant.OAuthUserId != oauthUserId {
+			continue
+		}
+		if found == nil || tenant.CreatedAt.Before(found.CreatedAt) {
+			found = tenant
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+	t := *found
+	return &t, nil
+}
+
+// DeleteTenant removes a tenant and all its jobs, transitions, notifications, schedules, workflows, API keys, organization and quota (CASCADE)
+func (m *MemoryStore) DeleteTenant(ctx context.Context, tenantID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.tenants, tenantID)
+	for key := range m.jobs {
+		if key.tenantID == tenantID {
+			m.deleteJobLocked(key)
+		}
+	}
+	delete(m.notifications, tenantID)
+	for key := range m.schedules {
+		if key.tenantID == tenantID {
+			delete(m.schedules, key)
+		}
+	}
+	for key := range m.workflows {
+		if key.tenantID == tenantID {
+			delete(m.workflows, key)
+		}
+	}
+	for key := range m.apiKeys {
+		if key.tenantID == tenantID {
+			delete(m.apiKeys, key)
+		}
+	}
+	delete(m.organizations, tenantID)
+	delete(m.quotas, tenantID)
+	for key := range m.members {
+		if key.tenantID == tenantID {
+			delete(m.members, key)
+		}
+	}
+	return nil
+}
+
+// ── Quotas ───────────────────────────────────────────────────────────────────
+
+// GetTenantQuota retrieves a tenant's quota overrides. It returns nil, nil
+// when the tenant has none.
+func (m *MemoryStore) GetTenantQuota(ctx context.Context, tenantID string) (*TenantQuota, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	q, ok := m.quotas[tenantID]
+	if !ok {
+		return nil, nil
+	}
+	return cloneTenantQuota(q), nil
+}
+
+// UpsertTenantQuota creates or replaces a tenant's quota overrides.
+func (m *MemoryStore) UpsertTenantQuota(ctx context.Context, q *TenantQuota) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tenants[q.TenantId]; !ok {
+		return fmt.Errorf("failed to upsert tenant quota: %w", errRowNotFound("Tenants", q.TenantId))
+	}
+	row := cloneTenantQuota(q)
+	row.UpdatedAt = m.commitTimestamp()
+	m.quotas[q.TenantId] = row
+	return nil
+}
+
+// ── Notifications ────────────────────────────────────────────────────────────
+
+// InsertNotification persists a notification row, replacing any existing row
+// with the same (TenantId, NotificationId) as the Spanner InsertOrUpdate does.
+func (m *MemoryStore) InsertNotification(ctx context.Context, n *Notification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tenants[n.TenantId]; !ok {
+		return fmt.Errorf("insert notification: %w", errRowNotFound("Tenants", n.TenantId))
+	}
+
+	row := cloneNotification(n)
+	row.IsRead = false
+	row.CreatedAt = m.commitTimestamp()
+
+	byID, ok := m.notifications[n.TenantId]
+	if !ok {
+		byID = make(map[string]*Notification)
+		m.notifications[n.TenantId] = byID
+	}
+	byID[n.NotificationId] = row
+	return nil
+}
+
+// ListNotifications returns notifications for a tenant ordered by newest first.
+// limit ≤ 0 defaults to 20.
+func (m *MemoryStore) ListNotifications(ctx context.Context, tenantID string, limit int32) ([]*Notification, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	return m.selectNotifications(tenantID,
+		func(*Notification) bool { return true },
+		func(a, b *Notification) bool { return a.OccurredAt.After(b.OccurredAt) },
+		limit,
+	), nil
+}
+
+// ListNotificationsSince returns notifications for a tenant that were created
+// after the given timestamp, ordered oldest-first. limit ≤ 0 defaults to 50.
+func (m *MemoryStore) ListNotificationsSince(ctx context.Context, tenantID string, since time.Time, limit int32) ([]*Notification, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	return m.selectNotifications(tenantID,
+		func(n *Notification) bool { return n.CreatedAt.After(since) },
+		func(a, b *Notification) bool { return a.CreatedAt.Before(b.CreatedAt) },
+		limit,
+	), nil
+}
+
+func (m *MemoryStore) selectNotifications(tenantID string, match func(*Notification) bool, less func(a, b *Notification) bool, limit int32) []*Notification {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var out []*Notification
+	for _, n := range m.notifications[tenantID] {
+		if match(n) {
+			out = append(out, cloneNotification(n))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return less(out[i], out[j]) })
+	if int32(len(out)) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// CountUnread returns the number of unread notifications for a tenant.
+func (m *MemoryStore) CountUnread(ctx context.Context, tenantID string) (int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var count int32
+	for _, n := range m.notifications[tenantID] {
+		if !n.IsRead {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// AckNotification marks a single notification as read.
+func (m *MemoryStore) AckNotification(ctx context.Context, tenantID, notificationID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n, ok := m.notifications[tenantID][notificationID]
+	if !ok {
+		return fmt.Errorf("ack notification %s: %w", notificationID, errRowNotFound("Notifications", tenantID, notificationID))
+	}
+	n.IsRead = true
+	return nil
+}
+
+// ── Workflows ────────────────────────────────────────────────────────────────
+
+// InsertWorkflow creates a workflow and all of its node jobs in one commit.
+func (m *MemoryStore) InsertWorkflow(ctx context.Context, wf *Workflow, nodes []*Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tenants[wf.TenantId]; !ok {
+		return fmt.Errorf("failed to insert workflow: %w", errRowNotFound("Tenants", wf.TenantId))
+	}
+	key := workflowKey{wf.TenantId, wf.WorkflowId}
+	if _, ok := m.workflows[key]; ok {
+		return fmt.Errorf("failed to insert workflow: %w", errRowExists("Workflows", wf.TenantId, wf.WorkflowId))
+	}
+	rows := make([]*Job, 0, len(nodes))
+	for _, job := range nodes {
+		row := cloneJob(job)
+		row.ScheduledAt = nil
+		row.StartedAt = nil
+		row.CompletedAt = nil
+		row.ErrorMessage = nil
+		if err := m.checkJobInsertLocked(row); err != nil {
+			return fmt.Errorf("failed to insert workflow: %w", err)
+		}
+		rows = append(rows, row)
+	}
+
+	ts := m.commitTimestamp()
+	w := *wf
+	w.Name = clonePtr(wf.Name)
+	w.CancelledAt = nil
+	w.CreatedAt = ts
+	w.UpdatedAt = ts
+	m.workflows[key] = &w
+	for _, row := range rows {
+		m.insertJobLocked(row, ts)
+	}
+	return nil
+}
+
+// GetWorkflow retrieves a workflow by tenant ID and workflow ID.
+func (m *MemoryStore) GetWorkflow(ctx context.Context, tenantID, workflowID string) (*Workflow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	wf, ok := m.workflows[workflowKey{tenantID, workflowID}]
+	if !ok {
+		return nil, fmt.Errorf("failed to get workflow: %w", errRowNotFound("Workflows", tenantID, workflowID))
+	}
+	c := *wf
+	c.Name = clonePtr(wf.Name)
+	c.CancelledAt = clonePtr(wf.CancelledAt)
+	return &c, nil
+}
+
+// CancelWorkflow records that a workflow was cancelled.
+func (m *MemoryStore) CancelWorkflow(ctx context.Context, tenantID, workflowID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	wf, ok := m.workflows[workflowKey{tenantID, workflowID}]
+	if !ok {
+		return fmt.Errorf("failed to cancel workflow: %w", errRowNotFound("Workflows", tenantID, workflowID))
+	}
+	ts := m.commitTimestamp()
+	wf.CancelledAt = &ts
+	wf.UpdatedAt = ts
+	return nil
+}
+
+// ListWorkflowJobs returns the node jobs of a workflow ordered by node ID.
+func (m *MemoryStore) ListWorkflowJobs(ctx context.Context, tenantID, workflowID string) ([]*Job, error) {
+	return m.selectJobs(func(j *Job) bool {
+		return j.TenantId == tenantID && j.WorkflowId != nil && *j.WorkflowId == workflowID
+	}, func(a, b *Job) bool {
+		return *a.WorkflowNodeId < *b.WorkflowNodeId
+	}), nil
+}
+
+// ── API keys ─────────────────────────────────────────────────────────────────
+
+// InsertApiKey creates an API key. Like the ApiKeysByHash unique index, a
+// second key with the same hash fails with AlreadyExists.
+func (m *MemoryStore) InsertApiKey(ctx context.Context, k *ApiKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tenants[k.TenantId]; !ok {
+		return fmt.Errorf("failed to insert API key: %w", errRowNotFound("Tenants", k.TenantId))
+	}
+	key := apiKeyKey{k.TenantId, k.ApiKeyId}
+	if _, ok := m.apiKeys[key]; ok {
+		return fmt.Errorf("failed to insert API key: %w", errRowExists("ApiKeys", k.TenantId, k.ApiKeyId))
+	}
+	for _, existing := range m.apiKeys {
+		if existing.KeyHash == k.KeyHash {
+			return fmt.Errorf("failed to insert API key: %w", errRowExists("ApiKeysByHash", k.KeyHash))
+		}
+	}
+
+	row := cloneApiKey(k)
+	row.RevokedAt = nil
+	row.CreatedAt = m.commitTimestamp()
+	m.apiKeys[key] = row
+	return nil
+}
+
+// GetApiKey retrieves an API key by tenant ID and API key ID.
+func (m *MemoryStore) GetApiKey(ctx context.Context, tenantID, apiKeyID string) (*ApiKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	k, ok := m.apiKeys[apiKeyKey{tenantID, apiKeyID}]
+	if !ok {
+		return nil, fmt.Errorf("failed to get API key: %w", errRowNotFound("ApiKeys", tenantID, apiKeyID))
+	}
+	return cloneApiKey(k), nil
+}
+
+// GetApiKeyByHash retrieves the API key with the given hash across tenants.
+// Returns nil, nil when no key matches.
+func (m *MemoryStore) GetApiKeyByHash(ctx context.Context, keyHash string) (*ApiKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, k := range m.apiKeys {
+		if k.KeyHash == keyHash {
+			return cloneApiKey(k), nil
+		}
+	}
+	return nil, nil
+}
+
+// ListApiKeys returns all API keys of a tenant, revoked ones included,
+// newest first.
+func (m *MemoryStore) ListApiKeys(ctx context.Context, tenantID string) ([]*ApiKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var keys []*ApiKey
+	for key, k := range m.apiKeys {
+		if key.tenantID == tenantID {
+			keys = append(keys, cloneApiKey(k))
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CreatedAt.After(keys[j].CreatedAt) })
+	return keys, nil
+}
+
+// RevokeApiKey marks an API key as revoked. The row is kept so listings
+// still show the key and when it was revoked.
+func (m *MemoryStore) RevokeApiKey(ctx context.Context, tenantID, apiKeyID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	k, ok := m.apiKeys[apiKeyKey{tenantID, apiKeyID}]
+	if !ok {
+		return fmt.Errorf("failed to revoke API key: %w", errRowNotFound("ApiKeys", tenantID, apiKeyID))
+	}
+	ts := m.commitTimestamp()
+	k.RevokedAt = &ts
+	return nil
+}
+
+// ── Organizations ────────────────────────────────────────────────────────────
+
+// InsertOrganization creates an organization, its Tenants row and its first
+// admin in one commit.
+func (m *MemoryStore) InsertOrganization(ctx context.Context, org *Organization, admin *OrganizationMember) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tenants[org.TenantId]; ok {
+		return fmt.Errorf("failed to insert organization: %w", errRowExists("Tenants", org.TenantId))
+	}
+	ts := m.commitTimestamp()
+	m.tenants[org.TenantId] = &Tenant{
+		TenantId:      org.TenantId,
+		UserEmail:     org.CreatedBy,
+		OAuthProvider: OrganizationProvider,
+		OAuthUserId:   org.TenantId,
+		CreatedAt:     ts,
+		UpdatedAt:     ts,
+	}
+	row := *org
+	row.CreatedAt = ts
+	row.UpdatedAt = ts
+	m.organizations[org.TenantId] = &row
+	m.insertMemberLocked(admin, ts)
+	return nil
+}
+
+// GetOrganization retrieves an organization by tenant ID.
+func (m *MemoryStore) GetOrganization(ctx context.Context, tenantID string) (*Organization, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	org, ok := m.organizations[tenantID]
+	if !ok {
+		return nil, fmt.Errorf("failed to get organization: %w", errRowNotFound("Organizations", tenantID))
+	}
+	o := *org
+	return &o, nil
+}
+
+// InsertOrganizationMember adds a member to an organization.
+func (m *MemoryStore) InsertOrganizationMember(ctx context.Context, member *OrganizationMember) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.organizations[member.TenantId]; !ok {
+		return fmt.Errorf("failed to insert organization member: %w", errRowNotFound("Organizations", member.TenantId))
+	}
+	if _, ok := m.members[memberKey{member.TenantId, member.Email}]; ok {
+		return fmt.Errorf("failed to insert organization member: %w", errRowExists("OrganizationMembers", member.TenantId, member.Email))
+	}
+	m.insertMemberLocked(member, m.commitTimestamp())
+	return nil
+}
+
+func (m *MemoryStore) insertMemberLocked(member *OrganizationMember, ts time.Time) {
+	row := *member
+	row.AddedBy = clonePtr(member.AddedBy)
+	row.CreatedAt = ts
+	row.UpdatedAt = ts
+	m.members[memberKey{member.TenantId, member.Email}] = &row
+}
+
+// GetOrganizationMember retrieves a member by tenant ID and email.
+func (m *MemoryStore) GetOrganizationMember(ctx context.Context, tenantID, email string) (*OrganizationMember, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	member, ok := m.members[memberKey{tenantID, email}]
+	if !ok {
+		return nil, fmt.Errorf("failed to get organization member: %w", errRowNotFound("OrganizationMembers", tenantID, email))
+	}
+	return cloneMember(member), nil
+}
+
+// ListOrganizationMembers returns the members of an organization, ordered by
+// email.
+func (m *MemoryStore) ListOrganizationMembers(ctx context.Context, tenantID string) ([]*OrganizationMember, error) {
+	return m.selectMembers(
+		func(member *OrganizationMember) bool { return member.TenantId == tenantID },
+		func(a, b *OrganizationMember) bool { return a.Email < b.Email },
+	), nil
+}
+
+// ListMemberships returns every organization membership of a user, oldest
+// first.
+func (m *MemoryStore) ListMemberships(ctx context.Context, email string) ([]*OrganizationMember, error) {
+	return m.selectMembers(
+		func(member *OrganizationMember) bool { return member.Email == email },
+		func(a, b *OrganizationMember) bool { return a.CreatedAt.Before(b.CreatedAt) },
+	), nil
+}
+
+func (m *MemoryStore) selectMembers(match func(*OrganizationMember) bool, less func(a, b *OrganizationMember) bool) []*OrganizationMember {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var out []*OrganizationMember
+	for _, member := range m.members {
+		if match(member) {
+			out = append(out, cloneMember(member))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return less(out[i], out[j]) })
+	return out
+}
+
+// UpdateOrganizationMemberRole changes a member's role.
+func (m *MemoryStore) UpdateOrganizationMemberRole(ctx context.Context, tenantID, email, role string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	member, ok := m.members[memberKey{tenantID, email}]
+	if !ok {
+		return fmt.Errorf("failed to update organization member: %w", errRowNotFound("OrganizationMembers", tenantID, email))
+	}
+	member.Role = role
+	member.UpdatedAt = m.commitTimestamp()
+	return nil
+}
+
+// DeleteOrganizationMember removes a member from an organization.
+func (m *MemoryStore) DeleteOrganizationMember(ctx context.Context, tenantID, email string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.members, memberKey{tenantID, email})
+	return nil
+}
+
+// ── State transitions ────────────────────────────────────────────────────────
+
+// RecordStateTransition creates a new state transition record
+func (m *MemoryStore) RecordStateTransition(ctx context.Context, tenantID, jobID, transitionID string, fromStatus *string, toStatus string, reason, workerID, providerDetail *string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := jobKey{tenantID, jobID}
+	if _, ok := m.jobs[key]; !ok {
+		return fmt.Errorf("failed to record state transition: %w", errRowNotFound("Jobs", tenantID, jobID))
+	}
+	byID, ok := m.transitions[key]
+	if !ok {
+		byID = make(map[string]*JobStateTransition)
+		m.transitions[key] = byID
+	}
+	if _, ok := byID[transitionID]; ok {
+		return fmt.Errorf("failed to record state transition: %w", errRowExists("JobStateTransitions", tenantID, jobID, transitionID))
+	}
+
+	byID[transitionID] = &JobStateTransition{
+		TenantId:       tenantID,
+		JobId:          jobID,
+		TransitionId:   transitionID,
+		FromStatus:     clonePtr(fromStatus),
+		ToStatus:       toStatus,
+		TransitionedAt: m.commitTimestamp(),
+		Reason:         clonePtr(reason),
+		WorkerId:       clonePtr(workerID),
+		ProviderDetail: clonePtr(providerDetail),
+	}
+	return nil
+}
+
+// GetJobTransitions retrieves all state transitions for a job, newest first
+func (m *MemoryStore) GetJobTransitions(ctx context.Context, tenantID, jobID string) ([]*JobStateTransition, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var transitions []*JobStateTransition
+	for _, t := range m.transitions[jobKey{tenantID, jobID}] {
+		c := *t
+		c.FromStatus = clonePtr(t.FromStatus)
+		c.Reason = clonePtr(t.Reason)
+		c.WorkerId = clonePtr(t.WorkerId)
+		c.ProviderDetail = clonePtr(t.ProviderDetail)
+		transitions = append(transitions, &c)
+	}
+	sort.Slice(transitions, func(i, j int) bool {
+		return transitions[i].TransitionedAt.After(transitions[j].TransitionedAt)
+	})
+	return transitions, nil
+}
+
+// ── Schedules ────────────────────────────────────────────────────────────────
+
+// InsertSchedule creates a schedule.
+func (m *MemoryStore) InsertSchedule(ctx context.Context, s *Schedule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tenants[s.TenantId]; !ok {
+		return fmt.Errorf("failed to insert schedule: %w", errRowNotFound("Tenants", s.TenantId))
+	}
+	key := scheduleKey{s.TenantId, s.ScheduleId}
+	if _, ok := m.schedules[key]; ok {
+		return fmt.Errorf("failed to insert schedule: %w", errRowExists("Schedules", s.TenantId, s.ScheduleId))
+	}
+
+	row := cloneSchedule(s)
+	row.LastRunAt = nil
+	row.LastJobId = nil
+	row.OwnerWorkerId = nil
+	row.LeaseExpiresAt = nil
+	ts := m.commitTimestamp()
+	row.CreatedAt = ts
+	row.UpdatedAt = ts
+	m.schedules[key] = row
+	return nil
+}
+
+// GetSchedule retrieves a schedule by tenant ID and schedule ID.
+func (m *MemoryStore) GetSchedule(ctx context.Context, tenantID, scheduleID string) (*Schedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.schedules[scheduleKey{tenantID, scheduleID}]
+	if !ok {
+		return nil, fmt.Errorf("failed to get schedule: %w", errRowNotFound("Schedules", tenantID, scheduleID))
+	}
+	return cloneSchedule(s), nil
+}
+
+// ListSchedules returns all schedules for a tenant, newest first.
+func (m *MemoryStore) ListSchedules(ctx context.Context, tenantID string) ([]*Schedule, error) {
+	return m.selectSchedules(
+		func(s *Schedule) bool { return s.TenantId == tenantID },
+		func(a, b *Schedule) bool { return a.CreatedAt.After(b.CreatedAt) },
+	), nil
+}
+
+// ListDueSchedules returns unpaused schedules across tenants whose NextRunAt
+// is at or before now, oldest first.
+func (m *MemoryStore) ListDueSchedules(ctx context.Context, now time.Time) ([]*Schedule, error) {
+	return m.selectSchedules(
+		func(s *Schedule) bool { return !s.Paused && !s.NextRunAt.After(now) },
+		func(a, b *Schedule) bool { return a.NextRunAt.Before(b.NextRunAt) },
+	), nil
+}
+
+func (m *MemoryStore) selectSchedules(match func(*Schedule) bool, less func(a, b *Schedule) bool) []*Schedule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var out []*Schedule
+	for _, s := range m.schedules {
+		if match(s) {
+			out = append(out, cloneSchedule(s))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return less(out[i], out[j]) })
+	return out
+}
+
+func (m *MemoryStore) updateSchedule(tenantID, scheduleID string, fn func(s *Schedule, ts time.Time)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.schedules[scheduleKey{tenantID, scheduleID}]
+	if !ok {
+		return errRowNotFound("Schedules", tenantID, scheduleID)
+	}
+	ts := m.commitTimestamp()
+	fn(s, ts)
+	s.UpdatedAt = ts
+	return nil
+}
+
+// SetSchedulePaused pauses or resumes a schedule. nextRunAt is stored as the
+// next fire time, so a resumed schedule does not replay fires it missed.
+func (m *MemoryStore) SetSchedulePaused(ctx context.Context, tenantID, scheduleID string, paused bool, nextRunAt time.Time) error {
+	err := m.updateSchedule(tenantID, scheduleID, func(s *Schedule, _ time.Time) {
+		s.Paused = paused
+		s.NextRunAt = nextRunAt
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update schedule: %w", err)
+	}
+	return nil
+}
+
+// AdvanceSchedule moves a schedule to its next fire time. When lastJobID is
+// non-nil the fire produced a job, which is recorded as the latest run.
+func (m *MemoryStore) AdvanceSchedule(ctx context.Context, tenantID, scheduleID string, nextRunAt time.Time, lastJobID *string) error {
+	err := m.updateSchedule(tenantID, scheduleID, func(s *Schedule, ts time.Time) {
+		s.NextRunAt = nextRunAt
+		if lastJobID != nil {
+			id := *lastJobID
+			s.LastJobId = &id
+			s.LastRunAt = &ts
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to advance schedule: %w", err)
+	}
+	return nil
+}
+
+// DeleteSchedule removes a schedule. Jobs it already created are kept.
+func (m *MemoryStore) DeleteSchedule(ctx context.Context, tenantID, scheduleID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.schedules, scheduleKey{tenantID, scheduleID})
+	return nil
+}
+
+// TryClaimOrRenewScheduleLease claims or renews the right to fire a schedule,
+// using the same rules as TryClaimOrRenewJobLease. Returns true when the
+// caller is the owner.
+func (m *MemoryStore) TryClaimOrRenewScheduleLease(ctx context.Context, tenantID, scheduleID, workerID string, leaseUntil time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.schedules[scheduleKey{tenantID, scheduleID}]
+	if !ok {
+		return false, fmt.Errorf("failed to claim/renew schedule lease: failed to read schedule lease state: %w", errRowNotFound("Schedules", tenantID, scheduleID))
+	}
+
+	now := time.Now().UTC()
+	isOwner := s.OwnerWorkerId != nil && *s.OwnerWorkerId == workerID
+	leaseExpired := s.LeaseExpiresAt == nil || s.LeaseExpiresAt.Before(now)
+	isUnowned := s.OwnerWorkerId == nil || *s.OwnerWorkerId == ""
+	if !(isOwner || leaseExpired || isUnowned) {
+		return false, nil
+	}
+
+	owner := workerID
+	until := leaseUntil
+	s.OwnerWorkerId = &owner
+	s.LeaseExpiresAt = &until
+	return true, nil
+}
+
+// ── Workers ──────────────────────────────────────────────────────────────────
+
+// UpsertWorker registers a worker or records its heartbeat. LastHeartbeatAt
+// is set to the commit time.
+func (m *MemoryStore) UpsertWorker(ctx context.Context, w *Worker) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	row := *w
+	row.LastHeartbeatAt = m.commitTimestamp()
+	m.workers[w.WorkerId] = &row
+	return nil
+}
+
+// ListWorkers returns every registered worker ordered by WorkerId, including
+// workers whose heartbeat stopped.
+func (m *MemoryStore) ListWorkers(ctx context.Context) ([]*Worker, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	workers := make([]*Worker, 0, len(m.workers))
+	for _, w := range m.workers {
+		row := *w
+		workers = append(workers, &row)
+	}
+	sort.Slice(workers, func(i, j int) bool { return workers[i].WorkerId < workers[j].WorkerId })
+	return workers, nil
+}
+
+// DeleteWorker removes a worker from the registry.
+func (m *MemoryStore) DeleteWorker(ctx context.Context, workerID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.workers, workerID)
+	return nil
+}
+
+// ── Outbox ───────────────────────────────────────────────────────────────────
+
+// ChangeJobStatus sets a job's status, records the state transition and puts
+// the change's event in the outbox, all in one transaction. Terminal statuses
+// also set CompletedAt.
+func (m *MemoryStore) ChangeJobStatus(ctx context.Context, change *JobStatusChange) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := jobKey{change.TenantId, change.JobId}
+	job, ok := m.jobs[key]
+	if !ok {
+		return fmt.Errorf("failed to change job status: %w", errRowNotFound("Jobs", change.TenantId, change.JobId))
+	}
+	if _, ok := m.transitions[key][change.TransitionId]; ok {
+		return fmt.Errorf("failed to change job status: %w", errRowExists("JobStateTransitions", change.TenantId, change.JobId, change.TransitionId))
+	}
+	if e := change.Event; e != nil {
+		if _, ok := m.outbox[e.EventId]; ok {
+			return fmt.Errorf("failed to change job status: %w", errRowExists("Outbox", e.EventId))
+		}
+	}
+
+	ts := m.commitTimestamp()
+	job.Status = change.ToStatus
+	job.UpdatedAt = ts
+	if isTerminalJobStatus(change.ToStatus) {
+		now := time.Now()
+		job.CompletedAt = &now
+	}
+	if change.ErrorMessage != nil {
+		job.ErrorMessage = clonePtr(change.ErrorMessage)
+	}
+	if change.RetryCount != nil {
+		job.RetryCount = *change.RetryCount
+	}
+	if change.GcpBatchJobPath != nil {
+		job.GcpBatchJobPath = clonePtr(change.GcpBatchJobPath)
+	}
+	if change.ServiceTier != nil {
+		job.ServiceTier = clonePtr(change.ServiceTier)
+	}
+	if change.AssignedService != nil {
+		job.AssignedService = clonePtr(change.AssignedService)
+	}
+
+	if m.transitions[key] == nil {
+		m.transitions[key] = make(map[string]*JobStateTransition)
+	}
+	m.transitions[key][change.TransitionId] = &JobStateTransition{
+		TenantId:       change.TenantId,
+		JobId:          change.JobId,
+		TransitionId:   change.TransitionId,
+		FromStatus:     clonePtr(change.FromStatus),
+		ToStatus:       change.ToStatus,
+		TransitionedAt: ts,
+		Reason:         clonePtr(change.Reason),
+		WorkerId:       clonePtr(change.WorkerId),
+		ProviderDetail: clonePtr(change.ProviderDetail),
+	}
+
+	if e := change.Event; e != nil {
+		m.outbox[e.EventId] = &OutboxEvent{
+			EventId:   e.EventId,
+			TenantId:  change.TenantId,
+			JobId:     change.JobId,
+			EventType: e.EventType,
+			Payload:   e.Payload,
+			CreatedAt: ts,
+		}
+	}
+	return nil
+}
+
+// InsertOutboxEvent puts an event that comes with no status change, or with
+// one written separately, in the outbox.
+func (m *MemoryStore) InsertOutboxEvent(ctx context.Context, e *OutboxEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.outbox[e.EventId]; ok {
+		return fmt.Errorf("failed to insert outbox event: %w", errRowExists("Outbox", e.EventId))
+	}
+	m.outbox[e.EventId] = &OutboxEvent{
+		EventId:   e.EventId,
+		TenantId:  e.TenantId,
+		JobId:     e.JobId,
+		EventType: e.EventType,
+		Payload:   e.Payload,
+		CreatedAt: m.commitTimestamp(),
+	}
+	return nil
+}
+
+// ClaimOutboxEvents leases up to limit undelivered events to workerID until
+// leaseUntil, oldest first. Events leased to another worker are skipped until
+// their lease expires, and dead-lettered events are never claimed.
+func (m *MemoryStore) ClaimOutboxEvents(ctx context.Context, workerID string, leaseUntil time.Time, limit int) ([]*OutboxEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	var claimable []*OutboxEvent
+	for _, e := range m.outbox {
+		if e.DeliveredAt == nil && e.DeadLetteredAt == nil && (e.LeaseExpiresAt == nil || e.LeaseExpiresAt.Before(now)) {
+			claimable = append(claimable, e)
+		}
+	}
+	sort.Slice(claimable, func(i, j int) bool { return claimable[i].CreatedAt.Before(claimable[j].CreatedAt) })
+	if len(claimable) > limit {
+		claimable = claimable[:limit]
+	}
+
+	events := make([]*OutboxEvent, 0, len(claimable))
+	for _, e := range claimable {
+		e.OwnerWorkerId = &workerID
+		e.LeaseExpiresAt = &leaseUntil
+		events = append(events, cloneOutboxEvent(e))
+	}
+	return events, nil
+}
+
+// MarkOutboxEventDelivered records that an event was published.
+func (m *MemoryStore) MarkOutboxEventDelivered(ctx context.Context, eventID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.outbox[eventID]
+	if !ok {
+		return fmt.Errorf("failed to mark outbox event delivered: %w", errRowNotFound("Outbox", eventID))
+	}
+	ts := m.commitTimestamp()
+	e.DeliveredAt = &ts
+	e.LeaseExpiresAt = nil
+	return nil
+}
+
+// MarkOutboxEventFailed records a failed publish. The event keeps its lease,
+// so it is retried once the lease expires.
+func (m *MemoryStore) MarkOutboxEventFailed(ctx context.Context, eventID, errorMessage string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.outbox[eventID]
+	if !ok {
+		return fmt.Errorf("failed to mark outbox event failed: %w", errRowNotFound("Outbox", eventID))
+	}
+	e.Attempts++
+	e.LastError = &errorMessage
+	return nil
+}
+
+// DeadLetterOutboxEvent records the last failed publish of an event and stops
+// retrying it.
+func (m *MemoryStore) DeadLetterOutboxEvent(ctx context.Context, eventID, errorMessage string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.outbox[eventID]
+	if !ok {
+		return fmt.Errorf("failed to dead-letter outbox event: %w", errRowNotFound("Outbox", eventID))
+	}
+	ts := m.commitTimestamp()
+	e.Attempts++
+	e.LastError = &errorMessage
+	e.DeadLetteredAt = &ts
+	e.LeaseExpiresAt = nil
+	return nil
+}
+
+// PruneOutboxEvents removes the events delivered or dead-lettered before
+// before and returns how many were removed.
+func (m *MemoryStore) PruneOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var count int64
+	for id, e := range m.outbox {
+		if (e.DeliveredAt != nil && e.DeliveredAt.Before(before)) || (e.DeadLetteredAt != nil && e.DeadLetteredAt.Before(before)) {
+			delete(m.outbox, id)
+			count++
+		}
+	}
+	return count, nil
+}
+
+// ── Copy helpers ─────────────────────────────────────────────────────────────
+
+// clonePtr returns a pointer to a copy of *p, or nil.
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// cloneJob deep-copies a Job so stored rows never alias caller memory.
+func cloneJob(j *Job) *Job {
+	c := *j
+	if j.Commands != nil {
+		c.Commands = append([]string(nil), j.Commands...)
+	}
+	c.ScheduledAt = clonePtr(j.ScheduledAt)
+	c.StartedAt = clonePtr(j.StartedAt)
+	c.CompletedAt = clonePtr(j.CompletedAt)
+	c.ErrorMessage = clonePtr(j.ErrorMessage)
+	c.GcpBatchJobPath = clonePtr(j.GcpBatchJobPath)
+	c.GcpBatchTaskGroup = clonePtr(j.GcpBatchTaskGroup)
+	c.EnvVarsJson = clonePtr(j.EnvVarsJson)
+	c.Name = clonePtr(j.Name)
+	c.ResourceProfile = clonePtr(j.ResourceProfile)
+	c.MachineType = clonePtr(j.MachineType)
+	c.BootDiskSizeGb = clonePtr(j.BootDiskSizeGb)
+	c.UseSpotVms = clonePtr(j.UseSpotVms)
+	c.ServiceAccount = clonePtr(j.ServiceAccount)
+	c.ServiceTier = clonePtr(j.ServiceTier)
+	c.AssignedService = clonePtr(j.AssignedService)
+	c.MemoryMib = clonePtr(j.MemoryMib)
+	c.CpuMillis = clonePtr(j.CpuMillis)
+	c.MaxRunDurationSeconds = clonePtr(j.MaxRunDurationSeconds)
+	c.OwnerWorkerId = clonePtr(j.OwnerWorkerId)
+	c.PreferredWorkerId = clonePtr(j.PreferredWorkerId)
+	c.LeaseExpiresAt = clonePtr(j.LeaseExpiresAt)
+	c.LastHeartbeatAt = clonePtr(j.LastHeartbeatAt)
+	c.RetryPolicy = clonePtr(j.RetryPolicy)
+	c.WorkflowId = clonePtr(j.WorkflowId)
+	c.WorkflowNodeId = clonePtr(j.WorkflowNodeId)
+	c.DependsOnJson = clonePtr(j.DependsOnJson)
+	c.IdempotencyKey = clonePtr(j.IdempotencyKey)
+	c.RequestHash = clonePtr(j.RequestHash)
+	c.SubmitResponseJson = clonePtr(j.SubmitResponseJson)
+	c.LabelsJson = clonePtr(j.LabelsJson)
+	return &c
+}
+
+// cloneTenantQuota deep-copies a TenantQuota.
+func cloneTenantQuota(q *TenantQuota) *TenantQuota {
+	c := *q
+	c.MaxConcurrentJobs = clonePtr(q.MaxConcurrentJobs)
+	c.MaxCpuMillis = clonePtr(q.MaxCpuMillis)
+	c.MaxMemoryMib = clonePtr(q.MaxMemoryMib)
+	c.MaxJobsPerDay = clonePtr(q.MaxJobsPerDay)
+	if q.AllowedMachineTypes != nil {
+		c.AllowedMachineTypes = append([]string(nil), q.AllowedMachineTypes...)
+	}
+	c.AllowSpotVms = clonePtr(q.AllowSpotVms)
+	c.QueueWhenBusy = clonePtr(q.QueueWhenBusy)
+	return &c
+}
+
+// cloneSchedule deep-copies a Schedule.
+func cloneSchedule(s *Schedule) *Schedule {
+	c := *s
+	c.Name = clonePtr(s.Name)
+	c.LastRunAt = clonePtr(s.LastRunAt)
+	c.LastJobId = clonePtr(s.LastJobId)
+	c.OwnerWorkerId = clonePtr(s.OwnerWorkerId)
+	c.LeaseExpiresAt = clonePtr(s.LeaseExpiresAt)
+	return &c
+}
+
+// cloneApiKey deep-copies an ApiKey.
+func cloneApiKey(k *ApiKey) *ApiKey {
+	c := *k
+	c.Name = clonePtr(k.Name)
+	c.Scopes = append([]string(nil), k.Scopes...)
+	c.ExpiresAt = clonePtr(k.ExpiresAt)
+	c.RevokedAt = clonePtr(k.RevokedAt)
+	return &c
+}
+
+// cloneMember deep-copies an OrganizationMember.
+func cloneMember(member *OrganizationMember) *OrganizationMember {
+	c := *member
+	c.AddedBy = clonePtr(member.AddedBy)
+	return &c
+}
+
+// cloneNotification deep-copies a Notification.
+func cloneNotification(n *Notification) *Notification {
+	c := *n
+	c.JobName = clonePtr(n.JobName)
+	c.ServiceTier = clonePtr(n.ServiceTier)
+	c.AssignedService = clonePtr(n.AssignedService)
+	c.ErrorMessage = clonePtr(n.ErrorMessage)
+	c.EventType = clonePtr(n.EventType)
+	return &c
+}
+
+// cloneOutboxEvent deep-copies an OutboxEvent.
+func cloneOutboxEvent(e *OutboxEvent) *OutboxEvent {
+	c := *e
+	c.DeliveredAt = clonePtr(e.DeliveredAt)
+	c.LastError = clonePtr(e.LastError)
+	c.OwnerWorkerId = clonePtr(e.OwnerWorkerId)
+	c.LeaseExpiresAt = clonePtr(e.LeaseExpiresAt)
+	c.DeadLetteredAt = clonePtr(e.DeadLetteredAt)
+	return &c
+}
@@ -0,0 +1,780 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+)
+
+func newTestStore(t *testing.T) *MemoryStore {
+	t.Helper()
+	m := NewMemoryStore()
+	if err := m.InsertTenant(context.Background(), "tenant-1", "a@example.com", "google", "u1"); err != nil {
+		t.Fatalf("InsertTenant: %v", err)
+	}
+	return m
+}
+
+func TestMemoryStore_InsertJobRequiresTenant(t *testing.T) {
+	m := NewMemoryStore()
+	err := m.InsertJob(context.Background(), "missing", "job-1", "img", nil)
+	if spanner.ErrCode(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for missing parent tenant, got %v", err)
+	}
+}
+
+func TestMemoryStore_DuplicateInsertsReturnAlreadyExists(t *testing.T) {
+	ctx := context.Background()
+	m := newTestStore(t)
+
+	if err := m.InsertTenant(ctx, "tenant-1", "a@example.com", "google", "u1"); spanner.ErrCode(err) != codes.AlreadyExists {
+		t.Fatalf("InsertTenant duplicate: expected AlreadyExists, got %v", err)
+	}
+	if err := m.InsertJob(ctx, "tenant-1", "job-1", "img", nil); err != nil {
+		t.Fatalf("InsertJob: %v", err)
+	}
+	if err := m.InsertJob(ctx, "tenant-1", "job-1", "img", nil); spanner.ErrCode(err) != codes.AlreadyExists {
+		t.Fatalf("InsertJob duplicate: expected AlreadyExists, got %v", err)
+	}
+}
+
+func TestMemoryStore_UpdateMissingJobReturnsNotFound(t *testing.T) {
+	m := newTestStore(t)
+	err := m.UpdateJobStatus(context.Background(), "tenant-1", "nope", JobStatusRunning)
+	if spanner.ErrCode(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
+
+func TestMemoryStore_ReturnedRowsDoNotAliasStore(t *testing.T) {
+	ctx := context.Background()
+	m := newTestStore(t)
+	if err := m.InsertJob(ctx, "tenant-1", "job-1", "img", []string{"echo"}); err != nil {
+		t.Fatalf("InsertJob: %v", err)
+	}
+
+	job, _ := m.GetJob(ctx, "tenant-1", "job-1")
+	job.Status = JobStatusFailed
+	job.Commands[0] = "mutated"
+
+	again, _ := m.GetJob(ctx, "tenant-1", "job-1")
+	if again.Status != JobStatusPending || again.Commands[0] != "echo" {
+		t.Fatalf("stored row was mutated through a returned copy: %+v", again)
+	}
+}
+
+func TestMemoryStore_ListActiveJobs(t *testing.T) {
+	ctx := context.Background()
+	m := newTestStore(t)
+
+	for _, id := range []string{"no-path", "running", "done"} {
+		if err := m.InsertJob(ctx, "tenant-1", id, "img", nil); err != nil {
+			t.Fatalf("InsertJob(%s): %v", id, err)
+		}
+	}
+	_ = m.UpdateJobStatusAndGcpBatchJobPath(ctx, "tenant-1", "running", JobStatusRunning, "projects/p/jobs/r", ServiceTierComplex, "CLOUD_BATCH")
+	_ = m.UpdateJobStatusAndGcpBatchJobPath(ctx, "tenant-1", "done", JobStatusRunning, "projects/p/jobs/d", ServiceTierComplex, "CLOUD_BATCH")
+	_ = m.CompleteJob(ctx, "tenant-1", "done")
+
+	jobs, err := m.ListActiveJobs(ctx)
+	if err != nil {
+		t.Fatalf("ListActiveJobs: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].JobId != "running" {
+		t.Fatalf("expected only the running job with a resource path, got %d jobs", len(jobs))
+	}
+}
+
+func TestMemoryStore_TryClaimOrRenewJobLease(t *testing.T) {
+	ctx := context.Background()
+	m := newTestStore(t)
+	if err := m.InsertJob(ctx, "tenant-1", "job-1", "img", nil); err != nil {
+		t.Fatalf("InsertJob: %v", err)
+	}
+
+	future := time.Now().UTC().Add(time.Minute)
+
+	claimed, err := m.TryClaimOrRenewJobLease(ctx, "tenant-1", "job-1", "worker-a", future)
+	if err != nil || !claimed {
+		t.Fatalf("unowned job: claimed=%v err=%v, want claimed", claimed, err)
+	}
+
+	claimed, _ = m.TryClaimOrRenewJobLease(ctx, "tenant-1", "job-1", "worker-b", future)
+	if claimed {
+		t.Fatal("worker-b must not steal a live lease held by worker-a")
+	}
+
+	claimed, _ = m.TryClaimOrRenewJobLease(ctx, "tenant-1", "job-1", "worker-a", future)
+	if !claimed {
+		t.Fatal("owner must be able to renew its own lease")
+	}
+
+	// Expire the lease; any worker may now take over.
+	past := time.Now().UTC().Add(-time.Second)
+	m.jobs[jobKey{"tenant-1", "job-1"}].LeaseExpiresAt = &past
+	claimed, _ = m.TryClaimOrRenewJobLease(ctx, "tenant-1", "job-1", "worker-b", future)
+	if !claimed {
+		t.Fatal("worker-b should claim an expired lease")
+	}
+
+	// A preferred worker may take over a live lease.
+	preferred := "worker-c"
+	m.jobs[jobKey{"tenant-1", "job-1"}].PreferredWorkerId = &preferred
+	claimed, _ = m.TryClaimOrRenewJobLease(ctx, "tenant-1", "job-1", "worker-c", future)
+	if !claimed {
+		t.Fatal("preferred worker should take over a live lease")
+	}
+	job, _ := m.GetJob(ctx, "tenant-1", "job-1")
+	if job.OwnerWorkerId == nil || *job.OwnerWorkerId != "worker-c" {
+		t.Fatalf("owner: got %v, want worker-c", job.OwnerWorkerId)
+	}
+
+	// Terminal jobs are never claimed.
+	_ = m.CompleteJob(ctx, "tenant-1", "job-1")
+	claimed, err = m.TryClaimOrRenewJobLease(ctx, "tenant-1", "job-1", "worker-c", future)
+	if err != nil || claimed {
+		t.Fatalf("terminal job: claimed=%v err=%v, want not claimed", claimed, err)
+	}
+
+	if _, err := m.TryClaimOrRenewJobLease(ctx, "tenant-1", "missing", "worker-a", future); spanner.ErrCode(err) != codes.NotFound {
+		t.Fatalf("missing job: expected NotFound, got %v", err)
+	}
+}
+
+func TestMemoryStore_ReleaseJobLease(t *testing.T) {
+	ctx := context.Background()
+	m := newTestStore(t)
+	if err := m.InsertJob(ctx, "tenant-1", "job-1", "img", nil); err != nil {
+		t.Fatalf("InsertJob: %v", err)
+	}
+	future := time.Now().UTC().Add(time.Minute)
+	if claimed, err := m.TryClaimOrRenewJobLease(ctx, "tenant-1", "job-1", "worker-a", future); err != nil || !claimed {
+		t.Fatalf("claim: claimed=%v err=%v", claimed, err)
+	}
+
+	if released, err := m.ReleaseJobLease(ctx, "tenant-1", "job-1", "worker-b", "worker-c"); err != nil || released {
+		t.Fatalf("release by a non-owner: released=%v err=%v, want not released", released, err)
+	}
+	released, err := m.ReleaseJobLease(ctx, "tenant-1", "job-1", "worker-a", "worker-c")
+	if err != nil || !released {
+		t.Fatalf("release by the owner: released=%v err=%v", released, err)
+	}
+	job, _ := m.GetJob(ctx, "tenant-1", "job-1")
+	if job.OwnerWorkerId != nil || job.LeaseExpiresAt != nil || job.PreferredWorkerId == nil || *job.PreferredWorkerId != "worker-c" {
+		t.Fatalf("after release: owner=%v lease=%v preferred=%v", job.OwnerWorkerId, job.LeaseExpiresAt, job.PreferredWorkerId)
+	}
+	if claimed, _ := m.TryClaimOrRenewJobLease(ctx, "tenant-1", "job-1", "worker-c", future); !claimed {
+		t.Fatal("preferred worker should claim a released lease")
+	}
+}
+
+func TestMemoryStore_ListNotificationsSinceCursor(t *testing.T) {
+	ctx := context.Background()
+	m := newTestStore(t)
+
+	insert := func(id string) {
+		t.Helper()
+		if err := m.InsertNotification(ctx, &Notification{
+			TenantId:       "tenant-1",
+			NotificationId: id,
+			JobId:          "job-" + id,
+			FinalStatus:    JobStatusCompleted,
+			OccurredAt:     time.Now(),
+		}); err != nil {
+			t.Fatalf("InsertNotification(%s): %v", id, err)
+		}
+	}
+
+	cursor := time.Now().UTC().Add(-time.Hour)
+	insert("n1")
+	insert("n2")
+	insert("n3")
+
+	page, _ := m.ListNotificationsSince(ctx, "tenant-1", cursor, 2)
+	if len(page) != 2 || page[0].NotificationId != "n1" || page[1].NotificationId != "n2" {
+		t.Fatalf("first page: got %v", notificationIDs(page))
+	}
+
+	cursor = page[len(page)-1].CreatedAt
+	page, _ = m.ListNotificationsSince(ctx, "tenant-1", cursor, 2)
+	if len(page) != 1 || page[0].NotificationId != "n3" {
+		t.Fatalf("second page: got %v", notificationIDs(page))
+	}
+
+	cursor = page[len(page)-1].CreatedAt
+	page, _ = m.ListNotificationsSince(ctx, "tenant-1", cursor, 2)
+	if len(page) != 0 {
+		t.Fatalf("cursor should be exhausted, got %v", notificationIDs(page))
+	}
+
+	if err := m.AckNotification(ctx, "tenant-1", "n2"); err != nil {
+		t.Fatalf("AckNotification: %v", err)
+	}
+	if unread, _ := m.CountUnread(ctx, "tenant-1"); unread != 2 {
+		t.Fatalf("unread: got %d, want 2", unread)
+	}
+}
+
+func TestMemoryStore_RetryJobStaysActive(t *testing.T) {
+	ctx := context.Background()
+	m := newTestStore(t)
+	if err := m.InsertJob(ctx, "tenant-1", "job-1", "img", nil); err != nil {
+		t.Fatalf("InsertJob: %v", err)
+	}
+	_ = m.UpdateJobStatusAndGcpBatchJobPath(ctx, "tenant-1", "job-1", JobStatusRunning, "projects/p/jobs/a", ServiceTierComplex, "CLOUD_BATCH")
+
+	if err := m.RetryJob(ctx, "tenant-1", "job-1", 1, "preempted"); err != nil {
+		t.Fatalf("RetryJob: %v", err)
+	}
+	job, _ := m.GetJob(ctx, "tenant-1", "job-1")
+	if job.Status != JobStatusRetrying || job.RetryCount != 1 || job.ErrorMessage == nil || *job.ErrorMessage != "preempted" {
+		t.Fatalf("unexpected job after RetryJob: status=%s retries=%d", job.Status, job.RetryCount)
+	}
+	if jobs, _ := m.ListActiveJobs(ctx); len(jobs) != 1 {
+		t.Fatalf("RETRYING jobs should be listed as active, got %d", len(jobs))
+	}
+	if err := m.RetryJob(ctx, "tenant-1", "nope", 1, "x"); spanner.ErrCode(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
+
+func TestMemoryStore_DeleteCascades(t *testing.T) {
+	ctx := context.Background()
+	m := newTestStore(t)
+
+	if err := m.InsertJob(ctx, "tenant-1", "job-1", "img", nil); err != nil {
+		t.Fatalf("InsertJob: %v", err)
+	}
+	if err := m.RecordStateTransition(ctx, "tenant-1", "job-1", "t1", nil, JobStatusPending, nil, nil, nil); err != nil {
+		t.Fatalf("RecordStateTransition: %v", err)
+	}
+	if err := m.InsertNotification(ctx, &Notification{TenantId: "tenant-1", NotificationId: "n1", JobId: "job-1", FinalStatus: JobStatusFailed}); err != nil {
+		t.Fatalf("InsertNotification: %v", err)
+	}
+
+	if err := m.DeleteJob(ctx, "tenant-1", "job-1"); err != nil {
+		t.Fatalf("DeleteJob: %v", err)
+	}
+	if transitions, _ := m.GetJobTransitions(ctx, "tenant-1", "job-1"); len(transitions) != 0 {
+		t.Fatalf("job delete should cascade to transitions, got %d", len(transitions))
+	}
+
+	if err := m.InsertJob(ctx, "tenant-1", "job-2", "img", nil); err != nil {
+		t.Fatalf("InsertJob: %v", err)
+	}
+	if err := m.DeleteTenant(ctx, "tenant-1"); err != nil {
+		t.Fatalf("DeleteTenant: %v", err)
+	}
+	if jobs, _ := m.ListJobs(ctx, "tenant-1"); len(jobs) != 0 {
+		t.Fatalf("tenant delete should cascade to jobs, got %d", len(jobs))
+	}
+	if n, _ := m.ListNotifications(ctx, "tenant-1", 0); len(n) != 0 {
+		t.Fatalf("tenant delete should cascade to notifications, got %d", len(n))
+	}
+}
+
+func TestMemoryStore_Schedules(t *testing.T) {
+	ctx := context.Background()
+	m := newTestStore(t)
+	now := time.Now().UTC()
+
+	for i, next := range []time.Time{now.Add(-time.Minute), now.Add(time.Hour)} {
+		s := &Schedule{
+			TenantId: "tenant-1", ScheduleId: fmt.Sprintf("s%d", i+1),
+			CronExpression: "* * * * *", TimeZone: "UTC", OverlapPolicy: OverlapPolicySkip,
+			JobTemplateJson: "{}", NextRunAt: next,
+		}
+		if err := m.InsertSchedule(ctx, s); err != nil {
+			t.Fatalf("InsertSchedule: %v", err)
+		}
+	}
+	if err := m.InsertSchedule(ctx, &Schedule{TenantId: "missing", ScheduleId: "s1"}); spanner.ErrCode(err) != codes.NotFound {
+		t.Fatalf("InsertSchedule without tenant: got %v, want NotFound", err)
+	}
+
+	due, _ := m.ListDueSchedules(ctx, now)
+	if len(due) != 1 || due[0].ScheduleId != "s1" {
+		t.Fatalf("due schedules = %+v, want only s1", due)
+	}
+
+	claimed, err := m.TryClaimOrRenewScheduleLease(ctx, "tenant-1", "s1", "w1", now.Add(time.Minute))
+	if err != nil || !claimed {
+		t.Fatalf("first claim: claimed=%v err=%v", claimed, err)
+	}
+	if claimed, _ := m.TryClaimOrRenewScheduleLease(ctx, "tenant-1", "s1", "w2", now.Add(time.Minute)); claimed {
+		t.Fatal("a held schedule lease should not be claimable by another worker")
+	}
+
+	jobID := "job-1"
+	if err := m.AdvanceSchedule(ctx, "tenant-1", "s1", now.Add(time.Hour), &jobID); err != nil {
+		t.Fatalf("AdvanceSchedule: %v", err)
+	}
+	if due, _ := m.ListDueSchedules(ctx, now); len(due) != 0 {
+		t.Fatalf("advanced schedule is still due: %+v", due)
+	}
+	s, _ := m.GetSchedule(ctx, "tenant-1", "s1")
+	if s.LastJobId == nil || *s.LastJobId != "job-1" || s.LastRunAt == nil {
+		t.Fatalf("AdvanceSchedule did not record the run: %+v", s)
+	}
+
+	if err := m.SetSchedulePaused(ctx, "tenant-1", "s2", true, now.Add(-time.Minute)); err != nil {
+		t.Fatalf("SetSchedulePaused: %v", err)
+	}
+	if due, _ := m.ListDueSchedules(ctx, now); len(due) != 0 {
+		t.Fatalf("paused schedule is due: %+v", due)
+	}
+
+	if err := m.DeleteTenant(ctx, "tenant-1"); err != nil {
+		t.Fatalf("DeleteTenant: %v", err)
+	}
+	if _, err := m.GetSchedule(ctx, "tenant-1", "s1"); spanner.ErrCode(err) != codes.NotFound {
+		t.Fatalf("tenant delete should cascade to schedules, got %v", err)
+	}
+}
+
+func TestMemoryStore_ApiKeys(t *testing.T) {
+	ctx := context.Background()
+	m := newTestStore(t)
+
+	key := &ApiKey{TenantId: "tenant-1", ApiKeyId: "k1", KeyPrefix: "jennah_abcd", KeyHash: "hash-1", Scopes: []string{ApiKeyScopeRead}}
+	if err := m.InsertApiKey(ctx, key); err != nil {
+		t.Fatalf("InsertApiKey: %v", err)
+	}
+	dup := &ApiKey{TenantId: "tenant-1", ApiKeyId: "k2", KeyPrefix: "jennah_abcd", KeyHash: "hash-1"}
+	if err := m.InsertApiKey(ctx, dup); spanner.ErrCode(err) != codes.AlreadyExists {
+		t.Fatalf("InsertApiKey with a duplicate hash: got %v, want AlreadyExists", err)
+	}
+	if err := m.InsertApiKey(ctx, &ApiKey{TenantId: "missing", ApiKeyId: "k1", KeyHash: "hash-2"}); spanner.ErrCode(err) != codes.NotFound {
+		t.Fatalf("InsertApiKey without tenant: got %v, want NotFound", err)
+	}
+
+	found, err := m.GetApiKeyByHash(ctx, "hash-1")
+	if err != nil || found == nil || found.ApiKeyId != "k1" || found.RevokedAt != nil {
+		t.Fatalf("GetApiKeyByHash = %+v, %v", found, err)
+	}
+	if found, err := m.GetApiKeyByHash(ctx, "unknown"); found != nil || err != nil {
+		t.Fatalf("GetApiKeyByHash(unknown) = %+v, %v; want nil, nil", found, err)
+	}
+
+	if err := m.RevokeApiKey(ctx, "tenant-1", "k1"); err != nil {
+		t.Fatalf("RevokeApiKey: %v", err)
+	}
+	if err := m.RevokeApiKey(ctx, "tenant-1", "missing"); spanner.ErrCode(err) != codes.NotFound {
+		t.Fatalf("RevokeApiKey on missing key: got %v, want NotFound", err)
+	}
+	if keys, _ := m.ListApiKeys(ctx, "tenant-1"); len(keys) != 1 || keys[0].RevokedAt == nil {
+		t.Fatalf("ListApiKeys = %+v, want one revoked key", keys)
+	}
+
+	if err := m.DeleteTenant(ctx, "tenant-1"); err != nil {
+		t.Fatalf("DeleteTenant: %v", err)
+	}
+	if found, _ := m.GetApiKeyByHash(ctx, "hash-1"); found != nil {
+		t.Fatalf("tenant delete should cascade to API keys, got %+v", found)
+	}
+}
+
+func TestMemoryStore_Organizations(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryStore()
+
+	org := &Organization{TenantId: "org-1", Name: "Platform", CreatedBy: "alice@example.com"}
+	admin := &OrganizationMember{TenantId: "org-1", Email: "alice@example.com", Role: RoleAdmin}
+	if err := m.InsertOrganization(ctx, org, admin); err != nil {
+		t.Fatalf("InsertOrganization: %v", err)
+	}
+	if err := m.InsertOrganization(ctx, org, admin); spanner.ErrCode(err) != codes.AlreadyExists {
+		t.Fatalf("duplicate InsertOrganization: got %v, want AlreadyExists", err)
+	}
+	// The organization's tenant row never matches a login.
+	if tenant, _ := m.GetTenantByOAuth(ctx, OrganizationProvider, "org-1"); tenant == nil {
+		t.Fatal("organization should have a Tenants row")
+	}
+	if err := m.InsertJob(ctx, "org-1", "job-1", "img", nil); err != nil {
+		t.Fatalf("InsertJob into organization: %v", err)
+	}
+
+	bob := &OrganizationMember{TenantId: "org-1", Email: "bob@example.com", Role: RoleViewer}
+	if err := m.InsertOrganizationMember(ctx, bob); err != nil {
+		t.Fatalf("InsertOrganizationMember: %v", err)
+	}
+	if err := m.InsertOrganizationMember(ctx, &OrganizationMember{TenantId: "missing", Email: "bob@example.com"}); spanner.ErrCode(err) != codes.NotFound {
+		t.Fatalf("InsertOrganizationMember without organization: got %v, want NotFound", err)
+	}
+	if err := m.UpdateOrganizationMemberRole(ctx, "org-1", "bob@example.com", RoleSubmitter); err != nil {
+		t.Fatalf("UpdateOrganizationMemberRole: %v", err)
+	}
+	if got, err := m.GetOrganizationMember(ctx, "org-1", "bob@example.com"); err != nil || got.Role != RoleSubmitter {
+		t.Fatalf("GetOrganizationMember = %+v, %v", got, err)
+	}
+	if members, _ := m.ListOrganizationMembers(ctx, "org-1"); len(members) != 2 || members[0].Email != "alice@example.com" {
+		t.Fatalf("ListOrganizationMembers = %+v", members)
+	}
+	if memberships, _ := m.ListMemberships(ctx, "bob@example.com"); len(memberships) != 1 || memberships[0].TenantId != "org-1" {
+		t.Fatalf("ListMemberships = %+v", memberships)
+	}
+
+	if err := m.DeleteOrganizationMember(ctx, "org-1", "bob@example.com"); err != nil {
+		t.Fatalf("DeleteOrganizationMember: %v", err)
+	}
+	if _, err := m.GetOrganizationMember(ctx, "org-1", "bob@example.com"); spanner.ErrCode(err) != codes.NotFound {
+		t.Fatalf("deleted member: got %v, want NotFound", err)
+	}
+
+	if err := m.DeleteTenant(ctx, "org-1"); err != nil {
+		t.Fatalf("DeleteTenant: %v", err)
+	}
+	if memberships, _ := m.ListMemberships(ctx, "alice@example.com"); len(memberships) != 0 {
+		t.Fatalf("tenant delete should cascade to members, got %+v", memberships)
+	}
+}
+
+func TestMemoryStore_Quotas(t *testing.T) {
+	ctx := context.Background()
+	m := newTestStore(t)
+
+	if q, err := m.GetTenantQuota(ctx, "tenant-1"); q != nil || err != nil {
+		t.Fatalf("GetTenantQuota without overrides = %+v, %v; want nil, nil", q, err)
+	}
+	limit := int64(2)
+	if err := m.UpsertTenantQuota(ctx, &TenantQuota{TenantId: "tenant-1", MaxConcurrentJobs: &limit}); err != nil {
+		t.Fatalf("UpsertTenantQuota: %v", err)
+	}
+	limit = 5 // stored rows must not alias caller memory
+	if q, err := m.GetTenantQuota(ctx, "tenant-1"); err != nil || q == nil || *q.MaxConcurrentJobs != 2 || q.MaxCpuMillis != nil {
+		t.Fatalf("GetTenantQuota = %+v, %v", q, err)
+	}
+	if err := m.UpsertTenantQuota(ctx, &TenantQuota{TenantId: "missing"}); spanner.ErrCode(err) != codes.NotFound {
+		t.Fatalf("UpsertTenantQuota without tenant: got %v, want NotFound", err)
+	}
+
+	for _, id := range []string{"j1", "j2", "j3"} {
+		if err := m.InsertJobFull(ctx, &Job{TenantId: "tenant-1", JobId: id, Status: JobStatusQueued, ImageUri: "img"}); err != nil {
+			t.Fatalf("InsertJobFull(%s): %v", id, err)
+		}
+	}
+	if err := m.UpdateJobStatus(ctx, "tenant-1", "j2", JobStatusPending); err != nil {
+		t.Fatalf("UpdateJobStatus: %v", err)
+	}
+	queued, _ := m.ListQueuedJobs(ctx)
+	if len(queued) != 2 || queued[0].JobId != "j1" || queued[1].JobId != "j3" {
+		t.Fatalf("ListQueuedJobs = %+v, want j1 then j3", queued)
+	}
+	if n, err := m.CountJobsSince(ctx, "tenant-1", time.Now().Add(-time.Hour)); err != nil || n != 3 {
+		t.Fatalf("CountJobsSince = %d, %v; want 3", n, err)
+	}
+	if n, _ := m.CountJobsSince(ctx, "tenant-1", time.Now().Add(time.Hour)); n != 0 {
+		t.Fatalf("CountJobsSince(future) = %d, want 0", n)
+	}
+
+	if err := m.DeleteTenant(ctx, "tenant-1"); err != nil {
+		t.Fatalf("DeleteTenant: %v", err)
+	}
+	if q, _ := m.GetTenantQuota(ctx, "tenant-1"); q != nil {
+		t.Fatalf("tenant delete should cascade to quotas, got %+v", q)
+	}
+}
+
+func TestMemoryStore_Workers(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryStore()
+
+	for _, w := range []*Worker{
+		{WorkerId: "worker-b", Address: "10.0.0.2:8081", StartedAt: time.Now()},
+		{WorkerId: "worker-a", Address: "10.0.0.1:8081", Capacity: 10, StartedAt: time.Now()},
+	} {
+		if err := m.UpsertWorker(ctx, w); err != nil {
+			t.Fatalf("UpsertWorker(%s): %v", w.WorkerId, err)
+		}
+	}
+	first, _ := m.ListWorkers(ctx)
+	if err := m.UpsertWorker(ctx, &Worker{WorkerId: "worker-a", Address: "10.0.0.1:8081", Capacity: 10, ActiveJobs: 3}); err != nil {
+		t.Fatalf("UpsertWorker heartbeat: %v", err)
+	}
+	workers, err := m.ListWorkers(ctx)
+	if err != nil || len(workers) != 2 || workers[0].WorkerId != "worker-a" || workers[1].WorkerId != "worker-b" {
+		t.Fatalf("ListWorkers = %+v, %v; want worker-a then worker-b", workers, err)
+	}
+	if workers[0].ActiveJobs != 3 || !workers[0].LastHeartbeatAt.After(first[0].LastHeartbeatAt) {
+		t.Fatalf("worker-a = %+v, want the heartbeat to update ActiveJobs and LastHeartbeatAt", workers[0])
+	}
+
+	if err := m.DeleteWorker(ctx, "worker-b"); err != nil {
+		t.Fatalf("DeleteWorker: %v", err)
+	}
+	if workers, _ := m.ListWorkers(ctx); len(workers) != 1 {
+		t.Fatalf("ListWorkers after delete = %+v, want worker-a only", workers)
+	}
+}
+
+func TestMemoryStore_Outbox(t *testing.T) {
+	ctx := context.Background()
+	m := newTestStore(t)
+	for _, id := range []string{"job-1", "job-2"} {
+		if err := m.InsertJob(ctx, "tenant-1", id, "img", nil); err != nil {
+			t.Fatalf("InsertJob(%s): %v", id, err)
+		}
+	}
+
+	running := JobStatusRunning
+	errMsg := "exit code 1"
+	change := &JobStatusChange{
+		TenantId: "tenant-1", JobId: "job-1", TransitionId: "t1",
+		FromStatus: &running, ToStatus: JobStatusFailed, ErrorMessage: &errMsg,
+		Event: &OutboxEvent{EventId: "t1", EventType: "job.terminal", Payload: `{"jobId":"job-1"}`},
+	}
+	if err := m.ChangeJobStatus(ctx, change); err != nil {
+		t.Fatalf("ChangeJobStatus: %v", err)
+	}
+	job, _ := m.GetJob(ctx, "tenant-1", "job-1")
+	if job.Status != JobStatusFailed || job.CompletedAt == nil || job.ErrorMessage == nil || *job.ErrorMessage != errMsg {
+		t.Fatalf("job = %+v, want FAILED with CompletedAt and the error", job)
+	}
+	if transitions, _ := m.GetJobTransitions(ctx, "tenant-1", "job-1"); len(transitions) != 1 || transitions[0].ToStatus != JobStatusFailed {
+		t.Fatalf("transitions = %+v, want the FAILED transition", transitions)
+	}
+
+	// A repeated transition writes nothing, not even the event.
+	if err := m.ChangeJobStatus(ctx, change); spanner.ErrCode(err) != codes.AlreadyExists {
+		t.Fatalf("repeated ChangeJobStatus: got %v, want AlreadyExists", err)
+	}
+	path, tier, service := "jobs/job-2", ServiceTierComplex, "CLOUD_BATCH"
+	if err := m.ChangeJobStatus(ctx, &JobStatusChange{
+		TenantId: "tenant-1", JobId: "job-2", TransitionId: "t2", ToStatus: JobStatusRunning,
+		GcpBatchJobPath: &path, ServiceTier: &tier, AssignedService: &service,
+	}); err != nil {
+		t.Fatalf("ChangeJobStatus without event: %v", err)
+	}
+	if job, _ := m.GetJob(ctx, "tenant-1", "job-2"); job.GcpBatchJobPath == nil || *job.GcpBatchJobPath != path ||
+		job.ServiceTier == nil || *job.ServiceTier != tier || job.AssignedService == nil || *job.AssignedService != service {
+		t.Fatalf("job = %+v, want the submission's path, tier and service", job)
+	}
+
+	events, err := m.ClaimOutboxEvents(ctx, "worker-a", time.Now().Add(time.Minute), 10)
+	if err != nil || len(events) != 1 || events[0].EventId != "t1" || events[0].JobId != "job-1" || *events[0].OwnerWorkerId != "worker-a" {
+		t.Fatalf("ClaimOutboxEvents = %+v, %v; want event t1 leased to worker-a", events, err)
+	}
+	if events, _ := m.ClaimOutboxEvents(ctx, "worker-b", time.Now().Add(time.Minute), 10); len(events) != 0 {
+		t.Fatalf("claim of a leased event = %+v, want none", events)
+	}
+
+	// A failed publish is retried once the lease expires.
+	if err := m.MarkOutboxEventFailed(ctx, "t1", "publish failed"); err != nil {
+		t.Fatalf("MarkOutboxEventFailed: %v", err)
+	}
+	m.outbox["t1"].LeaseExpiresAt = &time.Time{}
+	events, _ = m.ClaimOutboxEvents(ctx, "worker-b", time.Now().Add(time.Minute), 10)
+	if len(events) != 1 || events[0].Attempts != 1 || *events[0].LastError != "publish failed" {
+		t.Fatalf("reclaim after failure = %+v, want one attempt recorded", events)
+	}
+
+	if err := m.MarkOutboxEventDelivered(ctx, "t1"); err != nil {
+		t.Fatalf("MarkOutboxEventDelivered: %v", err)
+	}
+	m.outbox["t1"].LeaseExpiresAt = &time.Time{}
+	if events, _ := m.ClaimOutboxEvents(ctx, "worker-b", time.Now().Add(time.Minute), 10); len(events) != 0 {
+		t.Fatalf("claim of a delivered event = %+v, want none", events)
+	}
+
+	// A dead-lettered event is never claimed again.
+	if err := m.InsertOutboxEvent(ctx, &OutboxEvent{EventId: "e2", TenantId: "tenant-1", JobId: "job-1", EventType: "job.terminal", Payload: "{"}); err != nil {
+		t.Fatalf("InsertOutboxEvent: %v", err)
+	}
+	if err := m.DeadLetterOutboxEvent(ctx, "e2", "undecodable event"); err != nil {
+		t.Fatalf("DeadLetterOutboxEvent: %v", err)
+	}
+	if e := m.outbox["e2"]; e.DeadLetteredAt == nil || e.Attempts != 1 || *e.LastError != "undecodable event" {
+		t.Fatalf("dead-lettered event = %+v, want DeadLetteredAt and the attempt recorded", e)
+	}
+	if events, _ := m.ClaimOutboxEvents(ctx, "worker-b", time.Now().Add(time.Minute), 10); len(events) != 0 {
+		t.Fatalf("claim of a dead-lettered event = %+v, want none", events)
+	}
+
+	if deleted, err := m.PruneOutboxEvents(ctx, time.Now().Add(-time.Hour)); err != nil || deleted != 0 {
+		t.Fatalf("PruneOutboxEvents(an hour ago) = %d, %v; want 0", deleted, err)
+	}
+	if deleted, err := m.PruneOutboxEvents(ctx, time.Now().Add(time.Minute)); err != nil || deleted != 2 {
+		t.Fatalf("PruneOutboxEvents(now) = %d, %v; want the delivered and the dead-lettered event", deleted, err)
+	}
+}
+
+func TestMemoryStore_ListJobsFiltered(t *testing.T) {
+	ctx := context.Background()
+	m := newTestStore(t)
+	for _, id := range []string{"j1", "j2", "j3", "j4"} {
+		status := JobStatusRunning
+		if id == "j2" {
+			status = JobStatusFailed
+		}
+		labels := EncodeJobLabels(map[string]string{"team": "billing", "env": "prod"})
+		if id == "j3" {
+			labels = EncodeJobLabels(map[string]string{"team": "search"})
+		}
+		if err := m.InsertJobFull(ctx, &Job{TenantId: "tenant-1", JobId: id, Status: status, ImageUri: "img", LabelsJson: labels}); err != nil {
+			t.Fatalf("InsertJobFull(%s): %v", id, err)
+		}
+	}
+	ids := func(jobs []*Job) []string {
+		var out []string
+		for _, j := range jobs {
+			out = append(out, j.JobId)
+		}
+		return out
+	}
+
+	page, _ := m.ListJobsFiltered(ctx, "tenant-1", JobFilter{Limit: 2})
+	if got := ids(page); len(got) != 2 || got[0] != "j4" || got[1] != "j3" {
+		t.Fatalf("first page = %v, want [j4 j3]", got)
+	}
+	last := page[1]
+	page, _ = m.ListJobsFiltered(ctx, "tenant-1", JobFilter{Limit: 2, After: &JobCursor{CreatedAt: last.CreatedAt, JobId: last.JobId}})
+	if got := ids(page); len(got) != 2 || got[0] != "j2" || got[1] != "j1" {
+		t.Fatalf("second page = %v, want [j2 j1]", got)
+	}
+
+	page, _ = m.ListJobsFiltered(ctx, "tenant-1", JobFilter{Statuses: []string{JobStatusRunning}, Ascending: true})
+	if got := ids(page); len(got) != 3 || got[0] != "j1" || got[2] != "j4" {
+		t.Fatalf("RUNNING oldest first = %v, want [j1 j3 j4]", got)
+	}
+	page, _ = m.ListJobsFiltered(ctx, "tenant-1", JobFilter{CreatedAfter: last.CreatedAt})
+	if got := ids(page); len(got) != 2 {
+		t.Fatalf("created at or after j3 = %v, want [j4 j3]", got)
+	}
+
+	for _, tt := range []struct {
+		labels []LabelRequirement
+		want   string
+	}{
+		{[]LabelRequirement{{Key: "team", Operator: LabelEquals, Value: "billing"}, {Key: "env", Operator: LabelEquals, Value: "prod"}}, "[j4 j2 j1]"},
+		{[]LabelRequirement{{Key: "team", Operator: LabelNotEquals, Value: "billing"}}, "[j3]"},
+		{[]LabelRequirement{{Key: "env", Operator: LabelDoesNotExist}}, "[j3]"},
+		{[]LabelRequirement{{Key: "env", Operator: LabelExists}, {Key: "team", Operator: LabelEquals, Value: "search"}}, "[]"},
+	} {
+		page, _ = m.ListJobsFiltered(ctx, "tenant-1", JobFilter{Labels: tt.labels})
+		if got := fmt.Sprint(ids(page)); got != tt.want {
+			t.Fatalf("labels %+v = %s, want %s", tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryStore_IdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+	m := newTestStore(t)
+	if err := m.InsertTenant(ctx, "tenant-2", "b@example.com", "google", "u2"); err != nil {
+		t.Fatalf("InsertTenant: %v", err)
+	}
+
+	key, hash := "retry-1", "abc"
+	if err := m.InsertJobFull(ctx, &Job{TenantId: "tenant-1", JobId: "j1", Status: JobStatusPending, IdempotencyKey: &key, RequestHash: &hash}); err != nil {
+		t.Fatalf("InsertJobFull: %v", err)
+	}
+	err := m.InsertJobFull(ctx, &Job{TenantId: "tenant-1", JobId: "j2", Status: JobStatusPending, IdempotencyKey: &key})
+	if spanner.ErrCode(err) != codes.AlreadyExists {
+		t.Fatalf("second job with the key: got %v, want AlreadyExists", err)
+	}
+	// Keys are scoped to the tenant, and jobs without one never collide.
+	if err := m.InsertJobFull(ctx, &Job{TenantId: "tenant-2", JobId: "j1", Status: JobStatusPending, IdempotencyKey: &key}); err != nil {
+		t.Fatalf("InsertJobFull in another tenant: %v", err)
+	}
+	for _, id := range []string{"j3", "j4"} {
+		if err := m.InsertJobFull(ctx, &Job{TenantId: "tenant-1", JobId: id, Status: JobStatusPending}); err != nil {
+			t.Fatalf("InsertJobFull(%s) without key: %v", id, err)
+		}
+	}
+
+	job, err := m.GetJobByIdempotencyKey(ctx, "tenant-1", key)
+	if err != nil || job == nil || job.JobId != "j1" || *job.RequestHash != hash {
+		t.Fatalf("GetJobByIdempotencyKey = %+v, %v; want j1", job, err)
+	}
+	if job, err := m.GetJobByIdempotencyKey(ctx, "tenant-1", "unused"); job != nil || err != nil {
+		t.Fatalf("GetJobByIdempotencyKey(unused) = %+v, %v; want nil, nil", job, err)
+	}
+
+	if err := m.SetJobSubmitResponse(ctx, "tenant-1", "j1", `{"jobId":"j1"}`); err != nil {
+		t.Fatalf("SetJobSubmitResponse: %v", err)
+	}
+	if job, _ := m.GetJob(ctx, "tenant-1", "j1"); job.SubmitResponseJson == nil || *job.SubmitResponseJson != `{"jobId":"j1"}` {
+		t.Fatalf("SubmitResponseJson = %v", job.SubmitResponseJson)
+	}
+
+	if err := m.ClearJobIdempotencyKey(ctx, "tenant-1", "j1"); err != nil {
+		t.Fatalf("ClearJobIdempotencyKey: %v", err)
+	}
+	if job, _ := m.GetJobByIdempotencyKey(ctx, "tenant-1", key); job != nil {
+		t.Fatalf("cleared key still finds job %s", job.JobId)
+	}
+	if job, _ := m.GetJob(ctx, "tenant-1", "j1"); job.RequestHash != nil || job.SubmitResponseJson != nil {
+		t.Fatalf("ClearJobIdempotencyKey left %+v", job)
+	}
+	if err := m.InsertJobFull(ctx, &Job{TenantId: "tenant-1", JobId: "j2", Status: JobStatusPending, IdempotencyKey: &key}); err != nil {
+		t.Fatalf("InsertJobFull with a released key: %v", err)
+	}
+}
+
+func TestMemoryStore_Workflows(t *testing.T) {
+	ctx := context.Background()
+	m := newTestStore(t)
+
+	wf := &Workflow{TenantId: "tenant-1", WorkflowId: "wf-1"}
+	node := func(jobID, nodeID string) *Job {
+		return &Job{TenantId: "tenant-1", JobId: jobID, Status: JobStatusWaiting, ImageUri: "img", WorkflowId: &wf.WorkflowId, WorkflowNodeId: &nodeID}
+	}
+	if err := m.InsertJob(ctx, "tenant-1", "taken", "img", nil); err != nil {
+		t.Fatalf("InsertJob: %v", err)
+	}
+	err := m.InsertWorkflow(ctx, wf, []*Job{node("j1", "split"), node("taken", "merge")})
+	if spanner.ErrCode(err) != codes.AlreadyExists {
+		t.Fatalf("InsertWorkflow with a duplicate job: got %v, want AlreadyExists", err)
+	}
+	if _, err := m.GetWorkflow(ctx, "tenant-1", "wf-1"); spanner.ErrCode(err) != codes.NotFound {
+		t.Fatalf("a failed InsertWorkflow should write nothing, got %v", err)
+	}
+
+	if err := m.InsertWorkflow(ctx, wf, []*Job{node("j2", "merge"), node("j1", "split")}); err != nil {
+		t.Fatalf("InsertWorkflow: %v", err)
+	}
+	jobs, _ := m.ListWorkflowJobs(ctx, "tenant-1", "wf-1")
+	if len(jobs) != 2 || *jobs[0].WorkflowNodeId != "merge" || *jobs[1].WorkflowNodeId != "split" {
+		t.Fatalf("ListWorkflowJobs = %+v, want merge then split", jobs)
+	}
+	if WorkflowStatus(jobs) != JobStatusRunning {
+		t.Errorf("status with WAITING nodes = %s, want RUNNING", WorkflowStatus(jobs))
+	}
+	jobs[0].Status, jobs[1].Status = JobStatusSkipped, JobStatusCompleted
+	if WorkflowStatus(jobs) != JobStatusCompleted {
+		t.Errorf("status = %s, want COMPLETED", WorkflowStatus(jobs))
+	}
+	jobs[0].Status = JobStatusFailed
+	if WorkflowStatus(jobs) != JobStatusFailed {
+		t.Errorf("status = %s, want FAILED", WorkflowStatus(jobs))
+	}
+
+	if err := m.CancelWorkflow(ctx, "tenant-1", "wf-1"); err != nil {
+		t.Fatalf("CancelWorkflow: %v", err)
+	}
+	if got, _ := m.GetWorkflow(ctx, "tenant-1", "wf-1"); got.CancelledAt == nil {
+		t.Error("CancelledAt not set by CancelWorkflow")
+	}
+	if err := m.CancelWorkflow(ctx, "tenant-1", "missing"); spanner.ErrCode(err) != codes.NotFound {
+		t.Errorf("CancelWorkflow on missing workflow: got %v, want NotFound", err)
+	}
+
+	if err := m.DeleteTenant(ctx, "tenant-1"); err != nil {
+		t.Fatalf("DeleteTenant: %v", err)
+	}
+	if _, err := m.GetWorkflow(ctx, "tenant-1", "wf-1"); spanner.ErrCode(err) != codes.NotFound {
+		t.Fatalf("tenant delete should cascade to workflows, got %v", err)
+	}
+}
+
+func notificationIDs(ns []*Notification) []string {
+	ids := make([]string, 0, len(ns))
+	for _, n := range ns {
+		ids = append(ids, n.NotificationId)
+	}
+	return ids
+}
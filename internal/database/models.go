@@ -1,6 +1,10 @@
 package database
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+)
 
 // Tenant represents an organization/team using the platform
 type Tenant struct {
@@ -45,6 +49,72 @@ type Job struct {
 	PreferredWorkerId     *string    `spanner:"PreferredWorkerId"`
 	LeaseExpiresAt        *time.Time `spanner:"LeaseExpiresAt"`
 	LastHeartbeatAt       *time.Time `spanner:"LastHeartbeatAt"`
+	RetryPolicy           *string    `spanner:"RetryPolicy"`
+	WorkflowId            *string    `spanner:"WorkflowId"`
+	WorkflowNodeId        *string    `spanner:"WorkflowNodeId"`
+	DependsOnJson         *string    `spanner:"DependsOnJson"` // JSON []WorkflowDependency
+	IdempotencyKey        *string    `spanner:"IdempotencyKey"`
+	RequestHash           *string    `spanner:"RequestHash"`        // SHA-256 of the submit request, hex
+	SubmitResponseJson    *string    `spanner:"SubmitResponseJson"` // JSON SubmitJobResponse replayed for retries
+	LabelsJson            *string    `spanner:"LabelsJson"`         // JSON map[string]string of user labels
+}
+
+// JobFilter selects one page of a tenant's jobs for ListJobsFiltered. Zero
+// fields match every job. Jobs are ordered by CreatedAt, then JobId.
+type JobFilter struct {
+	Statuses        []string  // any of these statuses
+	CreatedAfter    time.Time // inclusive
+	CreatedBefore   time.Time // exclusive
+	NamePrefix      string
+	AssignedService string
+	Labels          []LabelRequirement // every requirement must hold
+	Ascending       bool               // oldest first instead of newest first
+	After           *JobCursor         // continue after this job, in the same order
+	Limit           int                // 0 returns every match
+}
+
+// JobCursor is the position of a job in a ListJobsFiltered listing.
+type JobCursor struct {
+	CreatedAt time.Time
+	JobId     string
+}
+
+// Matches reports whether job passes the filter, including the cursor.
+func (f JobFilter) Matches(job *Job) bool {
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, job.Status) {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && job.CreatedAt.Before(f.CreatedAfter) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !job.CreatedAt.Before(f.CreatedBefore) {
+		return false
+	}
+	if f.NamePrefix != "" && (job.Name == nil || !strings.HasPrefix(*job.Name, f.NamePrefix)) {
+		return false
+	}
+	if f.AssignedService != "" && (job.AssignedService == nil || *job.AssignedService != f.AssignedService) {
+		return false
+	}
+	if len(f.Labels) > 0 {
+		labels, _ := JobLabels(job)
+		for _, r := range f.Labels {
+			if !r.Matches(labels) {
+				return false
+			}
+		}
+	}
+	if f.After != nil {
+		c := job.CreatedAt.Compare(f.After.CreatedAt)
+		if c == 0 {
+			c = strings.Compare(job.JobId, f.After.JobId)
+		}
+		if f.Ascending {
+			return c > 0
+		}
+		return c < 0
+	}
+	return true
 }
 
 // JobStateTransition tracks state changes for audit trail
@@ -56,6 +126,8 @@ type JobStateTransition struct {
 	ToStatus       string    `spanner:"ToStatus"`
 	TransitionedAt time.Time `spanner:"TransitionedAt"`
 	Reason         *string   `spanner:"Reason"`
+	WorkerId       *string   `spanner:"WorkerId"`
+	ProviderDetail *string   `spanner:"ProviderDetail"`
 }
 
 // JobStatus constants
@@ -66,6 +138,26 @@ const (
 	JobStatusCompleted = "COMPLETED"
 	JobStatusFailed    = "FAILED"
 	JobStatusCancelled = "CANCELLED"
+	// JobStatusRetrying: the last attempt failed and a resubmission is
+	// waiting out its backoff. Not terminal.
+	JobStatusRetrying = "RETRYING"
+	// JobStatusWaiting: a workflow node whose parents have not all finished.
+	// Not terminal.
+	JobStatusWaiting = "WAITING"
+	// JobStatusSkipped: a workflow node whose dependency conditions can no
+	// longer be met, so it never ran. Terminal.
+	JobStatusSkipped = "SKIPPED"
+	// JobStatusQueued: admitted over its tenant's concurrency, vCPU or memory
+	// limit and held until a worker releases it. Not terminal.
+	JobStatusQueued = "QUEUED"
+)
+
+// RetryPolicy constants decide which failed attempts are resubmitted.
+// A nil RetryPolicy column means RetryPolicyOnFailure.
+const (
+	RetryPolicyOnFailure    = "ON_FAILURE"    // any failure
+	RetryPolicyOnPreemption = "ON_PREEMPTION" // only Spot VM preemption
+	RetryPolicyNever        = "NEVER"
 )
 
 // ServiceTier constants indicate which GCP service executes the job.
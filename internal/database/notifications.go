@@ -22,12 +22,13 @@ type Notification struct {
 	ErrorMessage   *string   `spanner:"ErrorMessage"`
 	IsRead         bool      `spanner:"IsRead"`
 	CreatedAt      time.Time `spanner:"CreatedAt"`
+	EventType      *string   `spanner:"EventType"` // nil: notifier.EventTypeTerminal
 }
 
 var notificationColumns = []string{
 	"TenantId", "NotificationId", "JobId", "JobName",
 	"FinalStatus", "ServiceTier", "AssignedService",
-	"OccurredAt", "ErrorMessage", "IsRead", "CreatedAt",
+	"OccurredAt", "ErrorMessage", "IsRead", "CreatedAt", "EventType",
 }
 
 // InsertNotification persists a new notification row. It is idempotent: if a
@@ -39,7 +40,7 @@ func (c *Client) InsertNotification(ctx context.Context, n *Notification) error
 			[]interface{}{
 				n.TenantId, n.NotificationId, n.JobId, n.JobName,
 				n.FinalStatus, n.ServiceTier, n.AssignedService,
-				n.OccurredAt, n.ErrorMessage, false, spanner.CommitTimestamp,
+				n.OccurredAt, n.ErrorMessage, false, spanner.CommitTimestamp, n.EventType,
 			},
 		),
 	})
@@ -0,0 +1,183 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+)
+
+// Organization is a tenant shared by several users. Its Tenants row has
+// OAuthProvider OrganizationProvider and OAuthUserId set to the tenant ID,
+// so it is never returned by GetTenantByOAuth.
+type Organization struct {
+	TenantId  string    `spanner:"TenantId"`
+	Name      string    `spanner:"Name"`
+	CreatedBy string    `spanner:"CreatedBy"` // Email of the creator
+	CreatedAt time.Time `spanner:"CreatedAt"`
+	UpdatedAt time.Time `spanner:"UpdatedAt"`
+}
+
+// OrganizationMember grants a user, identified by verified email, a role in
+// an organization.
+type OrganizationMember struct {
+	TenantId  string    `spanner:"TenantId"`
+	Email     string    `spanner:"Email"` // Lower-case
+	Role      string    `spanner:"Role"`
+	AddedBy   *string   `spanner:"AddedBy"`
+	CreatedAt time.Time `spanner:"CreatedAt"`
+	UpdatedAt time.Time `spanner:"UpdatedAt"`
+}
+
+// OrganizationProvider is the OAuthProvider of an organization's Tenants row.
+const OrganizationProvider = "organization"
+
+// Organization roles, from least to most privileged.
+const (
+	RoleViewer    = "viewer"    // read jobs, logs and notifications
+	RoleSubmitter = "submitter" // viewer, plus submit and cancel jobs
+	RoleAdmin     = "admin"     // everything, including members and API keys
+)
+
+// Roles lists every valid role.
+var Roles = []string{RoleViewer, RoleSubmitter, RoleAdmin}
+
+var organizationColumns = []string{"TenantId", "Name", "CreatedBy", "CreatedAt", "UpdatedAt"}
+
+var organizationMemberColumns = []string{"TenantId", "Email", "Role", "AddedBy", "CreatedAt", "UpdatedAt"}
+
+// InsertOrganization creates an organization, its Tenants row and its first
+// admin in one commit.
+func (c *Client) InsertOrganization(ctx context.Context, org *Organization, admin *OrganizationMember) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Insert("Tenants",
+			[]string{"TenantId", "UserEmail", "OAuthProvider", "OAuthUserId", "CreatedAt", "UpdatedAt"},
+			[]interface{}{org.TenantId, org.CreatedBy, OrganizationProvider, org.TenantId, spanner.CommitTimestamp, spanner.CommitTimestamp},
+		),
+		spanner.Insert("Organizations",
+			organizationColumns,
+			[]interface{}{org.TenantId, org.Name, org.CreatedBy, spanner.CommitTimestamp, spanner.CommitTimestamp},
+		),
+		spanner.Insert("OrganizationMembers",
+			organizationMemberColumns,
+			[]interface{}{org.TenantId, admin.Email, admin.Role, admin.AddedBy, spanner.CommitTimestamp, spanner.CommitTimestamp},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert organization: %w", err)
+	}
+	return nil
+}
+
+// GetOrganization retrieves an organization by tenant ID.
+func (c *Client) GetOrganization(ctx context.Context, tenantID string) (*Organization, error) {
+	row, err := c.client.Single().ReadRow(ctx, "Organizations", spanner.Key{tenantID}, organizationColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	var org Organization
+	if err := row.ToStruct(&org); err != nil {
+		return nil, fmt.Errorf("failed to parse organization: %w", err)
+	}
+	return &org, nil
+}
+
+// InsertOrganizationMember adds a member to an organization.
+func (c *Client) InsertOrganizationMember(ctx context.Context, m *OrganizationMember) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Insert("OrganizationMembers",
+			organizationMemberColumns,
+			[]interface{}{m.TenantId, m.Email, m.Role, m.AddedBy, spanner.CommitTimestamp, spanner.CommitTimestamp},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert organization member: %w", err)
+	}
+	return nil
+}
+
+// GetOrganizationMember retrieves a member by tenant ID and email.
+func (c *Client) GetOrganizationMember(ctx context.Context, tenantID, email string) (*OrganizationMember, error) {
+	row, err := c.client.Single().ReadRow(ctx, "OrganizationMembers", spanner.Key{tenantID, email}, organizationMemberColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+	var m OrganizationMember
+	if err := row.ToStruct(&m); err != nil {
+		return nil, fmt.Errorf("failed to parse organization member: %w", err)
+	}
+	return &m, nil
+}
+
+// ListOrganizationMembers returns the members of an organization, ordered by
+// email.
+func (c *Client) ListOrganizationMembers(ctx context.Context, tenantID string) ([]*OrganizationMember, error) {
+	return c.queryOrganizationMembers(ctx, spanner.Statement{
+		SQL: `SELECT ` + columnList(organizationMemberColumns) + `
+		      FROM OrganizationMembers
+		      WHERE TenantId = @tenantId
+		      ORDER BY Email`,
+		Params: map[string]interface{}{"tenantId": tenantID},
+	})
+}
+
+// ListMemberships returns every organization membership of a user, oldest
+// first.
+func (c *Client) ListMemberships(ctx context.Context, email string) ([]*OrganizationMember, error) {
+	return c.queryOrganizationMembers(ctx, spanner.Statement{
+		SQL: `SELECT ` + columnList(organizationMemberColumns) + `
+		      FROM OrganizationMembers@{FORCE_INDEX=OrganizationMembersByEmail}
+		      WHERE Email = @email
+		      ORDER BY CreatedAt`,
+		Params: map[string]interface{}{"email": email},
+	})
+}
+
+func (c *Client) queryOrganizationMembers(ctx context.Context, stmt spanner.Statement) ([]*OrganizationMember, error) {
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var members []*OrganizationMember
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate organization members: %w", err)
+		}
+		var m OrganizationMember
+		if err := row.ToStruct(&m); err != nil {
+			return nil, fmt.Errorf("failed to parse organization member: %w", err)
+		}
+		members = append(members, &m)
+	}
+	return members, nil
+}
+
+// UpdateOrganizationMemberRole changes a member's role.
+func (c *Client) UpdateOrganizationMemberRole(ctx context.Context, tenantID, email, role string) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Update("OrganizationMembers",
+			[]string{"TenantId", "Email", "Role", "UpdatedAt"},
+			[]interface{}{tenantID, email, role, spanner.CommitTimestamp},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update organization member: %w", err)
+	}
+	return nil
+}
+
+// DeleteOrganizationMember removes a member from an organization.
+func (c *Client) DeleteOrganizationMember(ctx context.Context, tenantID, email string) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Delete("OrganizationMembers", spanner.Key{tenantID, email}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete organization member: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+)
+
+// OutboxEvent is a job event waiting in the outbox to be published.
+type OutboxEvent struct {
+	EventId        string     `spanner:"EventId"`
+	TenantId       string     `spanner:"TenantId"`
+	JobId          string     `spanner:"JobId"`
+	EventType      string     `spanner:"EventType"`
+	Payload        string     `spanner:"Payload"` // JSON event as published
+	CreatedAt      time.Time  `spanner:"CreatedAt"`
+	DeliveredAt    *time.Time `spanner:"DeliveredAt"`
+	Attempts       int64      `spanner:"Attempts"`
+	LastError      *string    `spanner:"LastError"`
+	OwnerWorkerId  *string    `spanner:"OwnerWorkerId"`
+	LeaseExpiresAt *time.Time `spanner:"LeaseExpiresAt"`
+	DeadLetteredAt *time.Time `spanner:"DeadLetteredAt"` // nil: still retried
+}
+
+// JobStatusChange is a job status update together with its state transition
+// and, optionally, the event announcing it.
+type JobStatusChange struct {
+	TenantId        string
+	JobId           string
+	TransitionId    string
+	FromStatus      *string
+	ToStatus        string
+	ErrorMessage    *string // replaces the job's error message when set
+	RetryCount      *int64  // replaces the job's retry count when set
+	GcpBatchJobPath *string // replaces the job's cloud resource path when set
+	ServiceTier     *string // replaces the job's service tier when set
+	AssignedService *string // replaces the job's assigned service when set
+	Reason          *string
+	WorkerId        *string
+	ProviderDetail  *string
+	Event           *OutboxEvent // EventId, EventType and Payload; nil for none
+}
+
+// isTerminalJobStatus reports whether no further transitions follow status.
+func isTerminalJobStatus(status string) bool {
+	return status == JobStatusCompleted || status == JobStatusFailed ||
+		status == JobStatusCancelled || status == JobStatusSkipped
+}
+
+// ChangeJobStatus sets a job's status, records the state transition and puts
+// the change's event in the outbox, all in one transaction. Terminal statuses
+// also set CompletedAt.
+func (c *Client) ChangeJobStatus(ctx context.Context, change *JobStatusChange) error {
+	columns := []string{"TenantId", "JobId", "Status", "UpdatedAt"}
+	values := []interface{}{change.TenantId, change.JobId, change.ToStatus, spanner.CommitTimestamp}
+	if isTerminalJobStatus(change.ToStatus) {
+		columns = append(columns, "CompletedAt")
+		values = append(values, time.Now())
+	}
+	if change.ErrorMessage != nil {
+		columns = append(columns, "ErrorMessage")
+		values = append(values, *change.ErrorMessage)
+	}
+	if change.RetryCount != nil {
+		columns = append(columns, "RetryCount")
+		values = append(values, *change.RetryCount)
+	}
+	if change.GcpBatchJobPath != nil {
+		columns = append(columns, "GcpBatchJobPath")
+		values = append(values, *change.GcpBatchJobPath)
+	}
+	if change.ServiceTier != nil {
+		columns = append(columns, "ServiceTier")
+		values = append(values, *change.ServiceTier)
+	}
+	if change.AssignedService != nil {
+		columns = append(columns, "AssignedService")
+		values = append(values, *change.AssignedService)
+	}
+
+	mutations := []*spanner.Mutation{
+		spanner.Update("Jobs", columns, values),
+		spanner.Insert("JobStateTransitions",
+			[]string{"TenantId", "JobId", "TransitionId", "FromStatus", "ToStatus", "TransitionedAt", "Reason", "WorkerId", "ProviderDetail"},
+			[]interface{}{change.TenantId, change.JobId, change.TransitionId, change.FromStatus, change.ToStatus, spanner.CommitTimestamp, change.Reason, change.WorkerId, change.ProviderDetail},
+		),
+	}
+	if e := change.Event; e != nil {
+		mutations = append(mutations, spanner.Insert("Outbox",
+			[]string{"EventId", "TenantId", "JobId", "EventType", "Payload", "CreatedAt", "Attempts"},
+			[]interface{}{e.EventId, change.TenantId, change.JobId, e.EventType, e.Payload, spanner.CommitTimestamp, int64(0)},
+		))
+	}
+
+	if _, err := c.client.Apply(ctx, mutations); err != nil {
+		return fmt.Errorf("failed to change job status: %w", err)
+	}
+	return nil
+}
+
+// InsertOutboxEvent puts an event that comes with no status change, or with
+// one written separately, in the outbox.
+func (c *Client) InsertOutboxEvent(ctx context.Context, e *OutboxEvent) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Insert("Outbox",
+			[]string{"EventId", "TenantId", "JobId", "EventType", "Payload", "CreatedAt", "Attempts"},
+			[]interface{}{e.EventId, e.TenantId, e.JobId, e.EventType, e.Payload, spanner.CommitTimestamp, int64(0)},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+	return nil
+}
+
+// ClaimOutboxEvents leases up to limit undelivered events to workerID until
+// leaseUntil, oldest first. Events leased to another worker are skipped until
+// their lease expires, and dead-lettered events are never claimed.
+func (c *Client) ClaimOutboxEvents(ctx context.Context, workerID string, leaseUntil time.Time, limit int) ([]*OutboxEvent, error) {
+	var events []*OutboxEvent
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		events = nil
+		iter := txn.Query(ctx, spanner.Statement{
+			SQL: `SELECT EventId, TenantId, JobId, EventType, Payload, CreatedAt, DeliveredAt, Attempts, LastError, OwnerWorkerId, LeaseExpiresAt, DeadLetteredAt
+			      FROM Outbox
+			      WHERE DeliveredAt IS NULL AND DeadLetteredAt IS NULL
+			        AND (LeaseExpiresAt IS NULL OR LeaseExpiresAt < CURRENT_TIMESTAMP())
+			      ORDER BY CreatedAt
+			      LIMIT @limit`,
+			Params: map[string]interface{}{"limit": int64(limit)},
+		})
+		defer iter.Stop()
+
+		var mutations []*spanner.Mutation
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed to iterate outbox: %w", err)
+			}
+			var e OutboxEvent
+			if err := row.ToStruct(&e); err != nil {
+				return fmt.Errorf("failed to parse outbox event: %w", err)
+			}
+			owner, until := workerID, leaseUntil
+			e.OwnerWorkerId, e.LeaseExpiresAt = &owner, &until
+			events = append(events, &e)
+			mutations = append(mutations, spanner.Update("Outbox",
+				[]string{"EventId", "OwnerWorkerId", "LeaseExpiresAt"},
+				[]interface{}{e.EventId, workerID, leaseUntil},
+			))
+		}
+		return txn.BufferWrite(mutations)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	return events, nil
+}
+
+// MarkOutboxEventDelivered records that an event was published.
+func (c *Client) MarkOutboxEventDelivered(ctx context.Context, eventID string) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Update("Outbox",
+			[]string{"EventId", "DeliveredAt", "LeaseExpiresAt"},
+			[]interface{}{eventID, spanner.CommitTimestamp, nil},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event delivered: %w", err)
+	}
+	return nil
+}
+
+// MarkOutboxEventFailed records a failed publish. The event keeps its lease,
+// so it is retried once the lease expires.
+func (c *Client) MarkOutboxEventFailed(ctx context.Context, eventID, errorMessage string) error {
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		row, err := txn.ReadRow(ctx, "Outbox", spanner.Key{eventID}, []string{"Attempts"})
+		if err != nil {
+			return fmt.Errorf("failed to read outbox event: %w", err)
+		}
+		var attempts int64
+		if err := row.Columns(&attempts); err != nil {
+			return fmt.Errorf("failed to parse outbox event: %w", err)
+		}
+		return txn.BufferWrite([]*spanner.Mutation{
+			spanner.Update("Outbox",
+				[]string{"EventId", "Attempts", "LastError"},
+				[]interface{}{eventID, attempts + 1, errorMessage},
+			),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event failed: %w", err)
+	}
+	return nil
+}
+
+// DeadLetterOutboxEvent records the last failed publish of an event and stops
+// retrying it.
+func (c *Client) DeadLetterOutboxEvent(ctx context.Context, eventID, errorMessage string) error {
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		row, err := txn.ReadRow(ctx, "Outbox", spanner.Key{eventID}, []string{"Attempts"})
+		if err != nil {
+			return fmt.Errorf("failed to read outbox event: %w", err)
+		}
+		var attempts int64
+		if err := row.Columns(&attempts); err != nil {
+			return fmt.Errorf("failed to parse outbox event: %w", err)
+		}
+		return txn.BufferWrite([]*spanner.Mutation{
+			spanner.Update("Outbox",
+				[]string{"EventId", "Attempts", "LastError", "DeadLetteredAt", "LeaseExpiresAt"},
+				[]interface{}{eventID, attempts + 1, errorMessage, spanner.CommitTimestamp, nil},
+			),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to dead-letter outbox event: %w", err)
+	}
+	return nil
+}
+
+// PruneOutboxEvents removes the events delivered or dead-lettered before
+// before and returns how many were removed.
+func (c *Client) PruneOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	count, err := c.client.PartitionedUpdate(ctx, spanner.Statement{
+		SQL: `DELETE FROM Outbox
+		      WHERE (DeliveredAt IS NOT NULL AND DeliveredAt < @before)
+		         OR (DeadLetteredAt IS NOT NULL AND DeadLetteredAt < @before)`,
+		Params: map[string]interface{}{"before": before},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune outbox events: %w", err)
+	}
+	return count, nil
+}
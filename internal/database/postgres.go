@@ -0,0 +1,1554 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PostgresStore is a Store backed by PostgreSQL. It expects the schema in
+// database/schema.postgres.sql, which mirrors the Spanner tables with foreign
+// keys standing in for interleaving.
+//
+// Errors keep the same shape as the Spanner Client: missing rows surface as
+// NotFound and duplicate keys as AlreadyExists, so spanner.ErrCode works
+// unchanged for callers. clock_timestamp() stands in for
+// spanner.CommitTimestamp. Unlike now(), the transaction start time, it is
+// read when the statement runs, so a row's timestamp trails its commit by one
+// statement rather than by the whole transaction, and rows do not land behind
+// a ListNotificationsSince cursor that has already moved past them.
+type PostgresStore struct {
+	pool *pgxpool.Pool
+}
+
+var jobColumns = []string{
+	"TenantId", "JobId", "Status", "ImageUri", "Commands",
+	"CreatedAt", "UpdatedAt", "ScheduledAt", "StartedAt", "CompletedAt",
+	"RetryCount", "MaxRetries", "ErrorMessage",
+	"GcpBatchJobPath", "GcpBatchTaskGroup", "EnvVarsJson",
+	"Name", "ResourceProfile", "MachineType",
+	"BootDiskSizeGb", "UseSpotVms", "ServiceAccount", "ServiceTier",
+	"AssignedService", "MemoryMib", "CpuMillis", "MaxRunDurationSeconds",
+	"OwnerWorkerId", "PreferredWorkerId", "LeaseExpiresAt", "LastHeartbeatAt",
+	"RetryPolicy", "WorkflowId", "WorkflowNodeId", "DependsOnJson",
+	"IdempotencyKey", "RequestHash", "SubmitResponseJson", "LabelsJson",
+}
+
+var tenantColumns = []string{
+	"TenantId", "UserEmail", "OAuthProvider", "OAuthUserId", "CreatedAt", "UpdatedAt",
+}
+
+var transitionColumns = []string{
+	"TenantId", "JobId", "TransitionId", "FromStatus", "ToStatus", "TransitionedAt", "Reason",
+	"WorkerId", "ProviderDetail",
+}
+
+var outboxColumns = []string{
+	"EventId", "TenantId", "JobId", "EventType", "Payload", "CreatedAt",
+	"DeliveredAt", "Attempts", "LastError", "OwnerWorkerId", "LeaseExpiresAt", "DeadLetteredAt",
+}
+
+// NewPostgresStore connects to PostgreSQL and verifies the connection.
+// dsn is either a postgres:// URL or a libpq keyword/value string; anything
+// it omits (password, sslmode, ...) falls back to the standard PG* env vars.
+func NewPostgresStore(ctx context.Context, dsn string) (*PostgresStore, error) {
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create postgres pool: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+	return &PostgresStore{pool: pool}, nil
+}
+
+// postgresDSN builds a connection string from DB_ENDPOINT and DB_DATABASE.
+// A full URL or keyword/value DSN is used as-is; a bare host[:port] is
+// combined with the database name.
+func postgresDSN(endpoint, database string) string {
+	if strings.Contains(endpoint, "://") || strings.Contains(endpoint, "=") {
+		return endpoint
+	}
+	dsn := "postgres://" + endpoint
+	if database != "" {
+		dsn += "/" + database
+	}
+	return dsn
+}
+
+// Close closes the connection pool.
+func (p *PostgresStore) Close() {
+	p.pool.Close()
+}
+
+// pgStatusError carries a gRPC code for a PostgreSQL error while keeping the
+// original error reachable through errors.As/Is.
+type pgStatusError struct {
+	code codes.Code
+	err  error
+}
+
+func (e *pgStatusError) Error() string              { return e.err.Error() }
+func (e *pgStatusError) Unwrap() error              { return e.err }
+func (e *pgStatusError) GRPCStatus() *status.Status { return status.New(e.code, e.err.Error()) }
+
+// pgError maps driver errors onto the gRPC codes Spanner would return.
+func pgError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &pgStatusError{code: codes.NotFound, err: err}
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505": // unique_violation
+			return &pgStatusError{code: codes.AlreadyExists, err: err}
+		case "23503": // foreign_key_violation: parent row is missing
+			return &pgStatusError{code: codes.NotFound, err: err}
+		}
+	}
+	return err
+}
+
+// exec runs a single-row write and reports NotFound when no row matched,
+// matching spanner.Update/Delete on a missing key.
+func (p *PostgresStore) exec(ctx context.Context, table string, sql string, args ...any) error {
+	tag, err := p.pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return pgError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errRowNotFound(table, fmt.Sprint(args[0]), fmt.Sprint(args[1]))
+	}
+	return nil
+}
+
+func scanJob(row pgx.Row) (*Job, error) {
+	var j Job
+	err := row.Scan(
+		&j.TenantId, &j.JobId, &j.Status, &j.ImageUri, &j.Commands,
+		&j.CreatedAt, &j.UpdatedAt, &j.ScheduledAt, &j.StartedAt, &j.CompletedAt,
+		&j.RetryCount, &j.MaxRetries, &j.ErrorMessage,
+		&j.GcpBatchJobPath, &j.GcpBatchTaskGroup, &j.EnvVarsJson,
+		&j.Name, &j.ResourceProfile, &j.MachineType,
+		&j.BootDiskSizeGb, &j.UseSpotVms, &j.ServiceAccount, &j.ServiceTier,
+		&j.AssignedService, &j.MemoryMib, &j.CpuMillis, &j.MaxRunDurationSeconds,
+		&j.OwnerWorkerId, &j.PreferredWorkerId, &j.LeaseExpiresAt, &j.LastHeartbeatAt,
+		&j.RetryPolicy, &j.WorkflowId, &j.WorkflowNodeId, &j.DependsOnJson,
+		&j.IdempotencyKey, &j.RequestHash, &j.SubmitResponseJson, &j.LabelsJson,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+func scanTenant(row pgx.Row) (*Tenant, error) {
+	var t Tenant
+	if err := row.Scan(&t.TenantId, &t.UserEmail, &t.OAuthProvider, &t.OAuthUserId, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func scanNotification(row pgx.Row) (*Notification, error) {
+	var n Notification
+	err := row.Scan(
+		&n.TenantId, &n.NotificationId, &n.JobId, &n.JobName,
+		&n.FinalStatus, &n.ServiceTier, &n.AssignedService,
+		&n.OccurredAt, &n.ErrorMessage, &n.IsRead, &n.CreatedAt, &n.EventType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func scanTransition(row pgx.Row) (*JobStateTransition, error) {
+	var t JobStateTransition
+	if err := row.Scan(&t.TenantId, &t.JobId, &t.TransitionId, &t.FromStatus, &t.ToStatus, &t.TransitionedAt, &t.Reason, &t.WorkerId, &t.ProviderDetail); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func scanSchedule(row pgx.Row) (*Schedule, error) {
+	var s Schedule
+	err := row.Scan(
+		&s.TenantId, &s.ScheduleId, &s.Name, &s.CronExpression, &s.TimeZone,
+		&s.OverlapPolicy, &s.JobTemplateJson, &s.Paused, &s.NextRunAt,
+		&s.LastRunAt, &s.LastJobId, &s.OwnerWorkerId, &s.LeaseExpiresAt,
+		&s.CreatedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func scanApiKey(row pgx.Row) (*ApiKey, error) {
+	var k ApiKey
+	err := row.Scan(
+		&k.TenantId, &k.ApiKeyId, &k.Name, &k.KeyPrefix, &k.KeyHash,
+		&k.Scopes, &k.ExpiresAt, &k.RevokedAt, &k.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+func scanOrganization(row pgx.Row) (*Organization, error) {
+	var o Organization
+	if err := row.Scan(&o.TenantId, &o.Name, &o.CreatedBy, &o.CreatedAt, &o.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func scanOrganizationMember(row pgx.Row) (*OrganizationMember, error) {
+	var m OrganizationMember
+	if err := row.Scan(&m.TenantId, &m.Email, &m.Role, &m.AddedBy, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func scanTenantQuota(row pgx.Row) (*TenantQuota, error) {
+	var q TenantQuota
+	err := row.Scan(
+		&q.TenantId, &q.MaxConcurrentJobs, &q.MaxCpuMillis, &q.MaxMemoryMib, &q.MaxJobsPerDay,
+		&q.AllowedMachineTypes, &q.AllowSpotVms, &q.QueueWhenBusy, &q.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+func scanWorker(row pgx.Row) (*Worker, error) {
+	var w Worker
+	err := row.Scan(&w.WorkerId, &w.Address, &w.Capacity, &w.ActiveJobs, &w.StartedAt, &w.LastHeartbeatAt)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func scanOutboxEvent(row pgx.Row) (*OutboxEvent, error) {
+	var e OutboxEvent
+	err := row.Scan(
+		&e.EventId, &e.TenantId, &e.JobId, &e.EventType, &e.Payload, &e.CreatedAt,
+		&e.DeliveredAt, &e.Attempts, &e.LastError, &e.OwnerWorkerId, &e.LeaseExpiresAt, &e.DeadLetteredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// queryRows runs sql and scans every row with scan.
+func queryRows[T any](ctx context.Context, p *PostgresStore, scan func(pgx.Row) (*T, error), sql string, args ...any) ([]*T, error) {
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, pgError(err)
+	}
+	defer rows.Close()
+
+	var out []*T
+	for rows.Next() {
+		v, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, pgError(err)
+	}
+	return out, nil
+}
+
+// ── Jobs ─────────────────────────────────────────────────────────────────────
+
+// InsertJob creates a new job with PENDING status
+func (p *PostgresStore) InsertJob(ctx context.Context, tenantID, jobID, imageUri string, commands []string) error {
+	_, err := p.pool.Exec(ctx,
+		`INSERT INTO Jobs (TenantId, JobId, Status, ImageUri, Commands, CreatedAt, UpdatedAt, RetryCount, MaxRetries)
+		 VALUES ($1, $2, $3, $4, $5, clock_timestamp(), clock_timestamp(), 0, 3)`,
+		tenantID, jobID, JobStatusPending, imageUri, commands,
+	)
+	return pgError(err)
+}
+
+// InsertJobFull creates a new job with all fields including advanced configuration.
+func (p *PostgresStore) InsertJobFull(ctx context.Context, job *Job) error {
+	return insertJobFull(ctx, p.pool, job)
+}
+
+// pgExecer is satisfied by both *pgxpool.Pool and pgx.Tx.
+type pgExecer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// insertJobFull runs the full job insert on a pool or inside a transaction.
+func insertJobFull(ctx context.Context, db pgExecer, job *Job) error {
+	_, err := db.Exec(ctx,
+		`INSERT INTO Jobs (
+		   TenantId, JobId, Status, ImageUri, Commands,
+		   CreatedAt, UpdatedAt, RetryCount, MaxRetries,
+		   GcpBatchJobPath, GcpBatchTaskGroup, EnvVarsJson,
+		   Name, ResourceProfile, MachineType,
+		   BootDiskSizeGb, UseSpotVms, ServiceAccount, ServiceTier,
+		   AssignedService, MemoryMib, CpuMillis, MaxRunDurationSeconds,
+		   OwnerWorkerId, PreferredWorkerId, LeaseExpiresAt, LastHeartbeatAt,
+		   RetryPolicy, WorkflowId, WorkflowNodeId, DependsOnJson,
+		   IdempotencyKey, RequestHash, SubmitResponseJson, LabelsJson)
+		 VALUES ($1, $2, $3, $4, $5, clock_timestamp(), clock_timestamp(), $6, $7, $8, $9, $10, $11, $12, $13,
+		         $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26,
+		         $27, $28, $29, $30, $31, $32, $33)`,
+		job.TenantId, job.JobId, job.Status, job.ImageUri, job.Commands,
+		job.RetryCount, job.MaxRetries,
+		job.GcpBatchJobPath, job.GcpBatchTaskGroup, job.EnvVarsJson,
+		job.Name, job.ResourceProfile, job.MachineType,
+		job.BootDiskSizeGb, job.UseSpotVms, job.ServiceAccount, job.ServiceTier,
+		job.AssignedService, job.MemoryMib, job.CpuMillis, job.MaxRunDurationSeconds,
+		job.OwnerWorkerId, job.PreferredWorkerId, job.LeaseExpiresAt, job.LastHeartbeatAt,
+		job.RetryPolicy, job.WorkflowId, job.WorkflowNodeId, job.DependsOnJson,
+		job.IdempotencyKey, job.RequestHash, job.SubmitResponseJson, job.LabelsJson,
+	)
+	return pgError(err)
+}
+
+// GetJob retrieves a job by tenant ID and job ID
+func (p *PostgresStore) GetJob(ctx context.Context, tenantID, jobID string) (*Job, error) {
+	job, err := scanJob(p.pool.QueryRow(ctx,
+		`SELECT `+columnList(jobColumns)+` FROM Jobs WHERE TenantId = $1 AND JobId = $2`,
+		tenantID, jobID,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", pgError(err))
+	}
+	return job, nil
+}
+
+// ListJobs returns all jobs for a tenant
+func (p *PostgresStore) ListJobs(ctx context.Context, tenantID string) ([]*Job, error) {
+	jobs, err := queryRows(ctx, p, scanJob,
+		`SELECT `+columnList(jobColumns)+` FROM Jobs WHERE TenantId = $1 ORDER BY CreatedAt DESC`,
+		tenantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// ListJobsByStatus returns jobs for a tenant filtered by status
+func (p *PostgresStore) ListJobsByStatus(ctx context.Context, tenantID, status string) ([]*Job, error) {
+	jobs, err := queryRows(ctx, p, scanJob,
+		`SELECT `+columnList(jobColumns)+` FROM Jobs WHERE TenantId = $1 AND Status = $2 ORDER BY CreatedAt DESC`,
+		tenantID, status,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// ListJobsFiltered returns a page of a tenant's jobs matching filter, ordered
+// by CreatedAt then JobId, newest first unless filter.Ascending.
+func (p *PostgresStore) ListJobsFiltered(ctx context.Context, tenantID string, filter JobFilter) ([]*Job, error) {
+	conds := []string{"TenantId = $1"}
+	args := []any{tenantID}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if len(filter.Statuses) > 0 {
+		conds = append(conds, "Status = ANY("+arg(filter.Statuses)+")")
+	}
+	if filter.AssignedService != "" {
+		conds = append(conds, "AssignedService = "+arg(filter.AssignedService))
+	}
+	if filter.NamePrefix != "" {
+		conds = append(conds, "starts_with(Name, "+arg(filter.NamePrefix)+")")
+	}
+	if !filter.CreatedAfter.IsZero() {
+		conds = append(conds, "CreatedAt >= "+arg(filter.CreatedAfter))
+	}
+	if !filter.CreatedBefore.IsZero() {
+		conds = append(conds, "CreatedAt < "+arg(filter.CreatedBefore))
+	}
+	for _, r := range filter.Labels {
+		switch r.Operator {
+		case LabelEquals:
+			// Containment can use the JobsByLabels GIN index.
+			conds = append(conds, fmt.Sprintf("LabelsJson::jsonb @> jsonb_build_object(%s::text, %s::text)", arg(r.Key), arg(r.Value)))
+		case LabelNotEquals:
+			conds = append(conds, fmt.Sprintf("(LabelsJson::jsonb ->> %s) IS DISTINCT FROM %s", arg(r.Key), arg(r.Value)))
+		case LabelExists:
+			conds = append(conds, fmt.Sprintf("LabelsJson::jsonb ? %s", arg(r.Key)))
+		case LabelDoesNotExist:
+			conds = append(conds, fmt.Sprintf("NOT COALESCE(LabelsJson::jsonb ? %s, FALSE)", arg(r.Key)))
+		default:
+			return nil, fmt.Errorf("unknown label operator %q", r.Operator)
+		}
+	}
+
+	order, cmp := "DESC", "<"
+	if filter.Ascending {
+		order, cmp = "ASC", ">"
+	}
+	if filter.After != nil {
+		conds = append(conds, fmt.Sprintf("(CreatedAt, JobId) %s (%s, %s)", cmp, arg(filter.After.CreatedAt), arg(filter.After.JobId)))
+	}
+
+	sql := `SELECT ` + columnList(jobColumns) + ` FROM Jobs WHERE ` + strings.Join(conds, " AND ") +
+		` ORDER BY CreatedAt ` + order + `, JobId ` + order
+	if filter.Limit > 0 {
+		sql += ` LIMIT ` + arg(filter.Limit)
+	}
+
+	jobs, err := queryRows(ctx, p, scanJob, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// UpdateJobStatus updates the status of a job
+func (p *PostgresStore) UpdateJobStatus(ctx context.Context, tenantID, jobID, status string) error {
+	err := p.exec(ctx, "Jobs",
+		`UPDATE Jobs SET Status = $3, UpdatedAt = clock_timestamp() WHERE TenantId = $1 AND JobId = $2`,
+		tenantID, jobID, status,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update job status: %w", err)
+	}
+	return nil
+}
+
+// UpdateJobStatusAndGcpBatchJobPath updates the status, GCP Batch job path, service tier, and assigned service of a job.
+func (p *PostgresStore) UpdateJobStatusAndGcpBatchJobPath(ctx context.Context, tenantID, jobID, status, gcpBatchJobPath, serviceTier, assignedService string) error {
+	err := p.exec(ctx, "Jobs",
+		`UPDATE Jobs SET Status = $3, GcpBatchJobPath = $4, ServiceTier = $5, AssignedService = $6, UpdatedAt = clock_timestamp()
+		 WHERE TenantId = $1 AND JobId = $2`,
+		tenantID, jobID, status, gcpBatchJobPath, serviceTier, assignedService,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update job status and GCP Batch job path: %w", err)
+	}
+	return nil
+}
+
+// CompleteJob marks a job as completed with a completion timestamp
+func (p *PostgresStore) CompleteJob(ctx context.Context, tenantID, jobID string) error {
+	err := p.exec(ctx, "Jobs",
+		`UPDATE Jobs SET Status = $3, CompletedAt = $4, UpdatedAt = clock_timestamp() WHERE TenantId = $1 AND JobId = $2`,
+		tenantID, jobID, JobStatusCompleted, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to complete job: %w", err)
+	}
+	return nil
+}
+
+// FailJob marks a job as failed with an error message
+func (p *PostgresStore) FailJob(ctx context.Context, tenantID, jobID, errorMessage string) error {
+	err := p.exec(ctx, "Jobs",
+		`UPDATE Jobs SET Status = $3, ErrorMessage = $4, CompletedAt = $5, UpdatedAt = clock_timestamp() WHERE TenantId = $1 AND JobId = $2`,
+		tenantID, jobID, JobStatusFailed, errorMessage, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to fail job: %w", err)
+	}
+	return nil
+}
+
+// RetryJob marks a failed job as RETRYING, recording the attempt number and
+// the error that triggered the retry.
+func (p *PostgresStore) RetryJob(ctx context.Context, tenantID, jobID string, retryCount int64, errorMessage string) error {
+	err := p.exec(ctx, "Jobs",
+		`UPDATE Jobs SET Status = $3, RetryCount = $4, ErrorMessage = $5, UpdatedAt = clock_timestamp() WHERE TenantId = $1 AND JobId = $2`,
+		tenantID, jobID, JobStatusRetrying, retryCount, errorMessage,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark job for retry: %w", err)
+	}
+	return nil
+}
+
+// ScheduleJob marks a job as SCHEDULED with a scheduled timestamp
+func (p *PostgresStore) ScheduleJob(ctx context.Context, tenantID, jobID string) error {
+	err := p.exec(ctx, "Jobs",
+		`UPDATE Jobs SET Status = $3, ScheduledAt = $4, UpdatedAt = clock_timestamp() WHERE TenantId = $1 AND JobId = $2`,
+		tenantID, jobID, JobStatusScheduled, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule job: %w", err)
+	}
+	return nil
+}
+
+// StartJob marks a job as RUNNING with a started timestamp
+func (p *PostgresStore) StartJob(ctx context.Context, tenantID, jobID string) error {
+	err := p.exec(ctx, "Jobs",
+		`UPDATE Jobs SET Status = $3, StartedAt = $4, UpdatedAt = clock_timestamp() WHERE TenantId = $1 AND JobId = $2`,
+		tenantID, jobID, JobStatusRunning, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start job: %w", err)
+	}
+	return nil
+}
+
+// CancelJob marks a job as CANCELLED
+func (p *PostgresStore) CancelJob(ctx context.Context, tenantID, jobID string) error {
+	err := p.exec(ctx, "Jobs",
+		`UPDATE Jobs SET Status = $3, CompletedAt = $4, UpdatedAt = clock_timestamp() WHERE TenantId = $1 AND JobId = $2`,
+		tenantID, jobID, JobStatusCancelled, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to cancel job: %w", err)
+	}
+	return nil
+}
+
+// DeleteJob removes a job. Like spanner.Delete, deleting a missing row is not
+// an error; transitions go with it via ON DELETE CASCADE.
+func (p *PostgresStore) DeleteJob(ctx context.Context, tenantID, jobID string) error {
+	_, err := p.pool.Exec(ctx, `DELETE FROM Jobs WHERE TenantId = $1 AND JobId = $2`, tenantID, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to delete job: %w", pgError(err))
+	}
+	return nil
+}
+
+// ListActiveJobs returns all active (non-terminal) jobs across tenants that have a cloud resource path.
+// RETRYING jobs are included so a worker that takes over the lease can resume the retry.
+func (p *PostgresStore) ListActiveJobs(ctx context.Context) ([]*Job, error) {
+	jobs, err := queryRows(ctx, p, scanJob,
+		`SELECT `+columnList(jobColumns)+`
+		 FROM Jobs
+		 WHERE Status IN ($1, $2, $3, $4)
+		   AND GcpBatchJobPath IS NOT NULL
+		 ORDER BY UpdatedAt DESC`,
+		JobStatusPending, JobStatusScheduled, JobStatusRunning, JobStatusRetrying,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate active jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// ListQueuedJobs returns every QUEUED job across tenants, oldest first.
+func (p *PostgresStore) ListQueuedJobs(ctx context.Context) ([]*Job, error) {
+	jobs, err := queryRows(ctx, p, scanJob,
+		`SELECT `+columnList(jobColumns)+`
+		 FROM Jobs
+		 WHERE Status = $1
+		 ORDER BY CreatedAt`,
+		JobStatusQueued,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate queued jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// CountJobsSince returns how many jobs a tenant created at or after since.
+func (p *PostgresStore) CountJobsSince(ctx context.Context, tenantID string, since time.Time) (int64, error) {
+	var count int64
+	err := p.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM Jobs WHERE TenantId = $1 AND CreatedAt >= $2`,
+		tenantID, since,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count jobs: %w", pgError(err))
+	}
+	return count, nil
+}
+
+// GetJobByIdempotencyKey returns the tenant's job submitted with the given
+// idempotency key. It returns nil, nil when no job holds the key.
+func (p *PostgresStore) GetJobByIdempotencyKey(ctx context.Context, tenantID, idempotencyKey string) (*Job, error) {
+	job, err := scanJob(p.pool.QueryRow(ctx,
+		`SELECT `+columnList(jobColumns)+` FROM Jobs WHERE TenantId = $1 AND IdempotencyKey = $2`,
+		tenantID, idempotencyKey,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job by idempotency key: %w", pgError(err))
+	}
+	return job, nil
+}
+
+// SetJobSubmitResponse stores the SubmitJob response replayed to retries of
+// an idempotent submission.
+func (p *PostgresStore) SetJobSubmitResponse(ctx context.Context, tenantID, jobID, responseJson string) error {
+	err := p.exec(ctx, "Jobs",
+		`UPDATE Jobs SET SubmitResponseJson = $3 WHERE TenantId = $1 AND JobId = $2`,
+		tenantID, jobID, responseJson,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set job submit response: %w", err)
+	}
+	return nil
+}
+
+// ClearJobIdempotencyKey releases a job's idempotency key so a new
+// submission may use it. The job itself is left unchanged.
+func (p *PostgresStore) ClearJobIdempotencyKey(ctx context.Context, tenantID, jobID string) error {
+	err := p.exec(ctx, "Jobs",
+		`UPDATE Jobs SET IdempotencyKey = NULL, RequestHash = NULL, SubmitResponseJson = NULL WHERE TenantId = $1 AND JobId = $2`,
+		tenantID, jobID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to clear job idempotency key: %w", err)
+	}
+	return nil
+}
+
+// TryClaimOrRenewJobLease attempts to claim/renew ownership for an active job.
+// Returns true when caller becomes/continues owner. The row is locked with
+// SELECT ... FOR UPDATE so concurrent workers serialize on it.
+func (p *PostgresStore) TryClaimOrRenewJobLease(ctx context.Context, tenantID, jobID, workerID string, leaseUntil time.Time) (bool, error) {
+	claimed := false
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		var status string
+		var ownerWorkerID, preferredWorkerID *string
+		var leaseExpiresAt *time.Time
+		err := tx.QueryRow(ctx,
+			`SELECT Status, OwnerWorkerId, PreferredWorkerId, LeaseExpiresAt
+			 FROM Jobs WHERE TenantId = $1 AND JobId = $2 FOR UPDATE`,
+			tenantID, jobID,
+		).Scan(&status, &ownerWorkerID, &preferredWorkerID, &leaseExpiresAt)
+		if err != nil {
+			return fmt.Errorf("failed to read job lease state: %w", pgError(err))
+		}
+
+		if status == JobStatusCompleted || status == JobStatusFailed || status == JobStatusCancelled || status == JobStatusSkipped {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		isOwner := ownerWorkerID != nil && *ownerWorkerID == workerID
+		leaseExpired := leaseExpiresAt == nil || leaseExpiresAt.Before(now)
+		isUnowned := ownerWorkerID == nil || *ownerWorkerID == ""
+		preferredTakeover := preferredWorkerID != nil && *preferredWorkerID == workerID && !isOwner
+
+		canClaim := isOwner || leaseExpired || isUnowned || preferredTakeover
+		if !canClaim {
+			return nil
+		}
+
+		_, err = tx.Exec(ctx,
+			`UPDATE Jobs SET OwnerWorkerId = $3, LeaseExpiresAt = $4, LastHeartbeatAt = $5, UpdatedAt = clock_timestamp()
+			 WHERE TenantId = $1 AND JobId = $2`,
+			tenantID, jobID, workerID, leaseUntil, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to write lease: %w", pgError(err))
+		}
+		claimed = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to claim/renew lease: %w", err)
+	}
+	return claimed, nil
+}
+
+// ReleaseJobLease gives up workerID's lease on a job and names
+// preferredWorkerID (empty for none) as the worker to take it over. Returns
+// false when workerID does not own the job.
+func (p *PostgresStore) ReleaseJobLease(ctx context.Context, tenantID, jobID, workerID, preferredWorkerID string) (bool, error) {
+	var preferred *string
+	if preferredWorkerID != "" {
+		preferred = &preferredWorkerID
+	}
+	tag, err := p.pool.Exec(ctx,
+		`UPDATE Jobs SET OwnerWorkerId = NULL, PreferredWorkerId = $4, LeaseExpiresAt = NULL, UpdatedAt = clock_timestamp()
+		 WHERE TenantId = $1 AND JobId = $2 AND OwnerWorkerId = $3`,
+		tenantID, jobID, workerID, preferred,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to release lease: %w", pgError(err))
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ── Tenants ──────────────────────────────────────────────────────────────────
+
+// UpsertTenant creates a new tenant or updates it if it already exists.
+// This is safe to call even if the tenant row already exists (idempotent).
+func (p *PostgresStore) UpsertTenant(ctx context.Context, tenantID, userEmail, oauthProvider, oauthUserId string) error {
+	_, err := p.pool.Exec(ctx,
+		`INSERT INTO Tenants (TenantId, UserEmail, OAuthProvider, OAuthUserId, CreatedAt, UpdatedAt)
+		 VALUES ($1, $2, $3, $4, clock_timestamp(), clock_timestamp())
+		 ON CONFLICT (TenantId) DO UPDATE SET
+		   UserEmail = EXCLUDED.UserEmail,
+		   OAuthProvider = EXCLUDED.OAuthProvider,
+		   OAuthUserId = EXCLUDED.OAuthUserId,
+		   CreatedAt = EXCLUDED.CreatedAt,
+		   UpdatedAt = EXCLUDED.UpdatedAt`,
+		tenantID, userEmail, oauthProvider, oauthUserId,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert tenant: %w", pgError(err))
+	}
+	return nil
+}
+
+// InsertTenant creates a new tenant
+func (p *PostgresStore) InsertTenant(ctx context.Context, tenantID, userEmail, oauthProvider, oauthUserId string) error {
+	_, err := p.pool.Exec(ctx,
+		`INSERT INTO Tenants (TenantId, UserEmail, OAuthProvider, OAuthUserId, CreatedAt, UpdatedAt)
+		 VALUES ($1, $2, $3, $4, clock_timestamp(), clock_timestamp())`,
+		tenantID, userEmail, oauthProvider, oauthUserId,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert tenant: %w", pgError(err))
+	}
+	return nil
+}
+
+// GetTenant retrieves a tenant by ID
+func (p *PostgresStore) GetTenant(ctx context.Context, tenantID string) (*Tenant, error) {
+	tenant, err := scanTenant(p.pool.QueryRow(ctx,
+		`SELECT `+columnList(tenantColumns)+` FROM Tenants WHERE TenantId = $1`,
+		tenantID,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", pgError(err))
+	}
+	return tenant, nil
+}
+
+// ListTenants returns all tenants
+func (p *PostgresStore) ListTenants(ctx context.Context) ([]*Tenant, error) {
+	tenants, err := queryRows(ctx, p, scanTenant,
+		`SELECT `+columnList(tenantColumns)+` FROM Tenants ORDER BY CreatedAt DESC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate tenants: %w", err)
+	}
+	return tenants, nil
+}
+
+// GetTenantByOAuth retrieves a tenant by OAuth provider and user ID
+func (p *PostgresStore) GetTenantByOAuth(ctx context.Context, oauthProvider, oauthUserId string) (*Tenant, error) {
+	tenant, err := scanTenant(p.pool.QueryRow(ctx,
+		`SELECT `+columnList(tenantColumns)+`
+		 FROM Tenants
+		 WHERE OAuthProvider = $1 AND OAuthUserId = $2
+		 LIMIT 1`,
+		oauthProvider, oauthUserId,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil // No tenant found
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tenant by OAuth: %w", pgError(err))
+	}
+	return tenant, nil
+}
+
+// DeleteTenant removes a tenant and all its jobs (CASCADE)
+func (p *PostgresStore) DeleteTenant(ctx context.Context, tenantID string) error {
+	_, err := p.pool.Exec(ctx, `DELETE FROM Tenants WHERE TenantId = $1`, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete tenant: %w", pgError(err))
+	}
+	return nil
+}
+
+// ── Quotas ───────────────────────────────────────────────────────────────────
+
+// GetTenantQuota retrieves a tenant's quota overrides. It returns nil, nil
+// when the tenant has none.
+func (p *PostgresStore) GetTenantQuota(ctx context.Context, tenantID string) (*TenantQuota, error) {
+	q, err := scanTenantQuota(p.pool.QueryRow(ctx,
+		`SELECT `+columnList(tenantQuotaColumns)+` FROM TenantQuotas WHERE TenantId = $1`,
+		tenantID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant quota: %w", pgError(err))
+	}
+	return q, nil
+}
+
+// UpsertTenantQuota creates or replaces a tenant's quota overrides.
+func (p *PostgresStore) UpsertTenantQuota(ctx context.Context, q *TenantQuota) error {
+	_, err := p.pool.Exec(ctx,
+		`INSERT INTO TenantQuotas (`+columnList(tenantQuotaColumns)+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, clock_timestamp())
+		 ON CONFLICT (TenantId) DO UPDATE SET
+		   MaxConcurrentJobs = EXCLUDED.MaxConcurrentJobs,
+		   MaxCpuMillis = EXCLUDED.MaxCpuMillis,
+		   MaxMemoryMib = EXCLUDED.MaxMemoryMib,
+		   MaxJobsPerDay = EXCLUDED.MaxJobsPerDay,
+		   AllowedMachineTypes = EXCLUDED.AllowedMachineTypes,
+		   AllowSpotVms = EXCLUDED.AllowSpotVms,
+		   QueueWhenBusy = EXCLUDED.QueueWhenBusy,
+		   UpdatedAt = EXCLUDED.UpdatedAt`,
+		q.TenantId, q.MaxConcurrentJobs, q.MaxCpuMillis, q.MaxMemoryMib, q.MaxJobsPerDay,
+		q.AllowedMachineTypes, q.AllowSpotVms, q.QueueWhenBusy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert tenant quota: %w", pgError(err))
+	}
+	return nil
+}
+
+// ── Notifications ────────────────────────────────────────────────────────────
+
+// InsertNotification persists a new notification row, overwriting any row
+// with the same (TenantId, NotificationId) like spanner.InsertOrUpdate.
+func (p *PostgresStore) InsertNotification(ctx context.Context, n *Notification) error {
+	_, err := p.pool.Exec(ctx,
+		`INSERT INTO Notifications (`+columnList(notificationColumns)+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, FALSE, clock_timestamp(), $10)
+		 ON CONFLICT (TenantId, NotificationId) DO UPDATE SET
+		   JobId = EXCLUDED.JobId,
+		   JobName = EXCLUDED.JobName,
+		   FinalStatus = EXCLUDED.FinalStatus,
+		   ServiceTier = EXCLUDED.ServiceTier,
+		   AssignedService = EXCLUDED.AssignedService,
+		   OccurredAt = EXCLUDED.OccurredAt,
+		   ErrorMessage = EXCLUDED.ErrorMessage,
+		   IsRead = EXCLUDED.IsRead,
+		   CreatedAt = EXCLUDED.CreatedAt,
+		   EventType = EXCLUDED.EventType`,
+		n.TenantId, n.NotificationId, n.JobId, n.JobName,
+		n.FinalStatus, n.ServiceTier, n.AssignedService,
+		n.OccurredAt, n.ErrorMessage, n.EventType,
+	)
+	if err != nil {
+		return fmt.Errorf("insert notification: %w", pgError(err))
+	}
+	return nil
+}
+
+// ListNotifications returns notifications for a tenant ordered by newest first.
+// limit ≤ 0 defaults to 20.
+func (p *PostgresStore) ListNotifications(ctx context.Context, tenantID string, limit int32) ([]*Notification, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	notifications, err := queryRows(ctx, p, scanNotification,
+		`SELECT `+columnList(notificationColumns)+`
+		 FROM Notifications
+		 WHERE TenantId = $1
+		 ORDER BY OccurredAt DESC
+		 LIMIT $2`,
+		tenantID, int64(limit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list notifications: %w", err)
+	}
+	return notifications, nil
+}
+
+// ListNotificationsSince returns notifications created after since, oldest
+// first. limit ≤ 0 defaults to 50.
+func (p *PostgresStore) ListNotificationsSince(ctx context.Context, tenantID string, since time.Time, limit int32) ([]*Notification, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	notifications, err := queryRows(ctx, p, scanNotification,
+		`SELECT `+columnList(notificationColumns)+`
+		 FROM Notifications
+		 WHERE TenantId = $1 AND CreatedAt > $2
+		 ORDER BY CreatedAt ASC
+		 LIMIT $3`,
+		tenantID, since, int64(limit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list notifications since: %w", err)
+	}
+	return notifications, nil
+}
+
+// CountUnread returns the number of unread notifications for a tenant.
+func (p *PostgresStore) CountUnread(ctx context.Context, tenantID string) (int32, error) {
+	var count int64
+	err := p.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM Notifications WHERE TenantId = $1 AND IsRead = FALSE`,
+		tenantID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count unread: %w", pgError(err))
+	}
+	return int32(count), nil
+}
+
+// AckNotification marks a single notification as read.
+func (p *PostgresStore) AckNotification(ctx context.Context, tenantID, notificationID string) error {
+	err := p.exec(ctx, "Notifications",
+		`UPDATE Notifications SET IsRead = TRUE WHERE TenantId = $1 AND NotificationId = $2`,
+		tenantID, notificationID,
+	)
+	if err != nil {
+		return fmt.Errorf("ack notification %s: %w", notificationID, err)
+	}
+	return nil
+}
+
+// ── Schedules ────────────────────────────────────────────────────────────────
+
+// InsertSchedule creates a schedule.
+func (p *PostgresStore) InsertSchedule(ctx context.Context, s *Schedule) error {
+	_, err := p.pool.Exec(ctx,
+		`INSERT INTO Schedules (TenantId, ScheduleId, Name, CronExpression, TimeZone, OverlapPolicy, JobTemplateJson, Paused, NextRunAt, CreatedAt, UpdatedAt)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, clock_timestamp(), clock_timestamp())`,
+		s.TenantId, s.ScheduleId, s.Name, s.CronExpression, s.TimeZone, s.OverlapPolicy, s.JobTemplateJson, s.Paused, s.NextRunAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert schedule: %w", pgError(err))
+	}
+	return nil
+}
+
+// GetSchedule retrieves a schedule by tenant ID and schedule ID.
+func (p *PostgresStore) GetSchedule(ctx context.Context, tenantID, scheduleID string) (*Schedule, error) {
+	s, err := scanSchedule(p.pool.QueryRow(ctx,
+		`SELECT `+columnList(scheduleColumns)+` FROM Schedules WHERE TenantId = $1 AND ScheduleId = $2`,
+		tenantID, scheduleID,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", pgError(err))
+	}
+	return s, nil
+}
+
+// ListSchedules returns all schedules for a tenant, newest first.
+func (p *PostgresStore) ListSchedules(ctx context.Context, tenantID string) ([]*Schedule, error) {
+	schedules, err := queryRows(ctx, p, scanSchedule,
+		`SELECT `+columnList(scheduleColumns)+`
+		 FROM Schedules
+		 WHERE TenantId = $1
+		 ORDER BY CreatedAt DESC`,
+		tenantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// ListDueSchedules returns unpaused schedules across tenants whose NextRunAt
+// is at or before now, oldest first.
+func (p *PostgresStore) ListDueSchedules(ctx context.Context, now time.Time) ([]*Schedule, error) {
+	schedules, err := queryRows(ctx, p, scanSchedule,
+		`SELECT `+columnList(scheduleColumns)+`
+		 FROM Schedules
+		 WHERE Paused = FALSE AND NextRunAt <= $1
+		 ORDER BY NextRunAt`,
+		now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// SetSchedulePaused pauses or resumes a schedule. nextRunAt is stored as the
+// next fire time, so a resumed schedule does not replay fires it missed.
+func (p *PostgresStore) SetSchedulePaused(ctx context.Context, tenantID, scheduleID string, paused bool, nextRunAt time.Time) error {
+	err := p.exec(ctx, "Schedules",
+		`UPDATE Schedules SET Paused = $3, NextRunAt = $4, UpdatedAt = clock_timestamp()
+		 WHERE TenantId = $1 AND ScheduleId = $2`,
+		tenantID, scheduleID, paused, nextRunAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update schedule: %w", err)
+	}
+	return nil
+}
+
+// AdvanceSchedule moves a schedule to its next fire time. When lastJobID is
+// non-nil the fire produced a job, which is recorded as the latest run.
+func (p *PostgresStore) AdvanceSchedule(ctx context.Context, tenantID, scheduleID string, nextRunAt time.Time, lastJobID *string) error {
+	err := p.exec(ctx, "Schedules",
+		`UPDATE Schedules SET NextRunAt = $3,
+		   LastJobId = COALESCE($4::TEXT, LastJobId),
+		   LastRunAt = CASE WHEN $4::TEXT IS NULL THEN LastRunAt ELSE clock_timestamp() END,
+		   UpdatedAt = clock_timestamp()
+		 WHERE TenantId = $1 AND ScheduleId = $2`,
+		tenantID, scheduleID, nextRunAt, lastJobID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to advance schedule: %w", err)
+	}
+	return nil
+}
+
+// DeleteSchedule removes a schedule. Jobs it already created are kept.
+func (p *PostgresStore) DeleteSchedule(ctx context.Context, tenantID, scheduleID string) error {
+	_, err := p.pool.Exec(ctx,
+		`DELETE FROM Schedules WHERE TenantId = $1 AND ScheduleId = $2`,
+		tenantID, scheduleID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete schedule: %w", pgError(err))
+	}
+	return nil
+}
+
+// TryClaimOrRenewScheduleLease claims or renews the right to fire a schedule,
+// using the same rules as TryClaimOrRenewJobLease. Returns true when the
+// caller is the owner.
+func (p *PostgresStore) TryClaimOrRenewScheduleLease(ctx context.Context, tenantID, scheduleID, workerID string, leaseUntil time.Time) (bool, error) {
+	claimed := false
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		var ownerWorkerID *string
+		var leaseExpiresAt *time.Time
+		err := tx.QueryRow(ctx,
+			`SELECT OwnerWorkerId, LeaseExpiresAt
+			 FROM Schedules WHERE TenantId = $1 AND ScheduleId = $2 FOR UPDATE`,
+			tenantID, scheduleID,
+		).Scan(&ownerWorkerID, &leaseExpiresAt)
+		if err != nil {
+			return fmt.Errorf("failed to read schedule lease state: %w", pgError(err))
+		}
+
+		now := time.Now().UTC()
+		isOwner := ownerWorkerID != nil && *ownerWorkerID == workerID
+		leaseExpired := leaseExpiresAt == nil || leaseExpiresAt.Before(now)
+		isUnowned := ownerWorkerID == nil || *ownerWorkerID == ""
+		if !(isOwner || leaseExpired || isUnowned) {
+			return nil
+		}
+
+		_, err = tx.Exec(ctx,
+			`UPDATE Schedules SET OwnerWorkerId = $3, LeaseExpiresAt = $4
+			 WHERE TenantId = $1 AND ScheduleId = $2`,
+			tenantID, scheduleID, workerID, leaseUntil,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to write lease: %w", pgError(err))
+		}
+		claimed = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to claim/renew schedule lease: %w", err)
+	}
+	return claimed, nil
+}
+
+// ── Workflows ────────────────────────────────────────────────────────────────
+
+// InsertWorkflow creates a workflow and all of its node jobs in one transaction.
+func (p *PostgresStore) InsertWorkflow(ctx context.Context, wf *Workflow, nodes []*Job) error {
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO Workflows (TenantId, WorkflowId, Name, CreatedAt, UpdatedAt)
+			 VALUES ($1, $2, $3, clock_timestamp(), clock_timestamp())`,
+			wf.TenantId, wf.WorkflowId, wf.Name,
+		)
+		if err != nil {
+			return pgError(err)
+		}
+		for _, job := range nodes {
+			if err := insertJobFull(ctx, tx, job); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert workflow: %w", err)
+	}
+	return nil
+}
+
+// GetWorkflow retrieves a workflow by tenant ID and workflow ID.
+func (p *PostgresStore) GetWorkflow(ctx context.Context, tenantID, workflowID string) (*Workflow, error) {
+	var wf Workflow
+	err := p.pool.QueryRow(ctx,
+		`SELECT `+columnList(workflowColumns)+` FROM Workflows WHERE TenantId = $1 AND WorkflowId = $2`,
+		tenantID, workflowID,
+	).Scan(&wf.TenantId, &wf.WorkflowId, &wf.Name, &wf.CreatedAt, &wf.UpdatedAt, &wf.CancelledAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow: %w", pgError(err))
+	}
+	return &wf, nil
+}
+
+// CancelWorkflow records that a workflow was cancelled.
+func (p *PostgresStore) CancelWorkflow(ctx context.Context, tenantID, workflowID string) error {
+	err := p.exec(ctx, "Workflows",
+		`UPDATE Workflows SET CancelledAt = clock_timestamp(), UpdatedAt = clock_timestamp()
+		 WHERE TenantId = $1 AND WorkflowId = $2`,
+		tenantID, workflowID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to cancel workflow: %w", err)
+	}
+	return nil
+}
+
+// ListWorkflowJobs returns the node jobs of a workflow ordered by node ID.
+func (p *PostgresStore) ListWorkflowJobs(ctx context.Context, tenantID, workflowID string) ([]*Job, error) {
+	jobs, err := queryRows(ctx, p, scanJob,
+		`SELECT `+columnList(jobColumns)+`
+		 FROM Jobs
+		 WHERE TenantId = $1 AND WorkflowId = $2
+		 ORDER BY WorkflowNodeId`,
+		tenantID, workflowID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate workflow jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// ── API keys ─────────────────────────────────────────────────────────────────
+
+// InsertApiKey creates an API key.
+func (p *PostgresStore) InsertApiKey(ctx context.Context, k *ApiKey) error {
+	_, err := p.pool.Exec(ctx,
+		`INSERT INTO ApiKeys (TenantId, ApiKeyId, Name, KeyPrefix, KeyHash, Scopes, ExpiresAt, CreatedAt)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, clock_timestamp())`,
+		k.TenantId, k.ApiKeyId, k.Name, k.KeyPrefix, k.KeyHash, k.Scopes, k.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert API key: %w", pgError(err))
+	}
+	return nil
+}
+
+// GetApiKey retrieves an API key by tenant ID and API key ID.
+func (p *PostgresStore) GetApiKey(ctx context.Context, tenantID, apiKeyID string) (*ApiKey, error) {
+	k, err := scanApiKey(p.pool.QueryRow(ctx,
+		`SELECT `+columnList(apiKeyColumns)+` FROM ApiKeys WHERE TenantId = $1 AND ApiKeyId = $2`,
+		tenantID, apiKeyID,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", pgError(err))
+	}
+	return k, nil
+}
+
+// GetApiKeyByHash retrieves the API key with the given hash across tenants.
+// Returns nil, nil when no key matches.
+func (p *PostgresStore) GetApiKeyByHash(ctx context.Context, keyHash string) (*ApiKey, error) {
+	k, err := scanApiKey(p.pool.QueryRow(ctx,
+		`SELECT `+columnList(apiKeyColumns)+` FROM ApiKeys WHERE KeyHash = $1`,
+		keyHash,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", pgError(err))
+	}
+	return k, nil
+}
+
+// ListApiKeys returns all API keys of a tenant, revoked ones included,
+// newest first.
+func (p *PostgresStore) ListApiKeys(ctx context.Context, tenantID string) ([]*ApiKey, error) {
+	keys, err := queryRows(ctx, p, scanApiKey,
+		`SELECT `+columnList(apiKeyColumns)+`
+		 FROM ApiKeys
+		 WHERE TenantId = $1
+		 ORDER BY CreatedAt DESC`,
+		tenantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeApiKey marks an API key as revoked. The row is kept so listings
+// still show the key and when it was revoked.
+func (p *PostgresStore) RevokeApiKey(ctx context.Context, tenantID, apiKeyID string) error {
+	err := p.exec(ctx, "ApiKeys",
+		`UPDATE ApiKeys SET RevokedAt = clock_timestamp() WHERE TenantId = $1 AND ApiKeyId = $2`,
+		tenantID, apiKeyID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	return nil
+}
+
+// ── Organizations ────────────────────────────────────────────────────────────
+
+// InsertOrganization creates an organization, its Tenants row and its first
+// admin in one transaction.
+func (p *PostgresStore) InsertOrganization(ctx context.Context, org *Organization, admin *OrganizationMember) error {
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO Tenants (TenantId, UserEmail, OAuthProvider, OAuthUserId, CreatedAt, UpdatedAt)
+			 VALUES ($1, $2, $3, $1, clock_timestamp(), clock_timestamp())`,
+			org.TenantId, org.CreatedBy, OrganizationProvider,
+		)
+		if err != nil {
+			return pgError(err)
+		}
+		_, err = tx.Exec(ctx,
+			`INSERT INTO Organizations (TenantId, Name, CreatedBy, CreatedAt, UpdatedAt)
+			 VALUES ($1, $2, $3, clock_timestamp(), clock_timestamp())`,
+			org.TenantId, org.Name, org.CreatedBy,
+		)
+		if err != nil {
+			return pgError(err)
+		}
+		return insertOrganizationMember(ctx, tx, admin)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert organization: %w", err)
+	}
+	return nil
+}
+
+// GetOrganization retrieves an organization by tenant ID.
+func (p *PostgresStore) GetOrganization(ctx context.Context, tenantID string) (*Organization, error) {
+	org, err := scanOrganization(p.pool.QueryRow(ctx,
+		`SELECT `+columnList(organizationColumns)+` FROM Organizations WHERE TenantId = $1`,
+		tenantID,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", pgError(err))
+	}
+	return org, nil
+}
+
+// InsertOrganizationMember adds a member to an organization.
+func (p *PostgresStore) InsertOrganizationMember(ctx context.Context, m *OrganizationMember) error {
+	if err := insertOrganizationMember(ctx, p.pool, m); err != nil {
+		return fmt.Errorf("failed to insert organization member: %w", err)
+	}
+	return nil
+}
+
+func insertOrganizationMember(ctx context.Context, db pgExecer, m *OrganizationMember) error {
+	_, err := db.Exec(ctx,
+		`INSERT INTO OrganizationMembers (TenantId, Email, Role, AddedBy, CreatedAt, UpdatedAt)
+		 VALUES ($1, $2, $3, $4, clock_timestamp(), clock_timestamp())`,
+		m.TenantId, m.Email, m.Role, m.AddedBy,
+	)
+	return pgError(err)
+}
+
+// GetOrganizationMember retrieves a member by tenant ID and email.
+func (p *PostgresStore) GetOrganizationMember(ctx context.Context, tenantID, email string) (*OrganizationMember, error) {
+	m, err := scanOrganizationMember(p.pool.QueryRow(ctx,
+		`SELECT `+columnList(organizationMemberColumns)+` FROM OrganizationMembers WHERE TenantId = $1 AND Email = $2`,
+		tenantID, email,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization member: %w", pgError(err))
+	}
+	return m, nil
+}
+
+// ListOrganizationMembers returns the members of an organization, ordered by
+// email.
+func (p *PostgresStore) ListOrganizationMembers(ctx context.Context, tenantID string) ([]*OrganizationMember, error) {
+	members, err := queryRows(ctx, p, scanOrganizationMember,
+		`SELECT `+columnList(organizationMemberColumns)+`
+		 FROM OrganizationMembers
+		 WHERE TenantId = $1
+		 ORDER BY Email`,
+		tenantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate organization members: %w", err)
+	}
+	return members, nil
+}
+
+// ListMemberships returns every organization membership of a user, oldest
+// first.
+func (p *PostgresStore) ListMemberships(ctx context.Context, email string) ([]*OrganizationMember, error) {
+	members, err := queryRows(ctx, p, scanOrganizationMember,
+		`SELECT `+columnList(organizationMemberColumns)+`
+		 FROM OrganizationMembers
+		 WHERE Email = $1
+		 ORDER BY CreatedAt`,
+		email,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate organization members: %w", err)
+	}
+	return members, nil
+}
+
+// UpdateOrganizationMemberRole changes a member's role.
+func (p *PostgresStore) UpdateOrganizationMemberRole(ctx context.Context, tenantID, email, role string) error {
+	err := p.exec(ctx, "OrganizationMembers",
+		`UPDATE OrganizationMembers SET Role = $3, UpdatedAt = clock_timestamp() WHERE TenantId = $1 AND Email = $2`,
+		tenantID, email, role,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update organization member: %w", err)
+	}
+	return nil
+}
+
+// DeleteOrganizationMember removes a member from an organization.
+func (p *PostgresStore) DeleteOrganizationMember(ctx context.Context, tenantID, email string) error {
+	_, err := p.pool.Exec(ctx,
+		`DELETE FROM OrganizationMembers WHERE TenantId = $1 AND Email = $2`,
+		tenantID, email,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete organization member: %w", pgError(err))
+	}
+	return nil
+}
+
+// ── State transitions ────────────────────────────────────────────────────────
+
+// RecordStateTransition creates a new state transition record
+func (p *PostgresStore) RecordStateTransition(ctx context.Context, tenantID, jobID, transitionID string, fromStatus *string, toStatus string, reason, workerID, providerDetail *string) error {
+	_, err := p.pool.Exec(ctx,
+		`INSERT INTO JobStateTransitions (`+columnList(transitionColumns)+`)
+		 VALUES ($1, $2, $3, $4, $5, clock_timestamp(), $6, $7, $8)`,
+		tenantID, jobID, transitionID, fromStatus, toStatus, reason, workerID, providerDetail,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record state transition: %w", pgError(err))
+	}
+	return nil
+}
+
+// GetJobTransitions retrieves all state transitions for a job
+func (p *PostgresStore) GetJobTransitions(ctx context.Context, tenantID, jobID string) ([]*JobStateTransition, error) {
+	transitions, err := queryRows(ctx, p, scanTransition,
+		`SELECT `+columnList(transitionColumns)+`
+		 FROM JobStateTransitions
+		 WHERE TenantId = $1 AND JobId = $2
+		 ORDER BY TransitionedAt DESC`,
+		tenantID, jobID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate transitions: %w", err)
+	}
+	return transitions, nil
+}
+
+// ── Workers ──────────────────────────────────────────────────────────────────
+
+// UpsertWorker registers a worker or records its heartbeat. LastHeartbeatAt
+// is set to the commit time.
+func (p *PostgresStore) UpsertWorker(ctx context.Context, w *Worker) error {
+	_, err := p.pool.Exec(ctx,
+		`INSERT INTO Workers (`+columnList(workerColumns)+`)
+		 VALUES ($1, $2, $3, $4, $5, clock_timestamp())
+		 ON CONFLICT (WorkerId) DO UPDATE SET
+		   Address = EXCLUDED.Address,
+		   Capacity = EXCLUDED.Capacity,
+		   ActiveJobs = EXCLUDED.ActiveJobs,
+		   StartedAt = EXCLUDED.StartedAt,
+		   LastHeartbeatAt = EXCLUDED.LastHeartbeatAt`,
+		w.WorkerId, w.Address, w.Capacity, w.ActiveJobs, w.StartedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert worker: %w", pgError(err))
+	}
+	return nil
+}
+
+// ListWorkers returns every registered worker ordered by WorkerId, including
+// workers whose heartbeat stopped.
+func (p *PostgresStore) ListWorkers(ctx context.Context) ([]*Worker, error) {
+	workers, err := queryRows(ctx, p, scanWorker,
+		`SELECT `+columnList(workerColumns)+` FROM Workers ORDER BY WorkerId`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate workers: %w", err)
+	}
+	return workers, nil
+}
+
+// DeleteWorker removes a worker from the registry.
+func (p *PostgresStore) DeleteWorker(ctx context.Context, workerID string) error {
+	if _, err := p.pool.Exec(ctx, `DELETE FROM Workers WHERE WorkerId = $1`, workerID); err != nil {
+		return fmt.Errorf("failed to delete worker: %w", pgError(err))
+	}
+	return nil
+}
+
+// ── Outbox ───────────────────────────────────────────────────────────────────
+
+// ChangeJobStatus sets a job's status, records the state transition and puts
+// the change's event in the outbox, all in one transaction. Terminal statuses
+// also set CompletedAt.
+func (p *PostgresStore) ChangeJobStatus(ctx context.Context, change *JobStatusChange) error {
+	var completedAt *time.Time
+	if isTerminalJobStatus(change.ToStatus) {
+		now := time.Now()
+		completedAt = &now
+	}
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx,
+			`UPDATE Jobs SET Status = $3, CompletedAt = COALESCE($4, CompletedAt),
+			   ErrorMessage = COALESCE($5, ErrorMessage), RetryCount = COALESCE($6, RetryCount),
+			   GcpBatchJobPath = COALESCE($7, GcpBatchJobPath), ServiceTier = COALESCE($8, ServiceTier),
+			   AssignedService = COALESCE($9, AssignedService), UpdatedAt = clock_timestamp()
+			 WHERE TenantId = $1 AND JobId = $2`,
+			change.TenantId, change.JobId, change.ToStatus, completedAt, change.ErrorMessage, change.RetryCount,
+			change.GcpBatchJobPath, change.ServiceTier, change.AssignedService,
+		)
+		if err != nil {
+			return pgError(err)
+		}
+		if tag.RowsAffected() == 0 {
+			return errRowNotFound("Jobs", change.TenantId, change.JobId)
+		}
+
+		_, err = tx.Exec(ctx,
+			`INSERT INTO JobStateTransitions (`+columnList(transitionColumns)+`)
+			 VALUES ($1, $2, $3, $4, $5, clock_timestamp(), $6, $7, $8)`,
+			change.TenantId, change.JobId, change.TransitionId, change.FromStatus, change.ToStatus,
+			change.Reason, change.WorkerId, change.ProviderDetail,
+		)
+		if err != nil {
+			return pgError(err)
+		}
+
+		if e := change.Event; e != nil {
+			_, err = tx.Exec(ctx,
+				`INSERT INTO Outbox (EventId, TenantId, JobId, EventType, Payload, CreatedAt, Attempts)
+				 VALUES ($1, $2, $3, $4, $5, clock_timestamp(), 0)`,
+				e.EventId, change.TenantId, change.JobId, e.EventType, e.Payload,
+			)
+			if err != nil {
+				return pgError(err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to change job status: %w", err)
+	}
+	return nil
+}
+
+// InsertOutboxEvent puts an event that comes with no status change, or with
+// one written separately, in the outbox.
+func (p *PostgresStore) InsertOutboxEvent(ctx context.Context, e *OutboxEvent) error {
+	_, err := p.pool.Exec(ctx,
+		`INSERT INTO Outbox (EventId, TenantId, JobId, EventType, Payload, CreatedAt, Attempts)
+		 VALUES ($1, $2, $3, $4, $5, clock_timestamp(), 0)`,
+		e.EventId, e.TenantId, e.JobId, e.EventType, e.Payload,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", pgError(err))
+	}
+	return nil
+}
+
+// ClaimOutboxEvents leases up to limit undelivered events to workerID until
+// leaseUntil, oldest first. Events leased to another worker are skipped until
+// their lease expires, and dead-lettered events are never claimed; FOR UPDATE
+// SKIP LOCKED keeps concurrent relays from waiting on each other.
+func (p *PostgresStore) ClaimOutboxEvents(ctx context.Context, workerID string, leaseUntil time.Time, limit int) ([]*OutboxEvent, error) {
+	events, err := queryRows(ctx, p, scanOutboxEvent,
+		`UPDATE Outbox SET OwnerWorkerId = $1, LeaseExpiresAt = $2
+		 WHERE EventId IN (
+		   SELECT EventId FROM Outbox
+		   WHERE DeliveredAt IS NULL AND DeadLetteredAt IS NULL
+		     AND (LeaseExpiresAt IS NULL OR LeaseExpiresAt < clock_timestamp())
+		   ORDER BY CreatedAt
+		   LIMIT $3
+		   FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING `+columnList(outboxColumns),
+		workerID, leaseUntil, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].CreatedAt.Before(events[j].CreatedAt) })
+	return events, nil
+}
+
+// MarkOutboxEventDelivered records that an event was published.
+func (p *PostgresStore) MarkOutboxEventDelivered(ctx context.Context, eventID string) error {
+	tag, err := p.pool.Exec(ctx,
+		`UPDATE Outbox SET DeliveredAt = clock_timestamp(), LeaseExpiresAt = NULL WHERE EventId = $1`,
+		eventID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event delivered: %w", pgError(err))
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("failed to mark outbox event delivered: %w", errRowNotFound("Outbox", eventID))
+	}
+	return nil
+}
+
+// MarkOutboxEventFailed records a failed publish. The event keeps its lease,
+// so it is retried once the lease expires.
+func (p *PostgresStore) MarkOutboxEventFailed(ctx context.Context, eventID, errorMessage string) error {
+	tag, err := p.pool.Exec(ctx,
+		`UPDATE Outbox SET Attempts = Attempts + 1, LastError = $2 WHERE EventId = $1`,
+		eventID, errorMessage,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event failed: %w", pgError(err))
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("failed to mark outbox event failed: %w", errRowNotFound("Outbox", eventID))
+	}
+	return nil
+}
+
+// DeadLetterOutboxEvent records the last failed publish of an event and stops
+// retrying it.
+func (p *PostgresStore) DeadLetterOutboxEvent(ctx context.Context, eventID, errorMessage string) error {
+	tag, err := p.pool.Exec(ctx,
+		`UPDATE Outbox SET Attempts = Attempts + 1, LastError = $2, DeadLetteredAt = clock_timestamp(), LeaseExpiresAt = NULL
+		 WHERE EventId = $1`,
+		eventID, errorMessage,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to dead-letter outbox event: %w", pgError(err))
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("failed to dead-letter outbox event: %w", errRowNotFound("Outbox", eventID))
+	}
+	return nil
+}
+
+// PruneOutboxEvents removes the events delivered or dead-lettered before
+// before and returns how many were removed.
+func (p *PostgresStore) PruneOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := p.pool.Exec(ctx,
+		`DELETE FROM Outbox
+		 WHERE (DeliveredAt IS NOT NULL AND DeliveredAt < $1)
+		    OR (DeadLetteredAt IS NOT NULL AND DeadLetteredAt < $1)`,
+		before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune outbox events: %w", pgError(err))
+	}
+	return tag.RowsAffected(), nil
+}
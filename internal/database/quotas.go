@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+)
+
+// TenantQuota overrides the gateway's default admission limits for one
+// tenant. A nil field keeps the default; a limit of 0 means unlimited.
+type TenantQuota struct {
+	TenantId            string    `spanner:"TenantId"`
+	MaxConcurrentJobs   *int64    `spanner:"MaxConcurrentJobs"`
+	MaxCpuMillis        *int64    `spanner:"MaxCpuMillis"`
+	MaxMemoryMib        *int64    `spanner:"MaxMemoryMib"`
+	MaxJobsPerDay       *int64    `spanner:"MaxJobsPerDay"`
+	AllowedMachineTypes []string  `spanner:"AllowedMachineTypes"` // Empty keeps the default
+	AllowSpotVms        *bool     `spanner:"AllowSpotVms"`
+	QueueWhenBusy       *bool     `spanner:"QueueWhenBusy"`
+	UpdatedAt           time.Time `spanner:"UpdatedAt"`
+}
+
+var tenantQuotaColumns = []string{
+	"TenantId", "MaxConcurrentJobs", "MaxCpuMillis", "MaxMemoryMib", "MaxJobsPerDay",
+	"AllowedMachineTypes", "AllowSpotVms", "QueueWhenBusy", "UpdatedAt",
+}
+
+// GetTenantQuota retrieves a tenant's quota overrides. It returns nil, nil
+// when the tenant has none.
+func (c *Client) GetTenantQuota(ctx context.Context, tenantID string) (*TenantQuota, error) {
+	row, err := c.client.Single().ReadRow(ctx, "TenantQuotas", spanner.Key{tenantID}, tenantQuotaColumns)
+	if err != nil {
+		if spanner.ErrCode(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get tenant quota: %w", err)
+	}
+	var q TenantQuota
+	if err := row.ToStruct(&q); err != nil {
+		return nil, fmt.Errorf("failed to parse tenant quota: %w", err)
+	}
+	return &q, nil
+}
+
+// UpsertTenantQuota creates or replaces a tenant's quota overrides.
+func (c *Client) UpsertTenantQuota(ctx context.Context, q *TenantQuota) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.InsertOrUpdate("TenantQuotas",
+			tenantQuotaColumns,
+			[]interface{}{
+				q.TenantId, q.MaxConcurrentJobs, q.MaxCpuMillis, q.MaxMemoryMib, q.MaxJobsPerDay,
+				q.AllowedMachineTypes, q.AllowSpotVms, q.QueueWhenBusy, spanner.CommitTimestamp,
+			},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert tenant quota: %w", err)
+	}
+	return nil
+}
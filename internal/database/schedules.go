@@ -0,0 +1,200 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+)
+
+// Schedule is a cron trigger that materializes a job from a stored
+// SubmitJobRequest template each time it fires.
+type Schedule struct {
+	TenantId        string     `spanner:"TenantId"`
+	ScheduleId      string     `spanner:"ScheduleId"`
+	Name            *string    `spanner:"Name"`
+	CronExpression  string     `spanner:"CronExpression"`
+	TimeZone        string     `spanner:"TimeZone"`
+	OverlapPolicy   string     `spanner:"OverlapPolicy"`
+	JobTemplateJson string     `spanner:"JobTemplateJson"` // protojson SubmitJobRequest
+	Paused          bool       `spanner:"Paused"`
+	NextRunAt       time.Time  `spanner:"NextRunAt"`
+	LastRunAt       *time.Time `spanner:"LastRunAt"`
+	LastJobId       *string    `spanner:"LastJobId"`
+	OwnerWorkerId   *string    `spanner:"OwnerWorkerId"`
+	LeaseExpiresAt  *time.Time `spanner:"LeaseExpiresAt"`
+	CreatedAt       time.Time  `spanner:"CreatedAt"`
+	UpdatedAt       time.Time  `spanner:"UpdatedAt"`
+}
+
+// OverlapPolicy constants decide what a schedule does when it fires while the
+// job from its previous fire is still active.
+const (
+	OverlapPolicySkip    = "SKIP"    // drop this fire
+	OverlapPolicyQueue   = "QUEUE"   // fire as soon as the previous job finishes
+	OverlapPolicyReplace = "REPLACE" // cancel the previous job, then fire
+)
+
+var scheduleColumns = []string{
+	"TenantId", "ScheduleId", "Name", "CronExpression", "TimeZone",
+	"OverlapPolicy", "JobTemplateJson", "Paused", "NextRunAt",
+	"LastRunAt", "LastJobId", "OwnerWorkerId", "LeaseExpiresAt",
+	"CreatedAt", "UpdatedAt",
+}
+
+// InsertSchedule creates a schedule.
+func (c *Client) InsertSchedule(ctx context.Context, s *Schedule) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Insert("Schedules",
+			[]string{"TenantId", "ScheduleId", "Name", "CronExpression", "TimeZone", "OverlapPolicy", "JobTemplateJson", "Paused", "NextRunAt", "CreatedAt", "UpdatedAt"},
+			[]interface{}{s.TenantId, s.ScheduleId, s.Name, s.CronExpression, s.TimeZone, s.OverlapPolicy, s.JobTemplateJson, s.Paused, s.NextRunAt, spanner.CommitTimestamp, spanner.CommitTimestamp},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert schedule: %w", err)
+	}
+	return nil
+}
+
+// GetSchedule retrieves a schedule by tenant ID and schedule ID.
+func (c *Client) GetSchedule(ctx context.Context, tenantID, scheduleID string) (*Schedule, error) {
+	row, err := c.client.Single().ReadRow(ctx, "Schedules", spanner.Key{tenantID, scheduleID}, scheduleColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+	var s Schedule
+	if err := row.ToStruct(&s); err != nil {
+		return nil, fmt.Errorf("failed to parse schedule: %w", err)
+	}
+	return &s, nil
+}
+
+// ListSchedules returns all schedules for a tenant, newest first.
+func (c *Client) ListSchedules(ctx context.Context, tenantID string) ([]*Schedule, error) {
+	return c.querySchedules(ctx, spanner.Statement{
+		SQL: `SELECT ` + columnList(scheduleColumns) + `
+		      FROM Schedules
+		      WHERE TenantId = @tenantId
+		      ORDER BY CreatedAt DESC`,
+		Params: map[string]interface{}{"tenantId": tenantID},
+	})
+}
+
+// ListDueSchedules returns unpaused schedules across tenants whose NextRunAt
+// is at or before now, oldest first.
+func (c *Client) ListDueSchedules(ctx context.Context, now time.Time) ([]*Schedule, error) {
+	return c.querySchedules(ctx, spanner.Statement{
+		SQL: `SELECT ` + columnList(scheduleColumns) + `
+		      FROM Schedules
+		      WHERE Paused = FALSE AND NextRunAt <= @now
+		      ORDER BY NextRunAt`,
+		Params: map[string]interface{}{"now": now},
+	})
+}
+
+func (c *Client) querySchedules(ctx context.Context, stmt spanner.Statement) ([]*Schedule, error) {
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var schedules []*Schedule
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate schedules: %w", err)
+		}
+		var s Schedule
+		if err := row.ToStruct(&s); err != nil {
+			return nil, fmt.Errorf("failed to parse schedule: %w", err)
+		}
+		schedules = append(schedules, &s)
+	}
+	return schedules, nil
+}
+
+// SetSchedulePaused pauses or resumes a schedule. nextRunAt is stored as the
+// next fire time, so a resumed schedule does not replay fires it missed.
+func (c *Client) SetSchedulePaused(ctx context.Context, tenantID, scheduleID string, paused bool, nextRunAt time.Time) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Update("Schedules",
+			[]string{"TenantId", "ScheduleId", "Paused", "NextRunAt", "UpdatedAt"},
+			[]interface{}{tenantID, scheduleID, paused, nextRunAt, spanner.CommitTimestamp},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update schedule: %w", err)
+	}
+	return nil
+}
+
+// AdvanceSchedule moves a schedule to its next fire time. When lastJobID is
+// non-nil the fire produced a job, which is recorded as the latest run.
+func (c *Client) AdvanceSchedule(ctx context.Context, tenantID, scheduleID string, nextRunAt time.Time, lastJobID *string) error {
+	cols := []string{"TenantId", "ScheduleId", "NextRunAt", "UpdatedAt"}
+	vals := []interface{}{tenantID, scheduleID, nextRunAt, spanner.CommitTimestamp}
+	if lastJobID != nil {
+		cols = append(cols, "LastJobId", "LastRunAt")
+		vals = append(vals, *lastJobID, spanner.CommitTimestamp)
+	}
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{spanner.Update("Schedules", cols, vals)})
+	if err != nil {
+		return fmt.Errorf("failed to advance schedule: %w", err)
+	}
+	return nil
+}
+
+// DeleteSchedule removes a schedule. Jobs it already created are kept.
+func (c *Client) DeleteSchedule(ctx context.Context, tenantID, scheduleID string) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Delete("Schedules", spanner.Key{tenantID, scheduleID}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete schedule: %w", err)
+	}
+	return nil
+}
+
+// TryClaimOrRenewScheduleLease claims or renews the right to fire a schedule,
+// using the same rules as TryClaimOrRenewJobLease. Returns true when the
+// caller is the owner.
+func (c *Client) TryClaimOrRenewScheduleLease(ctx context.Context, tenantID, scheduleID, workerID string, leaseUntil time.Time) (bool, error) {
+	claimed := false
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		row, err := txn.ReadRow(ctx, "Schedules", spanner.Key{tenantID, scheduleID}, []string{"OwnerWorkerId", "LeaseExpiresAt"})
+		if err != nil {
+			return fmt.Errorf("failed to read schedule lease state: %w", err)
+		}
+
+		var ownerWorkerID spanner.NullString
+		var leaseExpiresAt spanner.NullTime
+		if err := row.Columns(&ownerWorkerID, &leaseExpiresAt); err != nil {
+			return fmt.Errorf("failed to parse schedule lease state: %w", err)
+		}
+
+		now := time.Now().UTC()
+		isOwner := ownerWorkerID.Valid && ownerWorkerID.StringVal == workerID
+		leaseExpired := !leaseExpiresAt.Valid || leaseExpiresAt.Time.Before(now)
+		isUnowned := !ownerWorkerID.Valid || ownerWorkerID.StringVal == ""
+		if !(isOwner || leaseExpired || isUnowned) {
+			return nil
+		}
+
+		mutation := spanner.Update("Schedules",
+			[]string{"TenantId", "ScheduleId", "OwnerWorkerId", "LeaseExpiresAt"},
+			[]interface{}{tenantID, scheduleID, workerID, leaseUntil},
+		)
+		if err := txn.BufferWrite([]*spanner.Mutation{mutation}); err != nil {
+			return fmt.Errorf("failed to buffer lease mutation: %w", err)
+		}
+		claimed = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to claim/renew schedule lease: %w", err)
+	}
+	return claimed, nil
+}
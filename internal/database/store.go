@@ -0,0 +1,147 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alphauslabs/jennah/internal/config"
+)
+
+// Store is the persistence contract shared by the gateway, worker and consumer.
+// The Spanner-backed Client is the production implementation; PostgresStore
+// serves self-hosted deployments and MemoryStore mirrors the same semantics
+// for local runs and tests.
+type Store interface {
+	// ── Jobs ──────────────────────────────────────────────────────────────────
+
+	InsertJob(ctx context.Context, tenantID, jobID, imageUri string, commands []string) error
+	InsertJobFull(ctx context.Context, job *Job) error
+	GetJob(ctx context.Context, tenantID, jobID string) (*Job, error)
+	ListJobs(ctx context.Context, tenantID string) ([]*Job, error)
+	ListJobsByStatus(ctx context.Context, tenantID, status string) ([]*Job, error)
+	ListJobsFiltered(ctx context.Context, tenantID string, filter JobFilter) ([]*Job, error)
+	UpdateJobStatus(ctx context.Context, tenantID, jobID, status string) error
+	UpdateJobStatusAndGcpBatchJobPath(ctx context.Context, tenantID, jobID, status, gcpBatchJobPath, serviceTier, assignedService string) error
+	CompleteJob(ctx context.Context, tenantID, jobID string) error
+	FailJob(ctx context.Context, tenantID, jobID, errorMessage string) error
+	RetryJob(ctx context.Context, tenantID, jobID string, retryCount int64, errorMessage string) error
+	ScheduleJob(ctx context.Context, tenantID, jobID string) error
+	StartJob(ctx context.Context, tenantID, jobID string) error
+	CancelJob(ctx context.Context, tenantID, jobID string) error
+	DeleteJob(ctx context.Context, tenantID, jobID string) error
+	ListActiveJobs(ctx context.Context) ([]*Job, error)
+	ListQueuedJobs(ctx context.Context) ([]*Job, error)
+	CountJobsSince(ctx context.Context, tenantID string, since time.Time) (int64, error)
+	GetJobByIdempotencyKey(ctx context.Context, tenantID, idempotencyKey string) (*Job, error)
+	SetJobSubmitResponse(ctx context.Context, tenantID, jobID, responseJson string) error
+	ClearJobIdempotencyKey(ctx context.Context, tenantID, jobID string) error
+	TryClaimOrRenewJobLease(ctx context.Context, tenantID, jobID, workerID string, leaseUntil time.Time) (bool, error)
+	ReleaseJobLease(ctx context.Context, tenantID, jobID, workerID, preferredWorkerID string) (bool, error)
+
+	// ── Tenants ───────────────────────────────────────────────────────────────
+
+	UpsertTenant(ctx context.Context, tenantID, userEmail, oauthProvider, oauthUserId string) error
+	InsertTenant(ctx context.Context, tenantID, userEmail, oauthProvider, oauthUserId string) error
+	GetTenant(ctx context.Context, tenantID string) (*Tenant, error)
+	ListTenants(ctx context.Context) ([]*Tenant, error)
+	GetTenantByOAuth(ctx context.Context, oauthProvider, oauthUserId string) (*Tenant, error)
+	DeleteTenant(ctx context.Context, tenantID string) error
+
+	// ── Quotas ────────────────────────────────────────────────────────────────
+
+	GetTenantQuota(ctx context.Context, tenantID string) (*TenantQuota, error)
+	UpsertTenantQuota(ctx context.Context, q *TenantQuota) error
+
+	// ── Notifications ─────────────────────────────────────────────────────────
+
+	InsertNotification(ctx context.Context, n *Notification) error
+	ListNotifications(ctx context.Context, tenantID string, limit int32) ([]*Notification, error)
+	ListNotificationsSince(ctx context.Context, tenantID string, since time.Time, limit int32) ([]*Notification, error)
+	CountUnread(ctx context.Context, tenantID string) (int32, error)
+	AckNotification(ctx context.Context, tenantID, notificationID string) error
+
+	// ── Schedules ─────────────────────────────────────────────────────────────
+
+	InsertSchedule(ctx context.Context, s *Schedule) error
+	GetSchedule(ctx context.Context, tenantID, scheduleID string) (*Schedule, error)
+	ListSchedules(ctx context.Context, tenantID string) ([]*Schedule, error)
+	ListDueSchedules(ctx context.Context, now time.Time) ([]*Schedule, error)
+	SetSchedulePaused(ctx context.Context, tenantID, scheduleID string, paused bool, nextRunAt time.Time) error
+	AdvanceSchedule(ctx context.Context, tenantID, scheduleID string, nextRunAt time.Time, lastJobID *string) error
+	DeleteSchedule(ctx context.Context, tenantID, scheduleID string) error
+	TryClaimOrRenewScheduleLease(ctx context.Context, tenantID, scheduleID, workerID string, leaseUntil time.Time) (bool, error)
+
+	// ── Workflows ─────────────────────────────────────────────────────────────
+
+	InsertWorkflow(ctx context.Context, wf *Workflow, nodes []*Job) error
+	GetWorkflow(ctx context.Context, tenantID, workflowID string) (*Workflow, error)
+	CancelWorkflow(ctx context.Context, tenantID, workflowID string) error
+	ListWorkflowJobs(ctx context.Context, tenantID, workflowID string) ([]*Job, error)
+
+	// ── API keys ──────────────────────────────────────────────────────────────
+
+	InsertApiKey(ctx context.Context, k *ApiKey) error
+	GetApiKey(ctx context.Context, tenantID, apiKeyID string) (*ApiKey, error)
+	GetApiKeyByHash(ctx context.Context, keyHash string) (*ApiKey, error)
+	ListApiKeys(ctx context.Context, tenantID string) ([]*ApiKey, error)
+	RevokeApiKey(ctx context.Context, tenantID, apiKeyID string) error
+
+	// ── Organizations ─────────────────────────────────────────────────────────
+
+	InsertOrganization(ctx context.Context, org *Organization, admin *OrganizationMember) error
+	GetOrganization(ctx context.Context, tenantID string) (*Organization, error)
+	InsertOrganizationMember(ctx context.Context, m *OrganizationMember) error
+	GetOrganizationMember(ctx context.Context, tenantID, email string) (*OrganizationMember, error)
+	ListOrganizationMembers(ctx context.Context, tenantID string) ([]*OrganizationMember, error)
+	ListMemberships(ctx context.Context, email string) ([]*OrganizationMember, error)
+	UpdateOrganizationMemberRole(ctx context.Context, tenantID, email, role string) error
+	DeleteOrganizationMember(ctx context.Context, tenantID, email string) error
+
+	// ── State transitions ─────────────────────────────────────────────────────
+
+	RecordStateTransition(ctx context.Context, tenantID, jobID, transitionID string, fromStatus *string, toStatus string, reason, workerID, providerDetail *string) error
+	GetJobTransitions(ctx context.Context, tenantID, jobID string) ([]*JobStateTransition, error)
+
+	// ── Workers ───────────────────────────────────────────────────────────────
+
+	UpsertWorker(ctx context.Context, w *Worker) error
+	ListWorkers(ctx context.Context) ([]*Worker, error)
+	DeleteWorker(ctx context.Context, workerID string) error
+
+	// ── Outbox ────────────────────────────────────────────────────────────────
+
+	ChangeJobStatus(ctx context.Context, change *JobStatusChange) error
+	InsertOutboxEvent(ctx context.Context, e *OutboxEvent) error
+	ClaimOutboxEvents(ctx context.Context, workerID string, leaseUntil time.Time, limit int) ([]*OutboxEvent, error)
+	MarkOutboxEventDelivered(ctx context.Context, eventID string) error
+	MarkOutboxEventFailed(ctx context.Context, eventID, errorMessage string) error
+	DeadLetterOutboxEvent(ctx context.Context, eventID, errorMessage string) error
+	PruneOutboxEvents(ctx context.Context, before time.Time) (int64, error)
+
+	// Close releases any resources held by the store.
+	Close()
+}
+
+var (
+	_ Store = (*Client)(nil)
+	_ Store = (*MemoryStore)(nil)
+	_ Store = (*PostgresStore)(nil)
+)
+
+// NewStore opens the Store selected by cfg.Provider.
+//   - "spanner": Cloud Spanner (ProjectID, Instance, Database)
+//   - "postgres": PostgreSQL (ProviderOptions["endpoint"], Database)
+//   - "memory":  process-local MemoryStore; data is lost on exit
+func NewStore(ctx context.Context, cfg config.DatabaseConfig) (Store, error) {
+	switch cfg.Provider {
+	case "", "spanner":
+		return NewClient(ctx, cfg.ProjectID, cfg.Instance, cfg.Database)
+	case "postgres":
+		return NewPostgresStore(ctx, postgresDSN(cfg.ProviderOptions["endpoint"], cfg.Database))
+	case "memory":
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unsupported database provider: %s", cfg.Provider)
+	}
+}
@@ -8,12 +8,14 @@ import (
 	"google.golang.org/api/iterator"
 )
 
-// RecordStateTransition creates a new state transition record
-func (c *Client) RecordStateTransition(ctx context.Context, tenantID, jobID, transitionID string, fromStatus *string, toStatus string, reason *string) error {
+// RecordStateTransition creates a new state transition record. workerID is
+// the worker that observed the change and providerDetail what the provider
+// reported with it; both may be nil.
+func (c *Client) RecordStateTransition(ctx context.Context, tenantID, jobID, transitionID string, fromStatus *string, toStatus string, reason, workerID, providerDetail *string) error {
 	_, err := c.client.Apply(ctx, []*spanner.Mutation{
 		spanner.Insert("JobStateTransitions",
-			[]string{"TenantId", "JobId", "TransitionId", "FromStatus", "ToStatus", "TransitionedAt", "Reason"},
-			[]interface{}{tenantID, jobID, transitionID, fromStatus, toStatus, spanner.CommitTimestamp, reason},
+			[]string{"TenantId", "JobId", "TransitionId", "FromStatus", "ToStatus", "TransitionedAt", "Reason", "WorkerId", "ProviderDetail"},
+			[]interface{}{tenantID, jobID, transitionID, fromStatus, toStatus, spanner.CommitTimestamp, reason, workerID, providerDetail},
 		),
 	})
 	if err != nil {
@@ -25,7 +27,7 @@ func (c *Client) RecordStateTransition(ctx context.Context, tenantID, jobID, tra
 // GetJobTransitions retrieves all state transitions for a job
 func (c *Client) GetJobTransitions(ctx context.Context, tenantID, jobID string) ([]*JobStateTransition, error) {
 	stmt := spanner.Statement{
-		SQL: `SELECT TenantId, JobId, TransitionId, FromStatus, ToStatus, TransitionedAt, Reason 
+		SQL: `SELECT TenantId, JobId, TransitionId, FromStatus, ToStatus, TransitionedAt, Reason, WorkerId, ProviderDetail
 		      FROM JobStateTransitions 
 		      WHERE TenantId = @tenantId AND JobId = @jobId 
 		      ORDER BY TransitionedAt DESC`,
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+)
+
+// Worker is a worker's entry in the worker registry. Workers upsert it on
+// every heartbeat; the gateway routes to the workers whose heartbeat is
+// recent.
+type Worker struct {
+	WorkerId        string    `spanner:"WorkerId"`
+	Address         string    `spanner:"Address"`    // host:port the gateway calls
+	Capacity        int64     `spanner:"Capacity"`   // active jobs the worker takes on; 0 is unlimited
+	ActiveJobs      int64     `spanner:"ActiveJobs"` // active jobs the worker held at the heartbeat
+	StartedAt       time.Time `spanner:"StartedAt"`
+	LastHeartbeatAt time.Time `spanner:"LastHeartbeatAt"`
+}
+
+var workerColumns = []string{"WorkerId", "Address", "Capacity", "ActiveJobs", "StartedAt", "LastHeartbeatAt"}
+
+// UpsertWorker registers a worker or records its heartbeat. LastHeartbeatAt
+// is set to the commit time.
+func (c *Client) UpsertWorker(ctx context.Context, w *Worker) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.InsertOrUpdate("Workers",
+			workerColumns,
+			[]interface{}{w.WorkerId, w.Address, w.Capacity, w.ActiveJobs, w.StartedAt, spanner.CommitTimestamp},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert worker: %w", err)
+	}
+	return nil
+}
+
+// ListWorkers returns every registered worker ordered by WorkerId, including
+// workers whose heartbeat stopped.
+func (c *Client) ListWorkers(ctx context.Context) ([]*Worker, error) {
+	iter := c.client.Single().Query(ctx, spanner.Statement{
+		SQL: `SELECT WorkerId, Address, Capacity, ActiveJobs, StartedAt, LastHeartbeatAt
+		      FROM Workers
+		      ORDER BY WorkerId`,
+	})
+	defer iter.Stop()
+
+	var workers []*Worker
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate workers: %w", err)
+		}
+		var w Worker
+		if err := row.ToStruct(&w); err != nil {
+			return nil, fmt.Errorf("failed to parse worker: %w", err)
+		}
+		workers = append(workers, &w)
+	}
+	return workers, nil
+}
+
+// DeleteWorker removes a worker from the registry.
+func (c *Client) DeleteWorker(ctx context.Context, workerID string) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Delete("Workers", spanner.Key{workerID}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete worker: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+)
+
+// Workflow groups the jobs of a DAG submitted together. Its nodes are
+// ordinary Jobs rows carrying WorkflowId, WorkflowNodeId and DependsOnJson;
+// the workflow's status is derived from them (see WorkflowStatus).
+type Workflow struct {
+	TenantId    string     `spanner:"TenantId"`
+	WorkflowId  string     `spanner:"WorkflowId"`
+	Name        *string    `spanner:"Name"`
+	CreatedAt   time.Time  `spanner:"CreatedAt"`
+	UpdatedAt   time.Time  `spanner:"UpdatedAt"`
+	CancelledAt *time.Time `spanner:"CancelledAt"` // nil: not cancelled
+}
+
+// WorkflowDependency is one depends_on edge of a workflow node.
+type WorkflowDependency struct {
+	NodeId    string `json:"node_id"`
+	Condition string `json:"condition"`
+}
+
+// Dependency conditions decide which terminal parent statuses release a node.
+const (
+	DependencyOnSuccess = "ON_SUCCESS" // parent COMPLETED
+	DependencyOnFailure = "ON_FAILURE" // parent FAILED
+	DependencyAlways    = "ALWAYS"     // parent reached any terminal status
+)
+
+var workflowColumns = []string{"TenantId", "WorkflowId", "Name", "CreatedAt", "UpdatedAt", "CancelledAt"}
+
+// JobDependencies decodes a workflow node's depends_on edges. Jobs outside a
+// workflow have none.
+func JobDependencies(job *Job) ([]WorkflowDependency, error) {
+	if job.DependsOnJson == nil || *job.DependsOnJson == "" {
+		return nil, nil
+	}
+	var deps []WorkflowDependency
+	if err := json.Unmarshal([]byte(*job.DependsOnJson), &deps); err != nil {
+		return nil, fmt.Errorf("failed to parse dependencies of job %s: %w", job.JobId, err)
+	}
+	return deps, nil
+}
+
+// WorkflowStatus derives a workflow's status from its nodes: RUNNING while any
+// node is not terminal, then FAILED if any node failed, CANCELLED if any was
+// cancelled, otherwise COMPLETED. SKIPPED nodes do not affect the outcome.
+func WorkflowStatus(nodes []*Job) string {
+	failed, cancelled := false, false
+	for _, n := range nodes {
+		switch n.Status {
+		case JobStatusCompleted, JobStatusSkipped:
+		case JobStatusFailed:
+			failed = true
+		case JobStatusCancelled:
+			cancelled = true
+		default:
+			return JobStatusRunning
+		}
+	}
+	switch {
+	case failed:
+		return JobStatusFailed
+	case cancelled:
+		return JobStatusCancelled
+	default:
+		return JobStatusCompleted
+	}
+}
+
+// InsertWorkflow creates a workflow and all of its node jobs in one commit.
+func (c *Client) InsertWorkflow(ctx context.Context, wf *Workflow, nodes []*Job) error {
+	mutations := []*spanner.Mutation{
+		spanner.Insert("Workflows",
+			[]string{"TenantId", "WorkflowId", "Name", "CreatedAt", "UpdatedAt"},
+			[]interface{}{wf.TenantId, wf.WorkflowId, wf.Name, spanner.CommitTimestamp, spanner.CommitTimestamp},
+		),
+	}
+	for _, job := range nodes {
+		mutations = append(mutations, insertJobMutation(job))
+	}
+	if _, err := c.client.Apply(ctx, mutations); err != nil {
+		return fmt.Errorf("failed to insert workflow: %w", err)
+	}
+	return nil
+}
+
+// GetWorkflow retrieves a workflow by tenant ID and workflow ID.
+func (c *Client) GetWorkflow(ctx context.Context, tenantID, workflowID string) (*Workflow, error) {
+	row, err := c.client.Single().ReadRow(ctx, "Workflows", spanner.Key{tenantID, workflowID}, workflowColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+	var wf Workflow
+	if err := row.ToStruct(&wf); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+	return &wf, nil
+}
+
+// CancelWorkflow records that a workflow was cancelled. No node of a
+// cancelled workflow is started afterwards.
+func (c *Client) CancelWorkflow(ctx context.Context, tenantID, workflowID string) error {
+	_, err := c.client.Apply(ctx, []*spanner.Mutation{
+		spanner.Update("Workflows",
+			[]string{"TenantId", "WorkflowId", "CancelledAt", "UpdatedAt"},
+			[]interface{}{tenantID, workflowID, spanner.CommitTimestamp, spanner.CommitTimestamp},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to cancel workflow: %w", err)
+	}
+	return nil
+}
+
+// ListWorkflowJobs returns the node jobs of a workflow ordered by node ID.
+func (c *Client) ListWorkflowJobs(ctx context.Context, tenantID, workflowID string) ([]*Job, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + columnList(jobColumns) + `
+		      FROM Jobs@{FORCE_INDEX=JobsByWorkflow}
+		      WHERE TenantId = @tenantId AND WorkflowId = @workflowId
+		      ORDER BY WorkflowNodeId`,
+		Params: map[string]interface{}{
+			"tenantId":   tenantID,
+			"workflowId": workflowID,
+		},
+	}
+
+	iter := c.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var jobs []*Job
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate workflow jobs: %w", err)
+		}
+		var job Job
+		if err := row.ToStruct(&job); err != nil {
+			return nil, fmt.Errorf("failed to parse job: %w", err)
+		}
+		jobs = append(jobs, &job)
+	}
+	return jobs, nil
+}
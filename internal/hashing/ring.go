@@ -3,6 +3,8 @@ package hashing
 import (
 	"crypto/sha256"
 	"encoding/binary"
+	"slices"
+	"sync"
 
 	"github.com/buraksezer/consistent"
 )
@@ -22,6 +24,7 @@ func (h hasher) Sum64(data []byte) uint64 {
 }
 
 type Router struct {
+	mu   sync.Mutex // serializes membership changes
 	ring *consistent.Consistent
 }
 
@@ -51,3 +54,55 @@ func (r *Router) GetWorkerIP(key string) string {
 	}
 	return member.String()
 }
+
+// GetWorkerIPs returns up to n workers for key: the one GetWorkerIP returns,
+// then the next members on the ring to fall back on when it is unavailable.
+func (r *Router) GetWorkerIPs(key string, n int) []string {
+	n = min(n, len(r.ring.GetMembers()))
+	if n <= 0 {
+		return nil
+	}
+	members, err := r.ring.GetClosestN([]byte(key), n)
+	if err != nil {
+		// Membership shrank since it was counted.
+		if ip := r.GetWorkerIP(key); ip != "" {
+			return []string{ip}
+		}
+		return nil
+	}
+	ips := make([]string, len(members))
+	for i, m := range members {
+		ips[i] = m.String()
+	}
+	return ips
+}
+
+// Members returns the workers on the ring, sorted.
+func (r *Router) Members() []string {
+	var ips []string
+	for _, m := range r.ring.GetMembers() {
+		ips = append(ips, m.String())
+	}
+	slices.Sort(ips)
+	return ips
+}
+
+// SetMembers makes members the workers on the ring. Only the difference is
+// added and removed, so keys keep their worker unless it left or a new
+// worker takes them over.
+func (r *Router) SetMembers(members []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.Members()
+	for _, ip := range current {
+		if !slices.Contains(members, ip) {
+			r.ring.Remove(ip)
+		}
+	}
+	for _, ip := range members {
+		if !slices.Contains(current, ip) {
+			r.ring.Add(Member(ip))
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package hashing
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestRouterSetMembers(t *testing.T) {
+	r := NewRouter([]string{"10.0.0.1", "10.0.0.2", "10.0.0.3"})
+
+	before := make(map[string]string)
+	for i := range 200 {
+		key := fmt.Sprintf("job-%d", i)
+		before[key] = r.GetWorkerIP(key)
+	}
+
+	r.SetMembers([]string{"10.0.0.1", "10.0.0.3", "10.0.0.4"})
+	if got := r.Members(); !slices.Equal(got, []string{"10.0.0.1", "10.0.0.3", "10.0.0.4"}) {
+		t.Fatalf("Members() = %v", got)
+	}
+	moved := 0
+	for key, ip := range before {
+		now := r.GetWorkerIP(key)
+		if now == "10.0.0.2" {
+			t.Fatalf("key %s still routed to the removed worker", key)
+		}
+		if ip != "10.0.0.2" && now != ip {
+			moved++
+		}
+	}
+	if moved > len(before)/2 {
+		t.Errorf("%d of %d keys of remaining workers moved, want most to stay", moved, len(before))
+	}
+
+	r.SetMembers(nil)
+	if ip := r.GetWorkerIP("job-1"); ip != "" || r.GetWorkerIPs("job-1", 3) != nil {
+		t.Fatalf("empty ring routed job-1 to %q", ip)
+	}
+}
+
+func TestRouterGetWorkerIPs(t *testing.T) {
+	r := NewRouter([]string{"10.0.0.1", "10.0.0.2", "10.0.0.3"})
+
+	ips := r.GetWorkerIPs("job-1", 5)
+	if len(ips) != 3 || ips[0] != r.GetWorkerIP("job-1") {
+		t.Fatalf("GetWorkerIPs = %v, want all three workers starting with the owner %s", ips, r.GetWorkerIP("job-1"))
+	}
+	slices.Sort(ips)
+	if !slices.Equal(ips, r.Members()) {
+		t.Fatalf("GetWorkerIPs returned duplicates: %v", ips)
+	}
+	if got := r.GetWorkerIPs("job-1", 2); len(got) != 2 {
+		t.Fatalf("GetWorkerIPs(n=2) = %v", got)
+	}
+}
@@ -0,0 +1,201 @@
+// Package migrate applies the versioned schema migrations in
+// database/migrations and records them in the SchemaMigrations table.
+//
+// Each migration file is named NNNN_description.sql. Statements are split on
+// semicolons and classified as DDL or DML, because Spanner runs the two
+// through different APIs: consecutive DDL statements become one schema update
+// and consecutive DML statements run in one read-write transaction.
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kind says which Spanner API executes a statement.
+type Kind int
+
+const (
+	DDL Kind = iota
+	DML
+)
+
+func (k Kind) String() string {
+	if k == DML {
+		return "DML"
+	}
+	return "DDL"
+}
+
+// Batch is a run of consecutive statements of the same kind.
+type Batch struct {
+	Kind       Kind
+	Statements []string
+}
+
+// Migration is one versioned migration file.
+type Migration struct {
+	Version  int64
+	Name     string
+	Checksum string // sha256 of the file contents
+	Batches  []Batch
+}
+
+// Label renders the migration as "0002_advanced_config".
+func (m *Migration) Label() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// Load reads every NNNN_name.sql file in fsys, sorted by version.
+func Load(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var migrations []*Migration
+	seen := make(map[int64]string)
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != ".sql" {
+			continue
+		}
+		version, name, err := parseFilename(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		if prev, ok := seen[version]; ok {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, prev, e.Name())
+		}
+		seen[version] = e.Name()
+
+		content, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", e.Name(), err)
+		}
+		m, err := Parse(version, name, string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		migrations = append(migrations, m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+func parseFilename(filename string) (int64, string, error) {
+	base := strings.TrimSuffix(filename, ".sql")
+	prefix, name, ok := strings.Cut(base, "_")
+	version, err := strconv.ParseInt(prefix, 10, 64)
+	if !ok || err != nil || version <= 0 || name == "" {
+		return 0, "", fmt.Errorf("invalid migration filename %q: want NNNN_description.sql", filename)
+	}
+	return version, name, nil
+}
+
+// Parse splits a migration file into DDL and DML batches.
+func Parse(version int64, name, content string) (*Migration, error) {
+	sum := sha256.Sum256([]byte(content))
+	m := &Migration{
+		Version:  version,
+		Name:     name,
+		Checksum: hex.EncodeToString(sum[:]),
+	}
+
+	for _, stmt := range SplitStatements(content) {
+		kind := classify(stmt)
+		if n := len(m.Batches); n > 0 && m.Batches[n-1].Kind == kind {
+			m.Batches[n-1].Statements = append(m.Batches[n-1].Statements, stmt)
+			continue
+		}
+		m.Batches = append(m.Batches, Batch{Kind: kind, Statements: []string{stmt}})
+	}
+	if len(m.Batches) == 0 {
+		return nil, fmt.Errorf("migration has no statements")
+	}
+	return m, nil
+}
+
+// classify reports DML for INSERT/UPDATE/DELETE and DDL for everything else.
+func classify(stmt string) Kind {
+	fields := strings.Fields(stmt)
+	if len(fields) == 0 {
+		return DDL
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "INSERT", "UPDATE", "DELETE":
+		return DML
+	default:
+		return DDL
+	}
+}
+
+// SplitStatements splits SQL on top-level semicolons, dropping comments and
+// empty statements. Quoted strings and identifiers are kept intact, including
+// any semicolons or comment markers inside them.
+func SplitStatements(sql string) []string {
+	var (
+		stmts []string
+		cur   strings.Builder
+	)
+	flush := func() {
+		// Drop the blank lines and trailing spaces left behind by comments.
+		var lines []string
+		for _, line := range strings.Split(cur.String(), "\n") {
+			if line = strings.TrimRight(line, " \t\r"); strings.TrimSpace(line) != "" {
+				lines = append(lines, line)
+			}
+		}
+		if s := strings.TrimSpace(strings.Join(lines, "\n")); s != "" {
+			stmts = append(stmts, s)
+		}
+		cur.Reset()
+	}
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '-' && i+1 < len(sql) && sql[i+1] == '-', c == '#':
+			// Line comment: skip to end of line, keep the newline.
+			for i < len(sql) && sql[i] != '\n' {
+				i++
+			}
+			if i < len(sql) {
+				cur.WriteByte('\n')
+			}
+		case c == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end + 3
+			}
+			cur.WriteByte(' ')
+		case c == '\'' || c == '"' || c == '`':
+			j := i + 1
+			for j < len(sql) && sql[j] != c {
+				if sql[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(sql) {
+				j = len(sql) - 1
+			}
+			cur.WriteString(sql[i : j+1])
+			i = j
+		case c == ';':
+			flush()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	flush()
+	return stmts
+}
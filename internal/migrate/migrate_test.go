@@ -0,0 +1,251 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/alphauslabs/jennah/database/migrations"
+)
+
+func TestSplitStatements(t *testing.T) {
+	sql := `-- header comment; with a semicolon
+ALTER TABLE Jobs ADD COLUMN A STRING(10); -- trailing
+/* block; comment */
+UPDATE Jobs SET A = 'x;y -- not a comment' WHERE B = "q;";
+# hash comment
+CREATE TABLE T (
+  Id INT64 NOT NULL, -- column comment
+) PRIMARY KEY (Id);
+;`
+	got := SplitStatements(sql)
+	want := []string{
+		"ALTER TABLE Jobs ADD COLUMN A STRING(10)",
+		`UPDATE Jobs SET A = 'x;y -- not a comment' WHERE B = "q;"`,
+		"CREATE TABLE T (\n  Id INT64 NOT NULL,\n) PRIMARY KEY (Id)",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d statements %q, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("statement %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseBatches(t *testing.T) {
+	m, err := Parse(7, "mixed", `
+ALTER TABLE Jobs ADD COLUMN A INT64;
+ALTER TABLE Jobs ADD COLUMN B INT64;
+update Jobs SET A = 1 WHERE TRUE;
+DELETE FROM Jobs WHERE A = 2;
+ALTER TABLE Jobs DROP COLUMN C;`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kinds := []Kind{DDL, DML, DDL}
+	sizes := []int{2, 2, 1}
+	if len(m.Batches) != len(kinds) {
+		t.Fatalf("got %d batches, want %d", len(m.Batches), len(kinds))
+	}
+	for i, b := range m.Batches {
+		if b.Kind != kinds[i] || len(b.Statements) != sizes[i] {
+			t.Errorf("batch %d = %s x%d, want %s x%d", i, b.Kind, len(b.Statements), kinds[i], sizes[i])
+		}
+	}
+	if m.Label() != "0007_mixed" {
+		t.Errorf("Label() = %q", m.Label())
+	}
+
+	if _, err := Parse(8, "empty", "-- nothing here\n"); err == nil {
+		t.Error("expected an error for a migration with no statements")
+	}
+}
+
+func TestClassify(t *testing.T) {
+	cases := map[string]Kind{
+		"UPDATE Jobs SET A = 1 WHERE TRUE":     DML,
+		"UPDATE\n  Jobs SET A = 1 WHERE TRUE":  DML,
+		"DELETE\tFROM Jobs WHERE A = 2":        DML,
+		"  insert\r\nINTO Jobs (A) VALUES (1)": DML,
+		"ALTER TABLE Jobs ADD COLUMN A INT64":  DDL,
+		"CREATE\nINDEX JobsByA ON Jobs(A)":     DDL,
+	}
+	for stmt, want := range cases {
+		if got := classify(stmt); got != want {
+			t.Errorf("classify(%q) = %s, want %s", stmt, got, want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_second.sql": {Data: []byte("ALTER TABLE Jobs ADD COLUMN B INT64;")},
+		"0001_first.sql":  {Data: []byte("CREATE TABLE T (Id INT64) PRIMARY KEY (Id);")},
+		"README.md":       {Data: []byte("ignored")},
+	}
+	ms, err := Load(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ms) != 2 || ms[0].Label() != "0001_first" || ms[1].Label() != "0002_second" {
+		t.Fatalf("unexpected migrations: %v, %v", ms[0].Label(), ms[1].Label())
+	}
+
+	fsys["0002_dupe.sql"] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+	if _, err := Load(fsys); err == nil || !strings.Contains(err.Error(), "duplicate") {
+		t.Errorf("expected duplicate version error, got %v", err)
+	}
+
+	if _, err := Load(fstest.MapFS{"add_column.sql": {Data: []byte("SELECT 1;")}}); err == nil {
+		t.Error("expected an error for a file without a version prefix")
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	ms, err := Load(migrations.FS)
+	if err != nil {
+		t.Fatalf("embedded migrations do not load: %v", err)
+	}
+	for i, m := range ms {
+		if m.Version != int64(i+1) {
+			t.Errorf("%s: versions should be contiguous from 1", m.Label())
+		}
+	}
+}
+
+// fakeTarget records what the runner asks it to do.
+type fakeTarget struct {
+	applied []Applied
+	ran     []string
+	failDML bool
+}
+
+func (f *fakeTarget) EnsureSchemaMigrations(ctx context.Context) error {
+	f.ran = append(f.ran, "ensure")
+	return nil
+}
+
+func (f *fakeTarget) AppliedMigrations(ctx context.Context) ([]Applied, error) {
+	return f.applied, nil
+}
+
+func (f *fakeTarget) ApplyDDL(ctx context.Context, statements []string) error {
+	f.ran = append(f.ran, "DDL:"+strings.Join(statements, "|"))
+	return nil
+}
+
+func (f *fakeTarget) ApplyDML(ctx context.Context, statements []string) error {
+	if f.failDML {
+		return errors.New("boom")
+	}
+	f.ran = append(f.ran, "DML:"+strings.Join(statements, "|"))
+	return nil
+}
+
+func (f *fakeTarget) RecordMigration(ctx context.Context, m *Migration) error {
+	f.ran = append(f.ran, "record:"+m.Label())
+	f.applied = append(f.applied, Applied{Version: m.Version, Name: m.Name, Checksum: m.Checksum, AppliedAt: time.Now()})
+	return nil
+}
+
+func mustParse(t *testing.T, version int64, name, sql string) *Migration {
+	t.Helper()
+	m, err := Parse(version, name, sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestRunnerUp(t *testing.T) {
+	ctx := context.Background()
+	ms := []*Migration{
+		mustParse(t, 1, "base", "CREATE TABLE A (Id INT64) PRIMARY KEY (Id);"),
+		mustParse(t, 2, "backfill", "ALTER TABLE A ADD COLUMN B INT64; UPDATE A SET B = 0 WHERE TRUE;"),
+		mustParse(t, 3, "index", "CREATE INDEX AB ON A(B);"),
+	}
+	target := &fakeTarget{}
+	r := NewRunner(target, ms, nil)
+
+	n, err := r.Up(ctx, 2)
+	if err != nil || n != 2 {
+		t.Fatalf("Up(2) = %d, %v", n, err)
+	}
+	want := []string{
+		"ensure",
+		"DDL:CREATE TABLE A (Id INT64) PRIMARY KEY (Id)",
+		"record:0001_base",
+		"DDL:ALTER TABLE A ADD COLUMN B INT64",
+		"DML:UPDATE A SET B = 0 WHERE TRUE",
+		"record:0002_backfill",
+	}
+	if strings.Join(target.ran, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("ran:\n%s\nwant:\n%s", strings.Join(target.ran, "\n"), strings.Join(want, "\n"))
+	}
+
+	pending, err := r.Plan(ctx, 0)
+	if err != nil || len(pending) != 1 || pending[0].Version != 3 {
+		t.Fatalf("Plan after Up(2) = %v, %v", pending, err)
+	}
+
+	if n, err := r.Up(ctx, 0); err != nil || n != 1 {
+		t.Fatalf("Up(0) = %d, %v", n, err)
+	}
+	if n, err := r.Up(ctx, 0); err != nil || n != 0 {
+		t.Fatalf("second Up(0) = %d, %v; want no-op", n, err)
+	}
+}
+
+func TestRunnerUpFailureIsNotRecorded(t *testing.T) {
+	ms := []*Migration{mustParse(t, 1, "backfill", "UPDATE A SET B = 0 WHERE TRUE;")}
+	target := &fakeTarget{failDML: true}
+
+	if _, err := NewRunner(target, ms, nil).Up(context.Background(), 0); err == nil {
+		t.Fatal("expected Up to fail")
+	}
+	if len(target.applied) != 0 {
+		t.Errorf("failed migration was recorded: %+v", target.applied)
+	}
+}
+
+func TestRunnerStatus(t *testing.T) {
+	base := mustParse(t, 1, "base", "CREATE TABLE A (Id INT64) PRIMARY KEY (Id);")
+	changed := mustParse(t, 2, "changed", "ALTER TABLE A ADD COLUMN B INT64;")
+	pending := mustParse(t, 4, "pending", "ALTER TABLE A ADD COLUMN C INT64;")
+	target := &fakeTarget{applied: []Applied{
+		{Version: 1, Name: "base", Checksum: base.Checksum},
+		{Version: 2, Name: "changed", Checksum: "stale"},
+		{Version: 3, Name: "gone", Checksum: "x"},
+	}}
+
+	entries, err := NewRunner(target, []*Migration{base, changed, pending}, nil).Status(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []State{StateApplied, StateModified, StateMissing, StatePending}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(want))
+	}
+	for i, e := range entries {
+		if e.State != want[i] {
+			t.Errorf("version %d state = %s, want %s", e.Version, e.State, want[i])
+		}
+	}
+}
+
+func TestRunnerPlanRejectsOutOfOrder(t *testing.T) {
+	ms := []*Migration{
+		mustParse(t, 1, "a", "CREATE TABLE A (Id INT64) PRIMARY KEY (Id);"),
+		mustParse(t, 2, "b", "CREATE TABLE B (Id INT64) PRIMARY KEY (Id);"),
+	}
+	target := &fakeTarget{applied: []Applied{{Version: 2, Name: "b", Checksum: ms[1].Checksum}}}
+
+	if _, err := NewRunner(target, ms, nil).Plan(context.Background(), 0); err == nil {
+		t.Fatal("expected an error when an older migration is still pending")
+	}
+}
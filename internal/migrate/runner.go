@@ -0,0 +1,176 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Applied is a row of the SchemaMigrations table.
+type Applied struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Target is a database that migrations can be applied to.
+type Target interface {
+	// EnsureSchemaMigrations creates the SchemaMigrations table if needed.
+	EnsureSchemaMigrations(ctx context.Context) error
+	// AppliedMigrations lists recorded migrations. It returns no rows, not an
+	// error, when the SchemaMigrations table does not exist yet.
+	AppliedMigrations(ctx context.Context) ([]Applied, error)
+	// ApplyDDL runs statements as a single schema update.
+	ApplyDDL(ctx context.Context, statements []string) error
+	// ApplyDML runs statements in a single read-write transaction.
+	ApplyDML(ctx context.Context, statements []string) error
+	// RecordMigration marks m as applied.
+	RecordMigration(ctx context.Context, m *Migration) error
+}
+
+// State describes how a migration relates to the database.
+type State string
+
+const (
+	StatePending State = "pending"
+	StateApplied State = "applied"
+	// StateModified means the file changed after it was applied.
+	StateModified State = "modified"
+	// StateMissing means the database recorded a version with no file.
+	StateMissing State = "missing"
+)
+
+// StatusEntry pairs a migration file with its SchemaMigrations row.
+type StatusEntry struct {
+	Version   int64
+	Name      string
+	State     State
+	AppliedAt *time.Time
+}
+
+// Runner plans and applies migrations against a Target.
+type Runner struct {
+	target     Target
+	migrations []*Migration
+	out        io.Writer
+}
+
+// NewRunner creates a runner for migrations sorted by version (as returned by
+// Load). Progress is written to out.
+func NewRunner(target Target, migrations []*Migration, out io.Writer) *Runner {
+	if out == nil {
+		out = io.Discard
+	}
+	return &Runner{target: target, migrations: migrations, out: out}
+}
+
+// Status reports every known version, from files and from the database.
+func (r *Runner) Status(ctx context.Context) ([]StatusEntry, error) {
+	applied, err := r.appliedByVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []StatusEntry
+	for _, m := range r.migrations {
+		entry := StatusEntry{Version: m.Version, Name: m.Name, State: StatePending}
+		if a, ok := applied[m.Version]; ok {
+			entry.State = StateApplied
+			if a.Checksum != m.Checksum {
+				entry.State = StateModified
+			}
+			appliedAt := a.AppliedAt
+			entry.AppliedAt = &appliedAt
+			delete(applied, m.Version)
+		}
+		entries = append(entries, entry)
+	}
+	for _, a := range applied {
+		appliedAt := a.AppliedAt
+		entries = append(entries, StatusEntry{Version: a.Version, Name: a.Name, State: StateMissing, AppliedAt: &appliedAt})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Version < entries[j].Version })
+	return entries, nil
+}
+
+// Plan returns the migrations up to and including version `to` (0 = all)
+// that have not been applied, in the order Up would apply them. It fails if
+// a pending migration is older than one already applied, since that usually
+// means two branches picked the same slot.
+func (r *Runner) Plan(ctx context.Context, to int64) ([]*Migration, error) {
+	applied, err := r.appliedByVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest int64
+	for v := range applied {
+		if v > latest {
+			latest = v
+		}
+	}
+
+	var pending []*Migration
+	for _, m := range r.migrations {
+		if to > 0 && m.Version > to {
+			break
+		}
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		if m.Version < latest {
+			return nil, fmt.Errorf("migration %s is pending but version %d is already applied; renumber it after %04d", m.Label(), latest, latest)
+		}
+		pending = append(pending, m)
+	}
+	return pending, nil
+}
+
+// Up applies pending migrations up to version `to` (0 = all) and returns how
+// many were applied. Each migration is recorded only after all of its batches
+// succeed, so a failed run can be retried once the cause is fixed.
+func (r *Runner) Up(ctx context.Context, to int64) (int, error) {
+	if err := r.target.EnsureSchemaMigrations(ctx); err != nil {
+		return 0, fmt.Errorf("failed to create SchemaMigrations: %w", err)
+	}
+
+	pending, err := r.Plan(ctx, to)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, m := range pending {
+		fmt.Fprintf(r.out, "applying %s\n", m.Label())
+		for _, b := range m.Batches {
+			fmt.Fprintf(r.out, "  %s: %d statement(s)\n", b.Kind, len(b.Statements))
+			var err error
+			if b.Kind == DML {
+				err = r.target.ApplyDML(ctx, b.Statements)
+			} else {
+				err = r.target.ApplyDDL(ctx, b.Statements)
+			}
+			if err != nil {
+				return i, fmt.Errorf("failed to apply %s (%s): %w", m.Label(), b.Kind, err)
+			}
+		}
+		if err := r.target.RecordMigration(ctx, m); err != nil {
+			return i, fmt.Errorf("failed to record %s: %w", m.Label(), err)
+		}
+	}
+	return len(pending), nil
+}
+
+func (r *Runner) appliedByVersion(ctx context.Context) (map[int64]Applied, error) {
+	rows, err := r.target.AppliedMigrations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SchemaMigrations: %w", err)
+	}
+	applied := make(map[int64]Applied, len(rows))
+	for _, a := range rows {
+		applied[a.Version] = a
+	}
+	return applied, nil
+}
@@ -0,0 +1,161 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	dbadmin "cloud.google.com/go/spanner/admin/database/apiv1"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"google.golang.org/api/iterator"
+)
+
+// schemaMigrationsDDL creates the version table. IF NOT EXISTS makes it safe
+// to run on every `up`.
+const schemaMigrationsDDL = `CREATE TABLE IF NOT EXISTS SchemaMigrations (
+  Version   INT64 NOT NULL,
+  Name      STRING(255) NOT NULL,
+  Checksum  STRING(64) NOT NULL,
+  AppliedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (Version)`
+
+// SpannerTarget applies migrations to a Cloud Spanner database: DDL through
+// the database admin API, DML through a read-write transaction.
+type SpannerTarget struct {
+	dbPath string
+	admin  *dbadmin.DatabaseAdminClient
+	client *spanner.Client
+}
+
+var _ Target = (*SpannerTarget)(nil)
+
+// NewSpannerTarget connects to projects/{project}/instances/{instance}/databases/{database}.
+func NewSpannerTarget(ctx context.Context, project, instance, database string) (*SpannerTarget, error) {
+	dbPath := fmt.Sprintf("projects/%s/instances/%s/databases/%s", project, instance, database)
+
+	admin, err := dbadmin.NewDatabaseAdminClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spanner admin client: %w", err)
+	}
+	client, err := spanner.NewClient(ctx, dbPath)
+	if err != nil {
+		admin.Close()
+		return nil, fmt.Errorf("failed to create spanner client: %w", err)
+	}
+	return &SpannerTarget{dbPath: dbPath, admin: admin, client: client}, nil
+}
+
+// DatabasePath returns the fully qualified database name.
+func (t *SpannerTarget) DatabasePath() string {
+	return t.dbPath
+}
+
+// Close closes both clients.
+func (t *SpannerTarget) Close() {
+	t.client.Close()
+	t.admin.Close()
+}
+
+// EnsureSchemaMigrations creates the SchemaMigrations table if needed.
+func (t *SpannerTarget) EnsureSchemaMigrations(ctx context.Context) error {
+	return t.ApplyDDL(ctx, []string{schemaMigrationsDDL})
+}
+
+// AppliedMigrations lists the rows of SchemaMigrations, or nothing if the
+// table has not been created yet.
+func (t *SpannerTarget) AppliedMigrations(ctx context.Context) ([]Applied, error) {
+	exists, err := t.tableExists(ctx, "SchemaMigrations")
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	iter := t.client.Single().Query(ctx, spanner.Statement{
+		SQL: `SELECT Version, Name, Checksum, AppliedAt FROM SchemaMigrations ORDER BY Version`,
+	})
+	defer iter.Stop()
+
+	var applied []Applied
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate schema migrations: %w", err)
+		}
+		var a Applied
+		if err := row.Columns(&a.Version, &a.Name, &a.Checksum, &a.AppliedAt); err != nil {
+			return nil, fmt.Errorf("failed to parse schema migration: %w", err)
+		}
+		applied = append(applied, a)
+	}
+	return applied, nil
+}
+
+// ApplyDDL submits statements as one UpdateDatabaseDdl operation and waits
+// for it to finish.
+func (t *SpannerTarget) ApplyDDL(ctx context.Context, statements []string) error {
+	op, err := t.admin.UpdateDatabaseDdl(ctx, &databasepb.UpdateDatabaseDdlRequest{
+		Database:   t.dbPath,
+		Statements: statements,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start schema update: %w", err)
+	}
+	if err := op.Wait(ctx); err != nil {
+		return fmt.Errorf("schema update failed: %w", err)
+	}
+	return nil
+}
+
+// ApplyDML runs statements in one read-write transaction.
+func (t *SpannerTarget) ApplyDML(ctx context.Context, statements []string) error {
+	stmts := make([]spanner.Statement, len(statements))
+	for i, sql := range statements {
+		stmts[i] = spanner.NewStatement(sql)
+	}
+	_, err := t.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		_, err := txn.BatchUpdate(ctx, stmts)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run DML: %w", err)
+	}
+	return nil
+}
+
+// RecordMigration writes the SchemaMigrations row for m.
+func (t *SpannerTarget) RecordMigration(ctx context.Context, m *Migration) error {
+	_, err := t.client.Apply(ctx, []*spanner.Mutation{
+		spanner.InsertOrUpdate("SchemaMigrations",
+			[]string{"Version", "Name", "Checksum", "AppliedAt"},
+			[]interface{}{m.Version, m.Name, m.Checksum, spanner.CommitTimestamp},
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+	return nil
+}
+
+func (t *SpannerTarget) tableExists(ctx context.Context, table string) (bool, error) {
+	iter := t.client.Single().Query(ctx, spanner.Statement{
+		SQL: `SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES
+		      WHERE TABLE_SCHEMA = '' AND TABLE_NAME = @table`,
+		Params: map[string]interface{}{"table": table},
+	})
+	defer iter.Stop()
+
+	row, err := iter.Next()
+	if err != nil {
+		return false, fmt.Errorf("failed to query information schema: %w", err)
+	}
+	var count int64
+	if err := row.Columns(&count); err != nil {
+		return false, fmt.Errorf("failed to parse information schema: %w", err)
+	}
+	return count > 0, nil
+}
@@ -31,6 +31,7 @@ const (
 //	use_spot_vms         → UseSpotVMs
 //	service_account      → ServiceAccount
 //	name                 → Name  (also used in generateProviderJobID)
+//	labels               → JobLabels
 //	jobID                → JobID (provider-compatible) + RequestID (idempotency)
 func buildJobConfig(
 	req *jennahv1.SubmitJobRequest,
@@ -39,24 +40,7 @@ func buildJobConfig(
 ) (batch.JobConfig, error) {
 
 	// ── Resource resolution ───────────────────────────────────────────────────
-	// Merge the named preset (resource_profile) with any per-field overrides
-	// (resource_override).  A nil cfg falls back to built-in defaults.
-	var resources *batch.ResourceRequirements
-	if cfg != nil {
-		var override *config.ResourceOverride
-		if ro := req.GetResourceOverride(); ro != nil {
-			override = &config.ResourceOverride{
-				CPUMillis:             ro.GetCpuMillis(),
-				MemoryMiB:             ro.GetMemoryMib(),
-				MaxRunDurationSeconds: ro.GetMaxRunDurationSeconds(),
-			}
-		}
-		resources = cfg.ResolveResources(req.GetMachineType(), req.GetResourceProfile(), override)
-	} else {
-		// No config file — fall back to "medium" hard-coded defaults so that
-		// the navigator is always usable in tests and minimal deployments.
-		resources = resolveBuiltinProfile(req.GetResourceProfile(), req.GetResourceOverride())
-	}
+	resources := ResolveResources(req, cfg)
 
 	// ── Validation ────────────────────────────────────────────────────────────
 	if req.GetBootDiskSizeGb() > 0 && req.GetBootDiskSizeGb() < 10 {
@@ -82,6 +66,15 @@ func buildJobConfig(
 		envVars[k] = v
 	}
 
+	// ── Labels ────────────────────────────────────────────────────────────────
+	var labels map[string]string
+	if len(req.GetLabels()) > 0 {
+		labels = make(map[string]string, len(req.GetLabels()))
+		for k, v := range req.GetLabels() {
+			labels[k] = v
+		}
+	}
+
 	// ── Task group defaults ───────────────────────────────────────────────────
 	taskGroup := &batch.TaskGroupConfig{
 		TaskCount:        1,
@@ -141,9 +134,33 @@ func buildJobConfig(
 
 		// Task group
 		TaskGroup: taskGroup,
+
+		// Job metadata
+		JobLabels: labels,
 	}, nil
 }
 
+// ResolveResources returns the CPU, memory and run-time limits a request
+// resolves to: the named preset (resource_profile) or machine type merged
+// with any per-field overrides (resource_override). A nil cfg falls back to
+// built-in defaults.
+func ResolveResources(req *jennahv1.SubmitJobRequest, cfg *config.JobConfigFile) *batch.ResourceRequirements {
+	if cfg == nil {
+		// No config file — fall back to "medium" hard-coded defaults so that
+		// the navigator is always usable in tests and minimal deployments.
+		return resolveBuiltinProfile(req.GetResourceProfile(), req.GetResourceOverride())
+	}
+	var override *config.ResourceOverride
+	if ro := req.GetResourceOverride(); ro != nil {
+		override = &config.ResourceOverride{
+			CPUMillis:             ro.GetCpuMillis(),
+			MemoryMiB:             ro.GetMemoryMib(),
+			MaxRunDurationSeconds: ro.GetMaxRunDurationSeconds(),
+		}
+	}
+	return cfg.ResolveResources(req.GetMachineType(), req.GetResourceProfile(), override)
+}
+
 // generateProviderJobID produces a GCP Batch-compatible job ID (≤ 63 chars,
 // alphanumeric + hyphens only).
 //
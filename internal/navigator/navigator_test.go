@@ -14,6 +14,7 @@ func TestNavigate_SimpleJob(t *testing.T) {
 	req := &jennahv1.SubmitJobRequest{
 		ImageUri: "gcr.io/google-samples/hello-app:1.0",
 		EnvVars:  map[string]string{"APP_NAME": "hello-world"},
+		Labels:   map[string]string{"team": "billing"},
 	}
 	plan, err := Navigate(req, "aaaaaaaa-0000-0000-0000-000000000001", nil)
 	if err != nil {
@@ -31,6 +32,9 @@ func TestNavigate_SimpleJob(t *testing.T) {
 	if plan.Config.EnvVars["APP_NAME"] != "hello-world" {
 		t.Errorf("EnvVars not propagated correctly")
 	}
+	if plan.Config.JobLabels["team"] != "billing" {
+		t.Errorf("JobLabels not propagated correctly")
+	}
 	if plan.Config.BootDiskSizeGb != defaultBootDiskGB {
 		t.Errorf("BootDiskSizeGb: got %d, want default %d", plan.Config.BootDiskSizeGb, defaultBootDiskGB)
 	}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,36 +15,73 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-// JobTerminalEvent is the payload published to Pub/Sub when a job reaches
-// a terminal state (COMPLETED, FAILED, or CANCELLED).
-type JobTerminalEvent struct {
-	EventID           string `json:"event_id"`
-	EventType         string `json:"event_type"`
-	TenantID          string `json:"tenant_id"`
-	JobID             string `json:"job_id"`
-	FinalStatus       string `json:"final_status"`
-	PreviousStatus    string `json:"previous_status"`
-	OccurredAt        string `json:"occurred_at"`
-	UserEmail         string `json:"user_email,omitempty"`
-	ServiceTier       string `json:"service_tier,omitempty"`
-	AssignedService   string `json:"assigned_service,omitempty"`
-	CloudResourcePath string `json:"cloud_resource_path,omitempty"`
-	ErrorMessage      string `json:"error_message,omitempty"`
-	JobName           string `json:"job_name,omitempty"`
-}
-
-// Notifier publishes job terminal events. Implementations must be safe
-// for concurrent use.
+// JobEventVersion is the version of the JobEvent schema. It is raised when a
+// field changes meaning or is removed; new fields do not raise it.
+const JobEventVersion = 1
+
+// Job event types. Every job state transition produces one event; events of
+// the terminal statuses (COMPLETED, FAILED, CANCELLED and SKIPPED) are all
+// EventTypeTerminal.
+const (
+	EventTypeSubmitted         = "job.submitted"
+	EventTypeScheduled         = "job.scheduled"
+	EventTypeRunning           = "job.running"
+	EventTypeRetrying          = "job.retrying"
+	EventTypeStatusChanged     = "job.status_changed" // statuses without a type of their own, e.g. QUEUED
+	EventTypeTerminal          = "job.terminal"
+	EventTypeLeaseOwnerChanged = "job.lease_owner_changed"
+)
+
+// JobEvent is the payload published to Pub/Sub when a job changes. Status is
+// the job's status once the event happened; FinalStatus repeats it for
+// terminal events only, as terminal-only consumers expect.
+type JobEvent struct {
+	Version               int    `json:"version"`
+	EventID               string `json:"event_id"`
+	EventType             string `json:"event_type"`
+	TenantID              string `json:"tenant_id"`
+	JobID                 string `json:"job_id"`
+	Status                string `json:"status,omitempty"`
+	FinalStatus           string `json:"final_status,omitempty"`
+	PreviousStatus        string `json:"previous_status,omitempty"`
+	OccurredAt            string `json:"occurred_at"`
+	UserEmail             string `json:"user_email,omitempty"`
+	ServiceTier           string `json:"service_tier,omitempty"`
+	AssignedService       string `json:"assigned_service,omitempty"`
+	CloudResourcePath     string `json:"cloud_resource_path,omitempty"`
+	ErrorMessage          string `json:"error_message,omitempty"`
+	JobName               string `json:"job_name,omitempty"`
+	OwnerWorkerID         string `json:"owner_worker_id,omitempty"`
+	PreviousOwnerWorkerID string `json:"previous_owner_worker_id,omitempty"`
+	RetryCount            int64  `json:"retry_count,omitempty"`
+}
+
+// JobTerminalEvent is a JobEvent of type EventTypeTerminal.
+//
+// Deprecated: use JobEvent.
+type JobTerminalEvent = JobEvent
+
+// Type returns the event's type. Events published before event types
+// existed carry none and are terminal.
+func (e JobEvent) Type() string {
+	if e.EventType == "" {
+		return EventTypeTerminal
+	}
+	return e.EventType
+}
+
+// Notifier publishes job events. Implementations must be safe for concurrent
+// use.
 type Notifier interface {
-	// PublishJobTerminalEvent publishes an event for a job that reached a terminal state.
+	// PublishJobEvent publishes an event about a job.
 	// The implementation resolves the correct tenant-scoped topic from the event's TenantID.
-	PublishJobTerminalEvent(ctx context.Context, event JobTerminalEvent) error
+	PublishJobEvent(ctx context.Context, event JobEvent) error
 
 	// Close releases any resources held by the notifier.
 	Close() error
 }
 
-// PubSubNotifier publishes terminal events to a shared Pub/Sub topic.
+// PubSubNotifier publishes job events to a shared Pub/Sub topic.
 // The topic is created lazily on the first publish and cached for the
 // lifetime of the notifier. Tenant isolation is maintained via the
 // tenant_id attribute and payload field on each message.
@@ -105,9 +144,10 @@ func (n *PubSubNotifier) ensureTopic(ctx context.Context) (*pubsub.Topic, error)
 	return n.topic, n.err
 }
 
-// PublishJobTerminalEvent publishes the event to the shared topic. It blocks
-// until the publish is acknowledged or the context is cancelled.
-func (n *PubSubNotifier) PublishJobTerminalEvent(ctx context.Context, event JobTerminalEvent) error {
+// PublishJobEvent publishes the event to the shared topic. It blocks until
+// the publish is acknowledged or the context is cancelled. The event_type
+// attribute lets subscriptions filter events by type.
+func (n *PubSubNotifier) PublishJobEvent(ctx context.Context, event JobEvent) error {
 	topic, err := n.ensureTopic(ctx)
 	if err != nil {
 		return fmt.Errorf("resolve topic %s: %w", n.topicID, err)
@@ -121,10 +161,12 @@ func (n *PubSubNotifier) PublishJobTerminalEvent(ctx context.Context, event JobT
 	result := topic.Publish(ctx, &pubsub.Message{
 		Data: data,
 		Attributes: map[string]string{
-			"event_type": event.EventType,
+			"event_id":   event.EventID,
+			"event_type": event.Type(),
+			"version":    strconv.Itoa(event.Version),
 			"tenant_id":  event.TenantID,
 			"job_id":     event.JobID,
-			"status":     event.FinalStatus,
+			"status":     event.Status,
 		},
 	})
 
@@ -132,8 +174,8 @@ func (n *PubSubNotifier) PublishJobTerminalEvent(ctx context.Context, event JobT
 	if err != nil {
 		return fmt.Errorf("publish event for job %s: %w", event.JobID, err)
 	}
-	log.Printf("Published terminal event for job %s to topic %s (msg id: %s, status: %s)",
-		event.JobID, n.topicID, serverID, event.FinalStatus)
+	log.Printf("Published %s event for job %s to topic %s (msg id: %s, status: %s)",
+		event.Type(), event.JobID, n.topicID, serverID, event.Status)
 	return nil
 }
 
@@ -177,25 +219,107 @@ func (n *PubSubNotifier) ensurePushSubscription(ctx context.Context, t *pubsub.T
 // NoopNotifier silently discards all events. Used when Pub/Sub is disabled.
 type NoopNotifier struct{}
 
-// PublishJobTerminalEvent is a no-op.
-func (n *NoopNotifier) PublishJobTerminalEvent(_ context.Context, event JobTerminalEvent) error {
-	log.Printf("Notification disabled: would publish terminal event for job %s (status: %s)", event.JobID, event.FinalStatus)
+// PublishJobEvent is a no-op.
+func (n *NoopNotifier) PublishJobEvent(_ context.Context, event JobEvent) error {
+	log.Printf("Notification disabled: would publish %s event for job %s (status: %s)", event.Type(), event.JobID, event.Status)
 	return nil
 }
 
 // Close is a no-op.
 func (n *NoopNotifier) Close() error { return nil }
 
-// BuildEvent constructs a JobTerminalEvent from the provided fields.
+// BuildEvent constructs the terminal event of a job that reached finalStatus.
 // eventID should be a unique identifier such as the transition UUID.
-func BuildEvent(eventID, tenantID, jobID, finalStatus, previousStatus string) JobTerminalEvent {
-	return JobTerminalEvent{
+func BuildEvent(eventID, tenantID, jobID, finalStatus, previousStatus string) JobEvent {
+	event := BuildStatusEvent(eventID, tenantID, jobID, finalStatus, previousStatus)
+	event.EventType = EventTypeTerminal
+	event.FinalStatus = finalStatus
+	return event
+}
+
+// BuildStatusEvent constructs the event of a job that moved from
+// previousStatus to status, typed by EventTypeForStatus.
+func BuildStatusEvent(eventID, tenantID, jobID, status, previousStatus string) JobEvent {
+	event := JobEvent{
+		Version:        JobEventVersion,
 		EventID:        eventID,
-		EventType:      "job.terminal",
+		EventType:      EventTypeForStatus(status),
 		TenantID:       tenantID,
 		JobID:          jobID,
-		FinalStatus:    finalStatus,
+		Status:         status,
 		PreviousStatus: previousStatus,
 		OccurredAt:     time.Now().UTC().Format(time.RFC3339),
 	}
+	if event.EventType == EventTypeTerminal {
+		event.FinalStatus = status
+	}
+	return event
+}
+
+// BuildSubmittedEvent constructs the event of a job accepted by its provider
+// in status. The job moved there from previousStatus, PENDING unless it was
+// held before submission.
+func BuildSubmittedEvent(eventID, tenantID, jobID, status, previousStatus string) JobEvent {
+	event := BuildStatusEvent(eventID, tenantID, jobID, status, previousStatus)
+	event.EventType = EventTypeSubmitted
+	return event
+}
+
+// BuildLeaseOwnerChangedEvent constructs the event of a job whose lease moved
+// from previousOwner to owner while it was in status.
+func BuildLeaseOwnerChangedEvent(eventID, tenantID, jobID, status, previousOwner, owner string) JobEvent {
+	return JobEvent{
+		Version:               JobEventVersion,
+		EventID:               eventID,
+		EventType:             EventTypeLeaseOwnerChanged,
+		TenantID:              tenantID,
+		JobID:                 jobID,
+		Status:                status,
+		OccurredAt:            time.Now().UTC().Format(time.RFC3339),
+		OwnerWorkerID:         owner,
+		PreviousOwnerWorkerID: previousOwner,
+	}
+}
+
+// EventTypeForStatus returns the type of the event announcing that a job
+// moved to status. PENDING, the status jobs are submitted to their provider
+// in, is EventTypeSubmitted.
+func EventTypeForStatus(status string) string {
+	switch status {
+	case "PENDING":
+		return EventTypeSubmitted
+	case "SCHEDULED":
+		return EventTypeScheduled
+	case "RUNNING":
+		return EventTypeRunning
+	case "RETRYING":
+		return EventTypeRetrying
+	case "COMPLETED", "FAILED", "CANCELLED", "SKIPPED":
+		return EventTypeTerminal
+	default:
+		return EventTypeStatusChanged
+	}
+}
+
+// ParseEventTypes parses a comma-separated list of event types, such as the
+// value of a filter setting. An empty list yields nil.
+func ParseEventTypes(list string) []string {
+	var types []string
+	for _, t := range strings.Split(list, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// MatchesEventType reports whether eventType is one of types. "*" matches
+// every type.
+func MatchesEventType(types []string, eventType string) bool {
+	for _, t := range types {
+		if t == "*" || t == eventType {
+			return true
+		}
+	}
+	return false
 }
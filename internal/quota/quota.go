@@ -0,0 +1,388 @@
+// Package quota enforces per-tenant admission limits.
+//
+// The gateway asks a Checker whether a new job may start, counting the
+// tenant's jobs from the Jobs table:
+//
+//	SubmitJobRequest
+//	    ↓
+//	Checker.Admit()
+//	    ├─ machine type / spot VM policy   — reject
+//	    ├─ single job larger than a limit  — reject
+//	    ├─ jobs per day                    — reject
+//	    └─ concurrency, vCPU, memory       — queue (QueueWhenBusy) or reject
+//
+// Queued jobs are stored with status QUEUED and released by the worker, which
+// asks CanStart as capacity frees up.
+//
+// Jobs that start later are checked too. The gateway asks CheckPolicy for
+// each node of a workflow and the template of a schedule, and CheckDailyLimit
+// for a workflow's nodes. Workers ask AdmitJob before starting a workflow
+// node and Admit before submitting a schedule's job; both may queue the job.
+//
+// Admit counts and the gateway inserts in separate steps, without a lock, so
+// limits are approximate: concurrent submissions can each be admitted into the
+// same last slot. Workers call CanStart under the lease of the queued job they
+// are about to start, which serializes releases for a tenant.
+//
+// Limits come from QUOTA_* environment variables and can be overridden per
+// tenant with a TenantQuotas row. A limit of 0 means unlimited.
+package quota
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	batch "github.com/alphauslabs/jennah/internal/cloudexec"
+	"github.com/alphauslabs/jennah/internal/config"
+	"github.com/alphauslabs/jennah/internal/database"
+	"github.com/alphauslabs/jennah/internal/navigator"
+)
+
+// activeStatuses are the statuses that hold capacity. RETRYING jobs keep
+// their slot while they wait for the next attempt.
+var activeStatuses = []string{
+	database.JobStatusPending,
+	database.JobStatusScheduled,
+	database.JobStatusRunning,
+	database.JobStatusRetrying,
+}
+
+// Limits are the admission limits of one tenant. Zero means unlimited.
+type Limits struct {
+	MaxConcurrentJobs   int64
+	MaxCpuMillis        int64    // Total across active jobs
+	MaxMemoryMib        int64    // Total across active jobs
+	MaxJobsPerDay       int64    // Jobs created since 00:00 UTC
+	AllowedMachineTypes []string // Empty allows any
+	AllowSpotVms        bool
+	QueueWhenBusy       bool // Queue jobs over the concurrency limits instead of rejecting them
+}
+
+// LimitsFromEnv reads the default limits from the environment:
+//
+//	QUOTA_MAX_CONCURRENT_JOBS     default 0 (unlimited)
+//	QUOTA_MAX_CPU_MILLIS          default 0 (unlimited)
+//	QUOTA_MAX_MEMORY_MIB          default 0 (unlimited)
+//	QUOTA_MAX_JOBS_PER_DAY        default 0 (unlimited)
+//	QUOTA_ALLOWED_MACHINE_TYPES   comma-separated, default any
+//	QUOTA_ALLOW_SPOT_VMS          default true
+//	QUOTA_QUEUE_WHEN_BUSY         default false
+func LimitsFromEnv() (Limits, error) {
+	l := Limits{AllowSpotVms: true}
+	ints := []struct {
+		key string
+		dst *int64
+	}{
+		{"QUOTA_MAX_CONCURRENT_JOBS", &l.MaxConcurrentJobs},
+		{"QUOTA_MAX_CPU_MILLIS", &l.MaxCpuMillis},
+		{"QUOTA_MAX_MEMORY_MIB", &l.MaxMemoryMib},
+		{"QUOTA_MAX_JOBS_PER_DAY", &l.MaxJobsPerDay},
+	}
+	for _, v := range ints {
+		raw := strings.TrimSpace(os.Getenv(v.key))
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || n < 0 {
+			return Limits{}, fmt.Errorf("invalid %s %q: want a non-negative integer", v.key, raw)
+		}
+		*v.dst = n
+	}
+	bools := []struct {
+		key string
+		dst *bool
+	}{
+		{"QUOTA_ALLOW_SPOT_VMS", &l.AllowSpotVms},
+		{"QUOTA_QUEUE_WHEN_BUSY", &l.QueueWhenBusy},
+	}
+	for _, v := range bools {
+		raw := strings.TrimSpace(os.Getenv(v.key))
+		if raw == "" {
+			continue
+		}
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return Limits{}, fmt.Errorf("invalid %s %q: want true or false", v.key, raw)
+		}
+		*v.dst = b
+	}
+	for _, mt := range strings.Split(os.Getenv("QUOTA_ALLOWED_MACHINE_TYPES"), ",") {
+		if mt = strings.TrimSpace(mt); mt != "" {
+			l.AllowedMachineTypes = append(l.AllowedMachineTypes, mt)
+		}
+	}
+	return l, nil
+}
+
+// WithOverrides returns l with the non-nil fields of a tenant's quota row
+// applied. A nil q returns l unchanged.
+func (l Limits) WithOverrides(q *database.TenantQuota) Limits {
+	if q == nil {
+		return l
+	}
+	if q.MaxConcurrentJobs != nil {
+		l.MaxConcurrentJobs = *q.MaxConcurrentJobs
+	}
+	if q.MaxCpuMillis != nil {
+		l.MaxCpuMillis = *q.MaxCpuMillis
+	}
+	if q.MaxMemoryMib != nil {
+		l.MaxMemoryMib = *q.MaxMemoryMib
+	}
+	if q.MaxJobsPerDay != nil {
+		l.MaxJobsPerDay = *q.MaxJobsPerDay
+	}
+	if len(q.AllowedMachineTypes) > 0 {
+		l.AllowedMachineTypes = q.AllowedMachineTypes
+	}
+	if q.AllowSpotVms != nil {
+		l.AllowSpotVms = *q.AllowSpotVms
+	}
+	if q.QueueWhenBusy != nil {
+		l.QueueWhenBusy = *q.QueueWhenBusy
+	}
+	return l
+}
+
+// Usage is what a tenant currently consumes.
+type Usage struct {
+	ActiveJobs int64
+	QueuedJobs int64
+	CpuMillis  int64 // Sum over active jobs
+	MemoryMib  int64 // Sum over active jobs
+	JobsToday  int64
+}
+
+// Exceeded reports why starting a job needing res would take usage over the
+// concurrency, vCPU or memory limits, or "" when it fits.
+func (l Limits) Exceeded(u Usage, res *batch.ResourceRequirements) string {
+	switch {
+	case l.MaxConcurrentJobs > 0 && u.ActiveJobs+1 > l.MaxConcurrentJobs:
+		return fmt.Sprintf("%d of %d concurrent jobs are active", u.ActiveJobs, l.MaxConcurrentJobs)
+	case l.MaxCpuMillis > 0 && u.CpuMillis+res.CPUMillis > l.MaxCpuMillis:
+		return fmt.Sprintf("%d of %d vCPU millis are in use and the job needs %d", u.CpuMillis, l.MaxCpuMillis, res.CPUMillis)
+	case l.MaxMemoryMib > 0 && u.MemoryMib+res.MemoryMiB > l.MaxMemoryMib:
+		return fmt.Sprintf("%d of %d MiB of memory are in use and the job needs %d", u.MemoryMib, l.MaxMemoryMib, res.MemoryMiB)
+	}
+	return ""
+}
+
+// Decision is the outcome of an admission check.
+type Decision int
+
+const (
+	// Admit starts the job now.
+	Admit Decision = iota
+	// Queue stores the job as QUEUED until capacity frees up.
+	Queue
+	// Reject refuses the job.
+	Reject
+)
+
+// Checker evaluates tenants' limits against their jobs.
+type Checker struct {
+	store     database.Store
+	defaults  Limits
+	jobConfig *config.JobConfigFile // nil uses the built-in resource profiles
+}
+
+// NewChecker creates a Checker that applies defaults to tenants without a
+// TenantQuotas row. jobConfig resolves resource profiles the same way the
+// worker does.
+func NewChecker(store database.Store, defaults Limits, jobConfig *config.JobConfigFile) *Checker {
+	return &Checker{store: store, defaults: defaults, jobConfig: jobConfig}
+}
+
+// Limits returns a tenant's effective limits.
+func (c *Checker) Limits(ctx context.Context, tenantID string) (Limits, error) {
+	q, err := c.store.GetTenantQuota(ctx, tenantID)
+	if err != nil {
+		return Limits{}, err
+	}
+	return c.defaults.WithOverrides(q), nil
+}
+
+// Usage counts a tenant's active and queued jobs and the jobs it created
+// since 00:00 UTC of now.
+func (c *Checker) Usage(ctx context.Context, tenantID string, now time.Time) (Usage, error) {
+	var u Usage
+	for _, status := range activeStatuses {
+		jobs, err := c.store.ListJobsByStatus(ctx, tenantID, status)
+		if err != nil {
+			return Usage{}, err
+		}
+		for _, job := range jobs {
+			res := c.JobResources(job)
+			u.ActiveJobs++
+			u.CpuMillis += res.CPUMillis
+			u.MemoryMib += res.MemoryMiB
+		}
+	}
+	queued, err := c.store.ListJobsByStatus(ctx, tenantID, database.JobStatusQueued)
+	if err != nil {
+		return Usage{}, err
+	}
+	u.QueuedJobs = int64(len(queued))
+
+	now = now.UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if u.JobsToday, err = c.store.CountJobsSince(ctx, tenantID, midnight); err != nil {
+		return Usage{}, err
+	}
+	return u, nil
+}
+
+// Resources resolves the CPU and memory a request will ask for.
+func (c *Checker) Resources(req *jennahv1.SubmitJobRequest) *batch.ResourceRequirements {
+	return navigator.ResolveResources(req, c.jobConfig)
+}
+
+// JobResources resolves the CPU and memory a stored job asks for.
+func (c *Checker) JobResources(job *database.Job) *batch.ResourceRequirements {
+	return c.Resources(jobRequest(job))
+}
+
+// jobRequest rebuilds the parts of a stored job's request that limits apply
+// to.
+func jobRequest(job *database.Job) *jennahv1.SubmitJobRequest {
+	req := &jennahv1.SubmitJobRequest{}
+	if job.ResourceProfile != nil {
+		req.ResourceProfile = *job.ResourceProfile
+	}
+	if job.MachineType != nil {
+		req.MachineType = *job.MachineType
+	}
+	if job.CpuMillis != nil || job.MemoryMib != nil {
+		req.ResourceOverride = &jennahv1.ResourceOverride{}
+		if job.CpuMillis != nil {
+			req.ResourceOverride.CpuMillis = *job.CpuMillis
+		}
+		if job.MemoryMib != nil {
+			req.ResourceOverride.MemoryMib = *job.MemoryMib
+		}
+	}
+	if job.UseSpotVms != nil {
+		req.UseSpotVms = *job.UseSpotVms
+	}
+	return req
+}
+
+// policyViolation reports why a job needing res may never run under l: its
+// machine type or spot VMs are not allowed, or it alone exceeds the vCPU or
+// memory limit. It returns "" when the job is allowed.
+func (l Limits) policyViolation(req *jennahv1.SubmitJobRequest, res *batch.ResourceRequirements) string {
+	if mt := req.GetMachineType(); mt != "" && len(l.AllowedMachineTypes) > 0 && !slices.Contains(l.AllowedMachineTypes, mt) {
+		return fmt.Sprintf("machine type %q is not allowed; allowed: %s", mt, strings.Join(l.AllowedMachineTypes, ", "))
+	}
+	if req.GetUseSpotVms() && !l.AllowSpotVms {
+		return "spot VMs are not allowed for this tenant"
+	}
+	if l.MaxCpuMillis > 0 && res.CPUMillis > l.MaxCpuMillis {
+		return fmt.Sprintf("job needs %d vCPU millis but the tenant limit is %d", res.CPUMillis, l.MaxCpuMillis)
+	}
+	if l.MaxMemoryMib > 0 && res.MemoryMiB > l.MaxMemoryMib {
+		return fmt.Sprintf("job needs %d MiB of memory but the tenant limit is %d", res.MemoryMiB, l.MaxMemoryMib)
+	}
+	return ""
+}
+
+// CheckPolicy reports why req may never run for a tenant, or "" when it may.
+// Unlike Admit it counts no jobs: it vets jobs that start later, such as
+// workflow nodes and scheduled jobs.
+func (c *Checker) CheckPolicy(ctx context.Context, tenantID string, req *jennahv1.SubmitJobRequest) (string, error) {
+	l, err := c.Limits(ctx, tenantID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load quota: %w", err)
+	}
+	return l.policyViolation(req, c.Resources(req)), nil
+}
+
+// CheckDailyLimit reports why a tenant may not create n more jobs today, or
+// "" when it may.
+func (c *Checker) CheckDailyLimit(ctx context.Context, tenantID string, n int64, now time.Time) (string, error) {
+	l, err := c.Limits(ctx, tenantID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load quota: %w", err)
+	}
+	if l.MaxJobsPerDay == 0 {
+		return "", nil
+	}
+	now = now.UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	today, err := c.store.CountJobsSince(ctx, tenantID, midnight)
+	if err != nil {
+		return "", fmt.Errorf("failed to count jobs: %w", err)
+	}
+	if today+n > l.MaxJobsPerDay {
+		return fmt.Sprintf("%d of %d daily jobs are used and %d more were requested; it resets at 00:00 UTC", today, l.MaxJobsPerDay, n), nil
+	}
+	return "", nil
+}
+
+// Admit decides whether a tenant may start req now. For Queue and Reject the
+// returned message says which limit was hit.
+func (c *Checker) Admit(ctx context.Context, tenantID string, req *jennahv1.SubmitJobRequest, now time.Time) (Decision, string, error) {
+	return c.admit(ctx, tenantID, req, now, true)
+}
+
+// AdmitJob decides whether a stored job that waited on other jobs, such as a
+// workflow node, may start now. It applies Admit's limits except the daily
+// one, which counted the job when it was created.
+func (c *Checker) AdmitJob(ctx context.Context, job *database.Job, now time.Time) (Decision, string, error) {
+	return c.admit(ctx, job.TenantId, jobRequest(job), now, false)
+}
+
+func (c *Checker) admit(ctx context.Context, tenantID string, req *jennahv1.SubmitJobRequest, now time.Time, daily bool) (Decision, string, error) {
+	l, err := c.Limits(ctx, tenantID)
+	if err != nil {
+		return Reject, "", fmt.Errorf("failed to load quota: %w", err)
+	}
+
+	res := c.Resources(req)
+	if msg := l.policyViolation(req, res); msg != "" {
+		return Reject, msg, nil
+	}
+
+	u, err := c.Usage(ctx, tenantID, now)
+	if err != nil {
+		return Reject, "", fmt.Errorf("failed to count jobs: %w", err)
+	}
+	if daily && l.MaxJobsPerDay > 0 && u.JobsToday >= l.MaxJobsPerDay {
+		return Reject, fmt.Sprintf("daily limit of %d jobs reached; it resets at 00:00 UTC", l.MaxJobsPerDay), nil
+	}
+
+	reason := l.Exceeded(u, res)
+	if reason == "" && l.QueueWhenBusy && u.QueuedJobs > 0 {
+		// Keep the queue first-in first-out.
+		reason = fmt.Sprintf("%d jobs are already queued", u.QueuedJobs)
+	}
+	switch {
+	case reason == "":
+		return Admit, "", nil
+	case l.QueueWhenBusy:
+		return Queue, reason, nil
+	default:
+		return Reject, reason, nil
+	}
+}
+
+// CanStart reports why a queued job cannot start yet, or "" when it fits in
+// the tenant's current limits.
+func (c *Checker) CanStart(ctx context.Context, job *database.Job, now time.Time) (string, error) {
+	l, err := c.Limits(ctx, job.TenantId)
+	if err != nil {
+		return "", fmt.Errorf("failed to load quota: %w", err)
+	}
+	u, err := c.Usage(ctx, job.TenantId, now)
+	if err != nil {
+		return "", fmt.Errorf("failed to count jobs: %w", err)
+	}
+	return l.Exceeded(u, c.JobResources(job)), nil
+}
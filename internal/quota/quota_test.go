@@ -0,0 +1,183 @@
+package quota
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	jennahv1 "github.com/alphauslabs/jennah/gen/proto"
+	"github.com/alphauslabs/jennah/internal/database"
+)
+
+func newTestChecker(t *testing.T, defaults Limits, running ...string) (*Checker, *database.MemoryStore) {
+	t.Helper()
+	ctx := context.Background()
+	store := database.NewMemoryStore()
+	if err := store.InsertTenant(ctx, "tenant-1", "a@example.com", "google", "u1"); err != nil {
+		t.Fatalf("InsertTenant: %v", err)
+	}
+	for _, profile := range running {
+		p := profile
+		job := &database.Job{TenantId: "tenant-1", JobId: "running-" + p, Status: database.JobStatusRunning, ImageUri: "img", ResourceProfile: &p}
+		if err := store.InsertJobFull(ctx, job); err != nil {
+			t.Fatalf("InsertJobFull: %v", err)
+		}
+	}
+	return NewChecker(store, defaults, nil), store
+}
+
+func TestAdmit(t *testing.T) {
+	small := &jennahv1.SubmitJobRequest{ImageUri: "img", ResourceProfile: "small"} // 2000 millis, 2048 MiB
+
+	tests := []struct {
+		name     string
+		limits   Limits
+		running  []string
+		req      *jennahv1.SubmitJobRequest
+		want     Decision
+		contains string
+	}{
+		{name: "unlimited", limits: Limits{AllowSpotVms: true}, running: []string{"large"}, req: small, want: Admit},
+		{
+			name:     "machine type not allowed",
+			limits:   Limits{AllowSpotVms: true, AllowedMachineTypes: []string{"e2-standard-4"}},
+			req:      &jennahv1.SubmitJobRequest{ImageUri: "img", MachineType: "n2-highmem-64"},
+			want:     Reject,
+			contains: `machine type "n2-highmem-64" is not allowed`,
+		},
+		{
+			name:     "spot not allowed",
+			limits:   Limits{},
+			req:      &jennahv1.SubmitJobRequest{ImageUri: "img", UseSpotVms: true},
+			want:     Reject,
+			contains: "spot VMs",
+		},
+		{
+			name:     "job larger than the cpu limit",
+			limits:   Limits{AllowSpotVms: true, MaxCpuMillis: 1000, QueueWhenBusy: true},
+			req:      small,
+			want:     Reject,
+			contains: "job needs 2000 vCPU millis",
+		},
+		{
+			name:     "concurrency limit",
+			limits:   Limits{AllowSpotVms: true, MaxConcurrentJobs: 1},
+			running:  []string{"small"},
+			req:      small,
+			want:     Reject,
+			contains: "1 of 1 concurrent jobs",
+		},
+		{
+			name:     "concurrency limit with queueing",
+			limits:   Limits{AllowSpotVms: true, MaxConcurrentJobs: 1, QueueWhenBusy: true},
+			running:  []string{"small"},
+			req:      small,
+			want:     Queue,
+			contains: "1 of 1 concurrent jobs",
+		},
+		{
+			name:     "memory in flight",
+			limits:   Limits{AllowSpotVms: true, MaxMemoryMib: 4096},
+			running:  []string{"medium"},
+			req:      small,
+			want:     Reject,
+			contains: "4096 of 4096 MiB",
+		},
+		{
+			name:     "daily limit",
+			limits:   Limits{AllowSpotVms: true, MaxJobsPerDay: 2, QueueWhenBusy: true},
+			running:  []string{"small", "medium"},
+			req:      small,
+			want:     Reject,
+			contains: "daily limit of 2 jobs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestChecker(t, tt.limits, tt.running...)
+			got, msg, err := c.Admit(context.Background(), "tenant-1", tt.req, time.Now())
+			if err != nil {
+				t.Fatalf("Admit: %v", err)
+			}
+			if got != tt.want || !strings.Contains(msg, tt.contains) {
+				t.Fatalf("Admit = %v, %q; want %v containing %q", got, msg, tt.want, tt.contains)
+			}
+		})
+	}
+}
+
+func TestAdmitQueuesBehindQueuedJobs(t *testing.T) {
+	ctx := context.Background()
+	c, store := newTestChecker(t, Limits{AllowSpotVms: true, MaxConcurrentJobs: 5, QueueWhenBusy: true})
+	if err := store.InsertJobFull(ctx, &database.Job{TenantId: "tenant-1", JobId: "queued", Status: database.JobStatusQueued, ImageUri: "img"}); err != nil {
+		t.Fatalf("InsertJobFull: %v", err)
+	}
+
+	got, msg, err := c.Admit(ctx, "tenant-1", &jennahv1.SubmitJobRequest{ImageUri: "img"}, time.Now())
+	if err != nil || got != Queue || !strings.Contains(msg, "already queued") {
+		t.Fatalf("Admit = %v, %q, %v; want Queue behind the queued job", got, msg, err)
+	}
+
+	job, _ := store.GetJob(ctx, "tenant-1", "queued")
+	if reason, err := c.CanStart(ctx, job, time.Now()); err != nil || reason != "" {
+		t.Fatalf("CanStart = %q, %v; want the queued job to fit", reason, err)
+	}
+}
+
+func TestAdmitJobSkipsTheDailyLimit(t *testing.T) {
+	ctx := context.Background()
+	c, store := newTestChecker(t, Limits{MaxJobsPerDay: 1}, "small")
+	spot := true
+	node := &database.Job{TenantId: "tenant-1", JobId: "node", Status: database.JobStatusWaiting, ImageUri: "img"}
+	if err := store.InsertJobFull(ctx, node); err != nil {
+		t.Fatalf("InsertJobFull: %v", err)
+	}
+
+	// The node was counted against the daily limit when it was stored.
+	if got, msg, err := c.AdmitJob(ctx, node, time.Now()); err != nil || got != Admit {
+		t.Fatalf("AdmitJob = %v, %q, %v; want Admit", got, msg, err)
+	}
+	node.UseSpotVms = &spot
+	if got, msg, err := c.AdmitJob(ctx, node, time.Now()); err != nil || got != Reject || !strings.Contains(msg, "spot VMs") {
+		t.Fatalf("AdmitJob with spot VMs = %v, %q, %v; want Reject", got, msg, err)
+	}
+	if msg, err := c.CheckDailyLimit(ctx, "tenant-1", 1, time.Now()); err != nil || !strings.Contains(msg, "2 of 1 daily jobs") {
+		t.Fatalf("CheckDailyLimit = %q, %v; want the limit reached", msg, err)
+	}
+}
+
+func TestTenantOverrides(t *testing.T) {
+	ctx := context.Background()
+	c, store := newTestChecker(t, Limits{AllowSpotVms: true, MaxConcurrentJobs: 1}, "small")
+
+	limit, spot := int64(0), false
+	if err := store.UpsertTenantQuota(ctx, &database.TenantQuota{TenantId: "tenant-1", MaxConcurrentJobs: &limit, AllowSpotVms: &spot}); err != nil {
+		t.Fatalf("UpsertTenantQuota: %v", err)
+	}
+	l, err := c.Limits(ctx, "tenant-1")
+	if err != nil || l.MaxConcurrentJobs != 0 || l.AllowSpotVms {
+		t.Fatalf("Limits = %+v, %v", l, err)
+	}
+	if got, msg, _ := c.Admit(ctx, "tenant-1", &jennahv1.SubmitJobRequest{ImageUri: "img"}, time.Now()); got != Admit {
+		t.Fatalf("Admit with the concurrency limit lifted = %v, %q", got, msg)
+	}
+}
+
+func TestLimitsFromEnv(t *testing.T) {
+	t.Setenv("QUOTA_MAX_CONCURRENT_JOBS", "10")
+	t.Setenv("QUOTA_ALLOWED_MACHINE_TYPES", "e2-standard-4, e2-standard-8")
+	t.Setenv("QUOTA_QUEUE_WHEN_BUSY", "true")
+	l, err := LimitsFromEnv()
+	if err != nil {
+		t.Fatalf("LimitsFromEnv: %v", err)
+	}
+	if l.MaxConcurrentJobs != 10 || len(l.AllowedMachineTypes) != 2 || !l.AllowSpotVms || !l.QueueWhenBusy {
+		t.Fatalf("LimitsFromEnv = %+v", l)
+	}
+
+	t.Setenv("QUOTA_MAX_CPU_MILLIS", "-1")
+	if _, err := LimitsFromEnv(); err == nil {
+		t.Fatal("LimitsFromEnv accepted a negative limit")
+	}
+}
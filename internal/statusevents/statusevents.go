@@ -0,0 +1,146 @@
+// Package statusevents receives provider job state-change events from
+// Pub/Sub, so workers learn about status changes without polling every job.
+//
+// Two kinds of message are understood:
+//   - Cloud Batch job notifications (JOB_STATE_CHANGED), published by jobs
+//     submitted with a notification topic.
+//   - Cloud Run job log entries, routed to the same topic by a log sink.
+//
+// An event only says which job changed. Workers read the job's status from
+// the provider when they receive one, so late or duplicate deliveries are
+// harmless.
+package statusevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Event sources.
+const (
+	SourceCloudBatch = "CLOUD_BATCH"
+	SourceCloudRun   = "CLOUD_RUN_JOB"
+)
+
+// subscriptionExpiration removes the subscriptions of workers that are gone.
+const subscriptionExpiration = 24 * time.Hour
+
+// Event reports that a provider job changed state.
+type Event struct {
+	Source string
+
+	// CloudResourcePath is the provider job, e.g.
+	// projects/<project>/locations/<region>/jobs/<job>.
+	CloudResourcePath string
+
+	// State is the provider state the message reports, if it carries one.
+	State string
+}
+
+// SameJob reports whether two cloud resource paths name the same provider
+// job. Only the job IDs are compared, since providers may report the project
+// by number where Jennah stored its ID.
+func SameJob(a, b string) bool {
+	return a != "" && b != "" && jobID(a) == jobID(b)
+}
+
+func jobID(cloudResourcePath string) string {
+	return cloudResourcePath[strings.LastIndex(cloudResourcePath, "/")+1:]
+}
+
+// logEntry is the part of a Cloud Logging entry needed to find the Cloud
+// Run job it is about.
+type logEntry struct {
+	Resource struct {
+		Type   string            `json:"type"`
+		Labels map[string]string `json:"labels"`
+	} `json:"resource"`
+}
+
+// Parse decodes a Pub/Sub message into an event. It returns false for
+// messages that are not job state changes.
+func Parse(attributes map[string]string, data []byte) (Event, bool) {
+	if attributes["Type"] == "JOB_STATE_CHANGED" {
+		if attributes["JobName"] == "" {
+			return Event{}, false
+		}
+		return Event{
+			Source:            SourceCloudBatch,
+			CloudResourcePath: attributes["JobName"],
+			State:             attributes["NewJobState"],
+		}, true
+	}
+
+	var entry logEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Resource.Type != "cloud_run_job" {
+		return Event{}, false
+	}
+	labels := entry.Resource.Labels
+	if labels["project_id"] == "" || labels["location"] == "" || labels["job_name"] == "" {
+		return Event{}, false
+	}
+	return Event{
+		Source:            SourceCloudRun,
+		CloudResourcePath: fmt.Sprintf("projects/%s/locations/%s/jobs/%s", labels["project_id"], labels["location"], labels["job_name"]),
+	}, true
+}
+
+// Subscriber receives events from one worker's subscription.
+type Subscriber struct {
+	sub *pubsub.Subscription
+}
+
+// NewSubscriber returns a subscriber on subscriptionID, creating the topic
+// and the subscription if they do not exist yet. Each worker needs its own
+// subscription: Pub/Sub delivers a message to one receiver per subscription,
+// and only the worker tracking a job acts on its events.
+func NewSubscriber(ctx context.Context, client *pubsub.Client, topicID, subscriptionID string) (*Subscriber, error) {
+	topic := client.Topic(topicID)
+	exists, err := topic.Exists(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("check topic %s: %w", topicID, err)
+	}
+	if !exists {
+		if _, err := client.CreateTopic(ctx, topicID); err != nil && status.Code(err) != codes.AlreadyExists {
+			return nil, fmt.Errorf("create topic %s: %w", topicID, err)
+		}
+		log.Printf("Created Pub/Sub topic %s", topicID)
+	}
+
+	sub := client.Subscription(subscriptionID)
+	exists, err = sub.Exists(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("check subscription %s: %w", subscriptionID, err)
+	}
+	if !exists {
+		_, err := client.CreateSubscription(ctx, subscriptionID, pubsub.SubscriptionConfig{
+			Topic:            topic,
+			ExpirationPolicy: subscriptionExpiration,
+		})
+		if err != nil && status.Code(err) != codes.AlreadyExists {
+			return nil, fmt.Errorf("create subscription %s: %w", subscriptionID, err)
+		}
+		log.Printf("Created status event subscription %s → topic %s", subscriptionID, topicID)
+	}
+	return &Subscriber{sub: sub}, nil
+}
+
+// Receive calls handle for every event until ctx is done. Messages are
+// acknowledged whether or not they were events: workers still reconcile
+// periodically, so a lost event only delays a status update.
+func (s *Subscriber) Receive(ctx context.Context, handle func(context.Context, Event)) error {
+	return s.sub.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
+		defer msg.Ack()
+		if event, ok := Parse(msg.Attributes, msg.Data); ok {
+			handle(ctx, event)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package statusevents
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]string
+		data       string
+		want       Event
+		ok         bool
+	}{
+		{
+			name: "cloud batch notification",
+			attributes: map[string]string{
+				"Type":        "JOB_STATE_CHANGED",
+				"JobName":     "projects/p/locations/us-central1/jobs/jennah-1",
+				"NewJobState": "RUNNING",
+			},
+			want: Event{Source: SourceCloudBatch, CloudResourcePath: "projects/p/locations/us-central1/jobs/jennah-1", State: "RUNNING"},
+			ok:   true,
+		},
+		{
+			name: "cloud run log entry",
+			data: `{"resource":{"type":"cloud_run_job","labels":{"project_id":"p","location":"asia-northeast1","job_name":"jennah-2"}},"textPayload":"Execution jennah-2-x has completed successfully."}`,
+			want: Event{Source: SourceCloudRun, CloudResourcePath: "projects/p/locations/asia-northeast1/jobs/jennah-2"},
+			ok:   true,
+		},
+		{
+			name:       "cloud batch task notification",
+			attributes: map[string]string{"Type": "TASK_STATE_CHANGED", "JobName": "projects/p/locations/l/jobs/j"},
+		},
+		{
+			name: "other log entry",
+			data: `{"resource":{"type":"cloud_run_revision","labels":{"project_id":"p","location":"l","service_name":"s"}}}`,
+		},
+		{name: "not json", data: "hello"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Parse(tt.attributes, []byte(tt.data))
+			if ok != tt.ok || got != tt.want {
+				t.Fatalf("Parse = %+v, %v; want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSameJob(t *testing.T) {
+	if !SameJob("projects/my-project/locations/l/jobs/jennah-1", "projects/123456/locations/l/jobs/jennah-1") {
+		t.Error("paths of the same job with the project by ID and by number should match")
+	}
+	if SameJob("projects/p/locations/l/jobs/jennah-1", "projects/p/locations/l/jobs/jennah-10") {
+		t.Error("different jobs should not match")
+	}
+	if SameJob("", "") {
+		t.Error("empty paths should not match")
+	}
+}
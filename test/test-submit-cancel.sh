@@ -1,14 +1,17 @@
 #!/bin/bash
 
-# Use consistent OAuth headers so gateway assigns same tenant
+# The gateway resolves the tenant from the verified bearer token; the
+# X-OAuth-* headers only matter for a gateway run with --trust-oauth-headers.
 OAUTH_EMAIL="test.user@example.com"
 OAUTH_USER_ID="test-user-$(date +%s)"
-OAUTH_PROVIDER="test"
+OAUTH_PROVIDER="github"
+JENNAH_TOKEN="${JENNAH_TOKEN:?set JENNAH_TOKEN to a GitHub access token (saved by jennah login)}"
 
 # 1. SUBMIT JOB
 echo "=== Submitting Job ==="
 JOB_RESPONSE=$(curl -s -X POST https://jennah-gateway-382915581671.asia-northeast1.run.app/jennah.v1.DeploymentService/SubmitJob \
   -H "Content-Type: application/json" \
+  -H "Authorization: Bearer $JENNAH_TOKEN" \
   -H "X-OAuth-Email: $OAUTH_EMAIL" \
   -H "X-OAuth-UserId: $OAUTH_USER_ID" \
   -H "X-OAuth-Provider: $OAUTH_PROVIDER" \
@@ -39,6 +42,7 @@ sleep 5
 echo -e "\n=== Cancelling Job ==="
 curl -s -X POST https://jennah-gateway-382915581671.asia-northeast1.run.app/jennah.v1.DeploymentService/CancelJob \
   -H "Content-Type: application/json" \
+  -H "Authorization: Bearer $JENNAH_TOKEN" \
   -H "X-OAuth-Email: $OAUTH_EMAIL" \
   -H "X-OAuth-UserId: $OAUTH_USER_ID" \
   -H "X-OAuth-Provider: $OAUTH_PROVIDER" \
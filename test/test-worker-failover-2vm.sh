@@ -5,6 +5,7 @@ GW_URL="${GW_URL:-}"
 OAUTH_EMAIL="${OAUTH_EMAIL:-failover-test@alphauslabs.com}"
 OAUTH_USER_ID="${OAUTH_USER_ID:-failover-test-user}"
 OAUTH_PROVIDER="${OAUTH_PROVIDER:-google}"
+JENNAH_TOKEN="${JENNAH_TOKEN:?set JENNAH_TOKEN to a bearer token for OAUTH_PROVIDER}"
 
 VM_PRIMARY="${VM_PRIMARY:-}"
 VM_SECONDARY="${VM_SECONDARY:-}"
@@ -20,6 +21,7 @@ echo "Submitting long-running job through gateway: ${GW_URL}"
 RESP="$(curl -sS -X POST "${GW_URL}/jennah.v1.DeploymentService/SubmitJob" \
 	-H "Content-Type: application/json" \
 	-H "Connect-Protocol-Version: 1" \
+	-H "Authorization: Bearer ${JENNAH_TOKEN}" \
 	-H "X-OAuth-Email: ${OAUTH_EMAIL}" \
 	-H "X-OAuth-UserId: ${OAUTH_USER_ID}" \
 	-H "X-OAuth-Provider: ${OAUTH_PROVIDER}" \
@@ -59,6 +61,7 @@ echo "Checking job status via gateway list..."
 curl -sS -X POST "${GW_URL}/jennah.v1.DeploymentService/ListJobs" \
 	-H "Content-Type: application/json" \
 	-H "Connect-Protocol-Version: 1" \
+	-H "Authorization: Bearer ${JENNAH_TOKEN}" \
 	-H "X-OAuth-Email: ${OAUTH_EMAIL}" \
 	-H "X-OAuth-UserId: ${OAUTH_USER_ID}" \
 	-H "X-OAuth-Provider: ${OAUTH_PROVIDER}" \